@@ -14,15 +14,32 @@
 package main
 
 import (
+	"cmp"
+	"flag"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 	"log"
+	"math"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"representacao-figuras/internal/core"
 	"representacao-figuras/internal/renderer"
+	"representacao-figuras/internal/tui"
 	"representacao-figuras/internal/viewer"
+	"representacao-figuras/pkg/geometry"
+	"representacao-figuras/pkg/types"
 )
 
+// version é a versão do programa, gravada nos metadados das imagens.
+// O Makefile a preenche na compilação (-ldflags "-X main.version=...").
+var version = "dev"
+
 // main é o ponto de entrada da aplicação.
 //
 // Implementa uma interface de linha de comando que oferece diferentes
@@ -48,23 +65,220 @@ func main() {
 	switch command {
 	// Comando para geração de imagens PNG
 	case "generate", "gen", "png":
-		if len(os.Args) < 3 {
+		opts, files := parseOptions("generate", os.Args[2:])
+		if len(files) < 1 {
 			fmt.Println("Erro: especifique o arquivo YAML")
-			fmt.Println("Uso: figuras3d generate <arquivo.yaml>")
+			fmt.Println("Uso: figuras3d generate [opções] <arquivo.yaml>")
 			os.Exit(1)
 		}
 		// Executa geração de PNG estático
-		generatePNG(os.Args[2])
+		generatePNG(files[0], opts)
+
+	// Folha de contatos: a figura vista de vários ângulos numa só imagem
+	case "sheet", "contact-sheet":
+		opts, files := parseOptions("sheet", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d sheet [opções] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() {
+			log.Fatalf("Erro nas opções: sheet não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras nem região")
+		}
+		if opts.angles < 1 || opts.angles > renderer.MaxContactAngles {
+			log.Fatalf("Erro nas opções: número de ângulos inválido: %d (use de 1 a %d)", opts.angles, renderer.MaxContactAngles)
+		}
+		opts.sheet = true
+		generatePNG(files[0], opts)
+
+	// Montagem: várias figuras de um diretório numa só imagem
+	case "montage", "montagem":
+		opts, files := parseOptions("montage", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o diretório com os arquivos YAML")
+			fmt.Println("Uso: figuras3d montage [opções] <diretório>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() || opts.saveCamera != "" || opts.autoCrop || opts.construction {
+			log.Fatalf("Erro nas opções: montage não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --save-camera, --autocrop, --construction nem região")
+		}
+		generateMontage(files[0], opts)
+
+	// Giro: a câmera dá uma volta em torno da figura, num GIF animado
+	case "gif", "giro", "turntable":
+		opts, files := parseOptions("gif", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d gif [opções] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.autoCrop || opts.terminal != "" || opts.construction {
+			log.Fatalf("Erro nas opções: gif não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --autocrop, --terminal nem --construction")
+		}
+		opts.frames = cmp.Or(opts.frames, renderer.DefaultTurntableFrames)
+		if opts.frames < renderer.MinTurntableFrames || opts.frames > renderer.MaxTurntableFrames {
+			log.Fatalf("Erro nas opções: número de quadros inválido: %d (use de %d a %d)", opts.frames, renderer.MinTurntableFrames, renderer.MaxTurntableFrames)
+		}
+		if _, err := renderer.ParseTurntableAxis(opts.axis); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		opts.turntable = true
+		generatePNG(files[0], opts)
+
+	// Voo da câmera pelos quadros-chave da seção "animacao" do YAML
+	case "animate", "animar":
+		opts, files := parseOptions("animate", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d animate [opções] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.autoCrop || opts.terminal != "" || opts.construction || opts.frameDelay != 0 {
+			log.Fatalf("Erro nas opções: animate não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --terminal, --construction nem --frame-delay (use quadros_por_segundo)")
+		}
+		if opts.spin != "" {
+			axis, err := renderer.ParseTurntableAxis(opts.spin)
+			if err != nil {
+				log.Fatalf("Erro nas opções: %v", err)
+			}
+			if axis != renderer.AxisZ {
+				log.Fatalf("Erro nas opções: --spin só gira em torno de z (em x e y a câmera passaria sobre os polos); use o comando gif")
+			}
+			opts.frames = cmp.Or(opts.frames, renderer.DefaultSpinFrames)
+		}
+		opts.keyframes = true
+		generatePNG(files[0], opts)
+
+	// Metamorfose: uma figura se transforma em outra e volta
+	case "morph", "metamorfose":
+		opts, files := parseOptions("morph", os.Args[2:])
+		if len(files) < 2 {
+			fmt.Println("Erro: especifique os arquivos YAML de origem e de destino")
+			fmt.Println("Uso: figuras3d morph [opções] <origem.yaml> <destino.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.autoCrop || opts.terminal != "" || opts.construction {
+			log.Fatalf("Erro nas opções: morph não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --terminal nem --construction")
+		}
+		opts.frames = cmp.Or(opts.frames, renderer.DefaultMorphFrames)
+		if opts.frames < renderer.MinMorphFrames || opts.frames > renderer.MaxMorphFrames {
+			log.Fatalf("Erro nas opções: número de quadros inválido: %d (use de %d a %d)", opts.frames, renderer.MinMorphFrames, renderer.MaxMorphFrames)
+		}
+		opts.morph = files[1]
+		generatePNG(files[0], opts)
+
+	// Desenho em caracteres, para o terminal ou um arquivo de texto
+	case "ascii", "texto":
+		opts, files := parseOptions("ascii", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d ascii [opções] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() || opts.autoCrop || opts.retro != "" || opts.crt != "" || opts.palette != "" || opts.terminal != "" || opts.construction {
+			log.Fatalf("Erro nas opções: ascii não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --retro, --crt, --palette, --terminal, --construction nem região")
+		}
+		generateASCII(files[0], opts)
+
+	// Figura num formato vetorial, para outros programas
+	case "export", "exportar":
+		opts, files := parseOptions("export", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d export [--format dxf|eps|pov|threejs|csv|gnuplot|basic] [--output arquivo] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() || opts.autoCrop || opts.retro != "" || opts.crt != "" || opts.palette != "" || opts.terminal != "" || opts.construction {
+			log.Fatalf("Erro nas opções: export não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --retro, --crt, --palette, --terminal, --construction nem região")
+		}
+		exportFigure(files[0], opts)
+
+	// Página HTML com a figura, para girar no navegador
+	case "html", "pagina":
+		opts, files := parseOptions("html", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d html [-o pagina.html] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() || opts.autoCrop || opts.retro != "" || opts.crt != "" || opts.palette != "" || opts.terminal != "" || opts.construction {
+			log.Fatalf("Erro nas opções: html não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --retro, --crt, --palette, --terminal, --construction nem região")
+		}
+		generateHTML(files[0], opts)
+
+	// Sólidos primitivos gravados como figuras YAML
+	case "gen-primitive", "primitiva":
+		generatePrimitive(os.Args[2:])
+
+	// Gráfico em arame de uma função z = f(x, y)
+	case "gen-surface", "superficie":
+		generateSurface(os.Args[2:])
+
+	// Curva paramétrica (x(t), y(t), z(t)) em arame
+	case "gen-curve", "curva":
+		generateCurve(os.Args[2:])
+
+	// Fractal de um L-system desenhado pela tartaruga, em arame
+	case "gen-lsystem", "lsystem":
+		generateLSystem(os.Args[2:])
+
+	// Terreno em arame a partir de um mapa de alturas
+	case "gen-terrain", "terreno":
+		generateTerrain(os.Args[2:])
+
+	// Figura aleatória, para medir o desempenho e testar o renderizador
+	case "gen-random", "aleatoria":
+		generateRandom(os.Args[2:])
+
+	// Figura sem os pontos repetidos
+	case "weld", "soldar":
+		weldFigure(os.Args[2:])
+
+	// Figura densa simplificada, com menos pontos e arestas
+	case "simplify", "simplificar":
+		simplifyFigure(os.Args[2:])
+
+	// Figura dos DATA de uma listagem BASIC, como as do artigo
+	case "import-basic", "importar-basic":
+		importBASIC(os.Args[2:])
+
+	// Figura de um formato para outro
+	case "convert", "converter":
+		convertFigure(os.Args[2:])
+
+	// Contagens e topologia da figura, para achar índices errados
+	case "info", "informacoes":
+		infoFigure(os.Args[2:])
+
+	// Problemas dos arquivos de figura, para a integração contínua
+	case "lint", "verificar":
+		lintFigures(os.Args[2:])
+
+	// Visualizador no terminal, para servidores sem interface gráfica
+	case "tui":
+		opts, files := parseOptions("tui", os.Args[2:])
+		if len(files) < 1 {
+			fmt.Println("Erro: especifique o arquivo YAML")
+			fmt.Println("Uso: figuras3d tui [opções] <arquivo.yaml>")
+			os.Exit(1)
+		}
+		if opts.layoutModes() > 0 || opts.allCameras || opts.hasRegion() || opts.autoCrop || opts.retro != "" || opts.crt != "" || opts.palette != "" || opts.terminal != "" || opts.saveCamera != "" || opts.construction {
+			log.Fatalf("Erro nas opções: tui não aceita --multiview, --anaglyph, --side-by-side, --cross-eye, --all-cameras, --autocrop, --retro, --crt, --palette, --terminal, --save-camera, --construction nem região")
+		}
+		if err := tui.New(files[0], opts.apply).Run(); err != nil {
+			log.Fatalf("Erro: %v", err)
+		}
 
 	// Comando para visualização interativa
 	case "view", "viewer", "show":
-		if len(os.Args) < 3 {
+		opts, files := parseOptions("view", os.Args[2:])
+		if len(files) < 1 {
 			fmt.Println("Erro: especifique o arquivo YAML")
-			fmt.Println("Uso: figuras3d view <arquivo.yaml>")
+			fmt.Println("Uso: figuras3d view [opções] <arquivo.yaml>")
 			os.Exit(1)
 		}
 		// Abre interface gráfica interativa
-		openViewer(os.Args[2])
+		openViewer(files[0], opts)
 
 	// Comando de ajuda
 	case "help", "--help", "-h":
@@ -73,13 +287,16 @@ func main() {
 	// === MODO COMPATIBILIDADE ===
 	// Se não é um comando reconhecido, tenta interpretar como arquivo
 	default:
+		// Sem opções: todas com os valores padrão
+		defaults, _ := parseOptions(command, nil)
+
 		// Caso especial: --viewer como primeiro argumento
 		if command == "--viewer" && len(os.Args) >= 3 {
-			openViewer(os.Args[2])
+			openViewer(os.Args[2], defaults)
 		} else {
 			// Assume que o primeiro argumento é um arquivo YAML
 			// Comportamento padrão: gera PNG
-			generatePNG(command)
+			generatePNG(command, defaults)
 		}
 	}
 }
@@ -99,21 +316,538 @@ func showHelp() {
 	fmt.Println("Comandos:")
 	fmt.Println("  generate <arquivo.yaml>    Gera imagem PNG (salva em output/)")
 	fmt.Println("  view <arquivo.yaml>        Abre viewfinder interativo")
+	fmt.Println("  sheet <arquivo.yaml>       Folha de contatos: a figura vista de vários")
+	fmt.Println("                             ângulos, numa grade (output/<nome>_angulos.png)")
+	fmt.Println("  montage <diretório>        Todas as figuras do diretório numa grade")
+	fmt.Println("                             (output/<diretório>_montagem.png)")
+	fmt.Println("  gif <arquivo.yaml>         GIF animado da câmera dando a volta na figura")
+	fmt.Println("                             (output/<nome>_giro_z.gif)")
+	fmt.Println("  animate <arquivo.yaml>     GIF do voo da câmera pelos quadros-chave da")
+	fmt.Println("                             seção animacao (output/<nome>_animacao.gif)")
+	fmt.Println("  morph <origem> <destino>   GIF de uma figura se transformando em outra")
+	fmt.Println("                             (output/<origem>_metamorfose.gif)")
+	fmt.Println("  ascii <arquivo.yaml>       Desenha a figura com caracteres no terminal")
+	fmt.Println("  export <arquivo.yaml>      Exporta a figura em DXF, com linhas 3D, para")
+	fmt.Println("                             programas de CAD, em EPS, projetada, para")
+	fmt.Println("                             editoração, ou como cena do POV-Ray ou do")
+	fmt.Println("                             Three.js (--format ou a extensão de --output);")
+	fmt.Println("                             em CSV, as coordenadas de cada etapa da")
+	fmt.Println("                             projeção de cada ponto; para o gnuplot, os")
+	fmt.Println("                             dados (.dat) e o script (.plt); ou como um")
+	fmt.Println("                             programa BASIC do HP-85 (.bas)")
+	fmt.Println("  html <arquivo.yaml>        Página HTML autossuficiente com a figura, que")
+	fmt.Println("                             gira com o mouse em qualquer navegador")
+	fmt.Println("  tui <arquivo.yaml>         Visualizador no terminal, em braille; as setas")
+	fmt.Println("                             giram a câmera (sem interface gráfica)")
+	fmt.Println("  gen-primitive <sólido>     Gera a figura YAML de um sólido: caixa, esfera,")
+	fmt.Println("                             cilindro, cone, toro (box, sphere, ...) ou um")
+	fmt.Println("                             sólido de Platão ou de Arquimedes (dodecaedro,")
+	fmt.Println("                             icosaedro_truncado, ...)")
+	fmt.Println("  gen-surface \"<expressão>\"  Gera a figura YAML do gráfico de z = f(x, y),")
+	fmt.Println("                             em arame, como \"sin(x) * cos(y)\"")
+	fmt.Println("  gen-curve [curva]          Gera a figura YAML de uma curva paramétrica:")
+	fmt.Println("                             lissajous, helice, trevo, no_toroidal ou as")
+	fmt.Println("                             coordenadas em t dadas por --x, --y e --z")
+	fmt.Println("  gen-lsystem [l-system]     Gera a figura YAML de um fractal desenhado pela")
+	fmt.Println("                             tartaruga: koch, dragao, sierpinski, planta,")
+	fmt.Println("                             arvore, hilbert ou um dado por --axiom e --rules")
+	fmt.Println("  gen-terrain <imagem>       Gera a figura YAML de um terreno em arame a")
+	fmt.Println("                             partir de um mapa de alturas em tons de cinza")
+	fmt.Println("  gen-random                 Gera a figura YAML de pontos, linhas e faces")
+	fmt.Println("                             aleatórios, para testes de desempenho")
+	fmt.Println("  weld <arquivo>             Grava a figura sem os pontos repetidos, a")
+	fmt.Println("                             menos de --epsilon (padrão 1e-6) uns dos outros")
+	fmt.Println("  simplify <arquivo>         Grava a figura com menos pontos e arestas,")
+	fmt.Println("                             mantendo a silhueta: até --edges n arestas ou")
+	fmt.Println("                             fundindo os pontos a menos de --tolerance t")
+	fmt.Println("  import-basic <listagem>    Gera a figura YAML a partir dos DATA de uma")
+	fmt.Println("                             listagem BASIC do HP-85, como as do artigo")
+	fmt.Println("  convert <entrada> <saída>  Converte a figura entre os formatos: lê YAML")
+	fmt.Println("                             ou BASIC e grava YAML ou qualquer formato do")
+	fmt.Println("                             export e do html (--from e --to ou as")
+	fmt.Println("                             extensões; \"-\" é a saída padrão)")
+	fmt.Println("  info <arquivo>             Mostra as contagens da figura, as partes")
+	fmt.Println("                             desconexas, os pontos isolados e as bordas")
+	fmt.Println("  lint <arquivo|diretório>   Aponta erros, defeitos (pontos e linhas")
+	fmt.Println("                             repetidos, faces sem área, pontos isolados)")
+	fmt.Println("                             e desvios de estilo; termina com código 1")
+	fmt.Println("                             se houver algum")
 	fmt.Println("  help                       Mostra esta ajuda")
 	fmt.Println("")
+	fmt.Println("  O arquivo pode ser um caminho local ou uma URL http(s)://")
+	fmt.Println("")
+
+	// Opções aceitas por generate e view
+	fmt.Println("Opções:")
+	fmt.Println("  --auto-camera              Enquadra a figura inteira automaticamente")
+	fmt.Println("  --view <nome>              Vista pré-definida: iso, dimetrica, cavaleira,")
+	fmt.Println("                             gabinete ou perspectiva")
+	fmt.Println("  --preset <nome|arquivo>    Aplica um preset de renderização: embutido")
+	fmt.Println("                             (blueprint: linhas brancas sobre azul, com")
+	fmt.Println("                             grade e carimbo; okabe-ito: cores seguras")
+	fmt.Println("                             para daltônicos; alto-contraste: amarelo")
+	fmt.Println("                             sobre preto), do usuário (<nome>.yaml")
+	fmt.Println("                             em $FIGURAS3D_PRESETS ou ~/.config/figuras3d/")
+	fmt.Println("                             presets) ou um arquivo YAML/JSON")
+	fmt.Println("  --style <nome>             O mesmo que --preset")
+	fmt.Println("  --weld <distância>         Funde os pontos repetidos, a menos da distância")
+	fmt.Println("                             uns dos outros (0 = só os idênticos)")
+	fmt.Println("  --subdivide <n>            Divide as arestas retas antes da projeção: a")
+	fmt.Println("                             mais longa em n trechos, as demais em trechos")
+	fmt.Println("                             do mesmo tamanho (profundidade nas linhas)")
+	fmt.Println("  --grid                     Desenha a grade de referência sob a figura")
+	fmt.Println("  --axes                     Desenha os eixos X, Y e Z do mundo")
+	fmt.Println("  --camera <nome>            Usa uma câmera nomeada da seção \"cameras\"")
+	fmt.Println("  --all-cameras              Um PNG por câmera nomeada (apenas generate)")
+	fmt.Println("  --camera-file <arquivo>    Usa a câmera de um arquivo YAML ou JSON")
+	fmt.Println("  --save-camera <arquivo>    Grava a câmera efetiva em YAML ou JSON (no")
+	fmt.Println("                             view, ao clicar em \"Salvar câmera\")")
+	fmt.Println("  --format <png|jpeg|webp>   Formato da imagem gerada (padrão: png); WebP")
+	fmt.Println("                             é sem perdas (apenas generate); no export,")
+	fmt.Println("                             dxf, eps, pov, threejs, csv, gnuplot ou basic")
+	fmt.Println("  --quality <1-100>          Qualidade do JPEG (padrão: 90)")
+	fmt.Println("  --angles <n>               Número de ângulos da folha de contatos")
+	fmt.Println("                             (padrão: 8; apenas sheet)")
+	fmt.Println("  --retro hp85               Como na tela do HP-85: 256×192, linhas de")
+	fmt.Println("                             Bresenham sem suavização (apenas generate)")
+	fmt.Println("  --retro-scale <n>          Amplia a imagem retrô n vezes, com pixels")
+	fmt.Println("                             nítidos (padrão: 1)")
+	fmt.Println("  --crt <fósforo>            Efeito de monitor de tubo: verde, ambar ou")
+	fmt.Println("                             branco (apenas generate)")
+	fmt.Println("  --palette <cga|zx|msx>     Reduz as cores às de um computador da época")
+	fmt.Println("                             (apenas generate)")
+	fmt.Println("  --construction             GIF com as linhas surgindo uma a uma, na")
+	fmt.Println("                             ordem do YAML (apenas generate)")
+	fmt.Println("  --frames <n>               Número de quadros do giro (padrão: 36), do")
+	fmt.Println("                             voo (padrão: pelo YAML) ou da metamorfose")
+	fmt.Println("                             (padrão: 30) (gif, animate e morph)")
+	fmt.Println("  --axis <x|y|z>             Eixo do giro da câmera (padrão: z; apenas gif)")
+	fmt.Println("  --spin z                   Gira a câmera sem seção animacao, em 100")
+	fmt.Println("                             quadros (apenas animate)")
+	fmt.Println("  --degrees <graus>          Ângulo do giro de --spin (padrão: 360)")
+	fmt.Println("  --ease <curva>             Suavização do voo: linear, in, out, in-out,")
+	fmt.Println("                             cubic ou hold")
+	fmt.Println("                             (apenas animate; padrão: a do YAML)")
+	fmt.Println("  --map <A=B,...>            Pares de pontos origem=destino, pelo nome")
+	fmt.Println("                             (apenas morph; padrão: pela ordem)")
+	fmt.Println("  --frame-delay <ms>         Intervalo entre os quadros (padrão: 300 na")
+	fmt.Println("                             construção, 50 no giro e na metamorfose;")
+	fmt.Println("                             não vale em animate)")
+	fmt.Println("  --sequence                 Grava os quadros numerados em vez do GIF")
+	fmt.Println("  --out <padrão>             Grava os quadros com este nome, como")
+	fmt.Println("                             quadros/frame_%04d.png (implica --sequence)")
+	fmt.Println("  --video <arquivo>          Grava um vídeo .mp4 ou .webm em vez do GIF")
+	fmt.Println("                             (precisa do ffmpeg instalado)")
+	fmt.Println("  --codec <h264|h265|vp9>    Codificador do vídeo (padrão: h264 em .mp4,")
+	fmt.Println("                             vp9 em .webm)")
+	fmt.Println("  --fps <n>                  Quadros por segundo do vídeo (padrão: os da")
+	fmt.Println("                             animação)")
+	fmt.Println("  --terminal <auto|sixel|kitty>")
+	fmt.Println("                             Mostra também a imagem no terminal; auto")
+	fmt.Println("                             detecta o protocolo (apenas generate)")
+	fmt.Println("  --cols <n>                 Largura do desenho em caracteres (padrão: 80;")
+	fmt.Println("                             apenas ascii)")
+	fmt.Println("  --output <arquivo>         Grava o desenho num arquivo de texto em vez")
+	fmt.Println("                             de mostrá-lo (apenas ascii, export e html;")
+	fmt.Println("                             -o é o mesmo)")
+	fmt.Println("  --segments <n>             Divisões em volta do eixo (padrão: 24; apenas")
+	fmt.Println("                             gen-primitive, como as opções abaixo)")
+	fmt.Println("  --rings <n>                Anéis da esfera ou do tubo do toro (padrão: 12)")
+	fmt.Println("  --radius <n>               Raio (padrão: 1; no toro, até o centro do tubo;")
+	fmt.Println("                             nos poliedros, até os vértices)")
+	fmt.Println("  --tube <n>                 Raio do tubo do toro (padrão: 0.4)")
+	fmt.Println("  --width/--depth/--height <n>")
+	fmt.Println("                             Medidas da caixa; altura do cilindro e do")
+	fmt.Println("                             cone (padrão: 2); em gen-terrain, largura")
+	fmt.Println("                             (padrão: 10), profundidade (padrão: a da")
+	fmt.Println("                             imagem) e altura do branco (padrão: 2)")
+	fmt.Println("  --invert                   Escuro é alto e claro é baixo (apenas")
+	fmt.Println("                             gen-terrain)")
+	fmt.Println("  --points/--lines/--faces <n>")
+	fmt.Println("                             Quantidades da figura aleatória (padrão: 1000")
+	fmt.Println("                             pontos, 2000 linhas, 0 faces; apenas gen-random)")
+	fmt.Println("  --clusters <n>             Aglomerados de pontos (padrão: 0; apenas")
+	fmt.Println("                             gen-random)")
+	fmt.Println("  --size <n>                 Aresta do cubo dos pontos (padrão: 10; apenas")
+	fmt.Println("                             gen-random)")
+	fmt.Println("  --seed <n>                 Semente do sorteio (padrão: 1; apenas gen-random)")
+	fmt.Println("  -o, --output <arquivo>     Grava a figura num arquivo (padrão: saída")
+	fmt.Println("                             padrão; comandos gen-*)")
+	fmt.Println("  --wireframe                Só as arestas, como linhas, sem faces")
+	fmt.Println("  --x/--y <início,fim>       Intervalos de x e de y (padrão: -3,3; aceitam")
+	fmt.Println("                             expressões, como -pi,pi; apenas gen-surface)")
+	fmt.Println("  --resolution <n|nxm>       Divisões da grade (padrão: 30 em gen-surface;")
+	fmt.Println("                             64 no lado mais longo em gen-terrain)")
+	fmt.Println("  --z-scale <n>              Fator aplicado a z (padrão: 1; apenas gen-surface)")
+	fmt.Println("  --x/--y/--z <expressão>    Coordenadas da curva em t (apenas gen-curve)")
+	fmt.Println("  --t <início,fim>           Intervalo de t (padrão: 0,2*pi; apenas gen-curve)")
+	fmt.Println("  --samples <n>              Pontos da curva (padrão: 600; apenas gen-curve)")
+	fmt.Println("  --closed                   Liga o fim da curva ao início (apenas gen-curve)")
+	fmt.Println("  --axiom <cadeia>           Cadeia inicial do L-system (apenas gen-lsystem)")
+	fmt.Println("  --rules <F=cadeia,...>     Regras do L-system (apenas gen-lsystem)")
+	fmt.Println("  --angle <graus>            Ângulo dos giros (padrão: 90; apenas gen-lsystem)")
+	fmt.Println("  --iterations <n>           Reescritas do L-system (padrão: 4; apenas")
+	fmt.Println("                             gen-lsystem)")
+	fmt.Println("  --step <n>                 Passo da tartaruga (padrão: 1; apenas gen-lsystem)")
+	fmt.Println("  --autocrop                 Recorta a imagem ao desenho (apenas generate)")
+	fmt.Println("  --autocrop-margin <n>      Margem do recorte em pixels (padrão: 10)")
+	fmt.Println("  --multiview                Folha com vistas frontal, lateral, superior")
+	fmt.Println("                             e perspectiva (apenas generate)")
+	fmt.Println("  --anaglyph                 Anáglifo vermelho/ciano (apenas generate)")
+	fmt.Println("  --side-by-side             Par estéreo lado a lado, largura dupla")
+	fmt.Println("  --cross-eye                Par estéreo para visão cruzada, largura dupla")
+	fmt.Println("  --eye-separation <n>       Distância entre os olhos nos modos estéreo")
+	fmt.Println("                             (padrão: 1/30 da convergência)")
+	fmt.Println("  --convergence <n>          Distância ao plano da tela nos modos estéreo")
+	fmt.Println("                             (padrão: distância ao alvo)")
+	fmt.Println("  --xmin/--xmax/--ymin/--ymax <n>")
+	fmt.Println("                             Amplia uma região da tela virtual (unidades")
+	fmt.Println("                             da câmera, centro = 0) (apenas generate)")
+	fmt.Println("")
 
 	// Exemplos práticos de uso
 	fmt.Println("Exemplos:")
 	fmt.Println("  figuras3d generate samples/cubo.yaml")
 	fmt.Println("  figuras3d view samples/casa.yaml")
+	fmt.Println("  figuras3d generate https://example.com/cubo.yaml")
 	fmt.Println("")
 
 	// Atalhos e conveniências
 	fmt.Println("Atalhos:")
 	fmt.Println("  figuras3d gen samples/cubo.yaml       # Mesmo que generate")
+	fmt.Println("  figuras3d gen --auto-camera fig.yaml  # Enquadra a figura inteira")
+	fmt.Println("  figuras3d gen --view iso fig.yaml     # Vista isométrica")
+	fmt.Println("  figuras3d gen --multiview fig.yaml    # Folha com quatro vistas")
+	fmt.Println("  figuras3d gen --anaglyph fig.yaml     # Anáglifo para óculos 3D")
+	fmt.Println("  figuras3d gen --cross-eye fig.yaml    # Par estéreo para visão cruzada")
+	fmt.Println("  figuras3d gen --style blueprint f.yaml # Cópia heliográfica")
+	fmt.Println("  figuras3d gen --preset equipe.yaml f.yaml # Preset em arquivo")
+	fmt.Println("  figuras3d gen --camera topo fig.yaml  # Câmera nomeada \"topo\"")
+	fmt.Println("  figuras3d gen --all-cameras fig.yaml  # Um PNG por câmera nomeada")
+	fmt.Println("  figuras3d gen --format webp fig.yaml  # WebP, menor para a web")
+	fmt.Println("  figuras3d gen --autocrop fig.yaml     # Sem as sobras de fundo")
+	fmt.Println("  figuras3d gen --subdivide 20 fig.yaml # Arestas longas desbotando aos poucos")
+	fmt.Println("  figuras3d gen --retro hp85 --retro-scale 3 fig.yaml # Tela do HP-85")
+	fmt.Println("  figuras3d gen --retro hp85 --retro-scale 3 --crt verde fig.yaml")
+	fmt.Println("  figuras3d gen --palette zx fig.yaml   # Só as cores do ZX Spectrum")
+	fmt.Println("  figuras3d gen --construction --retro hp85 fig.yaml # Desenho linha a linha")
+	fmt.Println("  figuras3d gif --frames 60 --axis z fig.yaml # Câmera dando a volta")
+	fmt.Println("  figuras3d gif --frames 120 --out giro/f_%04d.png fig.yaml # Para vídeo")
+	fmt.Println("  figuras3d animate --video voo.mp4 fig.yaml # Vídeo pelo ffmpeg")
+	fmt.Println("  figuras3d animate --spin z --degrees 360 --frames 120 --ease in-out fig.yaml")
+	fmt.Println("  figuras3d morph --map A=A,E=TOPO,... cubo.yaml piramide.yaml")
+	fmt.Println("  figuras3d ascii --cols 60 fig.yaml    # No terminal, até por SSH")
+	fmt.Println("  figuras3d html modelos/casa.yaml -o casa.html # Para girar no navegador")
+	fmt.Println("  figuras3d export --output casa.dxf modelos/casa.yaml # Para o CAD")
+	fmt.Println("  figuras3d export --format eps modelos/casa.yaml > casa.eps")
+	fmt.Println("  figuras3d export --output casa.pov modelos/casa.yaml # Para o POV-Ray")
+	fmt.Println("  figuras3d export --output casa.json modelos/casa.yaml # Para o Three.js")
+	fmt.Println("  figuras3d export --format csv modelos/cubo.yaml # Para conferir as contas")
+	fmt.Println("  figuras3d export --output casa.plt modelos/casa.yaml # casa.dat e casa.plt")
+	fmt.Println("  figuras3d export --output casa.bas modelos/casa.yaml # Para o HP-85")
+	fmt.Println("  figuras3d gen-primitive sphere --segments 24 --rings 12 -o esfera.yaml")
+	fmt.Println("  figuras3d gen-primitive dodecaedro --wireframe > dodecaedro.yaml")
+	fmt.Println("  figuras3d gen-surface --x -pi,pi --y -pi,pi \"sin(x) * cos(y)\" -o onda.yaml")
+	fmt.Println("  figuras3d gen-curve trevo --samples 300 -o trevo.yaml")
+	fmt.Println("  figuras3d gen-curve --x \"cos(3*t)\" --y \"sin(2*t)\" --closed > curva.yaml")
+	fmt.Println("  figuras3d gen-lsystem arvore --iterations 6 -o arvore.yaml")
+	fmt.Println("  figuras3d gen-lsystem --axiom F --rules \"F=F[+F]F[-F]F\" --angle 25.7 > mato.yaml")
+	fmt.Println("  figuras3d gen-terrain --resolution 80 --height 3 relevo.png -o relevo.yaml")
+	fmt.Println("  figuras3d gen-random --points 100000 --lines 200000 --clusters 50 -o grande.yaml")
+	fmt.Println("  figuras3d weld --epsilon 0.001 convertido.yaml -o soldado.yaml")
+	fmt.Println("  figuras3d simplify --edges 5000 malha.yaml -o leve.yaml")
+	fmt.Println("  figuras3d import-basic --from 1000 piramide.bas -o piramide.yaml")
+	fmt.Println("  figuras3d convert modelos/piramide.bas piramide.dxf")
+	fmt.Println("  figuras3d convert --to basic modelos/casa.yaml - # Listagem na tela")
+	fmt.Println("  figuras3d info convertido.yaml        # Partes, pontos isolados e bordas")
+	fmt.Println("  figuras3d lint modelos                # Verifica todas as figuras")
+	fmt.Println("  figuras3d gen --terminal auto fig.yaml # Imagem no kitty ou em Sixel")
+	fmt.Println("  figuras3d sheet --angles 6 fig.yaml   # Seis ângulos numa grade 3×2")
+	fmt.Println("  figuras3d montage modelos             # Galeria dos modelos")
+	fmt.Println("  figuras3d gen --camera-file cam.yaml fig.yaml # Câmera salva no view")
+	fmt.Println("  figuras3d gen --xmin 0 --ymin 0 f.yaml # Amplia o quadrante superior direito")
 	fmt.Println("  figuras3d samples/cubo.yaml           # Gera PNG (padrão)")
 }
 
+// options reúne as opções de linha de comando comuns a generate e view.
+//
+// As opções sobrepõem o que está definido no arquivo YAML, permitindo
+// experimentar variações sem editar a figura.
+type options struct {
+	autoCamera bool   // Força o enquadramento automático da câmera
+	view       string // Vista pré-definida (isometrica, dimetrica, ...)
+	preset     string // Preset de renderização (nome ou arquivo)
+	subdivide  int    // Trechos em que a aresta mais longa é dividida (0 = nenhum)
+	grid       bool   // Desenha a grade de referência (mostrar_grade)
+	axes       bool   // Desenha os eixos coordenados (mostrar_eixos)
+	multiView  bool   // Gera folha com vistas frontal/lateral/superior/perspectiva
+	anaglyph   bool   // Gera anáglifo vermelho/ciano
+	sideBySide bool   // Gera par estéreo lado a lado (visão paralela)
+	crossEye   bool   // Gera par estéreo para visão cruzada
+	sheet      bool   // Gera folha de contatos (comando sheet)
+	retro      string // Modo de fidelidade histórica (hp85)
+	retroScale int    // Ampliação da imagem retrô (vizinho mais próximo)
+	crt        string // Fósforo do efeito de monitor de tubo (verde, ambar, branco)
+	palette    string // Paleta retrô a que as cores são reduzidas (cga, zx, msx)
+	cols       int    // Largura do desenho em caracteres (comando ascii)
+	terminal   string // Protocolo para mostrar a imagem no terminal (auto, sixel, kitty)
+	output     string // Arquivo do desenho ou da exportação (vazio = saída padrão)
+	angles     int    // Número de ângulos da folha de contatos
+	camera     string // Câmera nomeada a usar (declarada em "cameras")
+	allCameras bool   // Gera um PNG para cada câmera nomeada
+	cameraFile string // Arquivo de câmera (YAML/JSON) que substitui a do YAML
+	saveCamera string // Arquivo onde gravar a câmera efetiva
+	format     string // Formato da imagem gerada (png, jpeg ou webp)
+	quality    int    // Qualidade do JPEG (1 a 100; 0 = padrão)
+	autoCrop   bool   // Recorta a imagem ao desenho
+	cropMargin int    // Margem do recorte em pixels
+
+	// Animações: construção da figura, linha a linha, giro da câmera
+	// (comando gif) com o eixo, ou voo da câmera pelos quadros-chave da
+	// seção "animacao" (comando animate); frames é o número de quadros
+	// do giro ou do voo (0 = padrão). sequence grava os quadros como
+	// imagens numeradas em vez do GIF, e out é o padrão do nome dessas
+	// imagens (vazio = output/<nome>_%03d); frameDelay é o intervalo
+	// entre os quadros em milissegundos (0 = padrão). video é o arquivo
+	// .mp4 ou .webm gravado pelo ffmpeg em vez do GIF, com o codificador
+	// codec ("" = padrão do formato) e fps quadros por segundo (0 = os
+	// da animação). morph é o arquivo da figura de destino da
+	// metamorfose (comando morph), e morphMap, os pares de pontos. spin
+	// é o eixo do giro da câmera do comando animate, sem seção
+	// "animacao", de degrees graus (0 = uma volta), e ease, a curva de
+	// suavização do voo ("" = a do YAML)
+	construction, turntable, keyframes, sequence bool
+	frames, frameDelay                           int
+	axis, out, video, codec, morph, morphMap     string
+	spin, ease                                   string
+	fps, degrees                                 float64
+
+	// Distância entre os olhos e ao plano de convergência nos modos
+	// estereoscópicos (sobrepõem separacao_olhos e convergencia)
+	eyeSeparation, convergence optionalFloat
+
+	// Região da tela virtual a ampliar (limites não informados
+	// ficam nas bordas da tela virtual)
+	xMin, xMax, yMin, yMax optionalFloat
+
+	// Distância em que os pontos repetidos se fundem (--weld)
+	weld optionalFloat
+}
+
+// optionalFloat é um número de linha de comando que lembra se foi informado.
+type optionalFloat struct {
+	value float64
+	set   bool
+}
+
+// String implementa flag.Value.
+func (f *optionalFloat) String() string {
+	if !f.set {
+		return ""
+	}
+	return strconv.FormatFloat(f.value, 'g', -1, 64)
+}
+
+// Set implementa flag.Value.
+func (f *optionalFloat) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	f.value, f.set = v, true
+	return nil
+}
+
+// hasRegion informa se alguma opção de região foi informada.
+func (o options) hasRegion() bool {
+	return o.xMin.set || o.xMax.set || o.yMin.set || o.yMax.set
+}
+
+// animated informa se a saída é uma animação (--construction, gif,
+// animate ou morph).
+func (o options) animated() bool {
+	return o.construction || o.turntable || o.keyframes || o.morph != ""
+}
+
+// layoutModes conta quantos modos de composição da imagem foram pedidos
+// (folha de vistas, anáglifo, pares estéreo e folha de contatos são
+// mutuamente exclusivos).
+func (o options) layoutModes() int {
+	n := 0
+	for _, on := range []bool{o.multiView, o.anaglyph, o.sideBySide, o.crossEye, o.sheet} {
+		if on {
+			n++
+		}
+	}
+	return n
+}
+
+// region monta a região a ampliar a partir da tela virtual completa.
+//
+// Parâmetros:
+//   full: tela virtual inteira da câmera (ver Renderer3D.VirtualScreen)
+//
+// Retorna:
+//   renderer.Region: tela virtual com os limites informados substituídos
+func (o options) region(full renderer.Region) renderer.Region {
+	if o.xMin.set {
+		full.XMin = o.xMin.value
+	}
+	if o.xMax.set {
+		full.XMax = o.xMax.value
+	}
+	if o.yMin.set {
+		full.YMin = o.yMin.value
+	}
+	if o.yMax.set {
+		full.YMax = o.yMax.value
+	}
+	return full
+}
+
+// parseOptions interpreta as opções de um subcomando.
+//
+// Diferente do pacote flag puro, aceita opções antes ou depois dos
+// arquivos (ex: "generate cubo.yaml --auto-camera"), que é como a
+// maioria das pessoas digita comandos.
+//
+// Parâmetros:
+//   name: nome do subcomando (usado nas mensagens de erro)
+//   args: argumentos após o subcomando
+//
+// Retorna:
+//   options: opções reconhecidas
+//   []string: argumentos posicionais (arquivos)
+func parseOptions(name string, args []string) (options, []string) {
+	var opts options
+
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.BoolVar(&opts.autoCamera, "auto-camera", false, "enquadra a figura inteira automaticamente")
+	fs.StringVar(&opts.view, "view", "", "vista pré-definida (iso, dimetrica, cavaleira, gabinete, perspectiva)")
+	fs.StringVar(&opts.preset, "preset", "", "preset de renderização: nome (embutido ou do usuário) ou arquivo YAML/JSON")
+	fs.StringVar(&opts.preset, "style", "", "o mesmo que --preset")
+	fs.Var(&opts.weld, "weld", "funde os pontos a menos desta distância uns dos outros (0 = só os idênticos)")
+	fs.IntVar(&opts.subdivide, "subdivide", 0, "divide as arestas retas antes da projeção: a mais longa em N trechos, as demais em trechos do mesmo tamanho")
+	fs.BoolVar(&opts.grid, "grid", false, "desenha a grade de referência sob a figura")
+	fs.BoolVar(&opts.axes, "axes", false, "desenha os eixos X, Y e Z do mundo")
+	fs.StringVar(&opts.camera, "camera", "", "usa uma das câmeras nomeadas da figura")
+	fs.BoolVar(&opts.allCameras, "all-cameras", false, "gera um PNG para cada câmera nomeada da figura")
+	fs.StringVar(&opts.cameraFile, "camera-file", "", "carrega a câmera de um arquivo YAML ou JSON")
+	fs.StringVar(&opts.saveCamera, "save-camera", "", "grava a câmera efetiva em arquivo YAML ou JSON")
+	fs.StringVar(&opts.format, "format", "", "formato da imagem: png (padrão), jpeg ou webp")
+	fs.IntVar(&opts.quality, "quality", 0, "qualidade do JPEG, de 1 a 100 (padrão: 90)")
+	fs.IntVar(&opts.angles, "angles", renderer.DefaultContactAngles, "número de ângulos da folha de contatos (comando sheet)")
+	fs.StringVar(&opts.retro, "retro", "", "modo de fidelidade histórica: hp85 (256×192, linhas de Bresenham)")
+	fs.IntVar(&opts.retroScale, "retro-scale", 1, "ampliação da imagem retrô, de 1 a 8 (vizinho mais próximo)")
+	fs.StringVar(&opts.crt, "crt", "", "efeito de monitor de tubo com fósforo verde, ambar ou branco")
+	fs.StringVar(&opts.palette, "palette", "", "reduz as cores à paleta de um computador da época: cga, zx ou msx")
+	fs.BoolVar(&opts.construction, "construction", false, "anima a construção da figura, uma linha por quadro (GIF)")
+	fs.BoolVar(&opts.sequence, "sequence", false, "grava os quadros da animação como imagens numeradas em vez do GIF")
+	fs.IntVar(&opts.frameDelay, "frame-delay", 0, "intervalo entre os quadros da animação em milissegundos (0 = padrão)")
+	fs.IntVar(&opts.frames, "frames", 0, "número de quadros do giro (comando gif, padrão 36) ou do voo (comando animate)")
+	fs.StringVar(&opts.out, "out", "", "grava os quadros da animação com este padrão de nome, como quadros/frame_%04d.png")
+	fs.StringVar(&opts.spin, "spin", "", "gira a câmera em torno do eixo, sem seção animacao (comando animate; apenas z)")
+	fs.Float64Var(&opts.degrees, "degrees", 0, "ângulo do giro de --spin em graus (padrão 360)")
+	fs.StringVar(&opts.ease, "ease", "", "suavização do voo: linear, in, out, in-out, cubic ou hold (comando animate)")
+	fs.StringVar(&opts.morphMap, "map", "", "pares de pontos origem=destino da metamorfose, como A=A,E=TOPO (comando morph)")
+	fs.StringVar(&opts.video, "video", "", "grava a animação num vídeo .mp4 ou .webm com o ffmpeg")
+	fs.StringVar(&opts.codec, "codec", "", "codificador do vídeo: h264, h265 ou vp9 (padrão: pelo formato)")
+	fs.Float64Var(&opts.fps, "fps", 0, "quadros por segundo do vídeo (0 = os da animação)")
+	fs.StringVar(&opts.axis, "axis", string(renderer.AxisZ), "eixo do giro da câmera: x, y ou z (comando gif)")
+	fs.StringVar(&opts.terminal, "terminal", "", "mostra a imagem no terminal: auto, sixel ou kitty")
+	fs.IntVar(&opts.cols, "cols", renderer.DefaultASCIIWidth, "largura do desenho em caracteres (comando ascii)")
+	fs.StringVar(&opts.output, "output", "", "arquivo de texto do desenho, da exportação ou da página (comandos ascii, export e html; padrão: saída padrão)")
+	fs.StringVar(&opts.output, "o", "", "o mesmo que --output")
+	fs.BoolVar(&opts.autoCrop, "autocrop", false, "recorta a imagem ao retângulo do desenho, mais a margem")
+	fs.IntVar(&opts.cropMargin, "autocrop-margin", renderer.DefaultCropMargin, "margem do recorte automático em pixels")
+	fs.Var(&opts.xMin, "xmin", "limite esquerdo da região ampliada (unidades da câmera)")
+	fs.Var(&opts.xMax, "xmax", "limite direito da região ampliada (unidades da câmera)")
+	fs.Var(&opts.yMin, "ymin", "limite inferior da região ampliada (unidades da câmera)")
+	fs.Var(&opts.yMax, "ymax", "limite superior da região ampliada (unidades da câmera)")
+	fs.BoolVar(&opts.multiView, "multiview", false, "gera folha com quatro vistas (frente, lateral, superior e perspectiva)")
+	fs.BoolVar(&opts.anaglyph, "anaglyph", false, "gera anáglifo vermelho/ciano para óculos 3D")
+	fs.BoolVar(&opts.sideBySide, "side-by-side", false, "gera par estéreo lado a lado, com largura dupla")
+	fs.BoolVar(&opts.crossEye, "cross-eye", false, "gera par estéreo para visão cruzada, com largura dupla")
+	fs.Var(&opts.eyeSeparation, "eye-separation", "distância entre os olhos nos modos estéreo (unidades da figura)")
+	fs.Var(&opts.convergence, "convergence", "distância do observador ao plano de convergência (unidades da figura)")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		// Guarda o primeiro posicional e continua interpretando o restante
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	return opts, files
+}
+
+// apply aplica as opções de linha de comando a uma figura carregada.
+//
+// Parâmetros:
+//   figura: figura recém-carregada, modificada no lugar
+//
+// Retorna:
+//   error: erro se alguma opção não puder ser aplicada
+func (o options) apply(figura *types.Figure) error {
+	// A câmera nomeada (ou do arquivo) vem primeiro: vista e
+	// enquadramento atuam sobre ela
+	if o.camera != "" && o.cameraFile != "" {
+		return fmt.Errorf("use --camera ou --camera-file, não ambos")
+	}
+	if o.camera != "" {
+		if err := figura.UseCamera(o.camera); err != nil {
+			return err
+		}
+	}
+	if o.cameraFile != "" {
+		camera, err := core.LoadCameraFile(o.cameraFile)
+		if err != nil {
+			return err
+		}
+		figura.Camera = camera
+	}
+	if o.view != "" {
+		if err := figura.Camera.ApplyView(o.view); err != nil {
+			return err
+		}
+	}
+	if o.autoCamera {
+		figura.Camera.Auto = true
+	}
+	if o.preset != "" {
+		if err := core.ApplyPreset(figura, o.preset); err != nil {
+			return err
+		}
+	}
+	if o.weld.set {
+		if _, err := figura.Weld(o.weld.value); err != nil {
+			return fmt.Errorf("--weld: %w", err)
+		}
+	}
+	if o.subdivide != 0 {
+		if _, err := figura.SubdivideLongest(o.subdivide); err != nil {
+			return fmt.Errorf("--subdivide: %w", err)
+		}
+	}
+	if o.grid || o.axes {
+		if figura.Render == nil {
+			figura.Render = &types.RenderSettings{}
+		}
+		show := true
+		if o.grid {
+			figura.Render.ShowGrid = &show
+		}
+		if o.axes {
+			figura.Render.ShowAxes = &show
+		}
+	}
+	return nil
+}
+
 // openViewer inicia a interface gráfica interativa.
 //
 // Permite visualizar e manipular figuras 3D em tempo real,
@@ -122,11 +856,15 @@ func showHelp() {
 //
 // Parâmetros:
 //   yamlFile: caminho para o arquivo de definição da figura
-func openViewer(yamlFile string) {
+//   opts: opções de linha de comando aplicadas a cada carregamento
+func openViewer(yamlFile string, opts options) {
 	fmt.Printf("Abrindo viewfinder para: %s\n", yamlFile)
 
 	// Cria e executa a interface gráfica
-	gui := viewer.NewGUI(yamlFile)
+	gui := viewer.NewGUI(yamlFile, opts.apply)
+	if opts.saveCamera != "" {
+		gui.SetCameraFile(opts.saveCamera)
+	}
 	gui.Run()
 }
 
@@ -139,9 +877,13 @@ func openViewer(yamlFile string) {
 // 4. Renderização em alta qualidade
 // 5. Export para arquivo moderno (PNG vs. tela do HP-85)
 //
+// Com --all-cameras, as etapas 2 a 5 são repetidas para cada câmera
+// nomeada da figura, gerando um PNG por câmera.
+//
 // Parâmetros:
 //   yamlFile: caminho para o arquivo de definição da figura
-func generatePNG(yamlFile string) {
+//   opts: opções de linha de comando que sobrepõem o YAML
+func generatePNG(yamlFile string, opts options) {
 	fmt.Printf("Gerando PNG para: %s\n", yamlFile)
 
 	// === ETAPA 1: CARREGAMENTO DA FIGURA ===
@@ -156,52 +898,1944 @@ func generatePNG(yamlFile string) {
 	fmt.Printf("Pontos 3D: %d\n", len(figura.Pontos))
 	fmt.Printf("Linhas: %d\n", len(figura.Linhas))
 
+	if !opts.allCameras {
+		// Opções de linha de comando sobrepõem o YAML
+		if err := opts.apply(figura); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		renderPNG(figura, opts, yamlFile, figura.Nome)
+		if opts.saveCamera != "" {
+			saveEffectiveCamera(figura, opts.saveCamera)
+		}
+	} else {
+		if opts.cameraFile != "" || opts.saveCamera != "" {
+			log.Fatalf("Erro nas opções: --camera-file e --save-camera não podem ser usados com --all-cameras")
+		}
+		names := figura.CameraNames()
+		if len(names) == 0 {
+			log.Fatalf("Erro nas opções: a figura não declara câmeras nomeadas (seção \"cameras\")")
+		}
+
+		// Cada câmera trabalha sobre uma cópia, para que vistas,
+		// enquadramentos e presets de uma não vazem para as seguintes
+		for _, name := range names {
+			copia := *figura
+			copia.Render = figura.Render.Clone()
+			cameraOpts := opts
+			cameraOpts.camera = name
+			if err := cameraOpts.apply(&copia); err != nil {
+				log.Fatalf("Erro nas opções (câmera %s): %v", name, err)
+			}
+			renderPNG(&copia, cameraOpts, yamlFile, figura.Nome+"_"+name)
+		}
+	}
+
+	// Dica de uso
+	fmt.Println("Dica: Use 'figuras3d view' para visualizar interativo!")
+}
+
+// renderPNG renderiza uma figura já preparada e salva a imagem em output/
+// (PNG, ou o formato escolhido com --format).
+//
+// Parâmetros:
+//   figura: figura carregada, com as opções de linha de comando aplicadas
+//   opts: opções de linha de comando (modo de renderização)
+//   source: arquivo YAML de onde a figura veio
+//   baseName: nome base do arquivo de saída, sem extensão
+func renderPNG(figura *types.Figure, opts options, source, baseName string) {
 	// === ETAPA 2: CONFIGURAÇÃO DE DIMENSÕES ===
 	// Define tamanho da tela de saída (muito superior ao HP-85: 256×192)
-	width, height := 800, 600 // Resolução padrão moderna
+	width, height := canvasSize(figura)
+
+	// Modo retrô: exatamente a tela gráfica do HP-85
+	var retro renderer.RetroMode
+	if opts.retro != "" {
+		mode, err := renderer.ParseRetroMode(opts.retro)
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		retro = mode
+		width, height = renderer.HP85Width, renderer.HP85Height
+	} else if opts.retroScale != 1 {
+		log.Fatalf("Erro nas opções: --retro-scale só vale com --retro")
+	}
+
+	// Efeito de monitor de tubo, aplicado à imagem pronta
+	var phosphor renderer.Phosphor
+	if opts.crt != "" {
+		p, err := renderer.ParsePhosphor(opts.crt)
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		phosphor = p
+	}
 
-	// Permite customização via configurações no YAML
-	if figura.Render != nil {
-		if figura.Render.CanvasWidth > 0 {
-			width = figura.Render.CanvasWidth
+	// Só as cores que um computador da época sabia gerar
+	var palette renderer.Palette
+	if opts.palette != "" {
+		p, err := renderer.ParsePalette(opts.palette)
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
 		}
-		if figura.Render.CanvasHeight > 0 {
-			height = figura.Render.CanvasHeight
+		if phosphor != "" {
+			log.Fatalf("Erro nas opções: --palette não pode ser usado com --crt")
 		}
+		palette = p
 	}
 
+	// Prévia no próprio terminal, além do arquivo
+	terminal := terminalProtocol(opts)
+
+	// Formato do arquivo: PNG, ou JPEG e WebP, menores, para a web
+	saveOpts := imageSaveOptions(opts)
+	saveOpts.Text = pngMetadata(figura, source, width, height)
+	ext := saveOpts.Format.Extension()
+
 	// === ETAPA 3: CONFIGURAÇÃO VISUAL ===
 	// Converte configurações YAML para formato interno do renderizador
 	renderCfg, err := renderer.ConfigFromFigure(figura)
 	if err != nil {
 		log.Fatalf("Erro na configuração de renderização: %v", err)
 	}
+	if opts.eyeSeparation.set {
+		if opts.eyeSeparation.value <= 0 {
+			log.Fatalf("Erro nas opções: separação dos olhos deve ser positiva: %g", opts.eyeSeparation.value)
+		}
+		renderCfg.EyeSeparation = opts.eyeSeparation.value
+	}
+	if opts.convergence.set {
+		if opts.convergence.value <= 0 {
+			log.Fatalf("Erro nas opções: distância de convergência deve ser positiva: %g", opts.convergence.value)
+		}
+		renderCfg.Convergence = opts.convergence.value
+	}
+	saveOpts.DPI = renderCfg.DPI
+	if retro != "" {
+		// Sem suavização nem gradientes de 16 bits, como na tela original
+		renderCfg.Antialias, renderCfg.BitDepth = 1, 8
+	}
+	if opts.layoutModes() > 1 {
+		log.Fatalf("Erro nas opções: use apenas uma entre --multiview, --anaglyph, --side-by-side e --cross-eye")
+	}
+	if opts.autoCrop && opts.layoutModes() > 0 {
+		log.Fatalf("Erro nas opções: --autocrop não pode ser usado com --multiview, --anaglyph, --side-by-side, --cross-eye ou sheet")
+	}
+	if retro != "" && (opts.layoutModes() > 0 || opts.autoCrop) {
+		log.Fatalf("Erro nas opções: --retro não pode ser usado com --multiview, --anaglyph, --side-by-side, --cross-eye, --autocrop ou sheet")
+	}
+	if opts.construction && (opts.layoutModes() > 0 || opts.autoCrop || opts.terminal != "") {
+		log.Fatalf("Erro nas opções: --construction não pode ser usado com --multiview, --anaglyph, --side-by-side, --cross-eye, --autocrop, --terminal ou sheet")
+	}
+	if !opts.animated() && (opts.sequence || opts.out != "" || opts.frameDelay != 0) {
+		log.Fatalf("Erro nas opções: --sequence, --out e --frame-delay só valem com --construction ou com os comandos gif, animate e morph")
+	}
+	if !opts.turntable && !opts.keyframes && opts.morph == "" && opts.frames != 0 {
+		log.Fatalf("Erro nas opções: --frames só vale com os comandos gif, animate e morph")
+	}
+	if opts.morph == "" && opts.morphMap != "" {
+		log.Fatalf("Erro nas opções: --map só vale com o comando morph")
+	}
+	if !opts.keyframes && (opts.spin != "" || opts.ease != "" || opts.degrees != 0) {
+		log.Fatalf("Erro nas opções: --spin, --degrees e --ease só valem com o comando animate")
+	}
+	if opts.spin == "" && opts.degrees != 0 {
+		log.Fatalf("Erro nas opções: --degrees só vale com --spin")
+	}
+	if opts.ease != "" {
+		if _, err := renderer.ParseEasing(opts.ease); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+	if !opts.turntable && opts.axis != string(renderer.AxisZ) {
+		log.Fatalf("Erro nas opções: --axis só vale com o comando gif")
+	}
+	if opts.out != "" {
+		if err := checkFramePattern(opts.out); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+	if opts.video == "" && (opts.codec != "" || opts.fps != 0) {
+		log.Fatalf("Erro nas opções: --codec e --fps só valem com --video")
+	}
+	if opts.video != "" {
+		if !opts.animated() || opts.sequence || opts.out != "" {
+			log.Fatalf("Erro nas opções: --video só vale com --construction ou com os comandos gif, animate e morph, e não com --sequence nem --out")
+		}
+		if _, err := videoCodec(opts); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		if opts.fps < 0 {
+			log.Fatalf("Erro nas opções: taxa de quadros inválida: %g (deve ser positiva)", opts.fps)
+		}
+	}
+	if opts.frameDelay < 0 {
+		log.Fatalf("Erro nas opções: intervalo entre quadros inválido: %d ms (deve ser positivo)", opts.frameDelay)
+	}
+	if opts.cropMargin < 0 {
+		log.Fatalf("Erro nas opções: margem do recorte inválida: %d (deve ser positiva)", opts.cropMargin)
+	}
+
+	// Imagens de tamanho de pôster não cabem em memória de uma só vez:
+	// são renderizadas em faixas, diretamente para o arquivo
+	if opts.layoutModes() == 0 && width*height > renderer.TiledThreshold {
+		renderTiledPNG(figura, opts, baseName, width, height, renderCfg, saveOpts)
+		return
+	}
 
 	// === ETAPA 4: INICIALIZAÇÃO DO RENDERIZADOR ===
 	// Cria o contexto gráfico com a resolução especificada
+	// (pares estéreo têm uma vista de largura inteira para cada olho)
+	if opts.sideBySide || opts.crossEye {
+		width *= 2
+	}
+
+	// Folha de contatos: uma célula de meia tela para cada ângulo
+	if opts.sheet {
+		cols, rows := renderer.SheetGrid(opts.angles)
+		width, height = cols*width/2, rows*height/2
+	}
 	r := renderer.New(width, height)
 
+	// Suavização por superamostragem: desenha em resolução maior e reduz
+	if err := r.SetSupersampling(renderCfg.Antialias); err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+
+	// Gradientes sem faixas para composição posterior: PNG de 16 bits
+	if err := r.SetBitDepth(renderCfg.BitDepth); err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+
 	// === ETAPA 5: CONFIGURAÇÃO DA CÂMERA ===
 	// Define os parâmetros fundamentais da perspectiva cônica
 	// (observador V, distância R, dimensões L1 e L2)
 	r.SetCamera(figura.Camera)
 
+	// Região de interesse: amplia parte da tela virtual sem mover a câmera
+	if opts.hasRegion() {
+		if err := r.SetRegion(opts.region(r.VirtualScreen())); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		baseName += "_regiao"
+	}
+
+	// Animação: as linhas surgindo uma a uma, como no programa do
+	// artigo, a câmera dando a volta na figura ou percorrendo os
+	// quadros-chave, ou a figura se transformando em outra
+	if opts.animated() {
+		var morph *renderer.Morph
+		if opts.morph != "" {
+			morph = loadMorph(figura, opts)
+		}
+		if opts.turntable || morph != nil {
+			// Como na folha de contatos, com enquadramento automático:
+			// a esfera envolvente (na metamorfose, a das duas figuras)
+			// cabe na tela em qualquer ângulo. Resolvido uma só vez, é o
+			// mesmo em todos os quadros
+			framed := figura
+			if morph != nil {
+				framed = morph.Envelope()
+			}
+			camera := figura.Camera
+			camera.Auto = true
+			r.SetCamera(renderer.EffectiveCamera(camera, framed, float64(width)/float64(height)))
+		}
+		if opts.spin != "" {
+			// Giro do animate: quadros-chave em volta da câmera com
+			// enquadramento automático, que serve de base para o voo
+			camera := figura.Camera
+			camera.Auto = true
+			figura.Camera = renderer.EffectiveCamera(camera, figura, float64(width)/float64(height))
+			figura.Animacao = renderer.SpinAnimation(figura.Camera, figura, cmp.Or(opts.degrees, renderer.DefaultSpinDegrees), opts.frames, "")
+		}
+		renderAnimation(r, figura, morph, opts, renderCfg, retro, phosphor, palette, baseName, saveOpts)
+		return
+	}
+
 	// === ETAPA 6: RENDERIZAÇÃO ===
 	// Aplica as transformações 3D→2D e desenha a figura
-	err = r.RenderFigureWithConfig(figura, renderCfg)
+	// (ou a folha de desenho técnico com quatro vistas, ou o anáglifo)
+	outputFile := fmt.Sprintf("output/%s%s", baseName, ext)
+	switch {
+	case retro != "":
+		err = r.RenderRetro(figura, renderCfg)
+		outputFile = fmt.Sprintf("output/%s_%s%s", baseName, retro, ext)
+	case opts.multiView:
+		err = r.RenderSheet(figura, renderCfg)
+		outputFile = fmt.Sprintf("output/%s_vistas%s", baseName, ext)
+	case opts.anaglyph:
+		err = r.RenderAnaglyph(figura, renderCfg)
+		outputFile = fmt.Sprintf("output/%s_anaglifo%s", baseName, ext)
+	case opts.sideBySide:
+		err = r.RenderStereoPair(figura, renderCfg, false)
+		outputFile = fmt.Sprintf("output/%s_estereo%s", baseName, ext)
+	case opts.crossEye:
+		err = r.RenderStereoPair(figura, renderCfg, true)
+		outputFile = fmt.Sprintf("output/%s_cruzado%s", baseName, ext)
+	case opts.sheet:
+		err = r.RenderContactSheet(figura, renderCfg, opts.angles)
+		outputFile = fmt.Sprintf("output/%s_angulos%s", baseName, ext)
+	default:
+		err = r.RenderFigureWithConfig(figura, renderCfg)
+	}
 	if err != nil {
 		log.Fatalf("Erro ao renderizar figura: %v", err)
 	}
 
+	// Sem as sobras de fundo em volta da figura (--autocrop não é
+	// aceito com --retro, então a ordem em relação à ampliação não importa)
+	if opts.autoCrop {
+		if _, err := r.AutoCrop(renderCfg, opts.cropMargin); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+
+	// Ampliação retrô, monitor de tubo e paleta
+	applyEffects(r, opts, retro, phosphor, palette)
+	outputFile = strings.TrimSuffix(outputFile, ext) + effectsSuffix(phosphor, palette) + ext
+
 	// === ETAPA 7: EXPORT ===
-	// Salva o resultado em arquivo PNG (tecnologia inexistente em 1982!)
-	outputFile := fmt.Sprintf("output/%s.png", figura.Nome)
-	err = r.SaveImage(outputFile)
+	// Salva o resultado em arquivo PNG (tecnologia inexistente em 1982!),
+	// com a figura e a câmera de origem registradas no próprio arquivo
+	err = r.SaveImage(outputFile, saveOpts)
 	if err != nil {
 		log.Fatalf("Erro ao salvar imagem: %v", err)
 	}
 
-	// Confirmação de sucesso e dica de uso
+	// Confirmação de sucesso
+	fmt.Printf("Imagem salva: %s\n", outputFile)
+
+	if terminal != "" {
+		if err := r.WriteTerminal(os.Stdout, terminal); err != nil {
+			log.Fatalf("Erro ao mostrar imagem no terminal: %v", err)
+		}
+	}
+}
+
+// applyEffects aplica à imagem pronta os efeitos de pós-processamento
+// pedidos, nesta ordem: ampliação dos pixels da tela retrô (--retro-scale),
+// monitor de tubo (--crt) e paleta retrô (--palette).
+//
+// Parâmetros:
+//   r: renderizador com a imagem já desenhada
+//   opts: opções de linha de comando
+//   retro, phosphor, palette: modos já validados (vazios se não pedidos)
+func applyEffects(r *renderer.Renderer3D, opts options, retro renderer.RetroMode, phosphor renderer.Phosphor, palette renderer.Palette) {
+	// Pixels da tela retrô ampliados como blocos nítidos
+	if retro != "" {
+		if err := r.Upscale(opts.retroScale); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+
+	// Como a foto da tela de um monitor de fósforo da época
+	if phosphor != "" {
+		if err := r.ApplyCRT(renderer.DefaultCRTEffect(phosphor)); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+
+	// Cores reduzidas à paleta retrô
+	if palette != "" {
+		if err := r.Quantize(palette); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+}
+
+// effectsSuffix retorna o sufixo que os efeitos acrescentam ao nome do
+// arquivo: "_crt" para o monitor de tubo e o nome da paleta retrô.
+func effectsSuffix(phosphor renderer.Phosphor, palette renderer.Palette) string {
+	suffix := ""
+	if phosphor != "" {
+		suffix += "_crt"
+	}
+	if palette != "" {
+		suffix += "_" + string(palette)
+	}
+	return suffix
+}
+
+// renderAnimation grava uma animação da figura, com um dos três roteiros:
+//
+//   --construction: um quadro para cada linha traçada, na ordem em que
+//   foram definidas, como o programa BASIC do artigo desenhava na tela
+//   do HP-85 (ver renderer.ConstructionStage). O primeiro quadro tem
+//   apenas os vértices e o último, que fica mais tempo na tela, a figura
+//   completa (sem faces nem cotas).
+//
+//   gif: a câmera dá uma volta completa em torno de um eixo que passa
+//   pelo centro da figura, em --frames passos iguais (ver
+//   renderer.TurntableStage). O último quadro precede o primeiro, e a
+//   animação se repete sem emendas.
+//
+//   animate: a câmera percorre os quadros-chave da seção "animacao",
+//   interpolados a quadros_por_segundo (ver renderer.KeyframeCameras),
+//   ou os do giro de --spin (ver renderer.SpinAnimation).
+//
+//   morph: os pontos da figura caminham até os da figura de destino e
+//   voltam, em 2×(--frames-1) quadros (ver renderer.Morph), e a
+//   animação se repete sem emendas.
+//
+// Os quadros vão para um GIF animado, para imagens numeradas (com
+// --sequence ou --out) ou, com --video, direto para o ffmpeg, que grava
+// o vídeo. Os efeitos (--retro-scale, --crt, --palette) são aplicados a
+// cada quadro.
+//
+// Parâmetros:
+//   r: renderizador já com câmera e região configuradas
+//   figura: figura completa
+//   morph: metamorfose do comando morph (nil nos demais)
+//   opts: opções de linha de comando
+//   renderCfg: configurações visuais
+//   retro, phosphor, palette: modos já validados (vazios se não pedidos)
+//   baseName: nome base dos arquivos gerados
+//   saveOpts: formato e metadados dos quadros numerados
+func renderAnimation(r *renderer.Renderer3D, figura *types.Figure, morph *renderer.Morph, opts options, renderCfg renderer.RenderConfig, retro renderer.RetroMode, phosphor renderer.Phosphor, palette renderer.Palette, baseName string, saveOpts renderer.SaveOptions) {
+	// Roteiro: número de quadros, figura de cada quadro e tempos
+	var (
+		suffix      string
+		count       int
+		stage       func(n int) *types.Figure
+		delay, hold int
+		rate        float64 // Quadros por segundo do vídeo, sem o arredondamento de delay
+	)
+	switch {
+	case morph != nil:
+		suffix, count = "_metamorfose", 2*(opts.frames-1)
+		stage = func(n int) *types.Figure {
+			return morph.Stage(renderer.MorphTime(n, count))
+		}
+		delay = cmp.Or(opts.frameDelay, renderer.DefaultMorphDelay)
+		hold = delay
+	case opts.keyframes:
+		if figura.Animacao == nil {
+			log.Fatalf("Erro nas opções: a figura não tem a seção animacao (ou use --spin)")
+		}
+		animation := *figura.Animacao
+		if opts.ease != "" {
+			animation.Easing, _ = renderer.ParseEasing(opts.ease) // Já validada
+		}
+		cameras, interval, err := renderer.KeyframeCameras(figura.Camera, &animation, opts.frames)
+		if err != nil {
+			log.Fatalf("Erro na animação: %v", err)
+		}
+		suffix, count = "_animacao", len(cameras)
+		stage = func(n int) *types.Figure {
+			r.SetCamera(cameras[n]) // Só a câmera muda de um quadro para outro
+			return figura
+		}
+		delay = max(1, int(math.Round(interval*1000)))
+		hold = delay
+		rate = math.Round(1e6/interval) / 1e6
+	case opts.turntable:
+		axis, err := renderer.ParseTurntableAxis(opts.axis)
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		suffix, count = "_giro_"+string(axis), opts.frames
+		stage = func(n int) *types.Figure {
+			return renderer.TurntableStage(figura, axis, float64(n)*360/float64(count))
+		}
+		delay = cmp.Or(opts.frameDelay, renderer.DefaultTurntableDelay)
+		hold = delay
+	default:
+		if len(figura.Linhas) == 0 {
+			log.Fatalf("Erro nas opções: --construction precisa de uma figura com linhas")
+		}
+		suffix, count = "_construcao", len(figura.Linhas)+1
+		stage = func(n int) *types.Figure {
+			return renderer.ConstructionStage(figura, n)
+		}
+		delay = cmp.Or(opts.frameDelay, renderer.DefaultConstructionDelay)
+		hold = max(delay, renderer.ConstructionHold)
+	}
+
+	name := baseName
+	if retro != "" {
+		name += "_" + string(retro)
+	}
+	name += suffix + effectsSuffix(phosphor, palette)
+	ext := saveOpts.Format.Extension()
+
+	// Quadros numerados: em output/ ou com o padrão de --out, cujo
+	// formato vem da extensão se --format não for informado
+	pattern := fmt.Sprintf("output/%s_%%03d%s", strings.ReplaceAll(name, "%", "%%"), ext)
+	if opts.out != "" {
+		pattern = opts.out
+		if opts.format == "" {
+			saveOpts.Format = ""
+		}
+		if err := os.MkdirAll(filepath.Dir(pattern), 0755); err != nil {
+			log.Fatalf("Erro ao criar diretório dos quadros: %v", err)
+		}
+	}
+	sequence := opts.sequence || opts.out != ""
+
+	// Vídeo: o ffmpeg começa no primeiro quadro, quando o tamanho é
+	// conhecido. O último quadro se repete pelo tempo que ficaria no GIF
+	var video *renderer.VideoWriter
+	fps := cmp.Or(opts.fps, rate, 1000/float64(delay))
+	repeat := max(1, int(math.Round(float64(hold)/float64(delay))))
+
+	var frames []image.Image
+	for n := 0; n < count; n++ {
+		var err error
+		if retro != "" {
+			err = r.RenderRetro(stage(n), renderCfg)
+		} else {
+			err = r.RenderFigureWithConfig(stage(n), renderCfg)
+		}
+		if err != nil {
+			log.Fatalf("Erro ao renderizar figura: %v", err)
+		}
+
+		// Os efeitos trocam a imagem do renderizador: cada quadro é
+		// processado numa cópia
+		frame := renderer.NewFromImage(r.GetImage().(image.Image))
+		applyEffects(frame, opts, retro, phosphor, palette)
+
+		if sequence {
+			outputFile := fmt.Sprintf(pattern, n)
+			if err := frame.SaveImage(outputFile, saveOpts); err != nil {
+				log.Fatalf("Erro ao salvar imagem: %v", err)
+			}
+			continue
+		}
+		if opts.video != "" {
+			img := frame.GetImage().(image.Image)
+			if video == nil {
+				video = startVideo(opts, fps, img.Bounds())
+			}
+			copies := 1
+			if n == count-1 {
+				copies = repeat
+			}
+			for range copies {
+				if err := video.WriteFrame(img); err != nil {
+					log.Fatalf("Erro ao gravar vídeo: %v", err)
+				}
+			}
+			continue
+		}
+		frames = append(frames, frame.GetImage().(image.Image))
+	}
+
+	if video != nil {
+		if err := video.Close(); err != nil {
+			log.Fatalf("Erro ao gravar vídeo: %v", err)
+		}
+		fmt.Printf("Vídeo salvo: %s (%d quadros, %.4g quadros/s)\n", opts.video, count+repeat-1, fps)
+		return
+	}
+	if sequence {
+		fmt.Printf("Quadros salvos: %s a %s\n", fmt.Sprintf(pattern, 0), fmt.Sprintf(pattern, count-1))
+		return
+	}
+
+	outputFile := fmt.Sprintf("output/%s.gif", name)
+	if err := renderer.SaveGIF(outputFile, frames, delay, hold); err != nil {
+		log.Fatalf("Erro ao salvar animação: %v", err)
+	}
+	fmt.Printf("Animação salva: %s\n", outputFile)
+}
+
+// loadMorph carrega a figura de destino do comando morph e pareia os
+// seus pontos com os da figura de origem (ver renderer.NewMorph).
+//
+// Parâmetros:
+//   figura: figura de origem, já com as opções aplicadas
+//   opts: opções com o arquivo de destino e os pares de --map
+//
+// Retorna:
+//   *renderer.Morph: metamorfose pronta
+func loadMorph(figura *types.Figure, opts options) *renderer.Morph {
+	destino, err := core.LoadFigureFromYAML(opts.morph)
+	if err != nil {
+		log.Fatalf("Erro ao carregar figura de destino: %v", err)
+	}
+	var pairs map[string]string
+	if opts.morphMap != "" {
+		if pairs, err = renderer.ParseMorphMap(opts.morphMap); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+	}
+	morph, err := renderer.NewMorph(figura, destino, pairs)
+	if err != nil {
+		log.Fatalf("Erro na metamorfose: %v", err)
+	}
+	fmt.Printf("Destino: %s (%d pontos)\n", destino.Nome, len(destino.Pontos))
+	return morph
+}
+
+// videoCodec resolve as opções --video e --codec (ver
+// renderer.VideoCodecFor).
+//
+// Retorna:
+//   renderer.VideoCodec: codificador do vídeo
+//   error: erro se o formato ou o codificador forem inválidos
+func videoCodec(opts options) (renderer.VideoCodec, error) {
+	var codec renderer.VideoCodec
+	if opts.codec != "" {
+		var err error
+		if codec, err = renderer.ParseVideoCodec(opts.codec); err != nil {
+			return "", err
+		}
+	}
+	return renderer.VideoCodecFor(opts.video, codec)
+}
+
+// startVideo inicia a gravação do vídeo de --video, criando o diretório
+// do arquivo se preciso.
+//
+// Parâmetros:
+//   opts: opções já validadas
+//   fps: quadros por segundo
+//   bounds: tamanho dos quadros
+//
+// Retorna:
+//   *renderer.VideoWriter: gravador aberto
+func startVideo(opts options, fps float64, bounds image.Rectangle) *renderer.VideoWriter {
+	codec, err := videoCodec(opts)
+	if err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.video), 0755); err != nil {
+		log.Fatalf("Erro ao criar diretório do vídeo: %v", err)
+	}
+	video, err := renderer.NewVideoWriter(opts.video, codec, fps, bounds.Dx(), bounds.Dy())
+	if err != nil {
+		log.Fatalf("Erro ao gravar vídeo: %v", err)
+	}
+	return video
+}
+
+// checkFramePattern verifica o padrão de nome dos quadros de --out: o
+// número do quadro entra no lugar de um verbo de fmt, como em
+// "quadros/frame_%04d.png", e cada quadro precisa de um nome diferente.
+//
+// Parâmetros:
+//   pattern: padrão informado em --out
+//
+// Retorna:
+//   error: erro se o padrão não tiver exatamente um número inteiro
+func checkFramePattern(pattern string) error {
+	first, second := fmt.Sprintf(pattern, 0), fmt.Sprintf(pattern, 1)
+	if first == second || strings.Contains(first, "%!") {
+		return fmt.Errorf("padrão de nome dos quadros inválido: %q (use um número como %%04d, por exemplo quadros/frame_%%04d.png)", pattern)
+	}
+	return nil
+}
+
+// terminalProtocol resolve a opção --terminal: "auto" consulta as
+// variáveis de ambiente (ver renderer.DetectTerminalProtocol); os demais
+// valores são o nome do protocolo.
+//
+// Retorna:
+//   renderer.TerminalProtocol: protocolo a usar ("" sem --terminal)
+func terminalProtocol(opts options) renderer.TerminalProtocol {
+	if opts.terminal == "" {
+		return ""
+	}
+	if strings.EqualFold(opts.terminal, "auto") {
+		protocol, ok := renderer.DetectTerminalProtocol(os.Getenv)
+		if !ok {
+			log.Fatalf("Erro nas opções: o terminal não parece mostrar imagens (TERM=%q); use --terminal sixel ou kitty", os.Getenv("TERM"))
+		}
+		return protocol
+	}
+	protocol, err := renderer.ParseTerminalProtocol(opts.terminal)
+	if err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+	return protocol
+}
+
+// canvasSize retorna as dimensões da imagem de saída em pixels.
+//
+// O padrão é 800×600; a seção "render" do YAML pode alterá-las, em
+// pixels ou em medidas físicas (ver renderer.CanvasSize).
+func canvasSize(figura *types.Figure) (width, height int) {
+	width, height, err := renderer.CanvasSize(figura.Render)
+	if err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+	return width, height
+}
+
+// imageSaveOptions monta as opções de gravação a partir de --format e
+// --quality, encerrando o programa se forem inválidas.
+//
+// Parâmetros:
+//   opts: opções de linha de comando
+//
+// Retorna:
+//   renderer.SaveOptions: formato (PNG por padrão) e qualidade, sem metadados
+func imageSaveOptions(opts options) renderer.SaveOptions {
+	saveOpts := renderer.SaveOptions{Format: renderer.FormatPNG, Quality: opts.quality}
+	if opts.format != "" {
+		format, err := renderer.ParseImageFormat(opts.format)
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		saveOpts.Format = format
+	}
+	if opts.quality < 0 || opts.quality > 100 {
+		log.Fatalf("Erro nas opções: qualidade inválida: %d (use de 1 a 100)", opts.quality)
+	}
+	return saveOpts
+}
+
+// pngMetadata monta os metadados gravados nas imagens geradas: o nome da
+// figura, o arquivo YAML de origem, a câmera efetiva (em YAML, pronta
+// para --camera-file) e a versão do programa. Meses depois, a própria
+// imagem diz como foi produzida.
+//
+// Parâmetros:
+//   figura: figura com as opções de linha de comando já aplicadas
+//   source: arquivo YAML (ou URL) de onde a figura veio
+//   width, height: dimensões da imagem em pixels
+//
+// Retorna:
+//   []renderer.PNGText: blocos de texto para o PNG
+func pngMetadata(figura *types.Figure, source string, width, height int) []renderer.PNGText {
+	if !strings.Contains(source, "://") {
+		if abs, err := filepath.Abs(source); err == nil {
+			source = abs
+		}
+	}
+	metadata := []renderer.PNGText{
+		{Keyword: "Title", Text: figura.Nome},
+		{Keyword: "Software", Text: "figuras3d " + version},
+		{Keyword: "Arquivo", Text: source},
+	}
+
+	camera := renderer.EffectiveCamera(figura.Camera, figura, float64(width)/float64(height))
+	if data, err := core.MarshalCamera(camera); err == nil {
+		metadata = append(metadata, renderer.PNGText{Keyword: "Camera", Text: string(data)})
+	}
+	return metadata
+}
+
+// saveEffectiveCamera grava a câmera efetivamente usada na renderização,
+// para que o mesmo enquadramento possa ser reproduzido com --camera-file.
+//
+// Parâmetros:
+//   figura: figura com as opções de linha de comando já aplicadas
+//   filename: arquivo de saída (.yaml ou .json)
+func saveEffectiveCamera(figura *types.Figure, filename string) {
+	width, height := canvasSize(figura)
+	camera := renderer.EffectiveCamera(figura.Camera, figura, float64(width)/float64(height))
+
+	if err := core.SaveCameraFile(filename, camera); err != nil {
+		log.Fatalf("Erro ao salvar câmera: %v", err)
+	}
+	fmt.Printf("Câmera salva: %s\n", filename)
+}
+
+// generateMontage reúne todas as figuras de um diretório numa só
+// imagem, cada uma numa célula com o seu nome (ver
+// renderer.RenderMontage), em output/<diretório>_montagem.png.
+//
+// As figuras são os arquivos .yaml e .yml do diretório, em ordem
+// alfabética, cada uma com a própria câmera e configuração e com as
+// opções de linha de comando aplicadas; as que não puderem ser
+// carregadas são ignoradas com um aviso. Cada célula tem metade do
+// tamanho padrão da tela.
+//
+// Parâmetros:
+//   dir: diretório com os arquivos YAML
+//   opts: opções de linha de comando aplicadas a todas as figuras
+func generateMontage(dir string, opts options) {
+	fmt.Printf("Gerando montagem para: %s\n", dir)
+	saveOpts := imageSaveOptions(opts)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatalf("Erro ao ler diretório: %v", err)
+	}
+
+	var cells []renderer.MontageCell
+	antialias := 1
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+
+		figura, err := core.LoadFigureFromYAML(file)
+		if err == nil {
+			err = opts.apply(figura)
+		}
+		var cfg renderer.RenderConfig
+		if err == nil {
+			cfg, err = renderer.ConfigFromFigure(figura)
+		}
+		if err != nil {
+			fmt.Printf("Aviso: %s ignorado: %v\n", file, err)
+			continue
+		}
+
+		cells = append(cells, renderer.MontageCell{Figure: figura, Config: cfg})
+		antialias = max(antialias, cfg.Antialias)
+	}
+	if len(cells) == 0 {
+		log.Fatalf("Erro: nenhuma figura em %s", dir)
+	}
+	fmt.Printf("Figuras: %d\n", len(cells))
+
+	// Uma célula de meia tela para cada figura, com a maior suavização
+	// pedida entre elas
+	cols, rows := renderer.SheetGrid(len(cells))
+	r := renderer.New(cols*renderer.DefaultCanvasWidth/2, rows*renderer.DefaultCanvasHeight/2)
+	if err := r.SetSupersampling(antialias); err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+	if err := r.RenderMontage(cells); err != nil {
+		log.Fatalf("Erro ao renderizar montagem: %v", err)
+	}
+
+	name := filepath.Base(filepath.Clean(dir))
+	saveOpts.Text = []renderer.PNGText{
+		{Keyword: "Title", Text: name},
+		{Keyword: "Software", Text: "figuras3d " + version},
+	}
+	outputFile := fmt.Sprintf("output/%s_montagem%s", name, saveOpts.Format.Extension())
+	if err := r.SaveImage(outputFile, saveOpts); err != nil {
+		log.Fatalf("Erro ao salvar imagem: %v", err)
+	}
+	fmt.Printf("Imagem salva: %s\n", outputFile)
+}
+
+// generateASCII desenha a figura com caracteres (ver
+// renderer.RenderASCII) e mostra o resultado na saída padrão ou o grava
+// num arquivo de texto.
+//
+// A proporção do desenho é a da imagem que generate produziria; a
+// largura em caracteres vem de --cols. Na saída padrão vai apenas o
+// desenho, para que possa ser redirecionado.
+//
+// Parâmetros:
+//   yamlFile: caminho para o arquivo de definição da figura
+//   opts: opções de linha de comando que sobrepõem o YAML
+func generateASCII(yamlFile string, opts options) {
+	figura, err := core.LoadFigureFromYAML(yamlFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	if err := opts.apply(figura); err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+
+	renderCfg, err := renderer.ConfigFromFigure(figura)
+	if err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+	width, height := canvasSize(figura)
+	width, height, err = renderer.ASCIISize(opts.cols, width, height)
+	if err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+
+	r := renderer.New(width, height)
+	r.SetCamera(figura.Camera)
+	lines, err := r.RenderASCII(figura, renderCfg)
+	if err != nil {
+		log.Fatalf("Erro ao renderizar figura: %v", err)
+	}
+	text := strings.Join(lines, "\n") + "\n"
+
+	if opts.output == "" {
+		fmt.Print(text)
+		return
+	}
+	if err := os.WriteFile(opts.output, []byte(text), 0644); err != nil {
+		log.Fatalf("Erro ao salvar desenho: %v", err)
+	}
+	fmt.Printf("Desenho salvo: %s\n", opts.output)
+}
+
+// exportFigure grava a figura num formato vetorial, para outros
+// programas: DXF, com linhas 3D, para o CAD (ver core.MarshalDXF), EPS,
+// com a projeção da câmera, para editoração (ver renderer.RenderEPS), ou
+// uma cena do POV-Ray ou do Three.js, com a câmera da figura (ver
+// renderer.RenderPOVRay e renderer.RenderThreeJS). Em CSV, grava a
+// tabela da projeção de cada ponto (ver renderer.ProjectionTable), e a
+// câmera usada vai para a saída de erros, já que a tabela não a mostra.
+// Para o gnuplot, grava dois arquivos com o nome de --output, os dados
+// (.dat) e o script (.plt) (ver core.MarshalGnuplot); em BASIC, um
+// programa do HP-85 que desenha a figura (ver renderer.RenderBASIC). O
+// formato vem de --format ou da extensão de --output (.json para o
+// Three.js, .dat ou .plt para o gnuplot, .bas para o BASIC); sem
+// --output, o arquivo vai para a saída padrão, como no comando ascii.
+//
+// Parâmetros:
+//   yamlFile: caminho para o arquivo de definição da figura
+//   opts: opções de linha de comando (--format, --output e as que
+//         alteram a figura, como --weld)
+func exportFigure(yamlFile string, opts options) {
+	format := exportFormat(opts.format, opts.output)
+	switch format {
+	case "dxf", "eps", "pov", "threejs", "csv", "gnuplot", "basic":
+	case "":
+		log.Fatalf("Erro nas opções: especifique o formato com --format dxf, eps, pov, threejs, csv, gnuplot ou basic, ou pela extensão de --output")
+	default:
+		log.Fatalf("Erro nas opções: formato de exportação desconhecido: %q (use dxf, eps, pov, threejs, csv, gnuplot ou basic)", format)
+	}
+	if format == "gnuplot" && opts.output == "" {
+		log.Fatalf("Erro nas opções: o formato gnuplot grava dois arquivos; especifique o nome com --output")
+	}
+
+	figura, err := core.LoadFigureFromYAML(yamlFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	if err := opts.apply(figura); err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+	writeExport(figura, format, opts.output)
+}
+
+// exportFormat escolhe o formato de um arquivo: o dado explicitamente
+// ou, sem ele, o da extensão do arquivo (.json para o Three.js, .dat ou
+// .plt para o gnuplot, .bas para o BASIC e .yml para o YAML).
+//
+// Parâmetros:
+//   format: formato dado pelo usuário ("" = pela extensão)
+//   filename: caminho do arquivo
+//
+// Retorna:
+//   string: nome do formato, em minúsculas ("" se não houver extensão)
+func exportFormat(format, filename string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	switch format {
+	case "json":
+		return "threejs"
+	case "dat", "plt":
+		return "gnuplot"
+	case "bas":
+		return "basic"
+	case "yml":
+		return "yaml"
+	}
+	return format
+}
+
+// writeExport grava a figura num dos formatos do export (ou numa página
+// HTML, como o comando html), já escolhido e verificado.
+//
+// Parâmetros:
+//   figura: figura 3D
+//   format: dxf, eps, pov, threejs, csv, gnuplot, basic ou html
+//   output: caminho do arquivo ("" = saída padrão, exceto no gnuplot)
+func writeExport(figura *types.Figure, format, output string) {
+	var data []byte
+	switch format {
+	case "dxf":
+		data = core.MarshalDXF(figura)
+	case "gnuplot":
+		base := strings.TrimSuffix(output, filepath.Ext(output))
+		dataFile, scriptFile := base+".dat", base+".plt"
+		data, script := core.MarshalGnuplot(figura, filepath.Base(dataFile))
+		if err := os.WriteFile(dataFile, data, 0644); err != nil {
+			log.Fatalf("Erro ao salvar figura: %v", err)
+		}
+		if err := os.WriteFile(scriptFile, script, 0644); err != nil {
+			log.Fatalf("Erro ao salvar script: %v", err)
+		}
+		fmt.Printf("Figura exportada: %s e %s\n", dataFile, scriptFile)
+		return
+	default:
+		renderCfg, err := renderer.ConfigFromFigure(figura)
+		if err != nil {
+			log.Fatalf("Erro na configuração de renderização: %v", err)
+		}
+		r := renderer.New(canvasSize(figura))
+		r.SetCamera(figura.Camera)
+		switch format {
+		case "eps":
+			data, err = r.RenderEPS(figura, renderCfg)
+		case "pov":
+			data, err = r.RenderPOVRay(figura, renderCfg)
+		case "basic":
+			data, err = r.RenderBASIC(figura, renderCfg)
+		case "html":
+			data, err = r.RenderHTML(figura, renderCfg)
+		case "csv":
+			var table renderer.ProjectionTable
+			if table, err = r.ProjectionTable(figura, renderCfg); err == nil {
+				data = table.CSV()
+				printCamera(table.Camera)
+			}
+		default:
+			data, err = r.RenderThreeJS(figura, renderCfg)
+		}
+		if err != nil {
+			log.Fatalf("Erro ao renderizar figura: %v", err)
+		}
+	}
+
+	if output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		log.Fatalf("Erro ao salvar figura: %v", err)
+	}
+	fmt.Printf("Figura exportada: %s\n", output)
+}
+
+// printCamera mostra na saída de erros a câmera efetivamente usada na
+// projeção, com os valores que entram nas fórmulas do artigo: o
+// observador V, a distância R e a tela virtual L1×L2.
+//
+// Parâmetros:
+//   camera: câmera já enquadrada (ver renderer.ProjectionTable)
+func printCamera(camera types.Camera) {
+	v := camera.Observer
+	fmt.Fprintf(os.Stderr, "Câmera: V = (%g, %g, %g), R = %g, L1 = %g, L2 = %g", v.X, v.Y, v.Z, camera.Distance, camera.Width, camera.Height)
+	if camera.Target != nil {
+		t := *camera.Target
+		fmt.Fprintf(os.Stderr, ", alvo (%g, %g, %g)", t.X, t.Y, t.Z)
+	}
+	if camera.Projection != "" {
+		fmt.Fprintf(os.Stderr, ", projeção %s", camera.Projection)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// generateHTML grava uma página HTML autossuficiente com a figura, que
+// pode ser girada com o mouse em qualquer navegador (ver
+// renderer.RenderHTML). A página tem as dimensões do canvas da figura;
+// sem -o, vai para a saída padrão, como no comando ascii.
+//
+// Parâmetros:
+//   yamlFile: caminho para o arquivo de definição da figura
+//   opts: opções de linha de comando que sobrepõem o YAML
+func generateHTML(yamlFile string, opts options) {
+	figura, err := core.LoadFigureFromYAML(yamlFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	if err := opts.apply(figura); err != nil {
+		log.Fatalf("Erro nas opções: %v", err)
+	}
+
+	renderCfg, err := renderer.ConfigFromFigure(figura)
+	if err != nil {
+		log.Fatalf("Erro na configuração de renderização: %v", err)
+	}
+	r := renderer.New(canvasSize(figura))
+	r.SetCamera(figura.Camera)
+	page, err := r.RenderHTML(figura, renderCfg)
+	if err != nil {
+		log.Fatalf("Erro ao renderizar figura: %v", err)
+	}
+
+	if opts.output == "" {
+		os.Stdout.Write(page)
+		return
+	}
+	if err := os.WriteFile(opts.output, page, 0644); err != nil {
+		log.Fatalf("Erro ao salvar página: %v", err)
+	}
+	fmt.Printf("Página salva: %s\n", opts.output)
+}
+
+// generatePrimitive gera um sólido primitivo (ver geometry.Generate) e
+// grava a figura em YAML, pronta para generate ou para ser editada
+// (ver saveGenerated). Sem -o, a figura vai para a saída padrão, para
+// que possa ser redirecionada.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (o sólido e as opções)
+func generatePrimitive(args []string) {
+	params := geometry.DefaultParams()
+	var output string
+	var wireframe bool
+
+	fs := flag.NewFlagSet("gen-primitive", flag.ExitOnError)
+	fs.IntVar(&params.Segments, "segments", params.Segments, "divisões em volta do eixo vertical")
+	fs.IntVar(&params.Rings, "rings", params.Rings, "anéis da esfera (de polo a polo) ou do tubo do toro")
+	fs.Float64Var(&params.Radius, "radius", params.Radius, "raio da esfera, do cilindro, do cone, do toro (até o centro do tubo) ou dos poliedros (até os vértices)")
+	fs.Float64Var(&params.Tube, "tube", params.Tube, "raio do tubo do toro")
+	fs.Float64Var(&params.Width, "width", params.Width, "largura da caixa (X)")
+	fs.Float64Var(&params.Depth, "depth", params.Depth, "profundidade da caixa (Y)")
+	fs.Float64Var(&params.Height, "height", params.Height, "altura da caixa, do cilindro e do cone (Z)")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+	fs.BoolVar(&wireframe, "wireframe", false, "grava só as arestas, como linhas, sem faces")
+
+	// Como em parseOptions, as opções podem vir depois do sólido
+	var names []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(names) != 1 {
+		fmt.Println("Erro: especifique um sólido:", strings.Join(geometry.Names(), ", "))
+		fmt.Println("Uso: figuras3d gen-primitive <sólido> [opções]")
+		os.Exit(1)
+	}
+
+	figura, err := geometry.Generate(names[0], params)
+	if err != nil {
+		log.Fatalf("Erro ao gerar sólido: %v", err)
+	}
+	if wireframe {
+		figura = geometry.Wireframe(figura)
+	}
+	saveGenerated(figura, output)
+}
+
+// generateSurface gera o gráfico em arame de uma função z = f(x, y)
+// (ver geometry.Surface) e grava a figura em YAML, como
+// generatePrimitive.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a expressão e as opções)
+func generateSurface(args []string) {
+	params := geometry.DefaultSurfaceParams()
+	var output string
+	xRange, yRange, resolution := "-3,3", "-3,3", "30"
+
+	fs := flag.NewFlagSet("gen-surface", flag.ExitOnError)
+	fs.StringVar(&xRange, "x", xRange, "intervalo de x: início,fim (aceita expressões, como -2*pi,2*pi)")
+	fs.StringVar(&yRange, "y", yRange, "intervalo de y: início,fim")
+	fs.StringVar(&resolution, "resolution", resolution, "divisões da grade: n, ou nx x ny como 40x20")
+	fs.Float64Var(&params.ZScale, "z-scale", params.ZScale, "fator aplicado a z, para achatar ou realçar o relevo")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var exprs []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		exprs = append(exprs, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(exprs) != 1 {
+		fmt.Println("Erro: especifique a expressão de z em x e y, entre aspas")
+		fmt.Println("Uso: figuras3d gen-surface [opções] \"sin(x) * cos(y)\"")
+		os.Exit(1)
+	}
+
+	var err error
+	if params.XMin, params.XMax, err = parseRange(xRange); err != nil {
+		log.Fatalf("Erro nas opções: --x: %v", err)
+	}
+	if params.YMin, params.YMax, err = parseRange(yRange); err != nil {
+		log.Fatalf("Erro nas opções: --y: %v", err)
+	}
+	if params.XSteps, params.YSteps, err = parseResolution(resolution); err != nil {
+		log.Fatalf("Erro nas opções: --resolution: %v", err)
+	}
+
+	f, err := geometry.ParseExpression(exprs[0])
+	if err != nil {
+		log.Fatalf("Erro na expressão: %v", err)
+	}
+	figura, err := geometry.Surface(f, params)
+	if err != nil {
+		log.Fatalf("Erro ao gerar superfície: %v", err)
+	}
+	saveGenerated(figura, output)
+}
+
+// generateCurve gera uma curva paramétrica em arame (ver
+// geometry.Curve), pronta ou dada pelas expressões de x, y e z em t, e
+// grava a figura em YAML, como generatePrimitive.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a curva pronta, se houver, e as
+//         opções)
+func generateCurve(args []string) {
+	var spec geometry.CurveSpec
+	var tRange, output string
+	samples := geometry.DefaultCurveSamples
+
+	fs := flag.NewFlagSet("gen-curve", flag.ExitOnError)
+	fs.StringVar(&spec.X, "x", "", "expressão de x em t, como cos(t)")
+	fs.StringVar(&spec.Y, "y", "", "expressão de y em t, como sin(t)")
+	fs.StringVar(&spec.Z, "z", "", "expressão de z em t (padrão: 0)")
+	fs.StringVar(&tRange, "t", "", "intervalo de t: início,fim (padrão: 0,2*pi ou o da curva pronta)")
+	fs.IntVar(&samples, "samples", samples, "número de pontos da curva")
+	fs.BoolVar(&spec.Closed, "closed", false, "liga o último ponto ao primeiro (padrão: o da curva pronta)")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var names []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// A curva pronta é a base; as opções dadas a substituem
+	if len(names) > 1 {
+		log.Fatalf("Erro nas opções: especifique no máximo uma curva pronta (%s)", strings.Join(geometry.CurvePresetNames(), ", "))
+	}
+	if len(names) == 1 {
+		preset, err := geometry.CurvePreset(names[0])
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		if !set["x"] {
+			spec.X = preset.X
+		}
+		if !set["y"] {
+			spec.Y = preset.Y
+		}
+		if !set["z"] {
+			spec.Z = preset.Z
+		}
+		if !set["closed"] {
+			spec.Closed = preset.Closed
+		}
+		spec.Name, spec.TMin, spec.TMax = preset.Name, preset.TMin, preset.TMax
+	} else {
+		if spec.X == "" || spec.Y == "" {
+			fmt.Println("Erro: especifique uma curva pronta ou as expressões de x e y em t")
+			fmt.Println("Uso: figuras3d gen-curve [opções] <curva>")
+			fmt.Println("     figuras3d gen-curve --x \"cos(t)\" --y \"sin(t)\" [--z <expressão>] [opções]")
+			fmt.Println("Curvas prontas:", strings.Join(geometry.CurvePresetNames(), ", "))
+			os.Exit(1)
+		}
+		spec.TMax = 2 * math.Pi
+	}
+	spec.Z = cmp.Or(spec.Z, "0")
+
+	if tRange != "" {
+		var err error
+		if spec.TMin, spec.TMax, err = parseRange(tRange); err != nil {
+			log.Fatalf("Erro nas opções: --t: %v", err)
+		}
+	}
+
+	figura, err := geometry.Curve(spec, samples)
+	if err != nil {
+		log.Fatalf("Erro ao gerar curva: %v", err)
+	}
+	saveGenerated(figura, output)
+}
+
+// generateLSystem gera o desenho em arame de um L-system (ver
+// geometry.LSystemFigure), pronto ou dado pelo axioma e pelas regras, e
+// grava a figura em YAML, como generatePrimitive.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (o L-system pronto, se houver,
+//         e as opções)
+func generateLSystem(args []string) {
+	sys := geometry.LSystem{Angle: 90, Iterations: 4}
+	var rules, output string
+
+	fs := flag.NewFlagSet("gen-lsystem", flag.ExitOnError)
+	fs.StringVar(&sys.Axiom, "axiom", "", "cadeia inicial, como F--F--F")
+	fs.StringVar(&rules, "rules", "", "regras símbolo=cadeia separadas por vírgulas, como F=F+F--F+F")
+	fs.Float64Var(&sys.Angle, "angle", sys.Angle, "ângulo dos giros em graus (padrão: 90 ou o do L-system pronto)")
+	fs.IntVar(&sys.Iterations, "iterations", sys.Iterations, "número de reescritas (padrão: 4 ou o do L-system pronto)")
+	fs.Float64Var(&sys.Step, "step", 1, "comprimento de cada passo da tartaruga")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var names []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		names = append(names, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var extra map[rune]string
+	if rules != "" {
+		var err error
+		if extra, err = geometry.ParseRules(rules); err != nil {
+			log.Fatalf("Erro nas opções: --rules: %v", err)
+		}
+	}
+
+	// O L-system pronto é a base; as opções dadas a substituem, e as
+	// regras de --rules trocam só as dos símbolos que citam
+	if len(names) > 1 {
+		log.Fatalf("Erro nas opções: especifique no máximo um L-system pronto (%s)", strings.Join(geometry.LSystemPresetNames(), ", "))
+	}
+	if len(names) == 1 {
+		preset, err := geometry.LSystemPreset(names[0])
+		if err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		if !set["axiom"] {
+			sys.Axiom = preset.Axiom
+		}
+		if !set["angle"] {
+			sys.Angle = preset.Angle
+		}
+		if !set["iterations"] {
+			sys.Iterations = preset.Iterations
+		}
+		sys.Name, sys.Rules = preset.Name, preset.Rules
+	} else if sys.Axiom == "" {
+		fmt.Println("Erro: especifique um L-system pronto ou o axioma e as regras")
+		fmt.Println("Uso: figuras3d gen-lsystem [opções] <l-system>")
+		fmt.Println("     figuras3d gen-lsystem --axiom <cadeia> --rules <símbolo=cadeia,...> [opções]")
+		fmt.Println("L-systems prontos:", strings.Join(geometry.LSystemPresetNames(), ", "))
+		os.Exit(1)
+	}
+	if sys.Rules == nil {
+		sys.Rules = make(map[rune]string)
+	}
+	for symbol, body := range extra {
+		sys.Rules[symbol] = body
+	}
+
+	figura, err := geometry.LSystemFigure(sys)
+	if err != nil {
+		log.Fatalf("Erro ao gerar L-system: %v", err)
+	}
+	saveGenerated(figura, output)
+}
+
+// generateTerrain gera o terreno em arame de um mapa de alturas (ver
+// geometry.Terrain) e grava a figura em YAML, como generatePrimitive.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a imagem e as opções)
+func generateTerrain(args []string) {
+	params := geometry.DefaultTerrainParams()
+	var resolution, output string
+
+	fs := flag.NewFlagSet("gen-terrain", flag.ExitOnError)
+	fs.StringVar(&resolution, "resolution", "", "divisões da grade: n, ou nx x ny como 80x40 (padrão: 64 no lado mais longo)")
+	fs.Float64Var(&params.Width, "width", params.Width, "largura do terreno (X)")
+	fs.Float64Var(&params.Depth, "depth", 0, "profundidade do terreno (Y) (padrão: a da proporção da imagem)")
+	fs.Float64Var(&params.Height, "height", params.Height, "altura dos pontos brancos (escala do relevo)")
+	fs.BoolVar(&params.Invert, "invert", false, "escuro é alto e claro é baixo")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 1 {
+		fmt.Println("Erro: especifique a imagem do mapa de alturas (PNG, JPEG ou GIF)")
+		fmt.Println("Uso: figuras3d gen-terrain [opções] <imagem>")
+		os.Exit(1)
+	}
+	if resolution != "" {
+		var err error
+		if params.XSteps, params.YSteps, err = parseResolution(resolution); err != nil {
+			log.Fatalf("Erro nas opções: --resolution: %v", err)
+		}
+	}
+
+	img, err := loadHeightmap(files[0])
+	if err != nil {
+		log.Fatalf("Erro ao carregar mapa de alturas: %v", err)
+	}
+	figura, err := geometry.Terrain(img, params)
+	if err != nil {
+		log.Fatalf("Erro ao gerar terreno: %v", err)
+	}
+	figura.Nome = strings.TrimSuffix(filepath.Base(files[0]), filepath.Ext(files[0]))
+	saveGenerated(figura, output)
+}
+
+// generateRandom gera uma figura aleatória (ver geometry.Random) e
+// grava a figura em YAML, como generatePrimitive.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (as opções)
+func generateRandom(args []string) {
+	params := geometry.DefaultRandomParams()
+	var output string
+
+	fs := flag.NewFlagSet("gen-random", flag.ExitOnError)
+	fs.IntVar(&params.Points, "points", params.Points, "número de pontos")
+	fs.IntVar(&params.Lines, "lines", params.Lines, "número de linhas")
+	fs.IntVar(&params.Faces, "faces", params.Faces, "número de faces triangulares")
+	fs.IntVar(&params.Clusters, "clusters", params.Clusters, "aglomerados de pontos (0 = espalhados por igual)")
+	fs.Float64Var(&params.Size, "size", params.Size, "aresta do cubo em que os pontos caem")
+	fs.Int64Var(&params.Seed, "seed", params.Seed, "semente (a mesma semente gera a mesma figura)")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fmt.Println("Erro: gen-random não recebe argumentos além das opções:", strings.Join(fs.Args(), " "))
+		fmt.Println("Uso: figuras3d gen-random [--points n] [--lines n] [--faces n] [--clusters n] [opções]")
+		os.Exit(1)
+	}
+
+	figura, err := geometry.Random(params)
+	if err != nil {
+		log.Fatalf("Erro ao gerar figura aleatória: %v", err)
+	}
+	saveGenerated(figura, output)
+}
+
+// weldFigure funde os pontos repetidos de uma figura (ver
+// types.Figure.Weld) e grava o resultado em YAML, como simplifyFigure.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a figura e as opções)
+func weldFigure(args []string) {
+	epsilon := 1e-6
+	var output string
+
+	fs := flag.NewFlagSet("weld", flag.ExitOnError)
+	fs.Float64Var(&epsilon, "epsilon", epsilon, "distância máxima entre os pontos fundidos (0 = só os idênticos)")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 1 {
+		fmt.Println("Erro: especifique a figura")
+		fmt.Println("Uso: figuras3d weld [--epsilon e] [-o saida.yaml] <arquivo.yaml>")
+		os.Exit(1)
+	}
+
+	figura, err := core.LoadFigureFromYAML(files[0])
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	points, lines, faces := len(figura.Pontos), len(figura.Linhas), len(figura.Faces)
+	if _, err := figura.Weld(epsilon); err != nil {
+		log.Fatalf("Erro ao fundir pontos: %v", err)
+	}
+	// Na saída de erros, para não se misturar ao YAML na saída padrão
+	fmt.Fprintf(os.Stderr, "Pontos: %d → %d, linhas: %d → %d, faces: %d → %d\n",
+		points, len(figura.Pontos), lines, len(figura.Linhas), faces, len(figura.Faces))
+	saveGenerated(figura, output)
+}
+
+// simplifyFigure simplifica uma figura densa (ver geometry.Simplify e
+// geometry.SimplifyToEdges) e grava o resultado em YAML, como os
+// comandos gen-*.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a figura e as opções)
+func simplifyFigure(args []string) {
+	var edges int
+	var tolerance float64
+	var output string
+
+	fs := flag.NewFlagSet("simplify", flag.ExitOnError)
+	fs.IntVar(&edges, "edges", 0, "número máximo de arestas da figura simplificada")
+	fs.Float64Var(&tolerance, "tolerance", 0, "tamanho dos cubos em que os pontos se fundem (unidades da figura)")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 1 || (edges == 0) == (tolerance == 0) {
+		fmt.Println("Erro: especifique a figura e uma das opções --edges ou --tolerance")
+		fmt.Println("Uso: figuras3d simplify (--edges n | --tolerance t) [-o saida.yaml] <arquivo.yaml>")
+		os.Exit(1)
+	}
+
+	original, err := core.LoadFigureFromYAML(files[0])
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	var figura *types.Figure
+	if edges != 0 {
+		figura, tolerance, err = geometry.SimplifyToEdges(original, edges)
+	} else {
+		figura, err = geometry.Simplify(original, tolerance)
+	}
+	if err != nil {
+		log.Fatalf("Erro ao simplificar: %v", err)
+	}
+	// Na saída de erros, para não se misturar ao YAML na saída padrão
+	fmt.Fprintf(os.Stderr, "Arestas: %d → %d, pontos: %d → %d (tolerância %g)\n",
+		geometry.EdgeCount(original), geometry.EdgeCount(figura), len(original.Pontos), len(figura.Pontos), tolerance)
+	saveGenerated(figura, output)
+}
+
+// importBASIC lê os DATA de uma listagem BASIC, como as do artigo
+// para o HP-85, e grava a figura em YAML, como os comandos gen-* (ver
+// core.ParseBASIC). Sem nome nos DATA, a figura recebe o do arquivo.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (a listagem e as opções)
+func importBASIC(args []string) {
+	opts := core.BASICOptions{Base: -1}
+	var base, output string
+
+	fs := flag.NewFlagSet("import-basic", flag.ExitOnError)
+	fs.IntVar(&opts.From, "from", 0, "primeira linha do programa cujos DATA são lidos (padrão: todas)")
+	fs.StringVar(&base, "base", "auto", "número do primeiro ponto nas linhas: 0, 1 ou auto")
+	fs.StringVar(&output, "output", "", "arquivo YAML da figura (padrão: saída padrão)")
+	fs.StringVar(&output, "o", "", "o mesmo que --output")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 1 {
+		fmt.Println("Erro: especifique a listagem")
+		fmt.Println("Uso: figuras3d import-basic [--from linha] [--base 0|1] [-o saida.yaml] <listagem.bas>")
+		os.Exit(1)
+	}
+	opts.Base = basicBase(base)
+
+	figura := readBASIC(files[0], opts)
+	// Na saída de erros, para não se misturar ao YAML na saída padrão
+	fmt.Fprintf(os.Stderr, "Pontos: %d, linhas: %d", len(figura.Pontos), len(figura.Linhas))
+	if figura.Camera.Auto {
+		fmt.Fprintf(os.Stderr, " (sem câmera nos DATA: enquadramento automático)")
+	}
+	fmt.Fprintln(os.Stderr)
+	saveGenerated(figura, output)
+}
+
+// basicBase interpreta a opção --base das listagens BASIC.
+//
+// Parâmetros:
+//   value: "0", "1" ou "auto"
+//
+// Retorna:
+//   int: base dos números dos pontos (-1 = detectar, ver core.BASICOptions)
+func basicBase(value string) int {
+	switch value {
+	case "auto":
+		return -1
+	case "0", "1":
+		base, _ := strconv.Atoi(value)
+		return base
+	}
+	log.Fatalf("Erro nas opções: base inválida: %q (use 0, 1 ou auto)", value)
+	return -1
+}
+
+// readBASIC lê a figura dos DATA de uma listagem BASIC (ver
+// core.ParseBASIC); sem nome nos DATA, a figura recebe o do arquivo.
+//
+// Parâmetros:
+//   filename: caminho da listagem
+//   opts: primeira linha lida e base dos números dos pontos
+//
+// Retorna:
+//   *types.Figure: figura lida
+func readBASIC(filename string, opts core.BASICOptions) *types.Figure {
+	listing, err := os.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Erro ao ler listagem: %v", err)
+	}
+	figura, err := core.ParseBASIC(listing, opts)
+	if err != nil {
+		log.Fatalf("Erro na listagem: %v", err)
+	}
+	if figura.Nome == "" {
+		figura.Nome = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	return figura
+}
+
+// convertFigure converte uma figura de um formato para outro, passando
+// pela figura do programa: lê o YAML ou os DATA de uma listagem BASIC e
+// grava o YAML (ver saveGenerated) ou qualquer formato do export e do
+// html (ver writeExport). Os formatos vêm de --from e --to ou das
+// extensões dos arquivos; "-" como saída é a saída padrão.
+//
+// Parâmetros:
+//   args: argumentos após o subcomando (os dois arquivos e as opções)
+func convertFigure(args []string) {
+	var from, to, base string
+	basic := core.BASICOptions{}
+
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	fs.StringVar(&from, "from", "", "formato da entrada: yaml ou basic (padrão: pela extensão)")
+	fs.StringVar(&to, "to", "", "formato da saída: yaml, dxf, eps, pov, threejs, csv, gnuplot, basic ou html (padrão: pela extensão)")
+	fs.IntVar(&basic.From, "basic-from", 0, "primeira linha do programa BASIC cujos DATA são lidos (padrão: todas)")
+	fs.StringVar(&base, "basic-base", "auto", "número do primeiro ponto nas linhas da listagem BASIC: 0, 1 ou auto")
+
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 2 {
+		fmt.Println("Erro: especifique a figura de entrada e o arquivo de saída")
+		fmt.Println("Uso: figuras3d convert [--from formato] [--to formato] [--basic-from linha] [--basic-base 0|1] <entrada> <saída|->")
+		os.Exit(1)
+	}
+	input, output := files[0], files[1]
+	if output == "-" {
+		output = ""
+	}
+	basic.Base = basicBase(base)
+
+	from = exportFormat(from, input)
+	switch from {
+	case "yaml", "basic":
+	case "":
+		log.Fatalf("Erro nas opções: especifique o formato da entrada com --from yaml ou basic, ou pela extensão")
+	default:
+		log.Fatalf("Erro nas opções: formato de entrada desconhecido: %q (use yaml ou basic)", from)
+	}
+	to = exportFormat(to, output)
+	switch to {
+	case "yaml", "dxf", "eps", "pov", "threejs", "csv", "gnuplot", "basic", "html":
+	case "":
+		log.Fatalf("Erro nas opções: especifique o formato da saída com --to, ou pela extensão")
+	default:
+		log.Fatalf("Erro nas opções: formato de saída desconhecido: %q (use yaml, dxf, eps, pov, threejs, csv, gnuplot, basic ou html)", to)
+	}
+	if to == "gnuplot" && output == "" {
+		log.Fatalf("Erro nas opções: o formato gnuplot grava dois arquivos; especifique o nome da saída")
+	}
+
+	var figura *types.Figure
+	if from == "basic" {
+		figura = readBASIC(input, basic)
+	} else {
+		var err error
+		if figura, err = core.LoadFigureFromYAML(input); err != nil {
+			log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+		}
+	}
+	if to == "yaml" {
+		saveGenerated(figura, output)
+		return
+	}
+	writeExport(figura, to, output)
+}
+
+// infoFigure mostra as contagens e a topologia de uma figura (ver
+// geometry.MeshStatistics): partes desconexas, pontos isolados, bordas
+// e arestas de mais de duas faces, para achar os índices errados de um
+// YAML escrito à mão ou os defeitos de uma malha importada.
+//
+// Parâmetros:
+//   args: argumentos após o nome do comando (o arquivo da figura)
+func infoFigure(args []string) {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	var files []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		files = append(files, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(files) != 1 {
+		fmt.Println("Erro: especifique a figura")
+		fmt.Println("Uso: figuras3d info <arquivo.yaml>")
+		os.Exit(1)
+	}
+
+	figura, err := core.LoadFigureFromYAML(files[0])
+	if err != nil {
+		log.Fatalf("Erro ao carregar arquivo YAML: %v", err)
+	}
+	stats := geometry.MeshStatistics(figura)
+
+	fmt.Printf("Figura: %s\n", figura.Nome)
+	fmt.Printf("Pontos: %d\n", stats.Vertices)
+	if figura.EdgesFromFaces {
+		fmt.Printf("Linhas: %d (derivadas das faces)\n", len(figura.Linhas))
+	} else {
+		fmt.Printf("Linhas: %d\n", len(figura.Linhas))
+	}
+	fmt.Printf("Faces: %d\n", stats.Faces)
+	if len(figura.Medidas) > 0 {
+		fmt.Printf("Medidas: %d\n", len(figura.Medidas))
+	}
+	if names := figura.CameraNames(); len(names) > 0 {
+		fmt.Printf("Câmeras: %s\n", strings.Join(names, ", "))
+	}
+	fmt.Printf("Arestas: %d\n", stats.Edges)
+	fmt.Printf("Característica de Euler: %d\n", stats.Euler)
+
+	switch {
+	case stats.Faces == 0:
+		fmt.Println("Superfície: sem faces")
+	case stats.Closed():
+		fmt.Println("Superfície: fechada")
+	default:
+		fmt.Println("Superfície: aberta")
+	}
+	edge := func(e [2]int) string { return fmt.Sprintf("%s-%s", pointLabel(figura, e[0]), pointLabel(figura, e[1])) }
+	if n := len(stats.BoundaryEdges); n > 0 && stats.Faces > 0 {
+		fmt.Printf("Arestas de borda: %d (%s)\n", n, briefList(n, func(i int) string { return edge(stats.BoundaryEdges[i]) }))
+	}
+	if n := len(stats.NonManifoldEdges); n > 0 {
+		fmt.Printf("Arestas de mais de duas faces: %d (%s)\n", n, briefList(n, func(i int) string { return edge(stats.NonManifoldEdges[i]) }))
+	}
+
+	parts := geometry.Components(figura)
+	if len(parts) > 1 {
+		fmt.Printf("Partes: %d (%s pontos)\n", len(parts), briefList(len(parts), func(i int) string { return strconv.Itoa(len(parts[i])) }))
+	} else {
+		fmt.Printf("Partes: %d\n", len(parts))
+	}
+	if n := len(stats.IsolatedPoints); n > 0 {
+		fmt.Printf("Pontos isolados: %d (%s)\n", n, briefList(n, func(i int) string { return pointLabel(figura, stats.IsolatedPoints[i]) }))
+	} else {
+		fmt.Println("Pontos isolados: nenhum")
+	}
+}
+
+// lintFigures examina arquivos de figura (ver core.LintFigure) e lista
+// os problemas, um por linha, no formato "arquivo: gravidade: mensagem".
+// Um diretório vale pelos seus arquivos .yaml e .yml, como em montage.
+// Termina com código 1 se houver algum problema, para uso em integração
+// contínua.
+//
+// Parâmetros:
+//   args: argumentos após o nome do comando (arquivos e diretórios)
+func lintFigures(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	var paths []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		paths = append(paths, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(paths) == 0 {
+		fmt.Println("Erro: especifique os arquivos ou diretórios")
+		fmt.Println("Uso: figuras3d lint <arquivo.yaml|diretório>...")
+		os.Exit(1)
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			files = append(files, path) // O erro de leitura sai como problema
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			log.Fatalf("Erro ao ler diretório: %v", err)
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	problems, failed := 0, 0
+	for _, file := range files {
+		findings := core.LintFigure(file)
+		for _, finding := range findings {
+			fmt.Printf("%s: %s: %s\n", file, finding.Level, finding.Message)
+		}
+		problems += len(findings)
+		if len(findings) > 0 {
+			failed++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Figuras: %d, com problemas: %d, problemas: %d\n", len(files), failed, problems)
+	if problems > 0 {
+		os.Exit(1)
+	}
+}
+
+// pointLabel identifica um ponto pelo nome ou, se não tiver, pelo índice.
+func pointLabel(figura *types.Figure, p int) string {
+	if name := figura.Pontos[p].Nome; name != "" {
+		return name
+	}
+	return strconv.Itoa(p)
+}
+
+// briefList junta os primeiros itens de uma lista, separados por
+// vírgulas, terminando com reticências quando há mais de dez.
+func briefList(n int, item func(i int) string) string {
+	const shown = 10
+	items := make([]string, 0, min(n, shown+1))
+	for i := 0; i < min(n, shown); i++ {
+		items = append(items, item(i))
+	}
+	if n > shown {
+		items = append(items, "...")
+	}
+	return strings.Join(items, ", ")
+}
+
+// loadHeightmap lê a imagem de um mapa de alturas (PNG, JPEG ou GIF).
+func loadHeightmap(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return img, nil
+}
+
+// parseRange converte um intervalo "início,fim"; cada extremo pode ser
+// uma expressão constante, como -pi ou 2*pi.
+func parseRange(value string) (float64, float64, error) {
+	from, to, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("intervalo inválido: %q (use início,fim, como -3,3)", value)
+	}
+	var bounds [2]float64
+	for i, text := range []string{from, to} {
+		f, err := geometry.ParseExpression(text)
+		if err != nil {
+			return 0, 0, err
+		}
+		bounds[i] = f(0, 0)
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// parseResolution converte as divisões da grade: "n" para os dois eixos
+// ou "nxm" para x e y.
+func parseResolution(value string) (int, int, error) {
+	nx, ny, both := strings.Cut(strings.ToLower(value), "x")
+	x, err := strconv.Atoi(strings.TrimSpace(nx))
+	if err != nil {
+		return 0, 0, fmt.Errorf("resolução inválida: %q (use n ou nxm, como 30 ou 40x20)", value)
+	}
+	if !both {
+		return x, x, nil
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(ny))
+	if err != nil {
+		return 0, 0, fmt.Errorf("resolução inválida: %q (use n ou nxm, como 30 ou 40x20)", value)
+	}
+	return x, y, nil
+}
+
+// saveGenerated grava uma figura gerada (pelos comandos gen-*, weld e
+// simplify) em YAML, com um comentário inicial com o comando que a
+// gerou, num arquivo ou, sem arquivo, na saída padrão.
+//
+// Parâmetros:
+//   figura: figura gerada
+//   output: caminho do arquivo ("" = saída padrão)
+func saveGenerated(figura *types.Figure, output string) {
+	data, err := core.MarshalFigure(figura)
+	if err != nil {
+		log.Fatalf("Erro ao converter figura: %v", err)
+	}
+	command := make([]string, len(os.Args)-1)
+	for i, arg := range os.Args[1:] {
+		// Entre aspas o que o shell separaria, como "sin(x) * cos(y)"
+		if strings.ContainsAny(arg, " \t*()^'\"$&|;<>[]?{}\\!#~`") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		command[i] = arg
+	}
+	text := fmt.Sprintf("# Gerado por: figuras3d %s\n", strings.Join(command, " ")) + string(data)
+
+	if output == "" {
+		fmt.Print(text)
+		return
+	}
+	if err := os.WriteFile(output, []byte(text), 0644); err != nil {
+		log.Fatalf("Erro ao salvar figura: %v", err)
+	}
+	fmt.Printf("Figura salva: %s (%d pontos, %d linhas, %d faces)\n", output, len(figura.Pontos), len(figura.Linhas), len(figura.Faces))
+}
+
+// renderTiledPNG gera uma imagem muito grande em faixas horizontais,
+// sem alocar a tela inteira em memória (ver renderer.TiledImage).
+//
+// Parâmetros:
+//   figura: figura 3D a ser renderizada
+//   opts: opções de linha de comando
+//   baseName: nome base do arquivo de saída (sem extensão)
+//   width, height: dimensões da imagem em pixels
+//   renderCfg: configurações visuais já convertidas
+//   saveOpts: formato e metadados do arquivo (ver pngMetadata)
+func renderTiledPNG(figura *types.Figure, opts options, baseName string, width, height int, renderCfg renderer.RenderConfig, saveOpts renderer.SaveOptions) {
+	if opts.autoCrop {
+		log.Fatalf("Erro nas opções: --autocrop não está disponível para imagens em faixas (%d×%d pixels)", width, height)
+	}
+	if opts.crt != "" {
+		log.Fatalf("Erro nas opções: --crt não está disponível para imagens em faixas (%d×%d pixels)", width, height)
+	}
+	if opts.palette != "" {
+		log.Fatalf("Erro nas opções: --palette não está disponível para imagens em faixas (%d×%d pixels)", width, height)
+	}
+	if opts.terminal != "" {
+		log.Fatalf("Erro nas opções: --terminal não está disponível para imagens em faixas (%d×%d pixels)", width, height)
+	}
+	if opts.animated() {
+		log.Fatalf("Erro nas opções: animações não estão disponíveis para imagens em faixas (%d×%d pixels)", width, height)
+	}
+
+	tiled, err := renderer.NewTiledImage(width, height, 0, figura, renderCfg, nil)
+	if err != nil {
+		log.Fatalf("Erro ao renderizar figura: %v", err)
+	}
+
+	if opts.hasRegion() {
+		if err := tiled.SetRegion(opts.region(tiled.VirtualScreen())); err != nil {
+			log.Fatalf("Erro nas opções: %v", err)
+		}
+		baseName += "_regiao"
+	}
+
+	fmt.Printf("Renderizando %d×%d em faixas...\n", width, height)
+	outputFile := fmt.Sprintf("output/%s%s", baseName, saveOpts.Format.Extension())
+	if err := tiled.Save(outputFile, saveOpts); err != nil {
+		log.Fatalf("Erro ao salvar imagem: %v", err)
+	}
+
 	fmt.Printf("Imagem salva: %s\n", outputFile)
-	fmt.Println("Dica: Use 'figuras3d view' para visualizar interativo!")
 }
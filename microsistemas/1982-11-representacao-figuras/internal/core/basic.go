@@ -0,0 +1,297 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"representacao-figuras/pkg/types"
+)
+
+// BASICOptions controla a leitura dos DATA de uma listagem BASIC.
+type BASICOptions struct {
+	// Primeira linha do programa cujos DATA são lidos (0 = todas), para
+	// pular os DATA que não são da figura, como os de um menu
+	From int
+
+	// Base dos números dos pontos nas linhas: 0 ou 1 (-1 = detectar: 0
+	// se algum ponto é 0, senão 1, como nos vetores do HP-85)
+	Base int
+}
+
+// basicValue é um valor de um DATA, com a linha do programa onde está.
+type basicValue struct {
+	text   string
+	number float64
+	isText bool // Texto entre aspas, ou que não é um número
+	line   int
+}
+
+// ParseBASIC lê os DATA de uma listagem BASIC, como as do artigo para
+// o HP-85, e monta a figura que eles descrevem.
+//
+// As linhas são ordenadas pelo número, e uma linha repetida substitui a
+// anterior, como ao digitá-las no BASIC. Os comandos de uma linha são
+// separados por @ (HP-85) ou dois-pontos; REM e ! começam comentários.
+// Os valores de todos os DATA, na ordem em que READ os leria, são
+// interpretados como:
+//   [nome] N, N × (X, Y, Z), M, M × (ponto inicial, ponto final)
+//   [V.X, V.Y, V.Z [, R [, L1, L2]]]
+// isto é: um texto opcional com o nome da figura, o número de pontos e
+// as coordenadas de cada um, o número de linhas e os pontos de cada
+// uma, e, opcionalmente, o observador V, a distância R e a tela virtual
+// L1×L2 da câmera. Sem câmera, a figura usa o enquadramento automático.
+//
+// Parâmetros:
+//   listing: texto da listagem
+//   opts: primeira linha lida e base dos números dos pontos
+//
+// Retorna:
+//   *types.Figure: figura lida
+//   error: erro de sintaxe, valores faltando ou sobrando, ou pontos
+//          inexistentes, com a linha do programa
+func ParseBASIC(listing []byte, opts BASICOptions) (*types.Figure, error) {
+	values, err := basicData(listing, opts.From)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("a listagem não tem DATA")
+	}
+
+	figure := &types.Figure{Camera: types.Camera{Auto: true}}
+	next := 0
+	if values[0].isText {
+		figure.Nome = values[0].text
+		next++
+	}
+	read := func(what string) (basicValue, error) {
+		if next >= len(values) {
+			return basicValue{}, fmt.Errorf("faltam valores nos DATA: esperava %s", what)
+		}
+		v := values[next]
+		next++
+		if v.isText {
+			return v, fmt.Errorf("linha %d: esperava %s, encontrou %q", v.line, what, v.text)
+		}
+		return v, nil
+	}
+	count := func(what string) (int, error) {
+		v, err := read(what)
+		if err != nil {
+			return 0, err
+		}
+		if v.number < 1 || v.number != float64(int(v.number)) {
+			return 0, fmt.Errorf("linha %d: %s deve ser um inteiro positivo: %s", v.line, what, v.text)
+		}
+		return int(v.number), nil
+	}
+
+	points, err := count("o número de pontos")
+	if err != nil {
+		return nil, err
+	}
+	for i := range points {
+		var xyz [3]float64
+		for k, axis := range []string{"X", "Y", "Z"} {
+			v, err := read(fmt.Sprintf("o %s do ponto %d", axis, i+1))
+			if err != nil {
+				return nil, err
+			}
+			xyz[k] = v.number
+		}
+		figure.Pontos = append(figure.Pontos, types.Point3D{X: xyz[0], Y: xyz[1], Z: xyz[2]})
+	}
+
+	lines, err := count("o número de linhas")
+	if err != nil {
+		return nil, err
+	}
+	if 2*lines > len(values)-next {
+		return nil, fmt.Errorf("faltam valores nos DATA: %d linhas pedem %d pontos, há só %d valores", lines, 2*lines, len(values)-next)
+	}
+	ends := make([]basicValue, 2*lines)
+	for i := range ends {
+		if ends[i], err = read(fmt.Sprintf("o ponto %d da linha %d", i%2+1, i/2+1)); err != nil {
+			return nil, err
+		}
+		if ends[i].number != float64(int(ends[i].number)) {
+			return nil, fmt.Errorf("linha %d: ponto inválido: %s", ends[i].line, ends[i].text)
+		}
+	}
+	base := opts.Base
+	if base < 0 {
+		base = 1
+		for _, v := range ends {
+			if v.number == 0 {
+				base = 0
+			}
+		}
+	}
+	for i := 0; i < len(ends); i += 2 {
+		p1, p2 := int(ends[i].number)-base, int(ends[i+1].number)-base
+		for _, end := range []struct {
+			index int
+			value basicValue
+		}{{p1, ends[i]}, {p2, ends[i+1]}} {
+			if end.index < 0 || end.index >= points {
+				return nil, fmt.Errorf("linha %d: a linha %d usa o ponto %s, mas os pontos vão de %d a %d",
+					end.value.line, i/2+1, end.value.text, base, points-1+base)
+			}
+		}
+		figure.Linhas = append(figure.Linhas, types.Line{P1: p1, P2: p2})
+	}
+
+	// Câmera opcional: observador, distância e tela virtual
+	var camera []float64
+	for next < len(values) {
+		v, err := read("os valores da câmera")
+		if err != nil {
+			return nil, err
+		}
+		camera = append(camera, v.number)
+	}
+	switch len(camera) {
+	case 0:
+	case 3, 4, 6:
+		figure.Camera = types.DefaultCamera()
+		figure.Camera.Observer = types.Point3D{X: camera[0], Y: camera[1], Z: camera[2]}
+		if len(camera) >= 4 {
+			figure.Camera.Distance = camera[3]
+		}
+		if len(camera) == 6 {
+			figure.Camera.Width, figure.Camera.Height = camera[4], camera[5]
+		}
+	default:
+		return nil, fmt.Errorf("linha %d: sobram %d valores nos DATA depois das linhas (a câmera tem 3, 4 ou 6: V, R, L1 e L2)",
+			values[len(values)-1].line, len(camera))
+	}
+
+	if err := validateFigure(figure); err != nil {
+		return nil, err
+	}
+	return figure, nil
+}
+
+// basicData junta os valores dos DATA de uma listagem, na ordem das
+// linhas do programa.
+//
+// Parâmetros:
+//   listing: texto da listagem
+//   from: primeira linha do programa lida (0 = todas)
+//
+// Retorna:
+//   []basicValue: valores dos DATA
+//   error: erro se uma linha não começa pelo número ou tem aspas abertas
+func basicData(listing []byte, from int) ([]basicValue, error) {
+	program := make(map[int]string)
+	scanner := bufio.NewScanner(bytes.NewReader(listing))
+	for row := 1; scanner.Scan(); row++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		digits := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+		if digits < 0 {
+			digits = len(text)
+		}
+		number, err := strconv.Atoi(text[:digits])
+		if err != nil {
+			return nil, fmt.Errorf("linha %d do arquivo não começa pelo número da linha do programa: %q", row, text)
+		}
+		program[number] = text[digits:] // A última digitada vale
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler listagem: %w", err)
+	}
+
+	numbers := make([]int, 0, len(program))
+	for number := range program {
+		if number >= from {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	var values []basicValue
+	for _, number := range numbers {
+		statements, err := basicStatements(program[number])
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", number, err)
+		}
+		for _, statement := range statements {
+			if len(statement) < 4 || !strings.EqualFold(statement[:4], "DATA") {
+				continue
+			}
+			for _, item := range basicItems(statement[4:]) {
+				item = strings.TrimSpace(item)
+				v := basicValue{text: item, line: number}
+				if unquoted, ok := strings.CutPrefix(item, `"`); ok {
+					v.text, v.isText = strings.TrimSuffix(unquoted, `"`), true
+				} else if v.number, err = strconv.ParseFloat(item, 64); err != nil || math.IsInf(v.number, 0) || math.IsNaN(v.number) {
+					v.isText = true // Texto sem aspas, que o BASIC também aceita
+				}
+				values = append(values, v)
+			}
+		}
+	}
+	return values, nil
+}
+
+// basicStatements separa os comandos de uma linha do programa (sem o
+// número), sem os comentários.
+func basicStatements(line string) ([]string, error) {
+	var statements []string
+	add := func(statement string) bool {
+		statement = strings.TrimSpace(statement)
+		if len(statement) >= 3 && strings.EqualFold(statement[:3], "REM") {
+			return false // O comentário vai até o fim da linha
+		}
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+		return true
+	}
+	quoted, start := false, 0
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '!':
+			add(line[start:i])
+			return statements, nil
+		case r == '@' || r == ':':
+			if !add(line[start:i]) {
+				return statements, nil
+			}
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("aspas sem fechar")
+	}
+	add(line[start:])
+	return statements, nil
+}
+
+// basicItems separa os itens de um DATA nas vírgulas fora das aspas.
+func basicItems(text string) []string {
+	var items []string
+	quoted, start := false, 0
+	for i, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			items = append(items, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, text[start:])
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// basicPyramid é uma listagem no estilo do HP-85: comandos separados
+// por @, comentários com ! e REM, e os DATA fora de ordem.
+const basicPyramid = `10 REM PIRAMIDE
+20 READ N @ DIM X(5),Y(5),Z(5) ! PONTOS
+30 FOR I=1 TO N @ READ X(I),Y(I),Z(I) @ NEXT I
+100 DATA "PIRAMIDE"
+110 DATA 5
+120 DATA -1,4,-1, 1,4,-1, 1,6,-1
+130 DATA -1,6,-1, 0,5,1 ! O TOPO
+150 DATA 1,2,2,3,3,4,4,1
+160 DATA 1,5,2,5,3,5,4,5
+140 DATA 8
+999 END
+`
+
+func TestParseBASIC(t *testing.T) {
+	figure, err := ParseBASIC([]byte(basicPyramid), BASICOptions{Base: -1})
+	if err != nil {
+		t.Fatalf("ParseBASIC failed: %v", err)
+	}
+	if figure.Nome != "PIRAMIDE" || len(figure.Pontos) != 5 || len(figure.Linhas) != 8 {
+		t.Fatalf("Expected PIRAMIDE with 5 points and 8 lines, got %q %d %d", figure.Nome, len(figure.Pontos), len(figure.Linhas))
+	}
+	if p := figure.Pontos[4]; p != (types.Point3D{X: 0, Y: 5, Z: 1}) {
+		t.Errorf("Expected the top at (0, 5, 1), got %+v", p)
+	}
+	// Pontos numerados a partir de 1, como nos vetores do HP-85
+	if l := figure.Linhas[7]; l.P1 != 3 || l.P2 != 4 {
+		t.Errorf("Expected last line 3-4, got %d-%d", l.P1, l.P2)
+	}
+	if !figure.Camera.Auto {
+		t.Error("Expected automatic framing without camera in DATA")
+	}
+
+	// Linha digitada de novo: vale a última
+	retyped := basicPyramid + "130 DATA -1,6,-1, 0,5,2\n"
+	if figure, err = ParseBASIC([]byte(retyped), BASICOptions{Base: -1}); err != nil || figure.Pontos[4].Z != 2 {
+		t.Errorf("Expected the retyped line to replace the first, got %v, %v", figure.Pontos[4], err)
+	}
+}
+
+func TestParseBASICCamera(t *testing.T) {
+	listing := "10 DATA 2, 0,5,0, 1,5,0 : DATA 1, 0,1\n20 DATA 0,-10,0, 8, 6,4.5\n"
+	figure, err := ParseBASIC([]byte(listing), BASICOptions{Base: -1})
+	if err != nil {
+		t.Fatalf("ParseBASIC failed: %v", err)
+	}
+	if l := figure.Linhas[0]; l.P1 != 0 || l.P2 != 1 {
+		t.Errorf("Expected point 0 to select base 0, got %d-%d", l.P1, l.P2)
+	}
+	camera := figure.Camera
+	if camera.Auto || camera.Observer != (types.Point3D{Y: -10}) || camera.Distance != 8 || camera.Width != 6 || camera.Height != 4.5 {
+		t.Errorf("Expected V = (0, -10, 0), R = 8, L1×L2 = 6×4.5, got %+v", camera)
+	}
+
+	// Só o observador: R, L1 e L2 padrão
+	listing = "10 DATA 2, 0,5,0, 1,5,0, 1, 1,2, 3,-10,2\n"
+	if figure, err = ParseBASIC([]byte(listing), BASICOptions{Base: -1}); err != nil {
+		t.Fatalf("ParseBASIC failed: %v", err)
+	}
+	if want := types.DefaultCamera(); figure.Camera.Distance != want.Distance || figure.Camera.Observer.X != 3 {
+		t.Errorf("Expected default R with the observer, got %+v", figure.Camera)
+	}
+}
+
+func TestParseBASICOptions(t *testing.T) {
+	listing := "10 DATA \"MENU\", \"SAIR\"\n100 DATA 3, 0,5,0, 1,5,0, 1,5,1, 2, 1,2, 2,3\n"
+	if _, err := ParseBASIC([]byte(listing), BASICOptions{Base: -1}); err == nil {
+		t.Error("Expected error for the menu DATA read as the figure")
+	}
+	figure, err := ParseBASIC([]byte(listing), BASICOptions{From: 100, Base: -1})
+	if err != nil {
+		t.Fatalf("ParseBASIC failed: %v", err)
+	}
+	if l := figure.Linhas[1]; l.P1 != 1 || l.P2 != 2 {
+		t.Errorf("Expected line 1-2, got %d-%d", l.P1, l.P2)
+	}
+	// Com base 0 forçada, o ponto 3 não existe
+	if _, err := ParseBASIC([]byte(listing), BASICOptions{From: 100, Base: 0}); err == nil || !strings.Contains(err.Error(), "de 0 a 2") {
+		t.Errorf("Expected point 3 out of range with base 0, got %v", err)
+	}
+}
+
+func TestParseBASICErrors(t *testing.T) {
+	tests := []struct {
+		name, listing, want string
+	}{
+		{"no data", "10 PRINT \"OI\"\n", "não tem DATA"},
+		{"no line number", "DATA 1,2\n", "número da linha"},
+		{"open quote", "10 DATA \"CASA\n", "aspas"},
+		{"missing", "10 DATA 2, 0,0,0, 1,1\n", "o Z do ponto 2"},
+		{"text", "10 DATA 1, 0,O,0\n", `encontrou "O"`},
+		{"point count", "10 DATA 1.5\n", "número de pontos deve ser um inteiro positivo"},
+		{"huge line count", "10 DATA 1, 0,0,0, 1000000000\n", "1000000000 linhas"},
+		{"out of range", "30 DATA 2, 0,0,0, 1,1,1, 1, 1,3\n", "linha 30: a linha 1 usa o ponto 3, mas os pontos vão de 1 a 2"},
+		{"leftover", "10 DATA 2, 0,0,0, 1,1,1, 1, 1,2, 5,5\n", "sobram 2 valores"},
+	}
+	for _, tt := range tests {
+		_, err := ParseBASIC([]byte(tt.listing), BASICOptions{Base: -1})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error with %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
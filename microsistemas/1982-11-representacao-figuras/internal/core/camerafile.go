@@ -0,0 +1,100 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadCameraFile carrega uma câmera gravada por SaveCameraFile.
+//
+// O arquivo contém apenas os campos da câmera, com as mesmas chaves da
+// seção "camera" das figuras (observador, distancia, alvo...). Arquivos
+// JSON são lidos pelo mesmo parser, já que JSON é um subconjunto de YAML.
+// Como as figuras, a câmera pode vir de um caminho local ou de uma URL.
+//
+// Parâmetros:
+//   filename: caminho ou URL do arquivo de câmera (.yaml, .yml ou .json)
+//
+// Retorna:
+//   types.Camera: câmera completada com os padrões e validada
+//   error: erro de leitura, de parse ou de validação
+func LoadCameraFile(filename string) (types.Camera, error) {
+	data, err := readSource(filename, DefaultLoadLimits())
+	if err != nil {
+		return types.Camera{}, fmt.Errorf("erro ao ler arquivo de câmera: %w", err)
+	}
+
+	var camera types.Camera
+	if err := yaml.Unmarshal(data, &camera); err != nil {
+		return types.Camera{}, fmt.Errorf("erro ao parsear arquivo de câmera: %w", err)
+	}
+
+	if err := prepareCamera(&camera); err != nil {
+		return types.Camera{}, fmt.Errorf("câmera inválida: %w", err)
+	}
+	if err := validateCamera(&camera); err != nil {
+		return types.Camera{}, fmt.Errorf("câmera inválida: %w", err)
+	}
+
+	return camera, nil
+}
+
+// MarshalCamera converte uma câmera para YAML, com as mesmas chaves dos
+// arquivos de câmera: o texto pode ser gravado num arquivo e lido de volta
+// por LoadCameraFile.
+//
+// Parâmetros:
+//   camera: câmera a converter
+//
+// Retorna:
+//   []byte: câmera em YAML
+//   error: erro de conversão
+func MarshalCamera(camera types.Camera) ([]byte, error) {
+	data, err := yaml.Marshal(camera)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter câmera: %w", err)
+	}
+	return data, nil
+}
+
+// SaveCameraFile grava uma câmera em arquivo YAML ou JSON.
+//
+// O formato é escolhido pela extensão: ".json" grava JSON, qualquer outra
+// grava YAML. Para reproduzir exatamente um enquadramento, grave a câmera
+// efetiva (ver renderer.EffectiveCamera), sem órbita nem enquadramento
+// automático pendentes.
+//
+// Parâmetros:
+//   filename: caminho do arquivo de saída
+//   camera: câmera a gravar
+//
+// Retorna:
+//   error: erro de conversão ou de escrita
+func SaveCameraFile(filename string, camera types.Camera) error {
+	data, err := MarshalCamera(camera)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		// Passa pelo YAML para manter as mesmas chaves nos dois formatos
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("erro ao converter câmera: %w", err)
+		}
+		data, err = json.MarshalIndent(fields, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erro ao converter câmera: %w", err)
+		}
+		data = append(data, '\n')
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
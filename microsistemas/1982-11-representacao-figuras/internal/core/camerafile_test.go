@@ -0,0 +1,114 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestSaveCameraFile_RoundTrip(t *testing.T) {
+	target := types.Point3D{X: 1, Y: 2, Z: 3}
+	camera := types.Camera{
+		Observer:   types.Point3D{X: 4.25, Y: -7.5, Z: 2},
+		Distance:   6,
+		Width:      10,
+		Height:     7.5,
+		Target:     &target,
+		Projection: types.ProjectionConic,
+	}
+
+	for _, name := range []string{"camera.yaml", "camera.json"} {
+		t.Run(name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), name)
+			if err := SaveCameraFile(filename, camera); err != nil {
+				t.Fatalf("SaveCameraFile failed: %v", err)
+			}
+
+			loaded, err := LoadCameraFile(filename)
+			if err != nil {
+				t.Fatalf("LoadCameraFile failed: %v", err)
+			}
+			if !reflect.DeepEqual(loaded, camera) {
+				t.Errorf("Expected %+v, got %+v", camera, loaded)
+			}
+		})
+	}
+}
+
+func TestSaveCameraFile_JSONKeys(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "camera.json")
+	if err := SaveCameraFile(filename, types.DefaultCamera()); err != nil {
+		t.Fatalf("SaveCameraFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read camera file: %v", err)
+	}
+
+	// JSON deve usar as mesmas chaves da seção "camera" do YAML
+	for _, key := range []string{`"observador"`, `"distancia"`, `"largura"`, `"altura"`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("Expected JSON to contain key %s, got:\n%s", key, data)
+		}
+	}
+}
+
+func TestLoadCameraFile_Defaults(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "camera.yaml")
+	content := `orbita: {azimute: 90, elevacao: 0, raio: 5}
+fov: 60`
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create camera file: %v", err)
+	}
+
+	camera, err := LoadCameraFile(filename)
+	if err != nil {
+		t.Fatalf("LoadCameraFile failed: %v", err)
+	}
+
+	// Campos ausentes são completados como nas figuras
+	if camera.Distance != types.DefaultCamera().Distance {
+		t.Errorf("Expected default distance, got %g", camera.Distance)
+	}
+	if camera.Target == nil || camera.Observer.X != 5 {
+		t.Errorf("Expected orbit to be resolved, got %+v", camera)
+	}
+}
+
+func TestLoadCameraFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"bad yaml", "observador: [1, 2", "erro ao parsear"},
+		{"bad fov", "fov: 200", "fov da câmera inválido"},
+		{"bad view", "vista: inexistente", "vista desconhecida"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "camera.yaml")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create camera file: %v", err)
+			}
+
+			_, err := LoadCameraFile(filename)
+			if err == nil {
+				t.Fatalf("Expected error containing '%s', got nil", tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tt.errMsg, err.Error())
+			}
+		})
+	}
+
+	if _, err := LoadCameraFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Camadas do arquivo DXF: as linhas da figura e as arestas das faces
+// ficam separadas, para que possam ser escondidas uma de cada vez no CAD.
+const (
+	dxfLayerLines = "LINHAS"
+	dxfLayerFaces = "FACES"
+)
+
+// MarshalDXF converte uma figura para DXF (versão R12, em texto), com
+// uma entidade LINE 3D por aresta, para que a geometria seja aberta e
+// continue a ser trabalhada em programas de CAD como o AutoCAD e o
+// LibreCAD.
+//
+// As coordenadas são as da figura, sem projeção: o eixo Z, vertical na
+// figura, é também o vertical no CAD. As linhas da figura ficam na camada
+// LINHAS, e as arestas das faces, cada uma uma vez só, na camada FACES
+// (sem as que repetem uma linha reta); as curvas de Bézier viram
+// CurveSegments trechos retos. Setas, cores, nomes e cotas ficam de fora.
+//
+// Parâmetros:
+//   figure: figura a converter
+//
+// Retorna:
+//   []byte: arquivo DXF
+func MarshalDXF(figure *types.Figure) []byte {
+	var buf bytes.Buffer
+	group := func(code int, value string) {
+		fmt.Fprintf(&buf, "%d\n%s\n", code, value)
+	}
+	point := func(code int, p types.Point3D) {
+		group(code, dxfNumber(p.X))
+		group(code+10, dxfNumber(p.Y))
+		group(code+20, dxfNumber(p.Z))
+	}
+
+	// Cabeçalho: a versão e a extensão do desenho, para o "zoom" inicial
+	low, high := figure.Bounds()
+	group(0, "SECTION")
+	group(2, "HEADER")
+	group(9, "$ACADVER")
+	group(1, "AC1009")
+	group(9, "$EXTMIN")
+	point(10, low)
+	group(9, "$EXTMAX")
+	point(10, high)
+	group(0, "ENDSEC")
+
+	// Tabelas: o tipo de linha contínua e as camadas, ambas na cor 7
+	// (branca no fundo escuro, preta no claro)
+	group(0, "SECTION")
+	group(2, "TABLES")
+	group(0, "TABLE")
+	group(2, "LTYPE")
+	group(70, "1")
+	group(0, "LTYPE")
+	group(2, "CONTINUOUS")
+	group(70, "0")
+	group(3, "Solid line")
+	group(72, "65")
+	group(73, "0")
+	group(40, "0.0")
+	group(0, "ENDTAB")
+	group(0, "TABLE")
+	group(2, "LAYER")
+	group(70, "2")
+	for _, name := range []string{dxfLayerLines, dxfLayerFaces} {
+		group(0, "LAYER")
+		group(2, name)
+		group(70, "0")
+		group(62, "7")
+		group(6, "CONTINUOUS")
+	}
+	group(0, "ENDTAB")
+	group(0, "ENDSEC")
+
+	group(0, "SECTION")
+	group(2, "ENTITIES")
+	line := func(layer string, a, b types.Point3D) {
+		group(0, "LINE")
+		group(8, layer)
+		point(10, a)
+		point(11, b)
+	}
+
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			curve := linha.Curve(figure.Pontos)
+			for i := 1; i < len(curve); i++ {
+				line(dxfLayerLines, curve[i-1], curve[i])
+			}
+			if len(linha.Controle) == 0 {
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+			}
+		}
+	}
+	for _, edge := range figure.FaceEdges() {
+		if !drawn[[2]int{min(edge.P1, edge.P2), max(edge.P1, edge.P2)}] {
+			line(dxfLayerFaces, figure.Pontos[edge.P1], figure.Pontos[edge.P2])
+		}
+	}
+	group(0, "ENDSEC")
+	group(0, "EOF")
+	return buf.Bytes()
+}
+
+// dxfNumber escreve um número em notação decimal, sem expoente, que
+// alguns leitores de DXF não aceitam.
+func dxfNumber(v float64) string {
+	return strconv.FormatFloat(v+0, 'f', -1, 64) // +0: -0 vira 0
+}
+
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/geometry"
+	"representacao-figuras/pkg/types"
+)
+
+// dxfLine é uma entidade LINE lida de volta do DXF.
+type dxfLine struct {
+	layer  string
+	coords [6]float64 // x1, y1, z1, x2, y2, z2
+}
+
+// readDXFLines lê os pares de código e valor do DXF e retorna as
+// entidades LINE, conferindo a estrutura das seções.
+func readDXFLines(t *testing.T, data []byte) []dxfLine {
+	t.Helper()
+	text := strings.TrimSuffix(string(data), "\n")
+	values := strings.Split(text, "\n")
+	if len(values)%2 != 0 {
+		t.Fatalf("Expected code/value pairs, got %d lines", len(values))
+	}
+	if values[len(values)-1] != "EOF" {
+		t.Errorf("Expected EOF at the end, got %q", values[len(values)-1])
+	}
+
+	var lines []dxfLine
+	var current *dxfLine
+	index := map[string]int{"10": 0, "20": 1, "30": 2, "11": 3, "21": 4, "31": 5}
+	for i := 0; i < len(values); i += 2 {
+		code, value := strings.TrimSpace(values[i]), values[i+1]
+		switch {
+		case code == "0":
+			current = nil
+			if value == "LINE" {
+				lines = append(lines, dxfLine{})
+				current = &lines[len(lines)-1]
+			}
+		case current != nil && code == "8":
+			current.layer = value
+		case current != nil:
+			if k, ok := index[code]; ok {
+				v, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					t.Fatalf("Invalid coordinate %q: %v", value, err)
+				}
+				current.coords[k] = v
+			}
+		}
+	}
+	return lines
+}
+
+func TestMarshalDXF(t *testing.T) {
+	box, err := geometry.Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	// Uma linha sobre uma aresta do cubo, uma diagonal e uma curva
+	box.Linhas = []types.Line{
+		{P1: 1, P2: 0},
+		{P1: 0, P2: 6, Direcionada: true},
+		{P1: 0, P2: 2, Controle: []types.Point3D{{Z: 3}}},
+	}
+
+	data := MarshalDXF(box)
+	for _, want := range []string{"AC1009", "$EXTMIN", "LINHAS", "FACES", "ENTITIES"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in the DXF", want)
+		}
+	}
+	lines := readDXFLines(t, data)
+	count := map[string]int{}
+	for _, l := range lines {
+		count[l.layer]++
+	}
+	// Duas retas e a curva em trechos; as 12 arestas menos a repetida
+	if count["LINHAS"] != 2+types.CurveSegments || count["FACES"] != 11 {
+		t.Errorf("Expected %d lines and 11 face edges, got %v", 2+types.CurveSegments, count)
+	}
+	a, b := box.Pontos[1], box.Pontos[0]
+	if got := lines[0].coords; got != [6]float64{a.X, a.Y, a.Z, b.X, b.Y, b.Z} {
+		t.Errorf("Expected the first line from %v to %v, got %v", a, b, got)
+	}
+}
+
+func TestMarshalDXF_EdgesFromFaces(t *testing.T) {
+	box, err := geometry.Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	// Como o carregamento faz com uma figura só de faces
+	box.Linhas = box.FaceEdges()
+	box.EdgesFromFaces = true
+
+	lines := readDXFLines(t, MarshalDXF(box))
+	if len(lines) != 12 {
+		t.Fatalf("Expected 12 edges, got %d", len(lines))
+	}
+	for _, l := range lines {
+		if l.layer != "FACES" {
+			t.Errorf("Expected face edges on layer FACES, got %q", l.layer)
+		}
+	}
+
+	if got := dxfNumber(math.Copysign(0, -1)); got != "0" {
+		t.Errorf("Expected negative zero written as 0, got %q", got)
+	}
+	if got := dxfNumber(1e-7); got != "0.0000001" {
+		t.Errorf("Expected decimal notation, got %q", got)
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"representacao-figuras/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// flowSections são as seções da figura escritas com um item por linha,
+// como nos modelos: "- {x: 1, y: 0, z: 0}".
+var flowSections = map[string]bool{
+	"pontos":  true,
+	"linhas":  true,
+	"faces":   true,
+	"medidas": true,
+}
+
+// MarshalFigure converte uma figura para YAML, no formato dos modelos:
+// cada ponto, linha, face ou cota numa linha só, e a câmera sem os
+// campos zerados que o carregamento completa com os padrões. O texto
+// pode ser gravado num arquivo e lido de volta por LoadFigureFromYAML.
+// As linhas derivadas das faces no carregamento não são gravadas: o
+// carregamento as deriva de novo. A chave "script" também não: o script
+// já rodou no carregamento, e o que ele gerou é gravado como os demais
+// pontos, linhas e faces (rodá-lo de novo duplicaria tudo).
+//
+// Parâmetros:
+//   figure: figura a converter
+//
+// Retorna:
+//   []byte: figura em YAML
+//   error: erro de conversão
+func MarshalFigure(figure *types.Figure) ([]byte, error) {
+	if figure.EdgesFromFaces || figure.Script != "" {
+		bare := *figure
+		if figure.EdgesFromFaces {
+			bare.Linhas = nil
+		}
+		bare.Script = ""
+		figure = &bare
+	}
+
+	var doc yaml.Node
+	if err := doc.Encode(figure); err != nil {
+		return nil, fmt.Errorf("erro ao converter figura: %w", err)
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i].Value, doc.Content[i+1]
+		switch {
+		case flowSections[key]:
+			value.Style = 0 // Lista em bloco, itens em fluxo
+			for _, item := range value.Content {
+				item.Style = yaml.FlowStyle
+			}
+			if len(value.Content) == 0 {
+				value.Style = yaml.FlowStyle // "[]"
+			}
+		case key == "camera":
+			compactCamera(value)
+		case key == "render":
+			flowNested(value)
+		}
+	}
+
+	unquote(&doc)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("erro ao converter figura: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("erro ao converter figura: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// compactCamera remove da seção "camera" os campos zerados (o observador
+// na origem, a distância e a tela virtual nulas), que o carregamento
+// preenche com os padrões, e escreve alvo e órbita numa linha só. O
+// alvo fica mesmo na origem: sem ele, o observador olha na direção +Y.
+func compactCamera(camera *yaml.Node) {
+	var kept []*yaml.Node
+	for i := 0; i+1 < len(camera.Content); i += 2 {
+		key, value := camera.Content[i].Value, camera.Content[i+1]
+		if zeroNode(value) && (value.Kind == yaml.ScalarNode || key == "observador") {
+			continue
+		}
+		kept = append(kept, camera.Content[i], value)
+	}
+	camera.Content = kept
+	flowNested(camera)
+}
+
+// flowNested escreve numa linha só os mapas de uma seção, como
+// "luz: {ambiente: 0.5}".
+func flowNested(section *yaml.Node) {
+	for i := 1; i < len(section.Content); i += 2 {
+		if section.Content[i].Kind == yaml.MappingNode {
+			section.Content[i].Style = yaml.FlowStyle
+		}
+	}
+}
+
+// zeroNode informa se um valor é zero ou um mapa só de zeros.
+func zeroNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value == "0"
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if !zeroNode(node.Content[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// unquote tira as aspas da chave "y", que o YAML 1.1 lia como "sim" e o
+// codificador por isso escreve entre aspas, e troca as aspas simples
+// das cores ('#e8e8e8') pelas duplas dos modelos.
+func unquote(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == "y" {
+				node.Content[i].Style = 0
+			}
+		}
+	}
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.SingleQuotedStyle {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		unquote(child)
+	}
+}
+
+// SaveFigureFile grava uma figura em arquivo YAML (ver MarshalFigure).
+//
+// Parâmetros:
+//   filename: caminho do arquivo de saída
+//   figure: figura a gravar
+//
+// Retorna:
+//   error: erro de conversão ou de escrita
+func SaveFigureFile(filename string, figure *types.Figure) error {
+	data, err := MarshalFigure(figure)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveFigureToYAML grava uma figura em arquivo YAML; é o par de
+// LoadFigureFromYAML, para figuras montadas ou transformadas por
+// programas. Equivale a SaveFigureFile: as chaves saem na ordem e com os
+// nomes dos modelos (ver MarshalFigure).
+//
+// Parâmetros:
+//   filename: caminho do arquivo de saída
+//   figure: figura a gravar
+//
+// Retorna:
+//   error: erro de conversão ou de escrita
+func SaveFigureToYAML(filename string, figure *types.Figure) error {
+	return SaveFigureFile(filename, figure)
+}
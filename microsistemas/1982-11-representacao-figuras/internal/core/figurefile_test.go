@@ -0,0 +1,124 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/geometry"
+)
+
+func TestSaveFigureFile_RoundTrip(t *testing.T) {
+	figure, err := geometry.Cylinder(1, 2, 8)
+	if err != nil {
+		t.Fatalf("Cylinder failed: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "cilindro.yaml")
+	if err := SaveFigureFile(filename, figure); err != nil {
+		t.Fatalf("SaveFigureFile failed: %v", err)
+	}
+	loaded, err := LoadFigureFromYAML(filename)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.Pontos, figure.Pontos) {
+		t.Errorf("Expected points %+v, got %+v", figure.Pontos, loaded.Pontos)
+	}
+	if !reflect.DeepEqual(loaded.Faces, figure.Faces) {
+		t.Errorf("Expected faces %+v, got %+v", figure.Faces, loaded.Faces)
+	}
+	if loaded.Render == nil || loaded.Render.Edges != "contorno" || loaded.Render.FaceColor != "#e8e8e8" {
+		t.Errorf("Expected render settings to survive, got %+v", loaded.Render)
+	}
+	// Órbita resolvida pelo carregamento, com os padrões da tela virtual
+	if !loaded.Camera.Auto || loaded.Camera.Distance == 0 || loaded.Camera.Observer == figure.Camera.Observer {
+		t.Errorf("Expected a resolved auto-framed camera, got %+v", loaded.Camera)
+	}
+}
+
+func TestSaveFigureToYAML(t *testing.T) {
+	box, err := geometry.Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	want, err := MarshalFigure(box)
+	if err != nil {
+		t.Fatalf("MarshalFigure failed: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "caixa.yaml")
+	if err := SaveFigureToYAML(filename, box); err != nil {
+		t.Fatalf("SaveFigureToYAML failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Reading saved figure failed: %v", err)
+	}
+	// Mesmo texto de MarshalFigure, e lido de volta por LoadFigureFromYAML
+	if string(data) != string(want) {
+		t.Errorf("Expected MarshalFigure output, got:\n%s", data)
+	}
+	loaded, err := LoadFigureFromYAML(filename)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Faces, box.Faces) {
+		t.Errorf("Expected faces %+v, got %+v", box.Faces, loaded.Faces)
+	}
+}
+
+func TestMarshalFigure_Layout(t *testing.T) {
+	box, err := geometry.Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	data, err := MarshalFigure(box)
+	if err != nil {
+		t.Fatalf("MarshalFigure failed: %v", err)
+	}
+	text := string(data)
+
+	// Um item por linha, como nos modelos, e a câmera sem campos zerados
+	for _, want := range []string{
+		"  - {x: -1, y: -1, z: -1, nome: A}\n",
+		"  - {pontos: [3, 2, 1, 0]}\n",
+		"linhas: []\n",
+		"  alvo: {x: 0, y: 0, z: 0}\n",
+		`  cor_faces: "#e8e8e8"` + "\n",
+		"  luz: {ambiente: 0.5}\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, text)
+		}
+	}
+	for _, unwanted := range []string{"observador", "distancia", "largura"} {
+		if strings.Contains(text, unwanted) {
+			t.Errorf("Expected no %q key, got:\n%s", unwanted, text)
+		}
+	}
+}
+
+func TestMarshalFigure_EdgesFromFaces(t *testing.T) {
+	box, err := geometry.Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	// Como o carregamento faz com uma figura só de faces
+	box.Linhas = box.FaceEdges()
+	box.EdgesFromFaces = true
+
+	data, err := MarshalFigure(box)
+	if err != nil {
+		t.Fatalf("MarshalFigure failed: %v", err)
+	}
+	if !strings.Contains(string(data), "linhas: []\n") {
+		t.Errorf("Expected derived lines left out, got:\n%s", data)
+	}
+	if len(box.Linhas) != 12 {
+		t.Errorf("Expected figure untouched, got %d lines", len(box.Linhas))
+	}
+}
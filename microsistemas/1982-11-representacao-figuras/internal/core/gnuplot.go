@@ -0,0 +1,166 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// MarshalGnuplot converte uma figura para o gnuplot: um arquivo de dados
+// com as arestas e um script (.plt) que as desenha com splot, para
+// conferir a geometria junto com outros gráficos.
+//
+// O arquivo de dados tem uma coordenada "x y z" por linha, sem projeção.
+// Cada linha da figura (ou curva, em CurveSegments trechos retos) é um
+// bloco separado por uma linha em branco, que o gnuplot não liga ao
+// seguinte; as linhas, as arestas das faces que não repetem uma linha
+// reta e os pontos nomeados, com o nome na quarta coluna, formam
+// conjuntos separados por duas linhas em branco, que o script escolhe
+// com index. O script usa a direção da câmera da figura como vista do
+// gnuplot (set view), que é sempre uma projeção paralela, com as
+// proporções da figura (set view equal xyz).
+//
+// Parâmetros:
+//   figure: figura a converter
+//   dataFile: nome do arquivo de dados, como o script deve lê-lo
+//
+// Retorna:
+//   []byte: arquivo de dados
+//   []byte: script do gnuplot
+func MarshalGnuplot(figure *types.Figure, dataFile string) (data, script []byte) {
+	var buf bytes.Buffer
+	point := func(p types.Point3D) string {
+		return gnuplotNumber(p.X) + " " + gnuplotNumber(p.Y) + " " + gnuplotNumber(p.Z)
+	}
+	var blocks []string // Conjuntos de dados, na ordem de index
+	begin := func(title string) {
+		if len(blocks) > 0 {
+			buf.WriteString("\n\n")
+		}
+		fmt.Fprintf(&buf, "# index %d: %s\n", len(blocks), title)
+		blocks = append(blocks, title)
+	}
+	dataset := func(title string, lines [][]types.Point3D) {
+		if len(lines) == 0 {
+			return
+		}
+		begin(title)
+		for i, line := range lines {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			for _, p := range line {
+				buf.WriteString(point(p) + "\n")
+			}
+		}
+	}
+
+	if figure.Nome != "" {
+		fmt.Fprintf(&buf, "# %s\n", figure.Nome)
+	}
+	fmt.Fprintf(&buf, "# Arestas da figura gerada por figuras3d: x y z\n")
+
+	var lines, faces [][]types.Point3D
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			lines = append(lines, linha.Curve(figure.Pontos))
+			if len(linha.Controle) == 0 {
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+			}
+		}
+	}
+	for _, edge := range figure.FaceEdges() {
+		if !drawn[[2]int{min(edge.P1, edge.P2), max(edge.P1, edge.P2)}] {
+			faces = append(faces, []types.Point3D{figure.Pontos[edge.P1], figure.Pontos[edge.P2]})
+		}
+	}
+	dataset("linhas", lines)
+	dataset("faces", faces)
+
+	// Pontos nomeados: um bloco só, com o nome entre aspas
+	named := false
+	for _, p := range figure.Pontos {
+		if p.Nome == "" {
+			continue
+		}
+		if !named {
+			begin("nomes")
+			named = true
+		}
+		fmt.Fprintf(&buf, "%s %s\n", point(p), gnuplotString(p.Nome))
+	}
+	data = buf.Bytes()
+
+	var s bytes.Buffer
+	if figure.Nome != "" {
+		fmt.Fprintf(&s, "# %s\n", figure.Nome)
+	}
+	fmt.Fprintf(&s, "# Script do gnuplot gerado por figuras3d; desenhe com gnuplot -p,\n")
+	fmt.Fprintf(&s, "# na pasta de %s\n", dataFile)
+	if figure.Nome != "" {
+		fmt.Fprintf(&s, "set title %s\n", gnuplotString(figure.Nome))
+	}
+	fmt.Fprintf(&s, "set xlabel \"X\"\nset ylabel \"Y\"\nset zlabel \"Z\"\n")
+	fmt.Fprintf(&s, "set view equal xyz\n")
+	fmt.Fprintf(&s, "set xyplane 0\n")
+	rotX, rotZ := gnuplotView(figure.Camera)
+	fmt.Fprintf(&s, "set view %s, %s\n", gnuplotNumber(rotX), gnuplotNumber(rotZ))
+
+	var plots []string
+	for i, title := range blocks {
+		source := gnuplotString(dataFile)
+		if i > 0 {
+			source = `""`
+		}
+		if title == "nomes" {
+			plots = append(plots, fmt.Sprintf("%s index %d using 1:2:3:4 with labels point pointtype 7 offset character 1, 1 notitle", source, i))
+		} else {
+			plots = append(plots, fmt.Sprintf("%s index %d with lines title %s", source, i, gnuplotString(title)))
+		}
+	}
+	if len(plots) > 0 {
+		fmt.Fprintf(&s, "splot %s\n", strings.Join(plots, ", \\\n      "))
+	}
+	return data, s.Bytes()
+}
+
+// gnuplotView converte a direção de visão da câmera nos ângulos de set
+// view: rot_x, a inclinação a partir da vista de cima, e rot_z, o giro em
+// torno do eixo Z. Sem alvo, a câmera olha ao longo de +Y, como no
+// artigo, e a vista é 90, 0.
+func gnuplotView(camera types.Camera) (rotX, rotZ float64) {
+	camera.ResolveOrbit()
+	if camera.Target == nil {
+		return 90, 0
+	}
+	t, o := *camera.Target, camera.Observer
+	dx, dy, dz := t.X-o.X, t.Y-o.Y, t.Z-o.Z
+	length := math.Sqrt(dx*dx + dy*dy + dz*dz)
+	if length == 0 {
+		return 90, 0
+	}
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	rotX = round(math.Acos(-dz/length) * 180 / math.Pi)
+	rotZ = round(math.Mod(math.Atan2(dx, dy)*180/math.Pi+360, 360))
+	if rotZ == 360 {
+		rotZ = 0
+	}
+	return rotX, rotZ
+}
+
+// gnuplotNumber escreve um número em notação decimal.
+func gnuplotNumber(v float64) string {
+	return strconv.FormatFloat(v+0, 'f', -1, 64) // +0: -0 vira 0
+}
+
+// gnuplotString escreve um texto entre aspas duplas; as aspas duplas do
+// texto viram simples, já que o gnuplot não as aceita escapadas nos
+// arquivos de dados.
+func gnuplotString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
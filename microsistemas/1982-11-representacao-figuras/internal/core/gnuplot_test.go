@@ -0,0 +1,89 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestMarshalGnuplot(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "telhado",
+		Pontos: []types.Point3D{
+			{X: 0, Nome: "A"}, {X: 1}, {X: 1, Z: 1}, {X: 0, Z: 1, Nome: `"B"`},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1},
+			{P1: 1, P2: 2, Controle: []types.Point3D{{X: 2, Z: 0.5}}}, // Curva de CurveSegments trechos
+		},
+		Faces: []types.Face{{Pontos: []int{0, 1, 2, 3}}},
+	}
+	data, script := MarshalGnuplot(figure, "telhado.dat")
+
+	// Conjuntos separados por duas linhas em branco: linhas, faces e nomes
+	sets := strings.Split(string(data), "\n\n\n")
+	if len(sets) != 3 {
+		t.Fatalf("Expected 3 data sets, got %d:\n%s", len(sets), data)
+	}
+	blocks := func(set string) []string {
+		return strings.Split(strings.TrimSuffix(set, "\n"), "\n\n")
+	}
+	if lines := blocks(sets[0]); len(lines) != 2 || strings.Count(lines[1], "\n") != types.CurveSegments {
+		t.Errorf("Expected the straight line and the curve as blocks, got %q", lines)
+	}
+	// A aresta 0-1 já é uma linha; a 1-2 não, porque a linha 1-2 é curva
+	if faces := blocks(sets[1]); len(faces) != 3 {
+		t.Errorf("Expected 3 face edges, got %q", faces)
+	}
+	if !strings.Contains(sets[2], "0 0 0 \"A\"\n") || !strings.Contains(sets[2], "0 0 1 \"'B'\"\n") {
+		t.Errorf("Expected quoted point names, got %q", sets[2])
+	}
+
+	text := string(script)
+	for _, want := range []string{
+		"set title \"telhado\"\n",
+		"set view 90, 0\n",
+		"splot \"telhado.dat\" index 0 with lines title \"linhas\", \\\n",
+		"\"\" index 1 with lines title \"faces\"",
+		"\"\" index 2 using 1:2:3:4 with labels",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in script:\n%s", want, text)
+		}
+	}
+
+	// Só as arestas das faces: um conjunto só, no index 0
+	figure.EdgesFromFaces = true
+	for i := range figure.Pontos {
+		figure.Pontos[i].Nome = ""
+	}
+	data, script = MarshalGnuplot(figure, "telhado.dat")
+	if strings.Contains(string(data), "\n\n\n") || !strings.Contains(string(script), "splot \"telhado.dat\" index 0 with lines title \"faces\"\n") {
+		t.Errorf("Expected only the face edges, got:\n%s\n%s", data, script)
+	}
+}
+
+func TestGnuplotView(t *testing.T) {
+	target := types.Point3D{}
+	tests := []struct {
+		name       string
+		observer   types.Point3D
+		rotX, rotZ float64
+	}{
+		{"front", types.Point3D{Y: -10}, 90, 0},
+		{"top", types.Point3D{Z: 10}, 0, 0},
+		{"right", types.Point3D{X: 10}, 90, 270},
+		{"left", types.Point3D{X: -10}, 90, 90},
+		{"iso", types.Point3D{X: -5, Y: -5, Z: 5}, 54.74, 45},
+	}
+	for _, tt := range tests {
+		camera := types.Camera{Observer: tt.observer, Target: &target}
+		if rotX, rotZ := gnuplotView(camera); rotX != tt.rotX || rotZ != tt.rotZ {
+			t.Errorf("%s: expected view %g, %g, got %g, %g", tt.name, tt.rotX, tt.rotZ, rotX, rotZ)
+		}
+	}
+	if rotX, rotZ := gnuplotView(types.DefaultCamera()); rotX != 90 || rotZ != 0 {
+		t.Errorf("Expected the article's view 90, 0 without target, got %g, %g", rotX, rotZ)
+	}
+}
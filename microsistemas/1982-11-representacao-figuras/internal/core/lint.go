@@ -0,0 +1,262 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"representacao-figuras/pkg/geometry"
+	"representacao-figuras/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintLevel é a gravidade de um problema apontado por LintFigure.
+type LintLevel int
+
+const (
+	LintError   LintLevel = iota // A figura não carrega
+	LintWarning                  // A figura carrega, mas tem defeitos
+	LintStyle                    // O arquivo foge ao estilo dos modelos
+)
+
+// String retorna o nome da gravidade, como aparece nas mensagens.
+func (l LintLevel) String() string {
+	switch l {
+	case LintError:
+		return "erro"
+	case LintWarning:
+		return "aviso"
+	default:
+		return "estilo"
+	}
+}
+
+// LintFinding é um problema encontrado num arquivo de figura.
+type LintFinding struct {
+	Level   LintLevel
+	Message string
+}
+
+// lintExamples é o número de ocorrências citadas em cada problema; as
+// demais só entram na contagem.
+const lintExamples = 5
+
+// unknownField reconhece, nos erros da leitura estrita do YAML, as chaves
+// que não existem na figura.
+var unknownField = regexp.MustCompile(`^line (\d+): field (.+) not found in type`)
+
+// LintFigure examina um arquivo de figura e aponta, além dos erros que
+// impedem o carregamento, os defeitos que o carregamento aceita e os
+// desvios do estilo dos modelos:
+//
+//   - erros: o arquivo não pode ser lido ou a figura é inválida
+//   - avisos: chaves desconhecidas (ignoradas pelo carregamento, em geral
+//     um erro de digitação), pontos repetidos na mesma posição, linhas
+//     repetidas ou que ligam um ponto a ele mesmo, faces repetidas ou
+//     sem área, pontos isolados, arestas de mais de duas faces e nomes
+//     de pontos repetidos
+//   - estilo: figura sem nome, espaços no fim das linhas, tabulações,
+//     quebras de linha do Windows e falta da quebra de linha final
+//
+// As linhas derivadas das faces (ver types.Figure.EdgesFromFaces) não
+// são examinadas como linhas.
+//
+// Parâmetros:
+//   filename: caminho ou URL do arquivo YAML da figura
+//
+// Retorna:
+//   []LintFinding: problemas encontrados, dos erros ao estilo (nil se
+//                  não há nenhum)
+func LintFigure(filename string) []LintFinding {
+	var findings []LintFinding
+	report := func(level LintLevel, format string, args ...any) {
+		findings = append(findings, LintFinding{Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+
+	data, err := readSource(filename, DefaultLoadLimits())
+	if err != nil {
+		report(LintError, "erro ao ler arquivo: %v", err)
+		return findings
+	}
+	figure, err := LoadFigureFromYAML(filename)
+	if err != nil {
+		report(LintError, "%v", err)
+	}
+
+	// Leitura estrita: as chaves que o carregamento ignora em silêncio
+	var strict types.Figure
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var typeErr *yaml.TypeError
+	if err := decoder.Decode(&strict); errors.As(err, &typeErr) {
+		for _, message := range typeErr.Errors {
+			if m := unknownField.FindStringSubmatch(message); m != nil {
+				report(LintWarning, "linha %s: chave desconhecida %s (ignorada)", m[1], strconv.Quote(m[2]))
+			}
+		}
+	}
+
+	if figure != nil {
+		lintGeometry(figure, report)
+		if figure.Nome == "" {
+			report(LintStyle, "figura sem nome (chave nome)")
+		}
+	}
+	lintText(data, report)
+
+	slices.SortStableFunc(findings, func(a, b LintFinding) int { return int(a.Level) - int(b.Level) })
+	return findings
+}
+
+// lintGeometry aponta os defeitos de uma figura carregada: repetições,
+// elementos degenerados e pontos que nada usa.
+func lintGeometry(figure *types.Figure, report func(LintLevel, string, ...any)) {
+	label := func(p int) string {
+		if name := figure.Pontos[p].Nome; name != "" {
+			return name
+		}
+		return strconv.Itoa(p)
+	}
+
+	// Pontos na posição de um anterior, que weld fundiria: os com nomes
+	// diferentes marcam coisas diferentes e ficam de fora
+	var repeated []string
+	first := make(map[[3]float64]int)
+	for i, p := range figure.Pontos {
+		key := [3]float64{p.X + 0, p.Y + 0, p.Z + 0} // -0 é o mesmo que 0
+		j, ok := first[key]
+		if !ok {
+			first[key] = i
+			continue
+		}
+		if q := figure.Pontos[j]; p.Nome == "" || q.Nome == "" || p.Nome == q.Nome {
+			repeated = append(repeated, fmt.Sprintf("%s = %s", label(i), label(j)))
+		}
+	}
+	reportList(report, LintWarning, "pontos repetidos na mesma posição", repeated, "funda-os com weld")
+
+	names := make(map[string]int)
+	var sameName []string
+	for i, p := range figure.Pontos {
+		if p.Nome == "" {
+			continue
+		}
+		if j, ok := names[p.Nome]; ok {
+			sameName = append(sameName, fmt.Sprintf("%s (pontos %d e %d)", p.Nome, j, i))
+			continue
+		}
+		names[p.Nome] = i
+	}
+	reportList(report, LintWarning, "nomes de pontos repetidos", sameName, "as cotas usam o primeiro")
+
+	if !figure.EdgesFromFaces {
+		type lineKey struct {
+			a, b     int
+			directed bool
+		}
+		var loops, twins []string
+		seen := make(map[lineKey]int)
+		for i, linha := range figure.Linhas {
+			if len(linha.Controle) > 0 {
+				continue // Curvas podem sair e voltar ao mesmo ponto
+			}
+			if linha.P1 == linha.P2 {
+				loops = append(loops, fmt.Sprintf("linha %d", i))
+				continue
+			}
+			key := lineKey{min(linha.P1, linha.P2), max(linha.P1, linha.P2), false}
+			if linha.Direcionada {
+				key = lineKey{linha.P1, linha.P2, true}
+			}
+			if j, ok := seen[key]; ok {
+				twins = append(twins, fmt.Sprintf("linha %d = %d", i, j))
+				continue
+			}
+			seen[key] = i
+		}
+		reportList(report, LintWarning, "linhas que ligam um ponto a ele mesmo", loops, "")
+		reportList(report, LintWarning, "linhas repetidas", twins, "")
+	}
+
+	// Faces sem área, com tolerância proporcional ao tamanho da figura,
+	// e faces com os mesmos vértices de uma anterior
+	low, high := figure.Bounds()
+	size := math.Max(high.X-low.X, math.Max(high.Y-low.Y, high.Z-low.Z))
+	var flat, twins []string
+	seen := make(map[string]int)
+	for i, face := range figure.Faces {
+		if geometry.FaceArea(figure.Pontos, face.Pontos) <= 1e-12*size*size {
+			flat = append(flat, fmt.Sprintf("face %d", i))
+		}
+		sorted := slices.Sorted(slices.Values(face.Pontos))
+		key := fmt.Sprint(slices.Compact(sorted))
+		if j, ok := seen[key]; ok {
+			twins = append(twins, fmt.Sprintf("face %d = %d", i, j))
+			continue
+		}
+		seen[key] = i
+	}
+	reportList(report, LintWarning, "faces sem área (vértices alinhados ou repetidos)", flat, "")
+	reportList(report, LintWarning, "faces repetidas", twins, "")
+
+	stats := geometry.MeshStatistics(figure)
+	var edges, isolated []string
+	for _, e := range stats.NonManifoldEdges {
+		edges = append(edges, label(e[0])+"-"+label(e[1]))
+	}
+	for _, p := range stats.IsolatedPoints {
+		isolated = append(isolated, label(p))
+	}
+	reportList(report, LintWarning, "arestas de mais de duas faces", edges, "")
+	reportList(report, LintWarning, "pontos isolados, que nenhuma linha, face ou cota usa", isolated, "")
+}
+
+// lintText aponta os desvios de estilo do texto do arquivo, que o YAML
+// aceita mas que sujam as comparações entre versões.
+func lintText(data []byte, report func(LintLevel, string, ...any)) {
+	var trailing, tabs []string
+	crlf := false
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if strings.HasSuffix(line, "\r") {
+			crlf = true
+			line = strings.TrimSuffix(line, "\r")
+		}
+		if strings.TrimRight(line, " \t") != line {
+			trailing = append(trailing, strconv.Itoa(i+1))
+		}
+		if strings.Contains(line, "\t") {
+			tabs = append(tabs, strconv.Itoa(i+1))
+		}
+	}
+	reportList(report, LintStyle, "linhas com espaços no fim", trailing, "")
+	reportList(report, LintStyle, "linhas com tabulações", tabs, "")
+	if crlf {
+		report(LintStyle, "quebras de linha do Windows (CRLF)")
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		report(LintStyle, "o arquivo não termina com quebra de linha")
+	}
+}
+
+// reportList relata um problema que se repete, com o número de
+// ocorrências e as primeiras delas, e uma dica opcional no fim.
+func reportList(report func(LintLevel, string, ...any), level LintLevel, problem string, items []string, hint string) {
+	if len(items) == 0 {
+		return
+	}
+	shown := strings.Join(items[:min(len(items), lintExamples)], ", ")
+	if len(items) > lintExamples {
+		shown += ", ..."
+	}
+	if hint != "" {
+		shown += "; " + hint
+	}
+	report(level, "%s (%d): %s", problem, len(items), shown)
+}
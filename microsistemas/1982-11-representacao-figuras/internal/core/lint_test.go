@@ -0,0 +1,105 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFigure(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "figura.yaml")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write figure: %v", err)
+	}
+	return filename
+}
+
+func TestLintFigure_Clean(t *testing.T) {
+	models, err := filepath.Glob("../../modelos/*.yaml")
+	if err != nil || len(models) == 0 {
+		t.Fatalf("Expected models, got %v (%v)", models, err)
+	}
+	for _, model := range models {
+		for _, finding := range LintFigure(model) {
+			t.Errorf("%s: %s: %s", model, finding.Level, finding.Message)
+		}
+	}
+}
+
+func TestLintFigure_Findings(t *testing.T) {
+	// Um quadrado com quase todos os defeitos que o carregamento aceita
+	filename := writeFigure(t, `pontos:
+  - {x: 0, y: 0, z: 0, nome: A}
+  - {x: 1, y: 0, z: 0}
+  - {x: 1, y: 1, z: 0}
+  - {x: 0, y: 1, z: 0}
+  - {x: 1, y: 0, z: 0}
+  - {x: 9, y: 9, z: 9}
+  - {x: 2, y: 0, z: 0, nome: A}
+linhas:
+  - {p1: 0, p2: 1}
+  - {p1: 1, p2: 0}
+  - {p1: 2, p2: 2}
+  - {p1: 0, p2: 6}
+faces:
+  - {pontos: [0, 1, 2, 3]}
+  - {pontos: [3, 0, 1, 2]}
+  - {pontos: [0, 1, 6]}
+camera:
+  observador: {x: 0, y: -5, z: 0}
+  distancai: 1   
+`)
+
+	findings := LintFigure(filename)
+	var text []string
+	for _, f := range findings {
+		text = append(text, f.Level.String()+": "+f.Message)
+	}
+	all := strings.Join(text, "\n")
+	for _, want := range []string{
+		`aviso: linha 20: chave desconhecida "distancai" (ignorada)`,
+		"aviso: pontos repetidos na mesma posição (1): 4 = 1; funda-os com weld",
+		"aviso: nomes de pontos repetidos (1): A (pontos 0 e 6)",
+		"aviso: linhas que ligam um ponto a ele mesmo (1): linha 2",
+		"aviso: linhas repetidas (1): linha 1 = 0",
+		"aviso: faces sem área (vértices alinhados ou repetidos) (1): face 2",
+		"aviso: faces repetidas (1): face 1 = 0",
+		"aviso: arestas de mais de duas faces (1): A-1",
+		"aviso: pontos isolados, que nenhuma linha, face ou cota usa (2): 4, 5",
+		"estilo: figura sem nome (chave nome)",
+		"estilo: linhas com espaços no fim (1): 20",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Expected finding %q, got:\n%s", want, all)
+		}
+	}
+	for i := 1; i < len(findings); i++ {
+		if findings[i].Level < findings[i-1].Level {
+			t.Errorf("Expected findings ordered by level, got:\n%s", all)
+		}
+	}
+}
+
+func TestLintFigure_Errors(t *testing.T) {
+	if findings := LintFigure(filepath.Join(t.TempDir(), "nada.yaml")); len(findings) != 1 || findings[0].Level != LintError {
+		t.Errorf("Expected a single error for a missing file, got %+v", findings)
+	}
+
+	// A chave com erro de digitação explica a figura sem linhas
+	filename := writeFigure(t, "nome: quebrada\npontos:\n  - {x: 0, y: 0, z: 0}\nlinha:\n  - {p1: 0, p2: 0}\n")
+	findings := LintFigure(filename)
+	if len(findings) != 2 || findings[0].Level != LintError || !strings.Contains(findings[1].Message, `"linha"`) {
+		t.Errorf("Expected the load error and the unknown key, got %+v", findings)
+	}
+
+	filename = writeFigure(t, "nome: crlf\r\npontos:\r\n  - {x: 0, y: 0, z: 0}\r\n  - {x: 1, y: 0, z: 0}\r\nlinhas:\r\n  - {p1: 0,\tp2: 1}")
+	var styles []string
+	for _, f := range LintFigure(filename) {
+		styles = append(styles, f.Message)
+	}
+	if len(styles) != 3 {
+		t.Errorf("Expected tabs, CRLF and missing final newline, got %q", styles)
+	}
+}
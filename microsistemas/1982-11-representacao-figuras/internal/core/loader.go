@@ -2,7 +2,7 @@
 // e validação de figuras tridimensionais.
 //
 // Este pacote é responsável por:
-// - Carregar definições de figuras a partir de arquivos YAML
+// - Carregar definições de figuras a partir de arquivos YAML (locais ou URLs)
 // - Validar a consistência dos dados carregados
 // - Aplicar configurações padrão quando necessário
 //
@@ -13,35 +13,95 @@ package core
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"representacao-figuras/pkg/types"
 
 	"gopkg.in/yaml.v3"
 )
 
+// LoadLimits define limites de recursos aplicados durante o carregamento.
+//
+// Figuras convertidas de malhas externas podem ter milhões de elementos;
+// sem limites, um arquivo acidentalmente gigante (ou malicioso) esgotaria
+// a memória antes de qualquer validação. Um valor zero em qualquer campo
+// desativa o limite correspondente.
+type LoadLimits struct {
+	MaxFileSize int64         // Tamanho máximo do arquivo em bytes
+	MaxPoints   int           // Número máximo de pontos (vértices)
+	MaxLines    int           // Número máximo de linhas (arestas)
+	HTTPTimeout time.Duration // Tempo máximo para baixar figuras via HTTP(S)
+
+	// Passos de execução do script da figura (chave "script"), que
+	// interrompem os laços infinitos (mas não limitam a memória: ver
+	// runScript)
+	MaxScriptSteps uint64
+}
+
+// DefaultLoadLimits retorna os limites usados por LoadFigureFromYAML.
+//
+// Os valores são generosos para qualquer figura desenhada à mão ou gerada
+// por conversão razoável, mas impedem que entradas de vários gigabytes
+// sejam lidas por inteiro para a memória.
+func DefaultLoadLimits() LoadLimits {
+	return LoadLimits{
+		MaxFileSize: 64 << 20, // 64 MiB
+		MaxPoints:   1_000_000,
+		MaxLines:    4_000_000,
+		HTTPTimeout: 30 * time.Second,
+
+		MaxScriptSteps: 100_000_000, // Alguns segundos
+	}
+}
+
 // LoadFigureFromYAML carrega e valida uma figura tridimensional a partir de um arquivo YAML.
 //
 // Esta função substitui a necessidade de definir figuras diretamente no código
 // (como era feito no BASIC original), permitindo que usuários criem suas próprias
 // figuras em um formato declarativo e intuitivo.
 //
-// Processo de carregamento:
-// 1. Lê o arquivo YAML do sistema de arquivos
-// 2. Faz o parse dos dados para a estrutura Figure
-// 3. Aplica configurações padrão se necessário (ex: câmera)
-// 4. Valida a consistência dos dados
-// 5. Retorna a figura pronta para renderização
+// Além de caminhos locais, aceita URLs HTTP(S) (ex: arquivos compartilhados
+// em gists), baixados com tempo e tamanho limitados.
+//
+// Usa os limites de DefaultLoadLimits; para limites personalizados,
+// use LoadFigureFromYAMLWithLimits diretamente.
 //
 // Parâmetros:
-//   filename: caminho para o arquivo YAML contendo a definição da figura
+//   filename: caminho ou URL do arquivo YAML contendo a definição da figura
 //
 // Retorna:
 //   *types.Figure: figura carregada e validada
 //   error: erro caso haja problemas na leitura, parse ou validação
 func LoadFigureFromYAML(filename string) (*types.Figure, error) {
-	// Etapa 1: Leitura do arquivo
-	data, err := os.ReadFile(filename)
+	return LoadFigureFromYAMLWithLimits(filename, DefaultLoadLimits())
+}
+
+// LoadFigureFromYAMLWithLimits carrega uma figura respeitando limites de recursos.
+//
+// Processo de carregamento:
+// 1. Lê o arquivo YAML do disco ou da URL (até MaxFileSize bytes)
+// 2. Faz o parse dos dados para a estrutura Figure (aplica o estilo
+//    referenciado em "estilo" e executa o script de "script", se houver)
+// 3. Verifica as quantidades de pontos e linhas contra os limites
+// 4. Aplica configurações padrão se necessário (ex: câmera)
+// 5. Valida a consistência dos dados
+// 6. Deriva as linhas das faces, se a figura só tiver faces
+// 7. Retorna a figura pronta para renderização
+//
+// Parâmetros:
+//   filename: caminho ou URL do arquivo YAML contendo a definição da figura
+//   limits: limites de tamanho do arquivo e quantidade de elementos
+//
+// Retorna:
+//   *types.Figure: figura carregada e validada
+//   error: erro caso haja problemas na leitura, parse, limites ou validação
+func LoadFigureFromYAMLWithLimits(filename string, limits LoadLimits) (*types.Figure, error) {
+	// Etapa 1: Leitura do arquivo ou URL (com limite de tamanho)
+	data, err := readSource(filename, limits)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
 	}
@@ -53,22 +113,232 @@ func LoadFigureFromYAML(filename string) (*types.Figure, error) {
 		return nil, fmt.Errorf("erro ao parsear YAML: %w", err)
 	}
 
-	// Etapa 3: Aplicação de padrões
-	// Se a câmera não foi especificada (Distance == 0), usa configuração padrão
-	// baseada no HP-85 original
-	if figure.Camera.Distance == 0 {
-		figure.Camera = types.DefaultCamera()
+	// Etapa 2b: Arquivos de fonte e da marca d'água relativos ao YAML
+	// (os do estilo já vêm relativos ao arquivo de estilo: ver LoadPreset)
+	if figure.Render != nil {
+		if err := resolveRenderFiles(figure.Render, filename); err != nil {
+			return nil, err
+		}
+	}
+
+	// Etapa 2c: Estilo compartilhado por baixo da seção "render"
+	if figure.Style != "" {
+		if err := applyFigureStyle(&figure, filename); err != nil {
+			return nil, err
+		}
+	}
+
+	// Etapa 2d: Pontos, linhas e faces gerados pelo script
+	if figure.Script != "" {
+		if err := applyFigureScript(&figure, filename, limits); err != nil {
+			return nil, err
+		}
 	}
 
-	// Etapa 4: Validação da consistência
+	// Etapa 3: Verificação dos limites de elementos
+	err = checkLimits(&figure, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Etapa 4: Aplicação de padrões
+	// (na câmera principal e em cada câmera nomeada)
+	if err := prepareCamera(&figure.Camera); err != nil {
+		return nil, fmt.Errorf("figura inválida: %w", err)
+	}
+	for name, camera := range figure.Cameras {
+		if err := prepareCamera(&camera); err != nil {
+			return nil, fmt.Errorf("figura inválida: câmera %q: %w", name, err)
+		}
+		figure.Cameras[name] = camera
+	}
+
+	// Etapa 5: Validação da consistência
 	err = validateFigure(&figure)
 	if err != nil {
 		return nil, fmt.Errorf("figura inválida: %w", err)
 	}
 
+	// Etapa 6: Figura só de faces ganha as arestas delas como linhas,
+	// para os desenhos que só traçam linhas (ASCII, retrô, construção)
+	if len(figure.Linhas) == 0 && len(figure.Faces) > 0 {
+		figure.Linhas = figure.FaceEdges()
+		figure.EdgesFromFaces = true
+	}
+
 	return &figure, nil
 }
 
+// isURL informa se o nome da figura é uma URL HTTP(S) em vez de um caminho local.
+func isURL(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// readSource lê a definição da figura de um arquivo local ou de uma URL.
+func readSource(name string, limits LoadLimits) ([]byte, error) {
+	if isURL(name) {
+		return readURLLimited(name, limits)
+	}
+	return readFileLimited(name, limits.MaxFileSize)
+}
+
+// readURLLimited baixa o conteúdo de uma URL HTTP(S) respeitando os limites.
+//
+// O download é abortado se exceder HTTPTimeout ou MaxFileSize. Respostas
+// com status diferente de 200 são tratadas como erro, para que páginas de
+// erro HTML não sejam interpretadas como YAML.
+//
+// Parâmetros:
+//   url: endereço HTTP(S) da figura
+//   limits: limites de tempo e tamanho (zero = sem limite)
+//
+// Retorna:
+//   []byte: conteúdo baixado
+//   error: erro de rede, de status HTTP ou de limite excedido
+func readURLLimited(url string, limits LoadLimits) ([]byte, error) {
+	client := &http.Client{Timeout: limits.HTTPTimeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resposta HTTP inesperada: %s", resp.Status)
+	}
+
+	if limits.MaxFileSize <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+
+	// Content-Length permite falhar antes de baixar qualquer byte
+	if resp.ContentLength > limits.MaxFileSize {
+		return nil, fmt.Errorf("arquivo excede o tamanho máximo: %d bytes (limite %d)",
+			resp.ContentLength, limits.MaxFileSize)
+	}
+
+	return readAllLimited(resp.Body, limits.MaxFileSize)
+}
+
+// readFileLimited lê um arquivo por inteiro, recusando arquivos maiores que maxSize.
+//
+// O tamanho é verificado antes da leitura (via Stat) para falhar rápido, e a
+// própria leitura é limitada para cobrir arquivos que crescem durante a
+// leitura ou que não informam tamanho (pipes, dispositivos).
+//
+// Parâmetros:
+//   filename: caminho do arquivo
+//   maxSize: tamanho máximo em bytes (0 = sem limite)
+//
+// Retorna:
+//   []byte: conteúdo do arquivo
+//   error: erro de E/S ou de limite excedido
+func readFileLimited(filename string, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return os.ReadFile(filename)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && info.Mode().IsRegular() && info.Size() > maxSize {
+		return nil, fmt.Errorf("arquivo excede o tamanho máximo: %d bytes (limite %d)", info.Size(), maxSize)
+	}
+
+	return readAllLimited(f, maxSize)
+}
+
+// readAllLimited lê todo o conteúdo de r, falhando se ultrapassar maxSize bytes.
+func readAllLimited(r io.Reader, maxSize int64) ([]byte, error) {
+	// Lê um byte além do limite para detectar entradas maiores
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("conteúdo excede o tamanho máximo de %d bytes", maxSize)
+	}
+	return data, nil
+}
+
+// checkLimits verifica se a figura respeita os limites de pontos e linhas.
+//
+// Parâmetros:
+//   figure: figura recém-carregada
+//   limits: limites a aplicar (zero = sem limite)
+//
+// Retorna:
+//   error: nil se dentro dos limites, ou descrição do limite excedido
+func checkLimits(figure *types.Figure, limits LoadLimits) error {
+	if limits.MaxPoints > 0 && len(figure.Pontos) > limits.MaxPoints {
+		return fmt.Errorf("figura excede o limite de pontos: %d (máximo %d)",
+			len(figure.Pontos), limits.MaxPoints)
+	}
+
+	if limits.MaxLines > 0 && len(figure.Linhas) > limits.MaxLines {
+		return fmt.Errorf("figura excede o limite de linhas: %d (máximo %d)",
+			len(figure.Linhas), limits.MaxLines)
+	}
+
+	return nil
+}
+
+// prepareCamera expande a vista pré-definida da câmera, se houver, e
+// completa os campos ausentes com os padrões.
+//
+// Vistas são expandidas antes dos padrões, pois definem a órbita.
+//
+// Parâmetros:
+//   camera: câmera carregada do YAML, modificada no lugar
+//
+// Retorna:
+//   error: erro se a vista pré-definida for desconhecida
+func prepareCamera(camera *types.Camera) error {
+	if camera.View != "" {
+		if err := camera.ApplyView(camera.View); err != nil {
+			return err
+		}
+	}
+	applyCameraDefaults(camera)
+	return nil
+}
+
+// applyCameraDefaults completa a câmera com os valores padrão do HP-85.
+//
+// Se a câmera não foi especificada (Distance == 0 e nenhum outro campo
+// relevante), usa a configuração padrão inteira. Câmeras parcialmente
+// especificadas (por FOV, alvo, órbita ou enquadramento automático) apenas têm os campos ausentes
+// preenchidos, e a órbita é convertida em observador e alvo explícitos.
+//
+// Parâmetros:
+//   camera: câmera carregada do YAML, modificada no lugar
+func applyCameraDefaults(camera *types.Camera) {
+	defaults := types.DefaultCamera()
+
+	partial := camera.FOV > 0 || camera.Target != nil || camera.Orbit != nil || camera.Auto
+	if camera.Distance == 0 && !partial {
+		*camera = defaults
+		return
+	}
+
+	// Com FOV definido, a distância R só afeta a escala do plano
+	// projetante, então basta completá-la com o padrão
+	if camera.Distance == 0 {
+		camera.Distance = defaults.Distance
+	}
+	if camera.FOV == 0 && (camera.Width == 0 || camera.Height == 0) {
+		camera.Width = defaults.Width
+		camera.Height = defaults.Height
+	}
+
+	camera.ResolveOrbit()
+}
+
 // validateFigure verifica se a figura está bem formada e consistente.
 //
 // Realiza verificações essenciais para garantir que a figura possa ser
@@ -76,8 +346,14 @@ func LoadFigureFromYAML(filename string) (*types.Figure, error) {
 //
 // Validações realizadas:
 // 1. Presença de pelo menos um ponto (vértice)
-// 2. Presença de pelo menos uma linha (aresta)
-// 3. Consistência das referências de índices nas linhas
+// 2. Presença de pelo menos uma linha (aresta) ou face
+// 3. Consistência das referências de índices nas linhas e faces, e
+//    dos nomes de pontos nas cotas
+// 4. Campo de visão da câmera dentro do intervalo válido
+// 5. Tipo de projeção conhecido
+// 6. Raio positivo quando a câmera usa órbita
+// (itens 4 a 6 também para cada câmera nomeada)
+// 7. Quadros-chave da animação em ordem de tempo, se houver
 //
 // Parâmetros:
 //   figure: ponteiro para a figura a ser validada
@@ -91,10 +367,11 @@ func validateFigure(figure *types.Figure) error {
 		return fmt.Errorf("figura deve ter pelo menos um ponto")
 	}
 
-	// Verificação 2: Deve ter pelo menos uma aresta
+	// Verificação 2: Deve ter pelo menos uma aresta ou face
 	// Linhas conectam os pontos para formar a figura visível
-	if len(figure.Linhas) == 0 {
-		return fmt.Errorf("figura deve ter pelo menos uma linha")
+	// (sólidos podem ser descritos apenas por faces)
+	if len(figure.Linhas) == 0 && len(figure.Faces) == 0 {
+		return fmt.Errorf("figura deve ter pelo menos uma linha ou face")
 	}
 
 	// Verificação 3: Consistência das referências de índices
@@ -112,8 +389,157 @@ func validateFigure(figure *types.Figure) error {
 			return fmt.Errorf("linha %d referencia ponto P2 inválido: %d (deve estar entre 0 e %d)",
 				i, linha.P2, len(figure.Pontos)-1)
 		}
+
+		// Curvas quadráticas ou cúbicas: um ou dois pontos de controle
+		if len(linha.Controle) > 2 {
+			return fmt.Errorf("linha %d deve ter no máximo 2 pontos de controle, tem %d",
+				i, len(linha.Controle))
+		}
+	}
+
+	// Cada face precisa de ao menos três vértices válidos
+	for i, face := range figure.Faces {
+		if len(face.Pontos) < 3 {
+			return fmt.Errorf("face %d deve ter pelo menos 3 pontos, tem %d", i, len(face.Pontos))
+		}
+		for _, p := range face.Pontos {
+			if p < 0 || p >= len(figure.Pontos) {
+				return fmt.Errorf("face %d referencia ponto inválido: %d (deve estar entre 0 e %d)",
+					i, p, len(figure.Pontos)-1)
+			}
+		}
+	}
+
+	// Cada cota liga dois pontos nomeados distintos
+	for i, medida := range figure.Medidas {
+		de, ok := figure.PointIndex(medida.De)
+		if !ok {
+			return fmt.Errorf("medida %d referencia ponto inexistente: %q", i, medida.De)
+		}
+		ate, ok := figure.PointIndex(medida.Ate)
+		if !ok {
+			return fmt.Errorf("medida %d referencia ponto inexistente: %q", i, medida.Ate)
+		}
+		if de == ate {
+			return fmt.Errorf("medida %d liga o ponto %q a ele mesmo", i, medida.De)
+		}
+	}
+
+	// Verificações 4 a 6: Parâmetros da câmera principal e das nomeadas
+	if err := validateCamera(&figure.Camera); err != nil {
+		return err
+	}
+	for _, name := range figure.CameraNames() {
+		camera := figure.Cameras[name]
+		if err := validateCamera(&camera); err != nil {
+			return fmt.Errorf("câmera %q: %w", name, err)
+		}
+	}
+
+	// Verificação 7: Animação da câmera
+	if figure.Animacao != nil {
+		if err := validateAnimation(figure.Animacao); err != nil {
+			return fmt.Errorf("animação: %w", err)
+		}
 	}
 
 	// Se chegou até aqui, a figura é válida
 	return nil
-}
\ No newline at end of file
+}
+
+// validateCamera verifica os parâmetros de uma câmera já completada.
+//
+// Validações realizadas:
+// 1. Campo de visão dentro do intervalo válido
+// 2. Tipo de projeção conhecido
+// 3. Raio positivo quando a câmera usa órbita
+//
+// Parâmetros:
+//   camera: câmera a ser validada
+//
+// Retorna:
+//   error: nil se válida, ou descrição do problema encontrado
+func validateCamera(camera *types.Camera) error {
+	// Verificação 1: Campo de visão, se especificado, deve ser um ângulo útil
+	// (0° não enxerga nada e 180° exigiria uma tela virtual infinita)
+	if camera.FOV < 0 || camera.FOV >= 180 {
+		return fmt.Errorf("fov da câmera inválido: %g (deve estar entre 0 e 180 graus)",
+			camera.FOV)
+	}
+
+	// Verificação 2: Tipo de projeção conhecido
+	switch camera.Projection {
+	case "", types.ProjectionConic, types.ProjectionOrthographic, types.ProjectionOblique:
+	default:
+		return fmt.Errorf("projeção desconhecida: %q (use %s, %s ou %s)",
+			camera.Projection, types.ProjectionConic,
+			types.ProjectionOrthographic, types.ProjectionOblique)
+	}
+
+	// Verificação 3: Órbita precisa de raio positivo
+	// (raio zero colocaria o observador sobre o próprio alvo)
+	if camera.Orbit != nil && camera.Orbit.Radius <= 0 {
+		return fmt.Errorf("raio da órbita da câmera deve ser positivo: %g",
+			camera.Orbit.Radius)
+	}
+
+	return nil
+}
+
+// validateAnimation verifica os quadros-chave da animação da câmera.
+//
+// Validações realizadas:
+// 1. Pelo menos dois quadros-chave (um só não descreve movimento)
+// 2. Tempos não negativos e estritamente crescentes
+// 3. Distância R não negativa (0 = a da câmera da figura)
+// 4. Alvo em todos os quadros-chave ou em nenhum, para que a direção
+//    de visão possa ser interpolada
+// 5. Número de quadros por segundo não negativo (0 = padrão)
+//
+// Parâmetros:
+//   animation: seção "animacao" da figura
+//
+// Retorna:
+//   error: nil se válida, ou descrição do problema encontrado
+func validateAnimation(animation *types.Animation) error {
+	keyframes := animation.Keyframes
+	if len(keyframes) < 2 {
+		return fmt.Errorf("deve ter pelo menos 2 quadros-chave, tem %d", len(keyframes))
+	}
+
+	for i, k := range keyframes {
+		if k.Time < 0 {
+			return fmt.Errorf("quadro-chave %d tem tempo negativo: %g", i, k.Time)
+		}
+		if i > 0 && k.Time <= keyframes[i-1].Time {
+			return fmt.Errorf("quadro-chave %d deve vir depois do anterior: %g s (anterior: %g s)",
+				i, k.Time, keyframes[i-1].Time)
+		}
+		if k.Distance < 0 {
+			return fmt.Errorf("quadro-chave %d tem distância negativa: %g", i, k.Distance)
+		}
+		if (k.Target == nil) != (keyframes[0].Target == nil) {
+			return fmt.Errorf("quadro-chave %d: o alvo deve estar em todos os quadros-chave ou em nenhum", i)
+		}
+		if err := validateEasing(k.Easing); err != nil {
+			return fmt.Errorf("quadro-chave %d: %w", i, err)
+		}
+	}
+
+	if animation.FPS < 0 {
+		return fmt.Errorf("quadros por segundo inválido: %g (deve ser positivo)", animation.FPS)
+	}
+
+	return validateEasing(animation.Easing)
+}
+
+// validateEasing verifica o nome de uma curva de suavização da animação
+// ("" vale como linear).
+func validateEasing(easing string) error {
+	switch easing {
+	case "", types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut, types.EasingCubic, types.EasingHold:
+		return nil
+	}
+	return fmt.Errorf("suavização desconhecida: %q (use %s, %s, %s, %s, %s ou %s)", easing,
+		types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut, types.EasingCubic, types.EasingHold)
+}
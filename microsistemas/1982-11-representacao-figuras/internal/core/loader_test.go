@@ -1,8 +1,12 @@
 package core
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"representacao-figuras/pkg/types"
@@ -99,6 +103,180 @@ linhas:
 	}
 }
 
+func TestLoadFigureFromYAML_FOVCamera(t *testing.T) {
+	// Câmera especificada apenas por FOV não deve ser trocada pela padrão
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test_fov.yaml")
+
+	yamlContent := `nome: fov_test
+pontos:
+  - {x: 0, y: 5, z: 0}
+
+linhas:
+  - {p1: 0, p2: 0}
+
+camera:
+  observador: {x: 1, y: 0, z: 0}
+  fov: 60`
+
+	err := os.WriteFile(testFile, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+
+	if figure.Camera.FOV != 60 {
+		t.Errorf("Expected fov=60, got %f", figure.Camera.FOV)
+	}
+
+	if figure.Camera.Observer.X != 1 {
+		t.Errorf("Expected observer X=1 to be preserved, got %f", figure.Camera.Observer.X)
+	}
+
+	if figure.Camera.Distance != types.DefaultCamera().Distance {
+		t.Errorf("Expected default distance to be filled in, got %f", figure.Camera.Distance)
+	}
+}
+
+func TestLoadFigureFromYAML_OrbitCamera(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test_orbit.yaml")
+
+	yamlContent := `nome: orbit_test
+pontos:
+  - {x: 0, y: 0, z: 0}
+
+linhas:
+  - {p1: 0, p2: 0}
+
+camera:
+  alvo: {x: 0, y: 0, z: 0}
+  orbita: {azimute: 90, elevacao: 0, raio: 12}`
+
+	err := os.WriteFile(testFile, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+
+	// Azimute 90° coloca o observador em +X
+	obs := figure.Camera.Observer
+	if obs.X < 11.999 || obs.X > 12.001 {
+		t.Errorf("Expected observer X=12 from orbit, got %f", obs.X)
+	}
+
+	if figure.Camera.Width != types.DefaultCamera().Width {
+		t.Errorf("Expected default width to be filled in, got %f", figure.Camera.Width)
+	}
+}
+
+func TestLoadFigureFromYAML_ViewPreset(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test_view.yaml")
+
+	yamlContent := `nome: view_test
+pontos:
+  - {x: 0, y: 0, z: 0}
+  - {x: 1, y: 1, z: 1}
+
+linhas:
+  - {p1: 0, p2: 1}
+
+camera:
+  vista: iso`
+
+	err := os.WriteFile(testFile, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+
+	if figure.Camera.Projection != types.ProjectionOrthographic {
+		t.Errorf("Expected orthographic projection, got %q", figure.Camera.Projection)
+	}
+	if !figure.Camera.Auto {
+		t.Error("Expected view preset to enable auto-fit")
+	}
+
+	// Vista desconhecida deve falhar
+	os.WriteFile(testFile, []byte(yamlContent+"_trimetrica"), 0644)
+	if _, err := LoadFigureFromYAML(testFile); err == nil {
+		t.Error("Expected error for unknown view, got nil")
+	}
+}
+
+func TestLoadFigureFromYAML_NamedCameras(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test_cameras.yaml")
+
+	yamlContent := `nome: cameras_test
+pontos:
+  - {x: 0, y: 0, z: 0}
+  - {x: 1, y: 1, z: 1}
+
+linhas:
+  - {p1: 0, p2: 1}
+
+cameras:
+  frente:
+    observador: {x: 0, y: -10, z: 0}
+    distancia: 5
+    largura: 4
+    altura: 3
+  topo:
+    vista: iso
+`
+
+	err := os.WriteFile(testFile, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+
+	// Sem bloco "camera", a câmera principal recebe o padrão
+	if figure.Camera != types.DefaultCamera() {
+		t.Errorf("Expected default main camera, got %+v", figure.Camera)
+	}
+
+	if len(figure.Cameras) != 2 {
+		t.Fatalf("Expected 2 named cameras, got %d", len(figure.Cameras))
+	}
+	if figure.Cameras["frente"].Distance != 5 {
+		t.Errorf("Expected camera frente distance 5, got %g", figure.Cameras["frente"].Distance)
+	}
+
+	// Vistas e padrões também valem para câmeras nomeadas
+	topo := figure.Cameras["topo"]
+	if topo.Projection != types.ProjectionOrthographic || !topo.Auto {
+		t.Errorf("Expected camera topo expanded from view preset, got %+v", topo)
+	}
+	if topo.Distance == 0 {
+		t.Error("Expected camera topo to receive default distance")
+	}
+
+	// Câmera nomeada inválida deve falhar
+	os.WriteFile(testFile, []byte(yamlContent+"  ruim:\n    fov: 200\n"), 0644)
+	if _, err := LoadFigureFromYAML(testFile); err == nil {
+		t.Error("Expected error for invalid named camera, got nil")
+	}
+}
+
 func TestLoadFigureFromYAML_FileNotFound(t *testing.T) {
 	_, err := LoadFigureFromYAML("nonexistent_file.yaml")
 	if err == nil {
@@ -122,6 +300,533 @@ func TestLoadFigureFromYAML_InvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoadFigureFromYAMLWithLimits(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "limits.yaml")
+
+	yamlContent := `nome: limits
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+  - {x: 1, y: 5, z: 1}
+
+linhas:
+  - {p1: 0, p2: 1}
+  - {p1: 1, p2: 2}`
+
+	err := os.WriteFile(testFile, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		limits  LoadLimits
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "no limits",
+			limits:  LoadLimits{},
+			wantErr: false,
+		},
+		{
+			name:    "within limits",
+			limits:  LoadLimits{MaxFileSize: 1024, MaxPoints: 3, MaxLines: 2},
+			wantErr: false,
+		},
+		{
+			name:    "file too large",
+			limits:  LoadLimits{MaxFileSize: 16},
+			wantErr: true,
+			errMsg:  "tamanho máximo",
+		},
+		{
+			name:    "too many points",
+			limits:  LoadLimits{MaxPoints: 2},
+			wantErr: true,
+			errMsg:  "limite de pontos",
+		},
+		{
+			name:    "too many lines",
+			limits:  LoadLimits{MaxLines: 1},
+			wantErr: true,
+			errMsg:  "limite de linhas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFigureFromYAMLWithLimits(testFile, tt.limits)
+
+			if tt.wantErr && err == nil {
+				t.Fatalf("Expected error containing '%s', got nil", tt.errMsg)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if tt.wantErr && !containsString(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'",
+					tt.errMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestDefaultLoadLimits(t *testing.T) {
+	limits := DefaultLoadLimits()
+
+	if limits.MaxFileSize <= 0 || limits.MaxPoints <= 0 || limits.MaxLines <= 0 {
+		t.Errorf("Expected all default limits to be positive, got %+v", limits)
+	}
+}
+
+func TestLoadFigureFromYAML_URL(t *testing.T) {
+	yamlContent := `nome: remote
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+
+linhas:
+  - {p1: 0, p2: 1}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cubo.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(yamlContent))
+	}))
+	defer server.Close()
+
+	figure, err := LoadFigureFromYAML(server.URL + "/cubo.yaml")
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed for URL: %v", err)
+	}
+
+	if figure.Nome != "remote" {
+		t.Errorf("Expected nome='remote', got '%s'", figure.Nome)
+	}
+
+	// Status diferente de 200 deve gerar erro
+	_, err = LoadFigureFromYAML(server.URL + "/missing.yaml")
+	if err == nil {
+		t.Error("Expected error for HTTP 404, got nil")
+	}
+
+	// Limite de tamanho também vale para downloads
+	_, err = LoadFigureFromYAMLWithLimits(server.URL+"/cubo.yaml", LoadLimits{MaxFileSize: 16})
+	if err == nil || !containsString(err.Error(), "tamanho máximo") {
+		t.Errorf("Expected size limit error for URL, got %v", err)
+	}
+}
+
+func TestLoadFigureFromYAML_Style(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "styles"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	style := "fundo: black\ncor_linha: white\nespessura_linha: 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "styles", "print.yaml"), []byte(style), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	geometry := `nome: estilizada
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+linhas:
+  - {p1: 0, p2: 1}
+`
+	tests := []struct {
+		name           string
+		content        string
+		wantBackground string
+		wantLine       string
+		wantErr        bool
+	}{
+		{"relative style file", geometry + "estilo: styles/print.yaml\n", "black", "white", false},
+		{"figure overrides style", geometry + "estilo: styles/print.yaml\nrender:\n  cor_linha: red\n", "black", "red", false},
+		{"built-in style", geometry + "estilo: blueprint\n", "#1d4f91", "white", false},
+		{"missing style file", geometry + "estilo: styles/falta.yaml\n", "", "", true},
+		{"unknown style name", geometry + "estilo: aquarela\n", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(dir, "figura.yaml")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			figure, err := LoadFigureFromYAML(filename)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadFigureFromYAML failed: %v", err)
+			}
+			if figure.Render == nil || figure.Render.Background != tt.wantBackground || figure.Render.LineColor != tt.wantLine {
+				t.Errorf("Expected background %q and lines %q, got %+v", tt.wantBackground, tt.wantLine, figure.Render)
+			}
+		})
+	}
+}
+
+func TestLoadFigureFromYAML_StyleURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/figuras/cubo.yaml":
+			w.Write([]byte("nome: remote\npontos:\n  - {x: 0, y: 5, z: 0}\n  - {x: 1, y: 5, z: 0}\nlinhas:\n  - {p1: 0, p2: 1}\nestilo: ../styles/print.yaml\n"))
+		case "/styles/print.yaml":
+			w.Write([]byte("fundo: black\nmarca_dagua:\n  imagem: logo.png\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	figure, err := LoadFigureFromYAML(server.URL + "/figuras/cubo.yaml")
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed for URL: %v", err)
+	}
+	if figure.Render == nil || figure.Render.Background != "black" {
+		t.Errorf("Expected style relative to the figure URL, got %+v", figure.Render)
+	}
+	// A imagem citada pelo estilo parte da URL do estilo
+	if want := server.URL + "/styles/logo.png"; figure.Render.Watermark == nil || figure.Render.Watermark.Image != want {
+		t.Errorf("Expected watermark %s, got %+v", want, figure.Render.Watermark)
+	}
+}
+
+func TestLoadFigureFromYAML_URLRejectsLocalFiles(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "local.yaml")
+	if err := os.WriteFile(local, []byte("fundo: black\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figures := map[string]string{
+		"/absoluto.yaml": "estilo: " + local + "\n",
+		"/file.yaml":     "estilo: file://" + filepath.ToSlash(local) + "\n",
+		"/script.yaml":   "script: /etc/figura.star\n",
+		"/raiz.yaml":     "script: file:///etc/figura.star\n",
+		"/fonte.yaml":    "render:\n  fonte: /etc/passwd\n",
+		"/marca.yaml":    "render:\n  marca_dagua:\n    imagem: /etc/passwd\n",
+		"/estilo.yaml":   "estilo: estilos/local.yaml\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Estilo da rede que cita a fonte local
+		if r.URL.Path == "/estilos/local.yaml" {
+			w.Write([]byte("fonte: /etc/passwd\n"))
+			return
+		}
+		extra, ok := figures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("nome: remota\npontos:\n  - {x: 0, y: 5, z: 0}\n  - {x: 1, y: 5, z: 0}\nlinhas:\n  - {p1: 0, p2: 1}\n" + extra))
+	}))
+	defer server.Close()
+
+	for path := range figures {
+		_, err := LoadFigureFromYAML(server.URL + path)
+		if err == nil || !strings.Contains(err.Error(), "figura baixada da rede") {
+			t.Errorf("%s: expected local reference to be rejected, got %v", path, err)
+		}
+	}
+
+	// Arquivos locais continuam podendo citar caminhos absolutos
+	figure := filepath.Join(t.TempDir(), "figura.yaml")
+	if err := os.WriteFile(figure, []byte("nome: local\npontos:\n  - {x: 0, y: 5, z: 0}\n  - {x: 1, y: 5, z: 0}\nlinhas:\n  - {p1: 0, p2: 1}\nestilo: "+local+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFigureFromYAML(figure); err != nil {
+		t.Errorf("Expected absolute style path in a local figure to load, got %v", err)
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	tests := []struct {
+		source, name, want string
+	}{
+		{"modelos/casa.yaml", "estilos/print.yaml", filepath.Join("modelos", "estilos", "print.yaml")},
+		{"https://ex.com/f/casa.yaml", "../estilos/print.yaml", "https://ex.com/estilos/print.yaml"},
+		{"https://ex.com/f/casa.yaml", "https://outro.com/print.yaml", "https://outro.com/print.yaml"},
+		{"https://ex.com/f/casa.yaml", "/etc/passwd", ""},
+		{"https://ex.com/f/casa.yaml", "file:///etc/passwd", ""},
+		{"https://ex.com/f/casa.yaml", "//outro.com/print.yaml", ""},
+	}
+	for _, tt := range tests {
+		got, err := relativeTo(tt.source, tt.name)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("relativeTo(%q, %q): expected error, got %q", tt.source, tt.name, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("relativeTo(%q, %q) = %q, %v; expected %q", tt.source, tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestLoadFigureFromYAML_Annotations(t *testing.T) {
+	yamlContent := `nome: anotada
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+linhas:
+  - {p1: 0, p2: 1}
+render:
+  titulo: Cubo
+  legenda:
+    texto: Figura 1
+    posicao: base_esquerda
+    tamanho: 12
+    cor: gray
+`
+	testFile := filepath.Join(t.TempDir(), "anotada.yaml")
+	if err := os.WriteFile(testFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	if figure.Render.Title == nil || *figure.Render.Title != (types.Annotation{Text: "Cubo"}) {
+		t.Errorf("Expected plain title, got %+v", figure.Render.Title)
+	}
+	want := types.Annotation{Text: "Figura 1", Position: "base_esquerda", Size: 12, Color: "gray"}
+	if figure.Render.Caption == nil || *figure.Render.Caption != want {
+		t.Errorf("Expected caption %+v, got %+v", want, figure.Render.Caption)
+	}
+}
+
+func TestLoadFigureFromYAML_RelativePaths(t *testing.T) {
+	dir := t.TempDir()
+	yamlContent := `nome: fonte
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+linhas:
+  - {p1: 0, p2: 1}
+render:
+  fonte: fontes/titulo.ttf
+  marca_dagua:
+    imagem: marca.png
+`
+	testFile := filepath.Join(dir, "fonte.yaml")
+	if err := os.WriteFile(testFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	if want := filepath.Join(dir, "fontes", "titulo.ttf"); figure.Render.Font != want {
+		t.Errorf("Expected font path %s, got %s", want, figure.Render.Font)
+	}
+	if want := filepath.Join(dir, "marca.png"); figure.Render.Watermark.Image != want {
+		t.Errorf("Expected watermark path %s, got %s", want, figure.Render.Watermark.Image)
+	}
+
+	// Os arquivos citados por um estilo partem do diretório do estilo
+	style := "fonte: fontes/estilo.ttf\nmarca_dagua:\n  imagem: logo.png\n"
+	if err := os.MkdirAll(filepath.Join(dir, "estilos"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "estilos", "revista.yaml"), []byte(style), 0644); err != nil {
+		t.Fatal(err)
+	}
+	styled := strings.Replace(yamlContent, "render:\n  fonte: fontes/titulo.ttf\n  marca_dagua:\n    imagem: marca.png\n", "estilo: estilos/revista.yaml\n", 1)
+	if err := os.WriteFile(testFile, []byte(styled), 0644); err != nil {
+		t.Fatal(err)
+	}
+	figure, err = LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML with style failed: %v", err)
+	}
+	if want := filepath.Join(dir, "estilos", "fontes", "estilo.ttf"); figure.Render.Font != want {
+		t.Errorf("Expected style font path %s, got %s", want, figure.Render.Font)
+	}
+	if want := filepath.Join(dir, "estilos", "logo.png"); figure.Render.Watermark == nil || figure.Render.Watermark.Image != want {
+		t.Errorf("Expected style watermark path %s, got %+v", want, figure.Render.Watermark)
+	}
+}
+
+func TestLoadFigureFromYAML_Dimensions(t *testing.T) {
+	yamlContent := `nome: cotada
+pontos:
+  - {x: 0, y: 5, z: 0, nome: A}
+  - {x: 3, y: 5, z: 4, nome: B}
+linhas:
+  - {p1: 0, p2: 1}
+medidas:
+  - {de: A, ate: B}
+  - {de: B, ate: A, afastamento: -30, unidade: cm}
+`
+	testFile := filepath.Join(t.TempDir(), "cotada.yaml")
+	if err := os.WriteFile(testFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	want := []types.Dimension{
+		{De: "A", Ate: "B"},
+		{De: "B", Ate: "A", Afastamento: -30, Unidade: "cm"},
+	}
+	if len(figure.Medidas) != len(want) {
+		t.Fatalf("Expected %d dimensions, got %d", len(want), len(figure.Medidas))
+	}
+	for i := range want {
+		if figure.Medidas[i] != want[i] {
+			t.Errorf("Dimension %d: expected %+v, got %+v", i, want[i], figure.Medidas[i])
+		}
+	}
+}
+
+func TestLoadFigureFromYAML_DirectedLines(t *testing.T) {
+	yamlContent := `nome: vetores
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+  - {x: 0, y: 5, z: 1}
+linhas:
+  - {p1: 0, p2: 1, direcionada: true}
+  - {p1: 0, p2: 2}
+  - {p1: 1, p2: 2, controle: [{x: 1, y: 5, z: 1}]}
+`
+	testFile := filepath.Join(t.TempDir(), "vetores.yaml")
+	if err := os.WriteFile(testFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	want := []types.Line{
+		{P1: 0, P2: 1, Direcionada: true},
+		{P1: 0, P2: 2},
+		{P1: 1, P2: 2, Controle: []types.Point3D{{X: 1, Y: 5, Z: 1}}},
+	}
+	for i := range want {
+		if !reflect.DeepEqual(figure.Linhas[i], want[i]) {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], figure.Linhas[i])
+		}
+	}
+}
+
+func TestLoadFigureFromYAML_EdgesFromFaces(t *testing.T) {
+	faces := `nome: telhado
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 2, y: 5, z: 0}
+  - {x: 2, y: 7, z: 0}
+  - {x: 0, y: 7, z: 0}
+  - {x: 1, y: 6, z: 1}
+linhas: []
+faces:
+  - {pontos: [0, 1, 4]}
+  - {pontos: [1, 2, 4]}
+  - {pontos: [2, 3, 4]}
+  - {pontos: [3, 0, 4]}
+`
+	dir := t.TempDir()
+	testFile := filepath.Join(dir, "telhado.yaml")
+	if err := os.WriteFile(testFile, []byte(faces), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	// Quatro arestas da base e quatro até o topo, cada uma uma vez só
+	if !figure.EdgesFromFaces || len(figure.Linhas) != 8 {
+		t.Errorf("Expected 8 lines derived from faces, got %+v (derived %v)", figure.Linhas, figure.EdgesFromFaces)
+	}
+
+	// Com linhas próprias, nada é derivado
+	withLines := strings.Replace(faces, "linhas: []", "linhas:\n  - {p1: 0, p2: 4}", 1)
+	testFile = filepath.Join(dir, "telhado_linhas.yaml")
+	if err := os.WriteFile(testFile, []byte(withLines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	figure, err = LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	if figure.EdgesFromFaces || len(figure.Linhas) != 1 {
+		t.Errorf("Expected the single declared line, got %+v (derived %v)", figure.Linhas, figure.EdgesFromFaces)
+	}
+}
+
+func TestLoadFigureFromYAML_Animation(t *testing.T) {
+	yamlContent := `nome: voo
+pontos:
+  - {x: 0, y: 5, z: 0}
+  - {x: 1, y: 5, z: 0}
+linhas:
+  - {p1: 0, p2: 1}
+animacao:
+  quadros_por_segundo: 12
+  suavizacao: in-out
+  quadros_chave:
+    - {tempo: 0, observador: {x: -5, y: 0, z: 1}, alvo: {x: 0, y: 5, z: 0}, suavizacao: hold}
+    - {tempo: 1.5, observador: {x: 5, y: 0, z: 1}, distancia: 8, alvo: {x: 1, y: 5, z: 0}}
+`
+	testFile := filepath.Join(t.TempDir(), "voo.yaml")
+	if err := os.WriteFile(testFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure, err := LoadFigureFromYAML(testFile)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	want := &types.Animation{
+		FPS:    12,
+		Easing: types.EasingInOut,
+		Keyframes: []types.Keyframe{
+			{Time: 0, Observer: types.Point3D{X: -5, Z: 1}, Target: &types.Point3D{Y: 5}, Easing: types.EasingHold},
+			{Time: 1.5, Observer: types.Point3D{X: 5, Z: 1}, Distance: 8, Target: &types.Point3D{X: 1, Y: 5}},
+		},
+	}
+	if !reflect.DeepEqual(figure.Animacao, want) {
+		t.Errorf("Expected animation %+v, got %+v", want, figure.Animacao)
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"https://example.com/cubo.yaml", true},
+		{"HTTP://example.com/cubo.yaml", true},
+		{"modelos/cubo.yaml", false},
+		{"/tmp/http.yaml", false},
+	}
+
+	for _, tt := range tests {
+		if got := isURL(tt.input); got != tt.expected {
+			t.Errorf("isURL(%q): expected %v, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
 func TestValidateFigure(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -163,6 +868,174 @@ func TestValidateFigure(t *testing.T) {
 			wantErr: true,
 			errMsg:  "pelo menos uma linha",
 		},
+		{
+			name: "faces only",
+			figure: types.Figure{
+				Nome:   "faces_only",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}, {X: 0, Y: 5, Z: 1}},
+				Faces:  []types.Face{{Pontos: []int{0, 1, 2}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "face with too few points",
+			figure: types.Figure{
+				Nome:   "short_face",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+				Faces:  []types.Face{{Pontos: []int{0, 1}}},
+			},
+			wantErr: true,
+			errMsg:  "pelo menos 3 pontos",
+		},
+		{
+			name: "face with invalid index",
+			figure: types.Figure{
+				Nome:   "bad_face",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}, {X: 0, Y: 5, Z: 1}},
+				Faces:  []types.Face{{Pontos: []int{0, 1, 3}}},
+			},
+			wantErr: true,
+			errMsg:  "face 0 referencia ponto inválido",
+		},
+		{
+			name: "dimension between named points",
+			figure: types.Figure{
+				Nome:    "dimension",
+				Pontos:  []types.Point3D{{X: 0, Y: 5, Z: 0, Nome: "A"}, {X: 1, Y: 5, Z: 0, Nome: "B"}},
+				Linhas:  []types.Line{{P1: 0, P2: 1}},
+				Medidas: []types.Dimension{{De: "A", Ate: "B"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dimension with unknown point",
+			figure: types.Figure{
+				Nome:    "bad_dimension",
+				Pontos:  []types.Point3D{{X: 0, Y: 5, Z: 0, Nome: "A"}, {X: 1, Y: 5, Z: 0, Nome: "B"}},
+				Linhas:  []types.Line{{P1: 0, P2: 1}},
+				Medidas: []types.Dimension{{De: "A", Ate: "C"}},
+			},
+			wantErr: true,
+			errMsg:  "medida 0 referencia ponto inexistente",
+		},
+		{
+			name: "dimension from a point to itself",
+			figure: types.Figure{
+				Nome:    "self_dimension",
+				Pontos:  []types.Point3D{{X: 0, Y: 5, Z: 0, Nome: "A"}, {X: 1, Y: 5, Z: 0, Nome: "B"}},
+				Linhas:  []types.Line{{P1: 0, P2: 1}},
+				Medidas: []types.Dimension{{De: "B", Ate: "B"}},
+			},
+			wantErr: true,
+			errMsg:  "a ele mesmo",
+		},
+		{
+			name: "curve with too many control points",
+			figure: types.Figure{
+				Nome:   "bad_curve",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 1, Controle: make([]types.Point3D, 3)}},
+			},
+			wantErr: true,
+			errMsg:  "no máximo 2 pontos de controle",
+		},
+		{
+			name: "invalid fov",
+			figure: types.Figure{
+				Nome:   "invalid_fov",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Camera: types.Camera{Distance: 10, FOV: 180},
+			},
+			wantErr: true,
+			errMsg:  "fov da câmera inválido",
+		},
+		{
+			name: "invalid orbit radius",
+			figure: types.Figure{
+				Nome:   "invalid_orbit",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Camera: types.Camera{Distance: 10, Orbit: &types.Orbit{Radius: 0}},
+			},
+			wantErr: true,
+			errMsg:  "raio da órbita",
+		},
+		{
+			name: "animation with one keyframe",
+			figure: types.Figure{
+				Nome:     "short_animation",
+				Pontos:   []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas:   []types.Line{{P1: 0, P2: 0}},
+				Animacao: &types.Animation{Keyframes: []types.Keyframe{{Time: 0}}},
+			},
+			wantErr: true,
+			errMsg:  "pelo menos 2 quadros-chave",
+		},
+		{
+			name: "animation out of order",
+			figure: types.Figure{
+				Nome:     "unordered_animation",
+				Pontos:   []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas:   []types.Line{{P1: 0, P2: 0}},
+				Animacao: &types.Animation{Keyframes: []types.Keyframe{{Time: 2}, {Time: 1}}},
+			},
+			wantErr: true,
+			errMsg:  "deve vir depois do anterior",
+		},
+		{
+			name: "animation with partial targets",
+			figure: types.Figure{
+				Nome:   "partial_targets",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Animacao: &types.Animation{Keyframes: []types.Keyframe{
+					{Time: 0, Target: &types.Point3D{Y: 5}},
+					{Time: 1},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "alvo deve estar em todos",
+		},
+		{
+			name: "animation with unknown easing",
+			figure: types.Figure{
+				Nome:   "unknown_easing",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Animacao: &types.Animation{Easing: "bounce", Keyframes: []types.Keyframe{
+					{Time: 0},
+					{Time: 1},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "suavização desconhecida",
+		},
+		{
+			name: "keyframe with unknown easing",
+			figure: types.Figure{
+				Nome:   "unknown_keyframe_easing",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Animacao: &types.Animation{Keyframes: []types.Keyframe{
+					{Time: 0, Easing: "elastic"},
+					{Time: 1},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "quadro-chave 0: suavização desconhecida",
+		},
+		{
+			name: "unknown projection",
+			figure: types.Figure{
+				Nome:   "unknown_projection",
+				Pontos: []types.Point3D{{X: 0, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 0}},
+				Camera: types.Camera{Distance: 10, Projection: "fisheye"},
+			},
+			wantErr: true,
+			errMsg:  "projeção desconhecida",
+		},
 		{
 			name: "invalid line reference",
 			figure: types.Figure{
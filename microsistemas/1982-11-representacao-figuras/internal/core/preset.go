@@ -0,0 +1,251 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PresetDirEnv é a variável de ambiente que indica o diretório dos
+// presets do usuário (ver PresetDir).
+const PresetDirEnv = "FIGURAS3D_PRESETS"
+
+// PresetDir retorna o diretório onde ficam os presets do usuário.
+//
+// É o valor de FIGURAS3D_PRESETS, quando definido (útil para uma equipe
+// compartilhar os mesmos presets num diretório comum), ou
+// "figuras3d/presets" dentro do diretório de configuração do usuário
+// (~/.config no Linux).
+//
+// Retorna:
+//   string: caminho do diretório (que pode não existir)
+//   error: erro se o diretório de configuração não puder ser determinado
+func PresetDir() (string, error) {
+	if dir := os.Getenv(PresetDirEnv); dir != "" {
+		return dir, nil
+	}
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(config, "figuras3d", "presets"), nil
+}
+
+// LoadPreset carrega um preset de configurações de renderização.
+//
+// O arquivo contém apenas as chaves da seção "render" das figuras
+// (fundo, cor_linha, grade...). Diferente das figuras, chaves
+// desconhecidas são erro: um preset com um erro de digitação seria
+// aplicado silenciosamente pela metade. Como as figuras, o preset pode
+// vir de um caminho local ou de uma URL, e a fonte e a imagem da marca
+// d'água que ele citar são procuradas a partir dele (ver
+// resolveRenderFiles).
+//
+// Parâmetros:
+//   filename: caminho ou URL do arquivo (.yaml, .yml ou .json)
+//
+// Retorna:
+//   types.RenderSettings: configurações do preset
+//   error: erro de leitura ou de parse
+func LoadPreset(filename string) (types.RenderSettings, error) {
+	data, err := readSource(filename, DefaultLoadLimits())
+	if err != nil {
+		return types.RenderSettings{}, fmt.Errorf("erro ao ler preset: %w", err)
+	}
+
+	var settings types.RenderSettings
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&settings); err != nil && !errors.Is(err, io.EOF) {
+		return types.RenderSettings{}, fmt.Errorf("erro ao parsear preset %s: %w", filename, err)
+	}
+	if err := resolveRenderFiles(&settings, filename); err != nil {
+		return types.RenderSettings{}, fmt.Errorf("preset %s: %w", filename, err)
+	}
+	return settings, nil
+}
+
+// resolveRenderFiles resolve os arquivos citados por configurações de
+// renderização (a fonte e a imagem da marca d'água) a partir do arquivo
+// de onde elas vieram, uma figura ou um preset (ver relativeTo): quem
+// vem da rede não pode citar arquivos locais.
+//
+// Parâmetros:
+//   settings: configurações recém-lidas, modificadas no lugar
+//   source: caminho ou URL do arquivo que as contém
+//
+// Retorna:
+//   error: erro se algum caminho for recusado
+func resolveRenderFiles(settings *types.RenderSettings, source string) error {
+	var err error
+	if settings.Font != "" {
+		if settings.Font, err = relativeTo(source, settings.Font); err != nil {
+			return fmt.Errorf("fonte inválida: %w", err)
+		}
+	}
+	if w := settings.Watermark; w != nil && w.Image != "" {
+		if w.Image, err = relativeTo(source, w.Image); err != nil {
+			return fmt.Errorf("marca d'água inválida: %w", err)
+		}
+	}
+	return nil
+}
+
+// ApplyPreset aplica um preset às configurações de renderização da figura.
+//
+// Como nos estilos, os campos definidos pelo preset substituem os do
+// YAML da figura e os demais são preservados.
+//
+// Parâmetros:
+//   figure: figura carregada, modificada no lugar
+//   name: nome ou arquivo do preset (ver presetSettings)
+//
+// Retorna:
+//   error: erro se o preset não existir ou não puder ser lido
+func ApplyPreset(figure *types.Figure, name string) error {
+	settings, err := presetSettings(name)
+	if err != nil {
+		return err
+	}
+	if figure.Render == nil {
+		figure.Render = &types.RenderSettings{}
+	}
+	figure.Render.Overlay(settings)
+	return nil
+}
+
+// presetSettings localiza e carrega um preset.
+//
+// O preset pode ser:
+// 1. Um arquivo ou URL (nomes com extensão .yaml, .yml ou .json, ou
+//    com separador de diretório)
+// 2. Um preset do usuário: <nome>.yaml no diretório de PresetDir, que
+//    tem prioridade sobre um estilo embutido de mesmo nome
+// 3. Um estilo embutido (ver types.StyleSettings)
+//
+// Parâmetros:
+//   name: nome ou arquivo do preset
+//
+// Retorna:
+//   types.RenderSettings: configurações do preset
+//   error: erro se o preset não existir ou não puder ser lido
+func presetSettings(name string) (types.RenderSettings, error) {
+	if isPresetFile(name) {
+		return LoadPreset(name)
+	}
+
+	dir, err := PresetDir()
+	if err != nil {
+		return types.StyleSettings(name)
+	}
+	filename := filepath.Join(dir, name+".yaml")
+	if _, err := os.Stat(filename); err == nil {
+		return LoadPreset(filename)
+	}
+
+	settings, err := types.StyleSettings(name)
+	if err != nil {
+		return settings, fmt.Errorf("%w; presets do usuário ficam em %s", err, dir)
+	}
+	return settings, nil
+}
+
+// applyFigureStyle aplica o estilo referenciado pela figura (chave
+// "estilo") por baixo da sua seção "render".
+//
+// O estilo é um preset (ver presetSettings); arquivos com caminho
+// relativo são procurados a partir do diretório da figura (ou da URL de
+// onde ela veio). Os campos da seção "render" da figura têm prioridade
+// sobre os do estilo.
+//
+// Parâmetros:
+//   figure: figura recém-carregada, modificada no lugar
+//   source: caminho ou URL de onde a figura foi carregada
+//
+// Retorna:
+//   error: erro se o estilo não existir ou não puder ser lido
+func applyFigureStyle(figure *types.Figure, source string) error {
+	style := figure.Style
+	if isPresetFile(style) {
+		var err error
+		if style, err = relativeTo(source, style); err != nil {
+			return fmt.Errorf("estilo inválido: %w", err)
+		}
+	}
+
+	settings, err := presetSettings(style)
+	if err != nil {
+		return fmt.Errorf("estilo %q: %w", figure.Style, err)
+	}
+	if figure.Render != nil {
+		settings.Overlay(*figure.Render)
+	}
+	figure.Render = &settings
+	return nil
+}
+
+// relativeTo resolve um arquivo citado pela figura (estilo, script,
+// fonte ou marca d'água) ou por um estilo: caminhos relativos partem do
+// diretório de quem o cita ou da URL de onde ele veio; caminhos
+// absolutos e URLs ficam como estão.
+//
+// Uma figura baixada da rede não pode citar arquivos da máquina: com
+// uma URL como origem, caminhos absolutos e referências com outro
+// esquema que não http(s), como file://, são recusados, para que um
+// YAML publicado não leia arquivos locais de quem o abre.
+//
+// Parâmetros:
+//   source: caminho ou URL da figura
+//   name: arquivo citado
+//
+// Retorna:
+//   string: caminho ou URL do arquivo
+//   error: erro se a URL da figura ou o nome forem inválidos, ou se uma
+//          figura da rede citar um arquivo local
+func relativeTo(source, name string) (string, error) {
+	if !isURL(source) {
+		if isURL(name) || filepath.IsAbs(name) {
+			return name, nil
+		}
+		return filepath.Join(filepath.Dir(source), name), nil
+	}
+
+	if isURL(name) {
+		return name, nil
+	}
+	slashed := filepath.ToSlash(name)
+	if filepath.IsAbs(name) || strings.HasPrefix(slashed, "/") {
+		return "", fmt.Errorf("figura baixada da rede não pode citar o caminho absoluto %q", name)
+	}
+	base, err := url.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("URL da figura inválida: %w", err)
+	}
+	ref, err := url.Parse(slashed)
+	if err != nil {
+		return "", err
+	}
+	if ref.Scheme != "" || ref.Host != "" {
+		return "", fmt.Errorf("figura baixada da rede só pode citar caminhos relativos ou URLs http(s), não %q", name)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// isPresetFile informa se o nome do preset é um arquivo (ou URL) em vez
+// de um nome a procurar entre os presets do usuário e os embutidos.
+func isPresetFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return isURL(name) || strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator)
+}
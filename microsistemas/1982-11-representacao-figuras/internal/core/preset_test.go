@@ -0,0 +1,94 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func writePreset(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	filename := filepath.Join(dir, name)
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write preset: %v", err)
+	}
+	return filename
+}
+
+func TestLoadPreset(t *testing.T) {
+	dir := t.TempDir()
+
+	settings, err := LoadPreset(writePreset(t, dir, "equipe.yaml", "fundo: black\ncor_linha: \"#00ff00\"\ngrade:\n  espacamento: 20\n"))
+	if err != nil {
+		t.Fatalf("LoadPreset failed: %v", err)
+	}
+	if settings.Background != "black" || settings.LineColor != "#00ff00" || settings.Grid == nil || settings.Grid.Spacing != 20 {
+		t.Errorf("Expected preset settings, got %+v", settings)
+	}
+
+	// JSON é lido pelo mesmo parser
+	settings, err = LoadPreset(writePreset(t, dir, "equipe.json", `{"espessura_linha": 3}`))
+	if err != nil || settings.LineWidth != 3 {
+		t.Errorf("Expected JSON preset with line width 3, got %+v (%v)", settings, err)
+	}
+
+	// Preset vazio não altera nada
+	if _, err := LoadPreset(writePreset(t, dir, "vazio.yaml", "")); err != nil {
+		t.Errorf("Expected empty preset to load, got %v", err)
+	}
+
+	// Chave com erro de digitação
+	if _, err := LoadPreset(writePreset(t, dir, "erro.yaml", "cor_linhas: red\n")); err == nil {
+		t.Error("Expected error for unknown key, got nil")
+	}
+	if _, err := LoadPreset(filepath.Join(dir, "inexistente.yaml")); err == nil {
+		t.Error("Expected error for missing file, got nil")
+	}
+}
+
+func TestApplyPreset(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(PresetDirEnv, dir)
+	writePreset(t, dir, "equipe.yaml", "fundo: black\n")
+	writePreset(t, dir, "blueprint.yaml", "fundo: navy\n")
+	file := writePreset(t, dir, "outro.yml", "cor_linha: red\n")
+
+	tests := []struct {
+		name           string
+		preset         string
+		wantBackground string
+		wantLine       string
+	}{
+		{"user preset by name", "equipe", "black", "gray"},
+		{"user preset overrides built-in", "blueprint", "navy", "gray"},
+		{"file path", file, "", "red"},
+		{"built-in style", "heliografica", "#1d4f91", "white"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			figure := &types.Figure{Render: &types.RenderSettings{LineColor: "gray"}}
+			if err := ApplyPreset(figure, tt.preset); err != nil {
+				t.Fatalf("ApplyPreset failed: %v", err)
+			}
+			if figure.Render.Background != tt.wantBackground || figure.Render.LineColor != tt.wantLine {
+				t.Errorf("Expected background %q and lines %q, got %q and %q",
+					tt.wantBackground, tt.wantLine, figure.Render.Background, figure.Render.LineColor)
+			}
+		})
+	}
+
+	// Figura sem seção render
+	figure := &types.Figure{}
+	if err := ApplyPreset(figure, "equipe"); err != nil || figure.Render == nil || figure.Render.Background != "black" {
+		t.Errorf("Expected render settings to be created, got %+v (%v)", figure.Render, err)
+	}
+
+	for _, name := range []string{"desconhecido", filepath.Join(dir, "falta.yaml")} {
+		if err := ApplyPreset(&types.Figure{}, name); err == nil {
+			t.Errorf("Expected error for preset %q, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	starlarkmath "go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	"representacao-figuras/pkg/types"
+)
+
+// scriptOptions são os recursos da linguagem liberados nos scripts:
+// laços e condições fora das funções, while e recursão. O limite de
+// passos (LoadLimits.MaxScriptSteps) é que impede os laços infinitos.
+var scriptOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// applyFigureScript executa o script referenciado pela figura (chave
+// "script"), que acrescenta pontos, linhas e faces aos do YAML.
+//
+// O script é procurado a partir do diretório da figura (ou da URL de
+// onde ela veio), como o estilo, e lido com o mesmo limite de tamanho.
+//
+// Parâmetros:
+//   figure: figura recém-carregada, modificada no lugar
+//   source: caminho ou URL de onde a figura foi carregada
+//   limits: limites de tamanho, de passos e de elementos
+//
+// Retorna:
+//   error: erro se o script não puder ser lido ou falhar
+func applyFigureScript(figure *types.Figure, source string, limits LoadLimits) error {
+	name, err := relativeTo(source, figure.Script)
+	if err != nil {
+		return fmt.Errorf("script inválido: %w", err)
+	}
+	src, err := readSource(name, limits)
+	if err != nil {
+		return fmt.Errorf("erro ao ler script: %w", err)
+	}
+	return runScript(figure, figure.Script, src, limits)
+}
+
+// runScript executa um script Starlark sobre a figura.
+//
+// Starlark é um dialeto de Python feito para ser embutido: o script não
+// tem acesso a arquivos, à rede nem ao relógio, e só conversa com a
+// figura pelas funções predefinidas:
+//
+//   ponto(x, y, z, nome="")        acrescenta um ponto e retorna o índice
+//   linha(a, b, direcionada=False) liga dois pontos (índices ou nomes)
+//   face(a, b, c, ..., cor="")     acrescenta uma face
+//
+// além do módulo math (math.sin, math.pi, ...). Os índices contam também
+// os pontos do YAML, que o script pode ligar pelo nome; print escreve na
+// saída de erros, para depuração.
+//
+// O limite de passos não limita a memória: uma única operação, como
+// [0] * 10**9, aloca gigabytes num passo só (o Starlark recusa apenas
+// listas e textos de 2³⁰ elementos ou mais). Figuras de fontes não
+// confiáveis devem ser carregadas num processo com a memória limitada.
+//
+// Parâmetros:
+//   figure: figura, modificada no lugar
+//   filename: nome do script, para as mensagens de erro
+//   src: código do script
+//   limits: limites de passos e de elementos (zero = sem limite)
+//
+// Retorna:
+//   error: erro de sintaxe ou de execução, com a linha do script, ou
+//          limite excedido
+func runScript(figure *types.Figure, filename string, src []byte, limits LoadLimits) error {
+	thread := &starlark.Thread{
+		Name:  filename,
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintf(os.Stderr, "%s: %s\n", filename, msg) },
+		Load: func(*starlark.Thread, string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("load não é permitido nos scripts")
+		},
+	}
+	if limits.MaxScriptSteps > 0 {
+		thread.SetMaxExecutionSteps(limits.MaxScriptSteps)
+	}
+
+	b := &scriptBuilder{figure: figure, limits: limits}
+	predeclared := starlark.StringDict{
+		"ponto": starlark.NewBuiltin("ponto", b.point),
+		"linha": starlark.NewBuiltin("linha", b.line),
+		"face":  starlark.NewBuiltin("face", b.face),
+		"math":  starlarkmath.Module,
+	}
+
+	_, err := starlark.ExecFileOptions(scriptOptions, thread, filename, src, predeclared)
+	if err == nil {
+		return nil
+	}
+	if limits.MaxScriptSteps > 0 && thread.ExecutionSteps() >= limits.MaxScriptSteps {
+		return fmt.Errorf("script %s: passou de %d passos de execução (laço infinito?)", filename, limits.MaxScriptSteps)
+	}
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		// A posição é a da última chamada feita no próprio script
+		for i := len(evalErr.CallStack) - 1; i >= 0; i-- {
+			if pos := evalErr.CallStack[i].Pos; pos.Line > 0 {
+				return fmt.Errorf("script %s:%d:%d: %s", filename, pos.Line, pos.Col, evalErr.Msg)
+			}
+		}
+	}
+	return fmt.Errorf("script %s: %w", filename, err)
+}
+
+// scriptBuilder implementa as funções predefinidas dos scripts, que
+// acrescentam elementos à figura.
+type scriptBuilder struct {
+	figure *types.Figure
+	limits LoadLimits
+}
+
+// point implementa ponto(x, y, z, nome="").
+func (b *scriptBuilder) point(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y, z scriptNumber
+	var name string
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "x", &x, "y", &y, "z", &z, "nome?", &name); err != nil {
+		return nil, err
+	}
+	if max := b.limits.MaxPoints; max > 0 && len(b.figure.Pontos) >= max {
+		return nil, fmt.Errorf("a figura passou do limite de %d pontos", max)
+	}
+	b.figure.Pontos = append(b.figure.Pontos, types.Point3D{X: float64(x), Y: float64(y), Z: float64(z), Nome: name})
+	return starlark.MakeInt(len(b.figure.Pontos) - 1), nil
+}
+
+// line implementa linha(a, b, direcionada=False).
+func (b *scriptBuilder) line(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var p1, p2 starlark.Value
+	var directed bool
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "a", &p1, "b", &p2, "direcionada?", &directed); err != nil {
+		return nil, err
+	}
+	a, err := b.index(p1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+	}
+	c, err := b.index(p2)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+	}
+	if max := b.limits.MaxLines; max > 0 && len(b.figure.Linhas) >= max {
+		return nil, fmt.Errorf("a figura passou do limite de %d linhas", max)
+	}
+	b.figure.Linhas = append(b.figure.Linhas, types.Line{P1: a, P2: c, Direcionada: directed})
+	return starlark.None, nil
+}
+
+// face implementa face(a, b, c, ..., cor="").
+func (b *scriptBuilder) face(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var color string
+	if err := starlark.UnpackArgs(fn.Name(), nil, kwargs, "cor?", &color); err != nil {
+		return nil, err
+	}
+	if len(args) < 3 {
+		return nil, fmt.Errorf("%s: a face precisa de pelo menos 3 pontos, tem %d", fn.Name(), len(args))
+	}
+	points := make([]int, len(args))
+	for i, arg := range args {
+		var err error
+		if points[i], err = b.index(arg); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+		}
+	}
+	b.figure.Faces = append(b.figure.Faces, types.Face{Pontos: points, Cor: color})
+	return starlark.None, nil
+}
+
+// index converte a referência a um ponto, o índice ou o nome, no índice.
+func (b *scriptBuilder) index(v starlark.Value) (int, error) {
+	if name, ok := starlark.AsString(v); ok {
+		i, found := b.figure.PointIndex(name)
+		if !found {
+			return 0, fmt.Errorf("ponto inexistente: %q", name)
+		}
+		return i, nil
+	}
+	var i int
+	if err := starlark.AsInt(v, &i); err != nil {
+		return 0, fmt.Errorf("ponto deve ser um índice ou um nome, não %s", v.Type())
+	}
+	if i < 0 || i >= len(b.figure.Pontos) {
+		return 0, fmt.Errorf("ponto inválido: %d (deve estar entre 0 e %d)", i, len(b.figure.Pontos)-1)
+	}
+	return i, nil
+}
+
+// scriptNumber é uma coordenada dos scripts: aceita inteiros e números
+// com ponto decimal.
+type scriptNumber float64
+
+// Unpack implementa starlark.Unpacker.
+func (n *scriptNumber) Unpack(v starlark.Value) error {
+	f, ok := starlark.AsFloat(v)
+	if !ok {
+		return fmt.Errorf("esperava um número, não %s", v.Type())
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("coordenada inválida: %g", f)
+	}
+	*n = scriptNumber(f)
+	return nil
+}
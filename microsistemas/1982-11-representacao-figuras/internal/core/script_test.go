@@ -0,0 +1,134 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRunScript(t *testing.T) {
+	figure := &types.Figure{Pontos: []types.Point3D{{X: 0, Y: 0, Z: 0, Nome: "O"}}}
+	src := `
+a = ponto(1, 0, 0)
+b = ponto(0, 1.5, 0, nome = "B")
+c = ponto(math.cos(0), 0, 2)
+linha("O", a)
+linha(a, "B", direcionada = True)
+for i in range(2):
+    linha(b, c)
+face(0, a, b, cor = "red")
+`
+	if err := runScript(figure, "teste.star", []byte(src), DefaultLoadLimits()); err != nil {
+		t.Fatalf("runScript failed: %v", err)
+	}
+
+	if len(figure.Pontos) != 4 {
+		t.Fatalf("Expected 4 points, got %d", len(figure.Pontos))
+	}
+	if figure.Pontos[2] != (types.Point3D{X: 0, Y: 1.5, Z: 0, Nome: "B"}) || figure.Pontos[3].X != 1 {
+		t.Errorf("Unexpected points: %+v", figure.Pontos)
+	}
+	want := []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2, Direcionada: true}, {P1: 2, P2: 3}, {P1: 2, P2: 3}}
+	if len(figure.Linhas) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), figure.Linhas)
+	}
+	for i := range want {
+		if got := figure.Linhas[i]; got.P1 != want[i].P1 || got.P2 != want[i].P2 || got.Direcionada != want[i].Direcionada {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], figure.Linhas[i])
+		}
+	}
+	if len(figure.Faces) != 1 || len(figure.Faces[0].Pontos) != 3 || figure.Faces[0].Cor != "red" {
+		t.Errorf("Expected one red triangle, got %+v", figure.Faces)
+	}
+}
+
+func TestRunScriptErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		limits  LoadLimits
+		wantErr string
+	}{
+		{"syntax error", "a = ponto(0, 0, 0)\nlinha(a, \n", LoadLimits{}, "teste.star:3:1"},
+		{"runtime error line", "a = ponto(0, 0, 0)\n\nlinha(a, 7)\n", LoadLimits{}, "teste.star:3:6: linha: ponto inválido: 7"},
+		{"unknown name", "ponto(0, 0, 0)\nlinha(0, \"X\")\n", LoadLimits{}, "ponto inexistente"},
+		{"bad reference", "ponto(0, 0, 0)\nlinha(0, 1.5)\n", LoadLimits{}, "índice ou um nome"},
+		{"small face", "face(ponto(0, 0, 0), ponto(1, 0, 0))\n", LoadLimits{}, "pelo menos 3 pontos"},
+		{"nan coordinate", "ponto(float(\"nan\"), 0, 0)\n", LoadLimits{}, "coordenada inválida"},
+		{"string coordinate", "ponto(\"1\", 0, 0)\n", LoadLimits{}, "esperava um número"},
+		{"infinite loop", "while True:\n    pass\n", LoadLimits{MaxScriptSteps: 1000}, "passou de 1000 passos"},
+		{"too many points", "for i in range(10):\n    ponto(i, 0, 0)\n", LoadLimits{MaxPoints: 5}, "limite de 5 pontos"},
+		{"too many lines", "a = ponto(0, 0, 0)\nb = ponto(1, 0, 0)\nfor i in range(10):\n    linha(a, b)\n", LoadLimits{MaxLines: 3}, "limite de 3 linhas"},
+		{"load forbidden", "load(\"outro.star\", \"x\")\n", LoadLimits{}, "load não é permitido"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runScript(&types.Figure{}, "teste.star", []byte(tt.src), tt.limits)
+			if err == nil || !containsString(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadFigureFromYAML_Script(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Anel de 12 pontos ligados em volta do ponto do YAML
+	script := `
+n = 12
+anel = [ponto(math.cos(2 * math.pi * i / n), math.sin(2 * math.pi * i / n), 0) for i in range(n)]
+for i in range(n):
+    linha(anel[i], anel[(i + 1) % n])
+    linha("CENTRO", anel[i])
+`
+	if err := os.WriteFile(filepath.Join(dir, "scripts", "anel.star"), []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	figure := `nome: anel
+pontos:
+  - {x: 0, y: 0, z: 0, nome: CENTRO}
+linhas: []
+script: scripts/anel.star
+`
+	filename := filepath.Join(dir, "anel.yaml")
+	if err := os.WriteFile(filename, []byte(figure), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadFigureFromYAML(filename)
+	if err != nil {
+		t.Fatalf("LoadFigureFromYAML failed: %v", err)
+	}
+	if len(loaded.Pontos) != 13 || len(loaded.Linhas) != 24 {
+		t.Errorf("Expected 13 points and 24 lines, got %d and %d", len(loaded.Pontos), len(loaded.Linhas))
+	}
+
+	// Gravada e lida de novo, a figura não roda o script outra vez
+	saved := filepath.Join(dir, "anel_salvo.yaml")
+	if err := SaveFigureFile(saved, loaded); err != nil {
+		t.Fatalf("SaveFigureFile failed: %v", err)
+	}
+	if reloaded, err := LoadFigureFromYAML(saved); err != nil || len(reloaded.Pontos) != 13 || len(reloaded.Linhas) != 24 {
+		t.Errorf("Expected the saved figure with 13 points and 24 lines, got %v", err)
+	}
+	if loaded.Script == "" {
+		t.Error("Expected the loaded figure untouched")
+	}
+
+	// Script inexistente
+	missing := strings.Replace(figure, "anel.star", "falta.star", 1)
+	if err := os.WriteFile(filename, []byte(missing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFigureFromYAML(filename); err == nil || !containsString(err.Error(), "erro ao ler script") {
+		t.Errorf("Expected error for missing script, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"os"
+)
+
+// NewFromImage cria um renderizador cuja tela é uma cópia de uma imagem
+// pronta, para aplicar os efeitos de pós-processamento (Upscale, ApplyCRT,
+// Quantize) a cada quadro de uma animação sem alterar o renderizador que
+// a desenha.
+//
+// Parâmetros:
+//   img: imagem a copiar
+//
+// Retorna:
+//   *Renderer3D: renderizador com a imagem, sem superamostragem
+func NewFromImage(img image.Image) *Renderer3D {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, b.Min, draw.Src)
+
+	r := &Renderer3D{basis: defaultBasis()}
+	r.setFinalImage(rgba)
+	return r
+}
+
+// SaveGIF grava uma animação GIF que repete indefinidamente.
+//
+// As cores de cada quadro são reduzidas à paleta do Plan 9 (256 cores,
+// com muitos tons de cinza), sem pontilhado, que borraria as linhas
+// finas. O último quadro pode ficar na tela por mais tempo antes de a
+// animação recomeçar (como na construção da figura) ou o mesmo tempo
+// que os demais (como no giro, que não tem fim nem começo).
+//
+// Parâmetros:
+//   filename: caminho do arquivo .gif
+//   frames: quadros da animação, todos do mesmo tamanho
+//   delay: intervalo entre os quadros em milissegundos
+//   hold: tempo do último quadro na tela em milissegundos
+//
+// Retorna:
+//   error: erro se não houver quadros, algum tempo for inválido ou a
+//          gravação falhar
+func SaveGIF(filename string, frames []image.Image, delay, hold int) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("nenhum quadro para a animação")
+	}
+	if delay <= 0 || hold <= 0 {
+		return fmt.Errorf("intervalo entre quadros inválido: %d ms (deve ser positivo)", min(delay, hold))
+	}
+
+	anim := &gif.GIF{}
+	for i, frame := range frames {
+		b := frame.Bounds()
+		pal := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), palette.Plan9)
+		draw.Draw(pal, pal.Rect, frame, b.Min, draw.Src)
+
+		// O GIF conta o tempo em centésimos de segundo
+		ms := delay
+		if i == len(frames)-1 {
+			ms = hold
+		}
+		anim.Image = append(anim.Image, pal)
+		anim.Delay = append(anim.Delay, max(1, ms/10))
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, anim); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
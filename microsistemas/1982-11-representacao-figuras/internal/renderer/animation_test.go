@@ -0,0 +1,77 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFromImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(10, 10, 30, 20))
+	src.Set(10, 10, color.RGBA{R: 255, A: 255})
+
+	r := NewFromImage(src)
+	img := r.image()
+	if img.Rect != image.Rect(0, 0, 20, 10) {
+		t.Fatalf("Expected 20x10 image at origin, got %v", img.Rect)
+	}
+	if c := img.RGBAAt(0, 0); c != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("Expected copied red pixel, got %v", c)
+	}
+
+	// A cópia é independente da imagem de origem
+	src.Set(11, 10, color.RGBA{G: 255, A: 255})
+	if c := img.RGBAAt(1, 0); c != (color.RGBA{}) {
+		t.Errorf("Expected copy independent of source, got %v", c)
+	}
+}
+
+func TestSaveGIF(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveGIF(filepath.Join(dir, "vazio.gif"), nil, 100, 100); err == nil {
+		t.Error("Expected error for no frames")
+	}
+
+	frames := []image.Image{
+		image.NewRGBA(image.Rect(0, 0, 8, 6)),
+		image.NewRGBA(image.Rect(0, 0, 8, 6)),
+		image.NewRGBA(image.Rect(0, 0, 8, 6)),
+	}
+	if err := SaveGIF(filepath.Join(dir, "zero.gif"), frames, 0, 100); err == nil {
+		t.Error("Expected error for zero delay")
+	}
+	if err := SaveGIF(filepath.Join(dir, "zero.gif"), frames, 100, 0); err == nil {
+		t.Error("Expected error for zero hold")
+	}
+
+	filename := filepath.Join(dir, "construcao.gif")
+	if err := SaveGIF(filename, frames, 250, 2000); err != nil {
+		t.Fatalf("SaveGIF failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	anim, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("Invalid GIF: %v", err)
+	}
+
+	// O último quadro fica mais tempo na tela
+	want := []int{25, 25, 200}
+	if len(anim.Delay) != len(want) {
+		t.Fatalf("Expected %d frames, got %d", len(want), len(anim.Delay))
+	}
+	for i, d := range anim.Delay {
+		if d != want[i] {
+			t.Errorf("Frame %d: expected delay %d, got %d", i, want[i], d)
+		}
+	}
+	if b := anim.Image[0].Bounds(); b.Dx() != 8 || b.Dy() != 6 {
+		t.Errorf("Expected 8x6 frames, got %v", b)
+	}
+}
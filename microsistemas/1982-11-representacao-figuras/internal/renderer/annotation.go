@@ -0,0 +1,178 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fogleman/gg"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Tamanhos padrão dos textos, em pixels da imagem final.
+const (
+	DefaultTitleSize   = 24 // Título
+	DefaultCaptionSize = 16 // Legenda
+)
+
+// annotationMargin é a distância, em pixels, entre os textos e as
+// bordas da imagem.
+const annotationMargin = 12
+
+// AnnotationPosition define onde um texto é escrito na imagem.
+type AnnotationPosition string
+
+// Posições dos textos: centrados no topo ou na base, ou nos cantos.
+const (
+	PositionTop         AnnotationPosition = "topo"
+	PositionBottom      AnnotationPosition = "base"
+	PositionTopLeft     AnnotationPosition = "topo_esquerda"
+	PositionTopRight    AnnotationPosition = "topo_direita"
+	PositionBottomLeft  AnnotationPosition = "base_esquerda"
+	PositionBottomRight AnnotationPosition = "base_direita"
+)
+
+// annotationPositionAliases aceita também os nomes em inglês das posições.
+var annotationPositionAliases = map[string]AnnotationPosition{
+	"topo":          PositionTop,
+	"top":           PositionTop,
+	"base":          PositionBottom,
+	"bottom":        PositionBottom,
+	"topo_esquerda": PositionTopLeft,
+	"top_left":      PositionTopLeft,
+	"topo_direita":  PositionTopRight,
+	"top_right":     PositionTopRight,
+	"base_esquerda": PositionBottomLeft,
+	"bottom_left":   PositionBottomLeft,
+	"base_direita":  PositionBottomRight,
+	"bottom_right":  PositionBottomRight,
+}
+
+// parseAnnotationPosition converte o nome de uma posição (português ou
+// inglês, com hífen ou sublinhado).
+//
+// Parâmetros:
+//   value: nome da posição, sem distinção de maiúsculas
+//
+// Retorna:
+//   AnnotationPosition: posição correspondente
+//   error: erro se o nome for desconhecido
+func parseAnnotationPosition(value string) (AnnotationPosition, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), "-", "_")
+	position, ok := annotationPositionAliases[key]
+	if !ok {
+		return "", fmt.Errorf("posição desconhecida: %q (use %s, %s, %s, %s, %s ou %s)",
+			value, PositionTop, PositionBottom, PositionTopLeft, PositionTopRight,
+			PositionBottomLeft, PositionBottomRight)
+	}
+	return position, nil
+}
+
+// Annotation é um texto escrito sobre a imagem: o título ou a legenda.
+type Annotation struct {
+	Text     string             // Texto (vazio no título = nome da figura)
+	Position AnnotationPosition // Onde o texto é escrito
+	Size     float64            // Altura da fonte em pixels
+	Color    colorRGB           // Cor do texto
+}
+
+// parseAnnotation converte a descrição YAML de um título ou legenda,
+// preenchendo o que não foi informado com os padrões.
+//
+// Parâmetros:
+//   a: descrição do YAML
+//   position: posição padrão
+//   size: tamanho padrão da fonte em pixels
+//   color: cor padrão do texto
+//
+// Retorna:
+//   *Annotation: texto pronto para ser desenhado
+//   error: erro se a posição, o tamanho ou a cor forem inválidos
+func parseAnnotation(a types.Annotation, position AnnotationPosition, size float64, color colorRGB) (*Annotation, error) {
+	out := &Annotation{Text: a.Text, Position: position, Size: size, Color: color}
+
+	if a.Position != "" {
+		p, err := parseAnnotationPosition(a.Position)
+		if err != nil {
+			return nil, err
+		}
+		out.Position = p
+	}
+	if a.Size < 0 {
+		return nil, fmt.Errorf("tamanho inválido: %g (deve ser positivo)", a.Size)
+	}
+	if a.Size > 0 {
+		out.Size = a.Size
+	}
+	if a.Color != "" {
+		col, err := parseColor(a.Color)
+		if err != nil {
+			return nil, fmt.Errorf("cor inválida: %w", err)
+		}
+		out.Color = col
+	}
+	return out, nil
+}
+
+// drawAnnotation escreve um título ou uma legenda na área do fundo (ver
+// backgroundArea).
+//
+// O texto é quebrado em linhas para caber na largura da imagem e
+// alinhado conforme a posição: centrado no topo ou na base, ou junto
+// a um dos cantos. Como a gg não aplica a escala do contexto às letras
+// (ver setLineWidth), o texto é escrito diretamente nos pixels da tela
+// interna, com a fonte ampliada pelo fator de superamostragem.
+//
+// Parâmetros:
+//   a: aparência do texto
+//   text: texto efetivo (já com o padrão aplicado)
+//   cfg: configurações visuais (arquivo de fonte)
+func (r *Renderer3D) drawAnnotation(a *Annotation, text string, cfg RenderConfig) {
+	if text == "" {
+		return
+	}
+	scale := float64(r.scale)
+
+	r.context.Push()
+	defer r.context.Pop()
+	r.context.Identity()
+	r.context.SetFontFace(r.fontFace(cfg, a.Size))
+
+	// Ponto de referência do bloco de texto e alinhamento das linhas
+	area := r.backgroundArea()
+	x, ax, align := area.X+area.Width/2, 0.5, gg.AlignCenter
+	switch a.Position {
+	case PositionTopLeft, PositionBottomLeft:
+		x, ax, align = area.X+annotationMargin, 0, gg.AlignLeft
+	case PositionTopRight, PositionBottomRight:
+		x, ax, align = area.X+area.Width-annotationMargin, 1, gg.AlignRight
+	}
+	y, ay := area.Y+annotationMargin, 0.0
+	switch a.Position {
+	case PositionBottom, PositionBottomLeft, PositionBottomRight:
+		y, ay = area.Y+area.Height-annotationMargin, 1
+	}
+
+	width := area.Width - 2*annotationMargin
+	r.setColor(a.Color)
+	r.context.DrawStringWrapped(text, x*scale, y*scale, ax, ay, width*scale, 1.2, align)
+}
+
+// drawAnnotations escreve o título e a legenda configurados.
+//
+// Parâmetros:
+//   figure: figura desenhada (o nome é o título padrão)
+//   cfg: configurações visuais (título e legenda)
+func (r *Renderer3D) drawAnnotations(figure *types.Figure, cfg RenderConfig) {
+	if cfg.Title != nil {
+		text := cfg.Title.Text
+		if text == "" {
+			text = figure.Nome
+		}
+		r.drawAnnotation(cfg.Title, text, cfg)
+	}
+	if cfg.Caption != nil {
+		r.drawAnnotation(cfg.Caption, cfg.Caption.Text, cfg)
+	}
+	r.setColor(cfg.LineColor)
+}
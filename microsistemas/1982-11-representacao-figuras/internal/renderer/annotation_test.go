@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestParseAnnotationPosition(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    AnnotationPosition
+		wantErr bool
+	}{
+		{"topo", PositionTop, false},
+		{"Bottom", PositionBottom, false},
+		{"base_direita", PositionBottomRight, false},
+		{"top-left", PositionTopLeft, false},
+		{"meio", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAnnotationPosition(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.value, tt.wantErr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %q, got %q", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestParseAnnotation(t *testing.T) {
+	black := colorRGB{A: 1}
+
+	got, err := parseAnnotation(types.Annotation{Text: "Cubo"}, PositionTop, DefaultTitleSize, black)
+	if err != nil {
+		t.Fatalf("parseAnnotation failed: %v", err)
+	}
+	if *got != (Annotation{Text: "Cubo", Position: PositionTop, Size: DefaultTitleSize, Color: black}) {
+		t.Errorf("Expected defaults, got %+v", got)
+	}
+
+	got, err = parseAnnotation(types.Annotation{Position: "top_right", Size: 30, Color: "red"}, PositionBottom, DefaultCaptionSize, black)
+	if err != nil {
+		t.Fatalf("parseAnnotation failed: %v", err)
+	}
+	if *got != (Annotation{Position: PositionTopRight, Size: 30, Color: colorRGB{R: 1, A: 1}}) {
+		t.Errorf("Expected overrides, got %+v", got)
+	}
+
+	for _, a := range []types.Annotation{{Position: "meio"}, {Size: -1}, {Color: "nocolor"}} {
+		if _, err := parseAnnotation(a, PositionTop, DefaultTitleSize, black); err == nil {
+			t.Errorf("Expected error for %+v, got nil", a)
+		}
+	}
+}
+
+func TestRenderFigureWithConfig_Annotations(t *testing.T) {
+	figure := &types.Figure{
+		Nome:   "teste",
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	// inked informa se há texto na faixa horizontal [x0, x1) × [y0, y1)
+	inked := func(img *image.RGBA, x0, y0, x1, y1 int) bool {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				if img.RGBAAt(x, y).G < 128 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, factor := range []int{1, 2} {
+		cfg := DefaultRenderConfig()
+		cfg.Title = &Annotation{Position: PositionTop, Size: DefaultTitleSize, Color: colorRGB{A: 1}}
+		cfg.Caption = &Annotation{Text: "legenda", Position: PositionBottomRight, Size: 12, Color: colorRGB{A: 1}}
+
+		renderer := New(400, 300)
+		if err := renderer.SetSupersampling(factor); err != nil {
+			t.Fatal(err)
+		}
+		renderer.SetCamera(types.DefaultCamera())
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+		img := renderer.image()
+
+		// Título (o nome da figura) centrado no topo, legenda no canto
+		// inferior direito, e nada nos outros cantos
+		if !inked(img, 170, annotationMargin, 230, annotationMargin+DefaultTitleSize) {
+			t.Errorf("%dx: expected title at the top center", factor)
+		}
+		if !inked(img, 330, 300-annotationMargin-12, 400-annotationMargin, 300-annotationMargin) {
+			t.Errorf("%dx: expected caption at the bottom right", factor)
+		}
+		if inked(img, 0, 0, 100, 50) || inked(img, 0, 250, 100, 300) {
+			t.Errorf("%dx: expected nothing at the left corners", factor)
+		}
+	}
+}
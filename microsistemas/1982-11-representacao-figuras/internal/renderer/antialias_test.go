@@ -0,0 +1,70 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestDownsample(t *testing.T) {
+	// Bloco 2×2 com metade dos pixels pretos e metade brancos
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{A: 255}
+			if x == 1 || x >= 2 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			src.SetRGBA(x, y, c)
+		}
+	}
+
+	dst := downsample(src, 2)
+	if dst.Bounds() != image.Rect(0, 0, 2, 1) {
+		t.Fatalf("Expected 2×1 image, got %v", dst.Bounds())
+	}
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 128, G: 128, B: 128, A: 255}) {
+		t.Errorf("Expected mid gray for half-covered block, got %v", got)
+	}
+	if got := dst.RGBAAt(1, 0); got != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("Expected white block to stay white, got %v", got)
+	}
+}
+
+func TestSetSupersampling(t *testing.T) {
+	renderer := New(200, 150)
+	for _, factor := range []int{0, MaxSupersampling + 1} {
+		if err := renderer.SetSupersampling(factor); err == nil {
+			t.Errorf("Expected error for factor %d, got nil", factor)
+		}
+	}
+
+	if err := renderer.SetSupersampling(4); err != nil {
+		t.Fatalf("SetSupersampling failed: %v", err)
+	}
+	if got := renderer.context.Width(); got != 800 {
+		t.Errorf("Expected internal canvas 800 pixels wide, got %d", got)
+	}
+
+	// Diagonal fina: a imagem final tem o tamanho pedido e a linha
+	// passa por onde passaria sem superamostragem
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: -1.5}, {X: 2, Y: 5, Z: 1.5}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, DefaultRenderConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	if img.Bounds() != image.Rect(0, 0, 200, 150) {
+		t.Fatalf("Expected 200×150 image, got %v", img.Bounds())
+	}
+	mid := renderer.ProjectToScreen(types.Point3D{Y: 5})
+	if !hasDarkPixel(img, image.Rect(int(mid.X)-1, int(mid.Y)-1, int(mid.X)+2, int(mid.Y)+2)) {
+		t.Error("Expected line through the center of the image")
+	}
+}
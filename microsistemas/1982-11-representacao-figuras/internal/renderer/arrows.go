@@ -0,0 +1,46 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultArrowSize é o comprimento padrão, em pixels, das setas das
+// linhas direcionadas.
+const DefaultArrowSize = 10
+
+// arrowWidthRatio é a meia largura da base das setas das linhas
+// direcionadas, em relação ao comprimento.
+const arrowWidthRatio = 0.4
+
+// drawArrowhead preenche uma seta triangular com a ponta em (x, y).
+//
+// Parâmetros:
+//   x, y: ponta da seta, em pixels
+//   dx, dy: direção unitária para onde a seta aponta
+//   length: comprimento da seta
+//   width: meia largura da base da seta
+func (r *Renderer3D) drawArrowhead(x, y, dx, dy, length, width float64) {
+	baseX, baseY := x-dx*length, y-dy*length
+	r.context.MoveTo(x, y)
+	r.context.LineTo(baseX-dy*width, baseY+dx*width)
+	r.context.LineTo(baseX+dy*width, baseY-dx*width)
+	r.context.ClosePath()
+	r.context.Fill()
+}
+
+// drawLineArrow desenha a seta na ponta P2 de uma linha direcionada,
+// na cor atual, alinhada ao trecho visível da linha.
+//
+// Parâmetros:
+//   p1, p2: extremos visíveis da linha em pixels (a seta vai em p2)
+//   cfg: configurações visuais (tamanho da seta)
+func (r *Renderer3D) drawLineArrow(p1, p2 types.Point2D, cfg RenderConfig) {
+	dx, dy := p2.X-p1.X, p2.Y-p1.Y
+	size := math.Hypot(dx, dy)
+	if size < 1 {
+		return // Linha vista de topo: vira um ponto e fica sem seta
+	}
+	r.drawArrowhead(p2.X, p2.Y, dx/size, dy/size, cfg.ArrowSize, cfg.ArrowSize*arrowWidthRatio)
+}
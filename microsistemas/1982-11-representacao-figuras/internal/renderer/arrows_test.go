@@ -0,0 +1,46 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRenderFigureWithConfig_DirectedLines(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		directed bool
+	}{
+		{"plain line", false},
+		{"directed line", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			figure := &types.Figure{
+				Pontos: []types.Point3D{{X: -2, Y: 5, Z: 0}, {X: 2, Y: 5, Z: 0}},
+				Linhas: []types.Line{{P1: 0, P2: 1, Direcionada: tt.directed}},
+			}
+
+			cfg := DefaultRenderConfig()
+			cfg.ArrowSize = 20
+
+			renderer := New(400, 300)
+			renderer.SetCamera(types.DefaultCamera())
+			if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+			img := renderer.GetImage().(*image.RGBA)
+
+			// Perto da ponta P2, ao lado da linha, só a seta pinta; na
+			// ponta P1 nunca há seta
+			p1 := renderer.ProjectToScreen(figure.Pontos[0])
+			p2 := renderer.ProjectToScreen(figure.Pontos[1])
+			if got := img.RGBAAt(int(p2.X)-12, int(p2.Y)-3).R < 128; got != tt.directed {
+				t.Errorf("Expected arrowhead at P2 %v, got %v", tt.directed, got)
+			}
+			if img.RGBAAt(int(p1.X)+12, int(p1.Y)-3).R < 128 {
+				t.Error("Expected no arrowhead at P1")
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Largura do desenho em caracteres (ver RenderASCII).
+const (
+	DefaultASCIIWidth = 80  // Largura padrão, a de um terminal
+	MinASCIIWidth     = 10  // Menor largura aceita
+	MaxASCIIWidth     = 400 // Maior largura aceita
+)
+
+// asciiCellAspect é a proporção altura/largura de um caractere no
+// terminal: cada caractere cobre 1×2 pixels do renderizador.
+const asciiCellAspect = 2
+
+// ASCIISize calcula as dimensões do renderizador para um desenho em
+// caracteres com a mesma proporção de uma imagem.
+//
+// Parâmetros:
+//   cols: largura do desenho em caracteres
+//   width, height: dimensões da imagem equivalente em pixels
+//
+// Retorna:
+//   int, int: largura e altura do renderizador em pixels (cols e duas
+//             vezes o número de linhas de texto)
+//   error: erro se a largura estiver fora do intervalo aceito
+func ASCIISize(cols, width, height int) (int, int, error) {
+	if cols < MinASCIIWidth || cols > MaxASCIIWidth {
+		return 0, 0, fmt.Errorf("largura em caracteres inválida: %d (use de %d a %d)", cols, MinASCIIWidth, MaxASCIIWidth)
+	}
+	rows := max(1, int(math.Round(float64(cols)*float64(height)/float64(width)/asciiCellAspect)))
+	return cols, rows * asciiCellAspect, nil
+}
+
+// RenderASCII desenha a figura com caracteres, como nos terminais de
+// texto: cada aresta vira uma sequência de "-", "|", "/" ou "\",
+// conforme a sua inclinação, e onde arestas de inclinações diferentes
+// se cruzam aparece "+".
+//
+// A projeção e o recorte são os de RenderRetro, sobre uma grade em que
+// cada caractere cobre 1×2 pixels do renderizador (crie-o com as
+// dimensões de ASCIISize). Os vértices aparecem como "o", se
+// mostrar_vertices estiver ativo, e os nomes dos pontos à direita
+// deles, se mostrar_nomes estiver; cores, faces e os demais acréscimos
+// gráficos são ignorados.
+//
+// Parâmetros:
+//   figure: figura 3D a ser desenhada
+//   cfg: configurações visuais (vértices, nomes e plano próximo)
+//
+// Retorna:
+//   []string: linhas do desenho, sem espaços no fim
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderASCII(figure *types.Figure, cfg RenderConfig) ([]string, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	cols, rows := r.width, (r.height+asciiCellAspect-1)/asciiCellAspect
+	grid := make([][]rune, rows)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", cols))
+	}
+	put := func(x, y int, ch rune) {
+		if x >= 0 && y >= 0 && x < cols && y < rows {
+			grid[y][x] = ch
+		}
+	}
+
+	r.traceEdges(figure, cfg, func(p1, p2 types.Point2D) {
+		ch := slopeChar(p2.X-p1.X, p2.Y-p1.Y)
+		x0, y0 := int(math.Floor(p1.X)), int(math.Floor(p1.Y))
+		x1, y1 := int(math.Floor(p2.X)), int(math.Floor(p2.Y))
+		bresenham(x0, y0, x1, y1, func(x, y int) {
+			y /= asciiCellAspect
+			if x < 0 || y < 0 || x >= cols || y >= rows {
+				return
+			}
+			if old := grid[y][x]; old != ' ' && old != ch {
+				grid[y][x] = '+'
+			} else {
+				grid[y][x] = ch
+			}
+		})
+	})
+
+	// Vértices e nomes por cima das arestas
+	if cfg.ShowVertices || cfg.ShowLabels {
+		near := cfg.NearPlane
+		if near <= 0 {
+			near = DefaultNearPlane
+		}
+		proj := r.projectBatch(figure.Pontos, near, false)
+		for i, p := range figure.Pontos {
+			if proj.codes[i]&outsideNear != 0 {
+				continue // Vértice atrás do observador
+			}
+			s := r.screenPoint(proj, i)
+			x, y := int(math.Floor(s.X)), int(math.Floor(s.Y))/asciiCellAspect
+			if cfg.ShowVertices {
+				put(x, y, 'o')
+			}
+			if cfg.ShowLabels && p.Nome != "" {
+				for k, ch := range []rune(p.Nome) {
+					put(x+1+k, y, ch)
+				}
+			}
+		}
+	}
+
+	lines := make([]string, rows)
+	for i, row := range grid {
+		lines[i] = strings.TrimRight(string(row), " ")
+	}
+	return lines, nil
+}
+
+// slopeChar escolhe o caractere de uma aresta pela sua inclinação na
+// tela (em pixels quadrados, com y para baixo): até 22,5° da horizontal,
+// "-"; até 22,5° da vertical, "|"; nas diagonais, "/" ou "\".
+func slopeChar(dx, dy float64) rune {
+	angle := math.Atan2(math.Abs(dy), math.Abs(dx)) * 180 / math.Pi
+	switch {
+	case angle < 22.5:
+		return '-'
+	case angle > 67.5:
+		return '|'
+	case (dx > 0) == (dy > 0):
+		return '\\'
+	default:
+		return '/'
+	}
+}
@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestASCIISize(t *testing.T) {
+	w, h, err := ASCIISize(80, 800, 600)
+	if err != nil || w != 80 || h != 60 {
+		t.Errorf("Expected 80x60 pixels (30 rows), got %dx%d (%v)", w, h, err)
+	}
+	for _, cols := range []int{MinASCIIWidth - 1, MaxASCIIWidth + 1} {
+		if _, _, err := ASCIISize(cols, 800, 600); err == nil {
+			t.Errorf("Expected error for %d columns", cols)
+		}
+	}
+}
+
+func TestSlopeChar(t *testing.T) {
+	cases := []struct {
+		dx, dy float64
+		want   rune
+	}{
+		{10, 1, '-'},
+		{-10, 2, '-'},
+		{1, 10, '|'},
+		{0, -5, '|'},
+		{5, 5, '\\'},
+		{-5, -4, '\\'},
+		{5, -5, '/'},
+		{-4, 5, '/'},
+	}
+	for _, c := range cases {
+		if got := slopeChar(c.dx, c.dy); got != c.want {
+			t.Errorf("slopeChar(%g, %g): expected %q, got %q", c.dx, c.dy, c.want, got)
+		}
+	}
+}
+
+func TestRenderASCII(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1, Nome: "A"},
+			{X: 1, Y: 5, Z: -1},
+			{X: 1, Y: 5, Z: 1},
+			{X: -1, Y: 5, Z: 1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 0}, {P1: 0, P2: 2}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	w, h, _ := ASCIISize(40, 800, 600)
+	r := New(w, h)
+	r.SetCamera(figure.Camera)
+	cfg := DefaultRenderConfig()
+	cfg.ShowVertices, cfg.ShowLabels = true, true
+
+	lines, err := r.RenderASCII(figure, cfg)
+	if err != nil {
+		t.Fatalf("RenderASCII failed: %v", err)
+	}
+	if len(lines) != h/2 {
+		t.Fatalf("Expected %d rows, got %d", h/2, len(lines))
+	}
+
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{"o", "A", "|", "-"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in drawing:\n%s", want, text)
+		}
+	}
+	if !strings.ContainsAny(text, "/\\") {
+		t.Errorf("Expected a diagonal in drawing:\n%s", text)
+	}
+	for i, line := range lines {
+		if strings.HasSuffix(line, " ") || len([]rune(line)) > w {
+			t.Errorf("Row %d: expected at most %d columns without trailing spaces, got %q", i, w, line)
+		}
+	}
+
+	if _, err := New(w, h).RenderASCII(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
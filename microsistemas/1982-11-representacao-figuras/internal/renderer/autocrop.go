@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/fogleman/gg"
+)
+
+// DefaultCropMargin é a margem padrão, em pixels, deixada em volta do
+// desenho pelo recorte automático.
+const DefaultCropMargin = 10
+
+// AutoCrop recorta a imagem renderizada ao retângulo ocupado pelo
+// desenho, mais uma margem.
+//
+// O desenho é tudo o que difere do fundo: o fundo da configuração
+// (cor, gradiente e grade) é pintado numa tela à parte, do mesmo tamanho,
+// e comparado pixel a pixel com a imagem. Títulos, carimbo e marca
+// d'água contam como desenho. Se nada foi desenhado, a imagem fica como
+// está.
+//
+// Deve ser chamado depois da renderização e antes de SaveImage; as
+// dimensões do renderizador passam a ser as do recorte.
+//
+// Parâmetros:
+//   cfg: configuração usada na renderização (para repintar o fundo)
+//   margin: margem em pixels da imagem final em volta do desenho
+//
+// Retorna:
+//   image.Rectangle: área mantida, em pixels da imagem original
+//   error: erro se a margem for negativa
+func (r *Renderer3D) AutoCrop(cfg RenderConfig, margin int) (image.Rectangle, error) {
+	if margin < 0 {
+		return image.Rectangle{}, fmt.Errorf("margem inválida: %d (deve ser positiva)", margin)
+	}
+	full := image.Rect(0, 0, r.width, r.height)
+
+	// Fundo sem a figura, na resolução da tela interna
+	blank := New(r.width, r.height)
+	blank.SetSupersampling(r.scale)
+	blank.fillBackground(cfg)
+
+	content, ok := contentBounds(r.context.Image().(*image.RGBA), blank.context.Image().(*image.RGBA))
+	if !ok {
+		return full, nil
+	}
+
+	// Volta à imagem final (arredondando para fora) e aplica a margem
+	s := r.scale
+	keep := image.Rect(
+		content.Min.X/s-margin, content.Min.Y/s-margin,
+		(content.Max.X+s-1)/s+margin, (content.Max.Y+s-1)/s+margin,
+	).Intersect(full)
+	if keep == full {
+		return full, nil
+	}
+
+	inner := image.Rect(keep.Min.X*s, keep.Min.Y*s, keep.Max.X*s, keep.Max.Y*s)
+	img := image.NewRGBA(image.Rect(0, 0, inner.Dx(), inner.Dy()))
+	draw.Draw(img, img.Bounds(), r.context.Image(), inner.Min, draw.Src)
+	ctx := gg.NewContextForRGBA(img)
+	ctx.Scale(float64(s), float64(s))
+	r.context = ctx
+
+	if r.deep != nil {
+		deep := image.NewRGBA64(img.Bounds())
+		draw.Draw(deep, deep.Bounds(), r.deep, inner.Min, draw.Src)
+		r.deep = deep
+	}
+
+	r.width, r.height = keep.Dx(), keep.Dy()
+	r.SetViewport(r.fullViewport())
+	return keep, nil
+}
+
+// contentBounds encontra o menor retângulo que contém todos os pixels
+// em que a imagem difere do fundo.
+//
+// Parâmetros:
+//   img: imagem renderizada
+//   background: apenas o fundo, com as mesmas dimensões
+//
+// Retorna:
+//   image.Rectangle: retângulo dos pixels diferentes
+//   bool: false se as imagens forem iguais
+func contentBounds(img, background *image.RGBA) (image.Rectangle, bool) {
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Max.X, b.Max.Y, b.Min.X-1, b.Min.Y-1
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(b.Min.X, y):img.PixOffset(b.Max.X, y)]
+		bgRow := background.Pix[background.PixOffset(b.Min.X, y):background.PixOffset(b.Max.X, y)]
+		for i := 0; i < len(row); i += 4 {
+			if row[i] == bgRow[i] && row[i+1] == bgRow[i+1] && row[i+2] == bgRow[i+2] && row[i+3] == bgRow[i+3] {
+				continue
+			}
+			x := b.Min.X + i/4
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
+		}
+	}
+	if maxX < minX {
+		return image.Rectangle{}, false
+	}
+	return image.Rect(minX, minY, maxX+1, maxY+1), true
+}
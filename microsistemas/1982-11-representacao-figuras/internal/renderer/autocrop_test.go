@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestContentBounds(t *testing.T) {
+	background := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	img := image.NewRGBA(background.Bounds())
+	if _, ok := contentBounds(img, background); ok {
+		t.Error("Expected no content in identical images")
+	}
+
+	img.SetRGBA(3, 7, color.RGBA{A: 1})
+	img.SetRGBA(12, 2, color.RGBA{R: 255, A: 255})
+	bounds, ok := contentBounds(img, background)
+	if !ok || bounds != image.Rect(3, 2, 13, 8) {
+		t.Errorf("Expected content at (3,2)-(13,8), got %v (%v)", bounds, ok)
+	}
+}
+
+func TestAutoCrop(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.Gradient = GradientVertical
+	cfg.GradientEnd = colorRGB{R: 0.5, G: 0.5, B: 0.8, A: 1}
+
+	renderer := New(100, 80)
+	if err := renderer.SetSupersampling(2); err != nil {
+		t.Fatalf("SetSupersampling failed: %v", err)
+	}
+	renderer.fillBackground(cfg)
+	renderer.setColor(cfg.LineColor)
+	renderer.context.DrawRectangle(30, 20, 10, 15)
+	renderer.context.Fill()
+
+	if _, err := renderer.AutoCrop(cfg, -1); err == nil {
+		t.Error("Expected error for negative margin")
+	}
+
+	keep, err := renderer.AutoCrop(cfg, 5)
+	if err != nil {
+		t.Fatalf("AutoCrop failed: %v", err)
+	}
+	if keep != image.Rect(25, 15, 45, 40) {
+		t.Errorf("Expected to keep (25,15)-(45,40), got %v", keep)
+	}
+	img := renderer.image()
+	if img.Bounds() != image.Rect(0, 0, 20, 25) {
+		t.Fatalf("Expected 20×25 image, got %v", img.Bounds())
+	}
+	if c := img.RGBAAt(10, 10); c.R != 0 || c.G != 0 || c.B != 0 {
+		t.Errorf("Expected the drawing in the middle of the crop, got %v", c)
+	}
+	if c := img.RGBAAt(0, 0); c.R == 0 {
+		t.Errorf("Expected background in the margin, got %v", c)
+	}
+}
+
+func TestAutoCrop_Margins(t *testing.T) {
+	cfg := DefaultRenderConfig()
+
+	// Sem desenho, nada é recortado
+	renderer := New(40, 30)
+	renderer.fillBackground(cfg)
+	keep, err := renderer.AutoCrop(cfg, 0)
+	if err != nil {
+		t.Fatalf("AutoCrop failed: %v", err)
+	}
+	if keep != image.Rect(0, 0, 40, 30) || renderer.image().Bounds().Dx() != 40 {
+		t.Errorf("Expected blank image to stay whole, got %v", keep)
+	}
+
+	// A margem não passa das bordas da imagem
+	renderer.setColor(cfg.LineColor)
+	renderer.context.DrawRectangle(2, 10, 5, 5)
+	renderer.context.Fill()
+	keep, err = renderer.AutoCrop(cfg, 10)
+	if err != nil {
+		t.Fatalf("AutoCrop failed: %v", err)
+	}
+	if keep != image.Rect(0, 0, 17, 25) {
+		t.Errorf("Expected margin clamped to (0,0)-(17,25), got %v", keep)
+	}
+}
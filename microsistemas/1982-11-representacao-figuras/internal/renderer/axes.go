@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Dimensões das pontas dos eixos, em pixels.
+const (
+	axisArrowLength = 9  // Comprimento da seta
+	axisArrowWidth  = 3  // Meia largura da base da seta
+	axisLabelOffset = 10 // Distância do nome além da ponta
+)
+
+// axisNames são os nomes escritos nas pontas dos eixos.
+var axisNames = [3]string{"X", "Y", "Z"}
+
+// axisDirections são os sentidos positivos dos eixos do mundo.
+var axisDirections = [3]types.Point3D{{X: 1}, {Y: 1}, {Z: 1}}
+
+// axesLength calcula o comprimento automático dos eixos: um quarto além
+// da maior coordenada (em valor absoluto) da figura, para que as pontas
+// fiquem fora dela.
+//
+// Parâmetros:
+//   figure: figura desenhada com os eixos
+//
+// Retorna:
+//   float64: comprimento dos eixos (1 para figuras na origem)
+func axesLength(figure *types.Figure) float64 {
+	min, max := figure.Bounds()
+	extent := 0.0
+	for _, v := range []float64{min.X, min.Y, min.Z, max.X, max.Y, max.Z} {
+		extent = math.Max(extent, math.Abs(v))
+	}
+	if extent == 0 {
+		return 1
+	}
+	return 1.25 * extent
+}
+
+// drawAxes desenha os eixos X, Y e Z do mundo, da origem até o
+// comprimento configurado, projetados pela câmera atual.
+//
+// Os eixos passam pelo mesmo recorte das arestas da figura: um eixo
+// inteiramente fora do volume de visão é omitido e o trecho atrás do
+// plano próximo é descartado. A seta e o nome só aparecem quando a
+// ponta do eixo está à frente do observador; o nome fica no
+// prolongamento do eixo, para não se sobrepor a ele.
+//
+// Parâmetros:
+//   figure: figura desenhada (define o comprimento automático)
+//   cfg: configurações visuais (comprimento, cores e nomes dos eixos)
+//   near: profundidade do plano próximo
+func (r *Renderer3D) drawAxes(figure *types.Figure, cfg RenderConfig, near float64) {
+	length := cfg.AxesLength
+	if length <= 0 {
+		length = axesLength(figure)
+	}
+
+	origin := r.cameraPoint(types.Point3D{})
+	for i, dir := range axisDirections {
+		tip := r.cameraPoint(types.Point3D{X: dir.X * length, Y: dir.Y * length, Z: dir.Z * length})
+		p1, p2, ok := r.clipSegment(origin, tip, near)
+		if !ok {
+			continue // Eixo fora do volume de visão
+		}
+
+		r.setColor(cfg.AxesColors[i])
+		r.context.DrawLine(p1.X, p1.Y, p2.X, p2.Y)
+		r.context.Stroke()
+		if r.outcode(tip, near)&outsideNear != 0 {
+			continue // Ponta atrás do observador
+		}
+
+		// Direção do eixo na tela; um eixo apontado para o observador
+		// vira um ponto e fica sem seta
+		dx, dy := p2.X-p1.X, p2.Y-p1.Y
+		size := math.Hypot(dx, dy)
+		if size >= 1 {
+			dx, dy = dx/size, dy/size
+			r.drawArrowhead(p2.X, p2.Y, dx, dy, axisArrowLength, axisArrowWidth)
+		} else {
+			dx, dy = 0.7, -0.7
+		}
+
+		if cfg.AxesLabels {
+			// Centrado além da ponta (como DrawStringAnchored com 0.5, 0.5)
+			w, h := r.measureText(axisNames[i])
+			r.drawLabel(axisNames[i], p2.X+dx*axisLabelOffset-w/2, p2.Y+dy*axisLabelOffset+h/2,
+				cfg.AxesColors[i], cfg)
+		}
+	}
+	r.setColor(cfg.LineColor)
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestAxesLength(t *testing.T) {
+	tests := []struct {
+		points []types.Point3D
+		want   float64
+	}{
+		{[]types.Point3D{{}}, 1},
+		{[]types.Point3D{{X: -1, Y: 2}, {X: 1, Y: 4, Z: 1}}, 5},
+		{[]types.Point3D{{X: -8, Y: 1}, {Z: 2}}, 10},
+	}
+
+	for _, tt := range tests {
+		if got := axesLength(&types.Figure{Pontos: tt.points}); got != tt.want {
+			t.Errorf("%v: expected length %g, got %g", tt.points, tt.want, got)
+		}
+	}
+}
+
+func TestRenderFigureWithConfig_Axes(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5}, {X: -1, Y: 5, Z: -1}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.Axes = true
+	cfg.AxesLength = 4
+	cfg.LineWidth = 2
+
+	// Observador à frente da origem: X para a direita, Z para cima e
+	// Y apontado para longe dele
+	camera := types.DefaultCamera()
+	camera.Observer = types.Point3D{Y: -10}
+
+	renderer := New(400, 300)
+	renderer.SetCamera(camera)
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	origin := renderer.ProjectToScreen(types.Point3D{})
+	x := renderer.ProjectToScreen(types.Point3D{X: 2})
+	z := renderer.ProjectToScreen(types.Point3D{Z: 2})
+	if got := img.RGBAAt(int(x.X), int(origin.Y)); got.R < 150 || got.B > 100 {
+		t.Errorf("Expected red X axis, got %v", got)
+	}
+	if got := img.RGBAAt(int(origin.X), int(z.Y)); got.B < 150 || got.R > 100 {
+		t.Errorf("Expected blue Z axis, got %v", got)
+	}
+	if got := img.RGBAAt(int(origin.X), int(2*origin.Y-z.Y)); got.R < 250 || got.G < 250 {
+		t.Errorf("Expected no axis below the origin, got %v", got)
+	}
+
+	// Observador na origem: os eixos começam atrás dele e são recortados
+	renderer = New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Limites das listagens do HP-85: o maior número de linha do programa e
+// o comprimento dos DATA, para que caibam numa linha da tela ao digitar.
+const (
+	basicMaxLine  = 9999
+	basicDataLine = 60
+)
+
+// basicProgram é o programa que lê os DATA e desenha a figura, no estilo
+// do artigo: projeta cada ponto pelas fórmulas x = P'x·R/P'z e
+// y = P'y·R/P'z (ou, nas projeções paralelas, apenas descarta a
+// profundidade) e traça as linhas com MOVE e DRAW na escala da tela
+// virtual. %s são os comentários iniciais, %d os tamanhos dos vetores e
+// a última %s, a sub-rotina da projeção.
+const basicProgram = `%s30 OPTION BASE 1
+40 DIM X(%d),Y(%d),Z(%d),A(%d),B(%d)
+50 READ N$,N
+60 FOR I=1 TO N @ READ X(I),Y(I),Z(I) @ NEXT I
+70 READ M
+80 FOR I=1 TO M @ READ A(I),B(I) @ NEXT I
+90 READ V1,V2,V3,R,L1,L2
+100 GCLEAR @ SCALE -(L1/2),L1/2,-(L2/2),L2/2
+110 FOR I=1 TO M
+120 J=A(I) @ GOSUB 200 @ MOVE U,W
+130 J=B(I) @ GOSUB 200 @ DRAW U,W
+140 NEXT I
+150 STOP
+%s`
+
+// Sub-rotinas da projeção do ponto J, em U e W.
+const (
+	basicConic = `190 ! PROJECAO CONICA: P'=P-V, X=P'X*R/P'Z, Y=P'Y*R/P'Z
+200 U=(X(J)-V1)*R/(Y(J)-V2) @ W=(Z(J)-V3)*R/(Y(J)-V2)
+210 RETURN
+`
+	basicParallel = `190 ! PROJECAO PARALELA: P'=P-V, X=P'X, Y=P'Y
+200 U=X(J)-V1 @ W=Z(J)-V3
+210 RETURN
+`
+)
+
+// RenderBASIC escreve a figura como um programa BASIC do HP-85, no
+// estilo das listagens do artigo: os DATA com os pontos, as linhas e a
+// câmera, e o laço que projeta e desenha cada linha, para rodar a
+// figura num HP-85 de verdade ou num emulador. Os DATA seguem o formato
+// lido por core.ParseBASIC: nome, pontos, linhas (numerados a partir de
+// 1) e V, R, L1 e L2.
+//
+// O programa do artigo só conhece o observador olhando ao longo de +Y.
+// Com a câmera do artigo, os pontos são os da figura; com alvo, órbita,
+// enquadramento automático ou vista, eles são escritos já na base do
+// observador (largura, profundidade e altura, com V na origem), o que
+// dá a mesma imagem, e a projeção oblíqua, como a ortogonal dos pontos
+// cisalhados (ver obliqueProject). As curvas viram trechos retos, com os
+// pontos intermediários acrescentados no fim, e as arestas das faces que
+// não repetem uma linha reta viram linhas. Na projeção cônica, as linhas
+// com algum ponto atrás do plano próximo ficam de fora, já que o HP-85
+// pararia na divisão, e um comentário as conta. A tela virtual é
+// esticada sobre a tela do HP-85, como na proporção "esticar".
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (plano próximo)
+//
+// Retorna:
+//   []byte: listagem BASIC
+//   error: erro se a figura não tiver pontos ou não couber na numeração
+//          das linhas do HP-85
+func (r *Renderer3D) RenderBASIC(figure *types.Figure, cfg RenderConfig) ([]byte, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	// Pontos e linhas, com as curvas em trechos retos
+	points := append([]types.Point3D(nil), figure.Pontos...)
+	var lines [][2]int
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			curve := linha.Curve(figure.Pontos)
+			previous := linha.P1
+			for i := 1; i < len(curve); i++ {
+				current := linha.P2
+				if i < len(curve)-1 {
+					points = append(points, curve[i])
+					current = len(points) - 1
+				}
+				lines = append(lines, [2]int{previous, current})
+				previous = current
+			}
+			if len(linha.Controle) == 0 {
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+			}
+		}
+	}
+	for _, e := range figure.FaceEdges() {
+		if !drawn[[2]int{min(e.P1, e.P2), max(e.P1, e.P2)}] {
+			lines = append(lines, [2]int{e.P1, e.P2})
+		}
+	}
+
+	// Câmera do artigo: os pontos como estão; as demais, na base do
+	// observador (X largura, Y profundidade, Z altura)
+	article := r.camera.Target == nil && r.camera.Projection != types.ProjectionOblique
+	observer := r.camera.Observer
+	near := cfg.NearPlane
+	if near <= 0 {
+		near = DefaultNearPlane
+	}
+	depth := make([]float64, len(points))
+	for i, p := range points {
+		px, py, pz := r.toCameraSpace(p)
+		depth[i] = pz
+		if article {
+			continue
+		}
+		if r.camera.Projection == types.ProjectionOblique {
+			px, py = r.obliqueProject(px, py, pz)
+		}
+		points[i] = types.Point3D{X: px, Y: pz, Z: py}
+	}
+	if !article {
+		observer = types.Point3D{}
+	}
+	kept := lines[:0]
+	for _, l := range lines {
+		if r.camera.IsParallel() || (depth[l[0]] >= near && depth[l[1]] >= near) {
+			kept = append(kept, l)
+		}
+	}
+	omitted := len(lines) - len(kept)
+	lines = kept
+
+	// Comentários iniciais, nas linhas 10 e 20 (e 25, se preciso)
+	name := basicText(figure.Nome)
+	if name == "" {
+		name = "FIGURA"
+	}
+	header := fmt.Sprintf("10 ! %s: GERADO POR FIGURAS3D\n20 ! REPRESENTACAO DE FIGURAS, MICRO SISTEMAS, 11/1982\n", name)
+	var notes []string
+	if !article {
+		notes = append(notes, "PONTOS NA BASE DO OBSERVADOR")
+	}
+	switch {
+	case omitted == 1:
+		notes = append(notes, "1 LINHA ATRAS DO OBSERVADOR OMITIDA")
+	case omitted > 1:
+		notes = append(notes, fmt.Sprintf("%d LINHAS ATRAS DO OBSERVADOR OMITIDAS", omitted))
+	}
+	if len(notes) > 0 {
+		header += "25 ! " + strings.Join(notes, "; ") + "\n"
+	}
+	projection := basicConic
+	if r.camera.IsParallel() {
+		projection = basicParallel
+	}
+
+	var buf bytes.Buffer
+	size := max(len(points), 1)
+	fmt.Fprintf(&buf, basicProgram, header, size, size, size, max(len(lines), 1), max(len(lines), 1), projection)
+
+	// DATA, em linhas curtas, numeradas de 10 em 10 a partir de 1000 (ou
+	// de 1 em 1, se não couberem)
+	var data []string
+	var row []string
+	flush := func() {
+		if len(row) > 0 {
+			data = append(data, "DATA "+strings.Join(row, ","))
+			row = nil
+		}
+	}
+	group := func(values ...string) {
+		item := strings.Join(values, ",")
+		if len(row) > 0 && len(strings.Join(row, ","))+1+len(item) > basicDataLine {
+			flush()
+		}
+		row = append(row, item)
+	}
+	data = append(data, fmt.Sprintf(`DATA "%s"`, name), "! PONTOS: N, E X,Y,Z DE CADA UM", "DATA "+strconv.Itoa(len(points)))
+	for _, p := range points {
+		group(basicNumber(p.X), basicNumber(p.Y), basicNumber(p.Z))
+	}
+	flush()
+	data = append(data, "! LINHAS: M, E OS DOIS PONTOS DE CADA UMA", "DATA "+strconv.Itoa(len(lines)))
+	for _, l := range lines {
+		group(strconv.Itoa(l[0]+1), strconv.Itoa(l[1]+1))
+	}
+	flush()
+	data = append(data, "! CAMERA: V, R, L1 E L2")
+	group(basicNumber(observer.X), basicNumber(observer.Y), basicNumber(observer.Z))
+	group(basicNumber(r.camera.Distance))
+	group(basicNumber(r.camera.Width), basicNumber(r.camera.Height))
+	flush()
+
+	step := 10
+	if 1000+step*len(data) > basicMaxLine {
+		step = 1
+	}
+	if 1000+step*len(data) > basicMaxLine {
+		return nil, fmt.Errorf("figura grande demais para uma listagem do HP-85: %d linhas de DATA (máximo %d)", len(data), basicMaxLine-1000)
+	}
+	for i, line := range data {
+		fmt.Fprintf(&buf, "%d %s\n", 1000+step*i, line)
+	}
+	fmt.Fprintf(&buf, "%d END\n", 1000+step*len(data))
+	return buf.Bytes(), nil
+}
+
+// basicNumber escreve um número com os 12 algarismos significativos do
+// HP-85, sem zeros à direita.
+func basicNumber(v float64) string {
+	if math.Abs(v) < 1e-12 {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'G', 12, 64)
+}
+
+// basicText escreve um texto com as letras do HP-85: maiúsculas, sem
+// acentos e sem aspas.
+func basicText(s string) string {
+	fold := strings.NewReplacer(
+		"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+		"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+		"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+		"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+		"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+		"Ç", "C", "Ñ", "N", `"`, "'",
+	)
+	var b strings.Builder
+	for _, r := range fold.Replace(strings.ToUpper(s)) {
+		if r < 32 || r > 126 {
+			r = '?'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"representacao-figuras/internal/core"
+	"representacao-figuras/pkg/types"
+)
+
+// basicProject projeta um ponto lido dos DATA como o programa gerado por
+// RenderBASIC faria no HP-85, em pixels de uma tela width×height.
+func basicProject(figure *types.Figure, p types.Point3D, parallel bool, width, height int) types.Point2D {
+	v, c := figure.Camera.Observer, figure.Camera
+	u, w := p.X-v.X, p.Z-v.Z
+	if !parallel {
+		u, w = u*c.Distance/(p.Y-v.Y), w*c.Distance/(p.Y-v.Y)
+	}
+	// SCALE -(L1/2),L1/2,-(L2/2),L2/2
+	return types.Point2D{X: (u/c.Width + 0.5) * float64(width), Y: (0.5 - w/c.Height) * float64(height)}
+}
+
+func TestRenderBASICCamera(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 4, Z: -1}, {X: 1, Y: 4, Z: -1}, {X: 1, Y: 6, Z: 1}, {X: -1, Y: 6, Z: 1}, {X: 0, Y: 5, Z: 2},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 4}, {P1: 3, P2: 0}},
+	}
+	target := types.Point3D{X: 0, Y: 5, Z: 0}
+	cameras := map[string]types.Camera{
+		"artigo": types.DefaultCamera(),
+		"alvo":   {Observer: types.Point3D{X: 6, Y: -3, Z: 4}, Target: &target, Distance: 2, FOV: 50},
+		"ortogonal": {Observer: types.Point3D{X: 5, Y: 0, Z: 5}, Target: &target, Distance: 1, Width: 6, Height: 4,
+			Projection: types.ProjectionOrthographic},
+		"obliqua": {Observer: types.Point3D{X: 0, Y: -10, Z: 0}, Target: &target, Distance: 1, Width: 8, Height: 6,
+			Projection: types.ProjectionOblique, ObliqueAngle: 30, ObliqueFactor: 0.5},
+	}
+	for name, camera := range cameras {
+		t.Run(name, func(t *testing.T) {
+			r := New(256, 192)
+			r.SetCamera(camera)
+			listing, err := r.RenderBASIC(figure, DefaultRenderConfig())
+			if err != nil {
+				t.Fatalf("RenderBASIC failed: %v", err)
+			}
+			read, err := core.ParseBASIC(listing, core.BASICOptions{Base: 1})
+			if err != nil {
+				t.Fatalf("Listing does not read back: %v\n%s", err, listing)
+			}
+			if len(read.Pontos) != len(figure.Pontos) || len(read.Linhas) != len(figure.Linhas) {
+				t.Fatalf("Expected %d points and %d lines, got %d and %d", len(figure.Pontos), len(figure.Linhas), len(read.Pontos), len(read.Linhas))
+			}
+			for i, p := range read.Pontos {
+				want := r.ProjectToScreen(figure.Pontos[i])
+				got := basicProject(read, p, camera.IsParallel(), 256, 192)
+				if math.Abs(got.X-want.X) > 1e-6 || math.Abs(got.Y-want.Y) > 1e-6 {
+					t.Errorf("Point %d: expected %v, the HP-85 would draw it at %v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderBASIC(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "Pirâmide \"nova\"",
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: -1}, {X: 0, Y: 5, Z: 1}, {X: 0, Y: -1, Z: 0},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1},
+			{P1: 1, P2: 2, Controle: []types.Point3D{{X: 1, Y: 5, Z: 1}}}, // Curva de CurveSegments trechos
+			{P1: 2, P2: 3}, // Atrás do observador
+		},
+		Faces:  []types.Face{{Pontos: []int{0, 1, 2}}},
+		Camera: types.DefaultCamera(),
+	}
+	listing, err := New(256, 192).RenderBASIC(figure, DefaultRenderConfig())
+	if err != nil {
+		t.Fatalf("RenderBASIC failed: %v", err)
+	}
+	text := string(listing)
+	for _, want := range []string{
+		"10 ! PIRAMIDE 'NOVA': GERADO POR FIGURAS3D\n",
+		"25 ! 1 LINHA ATRAS DO OBSERVADOR OMITIDA\n",
+		"200 U=(X(J)-V1)*R/(Y(J)-V2) @ W=(Z(J)-V3)*R/(Y(J)-V2)\n",
+		"1000 DATA \"PIRAMIDE 'NOVA'\"\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in listing:\n%s", want, text)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.Contains(line, " DATA ") && len(line) > 80 {
+			t.Errorf("Expected short DATA lines, got %q", line)
+		}
+	}
+
+	// A linha reta, a curva e as arestas 1-2 e 2-0 da face (a 1-2 da
+	// figura é curva)
+	read, err := core.ParseBASIC(listing, core.BASICOptions{Base: 1})
+	if err != nil {
+		t.Fatalf("Listing does not read back: %v", err)
+	}
+	if n := 1 + types.CurveSegments + 2; len(read.Linhas) != n {
+		t.Errorf("Expected %d lines, got %d", n, len(read.Linhas))
+	}
+	if n := len(figure.Pontos) + types.CurveSegments - 1; len(read.Pontos) != n {
+		t.Errorf("Expected the curve's inner points appended, got %d points", len(read.Pontos))
+	}
+	if !strings.HasSuffix(text, " END\n") {
+		t.Errorf("Expected END as the last line")
+	}
+
+	if _, err := New(256, 192).RenderBASIC(&types.Figure{}, DefaultRenderConfig()); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
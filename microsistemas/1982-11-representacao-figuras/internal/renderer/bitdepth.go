@@ -0,0 +1,136 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// SetBitDepth escolhe a profundidade de cor da imagem salva: 8 ou 16 bits
+// por canal.
+//
+// A gg desenha apenas em 8 bits, o que produz faixas visíveis em
+// gradientes suaves depois de uma composição. Com 16 bits, o gradiente
+// do fundo é gravado também numa tela paralela de 16 bits (ver
+// paintGradient) e, ao montar a imagem final, os pixels em que nada foi
+// desenhado por cima dele vêm dessa tela; os demais são convertidos da
+// tela de 8 bits. Com superamostragem, a média de cada bloco é guardada
+// com 16 bits, o que também suaviza as bordas.
+//
+// Como SetSupersampling, deve ser chamado antes de qualquer desenho.
+//
+// Parâmetros:
+//   bits: bits por canal (8 ou 16)
+//
+// Retorna:
+//   error: erro se a profundidade não for 8 nem 16
+func (r *Renderer3D) SetBitDepth(bits int) error {
+	switch bits {
+	case 8:
+		r.deep = nil
+	case 16:
+		r.deep = image.NewRGBA64(r.context.Image().Bounds())
+	default:
+		return fmt.Errorf("bits por canal inválidos: %d (use 8 ou 16)", bits)
+	}
+	return nil
+}
+
+// finalImage retorna a imagem a salvar: em 16 bits por canal, quando
+// ativados (ver SetBitDepth), ou em 8.
+func (r *Renderer3D) finalImage() image.Image {
+	if r.deep == nil {
+		return r.image()
+	}
+	return r.image16()
+}
+
+// image16 monta a imagem final com 16 bits por canal.
+//
+// Cada pixel da tela interna vem da tela de 16 bits quando ela tem
+// valor ali e a tela de 8 bits ainda mostra esse mesmo valor (reduzido a
+// 8 bits), isto é, quando nada foi desenhado sobre o gradiente; senão,
+// vem da tela de 8 bits. Com superamostragem, a imagem é então reduzida
+// pela média de cada bloco, como em downsample.
+func (r *Renderer3D) image16() *image.RGBA64 {
+	img := r.context.Image().(*image.RGBA)
+	b := img.Bounds()
+	full := image.NewRGBA64(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			d := r.deep.RGBA64At(x, y)
+			if d.A != 0 && c == to8(d) {
+				full.SetRGBA64(x, y, d)
+			} else {
+				full.SetRGBA64(x, y, color.RGBA64{
+					R: uint16(c.R) * 0x101, G: uint16(c.G) * 0x101,
+					B: uint16(c.B) * 0x101, A: uint16(c.A) * 0x101,
+				})
+			}
+		}
+	}
+	if r.scale <= 1 {
+		return full
+	}
+	return downsample16(full, r.scale)
+}
+
+// downsample16 reduz uma imagem de 16 bits pela média de cada bloco
+// factor×factor de pixels (ver downsample).
+//
+// Parâmetros:
+//   src: imagem em alta resolução (dimensões múltiplas de factor)
+//   factor: fator de redução
+//
+// Retorna:
+//   *image.RGBA64: imagem reduzida
+func downsample16(src *image.RGBA64, factor int) *image.RGBA64 {
+	b := src.Bounds()
+	dst := image.NewRGBA64(image.Rect(0, 0, b.Dx()/factor, b.Dy()/factor))
+	area := uint32(factor * factor)
+
+	for y := 0; y < dst.Rect.Dy(); y++ {
+		for x := 0; x < dst.Rect.Dx(); x++ {
+			var sum [4]uint32
+			for sy := 0; sy < factor; sy++ {
+				for sx := 0; sx < factor; sx++ {
+					c := src.RGBA64At(b.Min.X+x*factor+sx, b.Min.Y+y*factor+sy)
+					sum[0] += uint32(c.R)
+					sum[1] += uint32(c.G)
+					sum[2] += uint32(c.B)
+					sum[3] += uint32(c.A)
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16((sum[0] + area/2) / area),
+				G: uint16((sum[1] + area/2) / area),
+				B: uint16((sum[2] + area/2) / area),
+				A: uint16((sum[3] + area/2) / area),
+			})
+		}
+	}
+	return dst
+}
+
+// toRGBA64 converte uma cor para 16 bits por canal, com componentes
+// pré-multiplicadas pelo alfa (a versão de 16 bits de toRGBA).
+func toRGBA64(c colorRGB) color.RGBA64 {
+	return color.RGBA64{
+		R: uint16(math.Round(c.R * c.A * 0xffff)),
+		G: uint16(math.Round(c.G * c.A * 0xffff)),
+		B: uint16(math.Round(c.B * c.A * 0xffff)),
+		A: uint16(math.Round(c.A * 0xffff)),
+	}
+}
+
+// to8 reduz uma cor de 16 bits a 8 bits por canal, arredondando.
+func to8(c color.RGBA64) color.RGBA {
+	return color.RGBA{
+		R: uint8((uint32(c.R) + 0x80) / 0x101),
+		G: uint8((uint32(c.G) + 0x80) / 0x101),
+		B: uint8((uint32(c.B) + 0x80) / 0x101),
+		A: uint8((uint32(c.A) + 0x80) / 0x101),
+	}
+}
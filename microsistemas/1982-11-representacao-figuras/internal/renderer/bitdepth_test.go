@@ -0,0 +1,115 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetBitDepth(t *testing.T) {
+	renderer := New(10, 10)
+	for _, bits := range []int{0, 12, 32} {
+		if err := renderer.SetBitDepth(bits); err == nil {
+			t.Errorf("Expected error for %d bits", bits)
+		}
+	}
+
+	if err := renderer.SetBitDepth(16); err != nil {
+		t.Fatalf("SetBitDepth failed: %v", err)
+	}
+	if err := renderer.SetSupersampling(2); err != nil {
+		t.Fatalf("SetSupersampling failed: %v", err)
+	}
+	if renderer.deep.Bounds() != image.Rect(0, 0, 20, 20) {
+		t.Errorf("Expected 16 bit canvas to follow supersampling, got %v", renderer.deep.Bounds())
+	}
+	if _, ok := renderer.finalImage().(*image.RGBA64); !ok {
+		t.Errorf("Expected 16 bit final image, got %T", renderer.finalImage())
+	}
+
+	if err := renderer.SetBitDepth(8); err != nil {
+		t.Fatalf("SetBitDepth failed: %v", err)
+	}
+	if _, ok := renderer.finalImage().(*image.RGBA); !ok {
+		t.Errorf("Expected 8 bit final image, got %T", renderer.finalImage())
+	}
+}
+
+func TestImage16_SmoothGradient(t *testing.T) {
+	// Gradiente escuro e sutil: em 8 bits, só umas poucas faixas
+	start := colorRGB{R: 0.1, G: 0.1, B: 0.1, A: 1}
+	end := colorRGB{R: 0.15, G: 0.15, B: 0.15, A: 1}
+
+	renderer := New(4, 200)
+	if err := renderer.SetBitDepth(16); err != nil {
+		t.Fatalf("SetBitDepth failed: %v", err)
+	}
+	renderer.paintGradient(GradientVertical, start, end)
+
+	// Um pixel desenhado por cima vem da tela de 8 bits
+	renderer.context.SetColor(color.RGBA{R: 255, A: 255})
+	renderer.context.SetPixel(2, 100)
+
+	img := renderer.image16()
+	levels8 := map[uint8]bool{}
+	levels16 := map[uint16]bool{}
+	for y := 0; y < 200; y++ {
+		levels8[renderer.image().RGBAAt(0, y).R] = true
+		levels16[img.RGBA64At(0, y).R] = true
+	}
+	if len(levels8) > 15 || len(levels16) < 150 {
+		t.Errorf("Expected few 8 bit levels and many 16 bit levels, got %d and %d", len(levels8), len(levels16))
+	}
+
+	if got := img.RGBA64At(2, 100); got.R != 0xffff || got.G != 0 {
+		t.Errorf("Expected the drawn red pixel, got %+v", got)
+	}
+}
+
+func TestDownsample16(t *testing.T) {
+	src := image.NewRGBA64(image.Rect(0, 0, 2, 2))
+	src.SetRGBA64(0, 0, color.RGBA64{R: 1000, A: 0xffff})
+	src.SetRGBA64(1, 0, color.RGBA64{R: 2000, A: 0xffff})
+	src.SetRGBA64(0, 1, color.RGBA64{R: 3000, A: 0xffff})
+	src.SetRGBA64(1, 1, color.RGBA64{R: 4001, A: 0xffff})
+
+	dst := downsample16(src, 2)
+	if want := (color.RGBA64{R: 2500, A: 0xffff}); dst.RGBA64At(0, 0) != want {
+		t.Errorf("Expected %+v, got %+v", want, dst.RGBA64At(0, 0))
+	}
+}
+
+func TestTiledImage_Save16(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.BitDepth = 16
+	tiled, err := NewTiledImage(100, 200, 64, tiledTestFigure(), cfg, nil)
+	if err != nil {
+		t.Fatalf("NewTiledImage failed: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "tiled16.png")
+	if err := tiled.Save(filename, SaveOptions{}); err != nil {
+		t.Fatalf("SavePNG failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open PNG: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	switch img.(type) {
+	case *image.RGBA64, *image.NRGBA64:
+	default:
+		t.Errorf("Expected a 16 bit PNG, got %T", img)
+	}
+	if !hasDarkPixel(img, img.Bounds()) {
+		t.Error("Expected figure to be drawn in 16 bit PNG")
+	}
+}
@@ -0,0 +1,62 @@
+package renderer
+
+import "representacao-figuras/pkg/types"
+
+// boxCorners retorna os oito cantos de uma caixa alinhada aos eixos.
+//
+// O canto i usa a coordenada máxima no eixo X se o bit 0 de i estiver
+// ligado, no Y se o bit 1 estiver e no Z se o bit 2 estiver: dois cantos
+// estão ligados por uma aresta quando diferem em um único bit.
+func boxCorners(min, max types.Point3D) [8]types.Point3D {
+	var corners [8]types.Point3D
+	for i := range corners {
+		corners[i] = min
+		if i&1 != 0 {
+			corners[i].X = max.X
+		}
+		if i&2 != 0 {
+			corners[i].Y = max.Y
+		}
+		if i&4 != 0 {
+			corners[i].Z = max.Z
+		}
+	}
+	return corners
+}
+
+// drawBoundingBox desenha a caixa envolvente da figura (ver
+// types.Figure.Bounds) em linhas finas.
+//
+// Útil para entender por que uma figura aparece fora do centro ou
+// cortada: a caixa mostra onde a figura está em relação ao volume de
+// visão. As arestas da caixa passam pelo mesmo recorte das arestas da
+// figura (ver clipSegment).
+//
+// Parâmetros:
+//   figure: figura cuja caixa é desenhada
+//   cfg: configurações visuais (cor da caixa)
+//   near: profundidade do plano próximo
+func (r *Renderer3D) drawBoundingBox(figure *types.Figure, cfg RenderConfig, near float64) {
+	var camera [8]vec3
+	for i, corner := range boxCorners(figure.Bounds()) {
+		camera[i] = r.cameraPoint(corner)
+	}
+
+	for i := range camera {
+		for _, bit := range []int{1, 2, 4} {
+			if i&bit != 0 {
+				continue // Cada aresta uma única vez, a partir do canto menor
+			}
+			if p1, p2, ok := r.clipSegment(camera[i], camera[i|bit], near); ok {
+				r.context.MoveTo(p1.X, p1.Y)
+				r.context.LineTo(p2.X, p2.Y)
+			}
+		}
+	}
+
+	r.setColor(cfg.BoundingBoxColor)
+	r.setLineWidth(1)
+	r.context.Stroke()
+	r.setColor(cfg.LineColor)
+	r.setLineWidth(cfg.LineWidth)
+}
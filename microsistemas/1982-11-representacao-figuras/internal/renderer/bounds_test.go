@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestBoxCorners(t *testing.T) {
+	corners := boxCorners(types.Point3D{X: -1, Y: 2, Z: 3}, types.Point3D{X: 1, Y: 4, Z: 5})
+
+	want := map[types.Point3D]bool{}
+	for _, x := range []float64{-1, 1} {
+		for _, y := range []float64{2, 4} {
+			for _, z := range []float64{3, 5} {
+				want[types.Point3D{X: x, Y: y, Z: z}] = true
+			}
+		}
+	}
+	for _, c := range corners {
+		if !want[c] {
+			t.Errorf("Unexpected corner %+v", c)
+		}
+		delete(want, c)
+	}
+	if len(want) != 0 {
+		t.Errorf("Missing corners %v", want)
+	}
+	if corners[0] != (types.Point3D{X: -1, Y: 2, Z: 3}) || corners[7] != (types.Point3D{X: 1, Y: 4, Z: 5}) {
+		t.Errorf("Expected minimum and maximum at corners 0 and 7, got %+v and %+v", corners[0], corners[7])
+	}
+}
+
+func TestRenderFigureWithConfig_BoundingBox(t *testing.T) {
+	// Diagonal da caixa vista de frente: a caixa aparece como um
+	// retângulo em volta da linha
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: -1}, {X: 2, Y: 5, Z: 1}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.BoundingBox = true
+	cfg.BoundingBoxColor = colorRGB{R: 1, A: 1}
+
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	corner := renderer.ProjectToScreen(types.Point3D{X: -2, Y: 5, Z: 1})
+	middle := renderer.ProjectToScreen(types.Point3D{Y: 5, Z: 1})
+	if got := img.RGBAAt(int(middle.X), int(corner.Y)); got.R < 200 || got.G > 100 {
+		t.Errorf("Expected red top edge of the box, got %v", got)
+	}
+	if got := img.RGBAAt(int(corner.X), int(renderer.ProjectToScreen(types.Point3D{X: -2, Y: 5}).Y)); got.R < 200 || got.G > 100 {
+		t.Errorf("Expected red left edge of the box, got %v", got)
+	}
+}
@@ -0,0 +1,90 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Cada caractere braille é uma matriz de 2×4 pontos.
+const (
+	brailleCellWidth  = 2
+	brailleCellHeight = 4
+)
+
+// brailleBlank é o caractere braille sem nenhum ponto (U+2800).
+const brailleBlank = '⠀'
+
+// brailleDots são os bits de cada ponto de um caractere braille, por
+// coluna e linha da matriz 2×4 (os pontos 7 e 8, da última linha, foram
+// acrescentados depois e ficam nos bits mais altos).
+var brailleDots = [brailleCellWidth][brailleCellHeight]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// BrailleSize calcula as dimensões do renderizador para um desenho em
+// caracteres braille: cada caractere tem 2×4 pontos, e os pontos ficam
+// aproximadamente quadrados num terminal (caracteres duas vezes mais
+// altos que largos).
+//
+// Parâmetros:
+//   cols, rows: dimensões do desenho em caracteres
+//
+// Retorna:
+//   int, int: largura e altura do renderizador em pixels (pontos)
+func BrailleSize(cols, rows int) (int, int) {
+	return cols * brailleCellWidth, rows * brailleCellHeight
+}
+
+// RenderBraille desenha as arestas da figura com caracteres braille,
+// usados como uma tela de 2×4 pontos por caractere: a resolução é oito
+// vezes a do desenho em ASCII (ver RenderASCII) com o mesmo número de
+// caracteres.
+//
+// A projeção e o recorte são os de RenderRetro, sobre uma grade de
+// pontos com as dimensões do renderizador (ver BrailleSize). Caracteres
+// sem nenhum ponto viram espaços. Cores, faces, vértices e nomes são
+// ignorados.
+//
+// Parâmetros:
+//   figure: figura 3D a ser desenhada
+//   cfg: configurações visuais (tratamento do plano próximo)
+//
+// Retorna:
+//   []string: linhas do desenho, sem espaços no fim
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderBraille(figure *types.Figure, cfg RenderConfig) ([]string, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	cols := (r.width + brailleCellWidth - 1) / brailleCellWidth
+	rows := (r.height + brailleCellHeight - 1) / brailleCellHeight
+	grid := make([][]rune, rows)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(string(brailleBlank), cols))
+	}
+
+	r.traceEdges(figure, cfg, func(p1, p2 types.Point2D) {
+		x0, y0 := int(math.Floor(p1.X)), int(math.Floor(p1.Y))
+		x1, y1 := int(math.Floor(p2.X)), int(math.Floor(p2.Y))
+		bresenham(x0, y0, x1, y1, func(x, y int) {
+			if x < 0 || y < 0 || x >= r.width || y >= r.height {
+				return
+			}
+			grid[y/brailleCellHeight][x/brailleCellWidth] |= brailleDots[x%brailleCellWidth][y%brailleCellHeight]
+		})
+	})
+
+	lines := make([]string, rows)
+	for i, row := range grid {
+		lines[i] = strings.TrimRight(strings.ReplaceAll(string(row), string(brailleBlank), " "), " ")
+	}
+	return lines, nil
+}
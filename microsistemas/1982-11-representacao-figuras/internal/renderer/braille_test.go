@@ -0,0 +1,75 @@
+package renderer
+
+import (
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestBrailleSize(t *testing.T) {
+	if w, h := BrailleSize(40, 12); w != 80 || h != 48 {
+		t.Errorf("Expected 80x48 dots, got %dx%d", w, h)
+	}
+}
+
+func TestRenderBraille(t *testing.T) {
+	// Quadrado de frente para a câmera padrão, com enquadramento automático
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: 1}, {X: -1, Y: 5, Z: 1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 0}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	r := New(BrailleSize(20, 10))
+	r.SetCamera(figure.Camera)
+	lines, err := r.RenderBraille(figure, DefaultRenderConfig())
+	if err != nil {
+		t.Fatalf("RenderBraille failed: %v", err)
+	}
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 rows, got %d", len(lines))
+	}
+
+	// A primeira linha com desenho é a aresta de cima: pontos na linha
+	// superior de cada caractere, do canto esquerdo ao direito
+	top := -1
+	for i, line := range lines {
+		if line != "" {
+			top = i
+			break
+		}
+	}
+	if top < 0 {
+		t.Fatal("Expected a drawing")
+	}
+	runes := []rune(lines[top])
+	if len(runes) < 3 {
+		t.Fatalf("Expected a horizontal edge, got %q", lines[top])
+	}
+	for _, ch := range runes {
+		if ch != ' ' && (ch < brailleBlank || ch > brailleBlank+0xff) {
+			t.Errorf("Expected only braille characters, got %q", lines[top])
+			break
+		}
+	}
+
+	if _, err := New(4, 4).RenderBraille(&types.Figure{}, DefaultRenderConfig()); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
+
+func TestBrailleDots(t *testing.T) {
+	// Todos os pontos juntos formam o caractere cheio (U+28FF)
+	all := brailleBlank
+	for _, col := range brailleDots {
+		for _, dot := range col {
+			all |= dot
+		}
+	}
+	if all != '⣿' {
+		t.Errorf("Expected full braille cell, got %q", all)
+	}
+}
@@ -0,0 +1,322 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// vec3 é um vetor tridimensional usado nos cálculos de orientação da câmera.
+//
+// Diferente de types.Point3D, não carrega nome nem tags YAML: é apenas
+// um auxiliar matemático interno do renderizador.
+type vec3 struct {
+	X, Y, Z float64
+}
+
+// toVec3 converte um ponto da figura em vetor.
+func toVec3(p types.Point3D) vec3 {
+	return vec3{X: p.X, Y: p.Y, Z: p.Z}
+}
+
+// sub retorna a diferença a - b.
+func (a vec3) sub(b vec3) vec3 {
+	return vec3{X: a.X - b.X, Y: a.Y - b.Y, Z: a.Z - b.Z}
+}
+
+// add retorna a soma a + b.
+func (a vec3) add(b vec3) vec3 {
+	return vec3{X: a.X + b.X, Y: a.Y + b.Y, Z: a.Z + b.Z}
+}
+
+// scale retorna o vetor multiplicado por k.
+func (a vec3) scale(k float64) vec3 {
+	return vec3{X: a.X * k, Y: a.Y * k, Z: a.Z * k}
+}
+
+// dot retorna o produto escalar a · b.
+func (a vec3) dot(b vec3) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// cross retorna o produto vetorial a × b.
+func (a vec3) cross(b vec3) vec3 {
+	return vec3{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
+
+// length retorna o comprimento do vetor.
+func (a vec3) length() float64 {
+	return math.Sqrt(a.dot(a))
+}
+
+// normalize retorna o vetor com comprimento 1 (ou o próprio vetor, se nulo).
+func (a vec3) normalize() vec3 {
+	l := a.length()
+	if l == 0 {
+		return a
+	}
+	return vec3{X: a.X / l, Y: a.Y / l, Z: a.Z / l}
+}
+
+// viewBasis representa a orientação do observador no espaço.
+//
+// Os três vetores unitários definem para onde o observador olha (forward),
+// qual direção aparece como "direita" na tela (right) e qual aparece como
+// "cima" (up). Expressar P' = P - V nesta base reduz qualquer câmera
+// orientada ao caso do artigo, onde o observador olha ao longo de +Y.
+type viewBasis struct {
+	right, up, forward vec3
+}
+
+// defaultBasis retorna a orientação do artigo original.
+//
+// O observador olha para +Y (profundidade), com X para a direita e Z
+// para cima, reproduzindo exatamente as fórmulas da página 7.
+func defaultBasis() viewBasis {
+	return viewBasis{
+		right:   vec3{X: 1},
+		up:      vec3{Z: 1},
+		forward: vec3{Y: 1},
+	}
+}
+
+// cameraBasis calcula a orientação do observador a partir do alvo da câmera.
+//
+// A direção "frente" aponta do observador para o alvo; "direita" é
+// perpendicular à frente e ao eixo vertical Z do mundo, de modo que o
+// horizonte permaneça nivelado. Quando o observador olha exatamente para
+// cima ou para baixo, o eixo Y é usado como referência.
+//
+// Parâmetros:
+//   camera: câmera com observador e alvo opcional
+//
+// Retorna:
+//   viewBasis: base ortonormal do observador (a do artigo se não há alvo)
+func cameraBasis(camera types.Camera) viewBasis {
+	if camera.Target == nil {
+		return defaultBasis()
+	}
+
+	forward := toVec3(*camera.Target).sub(toVec3(camera.Observer))
+	if forward.length() == 0 {
+		return defaultBasis()
+	}
+	forward = forward.normalize()
+
+	right := forward.cross(vec3{Z: 1})
+	if right.length() < 1e-9 {
+		// Olhando na vertical: usa +Y como referência para "cima" na tela
+		right = forward.cross(vec3{Y: 1})
+	}
+	right = right.normalize()
+
+	up := right.cross(forward)
+
+	return viewBasis{right: right, up: up, forward: forward}
+}
+
+// toCameraSpace expressa um ponto no sistema de coordenadas do observador.
+//
+// Retorna as componentes horizontal (px), vertical (py) e de profundidade
+// (pz) do vetor P - V, na mesma convenção usada pelas fórmulas do artigo.
+func (r *Renderer3D) toCameraSpace(p types.Point3D) (px, py, pz float64) {
+	d := toVec3(p).sub(toVec3(r.camera.Observer))
+	return d.dot(r.basis.right), d.dot(r.basis.up), d.dot(r.basis.forward)
+}
+
+// applyFOV deriva as dimensões da tela virtual a partir do campo de visão.
+//
+// Pela geometria da projeção cônica, a tela virtual de largura L1 a uma
+// distância R do observador cobre um ângulo horizontal de 2·atan(L1/2R).
+// Invertendo a relação:
+//   L1 = 2 · R · tan(FOV/2)
+//   L2 = L1 / proporção
+//
+// Parâmetros:
+//   camera: câmera possivelmente especificada por FOV
+//   aspect: proporção largura/altura da tela em pixels
+//
+// Retorna:
+//   types.Camera: câmera com Width/Height calculados (inalterada se FOV <= 0)
+func applyFOV(camera types.Camera, aspect float64) types.Camera {
+	// Em projeções paralelas não há ângulo de visão: L1/L2 valem como estão
+	if camera.FOV <= 0 || aspect <= 0 || camera.IsParallel() {
+		return camera
+	}
+
+	halfAngle := camera.FOV * math.Pi / 360 // FOV/2 em radianos
+	camera.Width = 2 * camera.Distance * math.Tan(halfAngle)
+	camera.Height = camera.Width / aspect
+	return camera
+}
+
+// obliqueAngle retorna o ângulo do eixo de profundidade oblíquo (padrão 45°).
+func obliqueAngle(camera types.Camera) float64 {
+	if camera.ObliqueAngle == 0 {
+		return 45
+	}
+	return camera.ObliqueAngle
+}
+
+// obliqueFactor retorna a redução do eixo de profundidade oblíquo (padrão 1).
+func obliqueFactor(camera types.Camera) float64 {
+	if camera.ObliqueFactor == 0 {
+		return 1
+	}
+	return camera.ObliqueFactor
+}
+
+// obliqueProject aplica a projeção paralela oblíqua.
+//
+// Na projeção oblíqua o plano frontal aparece em verdadeira grandeza e a
+// profundidade é desenhada ao longo de uma direção inclinada na tela:
+//   x = Px + f · d · cos(α)
+//   y = Py + f · d · sen(α)
+// onde d é a profundidade medida a partir do plano do alvo (para que o
+// alvo permaneça no centro da tela), α o ângulo e f o fator de redução.
+func (r *Renderer3D) obliqueProject(px, py, pz float64) (float64, float64) {
+	depth := pz
+	if r.camera.Target != nil {
+		_, _, targetDepth := r.toCameraSpace(*r.camera.Target)
+		depth -= targetDepth
+	}
+
+	angle := obliqueAngle(r.camera) * math.Pi / 180
+	factor := obliqueFactor(r.camera)
+
+	return px + factor*depth*math.Cos(angle), py + factor*depth*math.Sin(angle)
+}
+
+// obliqueShear descreve a projeção oblíqua como um cisalhamento do
+// mundo seguido da projeção ortogonal, para os formatos que só têm esta:
+//   P' = P + s · ((P - O) · frente)
+// onde O é o alvo (ou o observador), de onde obliqueProject mede a
+// profundidade, e s o deslocamento na tela por unidade de profundidade.
+//
+// Retorna:
+//   shear: o vetor s, no plano da tela
+//   origin: o ponto O
+func (r *Renderer3D) obliqueShear() (shear, origin vec3) {
+	angle := obliqueAngle(r.camera) * math.Pi / 180
+	factor := obliqueFactor(r.camera)
+	shear = r.basis.right.scale(factor * math.Cos(angle)).add(r.basis.up.scale(factor * math.Sin(angle)))
+	origin = toVec3(r.camera.Observer)
+	if r.camera.Target != nil {
+		origin = toVec3(*r.camera.Target)
+	}
+	return shear, origin
+}
+
+// DefaultFitMargin é a margem usada pelo enquadramento automático,
+// como fração do raio da figura (10% de folga ao redor).
+const DefaultFitMargin = 0.1
+
+// FitCamera posiciona o observador para que a figura inteira caiba na tela.
+//
+// Novos usuários frequentemente obtêm imagens em branco porque a câmera
+// padrão não enxerga sua geometria. Este enquadramento calcula a esfera
+// envolvente da figura (centro da caixa envolvente e maior distância até
+// um vértice) e afasta o observador até que a esfera, com margem, caiba
+// no menor dos ângulos de visão horizontal e vertical:
+//   d = raio · (1 + margem) / sen(ângulo)
+//
+// A direção de visão é preservada quando a câmera tem alvo (ou órbita);
+// caso contrário o observador olha ao longo de +Y, como no artigo.
+//
+// Parâmetros:
+//   camera: câmera de partida (distância R e L1/L2 ou FOV são mantidos)
+//   figure: figura a enquadrar
+//   aspect: proporção largura/altura da tela em pixels
+//
+// Retorna:
+//   types.Camera: câmera com observador e alvo recalculados e Auto desligado
+func FitCamera(camera types.Camera, figure *types.Figure, aspect float64) types.Camera {
+	camera.ResolveOrbit()
+
+	// Direção do alvo para o observador (padrão do artigo: observador em -Y)
+	dir := vec3{Y: -1}
+	if camera.Target != nil {
+		d := toVec3(camera.Observer).sub(toVec3(*camera.Target))
+		if d.length() > 0 {
+			dir = d.normalize()
+		}
+	}
+
+	// Esfera envolvente aproximada a partir da caixa envolvente
+	min, max := figure.Bounds()
+	center := vec3{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2, Z: (min.Z + max.Z) / 2}
+	radius := 0.0
+	for _, p := range figure.Pontos {
+		radius = math.Max(radius, toVec3(p).sub(center).length())
+	}
+	if radius == 0 {
+		radius = 1 // Figura degenerada (um único ponto): usa escala unitária
+	}
+
+	// Projeções paralelas: a escala vem de L1/L2, não da distância.
+	// A tela virtual passa a cobrir o diâmetro da esfera com margem
+	if camera.IsParallel() {
+		extent := 2 * radius * (1 + DefaultFitMargin)
+		if camera.Projection == types.ProjectionOblique {
+			// O eixo de profundidade oblíquo pode alongar a figura
+			extent *= 1 + obliqueFactor(camera)
+		}
+		if aspect >= 1 {
+			camera.Height = extent
+			camera.Width = extent * aspect
+		} else {
+			camera.Width = extent
+			camera.Height = extent / aspect
+		}
+	}
+
+	// Menor meio-ângulo de visão entre horizontal e vertical
+	sized := applyFOV(camera, aspect)
+	halfX := math.Atan2(sized.Width/2, sized.Distance)
+	halfY := math.Atan2(sized.Height/2, sized.Distance)
+	half := math.Min(halfX, halfY)
+
+	dist := radius * (1 + DefaultFitMargin) / math.Sin(half)
+
+	target := types.Point3D{X: center.X, Y: center.Y, Z: center.Z}
+	camera.Observer = types.Point3D{
+		X: center.X + dir.X*dist,
+		Y: center.Y + dir.Y*dist,
+		Z: center.Z + dir.Z*dist,
+	}
+	camera.Target = &target
+	camera.Orbit = nil
+	camera.Auto = false
+
+	return camera
+}
+
+// EffectiveCamera resolve tudo o que a câmera deixa implícito, resultando
+// na câmera que de fato é usada na renderização.
+//
+// Órbita, vista pré-definida e enquadramento automático são convertidos
+// em observador e alvo explícitos. A câmera resultante reproduz a mesma
+// imagem em qualquer modo (generate ou view), por isso é a que se grava
+// em arquivos de câmera. O campo de visão é mantido: L1/L2 continuam
+// acompanhando a proporção da imagem.
+//
+// Parâmetros:
+//   camera: câmera carregada, possivelmente com órbita, vista ou auto
+//   figure: figura a enquadrar (usada apenas se Auto estiver ligado)
+//   aspect: proporção largura/altura da tela em pixels
+//
+// Retorna:
+//   types.Camera: câmera apenas com observador, alvo e parâmetros da projeção
+func EffectiveCamera(camera types.Camera, figure *types.Figure, aspect float64) types.Camera {
+	if camera.Auto {
+		camera = FitCamera(camera, figure, aspect)
+	}
+	camera.ResolveOrbit()
+	camera.Orbit = nil
+	camera.View = ""
+	return camera
+}
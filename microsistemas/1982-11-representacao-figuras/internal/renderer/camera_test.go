@@ -0,0 +1,240 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestCameraBasis_Default(t *testing.T) {
+	// Sem alvo, a base deve ser exatamente a do artigo
+	basis := cameraBasis(types.DefaultCamera())
+	expected := defaultBasis()
+
+	if basis != expected {
+		t.Errorf("Expected article basis %+v, got %+v", expected, basis)
+	}
+}
+
+func TestCameraBasis_TargetAlongY(t *testing.T) {
+	// Alvo à frente em +Y deve reproduzir a base do artigo
+	camera := types.DefaultCamera()
+	camera.Target = &types.Point3D{X: 0, Y: 10, Z: 0}
+
+	basis := cameraBasis(camera)
+	expected := defaultBasis()
+
+	if !vecNear(basis.right, expected.right) || !vecNear(basis.up, expected.up) ||
+		!vecNear(basis.forward, expected.forward) {
+		t.Errorf("Expected article basis %+v, got %+v", expected, basis)
+	}
+}
+
+func TestCameraBasis_LookingDown(t *testing.T) {
+	// Olhando na vertical não deve gerar vetores nulos ou NaN
+	camera := types.DefaultCamera()
+	camera.Observer = types.Point3D{X: 0, Y: 0, Z: 10}
+	camera.Target = &types.Point3D{X: 0, Y: 0, Z: 0}
+
+	basis := cameraBasis(camera)
+
+	for name, v := range map[string]vec3{"right": basis.right, "up": basis.up, "forward": basis.forward} {
+		if math.Abs(v.length()-1) > 1e-9 {
+			t.Errorf("Expected unit %s vector, got %+v", name, v)
+		}
+	}
+}
+
+func TestProjectPoint_OrbitCamera(t *testing.T) {
+	// O alvo de uma câmera em órbita deve sempre cair no centro da tela
+	renderer := New(800, 600)
+
+	target := types.Point3D{X: 1, Y: 2, Z: 3}
+	camera := types.DefaultCamera()
+	camera.Target = &target
+	camera.Orbit = &types.Orbit{Azimuth: 45, Elevation: 30, Radius: 12}
+	renderer.SetCamera(camera)
+
+	result := renderer.ProjectToScreen(target)
+	if math.Abs(result.X-400) > 1e-6 || math.Abs(result.Y-300) > 1e-6 {
+		t.Errorf("Expected target at screen center (400,300), got (%f,%f)", result.X, result.Y)
+	}
+
+	// Um ponto acima do alvo deve aparecer acima do centro
+	above := renderer.ProjectToScreen(types.Point3D{X: 1, Y: 2, Z: 4})
+	if above.Y >= 300 {
+		t.Errorf("Expected point above target to project above center, got Y=%f", above.Y)
+	}
+}
+
+func TestFitCamera(t *testing.T) {
+	// Figura longe da câmera padrão: sem enquadramento ficaria fora da tela
+	figure := &types.Figure{
+		Nome: "far_away",
+		Pontos: []types.Point3D{
+			{X: 100, Y: 200, Z: 50},
+			{X: 104, Y: 204, Z: 54},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	camera := types.DefaultCamera()
+	camera.Auto = true
+	fitted := FitCamera(camera, figure, 800.0/600.0)
+
+	if fitted.Auto {
+		t.Error("Expected Auto to be cleared after fitting")
+	}
+
+	renderer := New(800, 600)
+	renderer.SetCamera(fitted)
+
+	// Todos os pontos devem cair dentro da tela
+	for i, p := range figure.Pontos {
+		s := renderer.ProjectToScreen(p)
+		if s.X < 0 || s.X > 800 || s.Y < 0 || s.Y > 600 {
+			t.Errorf("Point %d projected outside canvas: (%f,%f)", i, s.X, s.Y)
+		}
+	}
+
+	// O centro da figura deve ficar no centro da tela
+	center := renderer.ProjectToScreen(types.Point3D{X: 102, Y: 202, Z: 52})
+	if math.Abs(center.X-400) > 1e-6 || math.Abs(center.Y-300) > 1e-6 {
+		t.Errorf("Expected figure center at (400,300), got (%f,%f)", center.X, center.Y)
+	}
+}
+
+func TestEffectiveCamera(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: -1, Z: -1}, {X: 1, Y: 1, Z: 1}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	camera := types.DefaultCamera()
+	if err := camera.ApplyView("iso"); err != nil {
+		t.Fatalf("ApplyView failed: %v", err)
+	}
+	effective := EffectiveCamera(camera, figure, 800.0/600.0)
+
+	if effective.Auto || effective.Orbit != nil || effective.View != "" {
+		t.Errorf("Expected auto, orbit and view to be resolved, got %+v", effective)
+	}
+	if effective.Target == nil {
+		t.Fatal("Expected explicit target")
+	}
+
+	// Renderizar a câmera efetiva deve dar a mesma projeção da original
+	want := New(800, 600)
+	want.SetCamera(FitCamera(camera, figure, 800.0/600.0))
+	got := New(800, 600)
+	got.SetCamera(effective)
+	for i, p := range figure.Pontos {
+		a, b := want.ProjectToScreen(p), got.ProjectToScreen(p)
+		if math.Abs(a.X-b.X) > 1e-9 || math.Abs(a.Y-b.Y) > 1e-9 {
+			t.Errorf("Point %d: expected (%f,%f), got (%f,%f)", i, a.X, a.Y, b.X, b.Y)
+		}
+	}
+
+	// Órbita sem enquadramento automático vira observador explícito
+	orbit := types.DefaultCamera()
+	orbit.Orbit = &types.Orbit{Azimuth: 90, Elevation: 0, Radius: 5}
+	resolved := EffectiveCamera(orbit, figure, 1)
+	if resolved.Orbit != nil {
+		t.Error("Expected orbit to be cleared")
+	}
+	if math.Abs(resolved.Observer.X-5) > 1e-9 || math.Abs(resolved.Observer.Y) > 1e-9 {
+		t.Errorf("Expected observer at (5,0,0), got %+v", resolved.Observer)
+	}
+}
+
+func TestRenderFigure_AutoCamera(t *testing.T) {
+	renderer := New(200, 150)
+
+	figure := &types.Figure{
+		Nome:   "auto",
+		Pontos: []types.Point3D{{X: -50, Y: -50, Z: 0}, {X: 50, Y: 50, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.Camera{Distance: 10, Width: 12.8, Height: 9.6, Auto: true},
+	}
+
+	renderer.SetCamera(figure.Camera)
+	if err := renderer.RenderFigure(figure); err != nil {
+		t.Fatalf("RenderFigure failed: %v", err)
+	}
+
+	if renderer.camera.Auto {
+		t.Error("Expected renderer camera to be fitted during rendering")
+	}
+}
+
+func TestProjectPoint_Orthographic(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.Camera{
+		Distance:   10,
+		Width:      8,
+		Height:     6,
+		Projection: types.ProjectionOrthographic,
+	})
+
+	// Na projeção paralela a profundidade não altera a posição na tela
+	near := renderer.ProjectToScreen(types.Point3D{X: 1, Y: 2, Z: 1})
+	far := renderer.ProjectToScreen(types.Point3D{X: 1, Y: 50, Z: 1})
+
+	if math.Abs(near.X-far.X) > 1e-9 || math.Abs(near.Y-far.Y) > 1e-9 {
+		t.Errorf("Expected depth-independent projection, got %+v and %+v", near, far)
+	}
+
+	// 1 unidade = 100 pixels (800/8)
+	if math.Abs(near.X-500) > 1e-9 || math.Abs(near.Y-200) > 1e-9 {
+		t.Errorf("Expected (500,200), got (%f,%f)", near.X, near.Y)
+	}
+}
+
+func TestProjectPoint_Oblique(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.Camera{
+		Distance:      10,
+		Width:         8,
+		Height:        6,
+		Projection:    types.ProjectionOblique,
+		ObliqueAngle:  45,
+		ObliqueFactor: 0.5,
+	})
+
+	// Profundidade d desloca o ponto em f·d ao longo de 45°
+	front := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 0, Z: 0})
+	back := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 2, Z: 0})
+
+	offset := 0.5 * 2 * math.Cos(math.Pi/4) * 100
+	if math.Abs(back.X-front.X-offset) > 1e-6 || math.Abs(front.Y-back.Y-offset) > 1e-6 {
+		t.Errorf("Expected oblique offset %f, got dx=%f dy=%f",
+			offset, back.X-front.X, front.Y-back.Y)
+	}
+}
+
+func TestFitCamera_Parallel(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -3, Y: -3, Z: -3}, {X: 3, Y: 3, Z: 3}},
+	}
+
+	camera := types.DefaultCamera()
+	if err := camera.ApplyView("iso"); err != nil {
+		t.Fatalf("ApplyView failed: %v", err)
+	}
+
+	renderer := New(800, 600)
+	renderer.SetCamera(FitCamera(camera, figure, 800.0/600.0))
+
+	for _, p := range figure.Pontos {
+		s := renderer.ProjectToScreen(p)
+		if s.X < 0 || s.X > 800 || s.Y < 0 || s.Y > 600 {
+			t.Errorf("Point %+v projected outside canvas: (%f,%f)", p, s.X, s.Y)
+		}
+	}
+}
+
+// vecNear compara dois vetores com tolerância numérica
+func vecNear(a, b vec3) bool {
+	return a.sub(b).length() < 1e-9
+}
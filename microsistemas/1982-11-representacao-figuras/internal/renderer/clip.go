@@ -0,0 +1,267 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultNearPlane é a profundidade mínima padrão, a partir do observador,
+// de um ponto desenhado na projeção cônica (o "plano próximo").
+//
+// O artigo não trata pontos atrás do observador: para eles Pz ≤ 0 e as
+// fórmulas x = Px·R/Pz, y = Py·R/Pz invertem ou explodem. Recortar as
+// arestas neste plano mantém apenas a parte realmente visível. Cenas em
+// escala muito pequena (ou muito grande) podem ajustar o valor via
+// RenderConfig.NearPlane.
+const DefaultNearPlane = 0.1
+
+// SetNearPlane define o plano próximo usado por ProjectPoint,
+// ProjectToScreen e ProjectPoints, para que projetem os pontos como o
+// desenho. RenderFigureWithConfig não o altera: cada desenho usa o
+// RenderConfig.NearPlane da própria chamada.
+//
+// Parâmetros:
+//   near: profundidade do plano próximo (0 = DefaultNearPlane)
+//
+// Retorna:
+//   error: erro se a profundidade for negativa
+func (r *Renderer3D) SetNearPlane(near float64) error {
+	if near < 0 {
+		return fmt.Errorf("plano próximo inválido: %g (deve ser positivo)", near)
+	}
+	if near == 0 {
+		near = DefaultNearPlane
+	}
+	r.near = near
+	return nil
+}
+
+// BehindPolicy define o tratamento de pontos atrás do plano próximo
+// na projeção cônica.
+type BehindPolicy string
+
+// Políticas para pontos atrás do observador.
+const (
+	// BehindClip recorta as arestas no plano próximo e desenha apenas
+	// o trecho visível (padrão)
+	BehindClip BehindPolicy = "recortar"
+
+	// BehindClamp aproxima os pontos de trás até o plano próximo, como
+	// a implementação original; rápido, mas pode gerar traços espúrios
+	BehindClamp BehindPolicy = "ajustar"
+
+	// BehindDrop descarta qualquer aresta com um extremo atrás do plano
+	BehindDrop BehindPolicy = "descartar"
+)
+
+// behindPolicyAliases aceita também os nomes em inglês das políticas.
+var behindPolicyAliases = map[string]BehindPolicy{
+	"recortar":  BehindClip,
+	"clip":      BehindClip,
+	"ajustar":   BehindClamp,
+	"clamp":     BehindClamp,
+	"descartar": BehindDrop,
+	"drop":      BehindDrop,
+}
+
+// parseBehindPolicy converte o nome de uma política (português ou inglês).
+//
+// Parâmetros:
+//   value: nome da política, sem distinção de maiúsculas
+//
+// Retorna:
+//   BehindPolicy: política correspondente
+//   error: erro se o nome for desconhecido
+func parseBehindPolicy(value string) (BehindPolicy, error) {
+	policy, ok := behindPolicyAliases[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("política desconhecida: %q (use %s, %s ou %s)",
+			value, BehindClip, BehindClamp, BehindDrop)
+	}
+	return policy, nil
+}
+
+// cameraPoint expressa um ponto no sistema de coordenadas do observador.
+//
+// X e Y são as componentes horizontal e vertical e Z a profundidade,
+// como em toCameraSpace.
+func (r *Renderer3D) cameraPoint(p types.Point3D) vec3 {
+	px, py, pz := r.toCameraSpace(p)
+	return vec3{X: px, Y: py, Z: pz}
+}
+
+// clipNear recorta um segmento pelo plano próximo da projeção cônica.
+//
+// Os extremos são dados no sistema do observador (Z = profundidade).
+// Se o segmento atravessa o plano Z = near, o extremo que fica atrás é
+// substituído pelo ponto de interseção, obtido por interpolação linear:
+//   t = (near - Az) / (Bz - Az)
+//   I = A + t · (B - A)
+//
+// Parâmetros:
+//   a, b: extremos do segmento no sistema do observador
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   vec3, vec3: extremos do trecho visível
+//   bool: false se o segmento está inteiramente atrás do plano
+func clipNear(a, b vec3, near float64) (vec3, vec3, bool) {
+	aIn := a.Z >= near
+	bIn := b.Z >= near
+
+	switch {
+	case aIn && bIn:
+		return a, b, true
+	case !aIn && !bIn:
+		return a, b, false
+	}
+
+	t := (near - a.Z) / (b.Z - a.Z)
+	hit := vec3{
+		X: a.X + t*(b.X-a.X),
+		Y: a.Y + t*(b.Y-a.Y),
+		Z: near,
+	}
+
+	if aIn {
+		return a, hit, true
+	}
+	return hit, b, true
+}
+
+// clipSegment recorta um segmento pelo volume de visão e o projeta em pixels.
+//
+// É o mesmo tratamento das arestas da figura com a política de recorte:
+// segmentos inteiramente fora do volume de visão são descartados e o
+// trecho atrás do plano próximo é removido (ver clipNear). O restante
+// do recorte pelas bordas fica a cargo do próprio desenho.
+//
+// Parâmetros:
+//   a, b: extremos do segmento no sistema do observador
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   types.Point2D, types.Point2D: extremos visíveis em pixels
+//   bool: false se nada do segmento está visível
+func (r *Renderer3D) clipSegment(a, b vec3, near float64) (types.Point2D, types.Point2D, bool) {
+	codeA, codeB := r.outcode(a, near), r.outcode(b, near)
+	if codeA&codeB != 0 {
+		return types.Point2D{}, types.Point2D{}, false
+	}
+	if (codeA|codeB)&outsideNear != 0 {
+		a, b, _ = clipNear(a, b, near)
+	}
+	return r.ViewportTransform(r.projectCameraSpace(a.X, a.Y, a.Z)),
+		r.ViewportTransform(r.projectCameraSpace(b.X, b.Y, b.Z)), true
+}
+
+// visibleEdge calcula o trecho visível de uma aresta entre dois pontos
+// já projetados.
+//
+// Arestas inteiramente fora do volume de visão são descartadas; as que
+// cruzam o plano próximo são recortadas nele, e apenas o trecho à frente
+// do observador é desenhado (ou a aresta inteira é descartada, conforme
+// a política).
+//
+// Parâmetros:
+//   proj: pontos projetados (ver projectBatch)
+//   a, b: índices dos extremos da aresta
+//   near: profundidade do plano próximo
+//   policy: tratamento das arestas que cruzam o plano próximo
+//
+// Retorna:
+//   types.Point2D, types.Point2D: extremos visíveis em pixels
+//   float64, float64: profundidades desses extremos
+//   bool: false se nada da aresta deve ser desenhado
+func (r *Renderer3D) visibleEdge(proj projectedPoints, a, b int, near float64, policy BehindPolicy) (types.Point2D, types.Point2D, float64, float64, bool) {
+	codeA, codeB := proj.codes[a], proj.codes[b]
+	if codeA&codeB != 0 {
+		return types.Point2D{}, types.Point2D{}, 0, 0, false
+	}
+
+	if (codeA|codeB)&outsideNear == 0 {
+		return r.screenPoint(proj, a), r.screenPoint(proj, b), proj.camera[a].Z, proj.camera[b].Z, true
+	}
+	if policy == BehindDrop {
+		return types.Point2D{}, types.Point2D{}, 0, 0, false
+	}
+	ca, cb, ok := clipNear(proj.camera[a], proj.camera[b], near)
+	if !ok {
+		return types.Point2D{}, types.Point2D{}, 0, 0, false // Aresta inteiramente atrás do observador
+	}
+	return r.ViewportTransform(r.projectCameraSpace(ca.X, ca.Y, ca.Z)),
+		r.ViewportTransform(r.projectCameraSpace(cb.X, cb.Y, cb.Z)), ca.Z, cb.Z, true
+}
+
+// Códigos de região do volume de visão (no estilo de Cohen-Sutherland).
+//
+// Cada bit indica um semiespaço, delimitado por um dos planos do volume
+// de visão, no qual o ponto está do lado de fora. Se os dois extremos
+// de uma aresta compartilham algum bit, a aresta inteira está fora.
+const (
+	outsideNear   uint8 = 1 << iota // Atrás do plano próximo (só cônica)
+	outsideLeft                     // À esquerda da tela virtual
+	outsideRight                    // À direita da tela virtual
+	outsideBottom                   // Abaixo da tela virtual
+	outsideTop                      // Acima da tela virtual
+)
+
+// outcode calcula o código de região de um ponto no sistema do observador.
+//
+// Na projeção cônica, os planos laterais passam pelo observador e pelas
+// bordas da janela visível (a tela virtual L1×L2, ou a região definida
+// por SetRegion) à distância R: um ponto está à direita se
+//   Px · R > Pz · xmax
+// (a forma multiplicada vale também atrás do observador, mantendo cada
+// teste um semiespaço). Nas projeções paralelas, compara-se diretamente
+// a posição projetada com os limites da janela.
+//
+// Parâmetros:
+//   c: ponto no sistema do observador (Z = profundidade)
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   uint8: combinação dos bits outside* (0 = dentro do volume de visão)
+func (r *Renderer3D) outcode(c vec3, near float64) uint8 {
+	var x, y float64
+	var code uint8
+
+	// Limites da janela visível no plano projetante; na cônica são
+	// multiplicados pela profundidade (ver acima)
+	w := r.window()
+	scale := 1.0
+
+	switch r.camera.Projection {
+	case types.ProjectionOrthographic:
+		x, y = c.X, c.Y
+
+	case types.ProjectionOblique:
+		x, y = r.obliqueProject(c.X, c.Y, c.Z)
+
+	default:
+		if c.Z < near {
+			code |= outsideNear
+		}
+		x, y = c.X*r.camera.Distance, c.Y*r.camera.Distance
+		scale = c.Z
+	}
+
+	// Testes independentes: atrás do observador um ponto pode estar
+	// fora de dois semiespaços opostos ao mesmo tempo
+	if x < scale*w.XMin {
+		code |= outsideLeft
+	}
+	if x > scale*w.XMax {
+		code |= outsideRight
+	}
+	if y < scale*w.YMin {
+		code |= outsideBottom
+	}
+	if y > scale*w.YMax {
+		code |= outsideTop
+	}
+
+	return code
+}
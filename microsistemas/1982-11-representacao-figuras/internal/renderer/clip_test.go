@@ -0,0 +1,310 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestClipNear(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    vec3
+		wantA   vec3
+		wantB   vec3
+		visible bool
+	}{
+		{
+			name:    "both in front",
+			a:       vec3{X: 1, Z: 5},
+			b:       vec3{X: -1, Z: 2},
+			wantA:   vec3{X: 1, Z: 5},
+			wantB:   vec3{X: -1, Z: 2},
+			visible: true,
+		},
+		{
+			name:    "both behind",
+			a:       vec3{Z: -1},
+			b:       vec3{Z: -0.05},
+			visible: false,
+		},
+		{
+			name:    "second behind",
+			a:       vec3{X: 2, Y: 2, Z: 2},
+			b:       vec3{X: -2, Y: 0, Z: -2},
+			wantA:   vec3{X: 2, Y: 2, Z: 2},
+			wantB:   vec3{X: 0, Y: 1, Z: 0},
+			visible: true,
+		},
+		{
+			name:    "first behind",
+			a:       vec3{X: -2, Y: 0, Z: -2},
+			b:       vec3{X: 2, Y: 2, Z: 2},
+			wantA:   vec3{X: 0, Y: 1, Z: 0},
+			wantB:   vec3{X: 2, Y: 2, Z: 2},
+			visible: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, ok := clipNear(tt.a, tt.b, 0)
+			if ok != tt.visible {
+				t.Fatalf("Expected visible=%v, got %v", tt.visible, ok)
+			}
+			if !ok {
+				return
+			}
+			if !vecNear(a, tt.wantA) || !vecNear(b, tt.wantB) {
+				t.Errorf("Expected %+v-%+v, got %+v-%+v", tt.wantA, tt.wantB, a, b)
+			}
+		})
+	}
+}
+
+func TestRenderFigure_LineCrossingNearPlane(t *testing.T) {
+	renderer := New(400, 300)
+
+	// Aresta que passa ao lado e por trás do observador na origem: a
+	// parte visível projeta-se à direita do centro, enquanto o antigo
+	// ajuste pz = 0.1 levava o extremo de trás para a borda esquerda
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 3, Y: 5, Z: 0},
+			{X: -1, Y: -5, Z: 0},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigure(figure); err != nil {
+		t.Fatalf("RenderFigure failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	if hasDarkPixel(img, image.Rect(0, 0, 200, 300)) {
+		t.Error("Expected no spurious line on the left half of the image")
+	}
+	if !hasDarkPixel(img, image.Rect(200, 140, 400, 160)) {
+		t.Error("Expected the visible part of the line to be drawn")
+	}
+}
+
+func TestOutcode(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.DefaultCamera()) // R=10, L1=12.8, L2=9.6
+
+	tests := []struct {
+		name string
+		p    types.Point3D
+		want uint8
+	}{
+		{"center", types.Point3D{Y: 5}, 0},
+		{"inside corner", types.Point3D{X: 3, Y: 5, Z: -2}, 0},
+		{"left", types.Point3D{X: -4, Y: 5}, outsideLeft},
+		{"right and top", types.Point3D{X: 4, Y: 5, Z: 3}, outsideRight | outsideTop},
+		{"bottom", types.Point3D{Y: 5, Z: -3}, outsideBottom},
+		// Atrás do observador, o ponto fica fora de todos os planos laterais
+		{"behind", types.Point3D{Y: -5}, outsideNear | outsideLeft | outsideRight | outsideBottom | outsideTop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderer.outcode(renderer.cameraPoint(tt.p), DefaultNearPlane)
+			if got != tt.want {
+				t.Errorf("Expected outcode %05b, got %05b", tt.want, got)
+			}
+		})
+	}
+
+	// Na projeção ortogonal os limites são a própria tela virtual
+	renderer.SetCamera(types.Camera{
+		Distance:   10,
+		Width:      4,
+		Height:     3,
+		Projection: types.ProjectionOrthographic,
+	})
+	if got := renderer.outcode(renderer.cameraPoint(types.Point3D{X: 1, Y: -50}), DefaultNearPlane); got != 0 {
+		t.Errorf("Expected orthographic point behind observer inside, got %05b", got)
+	}
+	if got := renderer.outcode(renderer.cameraPoint(types.Point3D{X: 3, Y: 5}), DefaultNearPlane); got != outsideRight {
+		t.Errorf("Expected orthographic point right of window, got %05b", got)
+	}
+}
+
+func TestRenderFigure_CullsLinesOutsideFrustum(t *testing.T) {
+	renderer := New(400, 300)
+
+	// Duas arestas: uma visível e outra inteiramente à esquerda da tela
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 0, Y: 5, Z: -1},
+			{X: 0, Y: 5, Z: 1},
+			{X: -20, Y: 5, Z: 0},
+			{X: -30, Y: 5, Z: 0},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 2, P2: 3}},
+		Camera: types.DefaultCamera(),
+	}
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigure(figure); err != nil {
+		t.Fatalf("RenderFigure failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	if !hasDarkPixel(img, image.Rect(195, 100, 205, 200)) {
+		t.Error("Expected the visible line to be drawn")
+	}
+}
+
+func TestVisibleEdge_ProjectsOnlyDrawnPoints(t *testing.T) {
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+
+	// Uma aresta visível e outra inteiramente à esquerda da tela
+	points := []types.Point3D{{X: 0, Y: 5, Z: -1}, {X: 0, Y: 5, Z: 1}, {X: -20, Y: 5}, {X: -30, Y: 5}}
+	proj := renderer.projectBatch(points, DefaultNearPlane, false)
+	for i, done := range proj.done {
+		if done {
+			t.Errorf("Expected point %d not projected by the batch", i)
+		}
+	}
+
+	if _, _, _, _, ok := renderer.visibleEdge(proj, 2, 3, DefaultNearPlane, BehindClip); ok {
+		t.Error("Expected the culled edge to be rejected")
+	}
+	if proj.done[2] || proj.done[3] {
+		t.Error("Expected culled points to stay unprojected")
+	}
+
+	p1, p2, _, _, ok := renderer.visibleEdge(proj, 0, 1, DefaultNearPlane, BehindClip)
+	if !ok || !proj.done[0] || !proj.done[1] {
+		t.Fatal("Expected the visible edge to be projected")
+	}
+	if want := renderer.ProjectToScreen(points[0]); p1 != want {
+		t.Errorf("Expected %v, got %v", want, p1)
+	}
+	if want := renderer.ProjectToScreen(points[1]); p2 != want {
+		t.Errorf("Expected %v, got %v", want, p2)
+	}
+}
+
+func TestRenderFigure_BehindPolicy(t *testing.T) {
+	// A mesma aresta de TestRenderFigure_LineCrossingNearPlane: o trecho
+	// visível fica à direita, e o extremo de trás, se ajustado, à esquerda
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 3, Y: 5, Z: 0},
+			{X: -1, Y: -5, Z: 0},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+
+	tests := []struct {
+		policy    BehindPolicy
+		wantLeft  bool
+		wantRight bool
+	}{
+		{BehindClip, false, true},
+		{BehindClamp, true, true},
+		{BehindDrop, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			renderer := New(400, 300)
+			renderer.SetCamera(figure.Camera)
+
+			cfg := DefaultRenderConfig()
+			cfg.BehindPolicy = tt.policy
+			if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+
+			img := renderer.GetImage().(image.Image)
+			if got := hasDarkPixel(img, image.Rect(0, 140, 200, 160)); got != tt.wantLeft {
+				t.Errorf("Expected drawing on the left=%v, got %v", tt.wantLeft, got)
+			}
+			if got := hasDarkPixel(img, image.Rect(200, 140, 400, 160)); got != tt.wantRight {
+				t.Errorf("Expected drawing on the right=%v, got %v", tt.wantRight, got)
+			}
+		})
+	}
+}
+
+func TestRenderFigure_NearPlane(t *testing.T) {
+	// Aresta muito próxima do observador, em escala milimétrica: com o
+	// plano próximo padrão (0.1) ela desaparece por inteiro
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -0.001, Y: 0.05, Z: 0},
+			{X: 0.001, Y: 0.05, Z: 0},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+
+	for _, near := range []float64{DefaultNearPlane, 0.01} {
+		renderer := New(400, 300)
+		renderer.SetCamera(figure.Camera)
+
+		cfg := DefaultRenderConfig()
+		cfg.NearPlane = near
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		img := renderer.GetImage().(image.Image)
+		drawn := hasDarkPixel(img, image.Rect(0, 140, 400, 160))
+		if want := near < 0.05; drawn != want {
+			t.Errorf("Near plane %g: expected drawn=%v, got %v", near, want, drawn)
+		}
+	}
+}
+
+func TestProjectPoint_NearPlane(t *testing.T) {
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+
+	// Ponto a 0.05 do observador: trazido até o plano próximo em uso
+	p := types.Point3D{X: 0.001, Y: 0.05}
+	onPlane := func(near float64) types.Point2D {
+		return renderer.ProjectPoint(types.Point3D{X: 0.001, Y: near})
+	}
+
+	if got, want := renderer.ProjectPoint(p), onPlane(DefaultNearPlane); got != want {
+		t.Errorf("Expected default near plane projection %v, got %v", want, got)
+	}
+
+	if err := renderer.SetNearPlane(0.01); err != nil {
+		t.Fatalf("SetNearPlane failed: %v", err)
+	}
+	if got := renderer.ProjectPoint(p); got == onPlane(DefaultNearPlane) {
+		t.Error("Expected the configured near plane to be used, got the default one")
+	}
+	if got := renderer.ProjectPoints([]types.Point3D{p})[0]; got != renderer.ProjectPoint(p) {
+		t.Errorf("Expected ProjectPoints to match ProjectPoint, got %v", got)
+	}
+	if err := renderer.SetNearPlane(-1); err == nil {
+		t.Error("Expected error for negative near plane")
+	}
+
+	// O plano da configuração de um desenho não fica para o próximo
+	// uso do mesmo renderizador
+	if err := renderer.SetNearPlane(0.01); err != nil {
+		t.Fatalf("SetNearPlane failed: %v", err)
+	}
+	want := renderer.ProjectPoint(p)
+	cfg := DefaultRenderConfig()
+	cfg.NearPlane = 0.5
+	if err := renderer.RenderFigureWithConfig(&types.Figure{Pontos: []types.Point3D{{Y: 5}}, Camera: types.DefaultCamera()}, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	if got := renderer.ProjectPoint(p); got != want {
+		t.Errorf("Expected the renderer's own near plane after rendering %v, got %v", want, got)
+	}
+}
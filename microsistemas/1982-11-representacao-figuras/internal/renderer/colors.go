@@ -0,0 +1,183 @@
+package renderer
+
+import "math"
+
+// namedColors contém as cores nomeadas do CSS, para conveniência.
+//
+// Permite uso de nomes intuitivos em vez de códigos hexadecimais,
+// tornando os arquivos YAML mais legíveis. A tabela é a lista completa
+// de nomes do CSS (CSS Color Module Level 4), com os mesmos valores,
+// incluindo as variações de grafia (gray/grey). Note que, como no CSS,
+// "darkgray" é mais claro que "gray"; os cinzas da tabela antiga, que
+// não seguia o CSS, continuam disponíveis com os nomes em português.
+var namedColors = map[string]colorRGB{
+	"aliceblue":            hexColor(0xf0f8ff),
+	"antiquewhite":         hexColor(0xfaebd7),
+	"aqua":                 hexColor(0x00ffff),
+	"aquamarine":           hexColor(0x7fffd4),
+	"azure":                hexColor(0xf0ffff),
+	"beige":                hexColor(0xf5f5dc),
+	"bisque":               hexColor(0xffe4c4),
+	"black":                hexColor(0x000000),
+	"blanchedalmond":       hexColor(0xffebcd),
+	"blue":                 hexColor(0x0000ff),
+	"blueviolet":           hexColor(0x8a2be2),
+	"brown":                hexColor(0xa52a2a),
+	"burlywood":            hexColor(0xdeb887),
+	"cadetblue":            hexColor(0x5f9ea0),
+	"chartreuse":           hexColor(0x7fff00),
+	"chocolate":            hexColor(0xd2691e),
+	"coral":                hexColor(0xff7f50),
+	"cornflowerblue":       hexColor(0x6495ed),
+	"cornsilk":             hexColor(0xfff8dc),
+	"crimson":              hexColor(0xdc143c),
+	"cyan":                 hexColor(0x00ffff),
+	"darkblue":             hexColor(0x00008b),
+	"darkcyan":             hexColor(0x008b8b),
+	"darkgoldenrod":        hexColor(0xb8860b),
+	"darkgray":             hexColor(0xa9a9a9),
+	"darkgreen":            hexColor(0x006400),
+	"darkgrey":             hexColor(0xa9a9a9),
+	"darkkhaki":            hexColor(0xbdb76b),
+	"darkmagenta":          hexColor(0x8b008b),
+	"darkolivegreen":       hexColor(0x556b2f),
+	"darkorange":           hexColor(0xff8c00),
+	"darkorchid":           hexColor(0x9932cc),
+	"darkred":              hexColor(0x8b0000),
+	"darksalmon":           hexColor(0xe9967a),
+	"darkseagreen":         hexColor(0x8fbc8f),
+	"darkslateblue":        hexColor(0x483d8b),
+	"darkslategray":        hexColor(0x2f4f4f),
+	"darkslategrey":        hexColor(0x2f4f4f),
+	"darkturquoise":        hexColor(0x00ced1),
+	"darkviolet":           hexColor(0x9400d3),
+	"deeppink":             hexColor(0xff1493),
+	"deepskyblue":          hexColor(0x00bfff),
+	"dimgray":              hexColor(0x696969),
+	"dimgrey":              hexColor(0x696969),
+	"dodgerblue":           hexColor(0x1e90ff),
+	"firebrick":            hexColor(0xb22222),
+	"floralwhite":          hexColor(0xfffaf0),
+	"forestgreen":          hexColor(0x228b22),
+	"fuchsia":              hexColor(0xff00ff),
+	"gainsboro":            hexColor(0xdcdcdc),
+	"ghostwhite":           hexColor(0xf8f8ff),
+	"gold":                 hexColor(0xffd700),
+	"goldenrod":            hexColor(0xdaa520),
+	"gray":                 hexColor(0x808080),
+	"green":                hexColor(0x008000),
+	"greenyellow":          hexColor(0xadff2f),
+	"grey":                 hexColor(0x808080),
+	"honeydew":             hexColor(0xf0fff0),
+	"hotpink":              hexColor(0xff69b4),
+	"indianred":            hexColor(0xcd5c5c),
+	"indigo":               hexColor(0x4b0082),
+	"ivory":                hexColor(0xfffff0),
+	"khaki":                hexColor(0xf0e68c),
+	"lavender":             hexColor(0xe6e6fa),
+	"lavenderblush":        hexColor(0xfff0f5),
+	"lawngreen":            hexColor(0x7cfc00),
+	"lemonchiffon":         hexColor(0xfffacd),
+	"lightblue":            hexColor(0xadd8e6),
+	"lightcoral":           hexColor(0xf08080),
+	"lightcyan":            hexColor(0xe0ffff),
+	"lightgoldenrodyellow": hexColor(0xfafad2),
+	"lightgray":            hexColor(0xd3d3d3),
+	"lightgreen":           hexColor(0x90ee90),
+	"lightgrey":            hexColor(0xd3d3d3),
+	"lightpink":            hexColor(0xffb6c1),
+	"lightsalmon":          hexColor(0xffa07a),
+	"lightseagreen":        hexColor(0x20b2aa),
+	"lightskyblue":         hexColor(0x87cefa),
+	"lightslategray":       hexColor(0x778899),
+	"lightslategrey":       hexColor(0x778899),
+	"lightsteelblue":       hexColor(0xb0c4de),
+	"lightyellow":          hexColor(0xffffe0),
+	"lime":                 hexColor(0x00ff00),
+	"limegreen":            hexColor(0x32cd32),
+	"linen":                hexColor(0xfaf0e6),
+	"magenta":              hexColor(0xff00ff),
+	"maroon":               hexColor(0x800000),
+	"mediumaquamarine":     hexColor(0x66cdaa),
+	"mediumblue":           hexColor(0x0000cd),
+	"mediumorchid":         hexColor(0xba55d3),
+	"mediumpurple":         hexColor(0x9370db),
+	"mediumseagreen":       hexColor(0x3cb371),
+	"mediumslateblue":      hexColor(0x7b68ee),
+	"mediumspringgreen":    hexColor(0x00fa9a),
+	"mediumturquoise":      hexColor(0x48d1cc),
+	"mediumvioletred":      hexColor(0xc71585),
+	"midnightblue":         hexColor(0x191970),
+	"mintcream":            hexColor(0xf5fffa),
+	"mistyrose":            hexColor(0xffe4e1),
+	"moccasin":             hexColor(0xffe4b5),
+	"navajowhite":          hexColor(0xffdead),
+	"navy":                 hexColor(0x000080),
+	"oldlace":              hexColor(0xfdf5e6),
+	"olive":                hexColor(0x808000),
+	"olivedrab":            hexColor(0x6b8e23),
+	"orange":               hexColor(0xffa500),
+	"orangered":            hexColor(0xff4500),
+	"orchid":               hexColor(0xda70d6),
+	"palegoldenrod":        hexColor(0xeee8aa),
+	"palegreen":            hexColor(0x98fb98),
+	"paleturquoise":        hexColor(0xafeeee),
+	"palevioletred":        hexColor(0xdb7093),
+	"papayawhip":           hexColor(0xffefd5),
+	"peachpuff":            hexColor(0xffdab9),
+	"peru":                 hexColor(0xcd853f),
+	"pink":                 hexColor(0xffc0cb),
+	"plum":                 hexColor(0xdda0dd),
+	"powderblue":           hexColor(0xb0e0e6),
+	"purple":               hexColor(0x800080),
+	"rebeccapurple":        hexColor(0x663399),
+	"red":                  hexColor(0xff0000),
+	"rosybrown":            hexColor(0xbc8f8f),
+	"royalblue":            hexColor(0x4169e1),
+	"saddlebrown":          hexColor(0x8b4513),
+	"salmon":               hexColor(0xfa8072),
+	"sandybrown":           hexColor(0xf4a460),
+	"seagreen":             hexColor(0x2e8b57),
+	"seashell":             hexColor(0xfff5ee),
+	"sienna":               hexColor(0xa0522d),
+	"silver":               hexColor(0xc0c0c0),
+	"skyblue":              hexColor(0x87ceeb),
+	"slateblue":            hexColor(0x6a5acd),
+	"slategray":            hexColor(0x708090),
+	"slategrey":            hexColor(0x708090),
+	"snow":                 hexColor(0xfffafa),
+	"springgreen":          hexColor(0x00ff7f),
+	"steelblue":            hexColor(0x4682b4),
+	"tan":                  hexColor(0xd2b48c),
+	"teal":                 hexColor(0x008080),
+	"thistle":              hexColor(0xd8bfd8),
+	"tomato":               hexColor(0xff6347),
+	"turquoise":            hexColor(0x40e0d0),
+	"violet":               hexColor(0xee82ee),
+	"wheat":                hexColor(0xf5deb3),
+	"white":                hexColor(0xffffff),
+	"whitesmoke":           hexColor(0xf5f5f5),
+	"yellow":               hexColor(0xffff00),
+	"yellowgreen":          hexColor(0x9acd32),
+
+	// Cinzas da tabela antiga: o "darkgray" de antes era um cinza a 25%,
+	// bem mais escuro que o do CSS
+	"cinza":       {R: 0.5, G: 0.5, B: 0.5, A: 1},
+	"cinzaclaro":  {R: 0.82, G: 0.82, B: 0.82, A: 1},
+	"cinzaescuro": {R: 0.25, G: 0.25, B: 0.25, A: 1},
+
+	// Sem cor (útil como fundo de PNG transparente)
+	"transparent":  {},
+	"transparente": {},
+}
+
+// hexColor converte uma cor opaca escrita como inteiro 0xRRGGBB.
+//
+// Os componentes são arredondados como em parseHexComponent, de modo
+// que um nome e o seu código hexadecimal produzem a mesma cor.
+func hexColor(rgb uint32) colorRGB {
+	component := func(shift uint) float64 {
+		return math.Round(float64((rgb>>shift)&0xff)/255*1000) / 1000
+	}
+	return colorRGB{R: component(16), G: component(8), B: component(0), A: 1}
+}
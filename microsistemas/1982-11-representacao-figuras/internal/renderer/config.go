@@ -25,10 +25,23 @@ import (
 //
 // Esta representação é compatível com a biblioteca gráfica gg
 // e permite precisão superior aos 16 ou 256 cores do hardware de 1982.
+// A opacidade permite linhas de construção e faces semitransparentes;
+// cores opacas devem declarar A: 1 explicitamente.
 type colorRGB struct {
 	R float64 // Componente vermelho (0.0 = sem vermelho, 1.0 = vermelho total)
 	G float64 // Componente verde (0.0 = sem verde, 1.0 = verde total)
 	B float64 // Componente azul (0.0 = sem azul, 1.0 = azul total)
+	A float64 // Opacidade (0.0 = transparente, 1.0 = opaca)
+}
+
+// mix retorna a mistura linear das cores c e d (t = 0 → c, t = 1 → d).
+func (c colorRGB) mix(d colorRGB, t float64) colorRGB {
+	return colorRGB{
+		R: c.R + t*(d.R-c.R),
+		G: c.G + t*(d.G-c.G),
+		B: c.B + t*(d.B-c.B),
+		A: c.A + t*(d.A-c.A),
+	}
 }
 
 // RenderConfig encapsula todas as opções visuais aplicadas pelo renderizador.
@@ -37,12 +50,335 @@ type colorRGB struct {
 // indo muito além das capacidades limitadas do HP-85 original que
 // tinha apenas algumas cores básicas e resolução fixa.
 type RenderConfig struct {
-	Background   colorRGB // Cor de fundo da imagem
+	Background   colorRGB // Cor de fundo da imagem (início do gradiente)
 	LineColor    colorRGB // Cor das linhas (arestas) da figura
 	LineWidth    float64  // Espessura das linhas em pixels
+	ArrowSize    float64  // Comprimento das setas das linhas direcionadas em pixels
+	Antialias    int      // Fator de superamostragem (1 = desligada)
+	BitDepth     int      // Bits por canal da imagem salva (8 ou 16)
+	DPI          float64  // Resolução de impressão registrada no PNG (0 = nenhuma)
 	VertexColor  colorRGB // Cor dos vértices (pontos)
+	FaceColor    colorRGB // Cor padrão de preenchimento das faces
 	ShowVertices bool     // Se deve mostrar círculos nos vértices
 	ShowLabels   bool     // Se deve mostrar nomes dos pontos
+
+	// Realce atrás dos nomes dos pontos e dos eixos (ver drawLabel)
+	LabelHalo      LabelHalo // Sem realce, contorno ou fundo
+	LabelHaloColor colorRGB  // Cor do realce (padrão: a do fundo)
+
+	// Fonte dos textos (ver setTextFont)
+	FontFile string  // Arquivo TrueType (vazio = fontes embutidas)
+	FontSize float64 // Altura dos nomes, eixos e carimbo (0 = fonte de bitmap)
+
+	// Estilo do traço das arestas
+	LineStyle LineStyle // Contínuo ou esboço
+	LineCap   LineCap   // Acabamento das pontas das linhas
+	LineJoin  LineJoin  // Acabamento das junções entre trechos de um traço
+	Seed      int64     // Semente do tremido do esboço (reprodutível)
+
+	// Cor das linhas pela distância ao observador (ver depthCueColor)
+	DepthCue    bool     // Se as linhas variam de LineColor a DepthCueFar
+	DepthCueFar colorRGB // Cor das linhas mais distantes
+
+	// Fundo em gradiente, de Background até GradientEnd
+	Gradient    Gradient // Direção do gradiente (GradientNone = fundo liso)
+	GradientEnd colorRGB // Cor final do gradiente
+
+	// Grade de referência sobre o fundo
+	Grid             bool     // Se a grade é desenhada
+	GridSpacing      float64  // Distância entre as linhas em pixels
+	GridColor        colorRGB // Cor das linhas da grade
+	GridSubdivisions int      // Divisões de cada célula pela subgrade (0 ou 1 = sem)
+	GridMinorColor   colorRGB // Cor das linhas da subgrade
+
+	// Carimbo no canto inferior direito (nil = sem carimbo)
+	TitleBlock *TitleBlock
+
+	// Título e legenda escritos sobre a imagem (nil = sem)
+	Title   *Annotation
+	Caption *Annotation
+
+	// Tabela com as coordenadas dos pontos nomeados (nil = sem)
+	PointTable *PointTable
+
+	// Logotipo ou texto semitransparente sobre a imagem (nil = sem)
+	Watermark *Watermark
+
+	// Eixos coordenados do mundo (ver drawAxes)
+	Axes       bool        // Se os eixos são desenhados
+	AxesLength float64     // Comprimento em unidades da figura (0 = automático)
+	AxesColors [3]colorRGB // Cores dos eixos X, Y e Z
+	AxesLabels bool        // Se os nomes dos eixos são escritos nas pontas
+
+	// Caixa envolvente da figura, para depuração (ver drawBoundingBox)
+	BoundingBox      bool     // Se a caixa é desenhada
+	BoundingBoxColor colorRGB // Cor das arestas da caixa
+
+	// Cor das cotas entre pontos nomeados (ver drawDimensions)
+	DimensionColor colorRGB
+
+	// Omite faces voltadas para longe do observador
+	BackfaceCulling bool
+
+	// Algoritmo que decide quais faces (ou partes delas) ficam visíveis
+	HiddenSurface HiddenSurface
+
+	// Arestas das faces traçadas: todas ou só as que desenham o contorno
+	EdgeMode    EdgeMode // Todas ou contorno (silhueta, bordas e vincos)
+	CreaseAngle float64  // Ângulo mínimo, em graus, entre normais de um vinco
+
+	// Arestas escondidas pelas faces: removidas ou tracejadas
+	HiddenEdges     HiddenEdges // Remover ou tracejar
+	HiddenEdgeColor colorRGB    // Cor dos trechos tracejados
+
+	// Sombreamento plano das faces por uma luz direcional
+	Lighting       bool    // Se as faces são sombreadas
+	LightDirection vec3    // Direção unitária que aponta para a luz (mundo)
+	Ambient        float64 // Fração da cor recebida sem luz direta (0 a 1)
+
+	// Tratamento de pontos atrás do observador (projeção cônica)
+	NearPlane    float64      // Profundidade do plano próximo (0 = DefaultNearPlane)
+	BehindPolicy BehindPolicy // Recortar, ajustar ou descartar arestas atrás do plano
+
+	// Tratamento de proporções diferentes entre tela virtual e imagem
+	AspectMode AspectMode // Esticar, usar faixas ou ajustar L2
+	BarColor   colorRGB   // Cor das faixas no modo AspectLetterbox
+
+	// Renderização estereoscópica (anáglifo e pares estéreo)
+	EyeSeparation float64 // Distância entre os olhos (0 = 1/30 da convergência)
+	Convergence   float64 // Distância ao plano de convergência (0 = alvo ou R)
+}
+
+// AspectMode define o que fazer quando a proporção L1/L2 da tela virtual
+// difere da proporção da imagem.
+type AspectMode string
+
+// Modos de preservação de proporção.
+const (
+	// AspectStretch estica a tela virtual até ocupar a imagem inteira,
+	// como na implementação original (círculos podem virar elipses)
+	AspectStretch AspectMode = "esticar"
+
+	// AspectLetterbox preserva a proporção da tela virtual, preenchendo
+	// as sobras com faixas horizontais ou verticais
+	AspectLetterbox AspectMode = "faixas"
+
+	// AspectAdjust preserva a escala horizontal e recalcula L2 para a
+	// proporção da imagem (mostra mais ou menos da cena na vertical)
+	AspectAdjust AspectMode = "ajustar"
+)
+
+// HiddenSurface define o algoritmo de remoção de superfícies ocultas
+// usado no desenho das faces.
+type HiddenSurface string
+
+// Algoritmos de superfícies ocultas.
+const (
+	// HiddenPainter desenha as faces inteiras, da mais distante para a
+	// mais próxima (algoritmo do pintor); rápido e com bordas suavizadas,
+	// mas falha com faces que se interpenetram
+	HiddenPainter HiddenSurface = "pintor"
+
+	// HiddenZBuffer rasteriza as faces guardando a profundidade de cada
+	// pixel, resolvendo interseções pixel a pixel (sem suavização); as
+	// linhas da figura passam pelo mesmo teste, com traço contínuo
+	HiddenZBuffer HiddenSurface = "zbuffer"
+)
+
+// EdgeMode define quais arestas das faces são traçadas.
+type EdgeMode string
+
+// Modos de traçado das arestas das faces.
+const (
+	// EdgesAll contorna cada face inteira, como num aramado
+	EdgesAll EdgeMode = "todas"
+
+	// EdgesOutline traça apenas silhuetas, bordas e vincos (ver
+	// featureEdges), para desenhos limpos a partir de malhas densas
+	EdgesOutline EdgeMode = "contorno"
+)
+
+// edgeModeAliases aceita também os nomes em inglês dos modos.
+var edgeModeAliases = map[string]EdgeMode{
+	"todas":      EdgesAll,
+	"all":        EdgesAll,
+	"contorno":   EdgesOutline,
+	"outline":    EdgesOutline,
+	"silhueta":   EdgesOutline,
+	"silhouette": EdgesOutline,
+}
+
+// LineStyle define o estilo do traço das arestas.
+type LineStyle string
+
+// Estilos de linha.
+const (
+	// LineSolid traça as arestas retas, com o traço uniforme
+	LineSolid LineStyle = "continuo"
+
+	// LineSketch traça as arestas tremidas e prolongadas além dos
+	// vértices, como um rascunho a lápis (ver addLine)
+	LineSketch LineStyle = "esboco"
+)
+
+// lineStyleAliases aceita também variações e os nomes em inglês dos estilos.
+var lineStyleAliases = map[string]LineStyle{
+	"continuo": LineSolid,
+	"contínuo": LineSolid,
+	"solid":    LineSolid,
+	"esboco":   LineSketch,
+	"esboço":   LineSketch,
+	"rascunho": LineSketch,
+	"sketch":   LineSketch,
+}
+
+// LabelHalo define o realce desenhado atrás dos nomes, para que
+// continuem legíveis sobre linhas escuras ou faces coloridas.
+type LabelHalo string
+
+// Realces dos nomes.
+const (
+	// HaloNone escreve os nomes diretamente sobre o desenho
+	HaloNone LabelHalo = ""
+
+	// HaloOutline contorna as letras com a cor do realce
+	HaloOutline LabelHalo = "contorno"
+
+	// HaloBox escreve os nomes sobre um retângulo arredondado na cor do
+	// realce, como uma etiqueta
+	HaloBox LabelHalo = "fundo"
+)
+
+// labelHaloAliases aceita também variações e os nomes em inglês dos realces.
+var labelHaloAliases = map[string]LabelHalo{
+	"nenhum":   HaloNone,
+	"none":     HaloNone,
+	"contorno": HaloOutline,
+	"outline":  HaloOutline,
+	"halo":     HaloOutline,
+	"fundo":    HaloBox,
+	"caixa":    HaloBox,
+	"box":      HaloBox,
+	"pill":     HaloBox,
+}
+
+// LineCap define o acabamento das pontas das linhas (ver gg.LineCap).
+type LineCap string
+
+// Acabamentos das pontas.
+const (
+	// CapRound arredonda as pontas, como o traço de uma caneta; nas
+	// linhas grossas, as arestas que se encontram num vértice se fundem
+	CapRound LineCap = "redonda"
+
+	// CapButt corta o traço exatamente nas pontas (o padrão do desenho
+	// técnico); linhas grossas deixam vãos nos cantos
+	CapButt LineCap = "reta"
+
+	// CapSquare prolonga o traço meia espessura além das pontas
+	CapSquare LineCap = "quadrada"
+)
+
+// lineCapAliases aceita também variações e os nomes em inglês das pontas.
+var lineCapAliases = map[string]LineCap{
+	"redonda":  CapRound,
+	"round":    CapRound,
+	"reta":     CapButt,
+	"butt":     CapButt,
+	"quadrada": CapSquare,
+	"square":   CapSquare,
+}
+
+// LineJoin define o acabamento das junções entre os trechos de um mesmo
+// traço (ver gg.LineJoin), como os cantos dos contornos das faces.
+//
+// A gg não oferece junções em ponta (miter): os cantos vivos podem ser
+// arredondados ou chanfrados.
+type LineJoin string
+
+// Acabamentos das junções.
+const (
+	// JoinRound arredonda os cantos
+	JoinRound LineJoin = "redonda"
+
+	// JoinBevel corta os cantos em chanfro
+	JoinBevel LineJoin = "chanfrada"
+)
+
+// lineJoinAliases aceita também variações e os nomes em inglês das junções.
+var lineJoinAliases = map[string]LineJoin{
+	"redonda":   JoinRound,
+	"round":     JoinRound,
+	"chanfrada": JoinBevel,
+	"chanfro":   JoinBevel,
+	"bevel":     JoinBevel,
+}
+
+// Gradient define a direção do gradiente do fundo.
+type Gradient string
+
+// Direções do gradiente.
+const (
+	// GradientNone pinta o fundo com uma cor lisa
+	GradientNone Gradient = ""
+
+	// GradientVertical vai do topo (cor inicial) à base (cor final)
+	GradientVertical Gradient = "vertical"
+
+	// GradientHorizontal vai da esquerda (cor inicial) à direita (cor final)
+	GradientHorizontal Gradient = "horizontal"
+
+	// GradientRadial vai do centro (cor inicial) aos cantos (cor final)
+	GradientRadial Gradient = "radial"
+)
+
+// HiddenEdges define o que fazer com os trechos de arestas escondidos
+// pelas faces.
+type HiddenEdges string
+
+// Tratamentos das arestas escondidas.
+const (
+	// HiddenEdgesRemove omite os trechos escondidos (as faces os cobrem)
+	HiddenEdgesRemove HiddenEdges = "remover"
+
+	// HiddenEdgesDashed traça os trechos escondidos tracejados, como no
+	// desenho técnico (ver drawHiddenEdges)
+	HiddenEdgesDashed HiddenEdges = "tracejar"
+)
+
+// hiddenEdgesAliases aceita também os nomes em inglês dos tratamentos.
+var hiddenEdgesAliases = map[string]HiddenEdges{
+	"remover":   HiddenEdgesRemove,
+	"remove":    HiddenEdgesRemove,
+	"tracejar":  HiddenEdgesDashed,
+	"tracejado": HiddenEdgesDashed,
+	"dashed":    HiddenEdgesDashed,
+}
+
+// gradientAliases aceita também variações dos nomes das direções.
+var gradientAliases = map[string]Gradient{
+	"vertical":   GradientVertical,
+	"horizontal": GradientHorizontal,
+	"radial":     GradientRadial,
+	"circular":   GradientRadial,
+}
+
+// hiddenSurfaceAliases aceita também os nomes em inglês dos algoritmos.
+var hiddenSurfaceAliases = map[string]HiddenSurface{
+	"pintor":   HiddenPainter,
+	"painter":  HiddenPainter,
+	"zbuffer":  HiddenZBuffer,
+	"z-buffer": HiddenZBuffer,
+}
+
+// aspectModeAliases aceita também os nomes em inglês dos modos.
+var aspectModeAliases = map[string]AspectMode{
+	"esticar":   AspectStretch,
+	"stretch":   AspectStretch,
+	"faixas":    AspectLetterbox,
+	"letterbox": AspectLetterbox,
+	"ajustar":   AspectAdjust,
+	"adjust":    AspectAdjust,
 }
 
 // DefaultRenderConfig retorna a configuração visual padrão.
@@ -57,20 +393,90 @@ type RenderConfig struct {
 func DefaultRenderConfig() RenderConfig {
 	return RenderConfig{
 		// Fundo branco (RGB: 255,255,255) - estética clássica
-		Background: colorRGB{R: 1, G: 1, B: 1},
+		Background: colorRGB{R: 1, G: 1, B: 1, A: 1},
 
 		// Linhas pretas (RGB: 0,0,0) - máximo contraste
-		LineColor: colorRGB{R: 0, G: 0, B: 0},
+		LineColor: colorRGB{R: 0, G: 0, B: 0, A: 1},
 
-		// Linha fina padrão (1 pixel)
+		// Linha fina padrão (1 pixel), apenas com a suavização da gg
 		LineWidth: 1.0,
+		ArrowSize: DefaultArrowSize,
+		Antialias: 1,
+		BitDepth:  8,
+		LineStyle: LineSolid,
+
+		// Pontas e junções arredondadas, o padrão da gg
+		LineCap:  CapRound,
+		LineJoin: JoinRound,
+
+		// Grade desligada; quando ligada, a cada 50 pixels em cinza
+		// claro, como a de AddGrid
+		GridSpacing:    DefaultGridSpacing,
+		GridColor:      colorRGB{R: 0.9, G: 0.9, B: 0.9, A: 1},
+		GridMinorColor: colorRGB{R: 0.9, G: 0.9, B: 0.9, A: 0.5},
+
+		// Eixos desligados; quando ligados, nas cores usuais (X vermelho,
+		// Y verde, Z azul), com os nomes nas pontas
+		AxesColors: [3]colorRGB{
+			{R: 0.8, G: 0.1, B: 0.1, A: 1},
+			{R: 0.1, G: 0.6, B: 0.1, A: 1},
+			{R: 0.1, G: 0.2, B: 0.8, A: 1},
+		},
+		AxesLabels: true,
+
+		// Caixa envolvente desligada; quando ligada, em cinza médio
+		BoundingBox:      false,
+		BoundingBoxColor: colorRGB{R: 0.6, G: 0.6, B: 0.6, A: 1},
+
+		// Cotas na cor das linhas
+		DimensionColor: colorRGB{R: 0, G: 0, B: 0, A: 1},
 
 		// Vértices em vermelho escuro para destaque quando ativados
-		VertexColor: colorRGB{R: 0.8, G: 0, B: 0},
+		VertexColor: colorRGB{R: 0.8, G: 0, B: 0, A: 1},
+
+		// Faces em cinza claro, para que as arestas continuem legíveis
+		FaceColor: colorRGB{R: 0.82, G: 0.82, B: 0.82, A: 1},
 
 		// Por padrão, apenas as linhas são visíveis (como no artigo)
 		ShowVertices: false,
 		ShowLabels:   false,
+
+		// Nomes sem realce; quando realçados, na cor do fundo
+		LabelHalo:      HaloNone,
+		LabelHaloColor: colorRGB{R: 1, G: 1, B: 1, A: 1},
+
+		// Todas as faces são desenhadas, qualquer que seja a orientação,
+		// em ordem de profundidade
+		BackfaceCulling: false,
+		HiddenSurface:   HiddenPainter,
+
+		// Cada face contornada inteira; no modo de contorno, vincos a
+		// partir de 30°
+		EdgeMode:    EdgesAll,
+		CreaseAngle: DefaultCreaseAngle,
+
+		// Arestas escondidas removidas; quando tracejadas, em cinza médio
+		HiddenEdges:     HiddenEdgesRemove,
+		HiddenEdgeColor: colorRGB{R: 0.55, G: 0.55, B: 0.55, A: 1},
+
+		// Faces com cor uniforme; quando a luz é ativada, vem de cima, da
+		// esquerda e da frente, como nas ilustrações técnicas
+		Lighting:       false,
+		LightDirection: toVec3(DefaultLightDirection).normalize(),
+		Ambient:        DefaultAmbient,
+
+		// Arestas recortadas no plano próximo a 0.1 unidade do observador
+		NearPlane:    DefaultNearPlane,
+		BehindPolicy: BehindClip,
+
+		// Tela virtual esticada até a imagem (comportamento original),
+		// com faixas pretas quando o modo de faixas é escolhido
+		AspectMode: AspectStretch,
+		BarColor:   colorRGB{R: 0, G: 0, B: 0, A: 1},
+
+		// Olhos convergindo no alvo, com separação proporcional à distância
+		EyeSeparation: 0,
+		Convergence:   0,
 	}
 }
 
@@ -114,6 +520,36 @@ func ConfigFromFigure(fig *types.Figure) (RenderConfig, error) {
 		cfg.Background = col
 	}
 
+	// Gradiente do fundo (a cor inicial substitui a cor de fundo)
+	if g := settings.Gradient; g != nil {
+		if g.Start != "" {
+			col, err := parseColor(g.Start)
+			if err != nil {
+				return cfg, fmt.Errorf("cor inicial do gradiente inválida: %w", err)
+			}
+			cfg.Background = col
+		}
+
+		if g.End == "" {
+			return cfg, fmt.Errorf("gradiente sem cor final (fim)")
+		}
+		col, err := parseColor(g.End)
+		if err != nil {
+			return cfg, fmt.Errorf("cor final do gradiente inválida: %w", err)
+		}
+		cfg.GradientEnd = col
+
+		cfg.Gradient = GradientVertical
+		if g.Direction != "" {
+			kind, ok := gradientAliases[strings.ToLower(strings.TrimSpace(g.Direction))]
+			if !ok {
+				return cfg, fmt.Errorf("direção do gradiente desconhecida: %q (use %s, %s ou %s)",
+					g.Direction, GradientVertical, GradientHorizontal, GradientRadial)
+			}
+			cfg.Gradient = kind
+		}
+	}
+
 	// Cor das linhas
 	if settings.LineColor != "" {
 		col, err := parseColor(settings.LineColor)
@@ -123,6 +559,16 @@ func ConfigFromFigure(fig *types.Figure) (RenderConfig, error) {
 		cfg.LineColor = col
 	}
 
+	// Cor das linhas mais distantes (liga a variação com a profundidade)
+	if settings.DepthCueColor != "" {
+		col, err := parseColor(settings.DepthCueColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor das linhas distantes inválida: %w", err)
+		}
+		cfg.DepthCue = true
+		cfg.DepthCueFar = col
+	}
+
 	// Cor dos vértices
 	if settings.VertexColor != "" {
 		col, err := parseColor(settings.VertexColor)
@@ -132,6 +578,15 @@ func ConfigFromFigure(fig *types.Figure) (RenderConfig, error) {
 		cfg.VertexColor = col
 	}
 
+	// Cor padrão das faces
+	if settings.FaceColor != "" {
+		col, err := parseColor(settings.FaceColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor das faces inválida: %w", err)
+		}
+		cfg.FaceColor = col
+	}
+
 	// === CONFIGURAÇÕES NUMÉRICAS ===
 
 	// Espessura das linhas (deve ser positiva)
@@ -139,6 +594,70 @@ func ConfigFromFigure(fig *types.Figure) (RenderConfig, error) {
 		cfg.LineWidth = settings.LineWidth
 	}
 
+	// Tamanho das setas das linhas direcionadas (zero = padrão)
+	if settings.ArrowSize < 0 {
+		return cfg, fmt.Errorf("tamanho de seta inválido: %g (deve ser positivo)", settings.ArrowSize)
+	}
+	if settings.ArrowSize > 0 {
+		cfg.ArrowSize = settings.ArrowSize
+	}
+
+	// Superamostragem ("2x" ou apenas "2")
+	if settings.Antialias != "" {
+		factor, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(settings.Antialias)), "x"))
+		if err != nil || factor < 1 || factor > MaxSupersampling {
+			return cfg, fmt.Errorf("suavização inválida: %q (use de 1x a %dx)", settings.Antialias, MaxSupersampling)
+		}
+		cfg.Antialias = factor
+	}
+
+	// Profundidade de cor da imagem salva
+	if settings.BitDepth != 0 {
+		if settings.BitDepth != 8 && settings.BitDepth != 16 {
+			return cfg, fmt.Errorf("bits por canal inválidos: %d (use 8 ou 16)", settings.BitDepth)
+		}
+		cfg.BitDepth = settings.BitDepth
+	}
+
+	// Tamanho físico: valida as medidas e guarda a resolução de impressão
+	if _, _, err := CanvasSize(settings); err != nil {
+		return cfg, err
+	}
+	if settings.DPI > 0 {
+		cfg.DPI = settings.DPI
+	} else if settings.PrintWidth != "" || settings.PrintHeight != "" {
+		cfg.DPI = DefaultDPI
+	}
+
+	// Estilo das linhas e semente do esboço
+	if settings.LineStyle != "" {
+		style, ok := lineStyleAliases[strings.ToLower(strings.TrimSpace(settings.LineStyle))]
+		if !ok {
+			return cfg, fmt.Errorf("estilo de linha desconhecido: %q (use %s ou %s)",
+				settings.LineStyle, LineSolid, LineSketch)
+		}
+		cfg.LineStyle = style
+	}
+	cfg.Seed = settings.Seed
+
+	// Acabamento das pontas e junções das linhas
+	if settings.LineCap != "" {
+		lineCap, ok := lineCapAliases[strings.ToLower(strings.TrimSpace(settings.LineCap))]
+		if !ok {
+			return cfg, fmt.Errorf("ponta de linha desconhecida: %q (use %s, %s ou %s)",
+				settings.LineCap, CapRound, CapButt, CapSquare)
+		}
+		cfg.LineCap = lineCap
+	}
+	if settings.LineJoin != "" {
+		join, ok := lineJoinAliases[strings.ToLower(strings.TrimSpace(settings.LineJoin))]
+		if !ok {
+			return cfg, fmt.Errorf("junção de linha desconhecida: %q (use %s ou %s)",
+				settings.LineJoin, JoinRound, JoinBevel)
+		}
+		cfg.LineJoin = join
+	}
+
 	// === CONFIGURAÇÕES BOOLEANAS ===
 	// Usa ponteiros para distinguir entre "não especificado" e "false"
 
@@ -150,26 +669,312 @@ func ConfigFromFigure(fig *types.Figure) (RenderConfig, error) {
 		cfg.ShowLabels = *settings.ShowLabels
 	}
 
-	return cfg, nil
-}
+	if settings.BackfaceCulling != nil {
+		cfg.BackfaceCulling = *settings.BackfaceCulling
+	}
 
-// namedColors contém cores pré-definidas por nome para conveniência.
-//
-// Permite uso de nomes intuitivos em vez de códigos hexadecimais,
-// tornando os arquivos YAML mais legíveis. Inclui variações de
-// grafia (gray/grey) para flexibilidade.
-var namedColors = map[string]colorRGB{
-	// Cores básicas
-	"white": {R: 1, G: 1, B: 1},       // Branco puro
-	"black": {R: 0, G: 0, B: 0},       // Preto puro
-
-	// Tons de cinza (ambas grafias aceitas)
-	"gray":      {R: 0.5, G: 0.5, B: 0.5},     // Cinza médio
-	"grey":      {R: 0.5, G: 0.5, B: 0.5},     // Cinza médio (grafia britânica)
-	"lightgray": {R: 0.82, G: 0.82, B: 0.82},  // Cinza claro
-	"lightgrey": {R: 0.82, G: 0.82, B: 0.82},  // Cinza claro (grafia britânica)
-	"darkgray":  {R: 0.25, G: 0.25, B: 0.25},  // Cinza escuro
-	"darkgrey":  {R: 0.25, G: 0.25, B: 0.25},  // Cinza escuro (grafia britânica)
+	// === REALCE DOS NOMES ===
+
+	if settings.LabelHalo != "" {
+		halo, ok := labelHaloAliases[strings.ToLower(strings.TrimSpace(settings.LabelHalo))]
+		if !ok {
+			return cfg, fmt.Errorf("realce dos nomes desconhecido: %q (use %s ou %s)",
+				settings.LabelHalo, HaloOutline, HaloBox)
+		}
+		cfg.LabelHalo = halo
+	}
+
+	// Sem cor própria, o realce acompanha a cor de fundo
+	cfg.LabelHaloColor = cfg.Background
+	if settings.LabelHaloColor != "" {
+		col, err := parseColor(settings.LabelHaloColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor do realce dos nomes inválida: %w", err)
+		}
+		cfg.LabelHaloColor = col
+	}
+
+	// === SUPERFÍCIES OCULTAS ===
+
+	if settings.HiddenSurface != "" {
+		algorithm, ok := hiddenSurfaceAliases[strings.ToLower(strings.TrimSpace(settings.HiddenSurface))]
+		if !ok {
+			return cfg, fmt.Errorf("algoritmo de superfícies ocultas desconhecido: %q (use %s ou %s)",
+				settings.HiddenSurface, HiddenPainter, HiddenZBuffer)
+		}
+		cfg.HiddenSurface = algorithm
+	}
+
+	// === ARESTAS DAS FACES ===
+
+	if settings.Edges != "" {
+		mode, ok := edgeModeAliases[strings.ToLower(strings.TrimSpace(settings.Edges))]
+		if !ok {
+			return cfg, fmt.Errorf("modo de arestas desconhecido: %q (use %s ou %s)",
+				settings.Edges, EdgesAll, EdgesOutline)
+		}
+		cfg.EdgeMode = mode
+	}
+
+	// Ângulo de vinco (entre 0 e 180 graus; zero = padrão)
+	if settings.CreaseAngle < 0 || settings.CreaseAngle > 180 {
+		return cfg, fmt.Errorf("ângulo de vinco inválido: %g (deve estar entre 0 e 180)", settings.CreaseAngle)
+	}
+	if settings.CreaseAngle > 0 {
+		cfg.CreaseAngle = settings.CreaseAngle
+	}
+
+	// === ARESTAS ESCONDIDAS ===
+
+	if settings.HiddenEdges != "" {
+		style, ok := hiddenEdgesAliases[strings.ToLower(strings.TrimSpace(settings.HiddenEdges))]
+		if !ok {
+			return cfg, fmt.Errorf("tratamento de arestas ocultas desconhecido: %q (use %s ou %s)",
+				settings.HiddenEdges, HiddenEdgesRemove, HiddenEdgesDashed)
+		}
+		cfg.HiddenEdges = style
+	}
+
+	if settings.HiddenEdgeColor != "" {
+		col, err := parseColor(settings.HiddenEdgeColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor das arestas ocultas inválida: %w", err)
+		}
+		cfg.HiddenEdgeColor = col
+	}
+
+	// === GRADE E CARIMBO ===
+
+	if grid := settings.Grid; grid != nil {
+		cfg.Grid = true
+		if grid.Spacing < 0 {
+			return cfg, fmt.Errorf("espaçamento da grade inválido: %g (deve ser positivo)", grid.Spacing)
+		}
+		if grid.Spacing > 0 {
+			cfg.GridSpacing = grid.Spacing
+		}
+		if grid.Color != "" {
+			col, err := parseColor(grid.Color)
+			if err != nil {
+				return cfg, fmt.Errorf("cor da grade inválida: %w", err)
+			}
+			cfg.GridColor = col
+		}
+
+		// Subgrade: por padrão, a cor da grade com metade da opacidade
+		if grid.Subdivisions < 0 {
+			return cfg, fmt.Errorf("subdivisões da grade inválidas: %d (deve ser positivo)", grid.Subdivisions)
+		}
+		cfg.GridSubdivisions = grid.Subdivisions
+		cfg.GridMinorColor = cfg.GridColor
+		cfg.GridMinorColor.A /= 2
+		if grid.MinorColor != "" {
+			col, err := parseColor(grid.MinorColor)
+			if err != nil {
+				return cfg, fmt.Errorf("cor da subgrade inválida: %w", err)
+			}
+			cfg.GridMinorColor = col
+		}
+	}
+	if settings.ShowGrid != nil {
+		cfg.Grid = *settings.ShowGrid
+	}
+
+	if block := settings.TitleBlock; block != nil {
+		cfg.TitleBlock = &TitleBlock{Title: block.Title, Author: block.Author, Date: block.Date}
+	}
+
+	// === FONTE ===
+
+	if settings.FontSize < 0 {
+		return cfg, fmt.Errorf("tamanho da fonte inválido: %g (deve ser positivo)", settings.FontSize)
+	}
+	cfg.FontSize = settings.FontSize
+	if settings.Font != "" {
+		// Carregada já aqui para que um arquivo inválido seja apontado
+		// antes de qualquer desenho
+		if _, err := loadFontFace(settings.Font, DefaultFontSize); err != nil {
+			return cfg, err
+		}
+		cfg.FontFile = settings.Font
+	}
+
+	// === TÍTULO E LEGENDA ===
+
+	if settings.Title != nil {
+		title, err := parseAnnotation(*settings.Title, PositionTop, DefaultTitleSize, cfg.LineColor)
+		if err != nil {
+			return cfg, fmt.Errorf("título inválido: %w", err)
+		}
+		cfg.Title = title
+	}
+	if settings.Caption != nil {
+		caption, err := parseAnnotation(*settings.Caption, PositionBottom, DefaultCaptionSize, cfg.LineColor)
+		if err != nil {
+			return cfg, fmt.Errorf("legenda inválida: %w", err)
+		}
+		cfg.Caption = caption
+	}
+
+	// === MARCA D'ÁGUA ===
+
+	if settings.Watermark != nil {
+		watermark, err := parseWatermark(*settings.Watermark, cfg.LineColor)
+		if err != nil {
+			return cfg, fmt.Errorf("marca d'água inválida: %w", err)
+		}
+		cfg.Watermark = watermark
+	}
+
+	// === TABELA DE PONTOS ===
+
+	if table := settings.PointTable; table != nil {
+		cfg.PointTable = &PointTable{Position: PositionTopLeft, Size: DefaultPointTableSize}
+		if table.Position != "" {
+			position, err := parseAnnotationPosition(table.Position)
+			if err != nil {
+				return cfg, fmt.Errorf("posição da tabela de pontos inválida: %w", err)
+			}
+			cfg.PointTable.Position = position
+		}
+		if table.Size < 0 {
+			return cfg, fmt.Errorf("tamanho da tabela de pontos inválido: %g (deve ser positivo)", table.Size)
+		}
+		if table.Size > 0 {
+			cfg.PointTable.Size = table.Size
+		}
+	}
+	if show := settings.ShowTable; show != nil {
+		switch {
+		case !*show:
+			cfg.PointTable = nil
+		case cfg.PointTable == nil:
+			cfg.PointTable = &PointTable{Position: PositionTopLeft, Size: DefaultPointTableSize}
+		}
+	}
+
+	// === EIXOS ===
+
+	if axes := settings.Axes; axes != nil {
+		cfg.Axes = true
+		if axes.Length < 0 {
+			return cfg, fmt.Errorf("comprimento dos eixos inválido: %g (deve ser positivo)", axes.Length)
+		}
+		cfg.AxesLength = axes.Length
+		for i, value := range []string{axes.ColorX, axes.ColorY, axes.ColorZ} {
+			if value == "" {
+				continue
+			}
+			col, err := parseColor(value)
+			if err != nil {
+				return cfg, fmt.Errorf("cor do eixo %s inválida: %w", axisNames[i], err)
+			}
+			cfg.AxesColors[i] = col
+		}
+		if axes.Labels != nil {
+			cfg.AxesLabels = *axes.Labels
+		}
+	}
+	if settings.ShowAxes != nil {
+		cfg.Axes = *settings.ShowAxes
+	}
+
+	// === CAIXA ENVOLVENTE ===
+
+	if settings.ShowBounds != nil {
+		cfg.BoundingBox = *settings.ShowBounds
+	}
+	if settings.BoundsColor != "" {
+		col, err := parseColor(settings.BoundsColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor da caixa envolvente inválida: %w", err)
+		}
+		cfg.BoundingBoxColor = col
+	}
+
+	// === COTAS ===
+
+	// Sem cor própria, as cotas acompanham a cor das linhas
+	cfg.DimensionColor = cfg.LineColor
+	if settings.DimensionColor != "" {
+		col, err := parseColor(settings.DimensionColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor das medidas inválida: %w", err)
+		}
+		cfg.DimensionColor = col
+	}
+
+	// === ILUMINAÇÃO ===
+
+	if settings.Light != nil {
+		cfg.Lighting = true
+
+		direction := toVec3(settings.Light.Direction)
+		if direction.length() > 0 {
+			cfg.LightDirection = direction.normalize()
+		}
+
+		if ambient := settings.Light.Ambient; ambient != nil {
+			if *ambient < 0 || *ambient > 1 {
+				return cfg, fmt.Errorf("luz ambiente inválida: %g (deve estar entre 0 e 1)", *ambient)
+			}
+			cfg.Ambient = *ambient
+		}
+	}
+
+	// === PONTOS ATRÁS DO OBSERVADOR ===
+
+	// Plano próximo (deve ser positivo; zero = padrão)
+	if settings.NearPlane < 0 {
+		return cfg, fmt.Errorf("plano próximo inválido: %g (deve ser positivo)", settings.NearPlane)
+	}
+	if settings.NearPlane > 0 {
+		cfg.NearPlane = settings.NearPlane
+	}
+
+	// Política para arestas atrás do plano próximo
+	if settings.BehindPolicy != "" {
+		policy, err := parseBehindPolicy(settings.BehindPolicy)
+		if err != nil {
+			return cfg, fmt.Errorf("política para pontos atrás da câmera inválida: %w", err)
+		}
+		cfg.BehindPolicy = policy
+	}
+
+	// === PROPORÇÃO DA TELA VIRTUAL ===
+
+	if settings.AspectMode != "" {
+		mode, ok := aspectModeAliases[strings.ToLower(strings.TrimSpace(settings.AspectMode))]
+		if !ok {
+			return cfg, fmt.Errorf("modo de proporção desconhecido: %q (use %s, %s ou %s)",
+				settings.AspectMode, AspectStretch, AspectLetterbox, AspectAdjust)
+		}
+		cfg.AspectMode = mode
+	}
+
+	if settings.BarColor != "" {
+		col, err := parseColor(settings.BarColor)
+		if err != nil {
+			return cfg, fmt.Errorf("cor das faixas inválida: %w", err)
+		}
+		cfg.BarColor = col
+	}
+
+	// === ESTEREOSCOPIA ===
+
+	// Separação entre os olhos (deve ser positiva; zero = automática)
+	if settings.EyeSeparation < 0 {
+		return cfg, fmt.Errorf("separação dos olhos inválida: %g (deve ser positiva)", settings.EyeSeparation)
+	}
+	cfg.EyeSeparation = settings.EyeSeparation
+
+	// Distância de convergência (deve ser positiva; zero = alvo da câmera)
+	if settings.Convergence < 0 {
+		return cfg, fmt.Errorf("distância de convergência inválida: %g (deve ser positiva)", settings.Convergence)
+	}
+	cfg.Convergence = settings.Convergence
+
+	return cfg, nil
 }
 
 // parseColor converte uma string de cor para colorRGB.
@@ -178,8 +983,12 @@ var namedColors = map[string]colorRGB{
 // 1. Nomes de cores ("white", "black", "red", etc.)
 // 2. Códigos hexadecimais completos ("#ff0000", "ff0000")
 // 3. Códigos hexadecimais curtos ("#f00" → "#ff0000")
+// 4. Hexadecimais com opacidade ("#ff000080", "#f008")
+// 5. Notação funcional ("rgb(255, 0, 0)", "rgba(255, 0, 0, 0.5)",
+//    "hsl(120, 50%, 50%)", "hsla(120, 50%, 50%, 0.5)")
 //
-// Todos os formatos são case-insensitive para conveniência.
+// Todos os formatos são case-insensitive para conveniência. Cores sem
+// opacidade explícita são opacas.
 //
 // Parâmetros:
 //   value: string representando uma cor
@@ -200,15 +1009,20 @@ func parseColor(value string) (colorRGB, error) {
 		return col, nil
 	}
 
-	// === TENTATIVA 2: CÓDIGO HEXADECIMAL ===
+	// === TENTATIVA 2: NOTAÇÃO FUNCIONAL ===
+	if strings.HasPrefix(v, "rgb") || strings.HasPrefix(v, "hsl") {
+		return parseFunctionalColor(v)
+	}
+
+	// === TENTATIVA 3: CÓDIGO HEXADECIMAL ===
 
 	// Remove prefixo '#' se presente
 	if strings.HasPrefix(v, "#") {
 		v = v[1:]
 	}
 
-	// Expande formato curto (#rgb → #rrggbb)
-	if len(v) == 3 {
+	// Expande formatos curtos (#rgb → #rrggbb, #rgba → #rrggbbaa)
+	if len(v) == 3 || len(v) == 4 {
 		// Cada caractere é duplicado: "f0a" → "ff00aa"
 		var sb strings.Builder
 		for _, ch := range v {
@@ -219,7 +1033,7 @@ func parseColor(value string) (colorRGB, error) {
 	}
 
 	// Valida comprimento final
-	if len(v) != 6 {
+	if len(v) != 6 && len(v) != 8 {
 		return colorRGB{}, fmt.Errorf("formato de cor inválido: %s", value)
 	}
 
@@ -241,7 +1055,109 @@ func parseColor(value string) (colorRGB, error) {
 		return colorRGB{}, err
 	}
 
-	return colorRGB{R: r, G: g, B: b}, nil
+	// Opacidade opcional (último par)
+	a := 1.0
+	if len(v) == 8 {
+		if a, err = parseHexComponent(v[6:8]); err != nil {
+			return colorRGB{}, err
+		}
+	}
+
+	return colorRGB{R: r, G: g, B: b, A: a}, nil
+}
+
+// parseFunctionalColor converte as notações rgb(r, g, b), rgba(r, g, b, a),
+// hsl(h, s%, l%) e hsla(h, s%, l%, a), como no CSS.
+//
+// Em rgb(), os componentes de cor vão de 0 a 255. Em hsl(), o matiz é
+// um ângulo em graus (qualquer valor, com ou sem o sufixo "deg") e a
+// saturação e a luminosidade são porcentagens de 0% a 100%. A
+// opacidade, quando presente, vai de 0 a 1.
+//
+// Parâmetros:
+//   value: string normalizada (minúsculas, sem espaços nas pontas)
+//
+// Retorna:
+//   colorRGB: cor convertida para formato interno
+//   error: erro se a notação ou algum componente for inválido
+func parseFunctionalColor(value string) (colorRGB, error) {
+	name, args, ok := strings.Cut(value, "(")
+	if !ok || !strings.HasSuffix(args, ")") {
+		return colorRGB{}, fmt.Errorf("formato de cor inválido: %s", value)
+	}
+	name = strings.TrimSpace(name)
+
+	parts := strings.Split(strings.TrimSuffix(args, ")"), ",")
+	want := map[string]int{"rgb": 3, "rgba": 4, "hsl": 3, "hsla": 4}[name]
+	if want == 0 || len(parts) != want {
+		return colorRGB{}, fmt.Errorf("formato de cor inválido: %s (use rgb(r, g, b), rgba(r, g, b, a), hsl(h, s%%, l%%) ou hsla(h, s%%, l%%, a))", value)
+	}
+	hsl := strings.HasPrefix(name, "hsl")
+
+	components := make([]float64, len(parts))
+	for i, part := range parts {
+		text := strings.TrimSpace(part)
+
+		// Sufixos da notação hsl: graus no matiz, porcentagem em s e l
+		limit := 255.0
+		switch {
+		case i == 3:
+			limit = 1
+		case hsl && i == 0:
+			text = strings.TrimSuffix(text, "deg")
+		case hsl:
+			var percent bool
+			if text, percent = strings.CutSuffix(text, "%"); !percent {
+				return colorRGB{}, fmt.Errorf("componente de cor inválido: %q (saturação e luminosidade são porcentagens)", strings.TrimSpace(part))
+			}
+			limit = 100
+		}
+
+		c, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return colorRGB{}, fmt.Errorf("componente de cor inválido: %q", strings.TrimSpace(part))
+		}
+
+		// O matiz é um ângulo: dá a volta no círculo de cores
+		if hsl && i == 0 {
+			components[i] = math.Mod(math.Mod(c, 360)+360, 360)
+			continue
+		}
+		if c < 0 || c > limit {
+			return colorRGB{}, fmt.Errorf("componente de cor fora do intervalo 0-%g: %g", limit, c)
+		}
+		components[i] = c / limit
+	}
+
+	col := colorRGB{R: components[0], G: components[1], B: components[2], A: 1}
+	if hsl {
+		col = hslToRGB(components[0], components[1], components[2])
+	}
+	if len(components) == 4 {
+		col.A = components[3]
+	}
+	return col, nil
+}
+
+// hslToRGB converte uma cor do modelo matiz/saturação/luminosidade.
+//
+// É a conversão da especificação do CSS: cada componente é lido numa
+// "rampa" de luminosidade deslocada conforme o matiz (vermelho em 0°,
+// verde em 120°, azul em 240°).
+//
+// Parâmetros:
+//   h: matiz em graus (0 a 360)
+//   s, l: saturação e luminosidade (0 a 1)
+//
+// Retorna:
+//   colorRGB: cor opaca equivalente
+func hslToRGB(h, s, l float64) colorRGB {
+	a := s * math.Min(l, 1-l)
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		return l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+	}
+	return colorRGB{R: f(0), G: f(8), B: f(4), A: 1}
 }
 
 // parseHexComponent converte um componente hexadecimal (00-FF) para float64 (0.0-1.0).
@@ -31,6 +31,11 @@ func TestDefaultRenderConfig(t *testing.T) {
 	if config.ShowLabels {
 		t.Error("Expected ShowLabels=false by default")
 	}
+
+	if config.NearPlane != DefaultNearPlane || config.BehindPolicy != BehindClip {
+		t.Errorf("Expected near plane %g with clip policy, got %g with %q",
+			DefaultNearPlane, config.NearPlane, config.BehindPolicy)
+	}
 }
 
 func TestConfigFromFigure_Nil(t *testing.T) {
@@ -109,6 +114,73 @@ func TestConfigFromFigure_WithSettings(t *testing.T) {
 	}
 }
 
+func TestConfigFromFigure_BehindPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   types.RenderSettings
+		wantNear   float64
+		wantPolicy BehindPolicy
+		wantErr    bool
+	}{
+		{"defaults", types.RenderSettings{}, DefaultNearPlane, BehindClip, false},
+		{"portuguese", types.RenderSettings{NearPlane: 0.001, BehindPolicy: "descartar"}, 0.001, BehindDrop, false},
+		{"english", types.RenderSettings{BehindPolicy: "Clamp"}, DefaultNearPlane, BehindClamp, false},
+		{"negative near plane", types.RenderSettings{NearPlane: -1}, 0, "", true},
+		{"unknown policy", types.RenderSettings{BehindPolicy: "ignorar"}, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			config, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if config.NearPlane != tt.wantNear || config.BehindPolicy != tt.wantPolicy {
+				t.Errorf("Expected near %g with %q, got %g with %q",
+					tt.wantNear, tt.wantPolicy, config.NearPlane, config.BehindPolicy)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_AspectMode(t *testing.T) {
+	config, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		AspectMode: "Letterbox",
+		BarColor:   "#808080",
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if config.AspectMode != AspectLetterbox {
+		t.Errorf("Expected letterbox mode, got %q", config.AspectMode)
+	}
+	if abs(config.BarColor.R-0.502) > 0.01 {
+		t.Errorf("Expected gray bars, got %+v", config.BarColor)
+	}
+
+	if DefaultRenderConfig().AspectMode != AspectStretch {
+		t.Error("Expected stretch mode by default")
+	}
+
+	invalid := []types.RenderSettings{
+		{AspectMode: "cortar"},
+		{BarColor: "not-a-color"},
+	}
+	for _, settings := range invalid {
+		settings := settings
+		if _, err := ConfigFromFigure(&types.Figure{Render: &settings}); err == nil {
+			t.Errorf("Expected error for %+v, got nil", settings)
+		}
+	}
+}
+
 func TestParseColor(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -233,4 +305,791 @@ func abs(x float64) float64 {
 		return -x
 	}
 	return x
-}
\ No newline at end of file
+}
+
+func TestConfigFromFigure_EyeSeparation(t *testing.T) {
+	figure := &types.Figure{Render: &types.RenderSettings{EyeSeparation: 0.5}}
+	cfg, err := ConfigFromFigure(figure)
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.EyeSeparation != 0.5 {
+		t.Errorf("Expected eye separation 0.5, got %g", cfg.EyeSeparation)
+	}
+
+	figure.Render.EyeSeparation = -1
+	if _, err := ConfigFromFigure(figure); err == nil {
+		t.Error("Expected error for negative eye separation, got nil")
+	}
+}
+
+func TestConfigFromFigure_Convergence(t *testing.T) {
+	figure := &types.Figure{Render: &types.RenderSettings{Convergence: 12}}
+	cfg, err := ConfigFromFigure(figure)
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.Convergence != 12 {
+		t.Errorf("Expected convergence 12, got %g", cfg.Convergence)
+	}
+
+	figure.Render.Convergence = -1
+	if _, err := ConfigFromFigure(figure); err == nil {
+		t.Error("Expected error for negative convergence, got nil")
+	}
+}
+
+func TestConfigFromFigure_BackfaceCulling(t *testing.T) {
+	enabled := true
+	figure := &types.Figure{Render: &types.RenderSettings{BackfaceCulling: &enabled}}
+	cfg, err := ConfigFromFigure(figure)
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.BackfaceCulling {
+		t.Error("Expected backface culling to be enabled")
+	}
+	if DefaultRenderConfig().BackfaceCulling {
+		t.Error("Expected backface culling to be disabled by default")
+	}
+}
+
+func TestConfigFromFigure_HiddenSurface(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    HiddenSurface
+		wantErr bool
+	}{
+		{"default", "", HiddenPainter, false},
+		{"portuguese", "pintor", HiddenPainter, false},
+		{"english", "Painter", HiddenPainter, false},
+		{"zbuffer", "zbuffer", HiddenZBuffer, false},
+		{"hyphenated", "z-buffer", HiddenZBuffer, false},
+		{"unknown", "raytracing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			figure := &types.Figure{Render: &types.RenderSettings{HiddenSurface: tt.value}}
+			cfg, err := ConfigFromFigure(figure)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.HiddenSurface != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, cfg.HiddenSurface)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_Light(t *testing.T) {
+	if DefaultRenderConfig().Lighting {
+		t.Error("Expected lighting to be disabled by default")
+	}
+
+	// Luz sem direção usa a direção padrão
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Light: &types.Light{}}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Lighting || cfg.LightDirection != toVec3(DefaultLightDirection).normalize() || cfg.Ambient != DefaultAmbient {
+		t.Errorf("Expected default light, got %+v ambient %g", cfg.LightDirection, cfg.Ambient)
+	}
+
+	// Direção informada é normalizada
+	ambient := 0.5
+	light := &types.Light{Direction: types.Point3D{Z: 4}, Ambient: &ambient}
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Light: light}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.LightDirection != (vec3{Z: 1}) || cfg.Ambient != 0.5 {
+		t.Errorf("Expected unit light along +Z with ambient 0.5, got %+v ambient %g", cfg.LightDirection, cfg.Ambient)
+	}
+
+	// Termo ambiente fora de [0, 1]
+	ambient = 1.5
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Light: light}}); err == nil {
+		t.Error("Expected error for ambient outside [0, 1], got nil")
+	}
+}
+
+func TestConfigFromFigure_GridAndTitleBlock(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	if cfg.Grid || cfg.TitleBlock != nil {
+		t.Error("Expected no grid and no title block by default")
+	}
+
+	settings := &types.RenderSettings{
+		Grid:       &types.Grid{Spacing: 25, Color: "blue"},
+		TitleBlock: &types.TitleBlock{Title: "Planta", Author: "eu"},
+	}
+	cfg, err := ConfigFromFigure(&types.Figure{Render: settings})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Grid || cfg.GridSpacing != 25 || cfg.GridColor != (colorRGB{B: 1, A: 1}) {
+		t.Errorf("Expected blue grid every 25 pixels, got %v %g %+v", cfg.Grid, cfg.GridSpacing, cfg.GridColor)
+	}
+	if cfg.TitleBlock == nil || *cfg.TitleBlock != (TitleBlock{Title: "Planta", Author: "eu"}) {
+		t.Errorf("Expected title block from settings, got %+v", cfg.TitleBlock)
+	}
+
+	// Grade sem detalhes usa os padrões
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Grid: &types.Grid{}}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Grid || cfg.GridSpacing != DefaultGridSpacing {
+		t.Errorf("Expected default grid, got %v %g", cfg.Grid, cfg.GridSpacing)
+	}
+
+	// Subgrade: por padrão, a cor da grade com metade da opacidade
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		Grid: &types.Grid{Color: "blue", Subdivisions: 5},
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.GridSubdivisions != 5 || cfg.GridMinorColor != (colorRGB{B: 1, A: 0.5}) {
+		t.Errorf("Expected 5 subdivisions in translucent blue, got %d %+v", cfg.GridSubdivisions, cfg.GridMinorColor)
+	}
+
+	// mostrar_grade liga a grade sem a seção e desliga a da seção
+	show, hide := true, false
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ShowGrid: &show}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Grid || cfg.GridSpacing != DefaultGridSpacing {
+		t.Errorf("Expected default grid from mostrar_grade, got %v %g", cfg.Grid, cfg.GridSpacing)
+	}
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Grid: &types.Grid{}, ShowGrid: &hide}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.Grid {
+		t.Error("Expected mostrar_grade: false to hide the grid")
+	}
+
+	for _, grid := range []*types.Grid{{Spacing: -5}, {Color: "nocolor"}, {Subdivisions: -1}, {MinorColor: "nocolor"}} {
+		if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Grid: grid}}); err == nil {
+			t.Errorf("Expected error for grid %+v, got nil", grid)
+		}
+	}
+}
+
+func TestConfigFromFigure_Axes(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	if cfg.Axes || !cfg.AxesLabels {
+		t.Error("Expected no axes by default, labelled when enabled")
+	}
+
+	hide := false
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		Axes: &types.Axes{Length: 3, ColorY: "black", Labels: &hide},
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Axes || cfg.AxesLength != 3 || cfg.AxesLabels {
+		t.Errorf("Expected unlabelled axes of length 3, got %v %g %v", cfg.Axes, cfg.AxesLength, cfg.AxesLabels)
+	}
+	if cfg.AxesColors[1] != (colorRGB{A: 1}) || cfg.AxesColors[0] != DefaultRenderConfig().AxesColors[0] {
+		t.Errorf("Expected black Y axis and default X axis, got %+v", cfg.AxesColors)
+	}
+
+	// mostrar_eixos liga os eixos sem a seção
+	show := true
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ShowAxes: &show}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.Axes || cfg.AxesLength != 0 {
+		t.Errorf("Expected automatic axes from mostrar_eixos, got %v %g", cfg.Axes, cfg.AxesLength)
+	}
+
+	for _, axes := range []*types.Axes{{Length: -1}, {ColorZ: "nocolor"}} {
+		if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Axes: axes}}); err == nil {
+			t.Errorf("Expected error for axes %+v, got nil", axes)
+		}
+	}
+}
+
+func TestConfigFromFigure_BoundingBox(t *testing.T) {
+	show := true
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ShowBounds: &show, BoundsColor: "#00f"}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.BoundingBox || cfg.BoundingBoxColor != (colorRGB{B: 1, A: 1}) {
+		t.Errorf("Expected blue bounding box, got %v %+v", cfg.BoundingBox, cfg.BoundingBoxColor)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{BoundsColor: "nocolor"}}); err == nil {
+		t.Error("Expected error for invalid bounding box color, got nil")
+	}
+}
+
+func TestConfigFromFigure_Annotations(t *testing.T) {
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		LineColor: "blue",
+		Title:     &types.Annotation{Text: "Cubo"},
+		Caption:   &types.Annotation{Text: "Figura 1", Position: "topo", Size: 10},
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	blue := colorRGB{B: 1, A: 1}
+	if cfg.Title == nil || *cfg.Title != (Annotation{Text: "Cubo", Position: PositionTop, Size: DefaultTitleSize, Color: blue}) {
+		t.Errorf("Expected default title in the line color, got %+v", cfg.Title)
+	}
+	if cfg.Caption == nil || *cfg.Caption != (Annotation{Text: "Figura 1", Position: PositionTop, Size: 10, Color: blue}) {
+		t.Errorf("Expected caption at the top, got %+v", cfg.Caption)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		Caption: &types.Annotation{Position: "meio"},
+	}}); err == nil {
+		t.Error("Expected error for unknown caption position, got nil")
+	}
+}
+
+func TestConfigFromFigure_PointTable(t *testing.T) {
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		PointTable: &types.PointTable{Position: "top_right", Size: 10},
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.PointTable == nil || *cfg.PointTable != (PointTable{Position: PositionTopRight, Size: 10}) {
+		t.Errorf("Expected table at the top right, got %+v", cfg.PointTable)
+	}
+
+	// mostrar_tabela liga a tabela com os padrões, ou desliga a da seção
+	show, hide := true, false
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ShowTable: &show}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.PointTable == nil || *cfg.PointTable != (PointTable{Position: PositionTopLeft, Size: DefaultPointTableSize}) {
+		t.Errorf("Expected default table, got %+v", cfg.PointTable)
+	}
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{PointTable: &types.PointTable{}, ShowTable: &hide}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.PointTable != nil {
+		t.Errorf("Expected mostrar_tabela: false to hide the table, got %+v", cfg.PointTable)
+	}
+
+	for _, table := range []*types.PointTable{{Position: "meio"}, {Size: -2}} {
+		if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{PointTable: table}}); err == nil {
+			t.Errorf("Expected error for table %+v, got nil", table)
+		}
+	}
+}
+
+func TestConfigFromFigure_Font(t *testing.T) {
+	filename := writeTestFont(t)
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Font: filename, FontSize: 20}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.FontFile != filename || cfg.FontSize != 20 {
+		t.Errorf("Expected font %s at 20 pixels, got %s at %g", filename, cfg.FontFile, cfg.FontSize)
+	}
+
+	for _, settings := range []*types.RenderSettings{{Font: filename + ".falta"}, {FontSize: -1}} {
+		if _, err := ConfigFromFigure(&types.Figure{Render: settings}); err == nil {
+			t.Errorf("Expected error for %+v, got nil", settings)
+		}
+	}
+}
+
+func TestConfigFromFigure_LineStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings types.RenderSettings
+		want     LineStyle
+		wantErr  bool
+	}{
+		{"default", types.RenderSettings{}, LineSolid, false},
+		{"portuguese", types.RenderSettings{LineStyle: "esboço", Seed: 42}, LineSketch, false},
+		{"english", types.RenderSettings{LineStyle: "Sketch"}, LineSketch, false},
+		{"unknown style", types.RenderSettings{LineStyle: "pontilhado"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			cfg, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.LineStyle != tt.want || cfg.Seed != settings.Seed {
+				t.Errorf("Expected style %q with seed %d, got %q with seed %d", tt.want, settings.Seed, cfg.LineStyle, cfg.Seed)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_DepthCue(t *testing.T) {
+	if DefaultRenderConfig().DepthCue {
+		t.Error("Expected depth cueing to be disabled by default")
+	}
+
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{DepthCueColor: "#cccccc"}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if !cfg.DepthCue || cfg.DepthCueFar != (colorRGB{R: 0.8, G: 0.8, B: 0.8, A: 1}) {
+		t.Errorf("Expected depth cueing towards #cccccc, got %v %+v", cfg.DepthCue, cfg.DepthCueFar)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{DepthCueColor: "nocolor"}}); err == nil {
+		t.Error("Expected error for invalid far color, got nil")
+	}
+}
+
+func TestConfigFromFigure_Gradient(t *testing.T) {
+	if DefaultRenderConfig().Gradient != GradientNone {
+		t.Error("Expected plain background by default")
+	}
+
+	tests := []struct {
+		name      string
+		settings  types.RenderSettings
+		wantKind  Gradient
+		wantStart colorRGB
+		wantEnd   colorRGB
+		wantErr   bool
+	}{
+		{"start from background", types.RenderSettings{Background: "black", Gradient: &types.Gradient{End: "#0000ff"}},
+			GradientVertical, colorRGB{A: 1}, colorRGB{B: 1, A: 1}, false},
+		{"explicit start", types.RenderSettings{Background: "black", Gradient: &types.Gradient{Start: "red", End: "white", Direction: "Radial"}},
+			GradientRadial, colorRGB{R: 1, A: 1}, colorRGB{R: 1, G: 1, B: 1, A: 1}, false},
+		{"horizontal", types.RenderSettings{Gradient: &types.Gradient{End: "black", Direction: "horizontal"}},
+			GradientHorizontal, colorRGB{R: 1, G: 1, B: 1, A: 1}, colorRGB{A: 1}, false},
+		{"missing end", types.RenderSettings{Gradient: &types.Gradient{Start: "red"}}, "", colorRGB{}, colorRGB{}, true},
+		{"invalid start", types.RenderSettings{Gradient: &types.Gradient{Start: "nocolor", End: "red"}}, "", colorRGB{}, colorRGB{}, true},
+		{"unknown direction", types.RenderSettings{Gradient: &types.Gradient{End: "red", Direction: "diagonal"}}, "", colorRGB{}, colorRGB{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			cfg, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.Gradient != tt.wantKind || cfg.Background != tt.wantStart || cfg.GradientEnd != tt.wantEnd {
+				t.Errorf("Expected %q gradient %+v → %+v, got %q %+v → %+v",
+					tt.wantKind, tt.wantStart, tt.wantEnd, cfg.Gradient, cfg.Background, cfg.GradientEnd)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_Edges(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   types.RenderSettings
+		wantMode   EdgeMode
+		wantCrease float64
+		wantErr    bool
+	}{
+		{"default", types.RenderSettings{}, EdgesAll, DefaultCreaseAngle, false},
+		{"portuguese", types.RenderSettings{Edges: "contorno", CreaseAngle: 45}, EdgesOutline, 45, false},
+		{"english", types.RenderSettings{Edges: "Silhouette"}, EdgesOutline, DefaultCreaseAngle, false},
+		{"unknown mode", types.RenderSettings{Edges: "algumas"}, "", 0, true},
+		{"crease too large", types.RenderSettings{CreaseAngle: 200}, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			cfg, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.EdgeMode != tt.wantMode || cfg.CreaseAngle != tt.wantCrease {
+				t.Errorf("Expected %q/%g, got %q/%g", tt.wantMode, tt.wantCrease, cfg.EdgeMode, cfg.CreaseAngle)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_HiddenEdges(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings types.RenderSettings
+		want     HiddenEdges
+		wantErr  bool
+	}{
+		{"default", types.RenderSettings{}, HiddenEdgesRemove, false},
+		{"portuguese", types.RenderSettings{HiddenEdges: "tracejar", HiddenEdgeColor: "gray"}, HiddenEdgesDashed, false},
+		{"english", types.RenderSettings{HiddenEdges: "Dashed"}, HiddenEdgesDashed, false},
+		{"unknown style", types.RenderSettings{HiddenEdges: "pontilhar"}, "", true},
+		{"invalid color", types.RenderSettings{HiddenEdgeColor: "cor-que-nao-existe"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			cfg, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.HiddenEdges != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, cfg.HiddenEdges)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_LabelHalo(t *testing.T) {
+	// Padrão: sem realce
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.LabelHalo != HaloNone {
+		t.Errorf("Expected no halo by default, got %q", cfg.LabelHalo)
+	}
+
+	// Sem cor própria, o realce segue a cor de fundo
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		LabelHalo:  "Outline",
+		Background: "black",
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.LabelHalo != HaloOutline {
+		t.Errorf("Expected %q, got %q", HaloOutline, cfg.LabelHalo)
+	}
+	if cfg.LabelHaloColor != cfg.Background {
+		t.Errorf("Expected halo color to follow the background, got %+v", cfg.LabelHaloColor)
+	}
+
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{
+		LabelHalo:      "fundo",
+		LabelHaloColor: "yellow",
+	}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.LabelHalo != HaloBox || cfg.LabelHaloColor != (colorRGB{R: 1, G: 1, B: 0, A: 1}) {
+		t.Errorf("Expected yellow box halo, got %q %+v", cfg.LabelHalo, cfg.LabelHaloColor)
+	}
+
+	for _, settings := range []types.RenderSettings{
+		{LabelHalo: "sombra"},
+		{LabelHaloColor: "cor-que-nao-existe"},
+	} {
+		settings := settings
+		if _, err := ConfigFromFigure(&types.Figure{Render: &settings}); err == nil {
+			t.Errorf("Expected error for %+v", settings)
+		}
+	}
+}
+
+func TestConfigFromFigure_DimensionColor(t *testing.T) {
+	// Sem cor própria, as cotas seguem a cor das linhas
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{LineColor: "navy"}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.DimensionColor != cfg.LineColor {
+		t.Errorf("Expected dimension color to follow the lines, got %+v", cfg.DimensionColor)
+	}
+
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{DimensionColor: "red"}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.DimensionColor != (colorRGB{R: 1, A: 1}) {
+		t.Errorf("Expected red dimensions, got %+v", cfg.DimensionColor)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{DimensionColor: "cor-que-nao-existe"}}); err == nil {
+		t.Error("Expected error for invalid dimension color")
+	}
+}
+
+func TestConfigFromFigure_ArrowSize(t *testing.T) {
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.ArrowSize != DefaultArrowSize {
+		t.Errorf("Expected default arrow size %d, got %g", DefaultArrowSize, cfg.ArrowSize)
+	}
+
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ArrowSize: 16}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.ArrowSize != 16 {
+		t.Errorf("Expected arrow size 16, got %g", cfg.ArrowSize)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{ArrowSize: -1}}); err == nil {
+		t.Error("Expected error for negative arrow size")
+	}
+}
+
+func TestConfigFromFigure_LineEnds(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings types.RenderSettings
+		wantCap  LineCap
+		wantJoin LineJoin
+		wantErr  bool
+	}{
+		{"default", types.RenderSettings{}, CapRound, JoinRound, false},
+		{"portuguese", types.RenderSettings{LineCap: "reta", LineJoin: "chanfrada"}, CapButt, JoinBevel, false},
+		{"english", types.RenderSettings{LineCap: "Square", LineJoin: "bevel"}, CapSquare, JoinBevel, false},
+		{"unknown cap", types.RenderSettings{LineCap: "pontuda"}, "", "", true},
+		{"miter join", types.RenderSettings{LineJoin: "miter"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			cfg, err := ConfigFromFigure(&types.Figure{Render: &settings})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromFigure failed: %v", err)
+			}
+			if cfg.LineCap != tt.wantCap || cfg.LineJoin != tt.wantJoin {
+				t.Errorf("Expected %q/%q, got %q/%q", tt.wantCap, tt.wantJoin, cfg.LineCap, cfg.LineJoin)
+			}
+		})
+	}
+}
+
+func TestConfigFromFigure_Antialias(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"", 1, false},
+		{"2x", 2, false},
+		{"4X", 4, false},
+		{"3", 3, false},
+		{"8x", 0, true},
+		{"0x", 0, true},
+		{"muito", 0, true},
+	}
+
+	for _, tt := range tests {
+		cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{Antialias: tt.value}})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got nil", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: ConfigFromFigure failed: %v", tt.value, err)
+		}
+		if cfg.Antialias != tt.want {
+			t.Errorf("%q: expected factor %d, got %d", tt.value, tt.want, cfg.Antialias)
+		}
+	}
+}
+
+func TestParseColor_Alpha(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    colorRGB
+		wantErr bool
+	}{
+		{"#ff000080", colorRGB{R: 1, A: 0.502}, false},
+		{"#f008", colorRGB{R: 1, A: 0.533}, false},
+		{"#00ff00", colorRGB{G: 1, A: 1}, false},
+		{"rgb(255, 0, 255)", colorRGB{R: 1, B: 1, A: 1}, false},
+		{"RGBA(0, 0, 255, 0.25)", colorRGB{B: 1, A: 0.25}, false},
+		{"transparent", colorRGB{}, false},
+		{"rgba(0, 0, 255)", colorRGB{}, true},
+		{"rgb(300, 0, 0)", colorRGB{}, true},
+		{"rgba(0, 0, 0, 2)", colorRGB{}, true},
+		{"rgb(a, b, c)", colorRGB{}, true},
+		{"#1234567", colorRGB{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseColor(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %+v", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if abs(got.R-tt.want.R) > 0.001 || abs(got.G-tt.want.G) > 0.001 ||
+			abs(got.B-tt.want.B) > 0.001 || abs(got.A-tt.want.A) > 0.001 {
+			t.Errorf("%q: expected %+v, got %+v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestParseColor_CSSNames(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+	}{
+		{"red", "#ff0000"},
+		{"rebeccapurple", "#663399"},
+		{"cornflowerblue", "#6495ed"},
+		{"DarkSlateGrey", "#2f4f4f"},
+		{"lightgray", "#d3d3d3"},
+		{"darkgray", "#a9a9a9"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseColor(tt.name)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.name, err)
+			continue
+		}
+		want, _ := parseColor(tt.hex)
+		if got != want {
+			t.Errorf("%q: expected %+v (%s), got %+v", tt.name, want, tt.hex, got)
+		}
+	}
+
+	if len(namedColors) < 148 {
+		t.Errorf("expected the full CSS color set, got %d names", len(namedColors))
+	}
+
+	// O cinza escuro da tabela antiga continua disponível
+	if got, _ := parseColor("cinzaescuro"); got != (colorRGB{R: 0.25, G: 0.25, B: 0.25, A: 1}) {
+		t.Errorf("expected the old 25%% dark gray for cinzaescuro, got %+v", got)
+	}
+}
+
+func TestParseColor_HSL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    colorRGB
+		wantErr bool
+	}{
+		{"hsl(0, 100%, 50%)", colorRGB{R: 1, A: 1}, false},
+		{"hsl(120, 100%, 50%)", colorRGB{G: 1, A: 1}, false},
+		{"hsl(240deg, 100%, 50%)", colorRGB{B: 1, A: 1}, false},
+		{"hsl(120, 50%, 50%)", colorRGB{R: 0.25, G: 0.75, B: 0.25, A: 1}, false},
+		{"hsl(-120, 100%, 50%)", colorRGB{B: 1, A: 1}, false},
+		{"hsl(480, 100%, 50%)", colorRGB{G: 1, A: 1}, false},
+		{"hsl(0, 0%, 50%)", colorRGB{R: 0.5, G: 0.5, B: 0.5, A: 1}, false},
+		{"HSLA(60, 100%, 50%, 0.5)", colorRGB{R: 1, G: 1, A: 0.5}, false},
+		{"hsl(120, 50, 50%)", colorRGB{}, true},
+		{"hsl(120, 150%, 50%)", colorRGB{}, true},
+		{"hsla(120, 50%, 50%)", colorRGB{}, true},
+		{"hsl(x, 50%, 50%)", colorRGB{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseColor(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %+v", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if abs(got.R-tt.want.R) > 0.001 || abs(got.G-tt.want.G) > 0.001 ||
+			abs(got.B-tt.want.B) > 0.001 || abs(got.A-tt.want.A) > 0.001 {
+			t.Errorf("%q: expected %+v, got %+v", tt.input, tt.want, got)
+		}
+	}
+}
+
+func TestConfigFromFigure_BitDepth(t *testing.T) {
+	if cfg := DefaultRenderConfig(); cfg.BitDepth != 8 {
+		t.Errorf("Expected 8 bits by default, got %d", cfg.BitDepth)
+	}
+
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{BitDepth: 16}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.BitDepth != 16 {
+		t.Errorf("Expected 16 bits, got %d", cfg.BitDepth)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{BitDepth: 24}}); err == nil {
+		t.Error("Expected error for 24 bits per channel")
+	}
+}
+
+func TestConfigFromFigure_DPI(t *testing.T) {
+	if cfg := DefaultRenderConfig(); cfg.DPI != 0 {
+		t.Errorf("Expected no DPI by default, got %g", cfg.DPI)
+	}
+
+	cfg, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{PrintWidth: "120mm"}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.DPI != DefaultDPI {
+		t.Errorf("Expected %d DPI for a printed size, got %g", DefaultDPI, cfg.DPI)
+	}
+
+	cfg, err = ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{DPI: 150}})
+	if err != nil {
+		t.Fatalf("ConfigFromFigure failed: %v", err)
+	}
+	if cfg.DPI != 150 {
+		t.Errorf("Expected 150 DPI, got %g", cfg.DPI)
+	}
+
+	if _, err := ConfigFromFigure(&types.Figure{Render: &types.RenderSettings{PrintWidth: "120px"}}); err == nil {
+		t.Error("Expected error for unknown unit")
+	}
+}
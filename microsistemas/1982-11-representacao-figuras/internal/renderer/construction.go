@@ -0,0 +1,30 @@
+package renderer
+
+import "representacao-figuras/pkg/types"
+
+// Tempos da animação da construção, em milissegundos (ver SaveGIF).
+const (
+	DefaultConstructionDelay = 300  // Intervalo padrão entre as linhas
+	ConstructionHold         = 2000 // Pausa mínima no último quadro
+)
+
+// ConstructionStage retorna a figura como estava num passo do programa
+// BASIC do artigo, que traçava as linhas uma a uma, na ordem em que
+// foram definidas: apenas as primeiras linhas, sem faces nem cotas.
+//
+// Os pontos são todos mantidos, para que o enquadramento automático, os
+// vértices e os nomes sejam os mesmos em todos os passos.
+//
+// Parâmetros:
+//   figure: figura completa
+//   lines: número de linhas já traçadas (de 0 a len(figure.Linhas))
+//
+// Retorna:
+//   *types.Figure: cópia rasa da figura com as linhas do passo
+func ConstructionStage(figure *types.Figure, lines int) *types.Figure {
+	stage := *figure
+	stage.Linhas = figure.Linhas[:max(0, min(lines, len(figure.Linhas)))]
+	stage.Faces = nil
+	stage.Medidas = nil
+	return &stage
+}
@@ -0,0 +1,31 @@
+package renderer
+
+import (
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestConstructionStage(t *testing.T) {
+	figure := &types.Figure{
+		Pontos:  []types.Point3D{{X: 0}, {X: 1}, {Y: 1}},
+		Linhas:  []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 0}},
+		Faces:   []types.Face{{Pontos: []int{0, 1, 2}}},
+		Medidas: []types.Dimension{{De: "A", Ate: "B"}},
+	}
+
+	for lines, want := range map[int]int{-1: 0, 0: 0, 2: 2, 3: 3, 10: 3} {
+		stage := ConstructionStage(figure, lines)
+		if len(stage.Linhas) != want {
+			t.Errorf("ConstructionStage(%d): expected %d lines, got %d", lines, want, len(stage.Linhas))
+		}
+		if len(stage.Pontos) != 3 || stage.Faces != nil || stage.Medidas != nil {
+			t.Errorf("ConstructionStage(%d): expected all points and no faces or dimensions, got %+v", lines, stage)
+		}
+	}
+
+	// A figura original não é alterada
+	if len(figure.Linhas) != 3 || len(figure.Faces) != 1 || len(figure.Medidas) != 1 {
+		t.Errorf("Expected original figure untouched, got %+v", figure)
+	}
+}
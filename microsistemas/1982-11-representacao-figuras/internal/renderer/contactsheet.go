@@ -0,0 +1,95 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Ângulos da folha de contatos (ver RenderContactSheet).
+const (
+	DefaultContactAngles = 8  // Número padrão de ângulos
+	MaxContactAngles     = 36 // Maior número de ângulos aceito
+	contactElevation     = 25 // Elevação comum a todos os ângulos, em graus
+	contactFirstAzimuth  = 45 // Azimute do primeiro ângulo, em graus
+)
+
+// contactViews monta as câmeras da folha de contatos: count órbitas em
+// volta da figura, espaçadas igualmente no azimute, todas com a mesma
+// elevação e com enquadramento automático.
+//
+// A projeção, a distância R e o campo de visão vêm da câmera da figura;
+// o primeiro ângulo é uma vista de três quartos (azimute de 45°).
+func contactViews(figure *types.Figure, count int) []sheetView {
+	views := make([]sheetView, count)
+	for i := range views {
+		azimuth := math.Mod(contactFirstAzimuth+float64(i)*360/float64(count), 360)
+
+		cam := figure.Camera
+		cam.View = ""
+		cam.Target = nil
+		cam.Orbit = &types.Orbit{Azimuth: azimuth, Elevation: contactElevation, Radius: 1}
+		cam.Auto = true
+
+		views[i] = sheetView{label: fmt.Sprintf("AZIMUTE %g", math.Round(azimuth*10)/10), camera: cam}
+	}
+	return views
+}
+
+// RenderContactSheet renderiza a figura vista de vários ângulos, lado a
+// lado numa grade, para avaliar rapidamente um modelo novo.
+//
+// Como na folha de desenho técnico (ver RenderSheet), cada célula tem o
+// seu viewport e o seu rótulo, e carimbo, título, legenda, tabela de
+// pontos e marca d'água aparecem uma só vez, sobre a folha inteira.
+// Células que sobram na última linha ficam apenas com o fundo.
+//
+// Parâmetros:
+//   figure: figura 3D a ser renderizada
+//   cfg: configurações visuais aplicadas a todas as células
+//   count: número de ângulos (de 1 a MaxContactAngles)
+//
+// Retorna:
+//   error: erro se o número de ângulos for inválido ou a figura também
+func (r *Renderer3D) RenderContactSheet(figure *types.Figure, cfg RenderConfig, count int) error {
+	if count < 1 || count > MaxContactAngles {
+		return fmt.Errorf("número de ângulos inválido: %d (use de 1 a %d)", count, MaxContactAngles)
+	}
+
+	// Preserva câmera e viewport do chamador
+	savedCamera := r.camera
+	defer func() {
+		r.SetViewport(r.fullViewport())
+		r.SetCamera(savedCamera)
+	}()
+
+	sheetCfg := cfg
+	cfg = withoutSheetDecorations(cfg)
+
+	cols, rows := SheetGrid(count)
+	views := contactViews(figure, count)
+
+	for i := 0; i < cols*rows; i++ {
+		cell := r.sheetCell(i, cols, rows)
+		r.SetViewport(cell)
+		if i >= count {
+			r.fillBackground(cfg)
+			continue
+		}
+
+		r.SetCamera(views[i].camera)
+		if err := r.RenderFigureWithConfig(figure, cfg); err != nil {
+			return fmt.Errorf("ângulo %s: %w", views[i].label, err)
+		}
+
+		// Rótulo da célula no canto superior esquerdo
+		r.setColor(cfg.LineColor)
+		r.context.DrawString(views[i].label, cell.X+8, cell.Y+16)
+	}
+
+	r.drawSheetDividers(cols, rows, cfg.LineColor)
+
+	r.drawSheetDecorations(figure, sheetCfg)
+	return nil
+}
@@ -0,0 +1,65 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestContactViews(t *testing.T) {
+	figure := &types.Figure{Camera: types.DefaultCamera()}
+	figure.Camera.View = "iso"
+
+	views := contactViews(figure, 4)
+	for i, want := range []float64{45, 135, 225, 315} {
+		cam := views[i].camera
+		if cam.Orbit == nil || cam.Orbit.Azimuth != want || cam.Orbit.Elevation != contactElevation {
+			t.Errorf("View %d: expected orbit at azimuth %g, got %+v", i, want, cam.Orbit)
+		}
+		if !cam.Auto || cam.View != "" {
+			t.Errorf("View %d: expected auto-fit without preset view, got %+v", i, cam)
+		}
+	}
+	if views[0].label != "AZIMUTE 45" {
+		t.Errorf("Expected label %q, got %q", "AZIMUTE 45", views[0].label)
+	}
+}
+
+func TestRenderContactSheet(t *testing.T) {
+	renderer := New(300, 200)
+
+	figure := &types.Figure{
+		Nome: "contatos",
+		Pontos: []types.Point3D{
+			{X: -1, Y: -1, Z: -1},
+			{X: 1, Y: 1, Z: 1},
+			{X: 1, Y: -1, Z: 0},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}},
+		Camera: types.DefaultCamera(),
+	}
+	renderer.SetCamera(figure.Camera)
+
+	for _, count := range []int{0, MaxContactAngles + 1} {
+		if err := renderer.RenderContactSheet(figure, DefaultRenderConfig(), count); err == nil {
+			t.Errorf("Expected error for %d angles", count)
+		}
+	}
+
+	// Cinco ângulos numa grade 3×2: a última célula fica vazia
+	if err := renderer.RenderContactSheet(figure, DefaultRenderConfig(), 5); err != nil {
+		t.Fatalf("RenderContactSheet failed: %v", err)
+	}
+	if renderer.viewport != renderer.fullViewport() {
+		t.Errorf("Expected full viewport after sheet, got %+v", renderer.viewport)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	for i := 0; i < 6; i++ {
+		cell := image.Rect(i%3*100, 20+i/3*100, i%3*100+100, i/3*100+100).Inset(3)
+		if got := hasDarkPixel(img, cell); got != (i < 5) {
+			t.Errorf("Cell %d: expected drawing %v, got %v", i, i < 5, got)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// Phosphor é a cor do fósforo do monitor simulado pelo efeito CRT.
+type Phosphor string
+
+const (
+	PhosphorGreen Phosphor = "verde"  // Fósforo P1, dos monitores monocromáticos
+	PhosphorAmber Phosphor = "ambar"  // Fósforo P3, âmbar
+	PhosphorWhite Phosphor = "branco" // Fósforo P4, branco azulado, como o do HP-85
+)
+
+// phosphorAliases aceita também os nomes em inglês e a grafia com acento.
+var phosphorAliases = map[string]Phosphor{
+	"verde":  PhosphorGreen,
+	"green":  PhosphorGreen,
+	"ambar":  PhosphorAmber,
+	"âmbar":  PhosphorAmber,
+	"amber":  PhosphorAmber,
+	"branco": PhosphorWhite,
+	"white":  PhosphorWhite,
+}
+
+// phosphorColors são as cores de cada fósforo aceso por inteiro.
+var phosphorColors = map[Phosphor]colorRGB{
+	PhosphorGreen: {R: 0.2, G: 1, B: 0.4, A: 1},
+	PhosphorAmber: {R: 1, G: 0.69, B: 0, A: 1},
+	PhosphorWhite: {R: 0.92, G: 0.95, B: 1, A: 1},
+}
+
+// ParsePhosphor converte o nome de um fósforo (português ou inglês).
+//
+// Parâmetros:
+//   value: nome do fósforo, sem distinção de maiúsculas
+//
+// Retorna:
+//   Phosphor: fósforo correspondente
+//   error: erro se o nome for desconhecido
+func ParsePhosphor(value string) (Phosphor, error) {
+	p, ok := phosphorAliases[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("fósforo desconhecido: %q (use %s, %s ou %s)", value, PhosphorGreen, PhosphorAmber, PhosphorWhite)
+	}
+	return p, nil
+}
+
+// CRTEffect descreve a simulação de um monitor de tubo aplicada à
+// imagem pronta (ver ApplyCRT). Intensidades zeradas desligam a etapa.
+type CRTEffect struct {
+	Phosphor  Phosphor // Cor do fósforo
+	Scanlines float64  // Escurecimento das linhas ímpares, de 0 a 1
+	Bloom     float64  // Brilho espalhado em volta do traço, de 0 a 1
+	Curvature float64  // Distorção de barril da tela, de 0 a 1
+}
+
+// DefaultCRTEffect retorna o efeito CRT padrão com o fósforo indicado:
+// linhas de varredura visíveis, um halo discreto e tela levemente
+// abaulada.
+func DefaultCRTEffect(phosphor Phosphor) CRTEffect {
+	return CRTEffect{Phosphor: phosphor, Scanlines: 0.35, Bloom: 0.5, Curvature: 0.08}
+}
+
+// ApplyCRT faz a imagem renderizada parecer a foto da tela de um
+// monitor de tubo da época.
+//
+// A imagem vira uma intensidade de 0 a 1 por pixel (a luminância;
+// invertida quando o fundo é claro, pois num monitor o traço é que
+// acende sobre o fundo escuro). Sobre ela são aplicados, em ordem: o
+// halo do fósforo (a intensidade desfocada somada à original), a cor
+// do fósforo, as linhas de varredura (linhas ímpares mais escuras) e a
+// distorção de barril (cada pixel busca a origem mais afastada do
+// centro; o que cai fora da tela fica preto).
+//
+// Deve ser chamado depois da renderização (e de Upscale ou AutoCrop,
+// se usados) e antes de SaveImage. A imagem resultante tem 8 bits por
+// canal.
+//
+// Parâmetros:
+//   effect: fósforo e intensidade de cada etapa
+//
+// Retorna:
+//   error: erro se o fósforo for desconhecido ou alguma intensidade
+//          estiver fora de 0 a 1
+func (r *Renderer3D) ApplyCRT(effect CRTEffect) error {
+	tint, ok := phosphorColors[effect.Phosphor]
+	if !ok {
+		return fmt.Errorf("fósforo desconhecido: %q", effect.Phosphor)
+	}
+	for _, v := range []float64{effect.Scanlines, effect.Bloom, effect.Curvature} {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("intensidade do efeito CRT inválida: %g (deve estar entre 0 e 1)", v)
+		}
+	}
+
+	src := r.image()
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+
+	// Intensidade do fósforo em cada pixel
+	glow := make([]float64, w*h)
+	mean := 0.0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := src.RGBAAt(x, y)
+			l := (0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)) / 255
+			glow[y*w+x] = l
+			mean += l
+		}
+	}
+	if mean/float64(w*h) > 0.5 {
+		for i := range glow {
+			glow[i] = 1 - glow[i]
+		}
+	}
+
+	// Halo: a intensidade desfocada, somada à original
+	if effect.Bloom > 0 {
+		radius := max(1, min(w, h)/150)
+		blurred := boxBlur(boxBlur(glow, w, h, radius), w, h, radius)
+		for i := range glow {
+			glow[i] = math.Min(1, glow[i]+effect.Bloom*blurred[i])
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	cx, cy := float64(w)/2, float64(h)/2
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			// Distorção de barril: coordenadas de -1 a 1 a partir do centro
+			sx, sy := x, y
+			if effect.Curvature > 0 {
+				u := (float64(x) + 0.5 - cx) / cx
+				v := (float64(y) + 0.5 - cy) / cy
+				k := 1 + effect.Curvature*(u*u+v*v)
+				fx, fy := cx+u*k*cx, cy+v*k*cy
+				if fx < 0 || fy < 0 || fx >= float64(w) || fy >= float64(h) {
+					dst.SetRGBA(x, y, toRGBA(colorRGB{A: 1}))
+					continue
+				}
+				sx, sy = int(fx), int(fy)
+			}
+
+			i := glow[sy*w+sx]
+			if sy%2 == 1 {
+				i *= 1 - effect.Scanlines
+			}
+			dst.SetRGBA(x, y, toRGBA(colorRGB{R: tint.R * i, G: tint.G * i, B: tint.B * i, A: 1}))
+		}
+	}
+
+	r.setFinalImage(dst)
+	return nil
+}
+
+// boxBlur desfoca uma imagem de intensidades pela média de uma janela
+// de (2·radius+1) pixels, na horizontal e depois na vertical. Aplicado
+// duas vezes, aproxima um desfoque gaussiano.
+//
+// Parâmetros:
+//   src: intensidades, linha a linha
+//   w, h: dimensões da imagem
+//   radius: raio da janela em pixels
+//
+// Retorna:
+//   []float64: intensidades desfocadas
+func boxBlur(src []float64, w, h, radius int) []float64 {
+	tmp := make([]float64, len(src))
+	out := make([]float64, len(src))
+	size := float64(2*radius + 1)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sum := 0.0
+			for d := -radius; d <= radius; d++ {
+				sum += src[y*w+min(w-1, max(0, x+d))]
+			}
+			tmp[y*w+x] = sum / size
+		}
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sum := 0.0
+			for d := -radius; d <= radius; d++ {
+				sum += tmp[min(h-1, max(0, y+d))*w+x]
+			}
+			out[y*w+x] = sum / size
+		}
+	}
+	return out
+}
+
+// setFinalImage substitui a tela interna por uma imagem pronta, na
+// resolução final (sem superamostragem nem tela de 16 bits), para os
+// efeitos aplicados depois da renderização.
+func (r *Renderer3D) setFinalImage(img *image.RGBA) {
+	r.context = gg.NewContextForRGBA(img)
+	r.scale = 1
+	r.deep = nil
+	r.width, r.height = img.Rect.Dx(), img.Rect.Dy()
+	r.SetViewport(r.fullViewport())
+}
@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+)
+
+func TestParsePhosphor(t *testing.T) {
+	cases := map[string]Phosphor{
+		"verde":   PhosphorGreen,
+		"Green":   PhosphorGreen,
+		"âmbar":   PhosphorAmber,
+		"amber":   PhosphorAmber,
+		" branco": PhosphorWhite,
+	}
+	for name, want := range cases {
+		if got, err := ParsePhosphor(name); err != nil || got != want {
+			t.Errorf("ParsePhosphor(%q): expected %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := ParsePhosphor("azul"); err == nil {
+		t.Error("Expected error for unknown phosphor")
+	}
+}
+
+func TestApplyCRT(t *testing.T) {
+	for _, effect := range []CRTEffect{
+		{Phosphor: "azul"},
+		{Phosphor: PhosphorGreen, Scanlines: 1.5},
+		{Phosphor: PhosphorGreen, Curvature: -0.1},
+	} {
+		if err := New(20, 20).ApplyCRT(effect); err == nil {
+			t.Errorf("Expected error for %+v", effect)
+		}
+	}
+
+	// Fundo branco com um traço preto: o traço acende em verde e o
+	// fundo fica apagado
+	r := New(40, 40)
+	r.setColor(colorRGB{R: 1, G: 1, B: 1, A: 1})
+	r.context.Clear()
+	r.setColor(colorRGB{A: 1})
+	r.context.DrawRectangle(0, 20, 40, 2)
+	r.context.Fill()
+
+	if err := r.ApplyCRT(CRTEffect{Phosphor: PhosphorGreen, Scanlines: 0.5}); err != nil {
+		t.Fatalf("ApplyCRT failed: %v", err)
+	}
+	img := r.image()
+	if img.Rect != image.Rect(0, 0, 40, 40) {
+		t.Fatalf("Expected 40x40 image, got %v", img.Rect)
+	}
+	if c := img.RGBAAt(10, 5); c.R != 0 || c.G != 0 || c.B != 0 {
+		t.Errorf("Expected dark background, got %v", c)
+	}
+	even, odd := img.RGBAAt(10, 20), img.RGBAAt(10, 21)
+	if even.G < 250 || even.G <= even.R {
+		t.Errorf("Expected bright green stroke, got %v", even)
+	}
+	if odd.G >= even.G {
+		t.Errorf("Expected darker scanline on odd row, got %v vs %v", odd, even)
+	}
+}
+
+func TestApplyCRT_Curvature(t *testing.T) {
+	r := New(40, 40)
+	r.setColor(colorRGB{A: 1})
+	r.context.Clear()
+	r.setColor(colorRGB{R: 1, G: 1, B: 1, A: 1})
+	r.context.DrawRectangle(0, 0, 40, 4)
+	r.context.DrawRectangle(16, 16, 8, 8)
+	r.context.Fill()
+
+	// A faixa na borda de cima sai da tela abaulada; o centro continua
+	if err := r.ApplyCRT(CRTEffect{Phosphor: PhosphorAmber, Curvature: 0.3}); err != nil {
+		t.Fatalf("ApplyCRT failed: %v", err)
+	}
+	img := r.image()
+	if c := img.RGBAAt(20, 1); c.R != 0 {
+		t.Errorf("Expected top edge pushed off screen, got %v", c)
+	}
+	if c := img.RGBAAt(20, 20); c.R < 250 || c.B != 0 {
+		t.Errorf("Expected lit amber center, got %v", c)
+	}
+}
@@ -0,0 +1,89 @@
+package renderer
+
+import "representacao-figuras/pkg/types"
+
+// drawCurve desenha uma linha curva (com pontos de controle) da figura.
+//
+// A curva é dividida em trechos retos no espaço (ver curveLines), que
+// são traçados como uma única poligonal; onde o plano próximo a
+// interrompe, a poligonal recomeça no trecho seguinte. No estilo de
+// esboço, as curvas são traçadas sem tremido: prolongar cada trecho
+// além das suas pontas riscaria a curva inteira.
+//
+// Com cfg.DepthCue, a curva recebe uma única cor, pela profundidade das
+// suas pontas; sendo direcionada, a seta segue a direção do último
+// trecho.
+//
+// Parâmetros:
+//   figure: figura a que a linha pertence
+//   linha: linha com pontos de controle
+//   cfg: configurações visuais
+//   near: profundidade do plano próximo
+//   nearest, farthest: faixa de profundidades da figura (ver depthRange)
+//
+// Retorna:
+//   [][2]types.Point2D: trechos traçados, em pixels (para os rótulos)
+func (r *Renderer3D) drawCurve(figure *types.Figure, linha types.Line, cfg RenderConfig, near, nearest, farthest float64) [][2]types.Point2D {
+	lines := r.curveLines(figure, linha, cfg, near, nearest, farthest)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	r.setColor(lines[0].color)
+	segments := make([][2]types.Point2D, len(lines))
+	for k, l := range lines {
+		if k == 0 || l.a != lines[k-1].b {
+			r.context.MoveTo(l.a.X, l.a.Y)
+		}
+		r.context.LineTo(l.b.X, l.b.Y)
+		segments[k] = [2]types.Point2D{l.a, l.b}
+	}
+	r.context.Stroke()
+
+	// Seta na ponta P2, se o último trecho chegou até ela
+	if last := lines[len(lines)-1]; last.arrow {
+		r.drawLineArrow(last.a, last.b, cfg)
+	}
+
+	r.setColor(cfg.LineColor)
+	return segments
+}
+
+// curveLines divide uma linha curva em trechos retos no espaço (ver
+// types.Line.Curve), projetados e recortados como arestas comuns (ver
+// visibleEdge).
+//
+// Parâmetros:
+//   figure: figura a que a linha pertence
+//   linha: linha com pontos de controle
+//   cfg: configurações visuais
+//   near: profundidade do plano próximo
+//   nearest, farthest: faixa de profundidades da figura (ver depthRange)
+//
+// Retorna:
+//   []screenLine: trechos visíveis, na ordem da curva, todos na mesma
+//                 cor; o último tem a seta, se a curva chega até P2
+func (r *Renderer3D) curveLines(figure *types.Figure, linha types.Line, cfg RenderConfig, near, nearest, farthest float64) []screenLine {
+	path := linha.Curve(figure.Pontos)
+	proj := r.projectBatch(path, near, cfg.BehindPolicy == BehindClamp)
+
+	color := cfg.LineColor
+	last := len(path) - 1
+	if cfg.DepthCue {
+		color = depthCueColor(cfg, proj.camera[0].Z, proj.camera[last].Z, nearest, farthest)
+	}
+
+	var lines []screenLine
+	connected := false // Se o trecho anterior foi até o ponto atual
+	for k := 0; k < last; k++ {
+		p1, p2, z1, z2, ok := r.visibleEdge(proj, k, k+1, near, cfg.BehindPolicy)
+		connected = ok
+		if ok {
+			lines = append(lines, screenLine{a: p1, b: p2, za: z1, zb: z2, color: color})
+		}
+	}
+	if linha.Direcionada && connected && proj.codes[last]&outsideNear == 0 {
+		lines[len(lines)-1].arrow = true
+	}
+	return lines
+}
@@ -0,0 +1,38 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRenderFigureWithConfig_Curves(t *testing.T) {
+	// Arco de A a B visto de frente, subindo até Z = 1 no meio
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: 0}, {X: 2, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1, Controle: []types.Point3D{{X: 0, Y: 5, Z: 2}}}},
+	}
+
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, DefaultRenderConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	dark := func(p types.Point2D) bool {
+		for dy := -1; dy <= 1; dy++ {
+			if img.RGBAAt(int(p.X), int(p.Y)+dy).R < 128 {
+				return true
+			}
+		}
+		return false
+	}
+	if top := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 5, Z: 1}); !dark(top) {
+		t.Error("Expected the curve to pass through its apex")
+	}
+	if chord := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 5, Z: 0}); dark(chord) {
+		t.Error("Expected no straight line between the end points")
+	}
+}
@@ -0,0 +1,51 @@
+package renderer
+
+import "math"
+
+// depthRange retorna a menor e a maior profundidade dos pontos à frente
+// do plano próximo, no sistema do observador.
+//
+// Parâmetros:
+//   proj: pontos da figura já projetados (ver projectBatch)
+//
+// Retorna:
+//   float64, float64: profundidades do ponto mais próximo e do mais
+//                     distante (ambas nulas se nenhum ponto for visível)
+func depthRange(proj projectedPoints) (float64, float64) {
+	nearest, farthest := math.Inf(1), math.Inf(-1)
+	for i, c := range proj.camera {
+		if proj.codes[i]&outsideNear != 0 {
+			continue
+		}
+		nearest = math.Min(nearest, c.Z)
+		farthest = math.Max(farthest, c.Z)
+	}
+	if nearest > farthest {
+		return 0, 0
+	}
+	return nearest, farthest
+}
+
+// depthCueColor calcula a cor de uma aresta pela sua distância ao
+// observador (depth cueing).
+//
+// Como a névoa que desbota os objetos distantes numa paisagem, as
+// arestas passam da cor das linhas (as mais próximas) para
+// cfg.DepthCueFar (as mais distantes), dando noção de profundidade a
+// figuras de arame sem sombreamento. Cada aresta recebe uma única cor,
+// pela profundidade do seu ponto médio.
+//
+// Parâmetros:
+//   cfg: configurações visuais (cores próxima e distante)
+//   z1, z2: profundidades dos extremos da aresta
+//   nearest, farthest: faixa de profundidades da figura (ver depthRange)
+//
+// Retorna:
+//   colorRGB: cor da aresta
+func depthCueColor(cfg RenderConfig, z1, z2, nearest, farthest float64) colorRGB {
+	if farthest <= nearest {
+		return cfg.LineColor
+	}
+	t := ((z1+z2)/2 - nearest) / (farthest - nearest)
+	return cfg.LineColor.mix(cfg.DepthCueFar, math.Max(0, math.Min(1, t)))
+}
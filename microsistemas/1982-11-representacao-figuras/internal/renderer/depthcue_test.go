@@ -0,0 +1,80 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestDepthCueColor(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.DepthCueFar = colorRGB{R: 1, G: 1, B: 1, A: 1}
+
+	tests := []struct {
+		name   string
+		z1, z2 float64
+		want   float64
+	}{
+		{"nearest edge", 2, 2, 0},
+		{"farthest edge", 10, 10, 1},
+		{"midpoint decides", 2, 10, 0.5},
+		{"clamped beyond range", 12, 12, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := depthCueColor(cfg, tt.z1, tt.z2, 2, 10)
+			if abs(got.R-tt.want) > 1e-9 || got.R != got.G || got.G != got.B || got.A != 1 {
+				t.Errorf("Expected gray %g, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	// Figura sem profundidade: todas as arestas na cor das linhas
+	if got := depthCueColor(cfg, 5, 5, 5, 5); got != cfg.LineColor {
+		t.Errorf("Expected line color for a flat range, got %+v", got)
+	}
+}
+
+func TestRenderFigureWithConfig_DepthCue(t *testing.T) {
+	// Duas arestas horizontais: a de baixo perto, a de cima longe
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -2, Y: 3, Z: -1}, {X: 2, Y: 3, Z: -1},
+			{X: -2, Y: 12, Z: 3}, {X: 2, Y: 12, Z: 3},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 2, P2: 3}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.LineWidth = 3
+	cfg.DepthCue = true
+	cfg.DepthCueFar = colorRGB{R: 0.8, G: 0.8, B: 0.8, A: 1}
+
+	renderer := New(200, 200)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	// Cor mais escura de cada aresta, procurando na coluna central
+	darkest := func(p int) uint8 {
+		y := int(renderer.ProjectToScreen(figure.Pontos[p]).Y)
+		level := uint8(255)
+		for dy := -3; dy <= 3; dy++ {
+			if v := img.RGBAAt(100, y+dy).R; v < level {
+				level = v
+			}
+		}
+		return level
+	}
+
+	if near := darkest(0); near > 10 {
+		t.Errorf("Expected nearest edge in the line color, got gray level %d", near)
+	}
+	if far := darkest(2); far < 195 || far > 215 {
+		t.Errorf("Expected farthest edge in the far color, got gray level %d", far)
+	}
+}
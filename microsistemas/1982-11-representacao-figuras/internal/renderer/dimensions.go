@@ -0,0 +1,117 @@
+package renderer
+
+import (
+	"math"
+	"strconv"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Medidas das cotas, em pixels.
+const (
+	dimensionOffset      = 20 // Afastamento padrão da linha de cota
+	dimensionGap         = 3  // Folga entre o ponto e a linha de chamada
+	dimensionOvershoot   = 4  // Quanto a linha de chamada passa da linha de cota
+	dimensionArrowLength = 8  // Comprimento das setas
+	dimensionArrowWidth  = 3  // Meia largura da base das setas
+	dimensionTextGap     = 3  // Distância do texto à linha de cota
+)
+
+// dimensionText retorna o texto de uma cota: o texto configurado ou a
+// distância entre os dois pontos no espaço, com até duas casas decimais
+// e a unidade, se houver.
+//
+// Parâmetros:
+//   a, b: pontos ligados pela cota
+//   m: cota da figura
+//
+// Retorna:
+//   string: texto escrito junto à linha de cota
+func dimensionText(a, b types.Point3D, m types.Dimension) string {
+	if m.Texto != "" {
+		return m.Texto
+	}
+	distance := math.Sqrt((b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y) + (b.Z-a.Z)*(b.Z-a.Z))
+	text := strconv.FormatFloat(math.Round(distance*100)/100, 'f', -1, 64)
+	if m.Unidade != "" {
+		text += " " + m.Unidade
+	}
+	return text
+}
+
+// drawDimensions desenha as cotas da figura (seção "medidas"), como
+// num desenho técnico.
+//
+// Cada cota tem duas linhas de chamada, perpendiculares ao segmento
+// entre os pontos na tela, e uma linha de cota paralela a ele, afastada
+// para o lado (à esquerda de quem vai do primeiro ao segundo ponto;
+// afastamentos negativos, à direita), com setas nas duas pontas. O texto
+// fica no meio da linha de cota, do lado de fora.
+//
+// Cotas com um dos pontos atrás do observador são omitidas: recortadas,
+// indicariam um comprimento que não corresponde ao texto.
+//
+// Parâmetros:
+//   figure: figura com as cotas
+//   cfg: configurações visuais (cor das cotas e realce do texto)
+//   proj: pontos da figura já projetados (ver projectBatch)
+//   near: profundidade do plano próximo
+func (r *Renderer3D) drawDimensions(figure *types.Figure, cfg RenderConfig, proj projectedPoints, near float64) {
+	r.setColor(cfg.DimensionColor)
+	r.setLineWidth(1)
+
+	for _, m := range figure.Medidas {
+		i, ok1 := figure.PointIndex(m.De)
+		j, ok2 := figure.PointIndex(m.Ate)
+		if !ok1 || !ok2 || (proj.codes[i]|proj.codes[j])&outsideNear != 0 {
+			continue
+		}
+		a, b, ok := r.clipSegment(proj.camera[i], proj.camera[j], near)
+		if !ok {
+			continue // Cota fora do volume de visão
+		}
+
+		// Direção do segmento na tela e normal à sua esquerda (com Y
+		// para baixo); pontos sobrepostos na tela não têm cota
+		dx, dy := b.X-a.X, b.Y-a.Y
+		size := math.Hypot(dx, dy)
+		if size < 1 {
+			continue
+		}
+		dx, dy = dx/size, dy/size
+		nx, ny := dy, -dx
+
+		offset := m.Afastamento
+		if offset == 0 {
+			offset = dimensionOffset
+		}
+		side := math.Copysign(1, offset)
+
+		// Linhas de chamada, dos pontos até um pouco além da linha de cota
+		for _, p := range []types.Point2D{a, b} {
+			r.context.MoveTo(p.X+nx*side*dimensionGap, p.Y+ny*side*dimensionGap)
+			r.context.LineTo(p.X+nx*(offset+side*dimensionOvershoot), p.Y+ny*(offset+side*dimensionOvershoot))
+		}
+
+		// Linha de cota com setas nas pontas
+		a = types.Point2D{X: a.X + nx*offset, Y: a.Y + ny*offset}
+		b = types.Point2D{X: b.X + nx*offset, Y: b.Y + ny*offset}
+		r.context.MoveTo(a.X, a.Y)
+		r.context.LineTo(b.X, b.Y)
+		r.context.Stroke()
+		r.drawArrowhead(a.X, a.Y, -dx, -dy, dimensionArrowLength, dimensionArrowWidth)
+		r.drawArrowhead(b.X, b.Y, dx, dy, dimensionArrowLength, dimensionArrowWidth)
+
+		// Texto centrado no meio da linha, afastado dela o bastante para
+		// que a sua caixa não a toque (a linha de base fica a 30% da
+		// altura abaixo do centro da caixa; ver drawLabel)
+		text := dimensionText(figure.Pontos[i], figure.Pontos[j], m)
+		w, h := r.measureText(text)
+		away := side * (math.Abs(nx)*w/2 + math.Abs(ny)*h/2 + dimensionTextGap)
+		cx, cy := (a.X+b.X)/2+nx*away, (a.Y+b.Y)/2+ny*away
+		r.drawLabel(text, cx-w/2, cy+0.3*h, cfg.DimensionColor, cfg)
+	}
+
+	r.setLineWidth(cfg.LineWidth)
+	r.setColor(cfg.LineColor)
+}
@@ -0,0 +1,82 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestDimensionText(t *testing.T) {
+	a := types.Point3D{X: 0, Y: 5, Z: 0}
+	tests := []struct {
+		name string
+		b    types.Point3D
+		m    types.Dimension
+		want string
+	}{
+		{"integer distance", types.Point3D{X: 3, Y: 5, Z: 4}, types.Dimension{}, "5"},
+		{"rounded to two decimals", types.Point3D{X: 1, Y: 6, Z: 0}, types.Dimension{}, "1.41"},
+		{"with unit", types.Point3D{X: 2, Y: 5, Z: 0}, types.Dimension{Unidade: "cm"}, "2 cm"},
+		{"custom text", types.Point3D{X: 2, Y: 5, Z: 0}, types.Dimension{Texto: "L", Unidade: "cm"}, "L"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dimensionText(a, tt.b, tt.m); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderFigureWithConfig_Dimensions(t *testing.T) {
+	// Segmento horizontal visto de frente: a cota positiva fica acima
+	// dele, a negativa abaixo
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: 0, Nome: "A"}, {X: 2, Y: 5, Z: 0, Nome: "B"}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		offset float64
+	}{
+		{"above", 30},
+		{"below", -30},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			figure.Medidas = []types.Dimension{{De: "A", Ate: "B", Afastamento: tt.offset}}
+
+			cfg := DefaultRenderConfig()
+			cfg.DimensionColor = colorRGB{B: 1, A: 1}
+
+			renderer := New(400, 300)
+			renderer.SetCamera(types.DefaultCamera())
+			if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+			img := renderer.GetImage().(*image.RGBA)
+
+			// Um quarto do caminho de A a B, longe do texto no meio
+			a := renderer.ProjectToScreen(figure.Pontos[0])
+			b := renderer.ProjectToScreen(figure.Pontos[1])
+			x := int(a.X + (b.X-a.X)/4)
+			blue := func(y float64) bool {
+				// A linha fina pode cair entre duas fileiras de pixels
+				for dy := -1; dy <= 1; dy++ {
+					if px := img.RGBAAt(x, int(y)+dy); px.B > 200 && px.R < 150 {
+						return true
+					}
+				}
+				return false
+			}
+			if !blue(a.Y - tt.offset) {
+				t.Errorf("Expected dimension line %g pixels above the segment", tt.offset)
+			}
+			if blue(a.Y + tt.offset) {
+				t.Errorf("Expected nothing on the other side of the segment")
+			}
+		})
+	}
+}
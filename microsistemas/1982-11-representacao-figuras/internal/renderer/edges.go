@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultCreaseAngle é o ângulo padrão, em graus, entre as normais de
+// duas faces vizinhas a partir do qual a aresta comum é um vinco.
+const DefaultCreaseAngle = 30.0
+
+// edgeKey identifica uma aresta pelos índices dos seus vértices, em
+// ordem crescente, para que as duas faces que a compartilham a encontrem.
+type edgeKey struct{ a, b int }
+
+// newEdgeKey cria a chave da aresta entre os pontos a e b.
+func newEdgeKey(a, b int) edgeKey {
+	if a > b {
+		a, b = b, a
+	}
+	return edgeKey{a: a, b: b}
+}
+
+// featureEdges seleciona as arestas que desenham o contorno de uma malha.
+//
+// Malhas densas (como as importadas de modelos prontos) têm tantas
+// arestas que traçar todas produz uma mancha ilegível. Apenas três
+// tipos de aresta carregam a forma do objeto:
+//   - borda: pertence a uma única face (ou a mais de duas)
+//   - silhueta: separa uma face voltada para o observador de outra
+//     voltada para longe dele
+//   - vinco: as normais das duas faces formam um ângulo maior que
+//     creaseAngle (quinas de um cubo, por exemplo)
+//
+// As faces vizinhas devem ter os vértices na mesma orientação (como
+// exige o descarte de faces traseiras); caso contrário, as normais
+// opostas fazem toda aresta parecer um vinco.
+//
+// Parâmetros:
+//   points: pontos da figura no espaço mundial
+//   faces: faces da figura
+//   creaseAngle: ângulo mínimo de vinco em graus
+//
+// Retorna:
+//   [][]bool: para cada face, se cada aresta Pontos[j]→Pontos[j+1] é traçada
+func (r *Renderer3D) featureEdges(points []types.Point3D, faces []types.Face, creaseAngle float64) [][]bool {
+	normals := make([]vec3, len(faces))
+	front := make([]bool, len(faces))
+	owners := make(map[edgeKey][]int)
+	for i, face := range faces {
+		normals[i] = faceNormal(points, face).normalize()
+		front[i] = r.facesObserver(points, face)
+		for j, p := range face.Pontos {
+			key := newEdgeKey(p, face.Pontos[(j+1)%len(face.Pontos)])
+			owners[key] = append(owners[key], i)
+		}
+	}
+
+	minCos := math.Cos(creaseAngle * math.Pi / 180)
+	feature := func(key edgeKey) bool {
+		faceIDs := owners[key]
+		if len(faceIDs) != 2 {
+			return true
+		}
+		a, b := faceIDs[0], faceIDs[1]
+		if front[a] != front[b] {
+			return true
+		}
+		// Normais nulas (faces degeneradas) não definem ângulo
+		if normals[a].length() == 0 || normals[b].length() == 0 {
+			return true
+		}
+		return normals[a].dot(normals[b]) < minCos
+	}
+
+	marks := make([][]bool, len(faces))
+	for i, face := range faces {
+		marks[i] = make([]bool, len(face.Pontos))
+		for j, p := range face.Pontos {
+			marks[i][j] = feature(newEdgeKey(p, face.Pontos[(j+1)%len(face.Pontos)]))
+		}
+	}
+	return marks
+}
@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// foldFigure retorna dois triângulos voltados para o observador que
+// compartilham a aresta 1–2, dobrados pelo ângulo informado (0 = planos).
+func foldFigure(fold float64) *types.Figure {
+	return &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: 0}, {X: 0, Y: 5, Z: -1}, {X: 0, Y: 5, Z: 1}, {X: 1, Y: 5 + fold, Z: 0},
+		},
+		Faces: []types.Face{
+			{Pontos: []int{0, 1, 2}},
+			{Pontos: []int{2, 1, 3}},
+		},
+		Camera: types.DefaultCamera(),
+	}
+}
+
+func TestFeatureEdges(t *testing.T) {
+	tests := []struct {
+		name       string
+		fold       float64
+		crease     float64
+		wantShared bool
+	}{
+		{"coplanar", 0, DefaultCreaseAngle, false},
+		{"crease above threshold", 1, DefaultCreaseAngle, true},
+		{"crease below threshold", 1, 60, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := New(200, 150)
+			figure := foldFigure(tt.fold)
+			renderer.SetCamera(figure.Camera)
+
+			marks := renderer.featureEdges(figure.Pontos, figure.Faces, tt.crease)
+
+			// Aresta 1→2 da primeira face e 2→1 da segunda
+			if marks[0][1] != tt.wantShared || marks[1][0] != tt.wantShared {
+				t.Errorf("Expected shared edge marked=%v, got %v and %v", tt.wantShared, marks[0][1], marks[1][0])
+			}
+
+			// As bordas são sempre traçadas
+			if !marks[0][0] || !marks[0][2] || !marks[1][1] || !marks[1][2] {
+				t.Errorf("Expected all boundary edges marked, got %v", marks)
+			}
+		})
+	}
+}
+
+func TestFeatureEdges_Silhouette(t *testing.T) {
+	// Dobra de 45° (abaixo do limite de vinco) com a segunda face de costas
+	renderer := New(200, 150)
+	figure := foldFigure(-1)
+	figure.Camera.Observer = types.Point3D{X: 5, Y: 0, Z: 0}
+	figure.Camera.Target = &types.Point3D{X: 0, Y: 5, Z: 0}
+	renderer.SetCamera(figure.Camera)
+
+	if renderer.facesObserver(figure.Pontos, figure.Faces[0]) == renderer.facesObserver(figure.Pontos, figure.Faces[1]) {
+		t.Fatal("Expected faces with opposite orientation relative to the observer")
+	}
+
+	marks := renderer.featureEdges(figure.Pontos, figure.Faces, 60)
+	if !marks[0][1] {
+		t.Error("Expected silhouette edge to be marked")
+	}
+}
+
+func TestClipPolygonNear_EdgeMarks(t *testing.T) {
+	// Quadrado atravessando o plano próximo, só com a aresta 0→1 marcada
+	square := []vec3{
+		{X: -1, Z: -1}, {X: 1, Z: -1}, {X: 1, Z: 1}, {X: -1, Z: 1},
+	}
+	edges := []bool{true, false, false, false}
+
+	clipped, marks := clipPolygonNear(square, edges, 0)
+	if len(marks) != len(clipped) {
+		t.Fatalf("Expected one mark per vertex, got %d marks for %d vertices", len(marks), len(clipped))
+	}
+
+	// A aresta 0→1 estava inteiramente atrás do plano e desaparece; a
+	// nova aresta, sobre o plano, não é marcada
+	for i, m := range marks {
+		if m {
+			t.Errorf("Expected edge %d unmarked, got marked (polygon %v)", i, clipped)
+		}
+	}
+
+	// A aresta 2→3, inteiramente à frente, mantém a sua marca
+	edges = []bool{false, false, true, false}
+	clipped, marks = clipPolygonNear(square, edges, 0)
+	marked := 0
+	for i, m := range marks {
+		if m {
+			marked++
+			a, b := clipped[i], clipped[(i+1)%len(clipped)]
+			if a.Z != 1 || b.Z != 1 {
+				t.Errorf("Expected marked edge along z=1, got %+v→%+v", a, b)
+			}
+		}
+	}
+	if marked != 1 {
+		t.Errorf("Expected exactly one marked edge, got %d", marked)
+	}
+}
@@ -0,0 +1,129 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageFormat é o formato do arquivo de imagem gravado.
+type ImageFormat string
+
+const (
+	FormatPNG  ImageFormat = "png"  // Sem perdas, com metadados e 16 bits
+	FormatJPEG ImageFormat = "jpeg" // Com perdas, qualidade ajustável
+	FormatWebP ImageFormat = "webp" // Sem perdas, arquivos menores que PNG
+)
+
+// DefaultJPEGQuality é a qualidade padrão dos arquivos JPEG (1 a 100).
+const DefaultJPEGQuality = 90
+
+// imageFormatAliases mapeia nomes e extensões aos formatos.
+var imageFormatAliases = map[string]ImageFormat{
+	"png":  FormatPNG,
+	"jpeg": FormatJPEG,
+	"jpg":  FormatJPEG,
+	"webp": FormatWebP,
+}
+
+// ParseImageFormat converte o nome de um formato ("png", "jpeg", "jpg"
+// ou "webp", sem distinção de maiúsculas).
+//
+// Parâmetros:
+//   value: nome do formato
+//
+// Retorna:
+//   ImageFormat: formato correspondente
+//   error: erro se o nome for desconhecido
+func ParseImageFormat(value string) (ImageFormat, error) {
+	format, ok := imageFormatAliases[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("formato de imagem desconhecido: %q (use %s, %s ou %s)", value, FormatPNG, FormatJPEG, FormatWebP)
+	}
+	return format, nil
+}
+
+// Extension retorna a extensão de arquivo do formato, com o ponto.
+func (f ImageFormat) Extension() string {
+	if f == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + string(f)
+}
+
+// SaveOptions são as opções de gravação de uma imagem.
+type SaveOptions struct {
+	Format  ImageFormat // Formato ("" = pela extensão do arquivo; PNG se não houver)
+	Quality int         // Qualidade do JPEG, de 1 a 100 (0 = DefaultJPEGQuality)
+	DPI     float64     // Resolução de impressão, gravada apenas em PNG (0 = nenhuma)
+	Text    []PNGText   // Metadados, gravados apenas em PNG (ver EncodePNG)
+}
+
+// format resolve o formato da gravação: o informado ou, na falta dele, o
+// da extensão do arquivo.
+//
+// Parâmetros:
+//   filename: arquivo de destino
+//
+// Retorna:
+//   ImageFormat: formato a usar
+//   error: erro se a extensão não corresponder a nenhum formato
+func (o SaveOptions) format(filename string) (ImageFormat, error) {
+	if o.Format != "" {
+		return o.Format, nil
+	}
+	ext := strings.TrimPrefix(filepath.Ext(filename), ".")
+	if ext == "" {
+		return FormatPNG, nil
+	}
+	return ParseImageFormat(ext)
+}
+
+// saveImageFile grava uma imagem no formato escolhido.
+//
+// Parâmetros:
+//   filename: caminho do arquivo a ser criado
+//   opts: formato, qualidade e metadados
+//   source: retorna a imagem a gravar no formato resolvido (só o PNG
+//           aceita 16 bits por canal)
+//
+// Retorna:
+//   error: erro de opções, de codificação ou de E/S
+func saveImageFile(filename string, opts SaveOptions, source func(ImageFormat) image.Image) error {
+	format, err := opts.format(filename)
+	if err != nil {
+		return err
+	}
+	quality := opts.Quality
+	if quality == 0 {
+		quality = DefaultJPEGQuality
+	}
+	if quality < 1 || quality > 100 {
+		return fmt.Errorf("qualidade inválida: %d (use de 1 a 100)", quality)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := encodeImage(f, format, source(format), quality, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// encodeImage codifica a imagem no formato indicado.
+func encodeImage(w io.Writer, format ImageFormat, img image.Image, quality int, opts SaveOptions) error {
+	switch format {
+	case FormatJPEG:
+		return EncodeJPEG(w, img, quality)
+	case FormatWebP:
+		return encodeWebP(w, img)
+	default:
+		return EncodePNG(w, img, opts.DPI, opts.Text)
+	}
+}
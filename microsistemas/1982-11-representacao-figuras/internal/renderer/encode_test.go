@@ -0,0 +1,108 @@
+package renderer
+
+import (
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func TestParseImageFormat(t *testing.T) {
+	for value, want := range map[string]ImageFormat{"png": FormatPNG, "JPG": FormatJPEG, " jpeg ": FormatJPEG, "WebP": FormatWebP} {
+		got, err := ParseImageFormat(value)
+		if err != nil || got != want {
+			t.Errorf("ParseImageFormat(%q) = %q, %v; expected %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseImageFormat("gif"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+
+	if ext := FormatJPEG.Extension(); ext != ".jpg" {
+		t.Errorf("Expected .jpg extension, got %s", ext)
+	}
+}
+
+func TestSaveImage_Formats(t *testing.T) {
+	renderer := New(64, 48)
+	renderer.context.SetRGB(0, 0, 0)
+	renderer.context.DrawLine(0, 0, 64, 48)
+	renderer.context.Stroke()
+
+	dir := t.TempDir()
+	tests := []struct {
+		filename string
+		opts     SaveOptions
+		decode   func(f *os.File) (image.Image, error)
+	}{
+		{"figura.png", SaveOptions{}, func(f *os.File) (image.Image, error) { return png.Decode(f) }},
+		{"figura.jpg", SaveOptions{Quality: 50}, func(f *os.File) (image.Image, error) { return jpeg.Decode(f) }},
+		{"figura.webp", SaveOptions{}, func(f *os.File) (image.Image, error) { return webp.Decode(f) }},
+		{"sem_extensao", SaveOptions{}, func(f *os.File) (image.Image, error) { return png.Decode(f) }},
+		{"figura.png", SaveOptions{Format: FormatJPEG}, func(f *os.File) (image.Image, error) { return jpeg.Decode(f) }},
+	}
+
+	for _, tt := range tests {
+		filename := filepath.Join(dir, tt.filename)
+		if err := renderer.SaveImage(filename, tt.opts); err != nil {
+			t.Fatalf("SaveImage(%s) failed: %v", tt.filename, err)
+		}
+		f, err := os.Open(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := tt.decode(f)
+		f.Close()
+		if err != nil {
+			t.Errorf("%s (%+v): decode failed: %v", tt.filename, tt.opts, err)
+			continue
+		}
+		if img.Bounds() != image.Rect(0, 0, 64, 48) {
+			t.Errorf("%s: expected 64×48 image, got %v", tt.filename, img.Bounds())
+		}
+	}
+}
+
+func TestSaveImage_Invalid(t *testing.T) {
+	renderer := New(8, 8)
+	dir := t.TempDir()
+	if err := renderer.SaveImage(filepath.Join(dir, "figura.gif"), SaveOptions{}); err == nil {
+		t.Error("Expected error for unknown extension")
+	}
+	if err := renderer.SaveImage(filepath.Join(dir, "figura.jpg"), SaveOptions{Quality: 101}); err == nil {
+		t.Error("Expected error for JPEG quality above 100")
+	}
+}
+
+func TestTiledImage_SaveJPEG(t *testing.T) {
+	tiled, err := NewTiledImage(100, 200, 64, tiledTestFigure(), DefaultRenderConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewTiledImage failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := tiled.Save(filepath.Join(dir, "tiled.webp"), SaveOptions{}); err == nil {
+		t.Error("Expected error for WebP in tiled rendering")
+	}
+
+	filename := filepath.Join(dir, "tiled.jpg")
+	if err := tiled.Save(filename, SaveOptions{}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := jpeg.Decode(f)
+	if err != nil {
+		t.Fatalf("Failed to decode JPEG: %v", err)
+	}
+	if !hasDarkPixel(img, img.Bounds()) {
+		t.Error("Expected figure to be drawn in tiled JPEG")
+	}
+}
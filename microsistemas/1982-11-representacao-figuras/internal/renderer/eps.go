@@ -0,0 +1,169 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"representacao-figuras/pkg/types"
+)
+
+// epsPathLimit é o número de trechos de um caminho antes de traçá-lo e
+// começar outro: as impressoras PostScript de nível 1 aceitam caminhos
+// de no máximo 1500 pontos.
+const epsPathLimit = 500
+
+// epsLabelFont é o tamanho dos nomes quando a figura usa a fonte de
+// bitmap, que não existe em PostScript.
+const epsLabelFont = 10
+
+// RenderEPS desenha a figura em PostScript encapsulado (EPS), como
+// vetores, para os fluxos de editoração que ainda recebem ilustrações
+// nesse formato.
+//
+// A projeção e o recorte são os de RenderRetro: a caixa do desenho
+// (BoundingBox) tem as dimensões do renderizador, com um ponto
+// PostScript por pixel, e cada aresta vira um traço com a cor, a
+// espessura, as pontas e as junções da configuração; os trechos
+// consecutivos de uma mesma aresta ou curva formam um só caminho. O
+// fundo é pintado se for opaco, os vértices aparecem como círculos e os
+// nomes dos pontos em Helvetica, se mostrar_vertices e mostrar_nomes
+// estiverem ativos. Faces, estilos de linha e os demais acréscimos
+// gráficos são ignorados; as cores semitransparentes são misturadas ao
+// fundo, já que o PostScript não tem transparência.
+//
+// Parâmetros:
+//   figure: figura 3D a ser desenhada
+//   cfg: configurações visuais (cores, traço, vértices, nomes e plano
+//        próximo)
+//
+// Retorna:
+//   []byte: arquivo EPS
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderEPS(figure *types.Figure, cfg RenderConfig) ([]byte, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	var buf bytes.Buffer
+	height := float64(r.height)
+	// O PostScript mede y de baixo para cima; os pixels, de cima para baixo
+	point := func(p types.Point2D) string {
+		return epsNumber(p.X) + " " + epsNumber(height-p.Y)
+	}
+	background := cfg.Background
+	ink := func(c colorRGB) string {
+		if c.A < 1 && background.A > 0 {
+			c = colorRGB{R: background.R, G: background.G, B: background.B}.mix(c, c.A)
+		}
+		return fmt.Sprintf("%s %s %s setrgbcolor", epsNumber(c.R), epsNumber(c.G), epsNumber(c.B))
+	}
+
+	fmt.Fprintf(&buf, "%%!PS-Adobe-3.0 EPSF-3.0\n")
+	fmt.Fprintf(&buf, "%%%%BoundingBox: 0 0 %d %d\n", r.width, r.height)
+	fmt.Fprintf(&buf, "%%%%HiResBoundingBox: 0 0 %d %d\n", r.width, r.height)
+	if figure.Nome != "" {
+		fmt.Fprintf(&buf, "%%%%Title: %s\n", epsLatin1(figure.Nome))
+	}
+	fmt.Fprintf(&buf, "%%%%Creator: figuras3d\n")
+	fmt.Fprintf(&buf, "%%%%EndComments\n")
+	fmt.Fprintf(&buf, "gsave\n")
+
+	if background.A > 0 {
+		fmt.Fprintf(&buf, "%s\n0 0 %d %d rectfill\n", ink(background), r.width, r.height)
+	}
+
+	caps := map[LineCap]int{CapButt: 0, CapRound: 1, CapSquare: 2}
+	joins := map[LineJoin]int{JoinRound: 1, JoinBevel: 2}
+	fmt.Fprintf(&buf, "%s\n%s setlinewidth\n%d setlinecap\n%d setlinejoin\n",
+		ink(cfg.LineColor), epsNumber(cfg.LineWidth), caps[cfg.LineCap], joins[cfg.LineJoin])
+
+	// Arestas: um trecho que começa onde o anterior acabou continua o
+	// mesmo caminho
+	var last types.Point2D
+	segments := 0
+	r.traceEdges(figure, cfg, func(p1, p2 types.Point2D) {
+		if segments == 0 || p1 != last || segments%epsPathLimit == 0 {
+			if segments > 0 {
+				buf.WriteString("stroke\n")
+			}
+			fmt.Fprintf(&buf, "newpath %s moveto\n", point(p1))
+		}
+		fmt.Fprintf(&buf, "%s lineto\n", point(p2))
+		last = p2
+		segments++
+	})
+	if segments > 0 {
+		buf.WriteString("stroke\n")
+	}
+
+	// Vértices e nomes por cima das arestas
+	if cfg.ShowVertices || cfg.ShowLabels {
+		near := cfg.NearPlane
+		if near <= 0 {
+			near = DefaultNearPlane
+		}
+		size := cfg.FontSize
+		if size == 0 {
+			size = epsLabelFont
+		}
+		if cfg.ShowLabels {
+			// Helvetica com a codificação Latin-1, para os acentos
+			fmt.Fprintf(&buf, "/Helvetica findfont dup length dict begin\n")
+			fmt.Fprintf(&buf, "{1 index /FID ne {def} {pop pop} ifelse} forall\n")
+			fmt.Fprintf(&buf, "/Encoding ISOLatin1Encoding def currentdict end\n")
+			fmt.Fprintf(&buf, "/Helvetica-Latin1 exch definefont %s scalefont setfont\n", epsNumber(size))
+		}
+		proj := r.projectBatch(figure.Pontos, near, false)
+		for i, p := range figure.Pontos {
+			if proj.codes[i]&outsideNear != 0 {
+				continue // Vértice atrás do observador
+			}
+			s := r.screenPoint(proj, i)
+			if s.X < 0 || s.Y < 0 || s.X > float64(r.width) || s.Y > height {
+				continue
+			}
+			if cfg.ShowVertices {
+				fmt.Fprintf(&buf, "%s\nnewpath %s 2 0 360 arc fill\n", ink(cfg.VertexColor), point(s))
+			}
+			if cfg.ShowLabels && p.Nome != "" {
+				label := types.Point2D{X: s.X + 5, Y: s.Y - 5} // Acima, à direita
+				fmt.Fprintf(&buf, "%s\n%s moveto (%s) show\n", ink(cfg.LineColor), point(label), epsLatin1(p.Nome))
+			}
+		}
+	}
+
+	fmt.Fprintf(&buf, "grestore\nshowpage\n%%%%EOF\n")
+	return buf.Bytes(), nil
+}
+
+// epsNumber escreve uma coordenada com duas casas decimais, sem os
+// zeros à direita: centésimos de ponto bastam para qualquer impressão.
+func epsNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100+0, 'f', -1, 64) // +0: -0 vira 0
+}
+
+// epsLatin1 prepara um texto para uma cadeia PostScript: os caracteres
+// Latin-1 fora do ASCII e os de controle viram escapes octais, "\", "("
+// e ")" são escapados, e os caracteres fora do Latin-1 viram "?".
+func epsLatin1(s string) string {
+	var buf bytes.Buffer
+	for _, ch := range s {
+		switch {
+		case ch == '\\' || ch == '(' || ch == ')':
+			buf.WriteByte('\\')
+			buf.WriteRune(ch)
+		case ch >= 0x20 && ch < 0x7f:
+			buf.WriteRune(ch)
+		case ch < 0x100:
+			fmt.Fprintf(&buf, "\\%03o", ch)
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
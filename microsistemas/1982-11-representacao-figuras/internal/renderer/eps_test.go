@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRenderEPS(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "quadrado",
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1, Nome: "Ação"},
+			{X: 1, Y: 5, Z: -1},
+			{X: 1, Y: 5, Z: 1},
+			{X: -1, Y: 5, Z: 1, Nome: "(B)"},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 0}, {P1: 0, P2: 2}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	r := New(400, 300)
+	r.SetCamera(figure.Camera)
+	cfg := DefaultRenderConfig()
+	cfg.ShowVertices, cfg.ShowLabels = true, true
+	cfg.LineCap = CapButt
+	data, err := r.RenderEPS(figure, cfg)
+	if err != nil {
+		t.Fatalf("RenderEPS failed: %v", err)
+	}
+	text := string(data)
+
+	if !strings.HasPrefix(text, "%!PS-Adobe-3.0 EPSF-3.0\n") || !strings.HasSuffix(text, "%%EOF\n") {
+		t.Errorf("Expected EPS header and trailer, got:\n%s", text)
+	}
+	for _, want := range []string{
+		"%%BoundingBox: 0 0 400 300\n",
+		"%%Title: quadrado\n",
+		"0 0 400 300 rectfill\n",
+		"0 setlinecap\n",
+		"(A\\347\\343o) show\n",
+		"(\\(B\\)) show\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in EPS:\n%s", want, text)
+		}
+	}
+	// O contorno é um caminho só; a diagonal, outro
+	if n := strings.Count(text, "stroke\n"); n != 2 {
+		t.Errorf("Expected 2 strokes, got %d:\n%s", n, text)
+	}
+	if n := strings.Count(text, " lineto\n"); n != 5 {
+		t.Errorf("Expected 5 segments, got %d", n)
+	}
+	if n := strings.Count(text, " arc fill\n"); n != 4 {
+		t.Errorf("Expected 4 vertices, got %d", n)
+	}
+
+	// Fundo transparente não é pintado
+	cfg.Background.A = 0
+	data, err = New(400, 300).RenderEPS(figure, cfg)
+	if err != nil || strings.Contains(string(data), "rectfill") {
+		t.Errorf("Expected no background fill (%v)", err)
+	}
+
+	if _, err := New(400, 300).RenderEPS(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
+
+func TestEPSNumber(t *testing.T) {
+	cases := map[float64]string{
+		0:        "0",
+		1.5:      "1.5",
+		191.6667: "191.67",
+		-0.001:   "0",
+		1e7:      "10000000",
+	}
+	for v, want := range cases {
+		if got := epsNumber(v); got != want {
+			t.Errorf("epsNumber(%v): expected %q, got %q", v, want, got)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package renderer
+
+import (
+	"fmt"
+	"sort"
+
+	"representacao-figuras/pkg/types"
+)
+
+// faceOrder retorna a ordem de desenho das faces pelo algoritmo do pintor.
+//
+// Como um pintor que pinta o fundo antes dos objetos em primeiro plano,
+// as faces são desenhadas da mais distante para a mais próxima, e cada
+// uma cobre as que ficaram atrás dela. A distância de cada face é a
+// profundidade média dos seus vértices no sistema do observador.
+//
+// O critério falha para faces que se interpenetram ou que se sobrepõem
+// ciclicamente, mas resolve a grande maioria dos sólidos simples.
+//
+// Parâmetros:
+//   faces: faces da figura
+//   camera: pontos da figura no sistema do observador (Z = profundidade)
+//
+// Retorna:
+//   []int: índices das faces, da mais distante para a mais próxima
+func faceOrder(faces []types.Face, camera []vec3) []int {
+	depths := make([]float64, len(faces))
+	order := make([]int, len(faces))
+	for i, face := range faces {
+		order[i] = i
+		for _, p := range face.Pontos {
+			depths[i] += camera[p].Z
+		}
+		depths[i] /= float64(len(face.Pontos))
+	}
+
+	// Ordenação estável: faces à mesma distância mantêm a ordem do YAML
+	sort.SliceStable(order, func(a, b int) bool {
+		return depths[order[a]] > depths[order[b]]
+	})
+	return order
+}
+
+// clipPolygonNear recorta um polígono pelo plano próximo da projeção cônica.
+//
+// É o algoritmo de Sutherland-Hodgman com um único plano: percorre as
+// arestas do polígono mantendo os vértices à frente do plano e inserindo
+// os pontos onde as arestas o atravessam (ver clipNear).
+//
+// As marcas de aresta (ver featureEdges) acompanham o recorte: cada
+// trecho de aresta original mantém a sua marca, e a aresta nova, ao
+// longo do plano próximo, nunca é marcada.
+//
+// Parâmetros:
+//   poly: vértices do polígono no sistema do observador, em ordem
+//   edges: marca de cada aresta poly[i]→poly[i+1] (nil = sem marcas)
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   []vec3: polígono recortado (menos de 3 vértices se nada ficou visível)
+//   []bool: marcas das arestas do polígono recortado (nil se edges for nil)
+func clipPolygonNear(poly []vec3, edges []bool, near float64) ([]vec3, []bool) {
+	var out []vec3
+	var outEdges []bool
+	add := func(v vec3, edge bool) {
+		out = append(out, v)
+		if edges != nil {
+			outEdges = append(outEdges, edge)
+		}
+	}
+
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		edge := edges != nil && edges[i]
+		aIn := a.Z >= near
+		if aIn {
+			add(a, edge)
+		}
+		if aIn != (b.Z >= near) {
+			t := (near - a.Z) / (b.Z - a.Z)
+			crossing := vec3{
+				X: a.X + t*(b.X-a.X),
+				Y: a.Y + t*(b.Y-a.Y),
+				Z: near,
+			}
+			// Saindo do volume, a aresta seguinte corre sobre o plano
+			add(crossing, !aIn && edge)
+		}
+	}
+	return out, outEdges
+}
+
+// faceNormal calcula a normal de uma face pelo método de Newell.
+//
+// O método soma as contribuições de todas as arestas e funciona mesmo
+// para polígonos não convexos ou ligeiramente não planos. A normal
+// segue a regra da mão direita: vértices em ordem anti-horária, vistos
+// de um lado, produzem uma normal apontando para esse lado.
+//
+// Parâmetros:
+//   points: pontos da figura no espaço mundial
+//   face: face cujos índices referenciam points
+//
+// Retorna:
+//   vec3: normal não normalizada (nula para faces degeneradas)
+func faceNormal(points []types.Point3D, face types.Face) vec3 {
+	var n vec3
+	for i, idx := range face.Pontos {
+		a := points[idx]
+		b := points[face.Pontos[(i+1)%len(face.Pontos)]]
+		n.X += (a.Y - b.Y) * (a.Z + b.Z)
+		n.Y += (a.Z - b.Z) * (a.X + b.X)
+		n.Z += (a.X - b.X) * (a.Y + b.Y)
+	}
+	return n
+}
+
+// facesObserver informa se a face está voltada para o observador.
+//
+// Compara a normal da face com a direção de visão: na projeção cônica,
+// o vetor de um vértice da face até o observador; nas paralelas, o
+// oposto da direção de visão, igual para todos os pontos. A face é
+// traseira quando o ângulo entre os dois passa de 90° (produto escalar
+// não positivo).
+//
+// Parâmetros:
+//   points: pontos da figura no espaço mundial
+//   face: face a testar
+//
+// Retorna:
+//   bool: true se a face está de frente para o observador
+func (r *Renderer3D) facesObserver(points []types.Point3D, face types.Face) bool {
+	normal := faceNormal(points, face)
+
+	toObserver := vec3{X: -r.basis.forward.X, Y: -r.basis.forward.Y, Z: -r.basis.forward.Z}
+	if !r.camera.IsParallel() {
+		toObserver = toVec3(r.camera.Observer).sub(toVec3(points[face.Pontos[0]]))
+	}
+	return normal.dot(toObserver) > 0
+}
+
+// screenFace é uma face pronta para ser desenhada: recortada, projetada
+// em pixels e com a cor de preenchimento resolvida.
+type screenFace struct {
+	id       int             // Índice da face na figura
+	fill     colorRGB        // Cor de preenchimento
+	screen   []types.Point2D // Vértices em pixels
+	depth    []float64       // Profundidade de cada vértice no sistema do observador
+	edges    []bool          // Arestas screen[i]→screen[i+1] a contornar (nil = todas)
+	distance float64         // Profundidade média, a da ordem do pintor (ver faceOrder)
+}
+
+// screenLine é um trecho reto de uma linha da figura, já recortado e
+// projetado em pixels.
+type screenLine struct {
+	a, b   types.Point2D // Extremos em pixels
+	za, zb float64       // Profundidades dos extremos no sistema do observador
+	color  colorRGB      // Cor do traço
+	arrow  bool          // Seta na ponta b (linha direcionada)
+}
+
+// lineOrder retorna a ordem das linhas da figura pela profundidade
+// média das suas pontas, como faceOrder faz com as faces.
+//
+// Parâmetros:
+//   linhas: linhas da figura
+//   camera: pontos da figura no sistema do observador (Z = profundidade)
+//
+// Retorna:
+//   []int: índices das linhas válidas, da mais distante para a mais próxima
+//   []float64: profundidade média de cada linha (indexada como linhas)
+func lineOrder(linhas []types.Line, camera []vec3) ([]int, []float64) {
+	depths := make([]float64, len(linhas))
+	order := make([]int, 0, len(linhas))
+	for i, linha := range linhas {
+		if linha.P1 < 0 || linha.P2 < 0 || linha.P1 >= len(camera) || linha.P2 >= len(camera) {
+			continue // Referências inválidas não são desenhadas
+		}
+		depths[i] = (camera[linha.P1].Z + camera[linha.P2].Z) / 2
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return depths[order[a]] > depths[order[b]]
+	})
+	return order, depths
+}
+
+// prepareFaces seleciona, recorta e projeta as faces a desenhar.
+//
+// Faces inteiramente fora do volume de visão são descartadas; as que
+// cruzam o plano próximo são recortadas nele, conforme a política para
+// pontos atrás do observador. Com cfg.BackfaceCulling, faces voltadas
+// para longe do observador também são descartadas: num sólido fechado
+// elas estão sempre escondidas. Com cfg.Lighting, a cor de cada face é
+// sombreada pela luz direcional (ver shadeColor). Com EdgesOutline, cada
+// face guarda quais das suas arestas são de contorno ou vinco.
+//
+// Parâmetros:
+//   figure: figura com faces
+//   cfg: configurações visuais
+//   proj: pontos da figura já projetados (ver projectBatch)
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   []screenFace: faces visíveis, da mais distante para a mais próxima
+//   error: erro se a cor de alguma face for inválida
+func (r *Renderer3D) prepareFaces(figure *types.Figure, cfg RenderConfig, proj projectedPoints, near float64) ([]screenFace, error) {
+	// Verificação de segurança: ignora faces com referências inválidas
+	faces := make([]types.Face, 0, len(figure.Faces))
+	ids := make([]int, 0, len(figure.Faces)) // Índice de cada face na figura
+	for id, face := range figure.Faces {
+		valid := len(face.Pontos) >= 3
+		for _, p := range face.Pontos {
+			if p < 0 || p >= len(proj.camera) {
+				valid = false
+			}
+		}
+		if valid {
+			faces = append(faces, face)
+			ids = append(ids, id)
+		}
+	}
+
+	// Arestas de contorno e vinco, quando só elas são traçadas
+	var marks [][]bool
+	if cfg.EdgeMode == EdgesOutline {
+		marks = r.featureEdges(figure.Pontos, faces, cfg.CreaseAngle)
+	}
+
+	var prepared []screenFace
+	for _, i := range faceOrder(faces, proj.camera) {
+		face := faces[i]
+		var edges []bool
+		if marks != nil {
+			edges = marks[i]
+		}
+
+		fill := cfg.FaceColor
+		if face.Cor != "" {
+			col, err := parseColor(face.Cor)
+			if err != nil {
+				return nil, fmt.Errorf("cor da face %d inválida: %w", ids[i], err)
+			}
+			fill = col
+		}
+
+		// Face inteiramente fora do volume de visão: todos os vértices
+		// compartilham um semiespaço externo
+		shared, combined := uint8(0xff), uint8(0)
+		poly := make([]vec3, len(face.Pontos))
+		for j, p := range face.Pontos {
+			shared &= proj.codes[p]
+			combined |= proj.codes[p]
+			poly[j] = proj.camera[p]
+		}
+		if shared != 0 {
+			continue
+		}
+
+		// Face traseira de um sólido fechado
+		front := r.facesObserver(figure.Pontos, face)
+		if cfg.BackfaceCulling && !front {
+			continue
+		}
+
+		// Sombreamento plano pela luz direcional
+		if cfg.Lighting {
+			fill = shadeColor(fill, faceNormal(figure.Pontos, face), front, cfg)
+		}
+
+		// Faces que cruzam o plano próximo
+		if combined&outsideNear != 0 {
+			if cfg.BehindPolicy == BehindDrop {
+				continue
+			}
+			poly, edges = clipPolygonNear(poly, edges, near)
+			if len(poly) < 3 {
+				continue
+			}
+		}
+
+		sf := screenFace{
+			id:     ids[i],
+			fill:   fill,
+			edges:  edges,
+			screen: make([]types.Point2D, len(poly)),
+			depth:  make([]float64, len(poly)),
+		}
+		for _, p := range face.Pontos {
+			sf.distance += proj.camera[p].Z / float64(len(face.Pontos))
+		}
+		for j, c := range poly {
+			sf.screen[j] = r.ViewportTransform(r.projectCameraSpace(c.X, c.Y, c.Z))
+			sf.depth[j] = c.Z
+		}
+		prepared = append(prepared, sf)
+	}
+
+	return prepared, nil
+}
+
+// drawFaces desenha as faces da figura, preenchidas e contornadas,
+// junto com as linhas da figura.
+//
+// Com o algoritmo do pintor (padrão), as faces são desenhadas em ordem
+// de profundidade (ver faceOrder), cada uma preenchida com a sua cor
+// (ou cfg.FaceColor) e contornada com a cor das linhas; com
+// EdgesOutline, só as arestas de contorno e vinco são traçadas; no
+// estilo de esboço, cada aresta é traçada à parte (ver addLine). Antes
+// de cada face, behind traça as linhas mais distantes do que ela (ver
+// lineOrder): a face cobre as linhas atrás dela, mas não as da frente.
+// Com HiddenZBuffer, a visibilidade das faces e das linhas é resolvida
+// pixel a pixel (ver rasterizeFaces), sempre com traço contínuo.
+//
+// Parâmetros:
+//   figure: figura com faces
+//   cfg: configurações visuais
+//   proj: pontos da figura já projetados (ver projectBatch)
+//   near: profundidade do plano próximo
+//   behind: traça as linhas ainda não traçadas mais distantes do que a
+//           profundidade dada (algoritmo do pintor)
+//   lines: trechos visíveis das linhas da figura (z-buffer)
+//
+// Retorna:
+//   error: erro se a cor de alguma face for inválida
+func (r *Renderer3D) drawFaces(figure *types.Figure, cfg RenderConfig, proj projectedPoints, near float64, behind func(depth float64), lines []screenLine) error {
+	faces, err := r.prepareFaces(figure, cfg, proj, near)
+	if err != nil {
+		return err
+	}
+
+	if cfg.HiddenSurface == HiddenZBuffer {
+		return r.rasterizeFaces(faces, lines, cfg)
+	}
+
+	for _, face := range faces {
+		behind(face.distance)
+		for j, p := range face.screen {
+			if j == 0 {
+				r.context.MoveTo(p.X, p.Y)
+			} else {
+				r.context.LineTo(p.X, p.Y)
+			}
+		}
+		r.context.ClosePath()
+
+		// Preenche e contorna com a cor das linhas
+		r.setColor(face.fill)
+		if face.edges == nil && cfg.LineStyle != LineSketch {
+			r.context.FillPreserve()
+			r.setColor(cfg.LineColor)
+			r.context.Stroke()
+			continue
+		}
+		// Sem o contorno, a suavização deixaria frestas claras entre faces
+		// vizinhas: um traço fino na própria cor da face as cobre (faces
+		// semitransparentes ficariam com a borda mais escura)
+		if face.fill.A < 1 {
+			r.context.Fill()
+		} else {
+			r.context.FillPreserve()
+			r.setLineWidth(1)
+			r.context.Stroke()
+			r.setLineWidth(cfg.LineWidth)
+		}
+
+		// Apenas as arestas de contorno e vinco (ou todas, no esboço)
+		n := len(face.screen)
+		for j, a := range face.screen {
+			if face.edges == nil || face.edges[j] {
+				b := face.screen[(j+1)%n]
+				r.addLine(a, b, cfg, sketchFaces, uint64(face.id)<<16|uint64(j))
+			}
+		}
+		r.setColor(cfg.LineColor)
+		r.context.Stroke()
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestFaceOrder(t *testing.T) {
+	camera := []vec3{
+		{Z: 2}, {Z: 2}, {Z: 2}, // Face próxima
+		{Z: 9}, {Z: 9}, {Z: 9}, // Face distante
+		{Z: 5}, {Z: 5}, {Z: 5}, // Face intermediária
+	}
+	faces := []types.Face{
+		{Pontos: []int{0, 1, 2}},
+		{Pontos: []int{3, 4, 5}},
+		{Pontos: []int{6, 7, 8}},
+	}
+
+	order := faceOrder(faces, camera)
+	want := []int{1, 2, 0}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected back-to-front order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestClipPolygonNear(t *testing.T) {
+	// Quadrado atravessando o plano próximo: metade fica visível
+	square := []vec3{
+		{X: -1, Z: -1}, {X: 1, Z: -1}, {X: 1, Z: 1}, {X: -1, Z: 1},
+	}
+
+	clipped, _ := clipPolygonNear(square, nil, 0)
+	if len(clipped) != 4 {
+		t.Fatalf("Expected 4 vertices after clipping, got %d: %v", len(clipped), clipped)
+	}
+	for _, v := range clipped {
+		if v.Z < 0 {
+			t.Errorf("Expected all vertices in front of near plane, got %+v", v)
+		}
+	}
+
+	// Polígono inteiramente atrás do plano desaparece
+	if got, _ := clipPolygonNear(square, nil, 2); len(got) != 0 {
+		t.Errorf("Expected polygon behind near plane to vanish, got %v", got)
+	}
+}
+
+// facesTestFigure retorna dois quadrados sobrepostos vistos de frente,
+// declarados do mais próximo para o mais distante
+func facesTestFigure() *types.Figure {
+	return &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: 1}, {X: -1, Y: 5, Z: 1},
+			{X: -2, Y: 8, Z: -2}, {X: 2, Y: 8, Z: -2}, {X: 2, Y: 8, Z: 2}, {X: -2, Y: 8, Z: 2},
+		},
+		Faces: []types.Face{
+			{Pontos: []int{0, 1, 2, 3}, Cor: "#ff0000"},
+			{Pontos: []int{4, 5, 6, 7}, Cor: "#0000ff"},
+		},
+		Camera: types.DefaultCamera(),
+	}
+}
+
+func TestRenderFigure_PainterOrder(t *testing.T) {
+	renderer := New(200, 150)
+	figure := facesTestFigure()
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigureWithConfig(figure, DefaultRenderConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	// O centro é coberto pela face próxima, mesmo ela vindo antes no YAML
+	img := renderer.GetImage().(image.Image)
+	r, g, b, _ := img.At(100, 75).RGBA()
+	if r < 0xc000 || g > 0x4000 || b > 0x4000 {
+		t.Errorf("Expected near red face at center, got (%x,%x,%x)", r, g, b)
+	}
+
+	// A face distante aparece ao redor da próxima
+	beside := renderer.ProjectToScreen(types.Point3D{X: 1.9, Y: 8, Z: 0})
+	r, g, b, _ = img.At(int(beside.X), int(beside.Y)).RGBA()
+	if b < 0xc000 || r > 0x4000 {
+		t.Errorf("Expected far blue face beside near face, got (%x,%x,%x)", r, g, b)
+	}
+}
+
+// linesAndFacesFigure acrescenta a facesTestFigure uma linha à frente
+// das duas faces e outra atrás delas
+func linesAndFacesFigure() *types.Figure {
+	figure := facesTestFigure()
+	figure.Pontos = append(figure.Pontos,
+		types.Point3D{X: -1.5, Y: 4, Z: 0.5}, types.Point3D{X: 1.5, Y: 4, Z: 0.5}, // À frente
+		types.Point3D{X: -0.5, Y: 9, Z: 0}, types.Point3D{X: 0.5, Y: 9, Z: 0}, // Atrás
+	)
+	figure.Linhas = []types.Line{{P1: 8, P2: 9}, {P1: 10, P2: 11}}
+	return figure
+}
+
+// checkLinesAndFaces confere que a linha da frente aparece sobre as
+// faces e que a de trás fica coberta pela face vermelha
+func checkLinesAndFaces(t *testing.T, hidden HiddenSurface) {
+	t.Helper()
+	renderer := New(200, 150)
+	figure := linesAndFacesFigure()
+	renderer.SetCamera(figure.Camera)
+
+	cfg := DefaultRenderConfig()
+	cfg.HiddenSurface = hidden
+	cfg.LineColor = colorRGB{G: 1, A: 1}
+	cfg.LineWidth = 3
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	front := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 4, Z: 0.5})
+	r, g, b, _ := img.At(int(front.X), int(front.Y)).RGBA()
+	if g < 0xc000 || r > 0x4000 || b > 0x4000 {
+		t.Errorf("%s: expected the near line over the faces, got (%x,%x,%x)", hidden, r, g, b)
+	}
+	r, g, b, _ = img.At(100, 75).RGBA()
+	if r < 0xc000 || g > 0x4000 || b > 0x4000 {
+		t.Errorf("%s: expected the far line covered by the red face, got (%x,%x,%x)", hidden, r, g, b)
+	}
+}
+
+func TestRenderFigure_LinesBetweenFaces(t *testing.T) {
+	checkLinesAndFaces(t, HiddenPainter)
+}
+
+func TestRenderFigure_EdgesFromFaces(t *testing.T) {
+	// As linhas derivadas das faces não mudam o desenho com faces: as
+	// faces já traçam o próprio contorno
+	render := func(figure *types.Figure) image.Image {
+		renderer := New(200, 150)
+		renderer.SetCamera(figure.Camera)
+		if err := renderer.RenderFigureWithConfig(figure, DefaultRenderConfig()); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+		return renderer.GetImage().(image.Image)
+	}
+
+	want := render(facesTestFigure())
+	figure := facesTestFigure()
+	figure.Linhas = figure.FaceEdges()
+	figure.EdgesFromFaces = true
+	got := render(figure)
+
+	for y := 0; y < 150; y++ {
+		for x := 0; x < 200; x++ {
+			if got.At(x, y) != want.At(x, y) {
+				t.Fatalf("Expected the same image, pixel (%d,%d) differs: %v and %v", x, y, got.At(x, y), want.At(x, y))
+			}
+		}
+	}
+}
+
+func TestRenderFigure_FaceColorError(t *testing.T) {
+	renderer := New(200, 150)
+	figure := facesTestFigure()
+	figure.Faces[1].Cor = "cor-que-nao-existe"
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigureWithConfig(figure, DefaultRenderConfig()); err == nil {
+		t.Error("Expected error for invalid face color, got nil")
+	}
+}
+
+func TestFaceNormal(t *testing.T) {
+	points := []types.Point3D{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}}
+
+	// Anti-horária vista de +Z: normal aponta para +Z
+	n := faceNormal(points, types.Face{Pontos: []int{0, 1, 2}})
+	if n.X != 0 || n.Y != 0 || n.Z <= 0 {
+		t.Errorf("Expected normal along +Z, got %+v", n)
+	}
+
+	// Ordem invertida inverte a normal
+	n = faceNormal(points, types.Face{Pontos: []int{2, 1, 0}})
+	if n.Z >= 0 {
+		t.Errorf("Expected normal along -Z, got %+v", n)
+	}
+}
+
+func TestRenderFigure_BackfaceCulling(t *testing.T) {
+	figure := facesTestFigure()
+	// A face distante passa a apontar para longe do observador
+	figure.Faces[1].Pontos = []int{7, 6, 5, 4}
+
+	tests := []struct {
+		name     string
+		culling  bool
+		wantBlue bool
+	}{
+		{"disabled", false, true},
+		{"enabled", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := New(200, 150)
+			renderer.SetCamera(figure.Camera)
+
+			cfg := DefaultRenderConfig()
+			cfg.BackfaceCulling = tt.culling
+			if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+
+			img := renderer.GetImage().(image.Image)
+			p := renderer.ProjectToScreen(types.Point3D{X: 1.9, Y: 8, Z: 0})
+			r, _, b, _ := img.At(int(p.X), int(p.Y)).RGBA()
+			blue := b > 0xc000 && r < 0x4000
+			if blue != tt.wantBlue {
+				t.Errorf("Expected back face drawn=%v, got %v", tt.wantBlue, blue)
+			}
+
+			// A face da frente é sempre desenhada
+			r, g, _, _ := img.At(100, 75).RGBA()
+			if r < 0xc000 || g > 0x4000 {
+				t.Error("Expected front face to be drawn")
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package renderer
+
+import (
+	"image"
+	"math"
+)
+
+// fillBackground pinta o fundo do viewport atual: uma cor lisa ou, com
+// cfg.Gradient, a transição de cfg.Background para cfg.GradientEnd; com
+// cfg.Grid, a grade de referência vem por cima.
+func (r *Renderer3D) fillBackground(cfg RenderConfig) {
+	r.setColor(cfg.Background)
+	r.fillViewport()
+	if cfg.Gradient != GradientNone {
+		r.paintGradient(cfg.Gradient, cfg.Background, cfg.GradientEnd)
+	}
+	if cfg.Grid {
+		r.drawGrid(cfg)
+	}
+}
+
+// backgroundArea retorna a área que o fundo decora (gradiente, grade e
+// carimbo): r.backdrop quando definida, senão o viewport atual.
+func (r *Renderer3D) backgroundArea() Viewport {
+	if r.backdrop != nil {
+		return *r.backdrop
+	}
+	return r.viewport
+}
+
+// paintGradient pinta o viewport atual com um gradiente de duas cores.
+//
+// Cada pixel recebe a mistura linear das cores na posição do seu
+// centro: de cima para baixo (vertical), da esquerda para a direita
+// (horizontal) ou do centro para os cantos (radial). O gradiente se
+// estende pela área r.backdrop quando definida, de modo que uma imagem
+// renderizada em faixas (ver TiledImage) recebe um gradiente contínuo.
+//
+// Os pixels são gravados diretamente na tela interna (com
+// superamostragem, na resolução ampliada), substituindo o que houver, e
+// também na tela de 16 bits, quando ativada (ver SetBitDepth).
+//
+// Parâmetros:
+//   kind: direção do gradiente
+//   start: cor no início (topo, esquerda ou centro)
+//   end: cor no fim (base, direita ou cantos)
+func (r *Renderer3D) paintGradient(kind Gradient, start, end colorRGB) {
+	img, ok := r.context.Image().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	area := r.backgroundArea()
+
+	scale := float64(r.scale)
+	vp := r.viewport
+	bounds := image.Rect(
+		int(math.Round(vp.X*scale)), int(math.Round(vp.Y*scale)),
+		int(math.Round((vp.X+vp.Width)*scale)), int(math.Round((vp.Y+vp.Height)*scale)),
+	).Intersect(img.Bounds())
+
+	// Raio do gradiente radial: do centro até os cantos da área
+	cx, cy := area.X+area.Width/2, area.Y+area.Height/2
+	radius := math.Hypot(area.Width, area.Height) / 2
+
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			// Centro do pixel em pixels da imagem final
+			x := (float64(px) + 0.5) / scale
+			y := (float64(py) + 0.5) / scale
+
+			var t float64
+			switch kind {
+			case GradientHorizontal:
+				t = (x - area.X) / area.Width
+			case GradientRadial:
+				t = math.Hypot(x-cx, y-cy) / radius
+			default:
+				t = (y - area.Y) / area.Height
+			}
+			t = math.Max(0, math.Min(1, t))
+
+			c := start.mix(end, t)
+			img.SetRGBA(px, py, toRGBA(c))
+			if r.deep != nil {
+				r.deep.SetRGBA64(px, py, toRGBA64(c))
+			}
+		}
+	}
+}
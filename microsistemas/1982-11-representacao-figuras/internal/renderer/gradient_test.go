@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestPaintGradient(t *testing.T) {
+	black := colorRGB{A: 1}
+	white := colorRGB{R: 1, G: 1, B: 1, A: 1}
+
+	tests := []struct {
+		name   string
+		kind   Gradient
+		points map[image.Point]uint8 // Pixel → nível de cinza esperado
+	}{
+		{"vertical", GradientVertical, map[image.Point]uint8{{50, 0}: 1, {50, 49}: 128, {0, 99}: 254}},
+		{"horizontal", GradientHorizontal, map[image.Point]uint8{{0, 50}: 1, {49, 0}: 128, {99, 99}: 254}},
+		{"radial", GradientRadial, map[image.Point]uint8{{49, 49}: 3, {0, 0}: 250, {49, 0}: 181}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := New(100, 100)
+			renderer.paintGradient(tt.kind, black, white)
+
+			img := renderer.GetImage().(*image.RGBA)
+			for p, want := range tt.points {
+				got := img.RGBAAt(p.X, p.Y)
+				if diff(uint32(got.R), uint32(want)) > 2 || got.R != got.G || got.G != got.B || got.A != 255 {
+					t.Errorf("Pixel %v: expected gray %d, got %v", p, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderFigureWithConfig_GradientViewport(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.FOV = 0
+	figure.Camera.Width, figure.Camera.Height = 4, 4
+
+	// Tela virtual quadrada numa imagem larga: faixas nas laterais e o
+	// gradiente apenas na área da figura
+	cfg := DefaultRenderConfig()
+	cfg.AspectMode = AspectLetterbox
+	cfg.Background = colorRGB{R: 1, A: 1}
+	cfg.Gradient = GradientVertical
+	cfg.GradientEnd = colorRGB{B: 1, A: 1}
+
+	renderer := New(200, 100)
+	renderer.SetCamera(figure.Camera)
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(*image.RGBA)
+	if got := img.RGBAAt(10, 50); got != (color.RGBA{A: 255}) {
+		t.Errorf("Expected black bar outside the figure area, got %v", got)
+	}
+	if got := img.RGBAAt(60, 0); got.R < 250 || got.B > 5 {
+		t.Errorf("Expected start color at the top, got %v", got)
+	}
+	if got := img.RGBAAt(60, 99); got.B < 250 || got.R > 5 {
+		t.Errorf("Expected end color at the bottom, got %v", got)
+	}
+}
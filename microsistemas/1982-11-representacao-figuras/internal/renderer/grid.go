@@ -0,0 +1,66 @@
+package renderer
+
+import "math"
+
+// DefaultGridSpacing é a distância padrão, em pixels, entre as linhas
+// da grade de referência.
+const DefaultGridSpacing = 50
+
+// drawGrid desenha a grade de referência sobre o fundo do viewport atual.
+//
+// As linhas partem do canto superior esquerdo da área do fundo (ver
+// backgroundArea), de modo que faixas de uma imagem renderizada em
+// partes continuam a mesma grade. Cada linha é alinhada ao centro de uma
+// coluna (ou linha) de pixels, para ficar nítida com 1 pixel de
+// espessura. Com subdivisões, a subgrade é desenhada primeiro, e a grade
+// principal por cima.
+//
+// Parâmetros:
+//   cfg: configurações visuais (espaçamento, subdivisões e cores da grade)
+func (r *Renderer3D) drawGrid(cfg RenderConfig) {
+	spacing := cfg.GridSpacing
+	if spacing <= 0 {
+		spacing = DefaultGridSpacing
+	}
+
+	if n := cfg.GridSubdivisions; n > 1 {
+		r.strokeGridLines(spacing/float64(n), n)
+		r.setColor(cfg.GridMinorColor)
+		r.setLineWidth(1)
+		r.context.Stroke()
+	}
+
+	r.strokeGridLines(spacing, 1)
+	r.setColor(cfg.GridColor)
+	r.setLineWidth(1)
+	r.context.Stroke()
+}
+
+// strokeGridLines acrescenta ao caminho atual as linhas de uma grade.
+//
+// Parâmetros:
+//   spacing: distância entre as linhas em pixels
+//   skip: omite uma linha a cada skip (as que coincidem com a grade
+//         principal, no caso da subgrade); 1 = nenhuma omitida
+func (r *Renderer3D) strokeGridLines(spacing float64, skip int) {
+	area, vp := r.backgroundArea(), r.viewport
+	crisp := func(v float64) float64 { return math.Floor(v) + 0.5 }
+
+	// Primeira linha de cada direção dentro do viewport
+	firstX := int(math.Ceil((vp.X - area.X) / spacing))
+	firstY := int(math.Ceil((vp.Y - area.Y) / spacing))
+	omitted := func(i int) bool { return skip > 1 && ((i%skip)+skip)%skip == 0 }
+
+	for i := firstX; area.X+float64(i)*spacing < vp.X+vp.Width; i++ {
+		if x := crisp(area.X + float64(i)*spacing); !omitted(i) {
+			r.context.MoveTo(x, vp.Y)
+			r.context.LineTo(x, vp.Y+vp.Height)
+		}
+	}
+	for i := firstY; area.Y+float64(i)*spacing < vp.Y+vp.Height; i++ {
+		if y := crisp(area.Y + float64(i)*spacing); !omitted(i) {
+			r.context.MoveTo(vp.X, y)
+			r.context.LineTo(vp.X+vp.Width, y)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDrawGrid(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.Grid = true
+	cfg.GridSpacing = 20
+	cfg.GridColor = colorRGB{A: 1}
+
+	renderer := New(100, 60)
+	renderer.fillBackground(cfg)
+	img := renderer.GetImage().(*image.RGBA)
+
+	for x := 0; x < 100; x++ {
+		want := x%20 == 0
+		if got := img.RGBAAt(x, 5).R < 128; got != want {
+			t.Errorf("Column %d: expected grid line %v, got %v", x, want, got)
+		}
+	}
+	for y := 0; y < 60; y++ {
+		want := y%20 == 0
+		if got := img.RGBAAt(5, y).R < 128; got != want {
+			t.Errorf("Row %d: expected grid line %v, got %v", y, want, got)
+		}
+	}
+}
+
+func TestDrawGrid_Backdrop(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.Grid = true
+	cfg.GridSpacing = 20
+	cfg.GridColor = colorRGB{A: 1}
+
+	// Faixa que começa na linha 30 de uma área maior: a grade continua
+	// a da área, com linhas em 40 e 60 (10 e 30 na faixa)
+	renderer := New(100, 40)
+	renderer.backdrop = &Viewport{X: 0, Y: -30, Width: 100, Height: 200}
+	renderer.fillBackground(cfg)
+	img := renderer.GetImage().(*image.RGBA)
+
+	for y := 0; y < 40; y++ {
+		want := y == 10 || y == 30
+		if got := img.RGBAAt(5, y).R < 128; got != want {
+			t.Errorf("Row %d: expected grid line %v, got %v", y, want, got)
+		}
+	}
+}
+
+func TestDrawGrid_Subdivisions(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.Grid = true
+	cfg.GridSpacing = 20
+	cfg.GridColor = colorRGB{A: 1}
+	cfg.GridSubdivisions = 4
+	cfg.GridMinorColor = colorRGB{R: 1, A: 1}
+
+	renderer := New(100, 60)
+	renderer.fillBackground(cfg)
+	img := renderer.GetImage().(*image.RGBA)
+
+	// Linhas principais pretas a cada 20 pixels, subgrade vermelha a cada 5
+	for x := 0; x < 100; x++ {
+		px := img.RGBAAt(x, 7)
+		major, minor := px.R < 128 && px.G < 128, px.R > 128 && px.G < 128
+		if major != (x%20 == 0) || minor != (x%5 == 0 && x%20 != 0) {
+			t.Errorf("Column %d: expected major %v minor %v, got %+v", x, x%20 == 0, x%5 == 0 && x%20 != 0, px)
+		}
+	}
+}
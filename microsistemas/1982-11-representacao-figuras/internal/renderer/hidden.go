@@ -0,0 +1,251 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// hiddenSampleStep é o espaçamento, em pixels, das amostras usadas para
+// classificar cada aresta em trechos visíveis e escondidos.
+const hiddenSampleStep = 1.5
+
+// occluder é uma face projetada vista como obstáculo para as arestas.
+type occluder struct {
+	screen    []types.Point2D // Vértices em pixels
+	min, max  types.Point2D   // Retângulo envolvente, para descarte rápido
+	plane     depthPlane      // Chave de profundidade na tela (ver depthKey)
+	tolerance float64         // Folga de meio pixel na profundidade
+}
+
+// newOccluders prepara as faces desenhadas para os testes de visibilidade.
+//
+// Faces de perfil (área projetada nula) não escondem nada e são omitidas.
+func (r *Renderer3D) newOccluders(faces []screenFace) []occluder {
+	occluders := make([]occluder, 0, len(faces))
+	for _, face := range faces {
+		xs := make([]float64, len(face.screen))
+		ys := make([]float64, len(face.screen))
+		ks := make([]float64, len(face.screen))
+		o := occluder{screen: face.screen, min: face.screen[0], max: face.screen[0]}
+		for j, p := range face.screen {
+			xs[j], ys[j], ks[j] = p.X, p.Y, r.depthKey(face.depth[j])
+			o.min.X, o.min.Y = math.Min(o.min.X, p.X), math.Min(o.min.Y, p.Y)
+			o.max.X, o.max.Y = math.Max(o.max.X, p.X), math.Max(o.max.Y, p.Y)
+		}
+
+		plane, ok := newDepthPlane(xs, ys, ks)
+		if !ok {
+			continue
+		}
+		o.plane = plane
+		o.tolerance = 0.75 * (math.Abs(plane.dx) + math.Abs(plane.dy))
+		occluders = append(occluders, o)
+	}
+	return occluders
+}
+
+// hides informa se a face esconde o ponto de tela p com chave de
+// profundidade key.
+//
+// O ponto está escondido se cair dentro do polígono da face (regra
+// par-ímpar) e atrás do plano dela além da tolerância; pontos sobre a
+// própria face, ou sobre as arestas que ela compartilha, ficam visíveis.
+func (o occluder) hides(p types.Point2D, key float64) bool {
+	if p.X < o.min.X || p.X > o.max.X || p.Y < o.min.Y || p.Y > o.max.Y {
+		return false
+	}
+
+	inside := false
+	n := len(o.screen)
+	for i := 0; i < n; i++ {
+		a, b := o.screen[i], o.screen[(i+1)%n]
+		if (a.Y <= p.Y) != (b.Y <= p.Y) {
+			x := a.X + (p.Y-a.Y)/(b.Y-a.Y)*(b.X-a.X)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+	}
+	if !inside {
+		return false
+	}
+
+	return o.plane.at(p.X, p.Y)+o.tolerance+1e-9*math.Abs(key) < key
+}
+
+// hiddenIntervals classifica um segmento projetado em trechos visíveis e
+// escondidos pelas faces.
+//
+// O segmento é amostrado a cada hiddenSampleStep pixels; como a chave de
+// profundidade varia linearmente na tela, ela é interpolada entre os
+// extremos sem distorção. Amostras escondidas consecutivas formam um
+// trecho.
+//
+// Parâmetros:
+//   a, b: extremos do segmento em pixels
+//   ka, kb: chaves de profundidade dos extremos (ver depthKey)
+//   occluders: faces que podem esconder o segmento
+//
+// Retorna:
+//   [][2]float64: trechos escondidos, como intervalos do parâmetro t ∈ [0, 1]
+func hiddenIntervals(a, b types.Point2D, ka, kb float64, occluders []occluder) [][2]float64 {
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	samples := int(math.Max(1, math.Ceil(length/hiddenSampleStep)))
+
+	var intervals [][2]float64
+	start := -1.0
+	for s := 0; s < samples; s++ {
+		t := (float64(s) + 0.5) / float64(samples)
+		p := types.Point2D{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}
+		key := ka + t*(kb-ka)
+
+		hidden := false
+		for _, o := range occluders {
+			if o.hides(p, key) {
+				hidden = true
+				break
+			}
+		}
+
+		switch {
+		case hidden && start < 0:
+			start = float64(s) / float64(samples)
+		case !hidden && start >= 0:
+			intervals = append(intervals, [2]float64{start, float64(s) / float64(samples)})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		intervals = append(intervals, [2]float64{start, 1})
+	}
+	return intervals
+}
+
+// figureEdges reúne as arestas da figura, sem repetições: as linhas retas
+// e as arestas das faces (apenas as de contorno e vinco, com EdgesOutline).
+//
+// Parâmetros:
+//   figure: figura a desenhar
+//   cfg: configurações visuais
+//
+// Retorna:
+//   []edgeKey: pares de índices de pontos, cada aresta uma única vez
+func (r *Renderer3D) figureEdges(figure *types.Figure, cfg RenderConfig) []edgeKey {
+	seen := make(map[edgeKey]bool)
+	var edges []edgeKey
+	add := func(a, b int) {
+		if a < 0 || b < 0 || a >= len(figure.Pontos) || b >= len(figure.Pontos) {
+			return // Ignora referências inválidas
+		}
+		key := newEdgeKey(a, b)
+		if !seen[key] {
+			seen[key] = true
+			edges = append(edges, key)
+		}
+	}
+
+	linhas := figure.Linhas
+	if figure.EdgesFromFaces && len(figure.Faces) > 0 {
+		linhas = nil // São as arestas das faces, reunidas abaixo
+	}
+	for _, linha := range linhas {
+		if len(linha.Controle) > 0 {
+			continue // Curvas não são tracejadas (ver drawCurve)
+		}
+		add(linha.P1, linha.P2)
+	}
+
+	faces := make([]types.Face, 0, len(figure.Faces))
+	for _, face := range figure.Faces {
+		valid := len(face.Pontos) >= 3
+		for _, p := range face.Pontos {
+			valid = valid && p >= 0 && p < len(figure.Pontos)
+		}
+		if valid {
+			faces = append(faces, face)
+		}
+	}
+
+	var marks [][]bool
+	if cfg.EdgeMode == EdgesOutline {
+		marks = r.featureEdges(figure.Pontos, faces, cfg.CreaseAngle)
+	}
+	for i, face := range faces {
+		for j, p := range face.Pontos {
+			if marks == nil || marks[i][j] {
+				add(p, face.Pontos[(j+1)%len(face.Pontos)])
+			}
+		}
+	}
+	return edges
+}
+
+// drawHiddenEdges traça, tracejados, os trechos de arestas escondidos
+// pelas faces, como nas convenções do desenho técnico.
+//
+// As faces e os trechos visíveis já foram desenhados normalmente; aqui
+// cada aresta da figura (linhas e arestas das faces) é classificada em
+// trechos visíveis e escondidos (ver hiddenIntervals), e apenas os
+// escondidos são traçados por cima, com a cor cfg.HiddenEdgeColor.
+//
+// Parâmetros:
+//   figure: figura a desenhar
+//   cfg: configurações visuais
+//   proj: pontos da figura já projetados (ver projectBatch)
+//   near: profundidade do plano próximo
+//
+// Retorna:
+//   error: erro se a cor de alguma face for inválida
+func (r *Renderer3D) drawHiddenEdges(figure *types.Figure, cfg RenderConfig, proj projectedPoints, near float64) error {
+	faces, err := r.prepareFaces(figure, cfg, proj, near)
+	if err != nil {
+		return err
+	}
+	occluders := r.newOccluders(faces)
+
+	// Tracejado em pixels da tela interna (ver SetSupersampling)
+	scale := float64(r.scale)
+	width := math.Max(cfg.LineWidth, 1) * scale
+	r.setColor(cfg.HiddenEdgeColor)
+	r.context.SetDash(4*width, 3*width)
+	defer func() {
+		r.context.SetDash()
+		r.context.SetDashOffset(0)
+		r.setColor(cfg.LineColor)
+	}()
+
+	for _, edge := range r.figureEdges(figure, cfg) {
+		// Aresta inteiramente fora do volume de visão
+		if proj.codes[edge.a]&proj.codes[edge.b] != 0 {
+			continue
+		}
+
+		a, b := proj.camera[edge.a], proj.camera[edge.b]
+		if (proj.codes[edge.a]|proj.codes[edge.b])&outsideNear != 0 {
+			if cfg.BehindPolicy == BehindDrop {
+				continue
+			}
+			var ok bool
+			if a, b, ok = clipNear(a, b, near); !ok {
+				continue
+			}
+		}
+
+		pa := r.ViewportTransform(r.projectCameraSpace(a.X, a.Y, a.Z))
+		pb := r.ViewportTransform(r.projectCameraSpace(b.X, b.Y, b.Z))
+		length := math.Hypot(pb.X-pa.X, pb.Y-pa.Y)
+
+		for _, in := range hiddenIntervals(pa, pb, r.depthKey(a.Z), r.depthKey(b.Z), occluders) {
+			// O tracejado continua de um trecho para o outro
+			r.context.SetDashOffset(in[0] * length * scale)
+			r.context.DrawLine(
+				pa.X+in[0]*(pb.X-pa.X), pa.Y+in[0]*(pb.Y-pa.Y),
+				pa.X+in[1]*(pb.X-pa.X), pa.Y+in[1]*(pb.Y-pa.Y),
+			)
+			r.context.Stroke()
+		}
+	}
+
+	return nil
+}
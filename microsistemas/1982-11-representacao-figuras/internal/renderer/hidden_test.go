@@ -0,0 +1,74 @@
+package renderer
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestHiddenIntervals(t *testing.T) {
+	// Quadrado de 100 a 200 pixels, à profundidade (chave) 1
+	square := screenFace{
+		screen: []types.Point2D{{X: 100, Y: 100}, {X: 200, Y: 100}, {X: 200, Y: 200}, {X: 100, Y: 200}},
+		depth:  []float64{1, 1, 1, 1},
+	}
+	renderer := New(300, 300)
+	renderer.SetCamera(types.Camera{Distance: 1, Width: 1, Height: 1, Projection: types.ProjectionOrthographic})
+	occluders := renderer.newOccluders([]screenFace{square})
+
+	a, b := types.Point2D{X: 0, Y: 150}, types.Point2D{X: 300, Y: 150}
+
+	// Segmento atrás do quadrado: escondido no terço central
+	intervals := hiddenIntervals(a, b, 2, 2, occluders)
+	if len(intervals) != 1 {
+		t.Fatalf("Expected one hidden interval, got %v", intervals)
+	}
+	if math.Abs(intervals[0][0]-1.0/3) > 0.01 || math.Abs(intervals[0][1]-2.0/3) > 0.01 {
+		t.Errorf("Expected hidden interval [1/3, 2/3], got %v", intervals[0])
+	}
+
+	// Segmento à frente do quadrado, ou sobre ele: sempre visível
+	for _, key := range []float64{0.5, 1} {
+		if got := hiddenIntervals(a, b, key, key, occluders); len(got) != 0 {
+			t.Errorf("Expected segment at depth %g to be visible, got %v", key, got)
+		}
+	}
+}
+
+func TestRenderFigure_DashedHiddenEdges(t *testing.T) {
+	figure := facesTestFigure()
+	// Só a face próxima, com uma linha horizontal atrás dela
+	figure.Faces = figure.Faces[:1]
+	figure.Pontos = append(figure.Pontos, types.Point3D{X: -3, Y: 8, Z: 0}, types.Point3D{X: 3, Y: 8, Z: 0})
+	figure.Linhas = []types.Line{{P1: 8, P2: 9}}
+
+	for _, style := range []HiddenEdges{HiddenEdgesRemove, HiddenEdgesDashed} {
+		renderer := New(200, 150)
+		renderer.SetCamera(figure.Camera)
+
+		cfg := DefaultRenderConfig()
+		cfg.HiddenEdges = style
+		cfg.HiddenEdgeColor = colorRGB{R: 0, G: 1, B: 0, A: 1}
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		// Procura o verde das arestas ocultas sobre a face vermelha
+		img := renderer.GetImage().(image.Image)
+		left := renderer.ProjectToScreen(types.Point3D{X: -0.8, Y: 5, Z: 0})
+		right := renderer.ProjectToScreen(types.Point3D{X: 0.8, Y: 5, Z: 0})
+		green := false
+		for x := int(left.X); x <= int(right.X); x++ {
+			_, g, _, _ := img.At(x, int(left.Y)).RGBA()
+			if g > 0x8000 {
+				green = true
+			}
+		}
+
+		if green != (style == HiddenEdgesDashed) {
+			t.Errorf("%s: expected hidden edge drawn=%v, got %v", style, style == HiddenEdgesDashed, green)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// htmlFigure são os dados da figura embutidos na página (ver RenderHTML),
+// lidos pelo JavaScript como um objeto literal.
+type htmlFigure struct {
+	Points   [][3]float64 `json:"pontos"`   // Pontos da figura, seguidos dos internos das curvas
+	Edges    [][2]int     `json:"arestas"`  // Pares de índices em pontos
+	Vertices int          `json:"vertices"` // Pontos desenhados como vértices (0 = nenhum)
+	Labels   []string     `json:"nomes"`    // Nomes dos pontos (vazio = sem nomes)
+	Camera   htmlCamera   `json:"camera"`
+	Style    htmlStyle    `json:"estilo"`
+}
+
+// htmlCamera é a câmera da página: o observador gira em volta do alvo.
+type htmlCamera struct {
+	Observer      [3]float64 `json:"observador"`
+	Target        [3]float64 `json:"alvo"`
+	Distance      float64    `json:"distancia"` // R
+	Width         float64    `json:"largura"`   // L1
+	Height        float64    `json:"altura"`    // L2
+	Near          float64    `json:"proximo"`   // Plano próximo da projeção cônica
+	Projection    string     `json:"projecao"`
+	ObliqueAngle  float64    `json:"angulo_obliquo"`
+	ObliqueFactor float64    `json:"fator_obliquo"`
+	ObliqueTarget bool       `json:"profundidade_do_alvo"` // A profundidade oblíqua é medida a partir do alvo
+}
+
+// htmlStyle são as cores e o traço da página, já em CSS.
+type htmlStyle struct {
+	Background string  `json:"fundo"` // Vazio = transparente
+	Line       string  `json:"linha"`
+	Vertex     string  `json:"vertice"`
+	LineWidth  float64 `json:"espessura"`
+	LineCap    string  `json:"pontas"`
+	LineJoin   string  `json:"juncoes"`
+	FontSize   float64 `json:"fonte"`
+}
+
+// RenderHTML monta uma página HTML autossuficiente com a figura: os
+// dados e um pequeno JavaScript que refaz a projeção cônica do artigo
+// num canvas, para que a figura possa ser girada com o mouse (ou o dedo)
+// em qualquer navegador, sem instalar nada.
+//
+// A página abre com a câmera da figura, nas dimensões do renderizador;
+// arrastar gira o observador em volta do alvo (o centro da figura, se a
+// câmera não tem alvo), a roda aproxima e afasta, e o clique duplo volta
+// à vista original. O JavaScript repete as contas do renderizador: a
+// base do observador (ver cameraBasis), as fórmulas x = Px·R/Pz e
+// y = Py·R/Pz, a tela virtual L1×L2 esticada sobre o canvas e o recorte
+// no plano próximo; as projeções ortogonal e oblíqua também valem. As
+// arestas são as linhas e as arestas das faces que não repetem uma linha
+// reta, como no DXF, com as curvas em trechos retos. Cores, espessura,
+// pontas, junções, vértices e nomes vêm da configuração; faces, setas,
+// cotas, estilos de linha e os demais acréscimos gráficos ficam de fora.
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (cores, traço, vértices, nomes, proporção
+//        e plano próximo)
+//
+// Retorna:
+//   []byte: página HTML
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderHTML(figure *types.Figure, cfg RenderConfig) ([]byte, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	data := htmlFigure{Edges: [][2]int{}, Labels: []string{}} // [] em vez de null
+	for _, p := range figure.Pontos {
+		data.Points = append(data.Points, [3]float64{p.X, p.Y, p.Z})
+	}
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			if len(linha.Controle) == 0 {
+				data.Edges = append(data.Edges, [2]int{linha.P1, linha.P2})
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+				continue
+			}
+			curve := linha.Curve(figure.Pontos)
+			last := linha.P1
+			for i, p := range curve[1:] {
+				next := linha.P2
+				if i < len(curve)-2 {
+					next = len(data.Points)
+					data.Points = append(data.Points, [3]float64{p.X, p.Y, p.Z})
+				}
+				data.Edges = append(data.Edges, [2]int{last, next})
+				last = next
+			}
+		}
+	}
+	for _, e := range figure.FaceEdges() {
+		if !drawn[[2]int{min(e.P1, e.P2), max(e.P1, e.P2)}] {
+			data.Edges = append(data.Edges, [2]int{e.P1, e.P2})
+		}
+	}
+	if cfg.ShowVertices {
+		data.Vertices = len(figure.Pontos)
+	}
+	if cfg.ShowLabels {
+		for _, p := range figure.Pontos {
+			data.Labels = append(data.Labels, p.Nome)
+		}
+	}
+
+	// Tela virtual, com a proporção do canvas quando não é esticada
+	width, height := r.camera.Width, r.camera.Height
+	switch cfg.AspectMode {
+	case AspectAdjust:
+		if r.camera.FOV <= 0 {
+			height = width / r.aspect()
+		}
+	case AspectLetterbox:
+		if width/height > r.aspect() {
+			height = width / r.aspect()
+		} else {
+			width = height * r.aspect()
+		}
+	}
+
+	// Sem alvo, o observador gira em volta do ponto à sua frente na
+	// profundidade do centro da figura: a base é a mesma
+	observer := toVec3(r.camera.Observer)
+	var target vec3
+	if r.camera.Target != nil && toVec3(*r.camera.Target) != observer {
+		target = toVec3(*r.camera.Target)
+	} else {
+		low, high := figure.Bounds()
+		center := vec3{X: (low.X + high.X) / 2, Y: (low.Y + high.Y) / 2, Z: (low.Z + high.Z) / 2}
+		depth := center.sub(observer).dot(r.basis.forward)
+		if depth <= 0 {
+			depth = r.camera.Distance
+		}
+		target = observer.add(r.basis.forward.scale(depth))
+	}
+	near := cfg.NearPlane
+	if near <= 0 {
+		near = DefaultNearPlane
+	}
+	data.Camera = htmlCamera{
+		Observer:      [3]float64{observer.X, observer.Y, observer.Z},
+		Target:        [3]float64{target.X, target.Y, target.Z},
+		Distance:      r.camera.Distance,
+		Width:         width,
+		Height:        height,
+		Near:          near,
+		Projection:    r.camera.Projection,
+		ObliqueAngle:  obliqueAngle(r.camera),
+		ObliqueFactor: obliqueFactor(r.camera),
+		ObliqueTarget: r.camera.Target != nil,
+	}
+	if data.Camera.Projection == "" {
+		data.Camera.Projection = types.ProjectionConic
+	}
+
+	caps := map[LineCap]string{CapButt: "butt", CapRound: "round", CapSquare: "square"}
+	joins := map[LineJoin]string{JoinRound: "round", JoinBevel: "bevel"}
+	data.Style = htmlStyle{
+		Line:      cssColor(cfg.LineColor),
+		Vertex:    cssColor(cfg.VertexColor),
+		LineWidth: cfg.LineWidth,
+		LineCap:   caps[cfg.LineCap],
+		LineJoin:  joins[cfg.LineJoin],
+		FontSize:  cfg.FontSize,
+	}
+	if cfg.Background.A > 0 {
+		data.Style.Background = cssColor(cfg.Background)
+	}
+	if data.Style.FontSize == 0 {
+		data.Style.FontSize = DefaultFontSize
+	}
+
+	title := figure.Nome
+	if title == "" {
+		title = "figura"
+	}
+	var buf bytes.Buffer
+	err := htmlPage.Execute(&buf, struct {
+		Title         string
+		Width, Height int
+		Figure        htmlFigure
+	}{title, r.width, r.height, data})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar página: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// cssColor escreve uma cor no formato rgba() do CSS.
+func cssColor(c colorRGB) string {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("rgba(%d, %d, %d, %g)", channel(c.R), channel(c.G), channel(c.B), math.Max(0, math.Min(1, c.A)))
+}
+
+// htmlPage é o modelo da página de RenderHTML. Os dados da figura entram
+// como objeto literal do JavaScript, que o html/template escapa.
+var htmlPage = template.Must(template.New("figura").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="figuras3d">
+<title>{{.Title}}</title>
+<style>
+  body { margin: 0; min-height: 100vh; display: flex; flex-direction: column;
+         align-items: center; justify-content: center; background: #444;
+         color: #ddd; font: 14px sans-serif; }
+  canvas { cursor: grab; touch-action: none; }
+  canvas:active { cursor: grabbing; }
+  p { margin: 0.6em; }
+</style>
+</head>
+<body>
+<canvas id="figura" width="{{.Width}}" height="{{.Height}}"></canvas>
+<p>Arraste para girar, use a roda para aproximar e clique duas vezes para voltar à vista original.</p>
+<script>
+"use strict";
+const figura = {{.Figure}};
+
+const canvas = document.getElementById("figura");
+const ctx = canvas.getContext("2d");
+const cam = figura.camera, estilo = figura.estilo;
+const W = canvas.width, H = canvas.height;
+
+const sub = (a, b) => [a[0] - b[0], a[1] - b[1], a[2] - b[2]];
+const dot = (a, b) => a[0] * b[0] + a[1] * b[1] + a[2] * b[2];
+const cross = (a, b) => [a[1] * b[2] - a[2] * b[1], a[2] * b[0] - a[0] * b[2], a[0] * b[1] - a[1] * b[0]];
+const normalize = (a) => { const l = Math.hypot(a[0], a[1], a[2]); return l ? [a[0] / l, a[1] / l, a[2] / l] : a; };
+
+// Observador em coordenadas esféricas em volta do alvo, como a órbita
+// das figuras: azimute 0 em -Y, elevação positiva acima do alvo
+let azimute, elevacao, raio, escala;
+function inicio() {
+  const d = sub(cam.observador, cam.alvo);
+  raio = Math.hypot(d[0], d[1], d[2]);
+  elevacao = Math.asin(Math.max(-1, Math.min(1, d[2] / raio)));
+  azimute = Math.atan2(d[0], -d[1]);
+  escala = 1;
+}
+
+function observador() {
+  const a = cam.alvo;
+  return [a[0] + raio * Math.cos(elevacao) * Math.sin(azimute),
+          a[1] - raio * Math.cos(elevacao) * Math.cos(azimute),
+          a[2] + raio * Math.sin(elevacao)];
+}
+
+// Base do observador: frente para o alvo, direita nivelada com o
+// horizonte e cima perpendicular às duas
+function base(obs) {
+  const frente = normalize(sub(cam.alvo, obs));
+  let direita = cross(frente, [0, 0, 1]);
+  if (Math.hypot(direita[0], direita[1], direita[2]) < 1e-9) {
+    direita = cross(frente, [0, 1, 0]); // Olhando na vertical
+  }
+  direita = normalize(direita);
+  return { direita, cima: cross(direita, frente), frente };
+}
+
+function desenha() {
+  const obs = observador();
+  const b = base(obs);
+  const profundidadeAlvo = cam.profundidade_do_alvo ? dot(sub(cam.alvo, obs), b.frente) : 0;
+  const angulo = cam.angulo_obliquo * Math.PI / 180;
+  const L1 = cam.largura * escala, L2 = cam.altura * escala;
+
+  // P' = P - V na base do observador
+  const p = figura.pontos.map((q) => { const d = sub(q, obs); return [dot(d, b.direita), dot(d, b.cima), dot(d, b.frente)]; });
+
+  // Projeção e conversão para pixels (y da tela cresce para baixo)
+  function tela(q) {
+    let x, y;
+    if (cam.projecao === "ortogonal") {
+      x = q[0]; y = q[1];
+    } else if (cam.projecao === "obliqua") {
+      const d = q[2] - profundidadeAlvo;
+      x = q[0] + cam.fator_obliquo * d * Math.cos(angulo);
+      y = q[1] + cam.fator_obliquo * d * Math.sin(angulo);
+    } else {
+      x = q[0] * cam.distancia / q[2];
+      y = q[1] * cam.distancia / q[2];
+    }
+    return [W / 2 + x / L1 * W, H / 2 - y / L2 * H];
+  }
+  const conica = cam.projecao !== "ortogonal" && cam.projecao !== "obliqua";
+  const visivel = (q) => !conica || q[2] >= cam.proximo;
+
+  ctx.clearRect(0, 0, W, H);
+  if (estilo.fundo) {
+    ctx.fillStyle = estilo.fundo;
+    ctx.fillRect(0, 0, W, H);
+  }
+
+  ctx.strokeStyle = estilo.linha;
+  ctx.lineWidth = estilo.espessura;
+  ctx.lineCap = estilo.pontas;
+  ctx.lineJoin = estilo.juncoes;
+  ctx.beginPath();
+  for (const [i, j] of figura.arestas) {
+    let a = p[i], c = p[j];
+    if (conica) {
+      // Recorte no plano próximo: o trecho atrás dele some
+      if (a[2] < cam.proximo && c[2] < cam.proximo) continue;
+      if (a[2] < cam.proximo || c[2] < cam.proximo) {
+        const t = (cam.proximo - a[2]) / (c[2] - a[2]);
+        const m = [a[0] + t * (c[0] - a[0]), a[1] + t * (c[1] - a[1]), cam.proximo];
+        if (a[2] < cam.proximo) a = m; else c = m;
+      }
+    }
+    const s = tela(a), e = tela(c);
+    ctx.moveTo(s[0], s[1]);
+    ctx.lineTo(e[0], e[1]);
+  }
+  ctx.stroke();
+
+  ctx.fillStyle = estilo.vertice;
+  for (let i = 0; i < figura.vertices; i++) {
+    if (!visivel(p[i])) continue;
+    const s = tela(p[i]);
+    ctx.beginPath();
+    ctx.arc(s[0], s[1], 2, 0, 2 * Math.PI);
+    ctx.fill();
+  }
+
+  ctx.fillStyle = estilo.linha;
+  ctx.font = estilo.fonte + "px sans-serif";
+  figura.nomes.forEach((nome, i) => {
+    if (!nome || !visivel(p[i])) return;
+    const s = tela(p[i]);
+    ctx.fillText(nome, s[0] + 5, s[1] - 5);
+  });
+}
+
+// Arrastar gira o observador; a elevação para antes dos polos
+let arrasto = null;
+canvas.addEventListener("pointerdown", (e) => {
+  arrasto = [e.clientX, e.clientY];
+  canvas.setPointerCapture(e.pointerId);
+});
+canvas.addEventListener("pointermove", (e) => {
+  if (!arrasto) return;
+  azimute -= (e.clientX - arrasto[0]) * 0.01;
+  elevacao = Math.max(-1.55, Math.min(1.55, elevacao + (e.clientY - arrasto[1]) * 0.01));
+  arrasto = [e.clientX, e.clientY];
+  desenha();
+});
+canvas.addEventListener("pointerup", () => { arrasto = null; });
+canvas.addEventListener("pointercancel", () => { arrasto = null; });
+
+// A roda afasta o observador na projeção cônica e amplia a tela
+// virtual nas paralelas, em que a distância não muda o tamanho
+canvas.addEventListener("wheel", (e) => {
+  e.preventDefault();
+  const k = Math.exp(e.deltaY * 0.001);
+  if (cam.projecao === "ortogonal" || cam.projecao === "obliqua") escala *= k; else raio *= k;
+  desenha();
+}, { passive: false });
+canvas.addEventListener("dblclick", () => { inicio(); desenha(); });
+
+inicio();
+desenha();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,77 @@
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRenderHTML(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "casa </script>",
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1, Nome: "Ação"}, {X: 1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: 1}, {X: 3, Y: 5},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1},
+			{P1: 1, P2: 3, Controle: []types.Point3D{{X: 3, Y: 5, Z: -1}}},
+		},
+		Faces:  []types.Face{{Pontos: []int{0, 1, 2}}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	r := New(400, 300)
+	r.SetCamera(figure.Camera)
+	cfg := DefaultRenderConfig()
+	cfg.ShowLabels = true
+	page, err := r.RenderHTML(figure, cfg)
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+	text := string(page)
+
+	for _, want := range []string{
+		"<title>casa &lt;/script&gt;</title>",
+		`<canvas id="figura" width="400" height="300">`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in page", want)
+		}
+	}
+	if n := strings.Count(text, "</script>"); n != 1 {
+		t.Errorf("Expected the figure name escaped inside the script, got %d </script>", n)
+	}
+
+	// Os dados da figura são JSON válido
+	_, rest, _ := strings.Cut(text, "const figura = ")
+	literal, _, _ := strings.Cut(rest, ";\n")
+	var data htmlFigure
+	if err := json.Unmarshal([]byte(literal), &data); err != nil {
+		t.Fatalf("Invalid figure data %q: %v", literal, err)
+	}
+
+	// A linha reta, a curva, e as duas arestas da face que não repetem 0-1
+	if want := 1 + types.CurveSegments + 2; len(data.Edges) != want {
+		t.Errorf("Expected %d edges, got %d", want, len(data.Edges))
+	}
+	if want := len(figure.Pontos) + types.CurveSegments - 1; len(data.Points) != want {
+		t.Errorf("Expected %d points with the curve's inner points, got %d", want, len(data.Points))
+	}
+	if data.Vertices != 0 || len(data.Labels) != len(figure.Pontos) || data.Labels[0] != "Ação" {
+		t.Errorf("Expected labels and no vertices, got %d vertices and labels %q", data.Vertices, data.Labels)
+	}
+	// A câmera automática já vem enquadrada, com o alvo no centro
+	if data.Camera.Projection != types.ProjectionConic || data.Camera.Target != [3]float64{1, 5, 0} {
+		t.Errorf("Expected fitted conic camera, got %+v", data.Camera)
+	}
+	if data.Style.Background != "rgba(255, 255, 255, 1)" || data.Style.LineCap != "round" {
+		t.Errorf("Unexpected style %+v", data.Style)
+	}
+
+	if _, err := New(400, 300).RenderHTML(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
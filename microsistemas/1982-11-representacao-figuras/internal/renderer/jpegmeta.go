@@ -0,0 +1,196 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"math"
+)
+
+// jpegSOIEnd é a posição, no arquivo JPEG, do fim do marcador SOI, o
+// primeiro do arquivo. O codificador da biblioteca padrão não grava
+// segmentos APPn; o perfil de cor entra logo depois do SOI, antes das
+// tabelas e dos dados da imagem.
+const jpegSOIEnd = 2
+
+// Cores do perfil sRGB IEC 61966-2.1 em XYZ: o ponto branco D65 e as
+// primárias adaptadas ao branco D50 do espaço de conexão do ICC, com os
+// valores do perfil original da HP e da Microsoft.
+var (
+	iccSRGBWhite = [3]float64{0.9505, 1.0, 1.0891}
+	iccSRGBRed   = [3]float64{0.4361, 0.2225, 0.0139}
+	iccSRGBGreen = [3]float64{0.3851, 0.7169, 0.0971}
+	iccSRGBBlue  = [3]float64{0.1431, 0.0606, 0.7141}
+	iccD50       = [3]float64{0.9642, 1.0, 0.8249}
+)
+
+// jpegICCSegment é o segmento APP2 com o perfil sRGB, montado uma vez.
+var jpegICCSegment = buildJPEGICCSegment()
+
+// EncodeJPEG codifica a imagem em JPEG marcada como sRGB.
+//
+// Como no PNG (ver EncodePNG), a saída do codificador da biblioteca
+// padrão passa por um escritor que insere o perfil de cor ICC do sRGB
+// num segmento APP2, sem guardar a imagem codificada em memória. O JPEG
+// não tem transparência: as áreas transparentes, como o fundo
+// "transparent", são misturadas ao branco antes da codificação, em vez
+// de saírem pretas.
+//
+// Parâmetros:
+//   w: destino do arquivo JPEG
+//   img: imagem a codificar
+//   quality: qualidade, de 1 a 100
+//
+// Retorna:
+//   error: erro de codificação ou de E/S
+func EncodeJPEG(w io.Writer, img image.Image, quality int) error {
+	cw := &chunkWriter{w: w, at: jpegSOIEnd, chunks: jpegICCSegment}
+	return jpeg.Encode(cw, flattenOnWhite(img), &jpeg.Options{Quality: quality})
+}
+
+// flattenOnWhite mistura as áreas transparentes da imagem ao branco.
+// Imagens opacas voltam sem alteração; uma *image.RGBA é copiada, para
+// manter o caminho rápido do codificador, e as demais (como TiledImage)
+// são misturadas pixel a pixel, à medida que são lidas.
+func flattenOnWhite(img image.Image) image.Image {
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+	if rgba, ok := img.(*image.RGBA); ok {
+		b := rgba.Bounds()
+		out := image.NewRGBA(b)
+		draw.Draw(out, b, image.White, image.Point{}, draw.Src)
+		draw.Draw(out, b, rgba, b.Min, draw.Over)
+		return out
+	}
+	return whiteMatte{img}
+}
+
+// whiteMatte é uma imagem vista sobre um fundo branco.
+type whiteMatte struct {
+	image.Image
+}
+
+// ColorModel implementa image.Image.
+func (m whiteMatte) ColorModel() color.Model {
+	return color.RGBA64Model
+}
+
+// At implementa image.Image: as cores são pré-multiplicadas pelo alfa,
+// e o que falta para a opacidade vem do branco.
+func (m whiteMatte) At(x, y int) color.Color {
+	r, g, b, a := m.Image.At(x, y).RGBA()
+	back := 0xffff - a
+	return color.RGBA64{R: uint16(r + back), G: uint16(g + back), B: uint16(b + back), A: 0xffff}
+}
+
+// buildJPEGICCSegment codifica o segmento APP2 do JPEG com o perfil sRGB:
+// marcador, tamanho, a assinatura "ICC_PROFILE" e o número da parte (o
+// perfil cabe numa só).
+func buildJPEGICCSegment() []byte {
+	profile := srgbICCProfile()
+	data := append([]byte("ICC_PROFILE\x00"), 1, 1) // Parte 1 de 1
+	data = append(data, profile...)
+
+	segment := []byte{0xff, 0xe2}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(data)+2))
+	return append(segment, data...)
+}
+
+// srgbICCProfile monta um perfil ICC versão 2 do sRGB para monitores:
+// descrição, direitos, ponto branco, as três primárias e a curva de
+// transferência do sRGB, tabelada e compartilhada pelos três canais.
+func srgbICCProfile() []byte {
+	curve := iccType("curv")
+	const points = 1024
+	curve = binary.BigEndian.AppendUint32(curve, points)
+	for i := 0; i < points; i++ {
+		v := float64(i) / (points - 1)
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		curve = binary.BigEndian.AppendUint16(curve, uint16(math.Round(v*0xffff)))
+	}
+
+	tags := []struct {
+		sig  string
+		data []byte
+	}{
+		{"desc", iccDescription("sRGB IEC61966-2.1")},
+		{"cprt", append(append(iccType("text"), "No copyright, use freely"...), 0)},
+		{"wtpt", iccXYZ(iccSRGBWhite)},
+		{"rXYZ", iccXYZ(iccSRGBRed)},
+		{"gXYZ", iccXYZ(iccSRGBGreen)},
+		{"bXYZ", iccXYZ(iccSRGBBlue)},
+		{"rTRC", curve},
+		{"gTRC", curve},
+		{"bTRC", curve},
+	}
+
+	// Tabela de etiquetas e dados, cada um alinhado a 4 bytes; etiquetas
+	// iguais, como as curvas, apontam para os mesmos dados
+	var table, body bytes.Buffer
+	table.Write(binary.BigEndian.AppendUint32(nil, uint32(len(tags))))
+	start := 128 + 4 + 12*len(tags)
+	offsets := map[string]uint32{}
+	for _, tag := range tags {
+		offset, ok := offsets[string(tag.data)]
+		if !ok {
+			offset = uint32(start + body.Len())
+			offsets[string(tag.data)] = offset
+			body.Write(tag.data)
+			for body.Len()%4 != 0 {
+				body.WriteByte(0)
+			}
+		}
+		table.WriteString(tag.sig)
+		table.Write(binary.BigEndian.AppendUint32(nil, offset))
+		table.Write(binary.BigEndian.AppendUint32(nil, uint32(len(tag.data))))
+	}
+
+	size := start + body.Len()
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(size))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // Versão 2.1
+	copy(header[12:], "mntr")                         // Monitor
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	for i, v := range []uint16{1982, 11, 1} { // Data fixa, para arquivos reproduzíveis
+		binary.BigEndian.PutUint16(header[24+2*i:], v)
+	}
+	copy(header[36:], "acsp")
+	copy(header[68:], iccXYZ(iccD50)[8:]) // Iluminante do espaço de conexão
+
+	profile := append(header, table.Bytes()...)
+	return append(profile, body.Bytes()...)
+}
+
+// iccType inicia os dados de uma etiqueta ICC: o tipo e 4 bytes reservados.
+func iccType(kind string) []byte {
+	return append([]byte(kind), 0, 0, 0, 0)
+}
+
+// iccXYZ codifica uma cor XYZ, com cada componente em ponto fixo s15.16.
+func iccXYZ(c [3]float64) []byte {
+	data := iccType("XYZ ")
+	for _, v := range c {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(math.Round(v*65536))))
+	}
+	return data
+}
+
+// iccDescription codifica a descrição do perfil no tipo textDescription
+// da versão 2: o texto ASCII e as versões Unicode e ScriptCode, vazias.
+func iccDescription(text string) []byte {
+	data := iccType("desc")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(text)+1))
+	data = append(append(data, text...), 0)
+	data = append(data, make([]byte, 4+4+2+1+67)...) // Unicode e ScriptCode
+	return data
+}
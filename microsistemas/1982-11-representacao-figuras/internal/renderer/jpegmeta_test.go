@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// readJPEGICC devolve o perfil ICC do primeiro segmento APP2 de um
+// arquivo JPEG, conferindo que ele vem logo após o SOI.
+func readJPEGICC(t *testing.T, data []byte) []byte {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff, 0xe2}) {
+		t.Fatalf("Expected APP2 segment right after SOI, got % x", data[:4])
+	}
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	body := data[6 : 4+n]
+	if !bytes.HasPrefix(body, []byte("ICC_PROFILE\x00\x01\x01")) {
+		t.Fatalf("Expected single-part ICC_PROFILE segment, got %q", body[:14])
+	}
+	return body[14:]
+}
+
+func TestEncodeJPEG_SRGBProfile(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeJPEG(&buf, img, 90); err != nil {
+		t.Fatalf("EncodeJPEG failed: %v", err)
+	}
+	profile := readJPEGICC(t, buf.Bytes())
+
+	if size := binary.BigEndian.Uint32(profile); int(size) != len(profile) {
+		t.Errorf("Expected profile size %d in header, got %d", len(profile), size)
+	}
+	if string(profile[12:24]) != "mntrRGB XYZ " || string(profile[36:40]) != "acsp" {
+		t.Errorf("Expected RGB monitor profile, got %q / %q", profile[12:24], profile[36:40])
+	}
+
+	// Todas as etiquetas dentro do perfil, e as curvas do sRGB indo de 0
+	// a 1 com o valor médio (50% → 21,4%) da função de transferência
+	tags := map[string][]byte{}
+	count := int(binary.BigEndian.Uint32(profile[128:]))
+	for i := 0; i < count; i++ {
+		entry := profile[132+12*i:]
+		offset, size := binary.BigEndian.Uint32(entry[4:]), binary.BigEndian.Uint32(entry[8:])
+		if offset%4 != 0 || int(offset+size) > len(profile) {
+			t.Fatalf("Tag %s out of bounds: offset %d, size %d", entry[:4], offset, size)
+		}
+		tags[string(entry[:4])] = profile[offset : offset+size]
+	}
+	for _, sig := range []string{"desc", "cprt", "wtpt", "rXYZ", "gXYZ", "bXYZ", "rTRC", "gTRC", "bTRC"} {
+		if tags[sig] == nil {
+			t.Errorf("Expected %s tag", sig)
+		}
+	}
+	curve := tags["rTRC"]
+	points := int(binary.BigEndian.Uint32(curve[8:]))
+	at := func(i int) uint16 { return binary.BigEndian.Uint16(curve[12+2*i:]) }
+	if at(0) != 0 || at(points-1) != 0xffff {
+		t.Errorf("Expected curve from 0 to 65535, got %d to %d", at(0), at(points-1))
+	}
+	if mid := float64(at(points/2)) / 0xffff; mid < 0.21 || mid > 0.22 {
+		t.Errorf("Expected sRGB curve near 0.214 at the middle, got %.3f", mid)
+	}
+
+	// O perfil não altera as cores gravadas
+	decoded, err := jpeg.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Encoded JPEG does not decode: %v", err)
+	}
+	if r, _, _, _ := decoded.At(8, 8).RGBA(); r>>8 < 250 {
+		t.Errorf("Expected white pixel, got red %d", r>>8)
+	}
+}
+
+func TestEncodeJPEG_TransparentBackground(t *testing.T) {
+	// Fundo transparente com um quadrado vermelho opaco no meio
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for y := 8; y < 24; y++ {
+		for x := 8; x < 24; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	for name, source := range map[string]image.Image{
+		"rgba":  img,
+		"other": struct{ image.Image }{img}, // Sem o caminho rápido de *image.RGBA
+	} {
+		var buf bytes.Buffer
+		if err := EncodeJPEG(&buf, source, 95); err != nil {
+			t.Fatalf("%s: EncodeJPEG failed: %v", name, err)
+		}
+		decoded, err := jpeg.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: encoded JPEG does not decode: %v", name, err)
+		}
+		if r, g, b, _ := decoded.At(2, 2).RGBA(); r>>8 < 245 || g>>8 < 245 || b>>8 < 245 {
+			t.Errorf("%s: expected transparent background to become white, got (%d, %d, %d)", name, r>>8, g>>8, b>>8)
+		}
+		if r, g, _, _ := decoded.At(16, 16).RGBA(); r>>8 < 200 || g>>8 > 60 {
+			t.Errorf("%s: expected opaque red to be kept, got red %d, green %d", name, r>>8, g>>8)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Quadros da animação da câmera (ver KeyframeCameras).
+const (
+	DefaultAnimationFPS = 25   // Quadros por segundo padrão, os do vídeo PAL
+	MaxAnimationFrames  = 3000 // Maior número de quadros aceito (2 minutos a 25 quadros/s)
+)
+
+// AnimationFPS retorna o número de quadros por segundo da animação: o
+// da seção "animacao" ou, se não informado, DefaultAnimationFPS.
+func AnimationFPS(animation *types.Animation) float64 {
+	if animation.FPS > 0 {
+		return animation.FPS
+	}
+	return DefaultAnimationFPS
+}
+
+// ParseEasing converte o nome de uma curva de suavização ("linear",
+// "in", "out", "in-out", "cubic" ou "hold", sem distinção de
+// maiúsculas).
+//
+// Parâmetros:
+//   value: nome da curva
+//
+// Retorna:
+//   string: curva correspondente (uma das constantes types.Easing*)
+//   error: erro se o nome for desconhecido
+func ParseEasing(value string) (string, error) {
+	switch e := strings.ToLower(strings.TrimSpace(value)); e {
+	case types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut, types.EasingCubic, types.EasingHold:
+		return e, nil
+	}
+	return "", fmt.Errorf("suavização desconhecida: %q (use %s, %s, %s, %s, %s ou %s)", value,
+		types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut, types.EasingCubic, types.EasingHold)
+}
+
+// Ease aplica uma curva de suavização à fração do tempo decorrido.
+// As curvas in, out e in-out são quadráticas: a de entrada parte com
+// velocidade zero, a de saída chega com velocidade zero, e a de entrada
+// e saída junta as duas, com a velocidade máxima no meio. A cúbica é
+// como in-out, com partida e chegada mais lentas e o meio mais rápido,
+// e hold fica parada até o fim.
+//
+// Parâmetros:
+//   easing: curva (types.Easing*; "" = linear)
+//   u: fração do tempo, de 0 a 1
+//
+// Retorna:
+//   float64: fração do caminho percorrido, de 0 a 1
+func Ease(easing string, u float64) float64 {
+	switch easing {
+	case types.EasingIn:
+		return u * u
+	case types.EasingOut:
+		return 1 - (1-u)*(1-u)
+	case types.EasingInOut:
+		if u < 0.5 {
+			return 2 * u * u
+		}
+		return 1 - 2*(1-u)*(1-u)
+	case types.EasingCubic:
+		if u < 0.5 {
+			return 4 * u * u * u
+		}
+		return 1 - 4*(1-u)*(1-u)*(1-u)
+	case types.EasingHold:
+		if u < 1 {
+			return 0
+		}
+		return 1
+	}
+	return u
+}
+
+// KeyframeCameras gera a câmera de cada quadro de uma animação, do
+// primeiro ao último quadro-chave, a intervalos de 1/AnimationFPS
+// segundos ou, se o número de quadros for informado, a intervalos
+// iguais que o distribuem por toda a duração. Com suavização, o
+// instante de cada quadro passa pela curva (ver Ease): os quadros se
+// adensam onde a câmera anda devagar. A suavização da animação vale
+// para a duração inteira, e a de cada quadro-chave, para o trecho até
+// o seguinte; as duas podem ser combinadas.
+//
+// Observador e alvo seguem uma spline de Catmull-Rom, que passa por
+// todos os quadros-chave sem quinas: a câmera não muda de direção
+// bruscamente ao passar por eles. A distância R é interpolada em linha
+// reta. Projeção, campo de visão e tela virtual são os da câmera base;
+// órbita, vista e enquadramento automático são descartados.
+//
+// Parâmetros:
+//   base: câmera da figura
+//   animation: seção "animacao" já validada (ver core.LoadFigureFromYAML)
+//   count: número de quadros (0 = pelos quadros por segundo)
+//
+// Retorna:
+//   []types.Camera: câmera de cada quadro
+//   float64: intervalo entre os quadros em segundos
+//   error: erro se houver menos de dois quadros-chave, o número de
+//          quadros for inválido ou a animação tiver quadros demais
+func KeyframeCameras(base types.Camera, animation *types.Animation, count int) ([]types.Camera, float64, error) {
+	keyframes := animation.Keyframes
+	if len(keyframes) < 2 {
+		return nil, 0, fmt.Errorf("animação deve ter pelo menos 2 quadros-chave, tem %d", len(keyframes))
+	}
+
+	start, end := keyframes[0].Time, keyframes[len(keyframes)-1].Time
+	interval := 1 / AnimationFPS(animation)
+	switch {
+	case count == 0:
+		count = int(math.Floor((end-start)/interval+1e-9)) + 1
+	case count < 2:
+		return nil, 0, fmt.Errorf("número de quadros inválido: %d (use de 2 a %d)", count, MaxAnimationFrames)
+	default:
+		interval = (end - start) / float64(count-1)
+	}
+	if count > MaxAnimationFrames {
+		return nil, 0, fmt.Errorf("animação longa demais: %d quadros (máximo: %d)", count, MaxAnimationFrames)
+	}
+
+	base.Orbit = nil
+	base.View = ""
+	base.Auto = false
+
+	cameras := make([]types.Camera, count)
+	segment := 0
+	for n := range cameras {
+		t := start + Ease(animation.Easing, float64(n)*interval/(end-start))*(end-start)
+
+		// Trecho entre os quadros-chave i e i+1 que contém o instante t
+		for segment < len(keyframes)-2 && t > keyframes[segment+1].Time {
+			segment++
+		}
+		i := segment
+		k1, k2 := keyframes[i], keyframes[i+1]
+		u := Ease(k1.Easing, math.Min(math.Max((t-k1.Time)/(k2.Time-k1.Time), 0), 1))
+
+		// Vizinhos da spline (os próprios extremos nas pontas)
+		k0, k3 := keyframes[max(i-1, 0)], keyframes[min(i+2, len(keyframes)-1)]
+
+		cam := base
+		cam.Observer = catmullRom(k0.Observer, k1.Observer, k2.Observer, k3.Observer, u)
+		d1, d2 := keyframeDistance(k1, base), keyframeDistance(k2, base)
+		cam.Distance = d1 + (d2-d1)*u
+		if k1.Target != nil {
+			target := catmullRom(*k0.Target, *k1.Target, *k2.Target, *k3.Target, u)
+			cam.Target = &target
+		}
+		cameras[n] = cam
+	}
+	return cameras, interval, nil
+}
+
+// keyframeDistance retorna a distância R de um quadro-chave, ou a da
+// câmera base se ele não a define.
+func keyframeDistance(k types.Keyframe, base types.Camera) float64 {
+	if k.Distance > 0 {
+		return k.Distance
+	}
+	return base.Distance
+}
+
+// catmullRom avalia a spline de Catmull-Rom entre p1 (u = 0) e p2 (u = 1),
+// com p0 e p3 como vizinhos que definem as tangentes:
+//   P(u) = ½ · (2p1 + (p2 - p0)·u + (2p0 - 5p1 + 4p2 - p3)·u² + (3p1 - p0 - 3p2 + p3)·u³)
+func catmullRom(p0, p1, p2, p3 types.Point3D, u float64) types.Point3D {
+	axis := func(a, b, c, d float64) float64 {
+		return 0.5 * (2*b + (c-a)*u + (2*a-5*b+4*c-d)*u*u + (3*b-a-3*c+d)*u*u*u)
+	}
+	return types.Point3D{
+		X: axis(p0.X, p1.X, p2.X, p3.X),
+		Y: axis(p0.Y, p1.Y, p2.Y, p3.Y),
+		Z: axis(p0.Z, p1.Z, p2.Z, p3.Z),
+	}
+}
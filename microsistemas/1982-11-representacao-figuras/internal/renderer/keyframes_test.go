@@ -0,0 +1,194 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestAnimationFPS(t *testing.T) {
+	if got := AnimationFPS(&types.Animation{}); got != DefaultAnimationFPS {
+		t.Errorf("Expected default %d fps, got %g", DefaultAnimationFPS, got)
+	}
+	if got := AnimationFPS(&types.Animation{FPS: 12}); got != 12 {
+		t.Errorf("Expected 12 fps, got %g", got)
+	}
+}
+
+func TestKeyframeCameras(t *testing.T) {
+	base := types.DefaultCamera()
+	base.Projection = types.ProjectionOrthographic
+	base.Auto = true
+
+	target := types.Point3D{Y: 5}
+	animation := &types.Animation{
+		FPS: 10,
+		Keyframes: []types.Keyframe{
+			{Time: 1, Observer: types.Point3D{X: -4}, Target: &target},
+			{Time: 2, Observer: types.Point3D{X: 0, Z: 2}, Distance: 20, Target: &target},
+			{Time: 3, Observer: types.Point3D{X: 4}, Target: &target},
+		},
+	}
+
+	cameras, interval, err := KeyframeCameras(base, animation, 0)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+
+	// De 1 s a 3 s, a 10 quadros por segundo
+	if len(cameras) != 21 || math.Abs(interval-0.1) > 1e-9 {
+		t.Fatalf("Expected 21 cameras 0.1 s apart, got %d (%g s)", len(cameras), interval)
+	}
+
+	// A spline passa pelos quadros-chave
+	for n, k := range map[int]types.Keyframe{0: animation.Keyframes[0], 10: animation.Keyframes[1], 20: animation.Keyframes[2]} {
+		if o := cameras[n].Observer; math.Abs(o.X-k.Observer.X) > 1e-9 || math.Abs(o.Z-k.Observer.Z) > 1e-9 {
+			t.Errorf("Camera %d: expected observer %v, got %v", n, k.Observer, o)
+		}
+	}
+
+	// Distância: a do quadro-chave ou a da câmera base, em linha reta
+	if d := cameras[5].Distance; math.Abs(d-(base.Distance+20)/2) > 1e-9 {
+		t.Errorf("Expected interpolated distance %g, got %g", (base.Distance+20)/2, d)
+	}
+
+	// Sem quinas: a subida até o quadro-chave do meio é suave
+	if z := cameras[5].Observer.Z; z <= 0 || z >= 2 {
+		t.Errorf("Expected observer between keyframes, got z=%g", z)
+	}
+
+	// Número de quadros informado: distribuídos pela duração inteira
+	sampled, interval, err := KeyframeCameras(base, animation, 5)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+	if len(sampled) != 5 || interval != 0.5 {
+		t.Errorf("Expected 5 cameras 0.5 s apart, got %d (%g s)", len(sampled), interval)
+	}
+	if o := sampled[4].Observer; math.Abs(o.X-4) > 1e-9 {
+		t.Errorf("Expected last camera at the last keyframe, got %v", o)
+	}
+
+	// Projeção da câmera base; enquadramento automático descartado
+	for _, cam := range []types.Camera{cameras[0], cameras[20]} {
+		if cam.Projection != types.ProjectionOrthographic || cam.Auto || cam.Target == nil || *cam.Target != target {
+			t.Errorf("Expected base projection and keyframe target, got %+v", cam)
+		}
+	}
+}
+
+func TestKeyframeCameras_Easing(t *testing.T) {
+	// Câmera em linha reta de x=0 a x=10 em 1 s, com 11 quadros
+	animation := &types.Animation{
+		Easing: types.EasingIn,
+		Keyframes: []types.Keyframe{
+			{Time: 0, Observer: types.Point3D{X: 0}},
+			{Time: 1, Observer: types.Point3D{X: 10}},
+		},
+	}
+	cameras, _, err := KeyframeCameras(types.DefaultCamera(), animation, 11)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+
+	// Na metade do tempo, a câmera está onde estaria sem suavização em
+	// um quarto do tempo, e as pontas continuam nos quadros-chave
+	linear := *animation
+	linear.Easing = ""
+	quarters, _, err := KeyframeCameras(types.DefaultCamera(), &linear, 5)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+	for n, want := range map[int]float64{0: 0, 5: quarters[1].Observer.X, 10: 10} {
+		if x := cameras[n].Observer.X; math.Abs(x-want) > 1e-9 {
+			t.Errorf("Camera %d: expected x=%g, got %g", n, want, x)
+		}
+	}
+}
+
+func TestKeyframeCameras_SegmentEasing(t *testing.T) {
+	// Parada no primeiro trecho, depois um trecho em linha reta
+	animation := &types.Animation{
+		Keyframes: []types.Keyframe{
+			{Time: 0, Observer: types.Point3D{X: 0}, Easing: types.EasingHold},
+			{Time: 1, Observer: types.Point3D{X: 10}},
+			{Time: 2, Observer: types.Point3D{X: 20}},
+		},
+	}
+	cameras, _, err := KeyframeCameras(types.DefaultCamera(), animation, 21)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+
+	// A câmera fica parada até o segundo quadro-chave e então salta
+	for n := 0; n < 10; n++ {
+		if x := cameras[n].Observer.X; x != 0 {
+			t.Errorf("Camera %d: expected to hold at x=0, got %g", n, x)
+		}
+	}
+	if x := cameras[10].Observer.X; math.Abs(x-10) > 1e-9 {
+		t.Errorf("Expected camera at the second keyframe, got x=%g", x)
+	}
+	if x := cameras[15].Observer.X; x <= 10 || x >= 20 {
+		t.Errorf("Expected camera moving in the second segment, got x=%g", x)
+	}
+}
+
+func TestEase(t *testing.T) {
+	cases := []struct {
+		easing  string
+		u, want float64
+	}{
+		{"", 0.3, 0.3},
+		{types.EasingLinear, 0.3, 0.3},
+		{types.EasingIn, 0.5, 0.25},
+		{types.EasingOut, 0.5, 0.75},
+		{types.EasingInOut, 0.25, 0.125},
+		{types.EasingInOut, 0.5, 0.5},
+		{types.EasingInOut, 0.75, 0.875},
+		{types.EasingCubic, 0.25, 0.0625},
+		{types.EasingCubic, 0.5, 0.5},
+		{types.EasingCubic, 0.75, 0.9375},
+		{types.EasingHold, 0.99, 0},
+	}
+	for _, c := range cases {
+		if got := Ease(c.easing, c.u); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("Ease(%q, %g): expected %g, got %g", c.easing, c.u, c.want, got)
+		}
+	}
+
+	// Todas as curvas vão de 0 a 1
+	for _, easing := range []string{types.EasingLinear, types.EasingIn, types.EasingOut, types.EasingInOut, types.EasingCubic, types.EasingHold} {
+		if Ease(easing, 0) != 0 || Ease(easing, 1) != 1 {
+			t.Errorf("Ease(%q): expected to go from 0 to 1", easing)
+		}
+	}
+}
+
+func TestParseEasing(t *testing.T) {
+	if got, err := ParseEasing(" In-Out"); err != nil || got != types.EasingInOut {
+		t.Errorf("ParseEasing: expected %q, got %q (%v)", types.EasingInOut, got, err)
+	}
+	if _, err := ParseEasing("bounce"); err == nil {
+		t.Error("Expected error for unknown easing")
+	}
+}
+
+func TestKeyframeCameras_Errors(t *testing.T) {
+	base := types.DefaultCamera()
+	if _, _, err := KeyframeCameras(base, &types.Animation{Keyframes: []types.Keyframe{{Time: 0}}}, 0); err == nil {
+		t.Error("Expected error for a single keyframe")
+	}
+
+	long := &types.Animation{Keyframes: []types.Keyframe{{Time: 0}, {Time: 3600}}}
+	if _, _, err := KeyframeCameras(base, long, 0); err == nil {
+		t.Error("Expected error for too many frames")
+	}
+	if _, _, err := KeyframeCameras(base, long, 1); err == nil {
+		t.Error("Expected error for a single frame")
+	}
+	if _, _, err := KeyframeCameras(base, long, 100); err != nil {
+		t.Errorf("Expected long animation resampled to 100 frames, got %v", err)
+	}
+}
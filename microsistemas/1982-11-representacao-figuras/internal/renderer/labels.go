@@ -0,0 +1,164 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// labelCandidate é uma posição possível para o nome de um ponto, em
+// relação ao vértice: o deslocamento do ponto de ancoragem e a âncora
+// do texto, como em DrawStringAnchored (ax = fração da largura do texto
+// à esquerda do ponto de ancoragem; ay = fração da altura abaixo dele).
+type labelCandidate struct {
+	dx, dy float64
+	ax, ay float64
+}
+
+// labelCandidates são as posições tentadas para cada nome, na ordem de
+// preferência. A primeira é a posição fixa de antes (acima e à
+// direita do vértice), mantida sempre que não atrapalha nada.
+var labelCandidates = []labelCandidate{
+	{dx: 5, dy: -5, ax: 0, ay: 0},   // Acima, à direita
+	{dx: -5, dy: -5, ax: 1, ay: 0},  // Acima, à esquerda
+	{dx: 5, dy: 5, ax: 0, ay: 1},    // Abaixo, à direita
+	{dx: -5, dy: 5, ax: 1, ay: 1},   // Abaixo, à esquerda
+	{dx: 6, dy: 0, ax: 0, ay: 0.5},  // À direita
+	{dx: -6, dy: 0, ax: 1, ay: 0.5}, // À esquerda
+	{dx: 0, dy: -6, ax: 0.5, ay: 0}, // Acima
+	{dx: 0, dy: 6, ax: 0.5, ay: 1},  // Abaixo
+}
+
+// Pesos do custo de uma posição (ver placeLabels).
+const (
+	labelCostOverlap = 100  // Por nome já posicionado que o texto cobre
+	labelCostVertex  = 20   // Por outro vértice coberto pelo texto
+	labelCostEdge    = 10   // Por aresta que atravessa o texto
+	labelCostOutside = 50   // Texto saindo do viewport
+	labelCostOrder   = 0.01 // Por posição na ordem de preferência
+)
+
+// labelBox é o retângulo ocupado por um texto na tela, em pixels.
+type labelBox struct {
+	x0, y0, x1, y1 float64
+}
+
+// overlaps informa se dois retângulos se sobrepõem.
+func (b labelBox) overlaps(o labelBox) bool {
+	return b.x0 < o.x1 && o.x0 < b.x1 && b.y0 < o.y1 && o.y0 < b.y1
+}
+
+// contains informa se o ponto está dentro do retângulo.
+func (b labelBox) contains(p types.Point2D) bool {
+	return p.X >= b.x0 && p.X <= b.x1 && p.Y >= b.y0 && p.Y <= b.y1
+}
+
+// crosses informa se o segmento a→b atravessa o retângulo.
+//
+// É o teste de Liang-Barsky: o segmento a + t·(b − a), t ∈ [0, 1], é
+// recortado pelas quatro faixas do retângulo; sobra algum trecho se o
+// maior t de entrada não passa do menor t de saída.
+func (b labelBox) crosses(p, q types.Point2D) bool {
+	t0, t1 := 0.0, 1.0
+	dx, dy := q.X-p.X, q.Y-p.Y
+	for _, edge := range [4][2]float64{
+		{-dx, p.X - b.x0}, {dx, b.x1 - p.X},
+		{-dy, p.Y - b.y0}, {dy, b.y1 - p.Y},
+	} {
+		den, num := edge[0], edge[1]
+		if den == 0 {
+			if num < 0 {
+				return false // Paralelo e fora da faixa
+			}
+			continue
+		}
+		t := num / den
+		if den < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+		if t0 > t1 {
+			return false
+		}
+	}
+	return true
+}
+
+// labelPlacement é a posição escolhida para o nome de um ponto.
+type labelPlacement struct {
+	x, y float64 // Início da linha de base do texto (como em DrawString)
+	ok   bool    // false = ponto sem nome ou invisível
+}
+
+// placeLabels escolhe onde escrever o nome de cada ponto.
+//
+// Os nomes são posicionados um a um, na ordem dos pontos. Para cada um,
+// as posições de labelCandidates ao redor do vértice são avaliadas por
+// um custo que soma os nomes já posicionados que o texto cobriria, os
+// outros vértices escondidos por ele, as arestas que o atravessam e a
+// saída do viewport; fica a de menor custo. Não é uma solução ótima,
+// mas em figuras densas evita a maior parte das sobreposições que a
+// posição fixa produzia.
+//
+// Parâmetros:
+//   points: pontos da figura (os nomes)
+//   screen: posição de cada ponto em pixels
+//   visible: quais pontos estão à frente do observador
+//   segments: arestas desenhadas, em pixels
+//   measure: largura e altura de um texto em pixels
+//
+// Retorna:
+//   []labelPlacement: posição do nome de cada ponto
+func (r *Renderer3D) placeLabels(points []types.Point3D, screen []types.Point2D, visible []bool,
+	segments [][2]types.Point2D, measure func(string) (float64, float64)) []labelPlacement {
+	vp := r.viewport
+	bounds := labelBox{x0: vp.X, y0: vp.Y, x1: vp.X + vp.Width, y1: vp.Y + vp.Height}
+
+	placements := make([]labelPlacement, len(points))
+	var placed []labelBox
+	for i, p := range points {
+		if p.Nome == "" || !visible[i] {
+			continue
+		}
+		w, h := measure(p.Nome)
+		v := screen[i]
+
+		best, bestCost := labelPlacement{}, math.Inf(1)
+		var bestBox labelBox
+		for k, c := range labelCandidates {
+			x, y := v.X+c.dx, v.Y+c.dy
+			// O texto fica acima da linha de base, que é a borda inferior
+			box := labelBox{x0: x - c.ax*w, y0: y + c.ay*h - h}
+			box.x1, box.y1 = box.x0+w, box.y0+h
+
+			cost := labelCostOrder * float64(k)
+			for _, other := range placed {
+				if box.overlaps(other) {
+					cost += labelCostOverlap
+				}
+			}
+			for j, q := range screen {
+				if j != i && visible[j] && box.contains(q) {
+					cost += labelCostVertex
+				}
+			}
+			for _, s := range segments {
+				if box.crosses(s[0], s[1]) {
+					cost += labelCostEdge
+				}
+			}
+			if box.x0 < bounds.x0 || box.y0 < bounds.y0 || box.x1 > bounds.x1 || box.y1 > bounds.y1 {
+				cost += labelCostOutside
+			}
+
+			if cost < bestCost {
+				best = labelPlacement{x: box.x0, y: box.y1, ok: true}
+				bestCost, bestBox = cost, box
+			}
+		}
+		placements[i] = best
+		placed = append(placed, bestBox)
+	}
+	return placements
+}
@@ -0,0 +1,65 @@
+package renderer
+
+import (
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestLabelBoxCrosses(t *testing.T) {
+	box := labelBox{x0: 0, y0: 0, x1: 10, y1: 5}
+
+	tests := []struct {
+		name string
+		p, q types.Point2D
+		want bool
+	}{
+		{"through", types.Point2D{X: -5, Y: 2}, types.Point2D{X: 15, Y: 2}, true},
+		{"diagonal", types.Point2D{X: -1, Y: -1}, types.Point2D{X: 11, Y: 6}, true},
+		{"inside", types.Point2D{X: 2, Y: 2}, types.Point2D{X: 3, Y: 3}, true},
+		{"above", types.Point2D{X: -5, Y: -2}, types.Point2D{X: 15, Y: -2}, false},
+		{"stops short", types.Point2D{X: -5, Y: 2}, types.Point2D{X: -1, Y: 2}, false},
+		{"misses corner", types.Point2D{X: 8, Y: -4}, types.Point2D{X: 14, Y: 2}, false},
+	}
+
+	for _, tt := range tests {
+		if got := box.crosses(tt.p, tt.q); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPlaceLabels(t *testing.T) {
+	measure := func(s string) (float64, float64) { return 7 * float64(len(s)), 13 }
+	renderer := New(400, 300)
+
+	// Ponto isolado: a posição de sempre, acima e à direita
+	points := []types.Point3D{{Nome: "A"}}
+	screen := []types.Point2D{{X: 100, Y: 100}}
+	got := renderer.placeLabels(points, screen, []bool{true}, nil, measure)
+	if got[0] != (labelPlacement{x: 105, y: 95, ok: true}) {
+		t.Errorf("Expected default placement, got %+v", got[0])
+	}
+
+	// Aresta saindo para cima e à direita: o nome vai para outro lado
+	segments := [][2]types.Point2D{{{X: 100, Y: 100}, {X: 200, Y: 0}}}
+	got = renderer.placeLabels(points, screen, []bool{true}, segments, measure)
+	box := labelBox{x0: got[0].x, y0: got[0].y - 13, x1: got[0].x + 7, y1: got[0].y}
+	if box.crosses(segments[0][0], segments[0][1]) {
+		t.Errorf("Expected label off the edge, got %+v", got[0])
+	}
+
+	// Dois pontos vizinhos: os nomes não se sobrepõem, e pontos sem
+	// nome ou invisíveis não recebem posição
+	points = []types.Point3D{{Nome: "P1"}, {Nome: "P2"}, {}, {Nome: "P4"}}
+	screen = []types.Point2D{{X: 100, Y: 100}, {X: 104, Y: 102}, {X: 300, Y: 200}, {X: 50, Y: 50}}
+	got = renderer.placeLabels(points, screen, []bool{true, true, true, false}, nil, measure)
+	a := labelBox{x0: got[0].x, y0: got[0].y - 13, x1: got[0].x + 14, y1: got[0].y}
+	b := labelBox{x0: got[1].x, y0: got[1].y - 13, x1: got[1].x + 14, y1: got[1].y}
+	if a.overlaps(b) {
+		t.Errorf("Expected separate labels, got %+v and %+v", got[0], got[1])
+	}
+	if got[2].ok || got[3].ok {
+		t.Errorf("Expected no placement for unnamed or hidden points, got %+v and %+v", got[2], got[3])
+	}
+}
@@ -0,0 +1,48 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultLightDirection é a direção padrão de onde vem a luz: de cima,
+// da esquerda e da frente da cena (com o observador em −Y olhando para
+// +Y), o que ilumina bem as faces frontal, esquerda e superior de um
+// sólido visto de frente.
+var DefaultLightDirection = types.Point3D{X: -1, Y: -2, Z: 3}
+
+// DefaultAmbient é a fração padrão da cor que uma face recebe mesmo sem
+// luz direta, para que as faces na sombra ainda mostrem a sua cor.
+const DefaultAmbient = 0.3
+
+// shadeColor aplica o sombreamento plano de Lambert à cor de uma face.
+//
+// A intensidade é ambiente + (1 − ambiente)·max(0, N·L), onde N é a
+// normal unitária da face e L a direção unitária que aponta para a luz.
+// Como as faces não têm um lado "de dentro" definido, a normal é
+// invertida quando aponta para longe do observador: o lado visível é o
+// que recebe (ou não) a luz.
+//
+// Parâmetros:
+//   base: cor da face sem sombreamento
+//   normal: normal da face no espaço mundial (não precisa ser unitária)
+//   front: true se a normal aponta para o observador
+//   cfg: configurações com a direção da luz e o termo ambiente
+//
+// Retorna:
+//   colorRGB: cor sombreada
+func shadeColor(base colorRGB, normal vec3, front bool, cfg RenderConfig) colorRGB {
+	n := normal.normalize()
+	if !front {
+		n = vec3{X: -n.X, Y: -n.Y, Z: -n.Z}
+	}
+
+	intensity := cfg.Ambient + (1-cfg.Ambient)*math.Max(0, n.dot(cfg.LightDirection))
+	return colorRGB{
+		R: base.R * intensity,
+		G: base.G * intensity,
+		B: base.B * intensity,
+		A: base.A,
+	}
+}
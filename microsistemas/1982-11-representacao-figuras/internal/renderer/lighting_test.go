@@ -0,0 +1,34 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShadeColor(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	cfg.LightDirection = vec3{Z: 1}
+	cfg.Ambient = 0.25
+	white := colorRGB{R: 1, G: 1, B: 1, A: 1}
+
+	tests := []struct {
+		name   string
+		normal vec3
+		front  bool
+		want   float64
+	}{
+		{"facing light", vec3{Z: 2}, true, 1},
+		{"oblique", vec3{X: 1, Z: 1}, true, 0.25 + 0.75*math.Sqrt(0.5)},
+		{"facing away", vec3{Z: -1}, true, 0.25},
+		{"back side lit", vec3{Z: -1}, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shadeColor(white, tt.normal, tt.front, cfg)
+			if math.Abs(got.R-tt.want) > 1e-9 || got.R != got.G || got.G != got.B {
+				t.Errorf("Expected intensity %g, got %+v", tt.want, got)
+			}
+		})
+	}
+}
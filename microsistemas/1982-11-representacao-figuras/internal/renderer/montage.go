@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"fmt"
+
+	"representacao-figuras/pkg/types"
+)
+
+// MontageCell é uma das figuras de uma montagem, com a sua configuração.
+type MontageCell struct {
+	Figure *types.Figure // Figura, com a própria câmera
+	Config RenderConfig  // Configuração visual da figura (ver ConfigFromFigure)
+}
+
+// RenderMontage renderiza várias figuras numa grade, cada uma na sua
+// célula, com o nome no canto superior esquerdo, como numa galeria.
+//
+// Cada figura usa a própria câmera e as próprias cores; carimbo, título,
+// legenda, tabela de pontos e marca d'água de cada uma são omitidos, por
+// não caberem numa célula. Células que sobram na última linha ficam em
+// branco. A grade é a de SheetGrid.
+//
+// Parâmetros:
+//   cells: figuras na ordem da grade, linha a linha
+//
+// Retorna:
+//   error: erro se não houver figuras ou se alguma for inválida
+func (r *Renderer3D) RenderMontage(cells []MontageCell) error {
+	if len(cells) == 0 {
+		return fmt.Errorf("nenhuma figura para a montagem")
+	}
+
+	// Preserva câmera e viewport do chamador
+	savedCamera := r.camera
+	defer func() {
+		r.SetViewport(r.fullViewport())
+		r.SetCamera(savedCamera)
+	}()
+
+	cols, rows := SheetGrid(len(cells))
+	for i, c := range cells {
+		cell := r.sheetCell(i, cols, rows)
+		r.SetViewport(cell)
+		r.SetCamera(c.Figure.Camera)
+
+		cfg := withoutSheetDecorations(c.Config)
+		if err := r.RenderFigureWithConfig(c.Figure, cfg); err != nil {
+			return fmt.Errorf("figura %s: %w", c.Figure.Nome, err)
+		}
+
+		// Nome da figura no canto superior esquerdo
+		r.setColor(cfg.LineColor)
+		r.context.DrawString(c.Figure.Nome, cell.X+8, cell.Y+16)
+	}
+
+	r.drawSheetDividers(cols, rows, DefaultRenderConfig().LineColor)
+	return nil
+}
@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestRenderMontage(t *testing.T) {
+	renderer := New(200, 200)
+	if err := renderer.RenderMontage(nil); err == nil {
+		t.Error("Expected error for empty montage")
+	}
+
+	segment := func(name string) *types.Figure {
+		return &types.Figure{
+			Nome:   name,
+			Pontos: []types.Point3D{{X: -1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: 1}},
+			Linhas: []types.Line{{P1: 0, P2: 1}},
+			Camera: types.DefaultCamera(),
+		}
+	}
+
+	// Três figuras numa grade 2×2; a segunda com fundo azul e linhas brancas
+	blue := DefaultRenderConfig()
+	blue.Background = colorRGB{B: 1, A: 1}
+	blue.LineColor = colorRGB{R: 1, G: 1, B: 1, A: 1}
+	cells := []MontageCell{
+		{Figure: segment("a"), Config: DefaultRenderConfig()},
+		{Figure: segment("b"), Config: blue},
+		{Figure: segment("c"), Config: DefaultRenderConfig()},
+	}
+	if err := renderer.RenderMontage(cells); err != nil {
+		t.Fatalf("RenderMontage failed: %v", err)
+	}
+	if renderer.viewport != renderer.fullViewport() {
+		t.Errorf("Expected full viewport after montage, got %+v", renderer.viewport)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	if !hasDarkPixel(img, image.Rect(0, 20, 100, 100).Inset(3)) {
+		t.Error("Expected drawing in the first cell")
+	}
+	if r, g, b, _ := img.At(110, 90).RGBA(); r != 0 || g != 0 || b != 0xffff {
+		t.Errorf("Expected blue background in the second cell, got (%d, %d, %d)", r, g, b)
+	}
+	if !hasDarkPixel(img, image.Rect(0, 120, 100, 200).Inset(3)) {
+		t.Error("Expected drawing in the third cell")
+	}
+	if hasDarkPixel(img, image.Rect(100, 100, 200, 200).Inset(3)) {
+		t.Error("Expected the unused cell to stay blank")
+	}
+
+	// Erros de uma figura indicam qual delas falhou
+	cells[2].Figure.Pontos = nil
+	if err := renderer.RenderMontage(cells); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
@@ -0,0 +1,176 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Quadros e tempos da metamorfose.
+const (
+	DefaultMorphFrames = 30  // Número padrão de quadros de uma figura à outra
+	MinMorphFrames     = 2   // Menor número de quadros aceito
+	MaxMorphFrames     = 360 // Maior número de quadros aceito
+	DefaultMorphDelay  = 50  // Intervalo padrão entre quadros, em milissegundos
+)
+
+// Morph é a metamorfose de uma figura em outra: cada ponto da origem
+// caminha em linha reta até o ponto do destino que lhe corresponde.
+// As linhas, faces e demais seções são as da origem, e apenas os
+// pontos se movem.
+type Morph struct {
+	from    *types.Figure
+	targets []types.Point3D // Destino de cada ponto da origem
+}
+
+// ParseMorphMap converte a correspondência de pontos da opção --map,
+// pares "origem=destino" de nomes de pontos separados por vírgulas,
+// como "A=A,B=B,E=TOPO".
+//
+// Parâmetros:
+//   value: lista de pares
+//
+// Retorna:
+//   map[string]string: nome do ponto de destino de cada ponto da origem
+//   error: erro se algum par for malformado ou repetir a origem
+func ParseMorphMap(value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		from, to, ok := strings.Cut(item, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("par de pontos inválido: %q (use origem=destino, como A=TOPO)", strings.TrimSpace(item))
+		}
+		if _, dup := pairs[from]; dup {
+			return nil, fmt.Errorf("ponto %q pareado mais de uma vez", from)
+		}
+		pairs[from] = to
+	}
+	return pairs, nil
+}
+
+// NewMorph prepara a metamorfose de uma figura em outra.
+//
+// Sem correspondência, os pontos são pareados pela ordem, e as figuras
+// precisam ter o mesmo número de pontos. Com ela, cada ponto da origem
+// vai para o ponto do destino de mesmo nome no mapa; vários pontos
+// podem ir para o mesmo destino (os vértices de cima de um cubo que se
+// juntam no topo de uma pirâmide, por exemplo).
+//
+// Parâmetros:
+//   from: figura de origem (não é alterada)
+//   to: figura de destino (só os pontos são usados)
+//   pairs: correspondência pelos nomes (nil = pela ordem)
+//
+// Retorna:
+//   *Morph: metamorfose pronta para Stage
+//   error: erro se os pontos não puderem ser pareados
+func NewMorph(from, to *types.Figure, pairs map[string]string) (*Morph, error) {
+	targets := make([]types.Point3D, len(from.Pontos))
+	if pairs == nil {
+		if len(from.Pontos) != len(to.Pontos) {
+			return nil, fmt.Errorf("as figuras têm números de pontos diferentes (%d e %d); use --map para parear os pontos pelo nome", len(from.Pontos), len(to.Pontos))
+		}
+		copy(targets, to.Pontos)
+		return &Morph{from: from, targets: targets}, nil
+	}
+
+	for name := range pairs {
+		if _, ok := from.PointIndex(name); !ok {
+			return nil, fmt.Errorf("ponto %q não existe na figura de origem", name)
+		}
+	}
+	for i, p := range from.Pontos {
+		name, ok := pairs[p.Nome]
+		if !ok || p.Nome == "" {
+			return nil, fmt.Errorf("ponto %d (%q) da origem sem par no destino", i+1, p.Nome)
+		}
+		j, ok := to.PointIndex(name)
+		if !ok {
+			return nil, fmt.Errorf("ponto %q não existe na figura de destino", name)
+		}
+		targets[i] = to.Pontos[j]
+	}
+	return &Morph{from: from, targets: targets}, nil
+}
+
+// Stage retorna a figura num instante da metamorfose.
+//
+// Os pontos de controle das curvas acompanham o deslocamento médio das
+// duas pontas da linha: a curva chega ao destino com o mesmo formato
+// que tinha na origem.
+//
+// Parâmetros:
+//   t: instante, de 0 (origem) a 1 (pontos do destino)
+//
+// Retorna:
+//   *types.Figure: cópia da origem com os pontos interpolados
+func (m *Morph) Stage(t float64) *types.Figure {
+	stage := *m.from
+	stage.Pontos = make([]types.Point3D, len(m.from.Pontos))
+	for i, p := range m.from.Pontos {
+		q := m.targets[i]
+		stage.Pontos[i] = types.Point3D{
+			X:    p.X + (q.X-p.X)*t,
+			Y:    p.Y + (q.Y-p.Y)*t,
+			Z:    p.Z + (q.Z-p.Z)*t,
+			Nome: p.Nome,
+		}
+	}
+
+	stage.Linhas = append([]types.Line(nil), m.from.Linhas...)
+	for i, linha := range stage.Linhas {
+		if len(linha.Controle) == 0 || !m.valid(linha.P1) || !m.valid(linha.P2) {
+			continue
+		}
+		dx, dy, dz := m.shift(linha.P1, linha.P2, t)
+		controle := make([]types.Point3D, len(linha.Controle))
+		for k, c := range linha.Controle {
+			controle[k] = types.Point3D{X: c.X + dx, Y: c.Y + dy, Z: c.Z + dz, Nome: c.Nome}
+		}
+		stage.Linhas[i].Controle = controle
+	}
+	return &stage
+}
+
+// valid informa se um índice de ponto da origem existe.
+func (m *Morph) valid(i int) bool {
+	return i >= 0 && i < len(m.targets)
+}
+
+// shift calcula o deslocamento médio de dois pontos da origem no instante t.
+func (m *Morph) shift(i, j int, t float64) (dx, dy, dz float64) {
+	p1, p2 := m.from.Pontos[i], m.from.Pontos[j]
+	q1, q2 := m.targets[i], m.targets[j]
+	dx = ((q1.X - p1.X) + (q2.X - p2.X)) / 2 * t
+	dy = ((q1.Y - p1.Y) + (q2.Y - p2.Y)) / 2 * t
+	dz = ((q1.Z - p1.Z) + (q2.Z - p2.Z)) / 2 * t
+	return dx, dy, dz
+}
+
+// Envelope retorna uma figura com os pontos da origem e os do destino,
+// para resolver o enquadramento automático uma só vez (ver
+// EffectiveCamera): a figura cabe na tela em todos os quadros.
+func (m *Morph) Envelope() *types.Figure {
+	envelope := *m.from
+	envelope.Pontos = append(append([]types.Point3D(nil), m.from.Pontos...), m.targets...)
+	envelope.Linhas = nil
+	return &envelope
+}
+
+// MorphTime retorna o instante da metamorfose em cada quadro de uma
+// animação de ida e volta: a origem se transforma no destino e volta,
+// acelerando e desacelerando suavemente (um ciclo de cosseno), de modo
+// que o último quadro emenda no primeiro.
+//
+// Parâmetros:
+//   n: número do quadro, de 0 a total-1
+//   total: número de quadros da ida e volta
+//
+// Retorna:
+//   float64: instante, de 0 (origem) a 1 (destino)
+func MorphTime(n, total int) float64 {
+	return (1 - math.Cos(2*math.Pi*float64(n)/float64(total))) / 2
+}
@@ -0,0 +1,99 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestParseMorphMap(t *testing.T) {
+	pairs, err := ParseMorphMap("A=A, B = TOPO,C=TOPO")
+	if err != nil {
+		t.Fatalf("ParseMorphMap failed: %v", err)
+	}
+	if len(pairs) != 3 || pairs["B"] != "TOPO" || pairs["C"] != "TOPO" {
+		t.Errorf("Unexpected pairs: %v", pairs)
+	}
+
+	for _, value := range []string{"", "A", "A=", "=B", "A=B,A=C"} {
+		if _, err := ParseMorphMap(value); err == nil {
+			t.Errorf("ParseMorphMap(%q): expected error", value)
+		}
+	}
+}
+
+func TestMorph(t *testing.T) {
+	// Segmento curvo que sobe 2 unidades e se afasta em X
+	from := &types.Figure{
+		Pontos: []types.Point3D{{X: 0, Nome: "A"}, {X: 2, Nome: "B"}},
+		Linhas: []types.Line{{P1: 0, P2: 1, Controle: []types.Point3D{{X: 1, Y: 1}}}},
+	}
+	to := &types.Figure{
+		Pontos: []types.Point3D{{X: 0, Z: 2, Nome: "P"}, {X: 4, Z: 2, Nome: "Q"}},
+	}
+
+	m, err := NewMorph(from, to, nil)
+	if err != nil {
+		t.Fatalf("NewMorph failed: %v", err)
+	}
+
+	stage := m.Stage(0.5)
+	if want := (types.Point3D{X: 3, Z: 1, Nome: "B"}); stage.Pontos[1] != want {
+		t.Errorf("Expected B at %v, got %v", want, stage.Pontos[1])
+	}
+	// O controle acompanha o deslocamento médio das pontas: (1, 0, 2) · 0,5
+	if want := (types.Point3D{X: 1.5, Y: 1, Z: 1}); stage.Linhas[0].Controle[0] != want {
+		t.Errorf("Expected control point at %v, got %v", want, stage.Linhas[0].Controle[0])
+	}
+	if stage := m.Stage(1); stage.Pontos[0] != (types.Point3D{Z: 2, Nome: "A"}) {
+		t.Errorf("Expected A at destination, got %v", stage.Pontos[0])
+	}
+
+	// A origem não é alterada, e o envelope tem os pontos das duas figuras
+	if from.Pontos[1] != (types.Point3D{X: 2, Nome: "B"}) || from.Linhas[0].Controle[0] != (types.Point3D{X: 1, Y: 1}) {
+		t.Errorf("Expected original figure untouched, got %+v", from)
+	}
+	if envelope := m.Envelope(); len(envelope.Pontos) != 4 || envelope.Linhas != nil {
+		t.Errorf("Expected envelope with 4 points and no lines, got %+v", envelope)
+	}
+}
+
+func TestNewMorphPairs(t *testing.T) {
+	from := &types.Figure{Pontos: []types.Point3D{{Nome: "A"}, {Nome: "B"}, {Nome: "C"}}}
+	to := &types.Figure{Pontos: []types.Point3D{{X: 1, Nome: "BASE"}, {Z: 5, Nome: "TOPO"}}}
+
+	// Contagens diferentes exigem o mapa
+	if _, err := NewMorph(from, to, nil); err == nil {
+		t.Error("Expected error for different point counts")
+	}
+
+	m, err := NewMorph(from, to, map[string]string{"A": "BASE", "B": "TOPO", "C": "TOPO"})
+	if err != nil {
+		t.Fatalf("NewMorph failed: %v", err)
+	}
+	if stage := m.Stage(1); stage.Pontos[1].Z != 5 || stage.Pontos[2].Z != 5 || stage.Pontos[0].X != 1 {
+		t.Errorf("Unexpected destination points: %v", stage.Pontos)
+	}
+
+	cases := map[string]map[string]string{
+		"missing pair":   {"A": "BASE", "B": "TOPO"},
+		"unknown target": {"A": "BASE", "B": "TOPO", "C": "X"},
+		"unknown origin": {"A": "BASE", "B": "TOPO", "C": "TOPO", "D": "TOPO"},
+	}
+	for name, pairs := range cases {
+		if _, err := NewMorph(from, to, pairs); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestMorphTime(t *testing.T) {
+	// Ida e volta: origem, destino no meio, e de volta perto da origem
+	cases := map[int]float64{0: 0, 5: 0.5, 10: 1, 15: 0.5}
+	for n, want := range cases {
+		if got := MorphTime(n, 20); math.Abs(got-want) > 1e-9 {
+			t.Errorf("MorphTime(%d, 20): expected %v, got %v", n, want, got)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Palette é a paleta fixa de cores de um computador da época.
+type Palette string
+
+const (
+	PaletteCGA Palette = "cga" // IBM PC CGA, modo gráfico de 4 cores (paleta 1, alta intensidade)
+	PaletteZX  Palette = "zx"  // Sinclair ZX Spectrum, 15 cores (normais e brilhantes)
+	PaletteMSX Palette = "msx" // MSX1 (TMS9918), 15 cores
+)
+
+// paletteAliases aceita também os nomes completos das máquinas.
+var paletteAliases = map[string]Palette{
+	"cga":         PaletteCGA,
+	"zx":          PaletteZX,
+	"spectrum":    PaletteZX,
+	"zx-spectrum": PaletteZX,
+	"zxspectrum":  PaletteZX,
+	"msx":         PaletteMSX,
+	"msx1":        PaletteMSX,
+	"tms9918":     PaletteMSX,
+}
+
+// paletteColors são as cores de cada paleta, como as máquinas as
+// geravam no vídeo (valores usuais dos emuladores).
+var paletteColors = map[Palette][]color.RGBA{
+	PaletteCGA: {
+		{0x00, 0x00, 0x00, 0xff}, // Preto
+		{0x55, 0xff, 0xff, 0xff}, // Ciano claro
+		{0xff, 0x55, 0xff, 0xff}, // Magenta claro
+		{0xff, 0xff, 0xff, 0xff}, // Branco
+	},
+	PaletteZX: {
+		{0x00, 0x00, 0x00, 0xff}, // Preto
+		{0x00, 0x00, 0xd7, 0xff}, // Azul
+		{0xd7, 0x00, 0x00, 0xff}, // Vermelho
+		{0xd7, 0x00, 0xd7, 0xff}, // Magenta
+		{0x00, 0xd7, 0x00, 0xff}, // Verde
+		{0x00, 0xd7, 0xd7, 0xff}, // Ciano
+		{0xd7, 0xd7, 0x00, 0xff}, // Amarelo
+		{0xd7, 0xd7, 0xd7, 0xff}, // Branco
+		{0x00, 0x00, 0xff, 0xff}, // Azul brilhante
+		{0xff, 0x00, 0x00, 0xff}, // Vermelho brilhante
+		{0xff, 0x00, 0xff, 0xff}, // Magenta brilhante
+		{0x00, 0xff, 0x00, 0xff}, // Verde brilhante
+		{0x00, 0xff, 0xff, 0xff}, // Ciano brilhante
+		{0xff, 0xff, 0x00, 0xff}, // Amarelo brilhante
+		{0xff, 0xff, 0xff, 0xff}, // Branco brilhante
+	},
+	PaletteMSX: {
+		{0x00, 0x00, 0x00, 0xff}, // Preto
+		{0x21, 0xc8, 0x42, 0xff}, // Verde médio
+		{0x5e, 0xdc, 0x78, 0xff}, // Verde claro
+		{0x54, 0x55, 0xed, 0xff}, // Azul escuro
+		{0x7d, 0x76, 0xfc, 0xff}, // Azul claro
+		{0xd4, 0x52, 0x4d, 0xff}, // Vermelho escuro
+		{0x42, 0xeb, 0xf5, 0xff}, // Ciano
+		{0xfc, 0x55, 0x54, 0xff}, // Vermelho médio
+		{0xff, 0x79, 0x78, 0xff}, // Vermelho claro
+		{0xd4, 0xc1, 0x54, 0xff}, // Amarelo escuro
+		{0xe6, 0xce, 0x80, 0xff}, // Amarelo claro
+		{0x21, 0xb0, 0x3b, 0xff}, // Verde escuro
+		{0xc9, 0x5b, 0xba, 0xff}, // Magenta
+		{0xcc, 0xcc, 0xcc, 0xff}, // Cinza
+		{0xff, 0xff, 0xff, 0xff}, // Branco
+	},
+}
+
+// ParsePalette converte o nome de uma paleta retrô ("cga", "zx" ou
+// "msx", sem distinção de maiúsculas; aceita também "spectrum").
+//
+// Parâmetros:
+//   value: nome da paleta
+//
+// Retorna:
+//   Palette: paleta correspondente
+//   error: erro se o nome for desconhecido
+func ParsePalette(value string) (Palette, error) {
+	p, ok := paletteAliases[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("paleta desconhecida: %q (use %s, %s ou %s)", value, PaletteCGA, PaletteZX, PaletteMSX)
+	}
+	return p, nil
+}
+
+// Quantize reduz a imagem renderizada às cores de uma paleta retrô:
+// cada pixel passa a ter a cor da paleta mais próxima, sem pontilhado,
+// como numa tela que só sabia gerar aquelas cores.
+//
+// A distância entre cores pesa mais o verde e menos o azul, como a
+// sensibilidade do olho. Pixels mais transparentes que opacos ficam
+// inteiramente transparentes; os demais, opacos.
+//
+// Deve ser chamado depois da renderização (e de Upscale ou AutoCrop,
+// se usados) e antes de SaveImage. A imagem resultante tem 8 bits por
+// canal.
+//
+// Parâmetros:
+//   palette: paleta de destino
+//
+// Retorna:
+//   error: erro se a paleta for desconhecida
+func (r *Renderer3D) Quantize(palette Palette) error {
+	colors, ok := paletteColors[palette]
+	if !ok {
+		return fmt.Errorf("paleta desconhecida: %q", palette)
+	}
+
+	src := r.image()
+	dst := image.NewRGBA(image.Rect(0, 0, src.Rect.Dx(), src.Rect.Dy()))
+	cache := make(map[color.RGBA]color.RGBA)
+	for y := 0; y < dst.Rect.Dy(); y++ {
+		for x := 0; x < dst.Rect.Dx(); x++ {
+			c := src.RGBAAt(src.Rect.Min.X+x, src.Rect.Min.Y+y)
+			if c.A < 0x80 {
+				continue
+			}
+			snapped, ok := cache[c]
+			if !ok {
+				snapped = nearestColor(c, colors)
+				cache[c] = snapped
+			}
+			dst.SetRGBA(x, y, snapped)
+		}
+	}
+
+	r.setFinalImage(dst)
+	return nil
+}
+
+// nearestColor retorna a cor da paleta mais próxima de c (com as
+// componentes divididas pelo alfa), pela distância euclidiana com pesos
+// 2, 4 e 3 para vermelho, verde e azul.
+//
+// Parâmetros:
+//   c: cor do pixel, pré-multiplicada
+//   colors: cores da paleta
+//
+// Retorna:
+//   color.RGBA: cor da paleta escolhida
+func nearestColor(c color.RGBA, colors []color.RGBA) color.RGBA {
+	unmul := func(v uint8) int { return int(v) * 255 / int(c.A) }
+	cr, cg, cb := unmul(c.R), unmul(c.G), unmul(c.B)
+
+	best, bestDist := colors[0], -1
+	for _, p := range colors {
+		dr, dg, db := cr-int(p.R), cg-int(p.G), cb-int(p.B)
+		dist := 2*dr*dr + 4*dg*dg + 3*db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = p, dist
+		}
+	}
+	return best
+}
@@ -0,0 +1,66 @@
+package renderer
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParsePalette(t *testing.T) {
+	cases := map[string]Palette{
+		"cga":         PaletteCGA,
+		"ZX":          PaletteZX,
+		"zx-spectrum": PaletteZX,
+		" msx ":       PaletteMSX,
+	}
+	for name, want := range cases {
+		if got, err := ParsePalette(name); err != nil || got != want {
+			t.Errorf("ParsePalette(%q): expected %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := ParsePalette("c64"); err == nil {
+		t.Error("Expected error for unknown palette")
+	}
+}
+
+func TestNearestColor(t *testing.T) {
+	cga := paletteColors[PaletteCGA]
+	cases := []struct {
+		in, want color.RGBA
+	}{
+		{color.RGBA{10, 20, 30, 255}, cga[0]},
+		{color.RGBA{60, 220, 240, 255}, cga[1]},
+		{color.RGBA{200, 40, 200, 255}, cga[2]},
+		{color.RGBA{240, 240, 240, 255}, cga[3]},
+		// Pré-multiplicada: branco com metade da opacidade
+		{color.RGBA{128, 128, 128, 128}, cga[3]},
+	}
+	for _, c := range cases {
+		if got := nearestColor(c.in, cga); got != c.want {
+			t.Errorf("nearestColor(%v): expected %v, got %v", c.in, c.want, got)
+		}
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	if err := New(10, 10).Quantize("c64"); err == nil {
+		t.Error("Expected error for unknown palette")
+	}
+
+	r := New(20, 10)
+	r.setColor(colorRGB{R: 0.9, G: 0.2, B: 0.1, A: 1})
+	r.context.Clear()
+	r.setColor(colorRGB{R: 0.1, G: 0.1, B: 0.8, A: 1})
+	r.context.DrawRectangle(10, 0, 10, 10)
+	r.context.Fill()
+
+	if err := r.Quantize(PaletteZX); err != nil {
+		t.Fatalf("Quantize failed: %v", err)
+	}
+	img := r.image()
+	if got, want := img.RGBAAt(2, 5), (color.RGBA{0xd7, 0, 0, 0xff}); got != want {
+		t.Errorf("Expected red %v, got %v", want, got)
+	}
+	if got, want := img.RGBAAt(15, 5), (color.RGBA{0, 0, 0xd7, 0xff}); got != want {
+		t.Errorf("Expected blue %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,183 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"math"
+)
+
+// PNGText é um par palavra-chave/texto gravado num bloco tEXt do PNG,
+// como a figura e a câmera que produziram a imagem.
+type PNGText struct {
+	Keyword string // Palavra-chave (1 a 79 caracteres, ex.: "Title")
+	Text    string // Valor, convertido para Latin-1 ao ser gravado
+}
+
+// pngIHDREnd é a posição, no arquivo PNG, do fim do bloco IHDR: a
+// assinatura (8 bytes) seguida do bloco (4 de tamanho, 4 de tipo, 13 de
+// dados e 4 de CRC). O IHDR é sempre o primeiro bloco e tem sempre esse
+// tamanho; os blocos de cor e de texto são inseridos logo depois dele,
+// antes dos dados da imagem, como a especificação exige.
+const pngIHDREnd = 8 + 4 + 4 + 13 + 4
+
+// Bloco sRGB e, para leitores que não o conhecem, os equivalentes gAMA
+// (gama 1/2,2 × 100000) e cHRM (ponto branco D65 e primárias do sRGB,
+// × 100000), com os valores recomendados pela especificação do PNG.
+var (
+	pngSRGBIntent     = []byte{0} // Intenção de renderização perceptual
+	pngSRGBGamma      = uint32(45455)
+	pngSRGBChromatics = []uint32{31270, 32900, 64000, 33000, 30000, 60000, 15000, 6000}
+)
+
+// EncodePNG codifica a imagem em PNG marcada como sRGB, com a resolução
+// de impressão e blocos de texto.
+//
+// O codificador da biblioteca padrão não grava perfil de cor nem
+// metadados; a saída dele passa por um escritor que insere os blocos
+// sRGB, gAMA, cHRM, pHYs e tEXt após o IHDR, sem guardar a imagem
+// codificada em memória (ver TiledImage.Save). As cores desenhadas já
+// estão em sRGB: a marcação só garante que visualizadores e fluxos de
+// impressão não as interpretem em outro espaço de cor.
+//
+// Parâmetros:
+//   w: destino do arquivo PNG
+//   img: imagem a codificar
+//   dpi: resolução de impressão em pontos por polegada (0 = sem pHYs)
+//   text: blocos de texto, na ordem em que serão gravados
+//
+// Retorna:
+//   error: erro se alguma palavra-chave for inválida, ou erro de E/S
+func EncodePNG(w io.Writer, img image.Image, dpi float64, text []PNGText) error {
+	var chunks bytes.Buffer
+	writeSRGBChunks(&chunks)
+	if dpi > 0 {
+		writePHYsChunk(&chunks, dpi)
+	}
+	for _, t := range text {
+		if err := writePNGText(&chunks, t); err != nil {
+			return err
+		}
+	}
+	return png.Encode(&chunkWriter{w: w, at: pngIHDREnd, chunks: chunks.Bytes()}, img)
+}
+
+// chunkWriter repassa um arquivo codificado ao escritor de destino,
+// inserindo blocos extras numa posição fixa: no PNG, assim que o IHDR
+// termina; no JPEG, logo após o marcador SOI (ver EncodeJPEG).
+type chunkWriter struct {
+	w       io.Writer
+	at      int    // Posição, no arquivo original, onde os blocos entram
+	chunks  []byte // Blocos extras já codificados
+	written int    // Bytes do arquivo original repassados até agora
+}
+
+// Write repassa p, inserindo os blocos extras na posição at.
+func (pw *chunkWriter) Write(p []byte) (int, error) {
+	if pw.chunks == nil || pw.written+len(p) < pw.at {
+		n, err := pw.w.Write(p)
+		pw.written += n
+		return n, err
+	}
+
+	head := pw.at - pw.written
+	if _, err := pw.w.Write(p[:head]); err != nil {
+		return 0, err
+	}
+	if _, err := pw.w.Write(pw.chunks); err != nil {
+		return head, err
+	}
+	pw.chunks = nil
+	n, err := pw.w.Write(p[head:])
+	pw.written += head + n
+	return head + n, err
+}
+
+// writePNGText codifica um bloco tEXt: palavra-chave, um byte nulo e o
+// texto, ambos em Latin-1 (caracteres fora dele viram "?").
+//
+// Parâmetros:
+//   buf: destino do bloco
+//   t: palavra-chave e texto
+//
+// Retorna:
+//   error: erro se a palavra-chave for vazia, longa demais ou tiver
+//          caracteres não permitidos
+func writePNGText(buf *bytes.Buffer, t PNGText) error {
+	for _, c := range t.Keyword {
+		if c < 32 || (c > 126 && c < 161) || c > 255 {
+			return fmt.Errorf("palavra-chave PNG inválida: %q (só caracteres Latin-1 imprimíveis)", t.Keyword)
+		}
+	}
+	keyword := latin1(t.Keyword)
+	if len(keyword) == 0 || len(keyword) > 79 {
+		return fmt.Errorf("palavra-chave PNG inválida: %q (deve ter de 1 a 79 caracteres)", t.Keyword)
+	}
+
+	writePNGChunk(buf, "tEXt", append(append(keyword, 0), latin1(t.Text)...))
+	return nil
+}
+
+// writeSRGBChunks codifica os blocos que marcam a imagem como sRGB: o
+// próprio sRGB e os equivalentes gAMA e cHRM.
+func writeSRGBChunks(buf *bytes.Buffer) {
+	writePNGChunk(buf, "sRGB", pngSRGBIntent)
+	writePNGChunk(buf, "gAMA", binary.BigEndian.AppendUint32(nil, pngSRGBGamma))
+
+	var chrm []byte
+	for _, v := range pngSRGBChromatics {
+		chrm = binary.BigEndian.AppendUint32(chrm, v)
+	}
+	writePNGChunk(buf, "cHRM", chrm)
+}
+
+// writePHYsChunk codifica o bloco pHYs, que guarda a resolução de
+// impressão em pixels por metro, igual nos dois eixos.
+//
+// Parâmetros:
+//   buf: destino do bloco
+//   dpi: resolução em pontos por polegada
+func writePHYsChunk(buf *bytes.Buffer, dpi float64) {
+	ppm := uint32(math.Round(dpi / mmPerInch * 1000))
+	data := binary.BigEndian.AppendUint32(nil, ppm)
+	data = binary.BigEndian.AppendUint32(data, ppm)
+	writePNGChunk(buf, "pHYs", append(data, 1)) // Unidade: metro
+}
+
+// writePNGChunk codifica um bloco PNG: tamanho, tipo, dados e o CRC do
+// tipo e dos dados.
+//
+// Parâmetros:
+//   buf: destino do bloco
+//   kind: tipo do bloco (quatro letras, ex.: "tEXt")
+//   data: dados do bloco
+func writePNGChunk(buf *bytes.Buffer, kind string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], kind)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+
+	buf.Write(header[:])
+	buf.Write(data)
+	buf.Write(binary.BigEndian.AppendUint32(nil, crc.Sum32()))
+}
+
+// latin1 converte um texto UTF-8 para Latin-1 (ISO 8859-1), a codificação
+// dos blocos tEXt, trocando por "?" os caracteres que não existem nela.
+func latin1(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, c := range s {
+		if c > 255 {
+			c = '?'
+		}
+		out = append(out, byte(c))
+	}
+	return out
+}
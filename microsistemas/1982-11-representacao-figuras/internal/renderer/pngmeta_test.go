@@ -0,0 +1,183 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readPNGChunks devolve os tipos dos blocos de um arquivo PNG, em ordem,
+// e o conteúdo dos blocos tEXt, conferindo o CRC de todos os blocos.
+func readPNGChunks(t *testing.T, data []byte) (kinds []string, text []PNGText) {
+	t.Helper()
+	for i := 8; i < len(data); {
+		n := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		body := data[i+8 : i+8+n]
+		if crc := binary.BigEndian.Uint32(data[i+8+n:]); crc != crc32.ChecksumIEEE(data[i+4:i+8+n]) {
+			t.Fatalf("Bad CRC in %s chunk", kind)
+		}
+		kinds = append(kinds, kind)
+		if kind == "tEXt" {
+			k := bytes.IndexByte(body, 0)
+			text = append(text, PNGText{Keyword: string(body[:k]), Text: string(body[k+1:])})
+		}
+		i += 12 + n
+	}
+	return kinds, text
+}
+
+func TestEncodePNG_Text(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	text := []PNGText{
+		{Keyword: "Title", Text: "cubo"},
+		{Keyword: "Camera", Text: "observador:\n    x: 0\ndistancia: 8\n"},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img, 0, text); err != nil {
+		t.Fatalf("EncodePNG failed: %v", err)
+	}
+
+	decoded, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Encoded PNG does not decode: %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+	}
+
+	kinds, got := readPNGChunks(t, buf.Bytes())
+	if len(got) != len(text) {
+		t.Fatalf("Expected %d text chunks, got %d", len(text), len(got))
+	}
+	for i := range text {
+		if got[i] != text[i] {
+			t.Errorf("Chunk %d: expected %+v, got %+v", i, text[i], got[i])
+		}
+	}
+	want := []string{"IHDR", "sRGB", "gAMA", "cHRM", "tEXt", "tEXt", "IDAT"}
+	if !reflect.DeepEqual(kinds[:len(want)], want) {
+		t.Errorf("Expected chunks %v before the image data, got %v", want, kinds)
+	}
+}
+
+func TestEncodePNG_PHYs(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img, 300, []PNGText{{Keyword: "Title", Text: "cubo"}}); err != nil {
+		t.Fatalf("EncodePNG failed: %v", err)
+	}
+	kinds, _ := readPNGChunks(t, buf.Bytes())
+	if want := []string{"IHDR", "sRGB", "gAMA", "cHRM", "pHYs", "tEXt", "IDAT"}; !reflect.DeepEqual(kinds[:len(want)], want) {
+		t.Errorf("Expected chunks %v, got %v", want, kinds)
+	}
+
+	// pHYs depois de sRGB (13 bytes), gAMA (16) e cHRM (44)
+	phys := buf.Bytes()[pngIHDREnd+13+16+44:]
+	x := binary.BigEndian.Uint32(phys[8:])
+	y := binary.BigEndian.Uint32(phys[12:])
+	if x != 11811 || y != 11811 || phys[16] != 1 {
+		t.Errorf("Expected 11811 pixels per metre on both axes, got %d×%d (unit %d)", x, y, phys[16])
+	}
+
+	// Sem resolução, nenhum bloco pHYs
+	buf.Reset()
+	if err := EncodePNG(&buf, img, 0, nil); err != nil {
+		t.Fatalf("EncodePNG failed: %v", err)
+	}
+	if kinds, _ := readPNGChunks(t, buf.Bytes()); strings.Contains(strings.Join(kinds, " "), "pHYs") {
+		t.Errorf("Expected no pHYs chunk without DPI, got %v", kinds)
+	}
+}
+
+func TestEncodePNG_SRGB(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	img.Set(2, 1, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img, 0, nil); err != nil {
+		t.Fatalf("EncodePNG failed: %v", err)
+	}
+	kinds, _ := readPNGChunks(t, buf.Bytes())
+	if want := []string{"IHDR", "sRGB", "gAMA", "cHRM", "IDAT"}; !reflect.DeepEqual(kinds[:len(want)], want) {
+		t.Errorf("Expected chunks %v, got %v", want, kinds)
+	}
+
+	// gAMA logo após o sRGB (13 bytes: 4 de tamanho, 4 de tipo, 1 de
+	// dados e 4 de CRC)
+	gamma := binary.BigEndian.Uint32(buf.Bytes()[pngIHDREnd+13+8:])
+	if gamma != 45455 {
+		t.Errorf("Expected gamma 45455, got %d", gamma)
+	}
+
+	// A marcação não altera as cores gravadas
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Encoded PNG does not decode: %v", err)
+	}
+	if r, g, b, _ := decoded.At(2, 1).RGBA(); r>>8 != 200 || g>>8 != 100 || b>>8 != 50 {
+		t.Errorf("Expected color (200, 100, 50), got (%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestChunkWriter_SplitWrites(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	var whole bytes.Buffer
+	if err := EncodePNG(&whole, img, 0, []PNGText{{Keyword: "Title", Text: "casa"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Escritas do PNG original em pedaços de tamanhos variados, cruzando
+	// o fim do IHDR no meio de um deles
+	var plain bytes.Buffer
+	if err := png.Encode(&plain, img); err != nil {
+		t.Fatal(err)
+	}
+	var dst bytes.Buffer
+	var chunks bytes.Buffer
+	writeSRGBChunks(&chunks)
+	if err := writePNGText(&chunks, PNGText{Keyword: "Title", Text: "casa"}); err != nil {
+		t.Fatal(err)
+	}
+	pw := &chunkWriter{w: &dst, at: pngIHDREnd, chunks: chunks.Bytes()}
+	data := plain.Bytes()
+	for _, size := range []int{5, 20, 11, len(data) - 36} {
+		if _, err := pw.Write(data[:size]); err != nil {
+			t.Fatal(err)
+		}
+		data = data[size:]
+	}
+
+	if !bytes.Equal(dst.Bytes(), whole.Bytes()) {
+		t.Error("Expected the same file regardless of how the writes are split")
+	}
+}
+
+func TestEncodePNG_Latin1(t *testing.T) {
+	var buf bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	if err := EncodePNG(&buf, img, 0, []PNGText{{Keyword: "Title", Text: "Cubo Técnico → vista"}}); err != nil {
+		t.Fatalf("EncodePNG failed: %v", err)
+	}
+	_, got := readPNGChunks(t, buf.Bytes())
+	if want := "Cubo T\xe9cnico ? vista"; len(got) != 1 || got[0].Text != want {
+		t.Errorf("Expected Latin-1 text %q, got %+v", want, got)
+	}
+}
+
+func TestEncodePNG_InvalidKeyword(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	for _, keyword := range []string{"", "Câmera → final", "linha\nnova", strings.Repeat("k", 80)} {
+		if err := EncodePNG(&bytes.Buffer{}, img, 0, []PNGText{{Keyword: keyword}}); err == nil {
+			t.Errorf("Expected error for keyword %q", keyword)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/gomono"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultPointTableSize é a altura padrão da fonte da tabela de pontos,
+// em pixels da imagem final.
+const DefaultPointTableSize = 12
+
+// Espaçamentos da tabela de pontos, em frações da altura da fonte.
+const (
+	pointTableRow     = 1.4 // Altura de cada linha
+	pointTablePadding = 0.6 // Espaço entre o texto e as bordas do quadro
+)
+
+// PointTable é a tabela de pontos: o quadro que relaciona o nome de
+// cada ponto nomeado às suas coordenadas, como as tabelas do artigo.
+type PointTable struct {
+	Position AnnotationPosition // Canto (ou borda) da imagem
+	Size     float64            // Altura da fonte em pixels
+}
+
+// tableFont é a fonte da tabela de pontos: a Go Mono, de largura fixa,
+// para que as colunas fiquem alinhadas apenas com espaços.
+var tableFont, _ = truetype.Parse(gomono.TTF)
+
+// pointTableRows monta as linhas da tabela de pontos, já alinhadas em
+// colunas: o cabeçalho e uma linha por ponto nomeado, na ordem da
+// figura. Os nomes ficam à esquerda e as coordenadas, à direita.
+//
+// Parâmetros:
+//   points: pontos da figura
+//
+// Retorna:
+//   []string: linhas da tabela (nil se nenhum ponto tem nome)
+func pointTableRows(points []types.Point3D) []string {
+	cells := [][4]string{{"Ponto", "X", "Y", "Z"}}
+	for _, p := range points {
+		if p.Nome == "" {
+			continue
+		}
+		format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+		cells = append(cells, [4]string{p.Nome, format(p.X), format(p.Y), format(p.Z)})
+	}
+	if len(cells) == 1 {
+		return nil
+	}
+
+	var widths [4]int
+	for _, row := range cells {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	rows := make([]string, len(cells))
+	for i, c := range cells {
+		rows[i] = fmt.Sprintf("%-*s  %*s  %*s  %*s",
+			widths[0], c[0], widths[1], c[1], widths[2], c[2], widths[3], c[3])
+	}
+	return rows
+}
+
+// drawPointTable desenha a tabela de pontos na área do fundo (ver
+// backgroundArea), no canto configurado.
+//
+// O quadro é preenchido com a cor de fundo e contornado com a cor das
+// linhas, como o carimbo, com um traço separando o cabeçalho. Se os
+// pontos não cabem na altura da imagem, as últimas linhas dão lugar a
+// uma indicação de quantos ficaram de fora. Como nos títulos (ver
+// drawAnnotation), o texto é escrito nos pixels da tela interna.
+//
+// Parâmetros:
+//   figure: figura cujos pontos nomeados são listados
+//   cfg: configurações visuais (tabela e cores)
+func (r *Renderer3D) drawPointTable(figure *types.Figure, cfg RenderConfig) {
+	rows := pointTableRows(figure.Pontos)
+	if rows == nil {
+		return
+	}
+	scale := float64(r.scale)
+	size := cfg.PointTable.Size
+	row, padding := size*pointTableRow, size*pointTablePadding
+	area := r.backgroundArea()
+
+	// Linhas que cabem na altura disponível
+	fit := int((area.Height - 2*annotationMargin - 2*padding) / row)
+	if fit < 2 {
+		return
+	}
+	if len(rows) > fit {
+		hidden := len(rows) - fit + 1
+		rows = append(rows[:fit-1], fmt.Sprintf("... mais %d", hidden))
+	}
+
+	r.context.Push()
+	defer r.context.Pop()
+	r.context.Identity()
+	r.context.SetFontFace(truetype.NewFace(tableFont, &truetype.Options{Size: size * scale}))
+
+	width := 0.0
+	for _, text := range rows {
+		w, _ := r.context.MeasureString(text)
+		width = math.Max(width, w/scale)
+	}
+	width += 2 * padding
+	height := float64(len(rows))*row + 2*padding
+
+	x := area.X + annotationMargin
+	switch cfg.PointTable.Position {
+	case PositionTopRight, PositionBottomRight:
+		x = area.X + area.Width - annotationMargin - width
+	case PositionTop, PositionBottom:
+		x = area.X + (area.Width-width)/2
+	}
+	y := area.Y + annotationMargin
+	switch cfg.PointTable.Position {
+	case PositionBottom, PositionBottomLeft, PositionBottomRight:
+		y = area.Y + area.Height - annotationMargin - height
+	}
+
+	r.context.DrawRectangle(x*scale, y*scale, width*scale, height*scale)
+	r.setColor(cfg.Background)
+	r.context.FillPreserve()
+	r.setColor(cfg.LineColor)
+	r.setLineWidth(1)
+	r.context.Stroke()
+
+	// Traço sob o cabeçalho
+	header := (y + padding + row) * scale
+	r.context.DrawLine(x*scale, header, (x+width)*scale, header)
+	r.context.Stroke()
+
+	for i, text := range rows {
+		center := y + padding + (float64(i)+0.5)*row
+		r.context.DrawStringAnchored(text, (x+padding)*scale, center*scale, 0, 0.35)
+	}
+}
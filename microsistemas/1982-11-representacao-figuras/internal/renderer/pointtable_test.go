@@ -0,0 +1,59 @@
+package renderer
+
+import (
+	"reflect"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestPointTableRows(t *testing.T) {
+	points := []types.Point3D{
+		{X: -1.5, Y: 8, Z: -1.5, Nome: "A"},
+		{X: 0, Y: 0, Z: 0},
+		{X: 0, Y: 8, Z: 3, Nome: "TOPO"},
+	}
+	want := []string{
+		"Ponto     X  Y     Z",
+		"A      -1.5  8  -1.5",
+		"TOPO      0  8     3",
+	}
+	if got := pointTableRows(points); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected rows\n%q\ngot\n%q", want, got)
+	}
+
+	if got := pointTableRows([]types.Point3D{{X: 1}}); got != nil {
+		t.Errorf("Expected no rows without named points, got %q", got)
+	}
+}
+
+func TestRenderFigureWithConfig_PointTable(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5, Nome: "A"}, {X: 1, Y: 5, Nome: "B"}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.PointTable = &PointTable{Position: PositionBottomLeft, Size: DefaultPointTableSize}
+
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.image()
+
+	// Quadro de três linhas (cabeçalho e dois pontos) no canto inferior
+	// esquerdo: borda esquerda e topo do quadro
+	height := 3*DefaultPointTableSize*pointTableRow + 2*DefaultPointTableSize*pointTablePadding
+	top := int(300 - annotationMargin - height)
+	if got := img.RGBAAt(annotationMargin, 300-annotationMargin-10); got.R > 128 {
+		t.Errorf("Expected table left border, got %v", got)
+	}
+	if got := img.RGBAAt(annotationMargin+20, top); got.R > 128 {
+		t.Errorf("Expected table top border at row %d, got %v", top, got)
+	}
+	if got := img.RGBAAt(annotationMargin+20, top-4); got.R < 250 {
+		t.Errorf("Expected nothing above the table, got %v", got)
+	}
+}
@@ -0,0 +1,206 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"representacao-figuras/pkg/types"
+)
+
+// RenderPOVRay monta uma cena do POV-Ray com a figura, para que ela
+// seja renderizada por traçado de raios, com luz e sombra: cada aresta
+// vira um cilindro e cada ponto uma esfera, vistos por uma câmera
+// equivalente à da figura.
+//
+// O POV-Ray usa um sistema de mão esquerda com Y para cima; trocando Y e
+// Z das coordenadas da figura (Z vertical, Y profundidade), a vista do
+// artigo, olhando para +Y, vira a vista padrão do POV-Ray, olhando para
+// +z. A câmera recebe a base do observador (ver cameraBasis) como
+// direction, right e up: na projeção cônica, direction mede a distância
+// R e right e up medem L1 e L2, a tela virtual, que o POV-Ray estica
+// sobre a imagem como o renderizador; na ortogonal, right e up medem a
+// região visível. A projeção oblíqua, que o POV-Ray não tem, é a
+// ortogonal de um cisalhamento da figura (ver obliqueProject). Com
+// proporcao_tela "ajustar" ou "faixas", a tela virtual cresce até a
+// proporção da imagem, e as faixas ficam com a cor do fundo.
+//
+// Os raios dos cilindros e das esferas são os da espessura da linha e
+// dos vértices na imagem, medidos à distância do alvo (ou do centro da
+// figura). As linhas e as arestas das faces que não repetem uma linha
+// reta viram cilindros, as curvas em trechos retos; as esferas arredondam
+// as junções e, com mostrar_vertices, têm o tamanho e a cor dos vértices.
+// Faces, nomes, setas, estilos de linha e os demais acréscimos gráficos
+// ficam de fora. Os raios e as texturas são declarados no início da
+// cena, para que possam ser ajustados à mão.
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (cores, espessura, vértices e proporção)
+//
+// Retorna:
+//   []byte: cena do POV-Ray (.pov), para as dimensões do renderizador
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderPOVRay(figure *types.Figure, cfg RenderConfig) ([]byte, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	// Tela virtual, com a proporção da imagem quando não é esticada
+	width, height := r.camera.Width, r.camera.Height
+	switch cfg.AspectMode {
+	case AspectAdjust:
+		if r.camera.FOV <= 0 {
+			height = width / r.aspect()
+		}
+	case AspectLetterbox:
+		if width/height > r.aspect() {
+			height = width / r.aspect()
+		} else {
+			width = height * r.aspect()
+		}
+	}
+
+	// Tamanho de um pixel no mundo, à distância do alvo ou do centro
+	observer := toVec3(r.camera.Observer)
+	low, high := figure.Bounds()
+	focus := vec3{X: (low.X + high.X) / 2, Y: (low.Y + high.Y) / 2, Z: (low.Z + high.Z) / 2}
+	if r.camera.Target != nil {
+		focus = toVec3(*r.camera.Target)
+	}
+	pixel := width / float64(r.width)
+	if !r.camera.IsParallel() {
+		if depth := focus.sub(observer).dot(r.basis.forward); depth > 0 {
+			pixel *= depth / r.camera.Distance
+		}
+	}
+	edgeRadius := cfg.LineWidth / 2 * pixel
+	vertexRadius, vertexColor := edgeRadius, cfg.LineColor
+	if cfg.ShowVertices {
+		vertexRadius, vertexColor = math.Max(2, cfg.LineWidth/2)*pixel, cfg.VertexColor
+	}
+
+	var buf bytes.Buffer
+	if figure.Nome != "" {
+		fmt.Fprintf(&buf, "// %s\n", figure.Nome)
+	}
+	fmt.Fprintf(&buf, "// Cena do POV-Ray gerada por figuras3d; renderize com\n")
+	fmt.Fprintf(&buf, "// povray +W%d +H%d +A cena.pov\n", r.width, r.height)
+	fmt.Fprintf(&buf, "#version 3.7;\n")
+	fmt.Fprintf(&buf, "global_settings { assumed_gamma 1.0 }\n\n")
+
+	fmt.Fprintf(&buf, "#declare RaioAresta = %s;\n", povNumber(edgeRadius))
+	fmt.Fprintf(&buf, "#declare RaioVertice = %s;\n", povNumber(vertexRadius))
+	fmt.Fprintf(&buf, "#declare Aresta = texture { pigment { %s } finish { ambient 0.2 diffuse 0.8 specular 0.3 } }\n", povColor(cfg.LineColor))
+	fmt.Fprintf(&buf, "#declare Vertice = texture { pigment { %s } finish { ambient 0.2 diffuse 0.8 specular 0.3 } }\n\n", povColor(vertexColor))
+
+	projection := "perspective"
+	direction := r.basis.forward.scale(r.camera.Distance)
+	if r.camera.IsParallel() {
+		projection, direction = "orthographic", r.basis.forward
+	}
+	fmt.Fprintf(&buf, "camera {\n")
+	fmt.Fprintf(&buf, "  %s\n", projection)
+	fmt.Fprintf(&buf, "  location %s\n", povVector(observer))
+	fmt.Fprintf(&buf, "  direction %s\n", povVector(direction))
+	fmt.Fprintf(&buf, "  right %s\n", povVector(r.basis.right.scale(width)))
+	fmt.Fprintf(&buf, "  up %s\n", povVector(r.basis.up.scale(height)))
+	fmt.Fprintf(&buf, "}\n\n")
+
+	background := cfg.Background
+	background.A = 1
+	fmt.Fprintf(&buf, "background { %s }\n", povColor(background))
+	fmt.Fprintf(&buf, "light_source { %s color rgb 1 }\n\n", povVector(observer))
+
+	fmt.Fprintf(&buf, "union {\n")
+	edge := func(a, b types.Point3D) {
+		if a.X == b.X && a.Y == b.Y && a.Z == b.Z {
+			return // O POV-Ray recusa cilindros sem comprimento
+		}
+		fmt.Fprintf(&buf, "  cylinder { %s, %s, RaioAresta texture { Aresta } }\n", povVector(toVec3(a)), povVector(toVec3(b)))
+	}
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			curve := linha.Curve(figure.Pontos)
+			for i := 1; i < len(curve); i++ {
+				edge(curve[i-1], curve[i])
+			}
+			if len(linha.Controle) == 0 {
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+			}
+		}
+	}
+	for _, e := range figure.FaceEdges() {
+		if !drawn[[2]int{min(e.P1, e.P2), max(e.P1, e.P2)}] {
+			edge(figure.Pontos[e.P1], figure.Pontos[e.P2])
+		}
+	}
+	for _, p := range figure.Pontos {
+		fmt.Fprintf(&buf, "  sphere { %s, RaioVertice texture { Vertice } }\n", povVector(toVec3(p)))
+	}
+
+	// Projeção oblíqua: o cisalhamento da figura, que a câmera ortogonal
+	// projeta (ver obliqueShear); o POV-Ray aplica a matriz M à direita
+	// de cada ponto, e a sua última linha é a translação
+	if r.camera.Projection == types.ProjectionOblique {
+		s, origin := r.obliqueShear()
+		shear, forward := povAxes(s), povAxes(r.basis.forward)
+		offset := -origin.dot(r.basis.forward)
+		var matrix [12]float64
+		for i := range 3 {
+			for j := range 3 {
+				matrix[3*i+j] = forward[i] * shear[j]
+				if i == j {
+					matrix[3*i+j]++
+				}
+			}
+			matrix[9+i] = shear[i] * offset
+		}
+		fmt.Fprintf(&buf, "  matrix <")
+		for i, v := range matrix {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(povNumber(v))
+		}
+		fmt.Fprintf(&buf, ">\n")
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return buf.Bytes(), nil
+}
+
+// povAxes retorna as componentes de um vetor da figura nos eixos do
+// POV-Ray: x, z e y.
+func povAxes(v vec3) [3]float64 {
+	return [3]float64{v.X, v.Z, v.Y}
+}
+
+// povVector escreve um vetor da figura nos eixos do POV-Ray (ver povAxes).
+func povVector(v vec3) string {
+	a := povAxes(v)
+	return fmt.Sprintf("<%s, %s, %s>", povNumber(a[0]), povNumber(a[1]), povNumber(a[2]))
+}
+
+// povColor escreve uma cor sRGB, com a transparência das cores que não
+// são opacas.
+func povColor(c colorRGB) string {
+	if c.A < 1 {
+		return fmt.Sprintf("srgbt <%s, %s, %s, %s>", povNumber(c.R), povNumber(c.G), povNumber(c.B), povNumber(1-c.A))
+	}
+	return fmt.Sprintf("srgb <%s, %s, %s>", povNumber(c.R), povNumber(c.G), povNumber(c.B))
+}
+
+// povNumber escreve um número em notação decimal, com até 6 algarismos
+// significativos: mais que o bastante para uma cena.
+func povNumber(v float64) string {
+	s := strconv.FormatFloat(v+0, 'g', 6, 64) // +0: -0 vira 0
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.FormatFloat(f+0, 'f', -1, 64)
+	}
+	return s
+}
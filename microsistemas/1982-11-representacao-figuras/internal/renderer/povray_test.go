@@ -0,0 +1,174 @@
+package renderer
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// povScene guarda a câmera e a matriz lidas de uma cena do POV-Ray.
+type povScene struct {
+	orthographic                   bool
+	location, direction, right, up vec3
+	matrix                         []float64
+}
+
+var povVectorPattern = regexp.MustCompile(`^\s*(location|direction|right|up) <(.+)>$`)
+
+// readPOVScene lê a câmera e a matriz de uma cena gerada por RenderPOVRay,
+// já nos eixos do POV-Ray.
+func readPOVScene(t *testing.T, text string) povScene {
+	t.Helper()
+	numbers := func(s string) []float64 {
+		var values []float64
+		for _, field := range strings.Split(s, ",") {
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				t.Fatalf("Invalid number %q in %q", field, s)
+			}
+			values = append(values, v)
+		}
+		return values
+	}
+	var scene povScene
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "orthographic" {
+			scene.orthographic = true
+		}
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "matrix <"); ok {
+			scene.matrix = numbers(strings.TrimSuffix(rest, ">"))
+		}
+		m := povVectorPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		v := numbers(m[2])
+		vec := vec3{X: v[0], Y: v[1], Z: v[2]}
+		switch m[1] {
+		case "location":
+			scene.location = vec
+		case "direction":
+			scene.direction = vec
+		case "right":
+			scene.right = vec
+		case "up":
+			scene.up = vec
+		}
+	}
+	return scene
+}
+
+// project projeta um ponto da figura como o POV-Ray faria com a câmera
+// e a matriz da cena, em pixels de uma imagem width×height.
+func (s povScene) project(p types.Point3D, width, height int) types.Point2D {
+	q := vec3{X: p.X, Y: p.Z, Z: p.Y}
+	if s.matrix != nil {
+		m := s.matrix
+		q = vec3{
+			X: q.X*m[0] + q.Y*m[3] + q.Z*m[6] + m[9],
+			Y: q.X*m[1] + q.Y*m[4] + q.Z*m[7] + m[10],
+			Z: q.X*m[2] + q.Y*m[5] + q.Z*m[8] + m[11],
+		}
+	}
+	d := q.sub(s.location)
+	u := d.dot(s.right) / s.right.dot(s.right)
+	v := d.dot(s.up) / s.up.dot(s.up)
+	if !s.orthographic {
+		depth := d.dot(s.direction) / s.direction.dot(s.direction)
+		u, v = u/depth, v/depth
+	}
+	return types.Point2D{X: (u + 0.5) * float64(width), Y: (0.5 - v) * float64(height)}
+}
+
+func TestRenderPOVRayCamera(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "casa",
+		Pontos: []types.Point3D{
+			{X: -1, Y: 4, Z: -1}, {X: 1, Y: 4, Z: -1}, {X: 1, Y: 6, Z: -1}, {X: -1, Y: 6, Z: -1},
+			{X: -1, Y: 4, Z: 1}, {X: 1, Y: 4, Z: 1}, {X: 1, Y: 6, Z: 1}, {X: -1, Y: 6, Z: 1},
+			{X: 0, Y: 5, Z: 2},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 4, P2: 8}},
+	}
+	target := types.Point3D{X: 0, Y: 5, Z: 0}
+	cameras := map[string]types.Camera{
+		"artigo": types.DefaultCamera(),
+		"alvo":   {Observer: types.Point3D{X: 6, Y: -3, Z: 4}, Target: &target, Distance: 2, FOV: 50},
+		"orbita": {Target: &target, Orbit: &types.Orbit{Azimuth: 120, Elevation: -30, Radius: 9}, Distance: 1, Width: 1.2, Height: 0.7},
+		"ortogonal": {Observer: types.Point3D{X: 5, Y: 0, Z: 5}, Target: &target, Distance: 1, Width: 6, Height: 4,
+			Projection: types.ProjectionOrthographic},
+		"obliqua": {Observer: types.Point3D{X: 0, Y: -10, Z: 0}, Target: &target, Distance: 1, Width: 8, Height: 6,
+			Projection: types.ProjectionOblique, ObliqueAngle: 30, ObliqueFactor: 0.5},
+	}
+	for name, camera := range cameras {
+		t.Run(name, func(t *testing.T) {
+			r := New(640, 400)
+			r.SetCamera(camera)
+			data, err := r.RenderPOVRay(figure, DefaultRenderConfig())
+			if err != nil {
+				t.Fatalf("RenderPOVRay failed: %v", err)
+			}
+			scene := readPOVScene(t, string(data))
+			if scene.orthographic != camera.IsParallel() {
+				t.Errorf("Expected orthographic %v", camera.IsParallel())
+			}
+			if (scene.matrix != nil) != (camera.Projection == types.ProjectionOblique) {
+				t.Errorf("Expected matrix only for the oblique projection, got %v", scene.matrix)
+			}
+			for i, p := range figure.Pontos {
+				want := r.ProjectToScreen(p)
+				got := scene.project(p, 640, 400)
+				if math.Abs(got.X-want.X) > 0.01 || math.Abs(got.Y-want.Y) > 0.01 {
+					t.Errorf("Point %d: expected %v, POV-Ray would draw it at %v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPOVRay(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: 0}, {X: 1}, {X: 1, Z: 1}, {X: 0, Z: 1}, {X: 0, Z: 1}},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 3, P2: 4}}, // A segunda não tem comprimento
+		Faces:  []types.Face{{Pontos: []int{0, 1, 2, 3}}},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	r := New(400, 300)
+	r.SetCamera(figure.Camera)
+	cfg := DefaultRenderConfig()
+	cfg.ShowVertices = true
+	cfg.VertexColor = colorRGB{R: 1, A: 0.5}
+	data, err := r.RenderPOVRay(figure, cfg)
+	if err != nil {
+		t.Fatalf("RenderPOVRay failed: %v", err)
+	}
+	text := string(data)
+
+	// A linha 0-1 e as três arestas das faces que não a repetem
+	if n := strings.Count(text, "  cylinder {"); n != 4 {
+		t.Errorf("Expected 4 cylinders, got %d:\n%s", n, text)
+	}
+	if n := strings.Count(text, "  sphere {"); n != 5 {
+		t.Errorf("Expected 5 spheres, got %d", n)
+	}
+	for _, want := range []string{
+		"#version 3.7;\n",
+		"+W400 +H300",
+		"#declare Vertice = texture { pigment { srgbt <1, 0, 0, 0.5> }",
+		"background { srgb <1, 1, 1> }\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in scene:\n%s", want, text)
+		}
+	}
+
+	if _, err := New(400, 300).RenderPOVRay(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
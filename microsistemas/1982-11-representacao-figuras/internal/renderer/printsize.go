@@ -0,0 +1,130 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Tamanho padrão da imagem de saída, em pixels.
+const (
+	DefaultCanvasWidth  = 800
+	DefaultCanvasHeight = 600
+)
+
+// DefaultDPI é a resolução de impressão usada quando o tamanho da imagem
+// é dado em medidas físicas sem "dpi".
+const DefaultDPI = 300
+
+// mmPerInch é o número de milímetros numa polegada.
+const mmPerInch = 25.4
+
+// lengthUnits mapeia as unidades aceitas nas medidas físicas ao seu
+// tamanho em milímetros.
+var lengthUnits = map[string]float64{
+	"mm":  1,
+	"cm":  10,
+	"in":  mmPerInch,
+	"pol": mmPerInch,
+	"\"":  mmPerInch,
+}
+
+// ParseLength converte uma medida física, como "120mm", "12 cm" ou
+// "4.7in", para milímetros.
+//
+// Parâmetros:
+//   value: número seguido da unidade (mm, cm, in ou pol)
+//
+// Retorna:
+//   float64: medida em milímetros
+//   error: erro se a unidade faltar ou for desconhecida, ou se a medida
+//          não for positiva
+func ParseLength(value string) (float64, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	i := strings.IndexFunc(s, func(c rune) bool {
+		return (c < '0' || c > '9') && c != '.' && c != ','
+	})
+	if i < 0 {
+		return 0, fmt.Errorf("medida sem unidade: %q (use mm, cm, in ou pol)", value)
+	}
+	unit, ok := lengthUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0, fmt.Errorf("unidade desconhecida: %q (use mm, cm, in ou pol)", value)
+	}
+	n, err := strconv.ParseFloat(strings.Replace(s[:i], ",", ".", 1), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("medida inválida: %q (deve ser positiva)", value)
+	}
+	return n * unit, nil
+}
+
+// CanvasSize calcula as dimensões da imagem de saída em pixels.
+//
+// O padrão é 800×600; a seção "render" do YAML pode alterá-las em
+// pixels (largura_canvas e altura_canvas) ou em medidas físicas
+// (largura_impressa e altura_impressa), convertidas pela resolução de
+// impressão (dpi, 300 se omitida). Com só uma das medidas físicas, a
+// outra mantém a proporção 4:3 do padrão.
+//
+// Parâmetros:
+//   settings: configurações de renderização (nil = padrão)
+//
+// Retorna:
+//   width, height: dimensões em pixels
+//   error: erro se pixels e medidas físicas forem misturados, ou se
+//          alguma medida ou a resolução for inválida
+func CanvasSize(settings *types.RenderSettings) (width, height int, err error) {
+	width, height = DefaultCanvasWidth, DefaultCanvasHeight
+	if settings == nil {
+		return width, height, nil
+	}
+	if settings.DPI < 0 {
+		return 0, 0, fmt.Errorf("dpi inválido: %g (deve ser positivo)", settings.DPI)
+	}
+
+	if settings.PrintWidth == "" && settings.PrintHeight == "" {
+		if settings.CanvasWidth > 0 {
+			width = settings.CanvasWidth
+		}
+		if settings.CanvasHeight > 0 {
+			height = settings.CanvasHeight
+		}
+		return width, height, nil
+	}
+	if settings.CanvasWidth > 0 || settings.CanvasHeight > 0 {
+		return 0, 0, fmt.Errorf("use largura_canvas/altura_canvas ou largura_impressa/altura_impressa, não ambos")
+	}
+
+	dpi := settings.DPI
+	if dpi == 0 {
+		dpi = DefaultDPI
+	}
+	pixels := func(value string) (int, error) {
+		mm, err := ParseLength(value)
+		if err != nil {
+			return 0, err
+		}
+		return int(math.Max(1, math.Round(mm/mmPerInch*dpi))), nil
+	}
+
+	if settings.PrintWidth != "" {
+		if width, err = pixels(settings.PrintWidth); err != nil {
+			return 0, 0, err
+		}
+	}
+	if settings.PrintHeight != "" {
+		if height, err = pixels(settings.PrintHeight); err != nil {
+			return 0, 0, err
+		}
+	}
+	switch {
+	case settings.PrintHeight == "":
+		height = int(math.Max(1, math.Round(float64(width)*DefaultCanvasHeight/DefaultCanvasWidth)))
+	case settings.PrintWidth == "":
+		width = int(math.Max(1, math.Round(float64(height)*DefaultCanvasWidth/DefaultCanvasHeight)))
+	}
+	return width, height, nil
+}
@@ -0,0 +1,78 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		value string
+		mm    float64
+	}{
+		{"120mm", 120},
+		{"12 cm", 120},
+		{"12,5cm", 125},
+		{"4.7in", 119.38},
+		{"2pol", 50.8},
+		{"1\"", 25.4},
+		{" 90MM ", 90},
+	}
+	for _, tt := range tests {
+		mm, err := ParseLength(tt.value)
+		if err != nil {
+			t.Errorf("ParseLength(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if math.Abs(mm-tt.mm) > 1e-9 {
+			t.Errorf("ParseLength(%q): expected %gmm, got %g", tt.value, tt.mm, mm)
+		}
+	}
+
+	for _, value := range []string{"", "120", "120px", "mm", "-5mm", "0cm", "1.2.3in"} {
+		if _, err := ParseLength(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestCanvasSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      *types.RenderSettings
+		width, height int
+	}{
+		{"default", nil, 800, 600},
+		{"pixels", &types.RenderSettings{CanvasWidth: 1024, CanvasHeight: 768}, 1024, 768},
+		{"dpi only", &types.RenderSettings{CanvasWidth: 1024, DPI: 150}, 1024, 600},
+		{"printed at 300 dpi", &types.RenderSettings{PrintWidth: "120mm", PrintHeight: "90mm"}, 1417, 1063},
+		{"printed at 150 dpi", &types.RenderSettings{PrintWidth: "4in", PrintHeight: "3in", DPI: 150}, 600, 450},
+		{"width keeps 4:3", &types.RenderSettings{PrintWidth: "4in", DPI: 100}, 400, 300},
+		{"height keeps 4:3", &types.RenderSettings{PrintHeight: "3in", DPI: 100}, 400, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, err := CanvasSize(tt.settings)
+			if err != nil {
+				t.Fatalf("CanvasSize failed: %v", err)
+			}
+			if width != tt.width || height != tt.height {
+				t.Errorf("Expected %d×%d, got %d×%d", tt.width, tt.height, width, height)
+			}
+		})
+	}
+}
+
+func TestCanvasSize_Invalid(t *testing.T) {
+	for name, settings := range map[string]*types.RenderSettings{
+		"pixels and printed": {CanvasWidth: 800, PrintHeight: "90mm"},
+		"negative dpi":       {DPI: -300},
+		"unknown unit":       {PrintWidth: "12pt"},
+	} {
+		if _, _, err := CanvasSize(settings); err == nil {
+			t.Errorf("Expected error for %s", name)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"representacao-figuras/pkg/types"
+)
+
+// ProjectedPoint guarda os resultados intermediários da projeção de um
+// ponto, na ordem das etapas de ProjectPoint.
+type ProjectedPoint struct {
+	Point  types.Point3D // Coordenadas do ponto na figura
+	View   types.Point3D // P' = P - V na base do observador (Px, Py, Pz)
+	Plane  types.Point2D // x e y no plano de projeção (x = Px·R/Pz na cônica)
+	NDC    types.Point2D // Coordenadas normalizadas: a tela virtual vai de -1 a +1
+	Screen types.Point2D // Pixels da imagem
+	Front  bool          // false se o ponto está atrás do plano próximo
+}
+
+// ProjectionTable é a projeção de todos os pontos de uma figura com a
+// câmera efetivamente usada, já enquadrada.
+type ProjectionTable struct {
+	Camera        types.Camera
+	Width, Height int // Dimensões da imagem em pixels
+	Points        []ProjectedPoint
+}
+
+// ProjectionTable projeta cada ponto da figura guardando os resultados
+// de cada etapa, para quem confere as fórmulas do artigo à mão.
+//
+// A câmera e a proporção da tela virtual são tratadas como em
+// RenderFigureWithConfig, para que os pixels sejam os da imagem gerada
+// com as mesmas opções. Os pontos atrás do plano próximo de uma
+// projeção cônica não têm projeção: ficam apenas com as coordenadas no
+// sistema do observador.
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (proporção da tela virtual e plano próximo)
+//
+// Retorna:
+//   ProjectionTable: câmera usada e pontos projetados, na ordem da figura
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) ProjectionTable(figure *types.Figure, cfg RenderConfig) (ProjectionTable, error) {
+	if len(figure.Pontos) == 0 {
+		return ProjectionTable{}, fmt.Errorf("figura não possui pontos")
+	}
+
+	outer := r.viewport
+	defer r.SetViewport(outer)
+	switch cfg.AspectMode {
+	case AspectAdjust:
+		if r.camera.FOV <= 0 {
+			r.camera.Height = r.camera.Width / r.aspect()
+		}
+	case AspectLetterbox:
+		if inner, ok := r.letterbox(); ok {
+			r.SetViewport(inner)
+		}
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	near := cfg.NearPlane
+	if near <= 0 {
+		near = DefaultNearPlane
+	}
+	table := ProjectionTable{Camera: r.camera, Width: r.width, Height: r.height, Points: make([]ProjectedPoint, len(figure.Pontos))}
+	for i, p := range figure.Pontos {
+		px, py, pz := r.toCameraSpace(p)
+		point := ProjectedPoint{Point: p, View: types.Point3D{X: px, Y: py, Z: pz}}
+		if r.camera.IsParallel() || pz >= near {
+			point.Front = true
+			point.Plane.X, point.Plane.Y = r.projectPlane(px, py, pz)
+			point.NDC = r.toNDC(point.Plane.X, point.Plane.Y)
+			point.Screen = r.ViewportTransform(point.NDC)
+		}
+		table.Points[i] = point
+	}
+	return table, nil
+}
+
+// CSV escreve a tabela em CSV, uma linha por ponto sob um cabeçalho, com
+// os números completos (sem arredondamento) e ponto decimal. As colunas
+// da projeção ficam vazias nos pontos atrás do plano próximo; visivel é
+// 1 para os pontos projetados dentro da imagem.
+//
+// Retorna:
+//   []byte: tabela em CSV
+func (t ProjectionTable) CSV() []byte {
+	format := func(v float64) string { return strconv.FormatFloat(v+0, 'f', -1, 64) } // +0: -0 vira 0
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"ponto", "nome", "x", "y", "z", "px", "py", "pz",
+		"x_proj", "y_proj", "ndc_x", "ndc_y", "tela_x", "tela_y", "visivel"})
+	for i, p := range t.Points {
+		row := []string{strconv.Itoa(i), p.Point.Nome,
+			format(p.Point.X), format(p.Point.Y), format(p.Point.Z),
+			format(p.View.X), format(p.View.Y), format(p.View.Z)}
+		if !p.Front {
+			row = append(row, "", "", "", "", "", "", "0")
+		} else {
+			visible := "0"
+			if p.Screen.X >= 0 && p.Screen.X <= float64(t.Width) && p.Screen.Y >= 0 && p.Screen.Y <= float64(t.Height) {
+				visible = "1"
+			}
+			row = append(row, format(p.Plane.X), format(p.Plane.Y), format(p.NDC.X), format(p.NDC.Y),
+				format(p.Screen.X), format(p.Screen.Y), visible)
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
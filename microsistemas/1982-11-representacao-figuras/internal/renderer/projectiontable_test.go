@@ -0,0 +1,94 @@
+package renderer
+
+import (
+	"encoding/csv"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestProjectionTable(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 1, Y: 5, Z: 2, Nome: "A"}, // Diante do observador
+			{X: 0, Y: -1, Z: 0},           // Atrás do observador
+			{X: 30, Y: 5, Z: 0},           // Fora da tela
+		},
+	}
+	r := New(400, 300)
+	r.SetCamera(types.DefaultCamera())
+	table, err := r.ProjectionTable(figure, DefaultRenderConfig())
+	if err != nil {
+		t.Fatalf("ProjectionTable failed: %v", err)
+	}
+	if len(table.Points) != 3 || table.Width != 400 || table.Height != 300 {
+		t.Fatalf("Expected 3 points for a 400x300 image, got %+v", table)
+	}
+
+	// Cada etapa confere com as fórmulas do artigo e com ProjectToScreen
+	a := table.Points[0]
+	camera := table.Camera
+	px, py, pz := a.Point.X-camera.Observer.X, a.Point.Z-camera.Observer.Z, a.Point.Y-camera.Observer.Y
+	if a.View != (types.Point3D{X: px, Y: py, Z: pz}) {
+		t.Errorf("Expected P' = P - V = (%g, %g, %g), got %+v", px, py, pz, a.View)
+	}
+	x, y := px*camera.Distance/pz, py*camera.Distance/pz
+	if math.Abs(a.Plane.X-x) > 1e-12 || math.Abs(a.Plane.Y-y) > 1e-12 {
+		t.Errorf("Expected x = Px·R/Pz = (%g, %g), got %v", x, y, a.Plane)
+	}
+	if math.Abs(a.NDC.X-2*x/camera.Width) > 1e-12 || math.Abs(a.NDC.Y-2*y/camera.Height) > 1e-12 {
+		t.Errorf("Expected NDC scaled by L1/2 and L2/2, got %v", a.NDC)
+	}
+	if want := r.ProjectToScreen(a.Point); a.Screen != want {
+		t.Errorf("Expected pixels %v, got %v", want, a.Screen)
+	}
+	if !a.Front || table.Points[1].Front || !table.Points[2].Front {
+		t.Errorf("Expected only the second point behind the observer")
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(string(table.CSV()))).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(rows) != 4 || len(rows[0]) != 15 || rows[0][0] != "ponto" {
+		t.Fatalf("Expected header and 3 rows of 15 columns, got %v", rows)
+	}
+	if rows[1][1] != "A" || rows[1][14] != "1" || rows[2][8] != "" || rows[2][14] != "0" || rows[3][14] != "0" {
+		t.Errorf("Unexpected rows: %v", rows[1:])
+	}
+	if v, err := strconv.ParseFloat(rows[1][12], 64); err != nil || v != a.Screen.X {
+		t.Errorf("Expected full precision pixel %v, got %q", a.Screen.X, rows[1][12])
+	}
+
+	if _, err := New(400, 300).ProjectionTable(&types.Figure{}, DefaultRenderConfig()); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
+
+func TestProjectionTableLetterbox(t *testing.T) {
+	figure := &types.Figure{Pontos: []types.Point3D{{X: 1, Y: 5, Z: 2}}}
+	camera := types.DefaultCamera()
+	camera.Width, camera.Height = 4, 4 // Tela virtual quadrada numa imagem larga
+	cfg := DefaultRenderConfig()
+	cfg.AspectMode = AspectLetterbox
+
+	r := New(400, 200)
+	r.SetCamera(camera)
+	table, err := r.ProjectionTable(figure, cfg)
+	if err != nil {
+		t.Fatalf("ProjectionTable failed: %v", err)
+	}
+	// Faixas laterais de 100 pixels: o centro fica no lugar, e a escala
+	// é a da região central de 200×200
+	p := table.Points[0]
+	want := types.Point2D{X: 200 + p.NDC.X*100, Y: 100 - p.NDC.Y*100}
+	if math.Abs(p.Screen.X-want.X) > 1e-9 || math.Abs(p.Screen.Y-want.Y) > 1e-9 {
+		t.Errorf("Expected letterboxed pixels %v, got %v", want, p.Screen)
+	}
+	if r.viewport != r.fullViewport() {
+		t.Errorf("Expected viewport restored, got %+v", r.viewport)
+	}
+}
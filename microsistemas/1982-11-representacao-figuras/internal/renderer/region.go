@@ -0,0 +1,69 @@
+package renderer
+
+import "fmt"
+
+// Region é uma janela retangular da tela virtual, em unidades da câmera.
+//
+// A tela virtual do artigo vai de -L1/2 a +L1/2 na horizontal e de
+// -L2/2 a +L2/2 na vertical, medidas no plano projetante (ou
+// diretamente no mundo, nas projeções paralelas). Renderizar apenas uma
+// sub-janela com a resolução inteira da imagem equivale a uma lente de
+// aumento sobre parte da figura, sem mover o observador.
+type Region struct {
+	XMin, XMax float64 // Limites horizontais (esquerda, direita)
+	YMin, YMax float64 // Limites verticais (baixo, cima)
+}
+
+// Validate verifica se a região tem largura e altura positivas.
+func (g Region) Validate() error {
+	if g.XMax <= g.XMin {
+		return fmt.Errorf("região inválida: xmax (%g) deve ser maior que xmin (%g)", g.XMax, g.XMin)
+	}
+	if g.YMax <= g.YMin {
+		return fmt.Errorf("região inválida: ymax (%g) deve ser maior que ymin (%g)", g.YMax, g.YMin)
+	}
+	return nil
+}
+
+// VirtualScreen retorna a tela virtual inteira da câmera atual (±L1/2, ±L2/2).
+//
+// Útil como ponto de partida para montar uma Region alterando apenas
+// alguns dos limites.
+func (r *Renderer3D) VirtualScreen() Region {
+	return Region{
+		XMin: -r.camera.Width / 2, XMax: r.camera.Width / 2,
+		YMin: -r.camera.Height / 2, YMax: r.camera.Height / 2,
+	}
+}
+
+// SetRegion restringe as próximas projeções a uma sub-janela da tela virtual.
+//
+// A região passa a ocupar o viewport inteiro: o que estiver fora dela
+// é recortado, e o que estiver dentro é ampliado na mesma proporção.
+//
+// Parâmetros:
+//   region: janela em unidades da câmera
+//
+// Retorna:
+//   error: erro se a região for degenerada
+func (r *Renderer3D) SetRegion(region Region) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+	r.region = &region
+	return nil
+}
+
+// ResetRegion volta a projetar a tela virtual inteira.
+func (r *Renderer3D) ResetRegion() {
+	r.region = nil
+}
+
+// window retorna a janela da tela virtual mapeada no viewport: a região
+// configurada ou, por padrão, a tela virtual inteira.
+func (r *Renderer3D) window() Region {
+	if r.region != nil {
+		return *r.region
+	}
+	return r.VirtualScreen()
+}
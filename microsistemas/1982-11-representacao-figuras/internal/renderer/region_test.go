@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestSetRegion(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.DefaultCamera()) // R=10, L1=12.8, L2=9.6
+
+	full := renderer.VirtualScreen()
+	if full != (Region{XMin: -6.4, XMax: 6.4, YMin: -4.8, YMax: 4.8}) {
+		t.Errorf("Unexpected virtual screen: %+v", full)
+	}
+
+	// Ponto em (4, 2) no plano projetante (a R/Pz = 2)
+	p := types.Point3D{X: 2, Y: 5, Z: 1}
+	before := renderer.ProjectToScreen(p)
+
+	// A região inteira não muda nada
+	if err := renderer.SetRegion(full); err != nil {
+		t.Fatalf("SetRegion failed: %v", err)
+	}
+	if got := renderer.ProjectToScreen(p); math.Abs(got.X-before.X) > 1e-9 || math.Abs(got.Y-before.Y) > 1e-9 {
+		t.Errorf("Expected full region to keep projection %v, got %v", before, got)
+	}
+
+	// Quadrante superior direito: (4, 2) fica a 4/6.4 e 2/4.8 da janela
+	if err := renderer.SetRegion(Region{XMin: 0, XMax: 6.4, YMin: 0, YMax: 4.8}); err != nil {
+		t.Fatalf("SetRegion failed: %v", err)
+	}
+	got := renderer.ProjectToScreen(p)
+	if math.Abs(got.X-500) > 1e-9 || math.Abs(got.Y-350) > 1e-9 {
+		t.Errorf("Expected (500, 350) in zoomed quadrant, got %v", got)
+	}
+
+	// Pontos fora da região ficam fora do volume de visão
+	if code := renderer.outcode(renderer.cameraPoint(types.Point3D{X: -1, Y: 5}), DefaultNearPlane); code != outsideLeft {
+		t.Errorf("Expected point left of region to be outside, got %05b", code)
+	}
+
+	// A desprojeção acompanha a região
+	ray := renderer.Unproject(got)
+	if back := renderer.ProjectToScreen(ray.At(12)); math.Abs(back.X-got.X) > 1e-6 || math.Abs(back.Y-got.Y) > 1e-6 {
+		t.Errorf("Expected unprojected ray to project back to %v, got %v", got, back)
+	}
+
+	renderer.ResetRegion()
+	if got := renderer.ProjectToScreen(p); got != before {
+		t.Errorf("Expected original projection after reset, got %v", got)
+	}
+}
+
+func TestSetRegion_Invalid(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.DefaultCamera())
+
+	invalid := []Region{
+		{XMin: 1, XMax: 1, YMin: 0, YMax: 1},
+		{XMin: 0, XMax: 1, YMin: 2, YMax: -2},
+	}
+	for _, region := range invalid {
+		if err := renderer.SetRegion(region); err == nil {
+			t.Errorf("Expected error for region %+v, got nil", region)
+		}
+	}
+	if renderer.region != nil {
+		t.Error("Expected invalid regions to be ignored")
+	}
+}
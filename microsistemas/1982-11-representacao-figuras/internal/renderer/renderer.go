@@ -21,6 +21,8 @@ package renderer
 
 import (
 	"fmt"
+	"image"
+	"math"
 
 	"representacao-figuras/pkg/types"
 
@@ -33,12 +35,29 @@ import (
 // para realizar a projeção de figuras tridimensionais em uma tela 2D,
 // seguindo fielmente as equações descritas no artigo original.
 type Renderer3D struct {
-	context *gg.Context   // Contexto gráfico para desenho (biblioteca gg)
-	width   int           // Largura da tela em pixels
-	height  int           // Altura da tela em pixels
-	camera  types.Camera  // Parâmetros da câmera virtual
-	centerX float64       // Centro X da tela (width/2)
-	centerY float64       // Centro Y da tela (height/2)
+	context  *gg.Context   // Contexto gráfico para desenho (biblioteca gg)
+	width    int           // Largura da tela em pixels
+	height   int           // Altura da tela em pixels
+	camera   types.Camera  // Parâmetros da câmera virtual
+	basis    viewBasis     // Orientação do observador (direita, cima, frente)
+	viewport Viewport      // Região da tela onde a projeção é desenhada
+	region   *Region       // Sub-janela da tela virtual (nil = inteira)
+	backdrop *Viewport     // Área coberta pelo gradiente do fundo (nil = viewport)
+	centerX  float64       // Centro X do viewport (width/2 na tela inteira)
+	centerY  float64       // Centro Y do viewport (height/2 na tela inteira)
+	scale    int           // Fator de superamostragem (ver SetSupersampling)
+	deep     *image.RGBA64 // Gradiente em 16 bits (nil = 8 bits; ver SetBitDepth)
+	near     float64       // Plano próximo de ProjectPoint (ver SetNearPlane)
+}
+
+// Viewport define a região retangular da imagem onde a figura é projetada.
+//
+// Por padrão o viewport cobre a tela inteira, como no HP-85. Dividir a
+// imagem em vários viewports permite compor várias projeções da mesma
+// figura numa só imagem (ex: folha de vistas ortográficas).
+type Viewport struct {
+	X, Y          float64 // Canto superior esquerdo em pixels
+	Width, Height float64 // Dimensões em pixels
 }
 
 // New cria um novo renderizador 3D com as dimensões especificadas.
@@ -66,26 +85,130 @@ func New(width, height int) *Renderer3D {
 	ctx.SetRGB(0, 0, 0) // RGB(0,0,0) = preto
 	ctx.SetLineWidth(1.0) // Linha fina padrão
 
-	return &Renderer3D{
+	r := &Renderer3D{
 		context: ctx,
 		width:   width,
 		height:  height,
-		// Calcula centro da tela para facilitar projeções
-		centerX: float64(width) / 2,
-		centerY: float64(height) / 2,
+		scale:   1,
+		near:    DefaultNearPlane,
+		// Sem câmera definida, olha na direção +Y como no artigo
+		basis: defaultBasis(),
+	}
+
+	// Viewport inicial cobre a tela inteira (também calcula o centro)
+	r.SetViewport(r.fullViewport())
+
+	return r
+}
+
+// fullViewport retorna o viewport que cobre a tela inteira.
+func (r *Renderer3D) fullViewport() Viewport {
+	return Viewport{Width: float64(r.width), Height: float64(r.height)}
+}
+
+// SetViewport define a região da tela onde as próximas projeções são desenhadas.
+//
+// As dimensões L1 e L2 da tela virtual passam a ser mapeadas para este
+// retângulo em vez da tela inteira. Câmeras especificadas por FOV são
+// recalculadas com a proporção do novo viewport.
+//
+// Parâmetros:
+//   v: região em pixels (largura e altura devem ser positivas)
+func (r *Renderer3D) SetViewport(v Viewport) {
+	r.viewport = v
+	r.centerX = v.X + v.Width/2
+	r.centerY = v.Y + v.Height/2
+
+	// Reaplica a câmera para atualizar L1/L2 derivados de FOV
+	r.SetCamera(r.camera)
+}
+
+// fillViewport pinta o viewport atual com a cor corrente do contexto.
+func (r *Renderer3D) fillViewport() {
+	if r.viewport == r.fullViewport() {
+		r.context.Clear()
+		return
+	}
+	vp := r.viewport
+	r.context.DrawRectangle(vp.X, vp.Y, vp.Width, vp.Height)
+	r.context.Fill()
+}
+
+// setColor define a cor de desenho do contexto, com a sua opacidade.
+func (r *Renderer3D) setColor(c colorRGB) {
+	r.context.SetRGBA(c.R, c.G, c.B, c.A)
+}
+
+// setLineEnds define o acabamento das pontas e das junções dos traços.
+func (r *Renderer3D) setLineEnds(cfg RenderConfig) {
+	switch cfg.LineCap {
+	case CapButt:
+		r.context.SetLineCapButt()
+	case CapSquare:
+		r.context.SetLineCapSquare()
+	default:
+		r.context.SetLineCapRound()
+	}
+
+	if cfg.LineJoin == JoinBevel {
+		r.context.SetLineJoinBevel()
+	} else {
+		r.context.SetLineJoinRound()
 	}
 }
 
+// letterbox calcula a maior região centrada no viewport atual com a
+// proporção L1/L2 da câmera (ou da região ampliada, se houver).
+//
+// Retorna:
+//   Viewport: região interna (faixas ficam nas sobras)
+//   bool: false se as proporções já coincidem (nada a fazer)
+func (r *Renderer3D) letterbox() (Viewport, bool) {
+	w := r.window()
+	if w.XMax <= w.XMin || w.YMax <= w.YMin {
+		return r.viewport, false
+	}
+
+	vp := r.viewport
+	camAspect := (w.XMax - w.XMin) / (w.YMax - w.YMin)
+	if math.Abs(camAspect-r.aspect()) < 1e-9 {
+		return vp, false
+	}
+
+	inner := vp
+	if camAspect > r.aspect() {
+		// Tela virtual mais larga: faixas em cima e embaixo
+		inner.Height = vp.Width / camAspect
+		inner.Y = vp.Y + (vp.Height-inner.Height)/2
+	} else {
+		// Tela virtual mais alta: faixas nas laterais
+		inner.Width = vp.Height * camAspect
+		inner.X = vp.X + (vp.Width-inner.Width)/2
+	}
+	return inner, true
+}
+
+// aspect retorna a proporção largura/altura do viewport atual.
+func (r *Renderer3D) aspect() float64 {
+	return r.viewport.Width / r.viewport.Height
+}
+
 // SetCamera define os parâmetros da câmera virtual.
 //
 // Configura a posição do observador (V), a distância do plano projetante (R)
 // e as dimensões da "tela virtual" (L1, L2) conforme descrito no artigo.
 // Estes parâmetros são fundamentais para os cálculos de perspectiva cônica.
 //
+// Se a câmera especifica um campo de visão (FOV), L1 e L2 são derivados
+// do ângulo e da proporção da tela do renderizador. Se especifica um alvo,
+// o observador é orientado para olhar em sua direção.
+//
 // Parâmetros:
 //   camera: configuração da câmera com observador, distância e dimensões
 func (r *Renderer3D) SetCamera(camera types.Camera) {
-	r.camera = camera
+	camera.ResolveOrbit()
+	r.camera = applyFOV(camera, r.aspect())
+	r.basis = cameraBasis(r.camera)
 }
 
 // ProjectPoint implementa a projeção cônica conforme o artigo original.
@@ -97,14 +220,24 @@ func (r *Renderer3D) SetCamera(camera types.Camera) {
 //
 // 1. TRANSLAÇÃO: Move o ponto para o sistema de coordenadas do observador
 //    P' = P - V (onde V é a posição do observador)
+//    Se a câmera tem um alvo, P' é ainda expresso na base orientada do
+//    observador (direita, cima, frente); sem alvo a base é a do artigo
 //
 // 2. PROJEÇÃO CÔNICA: Aplica as fórmulas do artigo (equações na página 7)
 //    x' = P'x * R / P'z
 //    y' = P'y * R / P'z
 //    onde R é a distância do plano projetante
+//    (câmeras com projeção paralela, usadas nas vistas axonométricas,
+//    substituem esta etapa; ver types.ProjectionOrthographic)
 //
-// 3. NORMALIZAÇÃO: Converte para coordenadas de tela (pixels)
-//    Usa as dimensões L1 e L2 para escalar proporcionalmente
+// 3. NORMALIZAÇÃO: Converte para coordenadas normalizadas (NDC)
+//    Divide pelas metades de L1 e L2: a tela virtual vai de -1 a +1
+//    nos dois eixos, com Y crescendo para cima
+//
+// A conversão para pixels fica a cargo de ViewportTransform, para que
+// ferramentas externas (exportação vetorial, seleção, testes) possam
+// trabalhar com as coordenadas projetadas independentes da resolução.
+// ProjectToScreen combina as duas etapas.
 //
 // SISTEMA DE COORDENADAS (conforme implementação):
 // - X: horizontal (largura)
@@ -115,52 +248,190 @@ func (r *Renderer3D) SetCamera(camera types.Camera) {
 //   p: ponto 3D no espaço mundial
 //
 // Retorna:
-//   types.Point2D: ponto projetado em coordenadas de tela (pixels)
+//   types.Point2D: ponto projetado em coordenadas normalizadas (NDC)
 func (r *Renderer3D) ProjectPoint(p types.Point3D) types.Point2D {
 	// === ETAPA 1: TRANSLAÇÃO ===
 	// Move o ponto para o sistema de coordenadas relativo ao observador
 	// Conforme descrito no artigo: P' = P - V
-
-	// Coordenada horizontal (largura)
-	px := p.X - r.camera.Observer.X
-
-	// Coordenada vertical (altura) - note o uso de Z
-	py := p.Z - r.camera.Observer.Z
-
-	// Coordenada de profundidade (distância) - note o uso de Y
-	pz := p.Y - r.camera.Observer.Y
+	// Sem alvo, a base é a do artigo: X largura, Z altura, Y profundidade
+	px, py, pz := r.toCameraSpace(p)
 
 	// === PROTEÇÃO CONTRA DIVISÃO POR ZERO ===
 	// Pontos atrás da câmera (pz ≤ 0) ou muito próximos causam problemas
-	// na divisão. O artigo não trata deste caso, mas é necessário na prática.
-	if pz <= 0.1 {
-		pz = 0.1 // Valor mínimo para evitar divisão por zero
+	// na divisão. O artigo não trata deste caso; para pontos isolados o
+	// melhor possível é aproximá-los do plano próximo (as arestas são
+	// recortadas nele durante a renderização, ver clipNear)
+	if !r.camera.IsParallel() && pz < r.near {
+		pz = r.near
 	}
 
-	// === ETAPA 2: PROJEÇÃO CÔNICA ===
-	// Aplica as fórmulas fundamentais do artigo (equações 2 da página 7)
-	// x = Px * R/Pz
-	// y = Py * R/Pz
-	projX := px * r.camera.Distance / pz
-	projY := py * r.camera.Distance / pz
+	return r.projectCameraSpace(px, py, pz)
+}
 
-	// === ETAPA 3: CONVERSÃO PARA COORDENADAS DE TELA ===
-	// Escala as coordenadas projetadas para o tamanho real da tela
-	// Usa as dimensões L1 (largura) e L2 (altura) da "tela virtual"
-	scaleX := float64(r.width) / r.camera.Width   // pixels por unidade em X
-	scaleY := float64(r.height) / r.camera.Height // pixels por unidade em Y
+// ProjectToScreen projeta um ponto 3D diretamente em pixels.
+//
+// Equivale a ViewportTransform(ProjectPoint(p)).
+//
+// Parâmetros:
+//   p: ponto 3D no espaço mundial
+//
+// Retorna:
+//   types.Point2D: ponto projetado em coordenadas de tela (pixels)
+func (r *Renderer3D) ProjectToScreen(p types.Point3D) types.Point2D {
+	return r.ViewportTransform(r.ProjectPoint(p))
+}
 
-	// Converte para coordenadas finais de tela
-	// Centro da tela + deslocamento escalado
-	screenX := r.centerX + (projX * scaleX)
+// ViewportTransform converte coordenadas normalizadas (NDC) em pixels.
+//
+// O intervalo [-1, +1] de cada eixo é mapeado para o viewport atual
+// (a tela inteira, por padrão). Como em telas o eixo Y cresce para
+// baixo, o sinal de Y é invertido.
+//
+// Parâmetros:
+//   ndc: ponto em coordenadas normalizadas (ver ProjectPoint)
+//
+// Retorna:
+//   types.Point2D: ponto em coordenadas de tela (pixels)
+func (r *Renderer3D) ViewportTransform(ndc types.Point2D) types.Point2D {
+	// === ETAPA 4: CONVERSÃO PARA COORDENADAS DE TELA ===
+	// Centro do viewport + deslocamento escalado pela meia largura/altura
+	screenX := r.centerX + ndc.X*r.viewport.Width/2
 
 	// Y negativo porque em telas o eixo Y cresce para baixo
 	// mas em matemática cresce para cima
-	screenY := r.centerY - (projY * scaleY)
+	screenY := r.centerY - ndc.Y*r.viewport.Height/2
 
 	return types.Point2D{X: screenX, Y: screenY}
 }
 
+// projectCameraSpace aplica as etapas 2 e 3 de ProjectPoint a um ponto
+// já expresso no sistema do observador, retornando NDC.
+//
+// Na projeção cônica, pz deve ser positivo (ver DefaultNearPlane).
+func (r *Renderer3D) projectCameraSpace(px, py, pz float64) types.Point2D {
+	return r.toNDC(r.projectPlane(px, py, pz))
+}
+
+// projectPlane aplica a etapa 2 de ProjectPoint: projeta um ponto do
+// sistema do observador no plano de projeção, em unidades do mundo.
+func (r *Renderer3D) projectPlane(px, py, pz float64) (projX, projY float64) {
+	switch r.camera.Projection {
+	case types.ProjectionOrthographic:
+		// === ETAPA 2 (ALTERNATIVA): PROJEÇÃO PARALELA ORTOGONAL ===
+		// Raios projetantes paralelos à direção de visão: a profundidade
+		// é simplesmente descartada (base das vistas isométricas)
+		projX, projY = px, py
+
+	case types.ProjectionOblique:
+		// === ETAPA 2 (ALTERNATIVA): PROJEÇÃO PARALELA OBLÍQUA ===
+		projX, projY = r.obliqueProject(px, py, pz)
+
+	default:
+		// === ETAPA 2: PROJEÇÃO CÔNICA ===
+		// Aplica as fórmulas fundamentais do artigo (equações 2 da página 7)
+		// x = Px * R/Pz
+		// y = Py * R/Pz
+		projX = px * r.camera.Distance / pz
+		projY = py * r.camera.Distance / pz
+	}
+	return projX, projY
+}
+
+// toNDC aplica a etapa 3 de ProjectPoint a um ponto do plano de
+// projeção, retornando NDC.
+func (r *Renderer3D) toNDC(projX, projY float64) types.Point2D {
+	// === ETAPA 3: NORMALIZAÇÃO ===
+	// Usa as dimensões L1 (largura) e L2 (altura) da "tela virtual":
+	// suas bordas correspondem a ±1 (ou as bordas da região definida
+	// por SetRegion, quando se amplia parte da tela virtual)
+	w := r.window()
+	return types.Point2D{
+		X: (2*projX - w.XMin - w.XMax) / (w.XMax - w.XMin),
+		Y: (2*projY - w.YMin - w.YMax) / (w.YMax - w.YMin),
+	}
+}
+
+// ProjectPoints projeta uma lista de pontos 3D de uma só vez.
+//
+// Equivale a chamar ProjectPoint para cada ponto (inclusive no
+// tratamento de pontos atrás do observador), mas percorre a lista
+// num único laço. Passa pela mesma classificação em lote usada por
+// RenderFigureWithConfig (ver projectBatch), que é o ponto natural
+// para paralelizar a projeção no futuro.
+//
+// Parâmetros:
+//   points: pontos 3D no espaço mundial
+//
+// Retorna:
+//   []types.Point2D: pontos projetados em coordenadas normalizadas (NDC),
+//   na mesma ordem da entrada
+func (r *Renderer3D) ProjectPoints(points []types.Point3D) []types.Point2D {
+	proj := r.projectBatch(points, r.near, true)
+	ndc := make([]types.Point2D, len(points))
+	for i, c := range proj.camera {
+		ndc[i] = r.projectCameraSpace(c.X, c.Y, c.Z) // Com clamp, todos à frente
+	}
+	return ndc
+}
+
+// projectedPoints guarda o resultado da projeção em lote de uma lista de pontos.
+type projectedPoints struct {
+	camera []vec3          // Pontos no sistema do observador
+	codes  []uint8         // Códigos de região do volume de visão (ver outcode)
+	screen []types.Point2D // Pixels, calculados sob demanda (ver screenPoint)
+	done   []bool          // Se o ponto de screen já foi calculado
+}
+
+// projectBatch leva uma lista de pontos ao sistema do observador e
+// calcula seus códigos de região, sem projetá-los.
+//
+// A divisão da projeção e a conversão para pixels ficam para
+// screenPoint, chamado só para os pontos que são de fato desenhados:
+// arestas descartadas pelos códigos (ver visibleEdge) nunca são
+// projetadas, e numa malha grande vista de perto a maior parte dos
+// pontos fica sem projeção.
+//
+// Parâmetros:
+//   points: pontos 3D no espaço mundial
+//   near: profundidade do plano próximo
+//   clamp: se pontos atrás do plano próximo devem ser trazidos até ele
+//          (caso contrário ficam marcados com outsideNear)
+//
+// Retorna:
+//   projectedPoints: coordenadas no sistema do observador e códigos
+func (r *Renderer3D) projectBatch(points []types.Point3D, near float64, clamp bool) projectedPoints {
+	clamp = clamp && !r.camera.IsParallel()
+
+	proj := projectedPoints{
+		camera: make([]vec3, len(points)),
+		codes:  make([]uint8, len(points)),
+		screen: make([]types.Point2D, len(points)),
+		done:   make([]bool, len(points)),
+	}
+
+	for i, p := range points {
+		c := r.cameraPoint(p)
+		if clamp && c.Z < near {
+			c.Z = near
+		}
+		proj.camera[i] = c
+		proj.codes[i] = r.outcode(c, near)
+	}
+
+	return proj
+}
+
+// screenPoint projeta em pixels o ponto i de um lote, na primeira vez
+// em que ele é pedido. O ponto deve estar à frente do plano próximo.
+func (r *Renderer3D) screenPoint(proj projectedPoints, i int) types.Point2D {
+	if !proj.done[i] {
+		c := proj.camera[i]
+		proj.screen[i] = r.ViewportTransform(r.projectCameraSpace(c.X, c.Y, c.Z))
+		proj.done[i] = true
+	}
+	return proj.screen[i]
+}
+
 // RenderFigure renderiza uma figura 3D usando projeção cônica com configurações padrão.
 //
 // Esta função é um wrapper conveniente que usa as configurações visuais padrão.
@@ -198,110 +469,309 @@ func (r *Renderer3D) RenderFigureWithConfig(figure *types.Figure, cfg RenderConf
 		return fmt.Errorf("figura não possui pontos")
 	}
 
+	// === PROPORÇÃO DA TELA VIRTUAL ===
+	// Quando L1/L2 difere da proporção do viewport, a imagem pode ser
+	// esticada (padrão), ter L2 recalculado ou ganhar faixas nas sobras
+	switch cfg.AspectMode {
+	case AspectAdjust:
+		if r.camera.FOV <= 0 {
+			// L2 ajustado só neste desenho: a câmera do renderizador
+			// volta a ser a definida por SetCamera
+			saved := r.camera
+			adjusted := r.camera
+			adjusted.Height = adjusted.Width / r.aspect()
+			r.camera = adjusted
+			defer func() { r.camera = saved }()
+		}
+
+	case AspectLetterbox:
+		if inner, ok := r.letterbox(); ok {
+			outer := r.viewport
+			r.setColor(cfg.BarColor)
+			r.fillViewport()
+
+			// A figura é desenhada apenas na região central
+			r.SetViewport(inner)
+			defer r.SetViewport(outer)
+		}
+	}
+
+	// === ENQUADRAMENTO AUTOMÁTICO ===
+	// Câmeras marcadas como automáticas são posicionadas para mostrar
+	// a figura inteira antes da projeção
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
 	// === CONFIGURAÇÃO VISUAL ===
 	// Prepara o contexto gráfico com as cores e estilos especificados
 
-	// Define cor de fundo e limpa a tela
-	r.context.SetRGB(cfg.Background.R, cfg.Background.G, cfg.Background.B)
-	r.context.Clear()
+	// Define cor (ou gradiente) de fundo e limpa a tela (ou apenas o
+	// viewport, quando a imagem é composta por várias projeções)
+	r.fillBackground(cfg)
+	if r.viewport != r.fullViewport() {
+		vp := r.viewport
 
-	// Configura cor e espessura das linhas
-	r.context.SetRGB(cfg.LineColor.R, cfg.LineColor.G, cfg.LineColor.B)
-	r.context.SetLineWidth(cfg.LineWidth)
+		// Nada desenhado para esta projeção deve invadir os vizinhos
+		r.context.DrawRectangle(vp.X, vp.Y, vp.Width, vp.Height)
+		r.context.Clip()
+		defer r.context.ResetClip()
+	}
+
+	// Configura cor, espessura e acabamento das linhas
+	r.setColor(cfg.LineColor)
+	r.setLineWidth(cfg.LineWidth)
+	r.setLineEnds(cfg)
+	r.setTextFont(cfg)
+
+	// === PROJEÇÃO 3D → 2D E RECORTE PELO VOLUME DE VISÃO ===
+	// Leva todos os pontos ao sistema do observador de uma vez (o mesmo
+	// lote de ProjectPoints). Cada ponto recebe um código indicando de
+	// que lados do volume de visão (plano próximo e bordas da tela
+	// virtual L1×L2) ele está, para que arestas invisíveis sejam
+	// descartadas antes de projetadas; a perspectiva cônica só é
+	// aplicada aos pontos desenhados (ver screenPoint).
+	// Pontos atrás do plano próximo são tratados conforme a política
+	// configurada; com "ajustar" são trazidos até o plano antes de tudo
+	near := cfg.NearPlane
+	if near <= 0 {
+		near = DefaultNearPlane
+	}
+	proj := r.projectBatch(figure.Pontos, near, cfg.BehindPolicy == BehindClamp)
+	codigos := proj.codes
 
-	// === PROJEÇÃO 3D → 2D ===
-	// Aplica a transformação de perspectiva cônica a todos os pontos
-	// Esta é a etapa central que implementa as equações do artigo
-	pontos2D := make([]types.Point2D, len(figure.Pontos))
-	for i, ponto3D := range figure.Pontos {
-		// Cada ponto 3D é projetado individualmente usando ProjectPoint
-		pontos2D[i] = r.ProjectPoint(ponto3D)
+	// === EIXOS COORDENADOS (OPCIONAL) ===
+	// Desenhados antes da figura, que passa por cima deles
+	if cfg.Axes {
+		r.drawAxes(figure, cfg, near)
 	}
 
 	// === DESENHO DAS ARESTAS ===
-	// Conecta os pontos projetados conforme especificado na figura
-	for _, linha := range figure.Linhas {
+	// Conecta os pontos projetados conforme especificado na figura;
+	// com cfg.DepthCue, a cor de cada aresta depende da sua distância
+	nearest, farthest := depthRange(proj)
+	var segments [][2]types.Point2D // Arestas traçadas, para os rótulos
+	linhas := figure.Linhas
+	if figure.EdgesFromFaces && len(figure.Faces) > 0 {
+		linhas = nil // As faces desenham o próprio contorno
+	}
+	drawLine := func(i int) {
+		linha := linhas[i]
+
 		// Verificação de segurança: índices válidos
-		if linha.P1 >= len(pontos2D) || linha.P2 >= len(pontos2D) {
-			continue // Ignora linhas com referências inválidas
+		if linha.P1 >= len(figure.Pontos) || linha.P2 >= len(figure.Pontos) {
+			return // Ignora linhas com referências inválidas
 		}
 
-		// Obtém os pontos 2D projetados
-		p1 := pontos2D[linha.P1]
-		p2 := pontos2D[linha.P2]
+		// Curvas de Bézier: divididas em trechos retos (ver drawCurve)
+		if len(linha.Controle) > 0 {
+			segments = append(segments, r.drawCurve(figure, linha, cfg, near, nearest, farthest)...)
+			return
+		}
+
+		// Trecho visível da aresta, recortado pelo plano próximo
+		p1, p2, z1, z2, ok := r.visibleEdge(proj, linha.P1, linha.P2, near, cfg.BehindPolicy)
+		if !ok {
+			return
+		}
+
+		if cfg.DepthCue {
+			r.setColor(depthCueColor(cfg, z1, z2, nearest, farthest))
+		}
 
-		// Desenha a linha conectando os dois pontos
-		r.context.MoveTo(p1.X, p1.Y)  // Move para o primeiro ponto
-		r.context.LineTo(p2.X, p2.Y)  // Desenha linha até o segundo
-		r.context.Stroke()            // Aplica o traço
+		// Desenha a linha conectando os dois pontos (no estilo configurado)
+		r.addLine(p1, p2, cfg, sketchLines, uint64(i))
+		r.context.Stroke() // Aplica o traço
+
+		// Vetores: seta na ponta P2, se ela estiver à frente do observador
+		if linha.Direcionada && codigos[linha.P2]&outsideNear == 0 {
+			r.drawLineArrow(p1, p2, cfg)
+		}
+		segments = append(segments, [2]types.Point2D{p1, p2})
+		r.setColor(cfg.LineColor)
+	}
+	if len(figure.Faces) == 0 {
+		for i := range linhas {
+			drawLine(i)
+		}
+	}
+
+	// === DESENHO DAS FACES (OPCIONAL) ===
+	// Faces são desenhadas da mais distante para a mais próxima, com as
+	// linhas intercaladas pela profundidade: cada face cobre o que
+	// ficou atrás dela, inclusive as arestas escondidas, mas não as
+	// linhas à sua frente. No z-buffer, as linhas passam pelo mesmo
+	// teste de profundidade das faces
+	if len(figure.Faces) > 0 {
+		order, depths := lineOrder(linhas, proj.camera)
+		next := 0
+		behind := func(depth float64) {
+			for ; next < len(order) && depths[order[next]] > depth; next++ {
+				drawLine(order[next])
+			}
+		}
+		var lines []screenLine
+		if cfg.HiddenSurface == HiddenZBuffer {
+			for _, i := range order {
+				lines = append(lines, r.figureLine(figure, linhas[i], proj, cfg, near, nearest, farthest)...)
+			}
+			for _, l := range lines {
+				segments = append(segments, [2]types.Point2D{l.a, l.b})
+			}
+			next = len(order)
+		}
+
+		if err := r.drawFaces(figure, cfg, proj, near, behind, lines); err != nil {
+			return err
+		}
+		behind(math.Inf(-1)) // As linhas à frente de todas as faces
+
+		// Desenho técnico: o que as faces esconderam volta tracejado
+		if cfg.HiddenEdges == HiddenEdgesDashed {
+			if err := r.drawHiddenEdges(figure, cfg, proj, near); err != nil {
+				return err
+			}
+		}
+	}
+
+	// === CAIXA ENVOLVENTE (OPCIONAL) ===
+	// Desenhada por cima das faces, para depuração do enquadramento
+	if cfg.BoundingBox {
+		r.drawBoundingBox(figure, cfg, near)
+	}
+
+	// === COTAS (OPCIONAL) ===
+	// Distâncias entre pontos nomeados, por cima das faces
+	if len(figure.Medidas) > 0 {
+		r.drawDimensions(figure, cfg, proj, near)
+	}
+
+	// === POSIÇÃO DOS RÓTULOS ===
+	// Cada nome vai para o lado do vértice em que menos atrapalha
+	// outros nomes, vértices e arestas (ver placeLabels)
+	var labels []labelPlacement
+	if cfg.ShowLabels {
+		visible := make([]bool, len(figure.Pontos))
+		pontos2D := make([]types.Point2D, len(figure.Pontos))
+		for i := range visible {
+			visible[i] = codigos[i]&outsideNear == 0
+			if visible[i] {
+				pontos2D[i] = r.screenPoint(proj, i)
+			}
+		}
+		for _, face := range figure.Faces {
+			for j, a := range face.Pontos {
+				b := face.Pontos[(j+1)%len(face.Pontos)]
+				if a < len(visible) && b < len(visible) && visible[a] && visible[b] {
+					segments = append(segments, [2]types.Point2D{pontos2D[a], pontos2D[b]})
+				}
+			}
+		}
+		labels = r.placeLabels(figure.Pontos, pontos2D, visible, segments, r.measureText)
 	}
 
 	// === DESENHO DOS VÉRTICES (OPCIONAL) ===
 	if cfg.ShowVertices {
 		// Muda para cor dos vértices
-		r.context.SetRGB(cfg.VertexColor.R, cfg.VertexColor.G, cfg.VertexColor.B)
+		r.setColor(cfg.VertexColor)
+
+		for i := range figure.Pontos {
+			if codigos[i]&outsideNear != 0 {
+				continue // Vértice atrás do observador
+			}
+			p2D := r.screenPoint(proj, i)
 
-		for i, p2D := range pontos2D {
 			// Desenha um pequeno círculo em cada vértice
 			r.context.DrawCircle(p2D.X, p2D.Y, 2)
 			r.context.Fill()
 
 			// === DESENHO DOS RÓTULOS (SE ATIVADO) ===
 			if cfg.ShowLabels && figure.Pontos[i].Nome != "" {
-				// Muda para cor do texto
-				r.context.SetRGB(cfg.LineColor.R, cfg.LineColor.G, cfg.LineColor.B)
-				// Desenha o nome do ponto próximo ao vértice
-				r.context.DrawString(figure.Pontos[i].Nome, p2D.X+5, p2D.Y-5)
+				// Desenha o nome do ponto próximo ao vértice, na cor do texto
+				r.drawLabel(figure.Pontos[i].Nome, labels[i].x, labels[i].y, cfg.LineColor, cfg)
 				// Volta para cor dos vértices
-				r.context.SetRGB(cfg.VertexColor.R, cfg.VertexColor.G, cfg.VertexColor.B)
+				r.setColor(cfg.VertexColor)
 			}
 		}
 
 		// Restaura cor das linhas para futuras operações
-		r.context.SetRGB(cfg.LineColor.R, cfg.LineColor.G, cfg.LineColor.B)
+		r.setColor(cfg.LineColor)
 
 	} else if cfg.ShowLabels {
 		// === RÓTULOS SEM VÉRTICES ===
 		// Se apenas os rótulos devem ser mostrados (sem os círculos)
-		for i, p2D := range pontos2D {
-			if figure.Pontos[i].Nome == "" {
-				continue // Pula pontos sem nome
+		for i := range figure.Pontos {
+			if !labels[i].ok {
+				continue // Pula pontos sem nome ou atrás do observador
 			}
 			// Usa cor das linhas para o texto
-			r.context.SetRGB(cfg.LineColor.R, cfg.LineColor.G, cfg.LineColor.B)
-			r.context.DrawString(figure.Pontos[i].Nome, p2D.X+5, p2D.Y-5)
+			r.drawLabel(figure.Pontos[i].Nome, labels[i].x, labels[i].y, cfg.LineColor, cfg)
 		}
 		// Garante que a cor das linhas permanece configurada
-		r.context.SetRGB(cfg.LineColor.R, cfg.LineColor.G, cfg.LineColor.B)
+		r.setColor(cfg.LineColor)
+	}
+
+	// === CARIMBO (OPCIONAL) ===
+	if cfg.TitleBlock != nil {
+		r.drawTitleBlock(figure, cfg)
+	}
+
+	// === TABELA DE PONTOS (OPCIONAL) ===
+	if cfg.PointTable != nil {
+		r.drawPointTable(figure, cfg)
+	}
+
+	// === TÍTULO E LEGENDA (OPCIONAIS) ===
+	r.drawAnnotations(figure, cfg)
+
+	// === MARCA D'ÁGUA (OPCIONAL) ===
+	// Por último, sobre tudo o que foi desenhado
+	if cfg.Watermark != nil {
+		r.drawWatermark(cfg)
 	}
 
 	return nil
 }
 
-// SaveImage salva a imagem renderizada em arquivo PNG.
+// SaveImage salva a imagem renderizada em arquivo PNG, JPEG ou WebP.
 //
 // Exporta o resultado da renderização para um arquivo de imagem,
 // permitindo preservar e compartilhar as figuras 3D geradas.
 // Uma grande evolução em relação ao HP-85 original!
 //
+// O formato vem de opts.Format ou, na falta dele, da extensão do
+// arquivo. Em PNG, a imagem tem 8 ou 16 bits por canal, conforme
+// SetBitDepth, e os blocos de texto de opts.Text ficam gravados no
+// próprio arquivo (ver EncodePNG), registrando, por exemplo, de que
+// figura e de que câmera a imagem veio. JPEG e WebP, menores, servem
+// para publicar muitas figuras na web.
+//
 // Parâmetros:
-//   filename: caminho do arquivo PNG a ser criado
+//   filename: caminho do arquivo a ser criado
+//   opts: formato, qualidade do JPEG e metadados do PNG
 //
 // Retorna:
-//   error: nil se bem-sucedido, erro caso haja problemas de E/S
-func (r *Renderer3D) SaveImage(filename string) error {
-	return r.context.SavePNG(filename)
+//   error: nil se bem-sucedido, erro de opções, de codificação ou de E/S
+func (r *Renderer3D) SaveImage(filename string, opts SaveOptions) error {
+	return saveImageFile(filename, opts, func(format ImageFormat) image.Image {
+		if format == FormatPNG {
+			return r.finalImage()
+		}
+		return r.image()
+	})
 }
 
 // GetImage retorna a imagem renderizada como interface{}.
 //
 // Permite acesso direto à imagem em memória para integração
-// com outros sistemas ou exibição em interfaces gráficas.
+// com outros sistemas ou exibição em interfaces gráficas. A imagem tem
+// sempre 8 bits por canal; os 16 bits (ver SetBitDepth) valem para
+// SaveImage em PNG.
 //
 // Retorna:
 //   interface{}: imagem renderizada (tipo image.Image)
 func (r *Renderer3D) GetImage() interface{} {
-	return r.context.Image()
+	return r.image()
 }
 
 // AddGrid adiciona uma grade de referência à imagem (função utilitária).
@@ -310,12 +780,15 @@ func (r *Renderer3D) GetImage() interface{} {
 // depuração das projeções. Útil para fins educacionais e de desenvolvimento.
 // Esta funcionalidade vai além do artigo original, sendo uma adição moderna.
 //
-// A grade é desenhada com espaçamento de 50 pixels em cor cinza claro
-// para não interferir na visualização das figuras principais.
+// A grade é desenhada sobre a imagem inteira, com espaçamento de 50
+// pixels em cor cinza claro para não interferir na visualização das
+// figuras principais. Para desenhá-la sob a figura, com espaçamento,
+// cores e subgrade configuráveis, use a seção "grade" do YAML (ver
+// RenderConfig.Grid); as linhas de lá são mais nítidas, com 1 pixel.
 func (r *Renderer3D) AddGrid() {
 	// Configuração visual da grade
 	r.context.SetRGB(0.9, 0.9, 0.9) // Cinza bem claro (quase branco)
-	r.context.SetLineWidth(0.5)     // Linha bem fina
+	r.setLineWidth(0.5)             // Linha bem fina
 
 	// Desenha linhas verticais (espaçadas a cada 50 pixels)
 	for x := 0; x < r.width; x += 50 {
@@ -333,5 +806,35 @@ func (r *Renderer3D) AddGrid() {
 
 	// Restaura configurações padrão para não afetar desenhos posteriores
 	r.context.SetRGB(0, 0, 0) // Volta para preto
-	r.context.SetLineWidth(1.0) // Volta para espessura padrão
+	r.setLineWidth(1.0)         // Volta para espessura padrão
+}
+
+// figureLine divide uma linha da figura nos seus trechos visíveis, como
+// são traçados por RenderFigureWithConfig, para o z-buffer.
+//
+// Parâmetros:
+//   figure: figura a que a linha pertence
+//   linha: linha da figura (reta ou curva)
+//   proj: pontos da figura já projetados (ver projectBatch)
+//   screen: os mesmos pontos em pixels
+//   cfg: configurações visuais
+//   near: profundidade do plano próximo
+//   nearest, farthest: faixa de profundidades da figura (ver depthRange)
+//
+// Retorna:
+//   []screenLine: trechos visíveis (nenhum se a linha está fora do volume)
+func (r *Renderer3D) figureLine(figure *types.Figure, linha types.Line, proj projectedPoints, cfg RenderConfig, near, nearest, farthest float64) []screenLine {
+	if len(linha.Controle) > 0 {
+		return r.curveLines(figure, linha, cfg, near, nearest, farthest)
+	}
+	p1, p2, z1, z2, ok := r.visibleEdge(proj, linha.P1, linha.P2, near, cfg.BehindPolicy)
+	if !ok {
+		return nil
+	}
+	color := cfg.LineColor
+	if cfg.DepthCue {
+		color = depthCueColor(cfg, z1, z2, nearest, farthest)
+	}
+	return []screenLine{{a: p1, b: p2, za: z1, zb: z2, color: color,
+		arrow: linha.Direcionada && proj.codes[linha.P2]&outsideNear == 0}}
 }
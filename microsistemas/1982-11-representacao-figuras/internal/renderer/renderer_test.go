@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"image"
 	"math"
 	"testing"
 
@@ -50,6 +51,29 @@ func TestSetCamera(t *testing.T) {
 	}
 }
 
+func TestSetCamera_FOV(t *testing.T) {
+	renderer := New(800, 400)
+	renderer.SetCamera(types.Camera{
+		Distance: 10,
+		FOV:      90,
+	})
+
+	// tan(45°) = 1 → L1 = 2·R = 20; proporção 2:1 → L2 = 10
+	if math.Abs(renderer.camera.Width-20) > 1e-9 {
+		t.Errorf("Expected width=20 derived from FOV, got %f", renderer.camera.Width)
+	}
+
+	if math.Abs(renderer.camera.Height-10) > 1e-9 {
+		t.Errorf("Expected height=10 derived from aspect, got %f", renderer.camera.Height)
+	}
+
+	// Um ponto na borda do campo de visão deve cair na borda da tela
+	result := renderer.ProjectToScreen(types.Point3D{X: 5, Y: 5, Z: 0})
+	if math.Abs(result.X-800) > 1e-6 {
+		t.Errorf("Expected point at 45° to project to right edge (800), got %f", result.X)
+	}
+}
+
 func TestProjectPoint(t *testing.T) {
 	renderer := New(800, 600)
 
@@ -90,7 +114,7 @@ func TestProjectPoint(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderer.ProjectPoint(tt.point3D)
+			result := renderer.ProjectToScreen(tt.point3D)
 
 			if math.Abs(result.X-tt.expected.X) > tt.tolerance {
 				t.Errorf("X projection: expected %f±%f, got %f",
@@ -121,7 +145,7 @@ func TestProjectPoint_PerspectiveFormula(t *testing.T) {
 	// Ponto de teste
 	point := types.Point3D{X: 2, Y: 5, Z: 1} // Px=2, Pz=5, Py=1
 
-	result := renderer.ProjectPoint(point)
+	result := renderer.ProjectToScreen(point)
 
 	// Calcula manualmente conforme as equações do artigo
 	px := point.X - camera.Observer.X // = 2
@@ -150,6 +174,88 @@ func TestProjectPoint_PerspectiveFormula(t *testing.T) {
 	}
 }
 
+func TestProjectPoint_NDC(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.Camera{
+		Distance: 10,
+		Width:    12.8,
+		Height:   9.6,
+	})
+
+	// x = 2·10/5 = 4 de 6.4 (L1/2); y = 1·10/5 = 2 de 4.8 (L2/2)
+	ndc := renderer.ProjectPoint(types.Point3D{X: 2, Y: 5, Z: 1})
+	if math.Abs(ndc.X-0.625) > 1e-9 || math.Abs(ndc.Y-2/4.8) > 1e-9 {
+		t.Errorf("Expected NDC (0.625, %f), got (%f, %f)", 2/4.8, ndc.X, ndc.Y)
+	}
+
+	// Canto da tela virtual (x = L1/2, y = L2/2 no plano projetante)
+	corner := renderer.ProjectPoint(types.Point3D{X: 6.4, Y: 10, Z: 4.8})
+	if math.Abs(corner.X-1) > 1e-9 || math.Abs(corner.Y-1) > 1e-9 {
+		t.Errorf("Expected NDC (1, 1) at virtual screen corner, got (%f, %f)", corner.X, corner.Y)
+	}
+}
+
+func TestViewportTransform(t *testing.T) {
+	renderer := New(800, 600)
+
+	tests := []struct {
+		ndc, screen types.Point2D
+	}{
+		{types.Point2D{X: 0, Y: 0}, types.Point2D{X: 400, Y: 300}},
+		{types.Point2D{X: -1, Y: 1}, types.Point2D{X: 0, Y: 0}},
+		{types.Point2D{X: 1, Y: -1}, types.Point2D{X: 800, Y: 600}},
+		{types.Point2D{X: 0.5, Y: 0.5}, types.Point2D{X: 600, Y: 150}},
+	}
+	for _, tt := range tests {
+		got := renderer.ViewportTransform(tt.ndc)
+		if got != tt.screen {
+			t.Errorf("ViewportTransform(%v): expected %v, got %v", tt.ndc, tt.screen, got)
+		}
+	}
+
+	// Com viewport reduzido, NDC ocupa apenas aquela região
+	renderer.SetViewport(Viewport{X: 400, Y: 0, Width: 400, Height: 300})
+	if got := renderer.ViewportTransform(types.Point2D{X: -1, Y: -1}); got != (types.Point2D{X: 400, Y: 300}) {
+		t.Errorf("Expected (400, 300) in reduced viewport, got %v", got)
+	}
+}
+
+func TestProjectPoints(t *testing.T) {
+	points := []types.Point3D{
+		{X: 0, Y: 5, Z: 0},
+		{X: 2, Y: 5, Z: 1},
+		{X: -3, Y: 12, Z: 4},
+		{X: 1, Y: -1, Z: 1}, // Atrás do observador
+	}
+
+	cameras := []types.Camera{
+		types.DefaultCamera(),
+		{Distance: 10, Width: 8, Height: 6, Projection: types.ProjectionOrthographic},
+	}
+
+	for _, camera := range cameras {
+		renderer := New(800, 600)
+		renderer.SetCamera(camera)
+
+		batch := renderer.ProjectPoints(points)
+		if len(batch) != len(points) {
+			t.Fatalf("Expected %d projected points, got %d", len(points), len(batch))
+		}
+
+		// O lote deve coincidir com a projeção ponto a ponto
+		for i, p := range points {
+			if single := renderer.ProjectPoint(p); batch[i] != single {
+				t.Errorf("Camera %q point %d: batch %v differs from ProjectPoint %v",
+					camera.Projection, i, batch[i], single)
+			}
+		}
+	}
+
+	if got := New(800, 600).ProjectPoints(nil); len(got) != 0 {
+		t.Errorf("Expected empty result for no points, got %v", got)
+	}
+}
+
 func TestProjectPoint_BehindCamera(t *testing.T) {
 	// Testa pontos atrás da câmera (pz <= 0)
 	renderer := New(800, 600)
@@ -164,7 +270,7 @@ func TestProjectPoint_BehindCamera(t *testing.T) {
 	// Ponto atrás da câmera
 	point := types.Point3D{X: 1, Y: -1, Z: 1} // Y negativo = atrás
 
-	result := renderer.ProjectPoint(point)
+	result := renderer.ProjectToScreen(point)
 
 	// Deve usar pz=0.1 para evitar divisão por zero
 	// A projeção deve ser extrema mas finita
@@ -243,6 +349,141 @@ func TestAddGrid(t *testing.T) {
 	// Testa se AddGrid não causa panic
 	renderer.AddGrid()
 
-	// Não há muito o que testar além de não dar panic
-	// A funcionalidade visual seria testada manualmente
-}
\ No newline at end of file
+	// Linha de 0,5 pixel centrada em x = 50: metade em cada coluna
+	// vizinha, bem clara; entre as linhas, o fundo branco
+	img := renderer.GetImage().(*image.RGBA)
+	for _, x := range []int{49, 50} {
+		if c := img.RGBAAt(x, 25); c.R == 255 || c.R < 240 {
+			t.Errorf("Column %d: expected a faint half-pixel grid line, got %+v", x, c)
+		}
+	}
+	if c := img.RGBAAt(25, 25); c.R != 255 {
+		t.Errorf("Expected white between grid lines, got %+v", c)
+	}
+}
+func TestRenderFigure_AspectModes(t *testing.T) {
+	// Tela virtual 4:3 numa imagem 2:1
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+
+	t.Run("letterbox", func(t *testing.T) {
+		renderer := New(800, 400)
+		renderer.SetCamera(figure.Camera)
+
+		cfg := DefaultRenderConfig()
+		cfg.AspectMode = AspectLetterbox
+		cfg.BarColor = colorRGB{R: 1, G: 0, B: 0, A: 1}
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		// Faixas laterais de (800 - 400·4/3)/2 ≈ 133 pixels
+		img := renderer.GetImage().(image.Image)
+		if r, g, _, _ := img.At(60, 200).RGBA(); r != 0xffff || g != 0 {
+			t.Errorf("Expected red bar at the left, got %v", img.At(60, 200))
+		}
+		if r, g, b, _ := img.At(400, 50).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+			t.Errorf("Expected white background in the center, got %v", img.At(400, 50))
+		}
+
+		// Depois da renderização o viewport inteiro volta a valer
+		if renderer.viewport != renderer.fullViewport() {
+			t.Errorf("Expected viewport restored after render, got %+v", renderer.viewport)
+		}
+		p := renderer.ProjectToScreen(types.Point3D{X: 1, Y: 5})
+		if math.Abs(p.X-525) > 0.5 {
+			t.Errorf("Expected stretched projection after restore (x=525), got %f", p.X)
+		}
+	})
+
+	t.Run("adjust", func(t *testing.T) {
+		renderer := New(800, 400)
+		renderer.SetCamera(figure.Camera)
+
+		cfg := DefaultRenderConfig()
+		cfg.AspectMode = AspectAdjust
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		// L2 recalculado para a proporção 2:1 da imagem só no desenho:
+		// a câmera do renderizador não muda
+		if math.Abs(renderer.camera.Height-figure.Camera.Height) > 1e-9 {
+			t.Errorf("Expected camera L2=%f kept after render, got %f", figure.Camera.Height, renderer.camera.Height)
+		}
+	})
+
+	t.Run("adjust does not leak", func(t *testing.T) {
+		// Linha vertical: o L2 muda a sua altura na imagem
+		tall := &types.Figure{
+			Pontos: []types.Point3D{{X: 0, Y: 5, Z: -1}, {X: 0, Y: 5, Z: 1}},
+			Linhas: []types.Line{{P1: 0, P2: 1}},
+			Camera: types.DefaultCamera(),
+		}
+		adjust, stretch := DefaultRenderConfig(), DefaultRenderConfig()
+		adjust.AspectMode = AspectAdjust
+
+		fresh := New(800, 400)
+		fresh.SetCamera(tall.Camera)
+		if err := fresh.RenderFigureWithConfig(tall, stretch); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		// O mesmo renderizador, depois de um desenho ajustado, estica
+		// como um novo
+		reused := New(800, 400)
+		reused.SetCamera(tall.Camera)
+		for _, cfg := range []RenderConfig{adjust, adjust, stretch} {
+			if err := reused.RenderFigureWithConfig(tall, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+		}
+		want, got := fresh.GetImage().(image.Image), reused.GetImage().(image.Image)
+		for y := 0; y < 400; y++ {
+			for x := 0; x < 800; x++ {
+				if got.At(x, y) != want.At(x, y) {
+					t.Fatalf("Expected reused renderer to match a fresh one, differs at (%d, %d)", x, y)
+				}
+			}
+		}
+	})
+}
+
+func TestRenderFigure_LineCaps(t *testing.T) {
+	// Linha grossa horizontal: além da ponta, só as pontas redonda e
+	// quadrada pintam
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: 0}, {X: 2, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	for _, tt := range []struct {
+		lineCap LineCap
+		beyond  bool
+	}{
+		{CapRound, true},
+		{CapButt, false},
+		{CapSquare, true},
+	} {
+		t.Run(string(tt.lineCap), func(t *testing.T) {
+			cfg := DefaultRenderConfig()
+			cfg.LineWidth = 10
+			cfg.LineCap = tt.lineCap
+
+			renderer := New(400, 300)
+			renderer.SetCamera(types.DefaultCamera())
+			if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+			img := renderer.GetImage().(*image.RGBA)
+
+			end := renderer.ProjectToScreen(figure.Pontos[1])
+			if got := img.RGBAAt(int(end.X)+2, int(end.Y)).R < 128; got != tt.beyond {
+				t.Errorf("Expected stroke beyond the end point %v, got %v", tt.beyond, got)
+			}
+		})
+	}
+}
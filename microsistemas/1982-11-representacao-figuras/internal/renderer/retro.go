@@ -0,0 +1,238 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// RetroMode é um modo de fidelidade a um computador da época do artigo.
+type RetroMode string
+
+const (
+	RetroHP85 RetroMode = "hp85" // Tela gráfica do HP-85: 256×192, traço de 1 pixel
+)
+
+// Resolução da tela gráfica do HP-85.
+const (
+	HP85Width  = 256
+	HP85Height = 192
+)
+
+// MaxRetroScale é o maior fator de ampliação da imagem retrô.
+const MaxRetroScale = 8
+
+// retroModeAliases aceita também a grafia com hífen.
+var retroModeAliases = map[string]RetroMode{
+	"hp85":  RetroHP85,
+	"hp-85": RetroHP85,
+}
+
+// ParseRetroMode converte o nome de um modo retrô ("hp85" ou "hp-85",
+// sem distinção de maiúsculas).
+//
+// Parâmetros:
+//   value: nome do modo
+//
+// Retorna:
+//   RetroMode: modo correspondente
+//   error: erro se o nome for desconhecido
+func ParseRetroMode(value string) (RetroMode, error) {
+	mode, ok := retroModeAliases[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return "", fmt.Errorf("modo retrô desconhecido: %q (use %s)", value, RetroHP85)
+	}
+	return mode, nil
+}
+
+// RenderRetro renderiza a figura como o programa BASIC do artigo a
+// desenhava no HP-85: apenas as arestas, cada uma traçada pixel a pixel
+// pelo algoritmo de Bresenham, com aritmética inteira e sem suavização.
+//
+// A projeção e o recorte pelo volume de visão são os mesmos de
+// RenderFigureWithConfig; as curvas são traçadas pelos seus trechos
+// retos. Faces, vértices, nomes, gradiente, grade e demais acréscimos
+// modernos são ignorados: o fundo é a cor de fundo, e as linhas, a cor
+// das linhas, ambas opacas. O renderizador deve ter HP85Width×HP85Height
+// pixels e não usar superamostragem para reproduzir a tela original.
+//
+// Parâmetros:
+//   figure: figura 3D a ser renderizada
+//   cfg: configurações visuais (cores e tratamento do plano próximo)
+//
+// Retorna:
+//   error: erro se a figura não tiver pontos
+func (r *Renderer3D) RenderRetro(figure *types.Figure, cfg RenderConfig) error {
+	if len(figure.Pontos) == 0 {
+		return fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	img := r.context.Image().(*image.RGBA)
+	background := cfg.Background
+	background.A = 1
+	r.setColor(background)
+	r.context.Clear()
+
+	line := cfg.LineColor
+	line.A = 1
+	ink := toRGBA(line)
+
+	bounds := image.Rect(0, 0, r.width, r.height)
+	r.traceEdges(figure, cfg, func(p1, p2 types.Point2D) {
+		x0, y0 := int(math.Floor(p1.X)), int(math.Floor(p1.Y))
+		x1, y1 := int(math.Floor(p2.X)), int(math.Floor(p2.Y))
+		bresenham(x0, y0, x1, y1, func(x, y int) {
+			if (image.Point{X: x, Y: y}).In(bounds) {
+				img.SetRGBA(x, y, ink)
+			}
+		})
+	})
+	return nil
+}
+
+// traceEdges projeta as arestas da figura e entrega cada trecho visível,
+// já recortado pela tela, em pixels. As curvas são entregues pelos seus
+// trechos retos. Faces, vértices e nomes não são considerados.
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (tratamento do plano próximo)
+//   segment: chamada para cada trecho, com os extremos em pixels
+func (r *Renderer3D) traceEdges(figure *types.Figure, cfg RenderConfig, segment func(p1, p2 types.Point2D)) {
+	near := cfg.NearPlane
+	if near <= 0 {
+		near = DefaultNearPlane
+	}
+	for _, linha := range figure.Linhas {
+		if linha.P1 >= len(figure.Pontos) || linha.P2 >= len(figure.Pontos) {
+			continue
+		}
+
+		path := linha.Curve(figure.Pontos)
+		proj := r.projectBatch(path, near, cfg.BehindPolicy == BehindClamp)
+
+		for k := 0; k+1 < len(path); k++ {
+			p1, p2, _, _, ok := r.visibleEdge(proj, k, k+1, near, cfg.BehindPolicy)
+			if !ok {
+				continue
+			}
+			if p1, p2, ok = clipToRect(p1, p2, float64(r.width), float64(r.height)); !ok {
+				continue
+			}
+			segment(p1, p2)
+		}
+	}
+}
+
+// clipToRect recorta um segmento pelo retângulo [0, width]×[0, height]
+// (algoritmo de Liang-Barsky), para que o traçado pixel a pixel não
+// percorra extremos muito distantes da tela.
+//
+// Parâmetros:
+//   a, b: extremos do segmento em pixels
+//   width, height: dimensões do retângulo
+//
+// Retorna:
+//   types.Point2D, types.Point2D: extremos do trecho dentro do retângulo
+//   bool: false se o segmento está inteiramente fora
+func clipToRect(a, b types.Point2D, width, height float64) (types.Point2D, types.Point2D, bool) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, a.X}, {dx, width - a.X},
+		{-dy, a.Y}, {dy, height - a.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return a, b, false // Paralelo à borda e do lado de fora
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+		if t0 > t1 {
+			return a, b, false
+		}
+	}
+	return types.Point2D{X: a.X + t0*dx, Y: a.Y + t0*dy},
+		types.Point2D{X: a.X + t1*dx, Y: a.Y + t1*dy}, true
+}
+
+// bresenham percorre os pixels de uma reta entre dois pixels pelo
+// algoritmo de Bresenham, apenas com somas e comparações de inteiros,
+// como as rotinas gráficas dos microcomputadores da época.
+//
+// Parâmetros:
+//   x0, y0: pixel inicial
+//   x1, y1: pixel final
+//   plot: chamada para cada pixel da reta, em ordem, incluindo os extremos
+func bresenham(x0, y0, x1, y1 int, plot func(x, y int)) {
+	dx, sx := x1-x0, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	dy, sy := y1-y0, 1
+	if dy < 0 {
+		dy, sy = -dy, -1
+	}
+
+	err := dx - dy
+	for {
+		plot(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x0 += sx
+		}
+		if e2 < dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Upscale amplia a imagem renderizada por um fator inteiro, repetindo
+// cada pixel (vizinho mais próximo): os pixels da tela retrô continuam
+// nítidos, como blocos, em vez de borrados.
+//
+// Deve ser chamado depois da renderização e antes de SaveImage; as
+// dimensões do renderizador passam a ser as da imagem ampliada.
+//
+// Parâmetros:
+//   factor: fator de ampliação (1 = nenhuma, até MaxRetroScale)
+//
+// Retorna:
+//   error: erro se o fator estiver fora do intervalo aceito
+func (r *Renderer3D) Upscale(factor int) error {
+	if factor < 1 || factor > MaxRetroScale {
+		return fmt.Errorf("fator de ampliação inválido: %d (use de 1 a %d)", factor, MaxRetroScale)
+	}
+	if factor == 1 {
+		return nil
+	}
+
+	src := r.image()
+	dst := image.NewRGBA(image.Rect(0, 0, r.width*factor, r.height*factor))
+	for y := 0; y < dst.Rect.Dy(); y++ {
+		for x := 0; x < dst.Rect.Dx(); x++ {
+			dst.SetRGBA(x, y, src.RGBAAt(x/factor, y/factor))
+		}
+	}
+
+	r.setFinalImage(dst)
+	return nil
+}
@@ -0,0 +1,140 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestParseRetroMode(t *testing.T) {
+	for _, name := range []string{"hp85", "HP-85", " hp85 "} {
+		if mode, err := ParseRetroMode(name); err != nil || mode != RetroHP85 {
+			t.Errorf("ParseRetroMode(%q): expected %q, got %q (%v)", name, RetroHP85, mode, err)
+		}
+	}
+	if _, err := ParseRetroMode("apple2"); err == nil {
+		t.Error("Expected error for unknown retro mode")
+	}
+}
+
+func TestBresenham(t *testing.T) {
+	var got []image.Point
+	plot := func(x, y int) { got = append(got, image.Pt(x, y)) }
+
+	bresenham(0, 0, 4, 2, plot)
+	want := []image.Point{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Em qualquer octante, um pixel por passo no eixo mais longo, dos
+	// dois extremos inclusive
+	for _, end := range []image.Point{{5, 9}, {-7, 3}, {-2, -8}, {6, -6}, {0, 4}, {-3, 0}, {0, 0}} {
+		got = nil
+		bresenham(1, 1, 1+end.X, 1+end.Y, plot)
+		steps := max(absInt(end.X), absInt(end.Y)) + 1
+		if len(got) != steps || got[0] != image.Pt(1, 1) || got[len(got)-1] != image.Pt(1+end.X, 1+end.Y) {
+			t.Errorf("Line to %v: expected %d pixels from (1,1) to the end, got %v", end, steps, got)
+		}
+		for i := 1; i < len(got); i++ {
+			if d := got[i].Sub(got[i-1]); absInt(d.X) > 1 || absInt(d.Y) > 1 {
+				t.Errorf("Line to %v: gap between %v and %v", end, got[i-1], got[i])
+			}
+		}
+	}
+}
+
+// absInt retorna o valor absoluto de um inteiro
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func TestClipToRect(t *testing.T) {
+	a, b, ok := clipToRect(types.Point2D{X: -10, Y: 5}, types.Point2D{X: 30, Y: 5}, 20, 10)
+	if !ok || a != (types.Point2D{X: 0, Y: 5}) || b != (types.Point2D{X: 20, Y: 5}) {
+		t.Errorf("Expected (0,5)-(20,5), got %v-%v (%v)", a, b, ok)
+	}
+
+	a, b, ok = clipToRect(types.Point2D{X: 2, Y: 3}, types.Point2D{X: 8, Y: 7}, 20, 10)
+	if !ok || a != (types.Point2D{X: 2, Y: 3}) || b != (types.Point2D{X: 8, Y: 7}) {
+		t.Errorf("Expected segment inside to stay whole, got %v-%v", a, b)
+	}
+
+	if _, _, ok := clipToRect(types.Point2D{X: -5, Y: -5}, types.Point2D{X: 30, Y: -1}, 20, 10); ok {
+		t.Error("Expected segment above the rectangle to be discarded")
+	}
+}
+
+func TestRenderRetro(t *testing.T) {
+	renderer := New(HP85Width, HP85Height)
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1},
+			{X: 1, Y: 5, Z: 1},
+			{X: 1, Y: 5, Z: -1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}},
+		Faces:  []types.Face{{Pontos: []int{0, 1, 2}}},
+		Camera: types.DefaultCamera(),
+	}
+	renderer.SetCamera(figure.Camera)
+
+	cfg := DefaultRenderConfig()
+	cfg.Gradient = GradientVertical
+	cfg.GradientEnd = colorRGB{R: 0, G: 0, B: 1, A: 1}
+	cfg.Antialias = 4
+	if err := renderer.RenderRetro(figure, cfg); err != nil {
+		t.Fatalf("RenderRetro failed: %v", err)
+	}
+
+	// Só duas cores: fundo e linha, sem gradiente, faces nem suavização
+	img := renderer.image()
+	colors := map[color.RGBA]int{}
+	for y := 0; y < HP85Height; y++ {
+		for x := 0; x < HP85Width; x++ {
+			colors[img.RGBAAt(x, y)]++
+		}
+	}
+	white, black := color.RGBA{255, 255, 255, 255}, color.RGBA{0, 0, 0, 255}
+	if len(colors) != 2 || colors[white] == 0 || colors[black] == 0 {
+		t.Errorf("Expected only white background and black lines, got %v", colors)
+	}
+
+	if err := renderer.RenderRetro(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+}
+
+func TestUpscale(t *testing.T) {
+	renderer := New(4, 3)
+	renderer.context.SetColor(color.Black)
+	renderer.context.SetPixel(1, 2)
+
+	for _, factor := range []int{0, MaxRetroScale + 1} {
+		if err := renderer.Upscale(factor); err == nil {
+			t.Errorf("Expected error for factor %d", factor)
+		}
+	}
+	if err := renderer.Upscale(3); err != nil {
+		t.Fatalf("Upscale failed: %v", err)
+	}
+
+	img := renderer.image()
+	if img.Bounds() != image.Rect(0, 0, 12, 9) {
+		t.Fatalf("Expected 12×9 image, got %v", img.Bounds())
+	}
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 12; x++ {
+			dark := img.RGBAAt(x, y).R == 0
+			if want := x/3 == 1 && y/3 == 2; dark != want {
+				t.Errorf("Pixel (%d,%d): expected dark %v", x, y, want)
+			}
+		}
+	}
+}
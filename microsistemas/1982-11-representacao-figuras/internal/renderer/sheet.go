@@ -0,0 +1,172 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// sheetView descreve uma das vistas da folha de desenho técnico.
+type sheetView struct {
+	label  string       // Nome impresso no canto do quadrante
+	camera types.Camera // Câmera usada no quadrante
+}
+
+// sheetViews monta as quatro vistas da folha a partir da câmera da figura.
+//
+// A disposição segue o 1º diedro adotado pela ABNT: vista frontal no
+// quadrante superior esquerdo, vista lateral esquerda à sua direita e
+// vista superior abaixo dela. O quarto quadrante mostra a perspectiva
+// cônica definida pela própria figura.
+//
+// As três vistas ortográficas usam enquadramento automático; como o raio
+// da figura não depende da direção de visão, todas ficam na mesma escala.
+func sheetViews(figure *types.Figure) [4]sheetView {
+	ortho := func(azimuth, elevation float64) types.Camera {
+		cam := types.DefaultCamera()
+		cam.Projection = types.ProjectionOrthographic
+		cam.Orbit = &types.Orbit{Azimuth: azimuth, Elevation: elevation, Radius: 1}
+		cam.Auto = true
+		return cam
+	}
+
+	return [4]sheetView{
+		{label: "FRENTE", camera: ortho(0, 0)},
+		{label: "LATERAL ESQUERDA", camera: ortho(-90, 0)},
+		{label: "SUPERIOR", camera: ortho(0, 90)},
+		{label: "PERSPECTIVA", camera: figure.Camera},
+	}
+}
+
+// RenderSheet renderiza a figura como uma folha de desenho técnico.
+//
+// A imagem é dividida em quatro quadrantes, cada um com seu próprio
+// viewport: vistas frontal, lateral e superior em projeção ortogonal,
+// mais a perspectiva cônica do artigo. É o leiaute padrão das pranchas
+// de desenho, que permite ler as dimensões reais e ao mesmo tempo ter
+// uma ideia da forma tridimensional.
+//
+// Parâmetros:
+//   figure: figura 3D a ser renderizada
+//   cfg: configurações visuais aplicadas a todos os quadrantes
+//
+// Retorna:
+//   error: nil se bem-sucedido, erro caso a figura seja inválida
+func (r *Renderer3D) RenderSheet(figure *types.Figure, cfg RenderConfig) error {
+	// Preserva câmera e viewport do chamador
+	savedCamera := r.camera
+	defer func() {
+		r.SetViewport(r.fullViewport())
+		r.SetCamera(savedCamera)
+	}()
+
+	halfW := float64(r.width) / 2
+	halfH := float64(r.height) / 2
+
+	quadrants := [4]Viewport{
+		{X: 0, Y: 0, Width: halfW, Height: halfH},
+		{X: halfW, Y: 0, Width: halfW, Height: halfH},
+		{X: 0, Y: halfH, Width: halfW, Height: halfH},
+		{X: halfW, Y: halfH, Width: halfW, Height: halfH},
+	}
+
+	// Um único carimbo (e título, legenda, tabela de pontos e marca
+	// d'água) para a folha inteira, não um por quadrante
+	sheetCfg := cfg
+	cfg = withoutSheetDecorations(cfg)
+
+	for i, view := range sheetViews(figure) {
+		r.SetViewport(quadrants[i])
+		r.SetCamera(view.camera)
+
+		if err := r.RenderFigureWithConfig(figure, cfg); err != nil {
+			return fmt.Errorf("vista %s: %w", view.label, err)
+		}
+
+		// Rótulo do quadrante no canto superior esquerdo
+		r.setColor(cfg.LineColor)
+		r.context.DrawString(view.label, quadrants[i].X+8, quadrants[i].Y+16)
+	}
+
+	// Linhas divisórias entre os quadrantes
+	r.setColor(cfg.LineColor)
+	r.setLineWidth(1)
+	r.context.DrawLine(halfW, 0, halfW, float64(r.height))
+	r.context.DrawLine(0, halfH, float64(r.width), halfH)
+	r.context.Stroke()
+
+	r.drawSheetDecorations(figure, sheetCfg)
+	return nil
+}
+
+// withoutSheetDecorations retorna a configuração das vistas de uma
+// folha: sem carimbo, título, legenda, tabela de pontos e marca d'água,
+// que aparecem uma única vez (ver drawSheetDecorations).
+func withoutSheetDecorations(cfg RenderConfig) RenderConfig {
+	cfg.TitleBlock, cfg.Title, cfg.Caption, cfg.PointTable, cfg.Watermark = nil, nil, nil, nil, nil
+	return cfg
+}
+
+// drawSheetDecorations desenha, sobre a folha inteira, o carimbo, a
+// tabela de pontos, o título, a legenda e a marca d'água configurados.
+//
+// Parâmetros:
+//   figure: figura da folha (nome e pontos)
+//   cfg: configuração completa da folha
+func (r *Renderer3D) drawSheetDecorations(figure *types.Figure, cfg RenderConfig) {
+	r.SetViewport(r.fullViewport())
+	if cfg.TitleBlock != nil {
+		r.drawTitleBlock(figure, cfg)
+	}
+	if cfg.PointTable != nil {
+		r.drawPointTable(figure, cfg)
+	}
+	r.drawAnnotations(figure, cfg)
+	if cfg.Watermark != nil {
+		r.drawWatermark(cfg)
+	}
+}
+
+// SheetGrid calcula a grade de uma folha com várias células (ângulos da
+// folha de contatos ou figuras de uma montagem): o menor número de
+// colunas que forma uma grade quase quadrada e as linhas necessárias.
+//
+// Parâmetros:
+//   count: número de células ocupadas
+//
+// Retorna:
+//   cols, rows: colunas e linhas da grade
+func SheetGrid(count int) (cols, rows int) {
+	if count < 1 {
+		return 1, 1
+	}
+	cols = int(math.Ceil(math.Sqrt(float64(count))))
+	rows = (count + cols - 1) / cols
+	return cols, rows
+}
+
+// sheetCell retorna o viewport da célula i de uma grade cols×rows que
+// ocupa a tela inteira, preenchida linha a linha.
+func (r *Renderer3D) sheetCell(i, cols, rows int) Viewport {
+	w := float64(r.width) / float64(cols)
+	h := float64(r.height) / float64(rows)
+	return Viewport{X: float64(i%cols) * w, Y: float64(i/cols) * h, Width: w, Height: h}
+}
+
+// drawSheetDividers traça as linhas divisórias entre as células de uma
+// grade cols×rows que ocupa a tela inteira.
+func (r *Renderer3D) drawSheetDividers(cols, rows int, col colorRGB) {
+	w := float64(r.width) / float64(cols)
+	h := float64(r.height) / float64(rows)
+
+	r.setColor(col)
+	r.setLineWidth(1)
+	for c := 1; c < cols; c++ {
+		r.context.DrawLine(float64(c)*w, 0, float64(c)*w, float64(r.height))
+	}
+	for l := 1; l < rows; l++ {
+		r.context.DrawLine(0, float64(l)*h, float64(r.width), float64(l)*h)
+	}
+	r.context.Stroke()
+}
@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestSheetViews(t *testing.T) {
+	figure := &types.Figure{Camera: types.DefaultCamera()}
+	views := sheetViews(figure)
+
+	// As três primeiras vistas são ortográficas e automáticas
+	for _, v := range views[:3] {
+		if v.camera.Projection != types.ProjectionOrthographic {
+			t.Errorf("Expected orthographic projection for %s, got %q", v.label, v.camera.Projection)
+		}
+		if !v.camera.Auto {
+			t.Errorf("Expected auto-fit for %s", v.label)
+		}
+	}
+
+	// A última usa a câmera da própria figura
+	if views[3].camera.Distance != figure.Camera.Distance || views[3].camera.Auto {
+		t.Errorf("Expected perspective quadrant to use the figure camera, got %+v", views[3].camera)
+	}
+}
+
+func TestRenderSheet(t *testing.T) {
+	renderer := New(400, 300)
+
+	figure := &types.Figure{
+		Nome: "sheet",
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1},
+			{X: 1, Y: 5, Z: 1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+		Camera: types.DefaultCamera(),
+	}
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderSheet(figure, DefaultRenderConfig()); err != nil {
+		t.Fatalf("RenderSheet failed: %v", err)
+	}
+
+	// Câmera e viewport originais devem ser restaurados
+	if renderer.viewport != renderer.fullViewport() {
+		t.Errorf("Expected full viewport after sheet, got %+v", renderer.viewport)
+	}
+	if renderer.camera.Projection != "" {
+		t.Errorf("Expected original camera after sheet, got projection %q", renderer.camera.Projection)
+	}
+
+	// Cada quadrante deve conter algum traço escuro
+	img := renderer.GetImage().(image.Image)
+	quadrants := []image.Rectangle{
+		image.Rect(0, 20, 200, 150),
+		image.Rect(200, 20, 400, 150),
+		image.Rect(0, 170, 200, 300),
+		image.Rect(200, 170, 400, 300),
+	}
+	for i, q := range quadrants {
+		if !hasDarkPixel(img, q.Inset(2)) {
+			t.Errorf("Expected drawing in quadrant %d", i)
+		}
+	}
+}
+
+func TestSheetGrid(t *testing.T) {
+	tests := []struct {
+		count, cols, rows int
+	}{
+		{1, 1, 1},
+		{2, 2, 1},
+		{4, 2, 2},
+		{6, 3, 2},
+		{8, 3, 3},
+		{9, 3, 3},
+		{10, 4, 3},
+	}
+	for _, tt := range tests {
+		cols, rows := SheetGrid(tt.count)
+		if cols != tt.cols || rows != tt.rows {
+			t.Errorf("SheetGrid(%d): expected %d×%d, got %d×%d", tt.count, tt.cols, tt.rows, cols, rows)
+		}
+	}
+}
+
+// hasDarkPixel verifica se há algum pixel escuro dentro do retângulo
+func hasDarkPixel(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r < 0x8000 && g < 0x8000 && b < 0x8000 {
+				return true
+			}
+		}
+	}
+	return false
+}
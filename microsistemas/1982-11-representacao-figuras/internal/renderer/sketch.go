@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Parâmetros do estilo de esboço, em pixels da imagem final.
+const (
+	sketchJitter    = 1.2 // Deslocamento máximo dos extremos, perpendicular ao traço
+	sketchBow       = 3.0 // Curvatura máxima do traço no meio
+	sketchOvershoot = 6.0 // Quanto o traço passa, no máximo, dos extremos
+	sketchPasses    = 2   // Traços sobrepostos por aresta, como a lápis
+)
+
+// Espaços das chaves de esboço, para que arestas da lista de linhas e
+// arestas das faces com o mesmo índice não tremam igual.
+const (
+	sketchLines uint64 = iota + 1
+	sketchFaces
+)
+
+// sketchRand é um gerador pseudoaleatório mínimo (SplitMix64).
+//
+// Cada aresta recebe o seu próprio gerador, semeado pela semente da
+// configuração e por uma chave da aresta: o tremido de uma aresta não
+// depende de quais outras foram desenhadas antes, e é o mesmo em cada
+// faixa de uma imagem renderizada em partes (ver TiledImage).
+type sketchRand struct {
+	state uint64
+}
+
+// newSketchRand cria o gerador de uma aresta.
+//
+// Parâmetros:
+//   seed: semente da configuração (cfg.Seed)
+//   kind: espaço da chave (sketchLines ou sketchFaces)
+//   index: índice da linha, ou da face com a aresta nos bits baixos
+func newSketchRand(seed int64, kind, index uint64) *sketchRand {
+	g := &sketchRand{state: uint64(seed)}
+	g.state ^= g.next() ^ kind*0x9e3779b97f4a7c15
+	g.state ^= g.next() ^ index*0xbf58476d1ce4e5b9
+	return g
+}
+
+// next retorna o próximo número de 64 bits da sequência.
+func (g *sketchRand) next() uint64 {
+	g.state += 0x9e3779b97f4a7c15
+	z := g.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// signed retorna um número uniforme entre -1 e 1.
+func (g *sketchRand) signed() float64 {
+	return float64(g.next()>>11)/(1<<52) - 1
+}
+
+// addLine acrescenta uma aresta ao caminho atual, no estilo de linha
+// configurado (o traço é aplicado depois, por quem chama).
+//
+// No estilo contínuo, é o segmento reto de a até b. No esboço, cada
+// aresta vira sketchPasses curvas levemente tortas, com os extremos
+// deslocados e prolongados além dos vértices, imitando um rascunho a
+// lápis. O tremido é determinístico: a mesma semente e a mesma aresta
+// produzem sempre o mesmo traço.
+//
+// Parâmetros:
+//   a, b: extremos da aresta em pixels
+//   cfg: configurações visuais (estilo de linha e semente)
+//   kind, index: chave da aresta (ver newSketchRand)
+func (r *Renderer3D) addLine(a, b types.Point2D, cfg RenderConfig, kind, index uint64) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	if cfg.LineStyle != LineSketch || length < 1 {
+		r.context.MoveTo(a.X, a.Y)
+		r.context.LineTo(b.X, b.Y)
+		return
+	}
+
+	// Direção do traço e a sua perpendicular
+	ux, uy := dx/length, dy/length
+	nx, ny := -uy, ux
+
+	// Arestas curtas tremem menos, para não se desfigurarem
+	limit := math.Min(1, length/40)
+	jitter := sketchJitter * limit
+	bow := sketchBow * limit
+	overshoot := math.Min(sketchOvershoot, length/8)
+
+	g := newSketchRand(cfg.Seed, kind, index)
+	for pass := 0; pass < sketchPasses; pass++ {
+		// Prolongamentos sempre para fora dos vértices
+		start := overshoot * (g.signed() + 1) / 2
+		end := overshoot * (g.signed() + 1) / 2
+		j1, j2, k := jitter*g.signed(), jitter*g.signed(), bow*g.signed()
+
+		x0, y0 := a.X-ux*start+nx*j1, a.Y-uy*start+ny*j1
+		x1, y1 := b.X+ux*end+nx*j2, b.Y+uy*end+ny*j2
+		cx, cy := (x0+x1)/2+nx*k, (y0+y1)/2+ny*k
+
+		r.context.MoveTo(x0, y0)
+		r.context.QuadraticTo(cx, cy, x1, y1)
+	}
+}
@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestSketchRand(t *testing.T) {
+	a := newSketchRand(42, sketchLines, 3)
+	b := newSketchRand(42, sketchLines, 3)
+	for i := 0; i < 100; i++ {
+		x, y := a.signed(), b.signed()
+		if x != y {
+			t.Fatalf("Expected identical sequences for the same seed and key, got %g and %g", x, y)
+		}
+		if x < -1 || x >= 1 {
+			t.Fatalf("Expected values in [-1, 1), got %g", x)
+		}
+	}
+
+	// Chaves diferentes (ou espaços diferentes) dão sequências diferentes
+	first := newSketchRand(42, sketchLines, 3).next()
+	for _, g := range []*sketchRand{
+		newSketchRand(43, sketchLines, 3),
+		newSketchRand(42, sketchLines, 4),
+		newSketchRand(42, sketchFaces, 3),
+	} {
+		if g.next() == first {
+			t.Errorf("Expected a different sequence for %+v", g)
+		}
+	}
+}
+
+func TestRenderFigureWithConfig_Sketch(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -2, Y: 5, Z: 0}, {X: 2, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	render := func(style LineStyle, seed int64) *image.RGBA {
+		cfg := DefaultRenderConfig()
+		cfg.LineStyle = style
+		cfg.Seed = seed
+		renderer := New(200, 100)
+		renderer.SetCamera(types.DefaultCamera())
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+		return renderer.GetImage().(*image.RGBA)
+	}
+
+	solid := render(LineSolid, 0)
+	sketch := render(LineSketch, 7)
+	if bytes.Equal(solid.Pix, sketch.Pix) {
+		t.Fatal("Expected the sketch style to change the strokes")
+	}
+	if !bytes.Equal(sketch.Pix, render(LineSketch, 7).Pix) {
+		t.Error("Expected the same seed to reproduce the same sketch")
+	}
+	if bytes.Equal(sketch.Pix, render(LineSketch, 8).Pix) {
+		t.Error("Expected a different seed to change the sketch")
+	}
+
+	// O traço passa dos vértices (prolongamento), mas pouco
+	inked := func(img *image.RGBA) (int, int) {
+		minX, maxX := img.Bounds().Dx(), -1
+		for y := 0; y < img.Bounds().Dy(); y++ {
+			for x := 0; x < img.Bounds().Dx(); x++ {
+				if img.RGBAAt(x, y).R < 128 {
+					minX, maxX = min(minX, x), max(maxX, x)
+				}
+			}
+		}
+		return minX, maxX
+	}
+	solidMin, solidMax := inked(solid)
+	sketchMin, sketchMax := inked(sketch)
+	if sketchMin > solidMin && sketchMax < solidMax {
+		t.Errorf("Expected sketch strokes to overshoot [%d, %d], got [%d, %d]", solidMin, solidMax, sketchMin, sketchMax)
+	}
+	if solidMin-sketchMin > int(sketchOvershoot)+2 || sketchMax-solidMax > int(sketchOvershoot)+2 {
+		t.Errorf("Expected overshoot of at most %g pixels, got [%d, %d] for [%d, %d]",
+			sketchOvershoot, sketchMin, sketchMax, solidMin, solidMax)
+	}
+}
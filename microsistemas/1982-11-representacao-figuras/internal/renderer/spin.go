@@ -0,0 +1,60 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Giro da câmera do comando animate (ver SpinAnimation).
+const (
+	DefaultSpinFrames  = 100 // Número padrão de quadros (4 s a 25 quadros/s)
+	DefaultSpinDegrees = 360 // Ângulo padrão: uma volta completa
+	spinStep           = 15  // Maior ângulo entre dois quadros-chave, em graus
+)
+
+// SpinAnimation descreve como quadros-chave a câmera dando a volta em
+// torno do eixo vertical que passa pelo centro da figura, o caso comum
+// de "só girar" sem escrever uma seção "animacao" no YAML.
+//
+// Ao contrário do comando gif, que gira a figura, aqui é a câmera que
+// se move, pelos mesmos quadros-chave de uma animação escrita à mão, e
+// por isso o giro aceita suavização (ver KeyframeCameras). Os quadros-
+// chave ficam a no máximo 15° um do outro, o bastante para que a
+// spline não se afaste do círculo mais de meio por cento do raio (nos
+// dois primeiros e nos dois últimos; entre os demais, um décimo disso).
+//
+// Parâmetros:
+//   camera: câmera já resolvida, com alvo (ver EffectiveCamera)
+//   figure: figura, de onde vem o centro do giro
+//   degrees: ângulo do giro (positivo = anti-horário visto de cima)
+//   frames: número de quadros, a DefaultAnimationFPS quadros por segundo
+//   easing: curva de suavização (types.Easing*)
+//
+// Retorna:
+//   *types.Animation: animação pronta para KeyframeCameras
+func SpinAnimation(camera types.Camera, figure *types.Figure, degrees float64, frames int, easing string) *types.Animation {
+	steps := int(math.Max(2, math.Ceil(math.Abs(degrees)/spinStep)))
+	duration := float64(max(frames, 2)-1) / DefaultAnimationFPS
+
+	min, max := figure.Bounds()
+	cx, cy, cz := (min.X+max.X)/2, (min.Y+max.Y)/2, (min.Z+max.Z)/2
+
+	animation := &types.Animation{FPS: DefaultAnimationFPS, Easing: easing}
+	for k := 0; k <= steps; k++ {
+		angle := degrees * float64(k) / float64(steps)
+		rotation := types.Translate(-cx, -cy, -cz).Then(types.RotateZ(angle)).Then(types.Translate(cx, cy, cz))
+
+		keyframe := types.Keyframe{
+			Time:     duration * float64(k) / float64(steps),
+			Observer: rotation.Transform(camera.Observer),
+			Distance: camera.Distance,
+		}
+		if camera.Target != nil {
+			target := rotation.Transform(*camera.Target)
+			keyframe.Target = &target
+		}
+		animation.Keyframes = append(animation.Keyframes, keyframe)
+	}
+	return animation
+}
@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestSpinAnimation(t *testing.T) {
+	// Caixa centrada em (0, 5, 0), câmera a 10 unidades do centro
+	figure := &types.Figure{Pontos: []types.Point3D{{X: -1, Y: 4, Z: -1}, {X: 1, Y: 6, Z: 1}}}
+	camera := types.DefaultCamera()
+	camera.Observer = types.Point3D{Y: -5, Z: 2}
+	camera.Target = &types.Point3D{Y: 5}
+
+	animation := SpinAnimation(camera, figure, 360, 101, types.EasingInOut)
+	if animation.Easing != types.EasingInOut || animation.FPS != DefaultAnimationFPS {
+		t.Errorf("Unexpected animation settings: %+v", animation)
+	}
+
+	// Uma volta em passos de 15°, em 4 s (101 quadros a 25 quadros/s)
+	keyframes := animation.Keyframes
+	if len(keyframes) != 25 {
+		t.Fatalf("Expected 25 keyframes, got %d", len(keyframes))
+	}
+	if last := keyframes[24]; math.Abs(last.Time-4) > 1e-9 || math.Abs(last.Observer.Y+5) > 1e-9 {
+		t.Errorf("Expected last keyframe back at the start at 4 s, got %+v", last)
+	}
+
+	// Um quarto de volta: o observador passa para +X, na mesma altura,
+	// e o alvo continua no centro
+	quarter := keyframes[6]
+	if o := quarter.Observer; math.Abs(o.X-10) > 1e-9 || math.Abs(o.Y-5) > 1e-9 || o.Z != 2 {
+		t.Errorf("Expected observer at (10, 5, 2), got %v", o)
+	}
+	if tg := *quarter.Target; math.Abs(tg.X) > 1e-9 || math.Abs(tg.Y-5) > 1e-9 {
+		t.Errorf("Expected target at the center, got %v", tg)
+	}
+
+	// As câmeras interpoladas ficam perto do círculo
+	cameras, _, err := KeyframeCameras(camera, animation, 101)
+	if err != nil {
+		t.Fatalf("KeyframeCameras failed: %v", err)
+	}
+	for n, cam := range cameras {
+		if r := math.Hypot(cam.Observer.X, cam.Observer.Y-5); math.Abs(r-10) > 0.05 {
+			t.Errorf("Camera %d: expected radius 10, got %g", n, r)
+		}
+	}
+
+	// Ângulos pequenos ainda têm dois trechos
+	if got := len(SpinAnimation(camera, figure, 10, 20, "").Keyframes); got != 3 {
+		t.Errorf("Expected 3 keyframes for a short spin, got %d", got)
+	}
+}
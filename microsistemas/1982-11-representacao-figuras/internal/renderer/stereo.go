@@ -0,0 +1,202 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultEyeSeparationRatio é a separação padrão entre os olhos como
+// fração da distância de convergência (a "regra de 1/30" da fotografia
+// estereoscópica, que mantém a paralaxe confortável para a maioria).
+const DefaultEyeSeparationRatio = 1.0 / 30
+
+// stereoCameras calcula as câmeras dos olhos esquerdo e direito.
+//
+// A câmera do renderizador é primeiro resolvida (órbita e enquadramento
+// automático), para que os dois olhos partam do mesmo observador. Cada
+// olho é então deslocado de metade da separação ao longo do vetor
+// "direita" do observador, e os dois olhos passam a olhar para o mesmo
+// ponto de convergência, que aparece no plano da tela. Por padrão é o
+// alvo da câmera ou, sem alvo, o ponto à distância R à frente do
+// observador; cfg.Convergence escolhe outra distância ao longo da mesma
+// direção. O que estiver mais perto parece saltar da tela; o que estiver
+// mais longe, afundar nela.
+//
+// Parâmetros:
+//   figure: figura a renderizar (usada no enquadramento automático)
+//   cfg: configurações visuais (separação, convergência e proporção)
+//
+// Retorna:
+//   left, right: câmeras dos olhos esquerdo e direito
+func (r *Renderer3D) stereoCameras(figure *types.Figure, cfg RenderConfig) (left, right types.Camera) {
+	camera := r.camera
+	if cfg.AspectMode == AspectAdjust && camera.FOV <= 0 {
+		camera.Height = camera.Width / r.aspect()
+	}
+	camera = EffectiveCamera(camera, figure, r.aspect())
+
+	// Ponto de convergência: o alvo ou, sem alvo, o centro do plano projetante
+	basis := cameraBasis(camera)
+	focus := camera.Distance
+	if camera.Target != nil {
+		focus = toVec3(*camera.Target).sub(toVec3(camera.Observer)).length()
+	}
+	if cfg.Convergence > 0 {
+		focus = cfg.Convergence
+	}
+	convergence := types.Point3D{
+		X: camera.Observer.X + basis.forward.X*focus,
+		Y: camera.Observer.Y + basis.forward.Y*focus,
+		Z: camera.Observer.Z + basis.forward.Z*focus,
+	}
+
+	separation := cfg.EyeSeparation
+	if separation <= 0 {
+		separation = focus * DefaultEyeSeparationRatio
+	}
+
+	offset := basis.right
+	half := separation / 2
+
+	left, right = camera, camera
+	left.Target = &convergence
+	right.Target = &convergence
+	left.Observer = types.Point3D{
+		X: camera.Observer.X - offset.X*half,
+		Y: camera.Observer.Y - offset.Y*half,
+		Z: camera.Observer.Z - offset.Z*half,
+	}
+	right.Observer = types.Point3D{
+		X: camera.Observer.X + offset.X*half,
+		Y: camera.Observer.Y + offset.Y*half,
+		Z: camera.Observer.Z + offset.Z*half,
+	}
+	return left, right
+}
+
+// RenderAnaglyph renderiza a figura como um anáglifo vermelho/ciano.
+//
+// A figura é projetada duas vezes, uma para cada olho, e as imagens são
+// combinadas canal a canal: o vermelho vem da luminância da vista do
+// olho esquerdo e o verde e o azul, da vista do olho direito. Vista com
+// óculos de filtro vermelho à esquerda e ciano à direita, cada olho
+// enxerga apenas a sua projeção e o cérebro reconstrói a profundidade.
+// Aramados são especialmente adequados, pois quase não há áreas
+// preenchidas que causem "fantasmas" entre as vistas.
+//
+// Parâmetros:
+//   figure: figura 3D a ser renderizada
+//   cfg: configurações visuais (EyeSeparation define a distância entre os olhos)
+//
+// Retorna:
+//   error: nil se bem-sucedido, erro caso a figura seja inválida
+func (r *Renderer3D) RenderAnaglyph(figure *types.Figure, cfg RenderConfig) error {
+	if len(figure.Pontos) == 0 {
+		return fmt.Errorf("figura não possui pontos")
+	}
+
+	// Preserva a câmera do chamador
+	savedCamera := r.camera
+	defer r.SetCamera(savedCamera)
+
+	left, right := r.stereoCameras(figure, cfg)
+
+	// Olho esquerdo: renderiza e guarda uma cópia
+	r.SetCamera(left)
+	if err := r.RenderFigureWithConfig(figure, cfg); err != nil {
+		return fmt.Errorf("olho esquerdo: %w", err)
+	}
+	bounds := r.context.Image().Bounds()
+	leftImage := image.NewRGBA(bounds)
+	draw.Draw(leftImage, bounds, r.context.Image(), bounds.Min, draw.Src)
+
+	// Olho direito: renderiza sobre a própria tela do renderizador
+	r.SetCamera(right)
+	if err := r.RenderFigureWithConfig(figure, cfg); err != nil {
+		return fmt.Errorf("olho direito: %w", err)
+	}
+
+	rgba, ok := r.context.Image().(*image.RGBA)
+	if !ok {
+		return fmt.Errorf("imagem do renderizador não é RGBA")
+	}
+
+	// Combina: vermelho = olho esquerdo, verde e azul = olho direito
+	for i := 0; i < len(rgba.Pix); i += 4 {
+		gray := luminance(rgba.Pix[i : i+3])
+		rgba.Pix[i] = luminance(leftImage.Pix[i : i+3])
+		rgba.Pix[i+1] = gray
+		rgba.Pix[i+2] = gray
+		rgba.Pix[i+3] = 255
+	}
+
+	return nil
+}
+
+// RenderStereoPair renderiza um par estéreo lado a lado.
+//
+// A imagem do renderizador é dividida em duas metades, cada uma com a
+// projeção de um olho; por isso deve ter o dobro da largura desejada
+// para cada vista. No modo paralelo (óculos de realidade virtual ou
+// visão paralela livre) o olho esquerdo fica à esquerda; no modo
+// cruzado (crossEye), as vistas são trocadas para serem fundidas
+// cruzando os olhos, o que permite imagens maiores.
+//
+// Parâmetros:
+//   figure: figura 3D a ser renderizada
+//   cfg: configurações visuais (EyeSeparation e Convergence definem os olhos)
+//   crossEye: true para trocar as vistas (visão cruzada)
+//
+// Retorna:
+//   error: nil se bem-sucedido, erro caso a figura seja inválida
+func (r *Renderer3D) RenderStereoPair(figure *types.Figure, cfg RenderConfig, crossEye bool) error {
+	if len(figure.Pontos) == 0 {
+		return fmt.Errorf("figura não possui pontos")
+	}
+
+	// Preserva câmera e viewport do chamador
+	savedCamera := r.camera
+	defer func() {
+		r.SetViewport(r.fullViewport())
+		r.SetCamera(savedCamera)
+	}()
+
+	halfW := float64(r.width) / 2
+	leftHalf := Viewport{X: 0, Y: 0, Width: halfW, Height: float64(r.height)}
+	rightHalf := Viewport{X: halfW, Y: 0, Width: halfW, Height: float64(r.height)}
+	if crossEye {
+		leftHalf, rightHalf = rightHalf, leftHalf
+	}
+
+	// As câmeras dos olhos usam a proporção de cada metade
+	r.SetViewport(leftHalf)
+	left, right := r.stereoCameras(figure, cfg)
+
+	eyes := []struct {
+		label    string
+		viewport Viewport
+		camera   types.Camera
+	}{
+		{"olho esquerdo", leftHalf, left},
+		{"olho direito", rightHalf, right},
+	}
+	for _, eye := range eyes {
+		r.SetViewport(eye.viewport)
+		r.SetCamera(eye.camera)
+		if err := r.RenderFigureWithConfig(figure, cfg); err != nil {
+			return fmt.Errorf("%s: %w", eye.label, err)
+		}
+	}
+
+	return nil
+}
+
+// luminance converte um pixel RGB em tom de cinza (pesos da ITU-R BT.601).
+func luminance(rgb []uint8) uint8 {
+	y := 0.299*float64(rgb[0]) + 0.587*float64(rgb[1]) + 0.114*float64(rgb[2])
+	return uint8(math.Round(y))
+}
@@ -0,0 +1,166 @@
+package renderer
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestStereoCameras(t *testing.T) {
+	target := types.Point3D{X: 0, Y: 10, Z: 0}
+	camera := types.DefaultCamera()
+	camera.Target = &target
+
+	renderer := New(400, 300)
+	renderer.SetCamera(camera)
+
+	cfg := DefaultRenderConfig()
+	cfg.EyeSeparation = 2
+	left, right := renderer.stereoCameras(&types.Figure{}, cfg)
+
+	// Olhando ao longo de +Y, a direita do observador é +X
+	if math.Abs(left.Observer.X+1) > 1e-9 || math.Abs(right.Observer.X-1) > 1e-9 {
+		t.Errorf("Expected eyes at x=-1 and x=1, got %g and %g", left.Observer.X, right.Observer.X)
+	}
+
+	// Os dois olhos convergem no alvo
+	for _, eye := range []types.Camera{left, right} {
+		if eye.Target == nil || *eye.Target != target {
+			t.Errorf("Expected eye to converge on target, got %+v", eye.Target)
+		}
+	}
+
+	// Separação automática: 1/30 da distância ao alvo
+	left, right = renderer.stereoCameras(&types.Figure{}, DefaultRenderConfig())
+	if got := right.Observer.X - left.Observer.X; math.Abs(got-10.0/30) > 1e-9 {
+		t.Errorf("Expected automatic separation %g, got %g", 10.0/30, got)
+	}
+}
+
+func TestStereoCameras_NoTarget(t *testing.T) {
+	// Sem alvo, os olhos convergem no centro do plano projetante
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+
+	left, right := renderer.stereoCameras(&types.Figure{}, DefaultRenderConfig())
+	want := types.Point3D{Y: types.DefaultCamera().Distance}
+	for _, eye := range []types.Camera{left, right} {
+		if eye.Target == nil || *eye.Target != want {
+			t.Errorf("Expected convergence at %+v, got %+v", want, eye.Target)
+		}
+	}
+}
+
+func TestRenderAnaglyph(t *testing.T) {
+	renderer := New(200, 150)
+	renderer.SetCamera(types.DefaultCamera())
+
+	// Uma aresta à frente do plano de convergência e outra atrás dele
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 5, Z: -1}, {X: -1, Y: 5, Z: 1},
+			{X: 1, Y: 20, Z: -1}, {X: 1, Y: 20, Z: 1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 2, P2: 3}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.LineWidth = 2
+	if err := renderer.RenderAnaglyph(figure, cfg); err != nil {
+		t.Fatalf("RenderAnaglyph failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	var red, cyan bool
+	for y := 0; y < 150; y++ {
+		for x := 0; x < 200; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r > 0xc000 && g < 0x4000 && b < 0x4000 {
+				red = true
+			}
+			if r < 0x4000 && g > 0xc000 && b > 0xc000 {
+				cyan = true
+			}
+			if g != b {
+				t.Fatalf("Expected green and blue channels to match at (%d,%d)", x, y)
+			}
+		}
+	}
+	if !red || !cyan {
+		t.Errorf("Expected both red and cyan pixels, got red=%v cyan=%v", red, cyan)
+	}
+
+	// A câmera do chamador é preservada
+	if renderer.camera.Target != nil || renderer.camera.Observer != (types.Point3D{}) {
+		t.Errorf("Expected caller camera to be restored, got %+v", renderer.camera)
+	}
+}
+
+func TestStereoCameras_Convergence(t *testing.T) {
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+
+	cfg := DefaultRenderConfig()
+	cfg.Convergence = 30
+	left, right := renderer.stereoCameras(&types.Figure{}, cfg)
+
+	want := types.Point3D{Y: 30}
+	if left.Target == nil || *left.Target != want || *right.Target != want {
+		t.Errorf("Expected convergence at %+v, got %+v and %+v", want, left.Target, right.Target)
+	}
+
+	// A separação automática acompanha a distância de convergência
+	if got := right.Observer.X - left.Observer.X; math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected automatic separation 1, got %g", got)
+	}
+}
+
+func TestRenderStereoPair(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: -1}, {X: 1, Y: 5, Z: 1}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	// Coluna mais à esquerda com algum pixel escuro dentro de uma metade
+	firstDark := func(img image.Image, minX, maxX int) int {
+		for x := minX; x < maxX; x++ {
+			if hasDarkPixel(img, image.Rect(x, 0, x+1, 150)) {
+				return x
+			}
+		}
+		return -1
+	}
+
+	offsets := map[bool]int{}
+	for _, crossEye := range []bool{false, true} {
+		renderer := New(400, 150)
+		renderer.SetCamera(types.DefaultCamera())
+
+		cfg := DefaultRenderConfig()
+		cfg.EyeSeparation = 1
+		if err := renderer.RenderStereoPair(figure, cfg, crossEye); err != nil {
+			t.Fatalf("RenderStereoPair failed: %v", err)
+		}
+
+		img := renderer.GetImage().(image.Image)
+		left, right := firstDark(img, 0, 200), firstDark(img, 200, 400)
+		if left < 0 || right < 0 {
+			t.Fatalf("Expected figure in both halves (crossEye=%v)", crossEye)
+		}
+		offsets[crossEye] = (right - 200) - left
+
+		// Viewport e câmera do chamador são restaurados
+		if renderer.viewport != renderer.fullViewport() {
+			t.Errorf("Expected full viewport to be restored, got %+v", renderer.viewport)
+		}
+	}
+
+	// A figura está à frente da convergência: cada olho a vê deslocada
+	// para o lado oposto, e a visão cruzada troca as metades
+	if offsets[false] >= 0 || offsets[true] <= 0 {
+		t.Errorf("Expected parallel offset < 0 and cross-eye offset > 0, got %d and %d",
+			offsets[false], offsets[true])
+	}
+}
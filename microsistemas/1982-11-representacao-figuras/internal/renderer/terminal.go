@@ -0,0 +1,224 @@
+package renderer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// TerminalProtocol é o protocolo de imagens de um terminal, usado para
+// mostrar a figura na própria janela do terminal.
+type TerminalProtocol string
+
+const (
+	TerminalSixel TerminalProtocol = "sixel" // Sixel, dos terminais DEC (xterm, mlterm, foot...)
+	TerminalKitty TerminalProtocol = "kitty" // Protocolo gráfico do kitty (também WezTerm e Ghostty)
+)
+
+// kittyChunkSize é o maior bloco de dados em base64 de cada comando do
+// protocolo do kitty.
+const kittyChunkSize = 4096
+
+// ParseTerminalProtocol converte o nome de um protocolo de imagens de
+// terminal ("sixel" ou "kitty", sem distinção de maiúsculas).
+//
+// Parâmetros:
+//   value: nome do protocolo
+//
+// Retorna:
+//   TerminalProtocol: protocolo correspondente
+//   error: erro se o nome for desconhecido
+func ParseTerminalProtocol(value string) (TerminalProtocol, error) {
+	switch p := TerminalProtocol(strings.ToLower(strings.TrimSpace(value))); p {
+	case TerminalSixel, TerminalKitty:
+		return p, nil
+	}
+	return "", fmt.Errorf("protocolo de terminal desconhecido: %q (use %s ou %s)", value, TerminalSixel, TerminalKitty)
+}
+
+// DetectTerminalProtocol descobre, pelas variáveis de ambiente, se o
+// terminal mostra imagens e com qual protocolo.
+//
+// O kitty é reconhecido por TERM=xterm-kitty ou KITTY_WINDOW_ID; WezTerm
+// e Ghostty, que usam o mesmo protocolo, por TERM_PROGRAM ou TERM. Sixel
+// é presumido quando TERM o menciona ou é de um terminal que sempre o
+// suporta (mlterm, foot, yaft). Terminais que só anunciam sixel em
+// resposta a uma consulta (como o xterm com -ti vt340) não são
+// detectados: informe o protocolo diretamente.
+//
+// Parâmetros:
+//   getenv: leitura das variáveis de ambiente (os.Getenv)
+//
+// Retorna:
+//   TerminalProtocol: protocolo detectado
+//   bool: false se o terminal não parece mostrar imagens
+func DetectTerminalProtocol(getenv func(string) string) (TerminalProtocol, bool) {
+	term := strings.ToLower(getenv("TERM"))
+	program := strings.ToLower(getenv("TERM_PROGRAM"))
+
+	switch {
+	case term == "xterm-kitty", getenv("KITTY_WINDOW_ID") != "",
+		program == "wezterm", program == "ghostty", term == "xterm-ghostty":
+		return TerminalKitty, true
+	case strings.Contains(term, "sixel"), strings.HasPrefix(term, "mlterm"),
+		strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "yaft"):
+		return TerminalSixel, true
+	}
+	return "", false
+}
+
+// WriteTerminal escreve a imagem renderizada como sequências de escape
+// que o terminal mostra no lugar do texto, na posição do cursor.
+//
+// Deve ser chamado depois da renderização (e dos efeitos, se usados).
+// Em Sixel as cores são reduzidas às 216 da paleta web, o máximo que
+// todos os terminais aceitam; no protocolo do kitty a imagem vai como
+// PNG, com as cores intactas.
+//
+// Parâmetros:
+//   w: destino, normalmente a saída padrão
+//   protocol: protocolo de imagens do terminal
+//
+// Retorna:
+//   error: erro se o protocolo for desconhecido ou a escrita falhar
+func (r *Renderer3D) WriteTerminal(w io.Writer, protocol TerminalProtocol) error {
+	switch protocol {
+	case TerminalSixel:
+		return writeSixel(w, r.image())
+	case TerminalKitty:
+		return writeKitty(w, r.image())
+	}
+	return fmt.Errorf("protocolo de terminal desconhecido: %q", protocol)
+}
+
+// writeKitty envia a imagem pelo protocolo gráfico do kitty: o PNG, em
+// base64, dividido em blocos de até kittyChunkSize caracteres; m=1
+// indica que ainda há blocos a seguir.
+//
+// Parâmetros:
+//   w: destino
+//   img: imagem a mostrar
+//
+// Retorna:
+//   error: erro de codificação ou de escrita
+func writeKitty(w io.Writer, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	out := bufio.NewWriter(w)
+	for first := true; first || len(data) > 0; first = false {
+		chunk := data[:min(len(data), kittyChunkSize)]
+		data = data[len(chunk):]
+
+		more := 0
+		if len(data) > 0 {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(out, "\x1b_Ga=T,f=100,m=%d;%s\x1b\\", more, chunk)
+		} else {
+			fmt.Fprintf(out, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	out.WriteString("\n")
+	return out.Flush()
+}
+
+// writeSixel envia a imagem em Sixel.
+//
+// A imagem é reduzida à paleta web (sem pontilhado, que borraria as
+// linhas finas) e percorrida em faixas de 6 linhas de pixels. Em cada
+// faixa, cada cor presente vira uma sequência de caracteres cujos 6 bits
+// (somados a 63) marcam os pixels daquela coluna com a cor; "$" volta ao
+// início da faixa para a próxima cor e "-" passa à faixa seguinte.
+// Repetições de um mesmo caractere são abreviadas como "!n<caractere>".
+//
+// Parâmetros:
+//   w: destino
+//   img: imagem a mostrar
+//
+// Retorna:
+//   error: erro de escrita
+func writeSixel(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	pal := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), palette.WebSafe)
+	draw.Draw(pal, pal.Rect, img, b.Min, draw.Src)
+	width, height := pal.Rect.Dx(), pal.Rect.Dy()
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "\x1bPq\"1;1;%d;%d", width, height)
+
+	// Apenas as cores usadas, em porcentagem de cada componente
+	used := make([]bool, len(pal.Palette))
+	for _, i := range pal.Pix {
+		used[i] = true
+	}
+	for i, c := range pal.Palette {
+		if used[i] {
+			cr, cg, cb, _ := c.RGBA()
+			fmt.Fprintf(out, "#%d;2;%d;%d;%d", i, cr*100/0xffff, cg*100/0xffff, cb*100/0xffff)
+		}
+	}
+
+	row := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		first := true
+		for i := range pal.Palette {
+			if !used[i] {
+				continue
+			}
+			present := false
+			for x := 0; x < width; x++ {
+				bits := byte(0)
+				for dy := 0; dy < 6 && top+dy < height; dy++ {
+					if pal.ColorIndexAt(x, top+dy) == uint8(i) {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = 63 + bits
+				present = present || bits != 0
+			}
+			if !present {
+				continue
+			}
+			if !first {
+				out.WriteByte('$')
+			}
+			first = false
+			fmt.Fprintf(out, "#%d", i)
+			writeSixelRun(out, row)
+		}
+		out.WriteByte('-')
+	}
+	out.WriteString("\x1b\\\n")
+	return out.Flush()
+}
+
+// writeSixelRun escreve os caracteres de uma cor numa faixa, abreviando
+// as repetições de 4 ou mais como "!n<caractere>". Colunas vazias no fim
+// são omitidas.
+func writeSixelRun(out *bufio.Writer, row []byte) {
+	row = bytes.TrimRight(row, "?")
+	for x := 0; x < len(row); {
+		n := 1
+		for x+n < len(row) && row[x+n] == row[x] {
+			n++
+		}
+		if n >= 4 {
+			fmt.Fprintf(out, "!%d%c", n, row[x])
+		} else {
+			out.Write(bytes.Repeat(row[x:x+1], n))
+		}
+		x += n
+	}
+}
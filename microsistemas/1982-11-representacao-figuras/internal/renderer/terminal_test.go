@@ -0,0 +1,110 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParseTerminalProtocol(t *testing.T) {
+	for name, want := range map[string]TerminalProtocol{"sixel": TerminalSixel, "Kitty": TerminalKitty} {
+		if got, err := ParseTerminalProtocol(name); err != nil || got != want {
+			t.Errorf("ParseTerminalProtocol(%q): expected %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := ParseTerminalProtocol("iterm"); err == nil {
+		t.Error("Expected error for unknown protocol")
+	}
+}
+
+func TestDetectTerminalProtocol(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		want TerminalProtocol
+		ok   bool
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, TerminalKitty, true},
+		{map[string]string{"TERM": "xterm-256color", "KITTY_WINDOW_ID": "1"}, TerminalKitty, true},
+		{map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, TerminalKitty, true},
+		{map[string]string{"TERM": "foot"}, TerminalSixel, true},
+		{map[string]string{"TERM": "mlterm"}, TerminalSixel, true},
+		{map[string]string{"TERM": "xterm-sixel"}, TerminalSixel, true},
+		{map[string]string{"TERM": "xterm-256color"}, "", false},
+		{map[string]string{}, "", false},
+	}
+	for _, c := range cases {
+		got, ok := DetectTerminalProtocol(func(key string) string { return c.env[key] })
+		if got != c.want || ok != c.ok {
+			t.Errorf("%v: expected %q (%v), got %q (%v)", c.env, c.want, c.ok, got, ok)
+		}
+	}
+}
+
+func TestWriteSixel(t *testing.T) {
+	// 5×7: coluna 0 branca, o resto preto; a sétima linha cai na
+	// segunda faixa
+	img := image.NewRGBA(image.Rect(0, 0, 5, 7))
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 5; x++ {
+			img.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
+		}
+		img.SetRGBA(0, y, color.RGBA{255, 255, 255, 255})
+	}
+
+	var buf bytes.Buffer
+	if err := writeSixel(&buf, img); err != nil {
+		t.Fatalf("writeSixel failed: %v", err)
+	}
+	want := "\x1bPq\"1;1;5;7#0;2;0;0;0#215;2;100;100;100" +
+		"#0?!4~$#215~-" + // Faixa 1: preto nas colunas 1 a 4, branco na 0
+		"#0?!4@$#215@-" + // Faixa 2: só a primeira linha de pixels
+		"\x1b\\\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWriteKitty(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	for i := range img.Pix {
+		img.Pix[i] = byte(i * 7) // Ruído: o PNG não cabe num só bloco
+	}
+
+	var buf bytes.Buffer
+	if err := writeKitty(&buf, img); err != nil {
+		t.Fatalf("writeKitty failed: %v", err)
+	}
+
+	commands := regexp.MustCompile("\x1b_G([^;]*);([^\x1b]*)\x1b\\\\").FindAllStringSubmatch(buf.String(), -1)
+	if len(commands) < 2 {
+		t.Fatalf("Expected several chunks, got %d", len(commands))
+	}
+	var data strings.Builder
+	for i, c := range commands {
+		last := i == len(commands)-1
+		if (c[1] == "m=0" || strings.HasSuffix(c[1], "m=0")) != last {
+			t.Errorf("Chunk %d: unexpected keys %q", i, c[1])
+		}
+		if len(c[2]) > kittyChunkSize {
+			t.Errorf("Chunk %d: %d bytes exceeds %d", i, len(c[2]), kittyChunkSize)
+		}
+		data.WriteString(c[2])
+	}
+	if !strings.HasPrefix(commands[0][1], "a=T,f=100") {
+		t.Errorf("Expected first chunk to transmit a PNG, got %q", commands[0][1])
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(data.String())
+	if err != nil {
+		t.Fatalf("Invalid base64: %v", err)
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || config.Width != 300 || config.Height != 300 {
+		t.Errorf("Expected a 300x300 PNG, got %dx%d (%v)", config.Width, config.Height, err)
+	}
+}
@@ -0,0 +1,131 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// DefaultFontSize é a altura padrão, em pixels, dos nomes dos pontos,
+// dos eixos e do carimbo quando um arquivo de fonte é informado sem
+// tamanho: a mesma da fonte de bitmap embutida.
+const DefaultFontSize = 13
+
+// regularFont é a fonte vetorial padrão dos textos: a Go Regular,
+// embutida no programa para que o resultado não dependa das fontes
+// instaladas no sistema.
+var regularFont, _ = truetype.Parse(goregular.TTF)
+
+// loadFontFace carrega um arquivo de fonte TrueType no tamanho pedido.
+//
+// Parâmetros:
+//   filename: caminho do arquivo .ttf
+//   size: altura da fonte em pixels
+//
+// Retorna:
+//   font.Face: fonte pronta para uso no contexto gráfico
+//   error: erro se o arquivo não puder ser lido ou não for uma fonte
+func loadFontFace(filename string, size float64) (font.Face, error) {
+	face, err := gg.LoadFontFace(filename, size)
+	if err != nil {
+		return nil, fmt.Errorf("fonte %q: %w", filename, err)
+	}
+	return face, nil
+}
+
+// fontFace retorna a fonte dos textos no tamanho pedido, já ampliada
+// pelo fator de superamostragem (a gg não aplica a escala do contexto
+// às letras; ver setLineWidth).
+//
+// Usa o arquivo de fonte configurado ou, sem ele, a Go Regular. Se o
+// arquivo não puder ser carregado (ConfigFromFigure já o verificou),
+// também recorre à Go Regular.
+//
+// Parâmetros:
+//   cfg: configurações visuais (arquivo de fonte)
+//   size: altura da fonte em pixels da imagem final
+//
+// Retorna:
+//   font.Face: fonte pronta para uso no contexto gráfico
+func (r *Renderer3D) fontFace(cfg RenderConfig, size float64) font.Face {
+	size *= float64(r.scale)
+	if cfg.FontFile != "" {
+		if face, err := loadFontFace(cfg.FontFile, size); err == nil {
+			return face
+		}
+	}
+	return truetype.NewFace(regularFont, &truetype.Options{Size: size})
+}
+
+// setTextFont prepara a fonte dos textos desenhados junto com a figura:
+// nomes dos pontos, eixos, carimbo e rótulos das vistas.
+//
+// Sem arquivo nem tamanho configurados, é a fonte de bitmap 7×13 da gg,
+// como sempre foi; ela fica ilegível em imagens grandes, e por isso
+// RenderConfig.FontFile e RenderConfig.FontSize permitem trocá-la.
+//
+// Parâmetros:
+//   cfg: configurações visuais (arquivo e tamanho da fonte)
+func (r *Renderer3D) setTextFont(cfg RenderConfig) {
+	if cfg.FontFile == "" && cfg.FontSize <= 0 {
+		r.context.SetFontFace(basicfont.Face7x13)
+		return
+	}
+	size := cfg.FontSize
+	if size <= 0 {
+		size = DefaultFontSize
+	}
+	r.context.SetFontFace(r.fontFace(cfg, size))
+}
+
+// measureText retorna a largura e a altura de um texto na fonte atual,
+// em pixels da imagem final (a gg mede na tela interna, ampliada pela
+// superamostragem).
+func (r *Renderer3D) measureText(text string) (float64, float64) {
+	w, h := r.context.MeasureString(text)
+	return w / float64(r.scale), h / float64(r.scale)
+}
+
+// drawLabel escreve um nome curto (de ponto ou de eixo) com o realce
+// configurado atrás dele.
+//
+// Sobre linhas escuras ou faces coloridas, o texto se perde no desenho.
+// Com HaloOutline, o texto é antes escrito na cor do realce em oito
+// posições deslocadas ao redor da original, formando um contorno em
+// volta de cada letra; com HaloBox, um retângulo de cantos arredondados
+// na cor do realce é preenchido atrás dele.
+//
+// Parâmetros:
+//   text: texto a escrever
+//   x, y: início da linha de base do texto, em pixels
+//   col: cor do texto
+//   cfg: configurações visuais (realce e sua cor)
+func (r *Renderer3D) drawLabel(text string, x, y float64, col colorRGB, cfg RenderConfig) {
+	w, h := r.measureText(text)
+
+	switch cfg.LabelHalo {
+	case HaloOutline:
+		r.setColor(cfg.LabelHaloColor)
+		radius := math.Max(1, h/8)
+		for k := 0; k < 8; k++ {
+			angle := float64(k) * math.Pi / 4
+			r.context.DrawString(text, x+radius*math.Cos(angle), y+radius*math.Sin(angle))
+		}
+	case HaloBox:
+		// A linha de base fica a cerca de 80% da altura da fonte
+		pad := math.Max(2, h/5)
+		top, bottom := y-0.8*h-pad, y+0.2*h+pad
+		r.setColor(cfg.LabelHaloColor)
+		width, height := w+2*pad, bottom-top
+		r.context.DrawRoundedRectangle(x-pad, top, width, height, math.Min(width, height)/2)
+		r.context.Fill()
+	}
+
+	r.setColor(col)
+	r.context.DrawString(text, x, y)
+}
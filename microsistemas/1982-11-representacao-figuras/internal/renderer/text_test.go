@@ -0,0 +1,116 @@
+package renderer
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// writeTestFont grava uma fonte TrueType válida num diretório temporário.
+func writeTestFont(t *testing.T) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "fonte.ttf")
+	if err := os.WriteFile(filename, goregular.TTF, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestSetTextFont(t *testing.T) {
+	renderer := New(200, 100)
+
+	// Padrão: a fonte de bitmap 7×13
+	renderer.setTextFont(DefaultRenderConfig())
+	if w, h := renderer.measureText("AB"); w != 14 || h != 13 {
+		t.Errorf("Expected bitmap font metrics 14x13, got %gx%g", w, h)
+	}
+
+	// Tamanho sem arquivo: a fonte vetorial embutida
+	cfg := DefaultRenderConfig()
+	cfg.FontSize = 30
+	renderer.setTextFont(cfg)
+	_, large := renderer.measureText("AB")
+	if large < 30 {
+		t.Errorf("Expected text at least 30 pixels high, got %g", large)
+	}
+
+	// Arquivo sem tamanho: DefaultFontSize, medido em pixels finais
+	// mesmo com superamostragem
+	cfg = DefaultRenderConfig()
+	cfg.FontFile = writeTestFont(t)
+	renderer.setTextFont(cfg)
+	w1, h1 := renderer.measureText("AB")
+	if err := renderer.SetSupersampling(2); err != nil {
+		t.Fatal(err)
+	}
+	renderer.setTextFont(cfg)
+	if w2, h2 := renderer.measureText("AB"); w2 != w1 || h2 != h1 {
+		t.Errorf("Expected the same metrics with supersampling, got %gx%g and %gx%g", w1, h1, w2, h2)
+	}
+	if h1 < DefaultFontSize || h1 >= 30 {
+		t.Errorf("Expected default size text, got height %g", h1)
+	}
+}
+
+func TestLoadFontFace(t *testing.T) {
+	if _, err := loadFontFace(writeTestFont(t), 12); err != nil {
+		t.Errorf("loadFontFace failed: %v", err)
+	}
+
+	notFont := filepath.Join(t.TempDir(), "texto.ttf")
+	os.WriteFile(notFont, []byte("não é uma fonte"), 0644)
+	for _, filename := range []string{notFont, filepath.Join(t.TempDir(), "falta.ttf")} {
+		if _, err := loadFontFace(filename, 12); err == nil {
+			t.Errorf("Expected error for %s, got nil", filename)
+		}
+	}
+}
+
+func TestDrawLabel_Halo(t *testing.T) {
+	tests := []struct {
+		name    string
+		halo    LabelHalo
+		wantRed bool // Pixels na cor do realce
+		wantBox bool // Realce também entre as letras
+	}{
+		{"none", HaloNone, false, false},
+		{"outline", HaloOutline, true, false},
+		{"box", HaloBox, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultRenderConfig()
+			cfg.Background = colorRGB{A: 1}
+			cfg.LabelHalo = tt.halo
+			cfg.LabelHaloColor = colorRGB{R: 1, A: 1}
+
+			renderer := New(100, 40)
+			renderer.fillBackground(cfg)
+			renderer.setTextFont(cfg)
+			renderer.drawLabel("II", 40, 25, colorRGB{G: 1, A: 1}, cfg)
+			img := renderer.GetImage().(*image.RGBA)
+
+			red := 0
+			for y := 0; y < 40; y++ {
+				for x := 0; x < 100; x++ {
+					if px := img.RGBAAt(x, y); px.R > 128 && px.G < 128 {
+						red++
+					}
+				}
+			}
+			if (red > 0) != tt.wantRed {
+				t.Errorf("Expected halo pixels %v, got %d", tt.wantRed, red)
+			}
+
+			// Um pouco à esquerda do texto, na altura das letras, só a
+			// etiqueta pinta
+			if px := img.RGBAAt(39, 20); (px.R > 128) != tt.wantBox {
+				t.Errorf("Expected box at the label's left edge %v, got %+v", tt.wantBox, px)
+			}
+		})
+	}
+}
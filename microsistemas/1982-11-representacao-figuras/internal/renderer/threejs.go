@@ -0,0 +1,322 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Estruturas do formato JSON de objetos do Three.js (versão 4), lido por
+// THREE.ObjectLoader. Só os campos usados pela exportação estão aqui.
+type (
+	threeScene struct {
+		Metadata   threeMetadata   `json:"metadata"`
+		Geometries []threeGeometry `json:"geometries"`
+		Materials  []threeMaterial `json:"materials"`
+		Object     threeObject     `json:"object"`
+	}
+
+	threeMetadata struct {
+		Version   float64 `json:"version"`
+		Type      string  `json:"type"`
+		Generator string  `json:"generator"`
+	}
+
+	threeGeometry struct {
+		UUID string            `json:"uuid"`
+		Type string            `json:"type"`
+		Data threeGeometryData `json:"data"`
+	}
+
+	threeGeometryData struct {
+		Attributes map[string]threeAttribute `json:"attributes"`
+		Index      *threeAttribute           `json:"index,omitempty"`
+		Groups     []threeGroup              `json:"groups,omitempty"`
+	}
+
+	threeAttribute struct {
+		ItemSize   int       `json:"itemSize"`
+		Type       string    `json:"type"`
+		Array      []float64 `json:"array"`
+		Normalized bool      `json:"normalized"`
+	}
+
+	threeGroup struct {
+		Start         int `json:"start"`
+		Count         int `json:"count"`
+		MaterialIndex int `json:"materialIndex"`
+	}
+
+	threeMaterial struct {
+		UUID                string  `json:"uuid"`
+		Type                string  `json:"type"`
+		Color               int     `json:"color"`
+		Opacity             float64 `json:"opacity"`
+		Transparent         bool    `json:"transparent"`
+		Size                float64 `json:"size,omitempty"`
+		SizeAttenuation     *bool   `json:"sizeAttenuation,omitempty"`
+		Side                int     `json:"side,omitempty"`
+		PolygonOffset       bool    `json:"polygonOffset,omitempty"`
+		PolygonOffsetFactor float64 `json:"polygonOffsetFactor,omitempty"`
+		PolygonOffsetUnits  float64 `json:"polygonOffsetUnits,omitempty"`
+	}
+
+	threeObject struct {
+		UUID             string         `json:"uuid"`
+		Type             string         `json:"type"`
+		Name             string         `json:"name,omitempty"`
+		Geometry         string         `json:"geometry,omitempty"`
+		Material         any            `json:"material,omitempty"`
+		Matrix           []float64      `json:"matrix,omitempty"`
+		MatrixAutoUpdate *bool          `json:"matrixAutoUpdate,omitempty"`
+		Up               []float64      `json:"up,omitempty"`
+		Background       *int           `json:"background,omitempty"`
+		FOV              float64        `json:"fov,omitempty"`
+		Aspect           float64        `json:"aspect,omitempty"`
+		Left             float64        `json:"left,omitempty"`
+		Right            float64        `json:"right,omitempty"`
+		Top              float64        `json:"top,omitempty"`
+		Bottom           float64        `json:"bottom,omitempty"`
+		Near             float64        `json:"near,omitempty"`
+		Far              float64        `json:"far,omitempty"`
+		UserData         map[string]any `json:"userData,omitempty"`
+		Children         []threeObject  `json:"children,omitempty"`
+	}
+)
+
+// threeVertexSize é o diâmetro dos vértices em pixels, o dos círculos da
+// imagem.
+const threeVertexSize = 4
+
+// RenderThreeJS converte a figura e a sua câmera para o formato JSON de
+// objetos do Three.js, para que as figuras possam ser mostradas, e
+// giradas, em páginas da web:
+//
+//   const cena = new THREE.ObjectLoader().parse(json);
+//   const camera = cena.getObjectByName("camera");
+//   renderer.render(cena, camera);
+//
+// A cena tem o fundo da figura, a câmera e um grupo "figura" com as
+// arestas (LineSegments), as faces (Mesh, uma cor por material, com os
+// polígonos divididos em leque) e, com mostrar_vertices, os vértices
+// (Points). As arestas são as linhas e as arestas das faces que não
+// repetem uma linha reta, como no DXF, com as curvas em trechos retos.
+// As coordenadas são as da figura; a câmera, que olha para o seu -Z
+// local, recebe a base do observador na sua matriz e "up" no eixo Z.
+// Na projeção cônica, o campo de visão vertical e a proporção vêm de R
+// e da tela virtual L1×L2; nas paralelas, a câmera é ortogonal, e a
+// projeção oblíqua é a ortogonal do grupo cisalhado (ver obliqueShear).
+// O alvo, para os controles de órbita, fica em camera.userData.alvo.
+// Nomes, setas, cotas, espessuras e estilos de linha ficam de fora.
+//
+// Parâmetros:
+//   figure: figura 3D
+//   cfg: configurações visuais (cores, vértices e plano próximo)
+//
+// Retorna:
+//   []byte: cena em JSON
+//   error: erro se a figura não tiver pontos ou se a cor de uma face for
+//          inválida
+func (r *Renderer3D) RenderThreeJS(figure *types.Figure, cfg RenderConfig) ([]byte, error) {
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if r.camera.Auto {
+		r.SetCamera(FitCamera(r.camera, figure, r.aspect()))
+	}
+
+	positions := make([]float64, 0, 3*len(figure.Pontos))
+	for _, p := range figure.Pontos {
+		positions = append(positions, p.X, p.Y, p.Z)
+	}
+	scene := threeScene{Metadata: threeMetadata{Version: 4.6, Type: "Object", Generator: "figuras3d"}}
+	group := threeObject{UUID: "figura", Type: "Group", Name: "figura"}
+	add := func(name, kind string, data threeGeometryData, material any) {
+		scene.Geometries = append(scene.Geometries, threeGeometry{UUID: "geometria-" + name, Type: "BufferGeometry", Data: data})
+		group.Children = append(group.Children, threeObject{
+			UUID: name, Type: kind, Name: name, Geometry: "geometria-" + name, Material: material,
+		})
+	}
+	material := func(m threeMaterial, c colorRGB) string {
+		m.UUID = fmt.Sprintf("material-%d", len(scene.Materials))
+		m.Color, m.Opacity, m.Transparent = threeColor(c), c.A, c.A < 1
+		scene.Materials = append(scene.Materials, m)
+		return m.UUID
+	}
+
+	// Faces, divididas em triângulos e agrupadas pela cor
+	if len(figure.Faces) > 0 {
+		type colored struct {
+			color     colorRGB
+			triangles []float64
+		}
+		var colors []*colored
+		for i, face := range figure.Faces {
+			fill := cfg.FaceColor
+			if face.Cor != "" {
+				col, err := parseColor(face.Cor)
+				if err != nil {
+					return nil, fmt.Errorf("cor da face %d inválida: %w", i, err)
+				}
+				fill = col
+			}
+			k := slices.IndexFunc(colors, func(c *colored) bool { return c.color == fill })
+			if k < 0 {
+				k = len(colors)
+				colors = append(colors, &colored{color: fill})
+			}
+			for j := 2; j < len(face.Pontos); j++ {
+				colors[k].triangles = append(colors[k].triangles,
+					float64(face.Pontos[0]), float64(face.Pontos[j-1]), float64(face.Pontos[j]))
+			}
+		}
+		data := threeGeometryData{
+			Attributes: map[string]threeAttribute{"position": {ItemSize: 3, Type: "Float32Array", Array: positions}},
+			Index:      &threeAttribute{ItemSize: 1, Type: "Uint32Array"},
+		}
+		var materials []string
+		for k, c := range colors {
+			data.Groups = append(data.Groups, threeGroup{Start: len(data.Index.Array), Count: len(c.triangles), MaterialIndex: k})
+			data.Index.Array = append(data.Index.Array, c.triangles...)
+			// As faces ficam um pouco atrás das arestas, que continuam visíveis
+			materials = append(materials, material(threeMaterial{
+				Type: "MeshBasicMaterial", Side: 2, PolygonOffset: true, PolygonOffsetFactor: 1, PolygonOffsetUnits: 1,
+			}, c.color))
+		}
+		add("faces", "Mesh", data, materials)
+	}
+
+	// Arestas: os pontos da figura seguidos dos pontos internos das curvas
+	edges := slices.Clone(positions)
+	var index []float64
+	drawn := make(map[[2]int]bool) // Linhas retas, que as faces não repetem
+	if !figure.EdgesFromFaces {
+		for _, linha := range figure.Linhas {
+			if len(linha.Controle) == 0 {
+				index = append(index, float64(linha.P1), float64(linha.P2))
+				drawn[[2]int{min(linha.P1, linha.P2), max(linha.P1, linha.P2)}] = true
+				continue
+			}
+			curve := linha.Curve(figure.Pontos)
+			last := linha.P1
+			for i, p := range curve[1:] {
+				next := linha.P2
+				if i < len(curve)-2 {
+					next = len(edges) / 3
+					edges = append(edges, p.X, p.Y, p.Z)
+				}
+				index = append(index, float64(last), float64(next))
+				last = next
+			}
+		}
+	}
+	for _, e := range figure.FaceEdges() {
+		if !drawn[[2]int{min(e.P1, e.P2), max(e.P1, e.P2)}] {
+			index = append(index, float64(e.P1), float64(e.P2))
+		}
+	}
+	if len(index) > 0 {
+		add("arestas", "LineSegments", threeGeometryData{
+			Attributes: map[string]threeAttribute{"position": {ItemSize: 3, Type: "Float32Array", Array: edges}},
+			Index:      &threeAttribute{ItemSize: 1, Type: "Uint32Array", Array: index},
+		}, material(threeMaterial{Type: "LineBasicMaterial"}, cfg.LineColor))
+	}
+
+	if cfg.ShowVertices {
+		attenuation := false
+		add("vertices", "Points", threeGeometryData{
+			Attributes: map[string]threeAttribute{"position": {ItemSize: 3, Type: "Float32Array", Array: positions}},
+		}, material(threeMaterial{Type: "PointsMaterial", Size: threeVertexSize, SizeAttenuation: &attenuation}, cfg.VertexColor))
+	}
+
+	// Projeção oblíqua: o grupo é cisalhado (ver obliqueShear), numa
+	// matriz que o Three.js não pode decompor em posição, rotação e escala
+	if r.camera.Projection == types.ProjectionOblique {
+		shear, origin := r.obliqueShear()
+		s, f := [3]float64{shear.X, shear.Y, shear.Z}, [3]float64{r.basis.forward.X, r.basis.forward.Y, r.basis.forward.Z}
+		offset := -origin.dot(r.basis.forward)
+		group.Matrix = make([]float64, 16) // Por colunas
+		for col := range 3 {
+			for row := range 3 {
+				group.Matrix[4*col+row] = s[row] * f[col]
+				if row == col {
+					group.Matrix[4*col+row]++
+				}
+			}
+			group.Matrix[12+col] = s[col] * offset
+		}
+		group.Matrix[15] = 1
+		manual := false
+		group.MatrixAutoUpdate = &manual
+	}
+
+	scene.Object = threeObject{UUID: "cena", Type: "Scene", Name: figure.Nome}
+	if cfg.Background.A > 0 {
+		background := threeColor(cfg.Background)
+		scene.Object.Background = &background
+	}
+	scene.Object.Children = []threeObject{r.threeCamera(figure, cfg), group}
+
+	data, err := json.Marshal(scene)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter figura: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// threeCamera monta a câmera do Three.js equivalente à da figura: a
+// matriz tem, por colunas, a direita, o cima e a frente invertida do
+// observador (ver cameraBasis), e a posição dele.
+func (r *Renderer3D) threeCamera(figure *types.Figure, cfg RenderConfig) threeObject {
+	b := r.basis
+	o := r.camera.Observer
+	camera := threeObject{
+		UUID: "camera",
+		Name: "camera",
+		Matrix: []float64{
+			b.right.X, b.right.Y, b.right.Z, 0,
+			b.up.X, b.up.Y, b.up.Z, 0,
+			-b.forward.X, -b.forward.Y, -b.forward.Z, 0,
+			o.X, o.Y, o.Z, 1,
+		},
+		Up: []float64{0, 0, 1},
+	}
+	if r.camera.Target != nil {
+		t := r.camera.Target
+		camera.UserData = map[string]any{"alvo": []float64{t.X, t.Y, t.Z}}
+	}
+
+	// O plano distante fica além do ponto mais afastado do observador
+	far := 0.0
+	for _, p := range figure.Pontos {
+		far = math.Max(far, toVec3(p).sub(toVec3(o)).length())
+	}
+	camera.Far = 2*far + 1
+
+	width, height := r.camera.Width, r.camera.Height
+	if r.camera.IsParallel() {
+		camera.Type = "OrthographicCamera"
+		camera.Left, camera.Right = -width/2, width/2
+		camera.Bottom, camera.Top = -height/2, height/2
+		return camera
+	}
+	camera.Type = "PerspectiveCamera"
+	camera.FOV = 2 * math.Atan2(height/2, r.camera.Distance) * 180 / math.Pi
+	camera.Aspect = width / height
+	camera.Near = cfg.NearPlane
+	if camera.Near <= 0 {
+		camera.Near = DefaultNearPlane
+	}
+	return camera
+}
+
+// threeColor converte uma cor para o inteiro 0xRRGGBB do Three.js.
+func threeColor(c colorRGB) int {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return channel(c.R)<<16 | channel(c.G)<<8 | channel(c.B)
+}
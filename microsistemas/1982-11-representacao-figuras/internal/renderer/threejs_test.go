@@ -0,0 +1,156 @@
+package renderer
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// threeProject projeta um ponto da figura como o Three.js faria com a
+// câmera e o grupo da cena, em pixels de uma imagem width×height.
+func threeProject(scene threeScene, p types.Point3D, width, height int) types.Point2D {
+	camera, group := scene.Object.Children[0], scene.Object.Children[1]
+	q := vec3{X: p.X, Y: p.Y, Z: p.Z}
+	if m := group.Matrix; m != nil {
+		q = vec3{
+			X: m[0]*q.X + m[4]*q.Y + m[8]*q.Z + m[12],
+			Y: m[1]*q.X + m[5]*q.Y + m[9]*q.Z + m[13],
+			Z: m[2]*q.X + m[6]*q.Y + m[10]*q.Z + m[14],
+		}
+	}
+	m := camera.Matrix
+	right, up, back := vec3{X: m[0], Y: m[1], Z: m[2]}, vec3{X: m[4], Y: m[5], Z: m[6]}, vec3{X: m[8], Y: m[9], Z: m[10]}
+	d := q.sub(vec3{X: m[12], Y: m[13], Z: m[14]})
+	x, y, z := d.dot(right), d.dot(up), d.dot(back)
+
+	var nx, ny float64
+	if camera.Type == "PerspectiveCamera" {
+		half := math.Tan(camera.FOV * math.Pi / 360)
+		nx, ny = x/(-z*half*camera.Aspect), y/(-z*half)
+	} else {
+		nx = (2*x - camera.Left - camera.Right) / (camera.Right - camera.Left)
+		ny = (2*y - camera.Bottom - camera.Top) / (camera.Top - camera.Bottom)
+	}
+	return types.Point2D{X: (nx + 1) / 2 * float64(width), Y: (1 - ny) / 2 * float64(height)}
+}
+
+func TestRenderThreeJSCamera(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: 4, Z: -1}, {X: 1, Y: 4, Z: -1}, {X: 1, Y: 6, Z: 1}, {X: -1, Y: 6, Z: 1}, {X: 0, Y: 5, Z: 2},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 2, P2: 4}},
+	}
+	target := types.Point3D{X: 0, Y: 5, Z: 0}
+	cameras := map[string]types.Camera{
+		"artigo": types.DefaultCamera(),
+		"alvo":   {Observer: types.Point3D{X: 6, Y: -3, Z: 4}, Target: &target, Distance: 2, FOV: 50},
+		"ortogonal": {Observer: types.Point3D{X: 5, Y: 0, Z: 5}, Target: &target, Distance: 1, Width: 6, Height: 4,
+			Projection: types.ProjectionOrthographic},
+		"obliqua": {Observer: types.Point3D{X: 0, Y: -10, Z: 0}, Target: &target, Distance: 1, Width: 8, Height: 6,
+			Projection: types.ProjectionOblique, ObliqueAngle: 30, ObliqueFactor: 0.5},
+	}
+	for name, camera := range cameras {
+		t.Run(name, func(t *testing.T) {
+			r := New(640, 400)
+			r.SetCamera(camera)
+			data, err := r.RenderThreeJS(figure, DefaultRenderConfig())
+			if err != nil {
+				t.Fatalf("RenderThreeJS failed: %v", err)
+			}
+			var scene threeScene
+			if err := json.Unmarshal(data, &scene); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if (scene.Object.Children[1].Matrix != nil) != (camera.Projection == types.ProjectionOblique) {
+				t.Errorf("Expected group matrix only for the oblique projection")
+			}
+			for i, p := range figure.Pontos {
+				want := r.ProjectToScreen(p)
+				got := threeProject(scene, p, 640, 400)
+				if math.Abs(got.X-want.X) > 1e-6 || math.Abs(got.Y-want.Y) > 1e-6 {
+					t.Errorf("Point %d: expected %v, Three.js would draw it at %v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderThreeJS(t *testing.T) {
+	figure := &types.Figure{
+		Nome: "telhado",
+		Pontos: []types.Point3D{
+			{X: 0}, {X: 1}, {X: 1, Z: 1}, {X: 0, Z: 1}, {X: 2, Z: 2}, {X: 3, Y: 1},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1},
+			{P1: 4, P2: 5, Controle: []types.Point3D{{X: 3, Z: 3}}}, // Curva de CurveSegments trechos
+		},
+		Faces: []types.Face{
+			{Pontos: []int{0, 1, 2, 3}},
+			{Pontos: []int{2, 3, 4}, Cor: "#ff0000"},
+			{Pontos: []int{1, 2, 4}},
+		},
+		Camera: types.DefaultCamera(),
+	}
+	figure.Camera.Auto = true
+
+	r := New(400, 300)
+	r.SetCamera(figure.Camera)
+	cfg := DefaultRenderConfig()
+	cfg.ShowVertices = true
+	data, err := r.RenderThreeJS(figure, cfg)
+	if err != nil {
+		t.Fatalf("RenderThreeJS failed: %v", err)
+	}
+	var scene threeScene
+	if err := json.Unmarshal(data, &scene); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if scene.Object.Type != "Scene" || scene.Object.Name != "telhado" || scene.Object.Background == nil || *scene.Object.Background != 0xffffff {
+		t.Errorf("Expected named scene with white background, got %+v", scene.Object)
+	}
+	if camera := scene.Object.Children[0]; camera.Type != "PerspectiveCamera" || camera.UserData["alvo"] == nil {
+		t.Errorf("Expected perspective camera with target, got %+v", camera)
+	}
+	group := scene.Object.Children[1]
+	if len(group.Children) != 3 || len(scene.Geometries) != 3 {
+		t.Fatalf("Expected faces, edges and vertices, got %+v", group.Children)
+	}
+
+	// Faces: dois triângulos cinza, depois o vermelho, e um material por cor
+	faces := scene.Geometries[0].Data
+	if len(faces.Index.Array) != 4*3 || len(faces.Groups) != 2 || faces.Groups[1].Count != 3 {
+		t.Errorf("Expected 4 triangles in 2 color groups, got %v %+v", faces.Index.Array, faces.Groups)
+	}
+	if scene.Materials[1].Color != 0xff0000 {
+		t.Errorf("Expected red face material, got %06x", scene.Materials[1].Color)
+	}
+
+	// Arestas: a linha reta, a curva, e as seis arestas das faces que
+	// não repetem 0-1
+	edges := scene.Geometries[1].Data
+	segments := 1 + types.CurveSegments + 6
+	if len(edges.Index.Array) != 2*segments {
+		t.Errorf("Expected %d segments, got %d", segments, len(edges.Index.Array)/2)
+	}
+	if n := len(edges.Attributes["position"].Array) / 3; n != len(figure.Pontos)+types.CurveSegments-1 {
+		t.Errorf("Expected the curve's inner points appended, got %d positions", n)
+	}
+	for _, v := range edges.Index.Array {
+		if int(v) < 0 || int(v) >= len(edges.Attributes["position"].Array)/3 {
+			t.Errorf("Index %v out of range", v)
+		}
+	}
+
+	if _, err := New(400, 300).RenderThreeJS(&types.Figure{}, cfg); err == nil {
+		t.Error("Expected error for figure without points")
+	}
+	figure.Faces[1].Cor = "roxo-claro"
+	if _, err := New(400, 300).RenderThreeJS(figure, cfg); err == nil {
+		t.Error("Expected error for invalid face color")
+	}
+}
@@ -0,0 +1,298 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultTileHeight é a altura padrão, em pixels, das faixas usadas na
+// renderização em partes, sem superamostragem; com ela, a faixa é
+// dividida pelo quadrado do fator (ver NewTiledImage).
+const DefaultTileHeight = 512
+
+// jpegBlockSize é a altura dos blocos que o codificador JPEG lê de cada
+// vez. A altura padrão das faixas é múltipla dela: um bloco que cruzasse
+// duas faixas obrigaria a renderizar as duas de novo a cada bloco.
+const jpegBlockSize = 16
+
+// TiledThreshold é a quantidade de pixels a partir da qual a imagem
+// deve ser renderizada em partes (4096×4096, cerca de 64 MiB em RGBA).
+const TiledThreshold = 4096 * 4096
+
+// TiledImage é uma imagem muito grande renderizada sob demanda, em faixas.
+//
+// A biblioteca gg aloca a tela inteira de uma só vez, o que inviabiliza
+// imagens de tamanho de pôster (20000×15000 pixels ocupariam 1,2 GB).
+// TiledImage divide a tela em faixas horizontais com a largura inteira
+// e renderiza cada uma separadamente, ampliando a sub-janela da tela
+// virtual que lhe corresponde (ver SetRegion). Como o codificador PNG
+// percorre a imagem linha a linha, só uma faixa fica em memória.
+//
+// A imagem implementa image.Image, mas o acesso deve ser sequencial
+// por linhas: voltar a uma faixa anterior a renderiza de novo.
+type TiledImage struct {
+	width, height int
+	tileHeight    int
+	figure        *types.Figure
+	cfg           RenderConfig
+	camera        types.Camera // Câmera já resolvida para a imagem inteira
+	screen        Region       // Tela virtual inteira da câmera resolvida
+	window        Region       // Janela da tela virtual mapeada em inner
+	inner         Viewport     // Área da imagem onde a figura é projetada
+
+	tile    image.Image // Faixa atual (*image.RGBA ou, com 16 bits, *image.RGBA64)
+	tileY   int         // Primeira linha da faixa atual
+	tileErr error       // Primeiro erro de renderização de faixa
+	renders int         // Faixas renderizadas até agora
+}
+
+// NewTiledImage prepara a renderização em faixas de uma figura.
+//
+// A câmera da figura é resolvida uma única vez para a imagem inteira
+// (órbita, campo de visão, enquadramento automático e proporção), de
+// modo que todas as faixas usem exatamente a mesma projeção.
+//
+// Com superamostragem, cada faixa é desenhada numa tela fator² vezes
+// maior; a altura padrão é dividida por fator², para que a faixa ocupe
+// a mesma memória que sem ela, e arredondada para baixo a um múltiplo
+// de 16 linhas, a altura dos blocos do JPEG (no mínimo 16).
+//
+// Parâmetros:
+//   width, height: dimensões da imagem completa em pixels
+//   tileHeight: altura das faixas em pixels (0 = DefaultTileHeight,
+//               dividida por fator² com superamostragem e
+//               arredondada a múltiplo de 16)
+//   figure: figura a renderizar
+//   cfg: configurações visuais
+//   region: sub-janela da tela virtual a ampliar (nil = inteira)
+//
+// Retorna:
+//   *TiledImage: imagem pronta para ser codificada
+//   error: erro se as dimensões ou a figura forem inválidas
+func NewTiledImage(width, height, tileHeight int, figure *types.Figure, cfg RenderConfig, region *Region) (*TiledImage, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("dimensões inválidas: %d×%d", width, height)
+	}
+	if len(figure.Pontos) == 0 {
+		return nil, fmt.Errorf("figura não possui pontos")
+	}
+	if tileHeight <= 0 {
+		factor := max(cfg.Antialias, 1)
+		tileHeight = DefaultTileHeight / (factor * factor)
+		tileHeight = max(tileHeight-tileHeight%jpegBlockSize, jpegBlockSize)
+	}
+
+	aspect := float64(width) / float64(height)
+
+	// Resolve a câmera para a proporção da imagem inteira; cada faixa
+	// tem outra proporção e não deve recalcular nada
+	camera := figure.Camera
+	camera.ResolveOrbit()
+	camera = applyFOV(camera, aspect)
+	camera.FOV = 0
+	if cfg.AspectMode == AspectAdjust {
+		camera.Height = camera.Width / aspect
+	}
+	if camera.Auto {
+		camera = FitCamera(camera, figure, aspect)
+	}
+
+	t := &TiledImage{
+		width:      width,
+		height:     height,
+		tileHeight: tileHeight,
+		figure:     figure,
+		cfg:        cfg,
+		camera:     camera,
+		tileY:      -1,
+	}
+	t.screen = Region{
+		XMin: -camera.Width / 2, XMax: camera.Width / 2,
+		YMin: -camera.Height / 2, YMax: camera.Height / 2,
+	}
+	t.window = t.screen
+	if region != nil {
+		if err := t.SetRegion(*region); err != nil {
+			return nil, err
+		}
+	} else {
+		t.layout()
+	}
+	return t, nil
+}
+
+// VirtualScreen retorna a tela virtual inteira (−L1/2..L1/2 × −L2/2..L2/2)
+// da câmera resolvida, independente da região em uso.
+func (t *TiledImage) VirtualScreen() Region {
+	return t.screen
+}
+
+// SetRegion restringe a imagem a uma sub-janela da tela virtual, como
+// Renderer.SetRegion. Deve ser chamado antes de codificar a imagem.
+//
+// Parâmetros:
+//   region: sub-janela em coordenadas da tela virtual
+//
+// Retorna:
+//   error: erro se a região for degenerada
+func (t *TiledImage) SetRegion(region Region) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+	t.window = region
+	t.layout()
+	t.tileY = -1 // Descarta faixa renderizada com a janela anterior
+	return nil
+}
+
+// layout calcula a área da imagem onde a janela é projetada; com faixas
+// de proporção, a figura ocupa só a área central.
+func (t *TiledImage) layout() {
+	t.inner = Viewport{Width: float64(t.width), Height: float64(t.height)}
+	if t.cfg.AspectMode != AspectLetterbox {
+		return
+	}
+	aspect := float64(t.width) / float64(t.height)
+	windowAspect := (t.window.XMax - t.window.XMin) / (t.window.YMax - t.window.YMin)
+	if windowAspect > aspect {
+		t.inner.Height = t.inner.Width / windowAspect
+		t.inner.Y = (float64(t.height) - t.inner.Height) / 2
+	} else {
+		t.inner.Width = t.inner.Height * windowAspect
+		t.inner.X = (float64(t.width) - t.inner.Width) / 2
+	}
+}
+
+// ColorModel implementa image.Image; com 16 bits por canal (ver
+// SetBitDepth), o codificador PNG grava a imagem em 16 bits.
+func (t *TiledImage) ColorModel() color.Model {
+	if t.cfg.BitDepth == 16 {
+		return color.RGBA64Model
+	}
+	return color.RGBAModel
+}
+
+// Bounds implementa image.Image.
+func (t *TiledImage) Bounds() image.Rectangle {
+	return image.Rect(0, 0, t.width, t.height)
+}
+
+// Opaque informa ao codificador PNG que a imagem pode ter transparência,
+// evitando que ele percorra (e renderize) a imagem inteira para descobrir.
+func (t *TiledImage) Opaque() bool {
+	return false
+}
+
+// At implementa image.Image, renderizando a faixa do pixel se necessário.
+func (t *TiledImage) At(x, y int) color.Color {
+	if y < 0 || y >= t.height {
+		return color.RGBA{}
+	}
+
+	tileY := y - y%t.tileHeight
+	if tileY != t.tileY {
+		var err error
+		t.tile, err = t.renderTile(tileY)
+		t.tileY = tileY
+		t.renders++
+		if err != nil && t.tileErr == nil {
+			t.tileErr = err // Guarda o primeiro erro
+		}
+	}
+	if t.tile == nil {
+		return color.RGBA{}
+	}
+	return t.tile.At(x, y-tileY)
+}
+
+// Err retorna o primeiro erro ocorrido ao renderizar as faixas, se houver.
+func (t *TiledImage) Err() error {
+	return t.tileErr
+}
+
+// renderTile renderiza a faixa que começa na linha tileY.
+//
+// A faixa recebe um renderizador próprio, com a parte da área da figura
+// que a intercepta como viewport e a parte correspondente da tela
+// virtual como região: a projeção de cada ponto cai exatamente no mesmo
+// pixel que cairia na imagem inteira.
+func (t *TiledImage) renderTile(tileY int) (image.Image, error) {
+	h := t.tileHeight
+	if tileY+h > t.height {
+		h = t.height - tileY
+	}
+
+	r := New(t.width, h)
+	if err := r.SetSupersampling(t.cfg.Antialias); err != nil {
+		return nil, err
+	}
+	if err := r.SetBitDepth(t.cfg.BitDepth); err != nil {
+		return nil, err
+	}
+
+	// Faixas de proporção ocupam o que ficar fora da área da figura
+	if t.cfg.AspectMode == AspectLetterbox {
+		r.setColor(t.cfg.BarColor)
+		r.context.Clear()
+	}
+
+	// Interseção (em pixels da imagem inteira) da faixa com a área da figura
+	top := math.Max(float64(tileY), t.inner.Y)
+	bottom := math.Min(float64(tileY+h), t.inner.Y+t.inner.Height)
+	if bottom > top {
+		inner, w := t.inner, t.window
+		scaleY := (w.YMax - w.YMin) / inner.Height
+
+		r.SetCamera(t.camera)
+		r.SetViewport(Viewport{X: inner.X, Y: top - float64(tileY), Width: inner.Width, Height: bottom - top})
+		r.region = &Region{
+			XMin: w.XMin,
+			XMax: w.XMax,
+			YMin: w.YMax - (bottom-inner.Y)*scaleY,
+			YMax: w.YMax - (top-inner.Y)*scaleY,
+		}
+
+		// O gradiente do fundo cobre a área da figura inteira, não a faixa
+		r.backdrop = &Viewport{X: inner.X, Y: inner.Y - float64(tileY), Width: inner.Width, Height: inner.Height}
+
+		cfg := t.cfg
+		cfg.AspectMode = AspectStretch
+		if err := r.RenderFigureWithConfig(t.figure, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.finalImage(), nil
+}
+
+// Save codifica a imagem completa em arquivo PNG ou JPEG, faixa por
+// faixa (ver Renderer3D.SaveImage).
+//
+// Os dois codificadores percorrem a imagem de cima para baixo; o de
+// WebP precisa dela inteira, por isso não está disponível aqui.
+//
+// Parâmetros:
+//   filename: caminho do arquivo a ser criado
+//   opts: formato, qualidade do JPEG e metadados do PNG
+//
+// Retorna:
+//   error: erro de opções, de E/S ou de renderização de alguma faixa
+func (t *TiledImage) Save(filename string, opts SaveOptions) error {
+	format, err := opts.format(filename)
+	if err != nil {
+		return err
+	}
+	if format == FormatWebP {
+		return fmt.Errorf("formato %s indisponível em imagens renderizadas em faixas (use %s ou %s)", FormatWebP, FormatPNG, FormatJPEG)
+	}
+
+	opts.Format = format
+	if err := saveImageFile(filename, opts, func(ImageFormat) image.Image { return t }); err != nil {
+		return err
+	}
+	return t.Err()
+}
@@ -0,0 +1,189 @@
+package renderer
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// tiledTestFigure retorna um cubo visto em perspectiva, com arestas
+// cruzando várias faixas
+func tiledTestFigure() *types.Figure {
+	target := types.Point3D{}
+	return &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -1, Y: -1, Z: -1}, {X: 1, Y: -1, Z: -1}, {X: 1, Y: 1, Z: -1}, {X: -1, Y: 1, Z: -1},
+			{X: -1, Y: -1, Z: 1}, {X: 1, Y: -1, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: -1, Y: 1, Z: 1},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 0},
+			{P1: 4, P2: 5}, {P1: 5, P2: 6}, {P1: 6, P2: 7}, {P1: 7, P2: 4},
+			{P1: 0, P2: 4}, {P1: 1, P2: 5}, {P1: 2, P2: 6}, {P1: 3, P2: 7},
+		},
+		Camera: types.Camera{
+			Target:   &target,
+			Orbit:    &types.Orbit{Azimuth: 30, Elevation: 20, Radius: 8},
+			Distance: 10,
+			FOV:      40,
+		},
+	}
+}
+
+func TestTiledImage_MatchesDirectRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      AspectMode
+		antialias int
+		gradient  Gradient
+	}{
+		{"stretch", AspectStretch, 1, GradientNone},
+		{"letterbox", AspectLetterbox, 1, GradientNone},
+		{"supersampled", AspectStretch, 2, GradientNone},
+		{"gradient", AspectLetterbox, 1, GradientRadial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			figure := tiledTestFigure()
+			figure.Camera.FOV = 0 // L1/L2 fixos, para o modo de faixas ter efeito
+			figure.Camera.Width, figure.Camera.Height = 4, 4
+
+			cfg := DefaultRenderConfig()
+			cfg.AspectMode = tt.mode
+			cfg.LineWidth = 2
+			cfg.Antialias = tt.antialias
+			cfg.Gradient = tt.gradient
+			cfg.GradientEnd = colorRGB{R: 0.2, G: 0.3, B: 0.6, A: 1}
+
+			direct := New(200, 150)
+			if err := direct.SetSupersampling(tt.antialias); err != nil {
+				t.Fatalf("SetSupersampling failed: %v", err)
+			}
+			direct.SetCamera(figure.Camera)
+			if err := direct.RenderFigureWithConfig(figure, cfg); err != nil {
+				t.Fatalf("RenderFigureWithConfig failed: %v", err)
+			}
+			want := direct.GetImage().(image.Image)
+
+			tiled, err := NewTiledImage(200, 150, 40, figure, cfg, nil)
+			if err != nil {
+				t.Fatalf("NewTiledImage failed: %v", err)
+			}
+
+			// Compara pixel a pixel, tolerando diferenças de suavização
+			differing := 0
+			for y := 0; y < 150; y++ {
+				for x := 0; x < 200; x++ {
+					r1, g1, b1, _ := want.At(x, y).RGBA()
+					r2, g2, b2, _ := tiled.At(x, y).RGBA()
+					if diff(r1, r2) > 0x1000 || diff(g1, g2) > 0x1000 || diff(b1, b2) > 0x1000 {
+						differing++
+					}
+				}
+			}
+			if err := tiled.Err(); err != nil {
+				t.Fatalf("Tile rendering failed: %v", err)
+			}
+			if differing > 20 {
+				t.Errorf("Expected tiled image to match direct render, %d pixels differ", differing)
+			}
+		})
+	}
+}
+
+func TestTiledImage_Save(t *testing.T) {
+	tiled, err := NewTiledImage(300, 1000, 64, tiledTestFigure(), DefaultRenderConfig(), nil)
+	if err != nil {
+		t.Fatalf("NewTiledImage failed: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "tiled.png")
+	if err := tiled.Save(filename, SaveOptions{}); err != nil {
+		t.Fatalf("SavePNG failed: %v", err)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open PNG: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 300, 1000) {
+		t.Errorf("Expected 300×1000 image, got %v", img.Bounds())
+	}
+	if !hasDarkPixel(img, img.Bounds()) {
+		t.Error("Expected figure to be drawn in tiled PNG")
+	}
+}
+
+func TestNewTiledImage_Invalid(t *testing.T) {
+	if _, err := NewTiledImage(0, 100, 0, tiledTestFigure(), DefaultRenderConfig(), nil); err == nil {
+		t.Error("Expected error for invalid dimensions, got nil")
+	}
+	if _, err := NewTiledImage(100, 100, 0, &types.Figure{}, DefaultRenderConfig(), nil); err == nil {
+		t.Error("Expected error for empty figure, got nil")
+	}
+	if _, err := NewTiledImage(100, 100, 0, tiledTestFigure(), DefaultRenderConfig(), &Region{XMin: 1, XMax: 0, YMax: 1}); err == nil {
+		t.Error("Expected error for invalid region, got nil")
+	}
+}
+
+func TestNewTiledImage_SupersampledTileHeight(t *testing.T) {
+	cfg := DefaultRenderConfig()
+	for factor, want := range map[int]int{1: 512, 2: 128, 3: 48, 4: 32, 8: 16} {
+		cfg.Antialias = factor
+		tiled, err := NewTiledImage(300, 1000, 0, tiledTestFigure(), cfg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tiled.tileHeight != want {
+			t.Errorf("Expected %d-row tiles at %dx, got %d", want, factor, tiled.tileHeight)
+		}
+	}
+
+	// Altura explícita é respeitada
+	tiled, err := NewTiledImage(300, 1000, 100, tiledTestFigure(), cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tiled.tileHeight != 100 {
+		t.Errorf("Expected explicit 100-row tiles, got %d", tiled.tileHeight)
+	}
+}
+
+func TestTiledImage_JPEGRendersEachTileOnce(t *testing.T) {
+	// Com 3x, a faixa padrão teria 512/9 = 56 linhas; os blocos de 16
+	// linhas do JPEG cruzariam as faixas e as renderizariam de novo
+	cfg := DefaultRenderConfig()
+	cfg.Antialias = 3
+	dir := t.TempDir()
+	for _, name := range []string{"poster.jpg", "poster.png"} {
+		tiled, err := NewTiledImage(1600, 600, 0, tiledTestFigure(), cfg, nil)
+		if err != nil {
+			t.Fatalf("NewTiledImage failed: %v", err)
+		}
+		if err := tiled.Save(filepath.Join(dir, name), SaveOptions{}); err != nil {
+			t.Fatalf("Save %s failed: %v", name, err)
+		}
+		tiles := (600 + tiled.tileHeight - 1) / tiled.tileHeight
+		if tiled.renders != tiles {
+			t.Errorf("%s: expected %d tile renders, got %d", name, tiles, tiled.renders)
+		}
+	}
+}
+
+// diff retorna a diferença absoluta entre dois componentes de cor
+func diff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Dimensões do carimbo, em pixels da imagem final.
+const (
+	titleBlockMargin   = 10  // Distância até as bordas da imagem
+	titleBlockRow      = 22  // Altura de cada linha do quadro
+	titleBlockPadding  = 8   // Espaço entre o texto e as bordas do quadro
+	titleBlockMinWidth = 180 // Largura mínima do quadro
+)
+
+// TitleBlock é o carimbo de uma prancha de desenho técnico: o quadro no
+// canto inferior direito que identifica o desenho.
+type TitleBlock struct {
+	Title  string // Título (vazio = nome da figura)
+	Author string // Autor (vazio = linha omitida)
+	Date   string // Data (vazio = linha omitida)
+}
+
+// rows retorna os textos das linhas do carimbo, de cima para baixo.
+func (b TitleBlock) rows(figure *types.Figure) []string {
+	title := b.Title
+	if title == "" {
+		title = figure.Nome
+	}
+	rows := []string{title}
+	if b.Author != "" {
+		rows = append(rows, "Autor: "+b.Author)
+	}
+	if b.Date != "" {
+		rows = append(rows, "Data: "+b.Date)
+	}
+	return rows
+}
+
+// drawTitleBlock desenha o carimbo no canto inferior direito da área do
+// fundo (ver backgroundArea).
+//
+// O quadro é preenchido com a cor de fundo, para que as linhas da figura
+// e da grade não atravessem o texto, e dividido em uma linha por
+// informação, com bordas e texto na cor das linhas.
+//
+// Parâmetros:
+//   figure: figura desenhada (o nome é o título padrão)
+//   cfg: configurações visuais (carimbo e cores)
+func (r *Renderer3D) drawTitleBlock(figure *types.Figure, cfg RenderConfig) {
+	rows := cfg.TitleBlock.rows(figure)
+
+	// Linhas mais altas para fontes maiores (ver RenderConfig.FontSize)
+	_, textHeight := r.measureText("Hg")
+	rowHeight := math.Max(titleBlockRow, 1.7*textHeight)
+
+	width := float64(titleBlockMinWidth)
+	for _, row := range rows {
+		w, _ := r.measureText(row)
+		width = math.Max(width, w+2*titleBlockPadding)
+	}
+	height := float64(len(rows)) * rowHeight
+
+	area := r.backgroundArea()
+	x := area.X + area.Width - titleBlockMargin - width
+	y := area.Y + area.Height - titleBlockMargin - height
+
+	r.context.DrawRectangle(x, y, width, height)
+	r.setColor(cfg.Background)
+	r.context.FillPreserve()
+	r.setColor(cfg.LineColor)
+	r.setLineWidth(1.5)
+	r.context.Stroke()
+
+	for i, row := range rows {
+		top := y + float64(i)*rowHeight
+		if i > 0 {
+			r.context.DrawLine(x, top, x+width, top)
+			r.context.Stroke()
+		}
+		// Linha de base um pouco abaixo do meio, centrando o texto
+		r.context.DrawString(row, x+titleBlockPadding, top+rowHeight/2+0.35*textHeight)
+	}
+	r.setLineWidth(cfg.LineWidth)
+}
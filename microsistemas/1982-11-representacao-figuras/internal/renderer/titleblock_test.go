@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestTitleBlock_Rows(t *testing.T) {
+	figure := &types.Figure{Nome: "cubo"}
+
+	tests := []struct {
+		block TitleBlock
+		want  []string
+	}{
+		{TitleBlock{}, []string{"cubo"}},
+		{TitleBlock{Title: "Peça 1", Date: "1982-11"}, []string{"Peça 1", "Data: 1982-11"}},
+		{TitleBlock{Author: "L. A. Pereira", Date: "1982"}, []string{"cubo", "Autor: L. A. Pereira", "Data: 1982"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.block.rows(figure); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v: expected rows %q, got %q", tt.block, tt.want, got)
+		}
+	}
+}
+
+func TestRenderFigureWithConfig_TitleBlock(t *testing.T) {
+	figure := &types.Figure{
+		Nome:   "teste",
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.TitleBlock = &TitleBlock{Author: "autor"}
+
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	// Quadro de duas linhas no canto inferior direito
+	bottom := 300 - titleBlockMargin
+	right := 400 - titleBlockMargin
+	if got := img.RGBAAt(right-50, bottom); got.R > 128 {
+		t.Errorf("Expected block bottom border, got %v", got)
+	}
+	if got := img.RGBAAt(right, bottom-30); got.R > 128 {
+		t.Errorf("Expected block right border, got %v", got)
+	}
+	if got := img.RGBAAt(right-100, bottom-titleBlockRow); got.R > 128 {
+		t.Errorf("Expected separator between rows, got %v", got)
+	}
+	if got := img.RGBAAt(right-100, bottom-2*titleBlockRow-6); got.R < 250 {
+		t.Errorf("Expected nothing above the block, got %v", got)
+	}
+}
@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// TurntableAxis é o eixo do mundo em torno do qual a câmera dá a volta
+// na animação de giro (ver TurntableStage).
+type TurntableAxis string
+
+const (
+	AxisX TurntableAxis = "x" // Eixo X, da esquerda para a direita
+	AxisY TurntableAxis = "y" // Eixo Y, da profundidade
+	AxisZ TurntableAxis = "z" // Eixo Z, vertical: o giro de um toca-discos
+)
+
+// Quadros e tempos da animação de giro.
+const (
+	DefaultTurntableFrames = 36  // Número padrão de quadros (10° cada)
+	MinTurntableFrames     = 2   // Menor número de quadros aceito
+	MaxTurntableFrames     = 360 // Maior número de quadros aceito
+	DefaultTurntableDelay  = 50  // Intervalo padrão entre quadros, em milissegundos
+)
+
+// ParseTurntableAxis converte o nome de um eixo do giro ("x", "y" ou
+// "z", sem distinção de maiúsculas).
+//
+// Parâmetros:
+//   value: nome do eixo
+//
+// Retorna:
+//   TurntableAxis: eixo correspondente
+//   error: erro se o nome for desconhecido
+func ParseTurntableAxis(value string) (TurntableAxis, error) {
+	switch a := TurntableAxis(strings.ToLower(strings.TrimSpace(value))); a {
+	case AxisX, AxisY, AxisZ:
+		return a, nil
+	}
+	return "", fmt.Errorf("eixo de giro desconhecido: %q (use %s, %s ou %s)", value, AxisX, AxisY, AxisZ)
+}
+
+// TurntableStage retorna a figura como a câmera a vê depois de girar
+// angle graus em torno de um eixo que passa pelo centro da figura
+// (o centro da caixa envolvente, o mesmo do enquadramento automático).
+//
+// Em vez de mover a câmera, a figura gira o mesmo ângulo no sentido
+// contrário: a imagem é a mesma, e a câmera, fixa, nunca passa sobre os
+// polos, onde o horizonte viraria de cabeça para baixo. Por isso a
+// câmera deve ser resolvida antes (ver EffectiveCamera), com a figura
+// original, para que o enquadramento não mude de um quadro para outro.
+//
+// Parâmetros:
+//   figure: figura original (não é alterada)
+//   axis: eixo do giro
+//   angle: ângulo da câmera em graus (regra da mão direita)
+//
+// Retorna:
+//   *types.Figure: cópia da figura com os pontos girados
+func TurntableStage(figure *types.Figure, axis TurntableAxis, angle float64) *types.Figure {
+	min, max := figure.Bounds()
+	cx, cy, cz := (min.X+max.X)/2, (min.Y+max.Y)/2, (min.Z+max.Z)/2
+
+	var rotation types.Mat4
+	switch axis {
+	case AxisX:
+		rotation = types.RotateX(-angle)
+	case AxisY:
+		rotation = types.RotateY(-angle)
+	default:
+		rotation = types.RotateZ(-angle)
+	}
+
+	// Cópia dos pontos e dos pontos de controle, que Apply altera no lugar
+	stage := *figure
+	stage.Pontos = append([]types.Point3D(nil), figure.Pontos...)
+	stage.Linhas = append([]types.Line(nil), figure.Linhas...)
+	for i, linha := range stage.Linhas {
+		stage.Linhas[i].Controle = append([]types.Point3D(nil), linha.Controle...)
+	}
+
+	stage.Apply(types.Translate(-cx, -cy, -cz).Then(rotation).Then(types.Translate(cx, cy, cz)))
+	return &stage
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestParseTurntableAxis(t *testing.T) {
+	cases := map[string]TurntableAxis{
+		"x":  AxisX,
+		"Y":  AxisY,
+		" z": AxisZ,
+	}
+	for name, want := range cases {
+		if got, err := ParseTurntableAxis(name); err != nil || got != want {
+			t.Errorf("ParseTurntableAxis(%q): expected %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := ParseTurntableAxis("w"); err == nil {
+		t.Error("Expected error for unknown axis")
+	}
+}
+
+func TestTurntableStage(t *testing.T) {
+	// Caixa de 2×2×2 centrada em (10, 0, 0), com uma curva
+	figure := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 9, Y: -1, Z: -1, Nome: "A"},
+			{X: 11, Y: 1, Z: 1, Nome: "B"},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1, Controle: []types.Point3D{{X: 11, Y: -1}}}},
+	}
+
+	near := func(a, b types.Point3D) bool {
+		return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9 && math.Abs(a.Z-b.Z) < 1e-9
+	}
+
+	// A câmera gira 90° em torno de Z: a figura gira -90° em volta do centro
+	stage := TurntableStage(figure, AxisZ, 90)
+	if want := (types.Point3D{X: 9, Y: 1, Z: -1, Nome: "A"}); !near(stage.Pontos[0], want) || stage.Pontos[0].Nome != "A" {
+		t.Errorf("Expected A at %v, got %v", want, stage.Pontos[0])
+	}
+	if want := (types.Point3D{X: 9, Y: -1}); !near(stage.Linhas[0].Controle[0], want) {
+		t.Errorf("Expected control point at %v, got %v", want, stage.Linhas[0].Controle[0])
+	}
+
+	// Em torno de X, Z passa para Y
+	stage = TurntableStage(figure, AxisX, 90)
+	if want := (types.Point3D{X: 9, Y: -1, Z: 1}); !near(stage.Pontos[0], want) {
+		t.Errorf("Expected A at %v, got %v", want, stage.Pontos[0])
+	}
+
+	// Uma volta completa traz a figura de volta
+	stage = TurntableStage(figure, AxisY, 360)
+	for i, p := range figure.Pontos {
+		if !near(stage.Pontos[i], p) {
+			t.Errorf("Point %d: expected %v after full turn, got %v", i, p, stage.Pontos[i])
+		}
+	}
+
+	// A figura original não é alterada
+	if figure.Pontos[0] != (types.Point3D{X: 9, Y: -1, Z: -1, Nome: "A"}) || figure.Linhas[0].Controle[0] != (types.Point3D{X: 11, Y: -1}) {
+		t.Errorf("Expected original figure untouched, got %+v", figure)
+	}
+}
@@ -0,0 +1,114 @@
+package renderer
+
+import (
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Ray representa uma semirreta no espaço, resultado da desprojeção de um
+// ponto da tela.
+//
+// Todos os pontos 3D que se projetam sobre um mesmo pixel estão sobre a
+// mesma reta: Origin + t·Direction, com t ≥ 0 à frente do observador.
+type Ray struct {
+	Origin    types.Point3D // Ponto de partida (no plano do observador)
+	Direction types.Point3D // Direção unitária, para longe do observador
+}
+
+// At retorna o ponto da semirreta a uma distância t da origem.
+func (ray Ray) At(t float64) types.Point3D {
+	return types.Point3D{
+		X: ray.Origin.X + t*ray.Direction.X,
+		Y: ray.Origin.Y + t*ray.Direction.Y,
+		Z: ray.Origin.Z + t*ray.Direction.Z,
+	}
+}
+
+// Unproject calcula a semirreta 3D que passa por um pixel da tela.
+//
+// É a operação inversa de ProjectToScreen, usada para mapear cliques do
+// mouse de volta para a cena (seleção de pontos e arestas). O pixel é
+// convertido para NDC e depois para o plano projetante:
+//   x' = NDCx · L1/2,  y' = NDCy · L2/2
+//
+// Na projeção cônica a semirreta parte do observador V e passa pelo
+// ponto (x', y') do plano projetante, à distância R. Nas projeções
+// paralelas todos os raios são paralelos: a semirreta parte do plano do
+// observador e segue a direção de visão (ortogonal) ou a direção
+// oblíqua que mantém a projeção constante.
+//
+// Parâmetros:
+//   screen: ponto em coordenadas de tela (pixels)
+//
+// Retorna:
+//   Ray: semirreta no espaço mundial, com direção unitária
+func (r *Renderer3D) Unproject(screen types.Point2D) Ray {
+	// Pixels → NDC (inverso de ViewportTransform)
+	ndcX := (screen.X - r.centerX) / (r.viewport.Width / 2)
+	ndcY := (r.centerY - screen.Y) / (r.viewport.Height / 2)
+
+	// NDC → plano projetante (inverso da normalização por L1/L2)
+	w := r.window()
+	projX := (ndcX*(w.XMax-w.XMin) + w.XMin + w.XMax) / 2
+	projY := (ndcY*(w.YMax-w.YMin) + w.YMin + w.YMax) / 2
+
+	// Origem e direção no sistema do observador (Z = profundidade)
+	var origin, dir vec3
+	switch r.camera.Projection {
+	case types.ProjectionOrthographic:
+		origin = vec3{X: projX, Y: projY}
+		dir = vec3{Z: 1}
+
+	case types.ProjectionOblique:
+		// Cada unidade de profundidade desloca a projeção em
+		// (f·cos α, f·sen α); a direção compensa esse deslocamento
+		angle := obliqueAngle(r.camera) * math.Pi / 180
+		factor := obliqueFactor(r.camera)
+		shiftX, shiftY := factor*math.Cos(angle), factor*math.Sin(angle)
+
+		// No plano do observador (profundidade 0) a distância ao plano
+		// do alvo entra no deslocamento (ver obliqueProject)
+		targetDepth := 0.0
+		if r.camera.Target != nil {
+			_, _, targetDepth = r.toCameraSpace(*r.camera.Target)
+		}
+		origin = vec3{X: projX + shiftX*targetDepth, Y: projY + shiftY*targetDepth}
+		dir = vec3{X: -shiftX, Y: -shiftY, Z: 1}
+
+	default:
+		// Projeção cônica: do observador através do plano projetante
+		dir = vec3{X: projX, Y: projY, Z: r.camera.Distance}
+	}
+
+	return Ray{
+		Origin:    r.fromCameraSpace(origin, true),
+		Direction: r.fromCameraSpace(dir.normalize(), false),
+	}
+}
+
+// fromCameraSpace converte um vetor do sistema do observador para o mundo.
+//
+// Parâmetros:
+//   c: vetor no sistema do observador (X direita, Y cima, Z frente)
+//   point: se true, c é um ponto (somado à posição do observador);
+//          se false, uma direção
+//
+// Retorna:
+//   types.Point3D: vetor no sistema do mundo
+func (r *Renderer3D) fromCameraSpace(c vec3, point bool) types.Point3D {
+	b := r.basis
+	w := vec3{
+		X: c.X*b.right.X + c.Y*b.up.X + c.Z*b.forward.X,
+		Y: c.X*b.right.Y + c.Y*b.up.Y + c.Z*b.forward.Y,
+		Z: c.X*b.right.Z + c.Y*b.up.Z + c.Z*b.forward.Z,
+	}
+	if point {
+		w = vec3{
+			X: w.X + r.camera.Observer.X,
+			Y: w.Y + r.camera.Observer.Y,
+			Z: w.Z + r.camera.Observer.Z,
+		}
+	}
+	return types.Point3D{X: w.X, Y: w.Y, Z: w.Z}
+}
@@ -0,0 +1,72 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestUnproject_RoundTrip(t *testing.T) {
+	target := types.Point3D{X: 1, Y: 2, Z: 0.5}
+
+	cameras := map[string]types.Camera{
+		"article": types.DefaultCamera(),
+		"orbit": {
+			Target:   &target,
+			Orbit:    &types.Orbit{Azimuth: 30, Elevation: 25, Radius: 12},
+			Distance: 10, Width: 12.8, Height: 9.6,
+		},
+		"orthographic": {
+			Target:   &target,
+			Orbit:    &types.Orbit{Azimuth: 45, Elevation: 35, Radius: 12},
+			Distance: 10, Width: 8, Height: 6,
+			Projection: types.ProjectionOrthographic,
+		},
+		"oblique": {
+			Observer: types.Point3D{Y: -10},
+			Target:   &types.Point3D{},
+			Distance: 10, Width: 8, Height: 6,
+			Projection:    types.ProjectionOblique,
+			ObliqueFactor: 0.5,
+		},
+	}
+
+	for name, camera := range cameras {
+		t.Run(name, func(t *testing.T) {
+			renderer := New(800, 600)
+			renderer.SetCamera(camera)
+
+			for _, screen := range []types.Point2D{{X: 400, Y: 300}, {X: 123, Y: 456}, {X: 790, Y: 10}} {
+				ray := renderer.Unproject(screen)
+
+				if l := math.Sqrt(ray.Direction.X*ray.Direction.X +
+					ray.Direction.Y*ray.Direction.Y + ray.Direction.Z*ray.Direction.Z); math.Abs(l-1) > 1e-9 {
+					t.Errorf("Expected unit direction, got length %f", l)
+				}
+
+				// Qualquer ponto da semirreta deve voltar ao mesmo pixel
+				for _, dist := range []float64{1, 7.5, 30} {
+					got := renderer.ProjectToScreen(ray.At(dist))
+					if math.Abs(got.X-screen.X) > 1e-6 || math.Abs(got.Y-screen.Y) > 1e-6 {
+						t.Errorf("Point at %g along ray of %v projects to %v", dist, screen, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUnproject_CenterLooksForward(t *testing.T) {
+	renderer := New(800, 600)
+	renderer.SetCamera(types.DefaultCamera())
+
+	// O centro da tela corresponde à direção de visão do artigo (+Y)
+	ray := renderer.Unproject(types.Point2D{X: 400, Y: 300})
+	if ray.Origin != (types.Point3D{}) {
+		t.Errorf("Expected ray from observer at origin, got %v", ray.Origin)
+	}
+	if math.Abs(ray.Direction.Y-1) > 1e-9 {
+		t.Errorf("Expected direction +Y, got %v", ray.Direction)
+	}
+}
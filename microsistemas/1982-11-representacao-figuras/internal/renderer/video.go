@@ -0,0 +1,202 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// VideoCodec é o codificador de vídeo usado pelo ffmpeg (ver VideoWriter).
+type VideoCodec string
+
+const (
+	CodecH264 VideoCodec = "h264" // H.264 (libx264): toca em qualquer lugar, só em .mp4
+	CodecH265 VideoCodec = "h265" // H.265 (libx265): arquivos menores, só em .mp4
+	CodecVP9  VideoCodec = "vp9"  // VP9 (libvpx-vp9): o dos navegadores, em .mp4 ou .webm
+)
+
+// ffmpegEncoders mapeia os codificadores aos nomes das bibliotecas do
+// ffmpeg e aos parâmetros de qualidade de cada uma.
+var ffmpegEncoders = map[VideoCodec][]string{
+	CodecH264: {"-c:v", "libx264", "-crf", "18"},
+	CodecH265: {"-c:v", "libx265", "-crf", "22", "-tag:v", "hvc1"},
+	CodecVP9:  {"-c:v", "libvpx-vp9", "-crf", "31", "-b:v", "0"},
+}
+
+// videoContainers mapeia as extensões de vídeo aceitas ao codificador
+// padrão de cada uma.
+var videoContainers = map[string]VideoCodec{
+	".mp4":  CodecH264,
+	".webm": CodecVP9,
+}
+
+// ParseVideoCodec converte o nome de um codificador ("h264", "h265" ou
+// "vp9", sem distinção de maiúsculas; "x264", "hevc" e "x265" também
+// valem).
+//
+// Parâmetros:
+//   value: nome do codificador
+//
+// Retorna:
+//   VideoCodec: codificador correspondente
+//   error: erro se o nome for desconhecido
+func ParseVideoCodec(value string) (VideoCodec, error) {
+	switch c := strings.ToLower(strings.TrimSpace(value)); c {
+	case "h264", "x264":
+		return CodecH264, nil
+	case "h265", "x265", "hevc":
+		return CodecH265, nil
+	case "vp9":
+		return CodecVP9, nil
+	}
+	return "", fmt.Errorf("codificador de vídeo desconhecido: %q (use %s, %s ou %s)", value, CodecH264, CodecH265, CodecVP9)
+}
+
+// VideoCodecFor escolhe o codificador de um arquivo de vídeo pela
+// extensão: o informado, se o contêiner o aceitar, ou o padrão do
+// contêiner (H.264 em .mp4, VP9 em .webm).
+//
+// Parâmetros:
+//   filename: caminho do arquivo .mp4 ou .webm
+//   codec: codificador pedido ("" = padrão do contêiner)
+//
+// Retorna:
+//   VideoCodec: codificador a usar
+//   error: erro se a extensão for desconhecida ou não aceitar o codificador
+func VideoCodecFor(filename string, codec VideoCodec) (VideoCodec, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	standard, ok := videoContainers[ext]
+	if !ok {
+		return "", fmt.Errorf("formato de vídeo desconhecido: %q (use .mp4 ou .webm)", filename)
+	}
+	if codec == "" {
+		return standard, nil
+	}
+	if ext == ".webm" && codec != CodecVP9 {
+		return "", fmt.Errorf("o formato .webm só aceita o codificador %s, não %s", CodecVP9, codec)
+	}
+	return codec, nil
+}
+
+// VideoWriter grava um vídeo passando os quadros, um a um, para um
+// processo do ffmpeg, sem imagens intermediárias em disco.
+type VideoWriter struct {
+	cmd           *exec.Cmd
+	stdin         io.WriteCloser
+	stderr        bytes.Buffer
+	width, height int
+	frame         *image.RGBA
+}
+
+// NewVideoWriter inicia o ffmpeg para gravar um vídeo de quadros do
+// tamanho dado. Os quadros chegam sem compressão (RGBA) pela entrada
+// padrão; o vídeo sai em YUV 4:2:0, o formato que os reprodutores
+// aceitam, e por isso largura e altura ímpares ganham uma linha ou
+// coluna de borda.
+//
+// Parâmetros:
+//   filename: caminho do arquivo .mp4 ou .webm (sobrescrito se existir)
+//   codec: codificador (ver VideoCodecFor)
+//   fps: quadros por segundo
+//   width: largura dos quadros em pixels
+//   height: altura dos quadros em pixels
+//
+// Retorna:
+//   *VideoWriter: gravador pronto para WriteFrame
+//   error: erro se o ffmpeg não estiver instalado ou não iniciar
+func NewVideoWriter(filename string, codec VideoCodec, fps float64, width, height int) (*VideoWriter, error) {
+	if fps <= 0 {
+		return nil, fmt.Errorf("taxa de quadros inválida: %g (deve ser positiva)", fps)
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("tamanho de quadro inválido: %dx%d", width, height)
+	}
+	path, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg não encontrado; instale-o ou use --out para gravar os quadros")
+	}
+
+	w := &VideoWriter{
+		cmd:    exec.Command(path, ffmpegArgs(filename, codec, fps, width, height)...),
+		width:  width,
+		height: height,
+		frame:  image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+	w.cmd.Stderr = &w.stderr
+	if w.stdin, err = w.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+	if err := w.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("erro ao iniciar o ffmpeg: %w", err)
+	}
+	return w, nil
+}
+
+// ffmpegArgs monta a linha de comando do ffmpeg para NewVideoWriter.
+func ffmpegArgs(filename string, codec VideoCodec, fps float64, width, height int) []string {
+	args := []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-f", "rawvideo", "-pix_fmt", "rgba",
+		"-s", fmt.Sprintf("%dx%d", width, height),
+		"-framerate", strconv.FormatFloat(fps, 'f', -1, 64),
+		"-i", "-",
+		"-an", "-vf", "pad=ceil(iw/2)*2:ceil(ih/2)*2",
+	}
+	args = append(args, ffmpegEncoders[codec]...)
+	args = append(args, "-pix_fmt", "yuv420p")
+	if strings.EqualFold(filepath.Ext(filename), ".mp4") {
+		args = append(args, "-movflags", "+faststart") // Toca antes de baixar inteiro
+	}
+	return append(args, filename)
+}
+
+// WriteFrame envia um quadro ao ffmpeg.
+//
+// Parâmetros:
+//   img: quadro, do tamanho informado em NewVideoWriter
+//
+// Retorna:
+//   error: erro se o tamanho for outro ou o ffmpeg tiver parado
+func (w *VideoWriter) WriteFrame(img image.Image) error {
+	b := img.Bounds()
+	if b.Dx() != w.width || b.Dy() != w.height {
+		return fmt.Errorf("quadro de %dx%d num vídeo de %dx%d", b.Dx(), b.Dy(), w.width, w.height)
+	}
+	draw.Draw(w.frame, w.frame.Rect, img, b.Min, draw.Src)
+	if _, err := w.stdin.Write(w.frame.Pix); err != nil {
+		// O ffmpeg parou: espera a mensagem dele (o gravador não pode
+		// mais ser usado)
+		w.stdin.Close()
+		w.cmd.Wait()
+		return w.failure(err)
+	}
+	return nil
+}
+
+// Close termina a entrada do ffmpeg e espera o vídeo ser gravado.
+//
+// Retorna:
+//   error: erro se o ffmpeg falhar, com a última mensagem dele
+func (w *VideoWriter) Close() error {
+	w.stdin.Close()
+	if err := w.cmd.Wait(); err != nil {
+		return w.failure(err)
+	}
+	return nil
+}
+
+// failure junta a um erro a última linha que o ffmpeg escreveu, que
+// costuma explicar o problema (como um codificador não compilado).
+func (w *VideoWriter) failure(err error) error {
+	lines := strings.Split(strings.TrimSpace(w.stderr.String()), "\n")
+	if last := lines[len(lines)-1]; last != "" {
+		return fmt.Errorf("ffmpeg: %v (%s)", err, last)
+	}
+	return fmt.Errorf("ffmpeg: %v", err)
+}
@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"image"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseVideoCodec(t *testing.T) {
+	cases := map[string]VideoCodec{
+		"h264":  CodecH264,
+		"X264":  CodecH264,
+		"hevc":  CodecH265,
+		" vp9 ": CodecVP9,
+	}
+	for name, want := range cases {
+		if got, err := ParseVideoCodec(name); err != nil || got != want {
+			t.Errorf("ParseVideoCodec(%q): expected %q, got %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := ParseVideoCodec("mpeg2"); err == nil {
+		t.Error("Expected error for unknown codec")
+	}
+}
+
+func TestVideoCodecFor(t *testing.T) {
+	cases := []struct {
+		filename string
+		codec    VideoCodec
+		want     VideoCodec
+	}{
+		{"voo.mp4", "", CodecH264},
+		{"VOO.MP4", CodecH265, CodecH265},
+		{"voo.mp4", CodecVP9, CodecVP9},
+		{"voo.webm", "", CodecVP9},
+		{"voo.webm", CodecVP9, CodecVP9},
+	}
+	for _, c := range cases {
+		if got, err := VideoCodecFor(c.filename, c.codec); err != nil || got != c.want {
+			t.Errorf("VideoCodecFor(%q, %q): expected %q, got %q (%v)", c.filename, c.codec, c.want, got, err)
+		}
+	}
+
+	// Extensão desconhecida e codificador que o contêiner não aceita
+	if _, err := VideoCodecFor("voo.avi", ""); err == nil {
+		t.Error("Expected error for unknown container")
+	}
+	if _, err := VideoCodecFor("voo.webm", CodecH264); err == nil {
+		t.Error("Expected error for h264 in webm")
+	}
+}
+
+func TestFFmpegArgs(t *testing.T) {
+	args := ffmpegArgs("saida/voo.mp4", CodecH264, 12.5, 641, 480)
+
+	for _, want := range [][]string{
+		{"-f", "rawvideo"},
+		{"-pix_fmt", "rgba"},
+		{"-s", "641x480"},
+		{"-framerate", "12.5"},
+		{"-i", "-"},
+		{"-c:v", "libx264"},
+		{"-pix_fmt", "yuv420p"},
+		{"-movflags", "+faststart"},
+	} {
+		// Opção seguida do valor, em qualquer posição
+		found := false
+		for i := 0; i+1 < len(args); i++ {
+			found = found || (args[i] == want[0] && args[i+1] == want[1])
+		}
+		if !found {
+			t.Errorf("Expected %v in %v", want, args)
+		}
+	}
+	if last := args[len(args)-1]; last != "saida/voo.mp4" {
+		t.Errorf("Expected output file last, got %q", last)
+	}
+
+	// WebM não leva o faststart do MP4
+	if args := ffmpegArgs("voo.webm", CodecVP9, 25, 10, 10); slices.Contains(args, "-movflags") || !slices.Contains(args, "libvpx-vp9") {
+		t.Errorf("Unexpected webm args: %v", args)
+	}
+}
+
+func TestNewVideoWriter(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewVideoWriter(filepath.Join(dir, "voo.mp4"), CodecH264, 0, 10, 10); err == nil {
+		t.Error("Expected error for zero fps")
+	}
+	if _, err := NewVideoWriter(filepath.Join(dir, "voo.mp4"), CodecH264, 25, 0, 10); err == nil {
+		t.Error("Expected error for empty frame")
+	}
+
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg não instalado")
+	}
+
+	filename := filepath.Join(dir, "voo.mp4")
+	w, err := NewVideoWriter(filename, CodecH264, 25, 15, 9)
+	if err != nil {
+		t.Fatalf("NewVideoWriter failed: %v", err)
+	}
+	if err := w.WriteFrame(image.NewRGBA(image.Rect(0, 0, 8, 8))); err == nil {
+		t.Error("Expected error for frame of another size")
+	}
+	for range 5 {
+		if err := w.WriteFrame(image.NewRGBA(image.Rect(0, 0, 15, 9))); err != nil {
+			t.Fatalf("WriteFrame failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Errorf("Expected video file, got %v (%v)", info, err)
+	}
+}
@@ -0,0 +1,174 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Aparência padrão da marca d'água.
+const (
+	DefaultWatermarkOpacity = 0.3 // Opacidade
+	DefaultWatermarkSize    = 16  // Altura da fonte do texto em pixels
+)
+
+// Watermark é um logotipo ou texto aplicado, semitransparente, sobre a
+// imagem pronta.
+type Watermark struct {
+	Image    image.Image        // Logotipo (nil = texto)
+	Text     string             // Texto, quando não há logotipo
+	Position AnnotationPosition // Onde a marca é aplicada
+	Opacity  float64            // De 0 (invisível) a 1 (opaca)
+	Width    float64            // Largura do logotipo em pixels (0 = original)
+	Size     float64            // Altura da fonte do texto em pixels
+	Color    colorRGB           // Cor do texto
+}
+
+// parseWatermark converte a descrição YAML da marca d'água, carregando
+// o logotipo e preenchendo o que não foi informado com os padrões.
+//
+// Parâmetros:
+//   w: descrição do YAML
+//   textColor: cor padrão do texto
+//
+// Retorna:
+//   *Watermark: marca pronta para ser aplicada
+//   error: erro se faltar a imagem e o texto (ou se houver os dois), se a
+//          imagem não puder ser carregada ou se algum valor for inválido
+func parseWatermark(w types.Watermark, textColor colorRGB) (*Watermark, error) {
+	if (w.Image == "") == (w.Text == "") {
+		return nil, fmt.Errorf("informe a imagem ou o texto")
+	}
+	out := &Watermark{
+		Text:     w.Text,
+		Position: PositionBottomRight,
+		Opacity:  DefaultWatermarkOpacity,
+		Size:     DefaultWatermarkSize,
+		Color:    textColor,
+	}
+
+	if w.Image != "" {
+		img, err := gg.LoadImage(w.Image)
+		if err != nil {
+			return nil, fmt.Errorf("imagem %q: %w", w.Image, err)
+		}
+		out.Image = img
+	}
+	if w.Position != "" {
+		p, err := parseAnnotationPosition(w.Position)
+		if err != nil {
+			return nil, err
+		}
+		out.Position = p
+	}
+	if w.Opacity != nil {
+		if *w.Opacity < 0 || *w.Opacity > 1 {
+			return nil, fmt.Errorf("opacidade inválida: %g (deve estar entre 0 e 1)", *w.Opacity)
+		}
+		out.Opacity = *w.Opacity
+	}
+	if w.Width < 0 {
+		return nil, fmt.Errorf("largura inválida: %g (deve ser positiva)", w.Width)
+	}
+	out.Width = w.Width
+	if w.Size < 0 {
+		return nil, fmt.Errorf("tamanho inválido: %g (deve ser positivo)", w.Size)
+	}
+	if w.Size > 0 {
+		out.Size = w.Size
+	}
+	if w.Color != "" {
+		col, err := parseColor(w.Color)
+		if err != nil {
+			return nil, fmt.Errorf("cor inválida: %w", err)
+		}
+		out.Color = col
+	}
+	return out, nil
+}
+
+// watermarkOrigin calcula onde fica o canto superior esquerdo de uma
+// marca d'água, dentro da área do fundo (ver backgroundArea), conforme a
+// posição: centrada no topo ou na base, ou junto a um dos cantos.
+//
+// Parâmetros:
+//   area: área da imagem que recebe a marca
+//   position: posição da marca
+//   width, height: dimensões da marca em pixels
+//
+// Retorna:
+//   float64, float64: canto superior esquerdo da marca em pixels
+func watermarkOrigin(area Viewport, position AnnotationPosition, width, height float64) (float64, float64) {
+	x := area.X + (area.Width-width)/2
+	switch position {
+	case PositionTopLeft, PositionBottomLeft:
+		x = area.X + annotationMargin
+	case PositionTopRight, PositionBottomRight:
+		x = area.X + area.Width - annotationMargin - width
+	}
+	y := area.Y + annotationMargin
+	switch position {
+	case PositionBottom, PositionBottomLeft, PositionBottomRight:
+		y = area.Y + area.Height - annotationMargin - height
+	}
+	return x, y
+}
+
+// drawWatermark aplica a marca d'água sobre a imagem pronta.
+//
+// O logotipo é redimensionado para a largura configurada (mantendo a
+// proporção) e composto diretamente nos pixels da tela interna, com a
+// opacidade como máscara; o texto é escrito como os títulos (ver
+// drawAnnotation), com a opacidade aplicada à sua cor.
+//
+// Parâmetros:
+//   cfg: configurações visuais (marca d'água e arquivo de fonte)
+func (r *Renderer3D) drawWatermark(cfg RenderConfig) {
+	w := cfg.Watermark
+	scale := float64(r.scale)
+	area := r.backgroundArea()
+
+	if w.Image == nil {
+		r.context.Push()
+		defer r.context.Pop()
+		r.context.Identity()
+		r.context.SetFontFace(r.fontFace(cfg, w.Size))
+
+		width, height := r.measureText(w.Text)
+		x, y := watermarkOrigin(area, w.Position, width, height)
+		col := w.Color
+		col.A *= w.Opacity
+		r.setColor(col)
+		r.context.DrawStringAnchored(w.Text, x*scale, y*scale, 0, 1)
+		return
+	}
+
+	dst, ok := r.context.Image().(*image.RGBA)
+	if !ok {
+		return
+	}
+	src := w.Image.Bounds()
+	width := float64(src.Dx())
+	if w.Width > 0 {
+		width = w.Width
+	}
+	height := width * float64(src.Dy()) / float64(src.Dx())
+	x, y := watermarkOrigin(area, w.Position, width, height)
+
+	// Logotipo na resolução da tela interna
+	target := image.Rect(
+		int(math.Round(x*scale)), int(math.Round(y*scale)),
+		int(math.Round((x+width)*scale)), int(math.Round((y+height)*scale)),
+	)
+	logo := image.NewRGBA(image.Rect(0, 0, target.Dx(), target.Dy()))
+	draw.CatmullRom.Scale(logo, logo.Bounds(), w.Image, src, draw.Src, nil)
+
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(w.Opacity * 255))})
+	draw.DrawMask(dst, target, logo, image.Point{}, mask, image.Point{}, draw.Over)
+}
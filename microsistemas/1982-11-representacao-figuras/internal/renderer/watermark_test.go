@@ -0,0 +1,122 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// writeTestLogo grava um logotipo PNG vermelho de 20×10 pixels num
+// diretório temporário.
+func writeTestLogo(t *testing.T) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	filename := filepath.Join(t.TempDir(), "logo.png")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestParseWatermark(t *testing.T) {
+	black := colorRGB{A: 1}
+
+	w, err := parseWatermark(types.Watermark{Text: "Revista"}, black)
+	if err != nil {
+		t.Fatalf("parseWatermark failed: %v", err)
+	}
+	want := Watermark{Text: "Revista", Position: PositionBottomRight, Opacity: DefaultWatermarkOpacity,
+		Size: DefaultWatermarkSize, Color: black}
+	if *w != want {
+		t.Errorf("Expected defaults %+v, got %+v", want, *w)
+	}
+
+	w, err = parseWatermark(types.Watermark{Image: writeTestLogo(t), Position: "top-left"}, black)
+	if err != nil {
+		t.Fatalf("parseWatermark failed: %v", err)
+	}
+	if w.Image == nil || w.Image.Bounds().Dx() != 20 || w.Position != PositionTopLeft {
+		t.Errorf("Expected 20 pixel wide logo at the top left, got %+v", w)
+	}
+
+	opacity := 1.5
+	for _, invalid := range []types.Watermark{
+		{},
+		{Text: "Revista", Image: "logo.png"},
+		{Image: filepath.Join(t.TempDir(), "inexistente.png")},
+		{Text: "Revista", Opacity: &opacity},
+		{Text: "Revista", Position: "meio"},
+		{Text: "Revista", Size: -1},
+		{Text: "Revista", Color: "cor-que-nao-existe"},
+	} {
+		if _, err := parseWatermark(invalid, black); err == nil {
+			t.Errorf("Expected error for %+v", invalid)
+		}
+	}
+}
+
+func TestWatermarkOrigin(t *testing.T) {
+	area := Viewport{Width: 200, Height: 100}
+	tests := []struct {
+		position AnnotationPosition
+		x, y     float64
+	}{
+		{PositionTopLeft, annotationMargin, annotationMargin},
+		{PositionTop, 90, annotationMargin},
+		{PositionBottomRight, 200 - annotationMargin - 20, 100 - annotationMargin - 10},
+		{PositionBottomLeft, annotationMargin, 100 - annotationMargin - 10},
+	}
+
+	for _, tt := range tests {
+		if x, y := watermarkOrigin(area, tt.position, 20, 10); x != tt.x || y != tt.y {
+			t.Errorf("%s: expected (%g, %g), got (%g, %g)", tt.position, tt.x, tt.y, x, y)
+		}
+	}
+}
+
+func TestRenderFigureWithConfig_Watermark(t *testing.T) {
+	figure := &types.Figure{
+		Pontos: []types.Point3D{{X: -1, Y: 5, Z: 0}, {X: 1, Y: 5, Z: 0}},
+		Linhas: []types.Line{{P1: 0, P2: 1}},
+	}
+	opacity := 0.5
+	watermark, err := parseWatermark(types.Watermark{Image: writeTestLogo(t), Width: 40, Opacity: &opacity}, colorRGB{A: 1})
+	if err != nil {
+		t.Fatalf("parseWatermark failed: %v", err)
+	}
+
+	cfg := DefaultRenderConfig()
+	cfg.Watermark = watermark
+
+	renderer := New(400, 300)
+	renderer.SetCamera(types.DefaultCamera())
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+	img := renderer.GetImage().(*image.RGBA)
+
+	// Logotipo de 40×20 no canto inferior direito, meio transparente
+	// sobre o fundo branco
+	inside := img.RGBAAt(400-annotationMargin-20, 300-annotationMargin-10)
+	if inside.R != 255 || inside.G < 120 || inside.G > 135 {
+		t.Errorf("Expected half transparent red logo, got %+v", inside)
+	}
+	if outside := img.RGBAAt(400-annotationMargin-50, 300-annotationMargin-10); outside.G != 255 {
+		t.Errorf("Expected white background left of the logo, got %+v", outside)
+	}
+}
@@ -0,0 +1,448 @@
+package renderer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"sort"
+)
+
+// Limites do formato WebP sem perdas (VP8L).
+const (
+	webpMaxSize      = 16384   // Maior largura ou altura
+	webpMaxMatch     = 4096    // Maior cópia de pixels anteriores
+	webpMinMatch     = 3       // Menor cópia que compensa sobre literais
+	webpMaxDistance  = 1 << 20 // Maior código de distância (menos 120)
+	webpDistanceBias = 120     // Códigos 1..120 são deslocamentos 2D curtos
+	webpHashBits     = 16      // Tamanho da tabela de sequências já vistas
+)
+
+// Alfabetos dos cinco códigos de prefixo de um VP8L sem cache de cores:
+// verde (mais os 24 prefixos de comprimento), vermelho, azul, alfa e
+// distância.
+var webpAlphabets = [5]int{256 + 24, 256, 256, 256, 40}
+
+// Ordem em que os comprimentos do código dos comprimentos são gravados.
+var webpCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// encodeWebP codifica a imagem em WebP sem perdas (VP8L).
+//
+// A biblioteca padrão e a golang.org/x/image só leem WebP; este é um
+// codificador enxuto, suficiente para figuras: sem as transformações do
+// formato (predição, cores subtraídas, paleta), apenas cópias de
+// trechos repetidos (LZ77, à esquerda e na linha de cima) e códigos de
+// Huffman. Figuras de traço, com grandes áreas de uma só cor, ficam bem
+// menores que em PNG.
+//
+// Parâmetros:
+//   w: destino do arquivo WebP
+//   img: imagem a codificar
+//
+// Retorna:
+//   error: erro se a imagem passar do tamanho máximo do formato, ou de E/S
+func encodeWebP(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > webpMaxSize || height > webpMaxSize {
+		return fmt.Errorf("imagem %d×%d fora dos limites do WebP (1 a %d pixels)", width, height, webpMaxSize)
+	}
+
+	// Pixels em ARGB, sem pré-multiplicação
+	pixels := make([]uint32, 0, width*height)
+	alpha := false
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			alpha = alpha || c.A != 255
+			pixels = append(pixels, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+
+	tokens := webpTokens(pixels, width)
+
+	// Frequências e códigos dos cinco alfabetos
+	var freqs [5][]int
+	for i, n := range webpAlphabets {
+		freqs[i] = make([]int, n)
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			freqs[0][t.pixel>>8&0xff]++
+			freqs[1][t.pixel>>16&0xff]++
+			freqs[2][t.pixel&0xff]++
+			freqs[3][t.pixel>>24]++
+			continue
+		}
+		lengthPrefix, _, _ := webpPrefix(t.length)
+		distPrefix, _, _ := webpPrefix(t.distance)
+		freqs[0][256+lengthPrefix]++
+		freqs[4][distPrefix]++
+	}
+	var codes [5]webpCode
+	for i := range codes {
+		codes[i] = newWebPCode(freqs[i], 15)
+	}
+
+	// Cabeçalho VP8L: assinatura, dimensões, uso de alfa e versão
+	bw := &webpBitWriter{}
+	bw.writeBits(0x2f, 8)
+	bw.writeBits(uint32(width-1), 14)
+	bw.writeBits(uint32(height-1), 14)
+	if alpha {
+		bw.writeBits(1, 1)
+	} else {
+		bw.writeBits(0, 1)
+	}
+	bw.writeBits(0, 3)
+
+	bw.writeBits(0, 1) // Sem transformações
+	bw.writeBits(0, 1) // Sem cache de cores
+	bw.writeBits(0, 1) // Um único grupo de códigos para a imagem toda
+	for i := range codes {
+		codes[i].writeHeader(bw)
+	}
+
+	// Pixels literais e cópias
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].write(bw, int(t.pixel>>8&0xff))
+			codes[1].write(bw, int(t.pixel>>16&0xff))
+			codes[2].write(bw, int(t.pixel&0xff))
+			codes[3].write(bw, int(t.pixel>>24))
+			continue
+		}
+		prefix, extraBits, extra := webpPrefix(t.length)
+		codes[0].write(bw, 256+prefix)
+		bw.writeBits(extra, extraBits)
+		prefix, extraBits, extra = webpPrefix(t.distance)
+		codes[4].write(bw, prefix)
+		bw.writeBits(extra, extraBits)
+	}
+	data := bw.flush()
+
+	// Contêiner RIFF com um único bloco VP8L (de tamanho par)
+	size := len(data) + len(data)%2
+	header := make([]byte, 0, 20)
+	header = append(header, "RIFF"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(4+8+size))
+	header = append(header, "WEBPVP8L"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if len(data)%2 == 1 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// webpToken é um pixel literal ou uma cópia de pixels anteriores.
+type webpToken struct {
+	pixel    uint32 // Pixel literal (ARGB), se length == 0
+	length   int    // Quantidade de pixels copiados (0 = literal)
+	distance int    // Código de distância da cópia (ver webpTokens)
+}
+
+// webpTokens divide a imagem em literais e cópias (LZ77).
+//
+// Em cada posição são tentadas três origens: o pixel à esquerda (faixas
+// de uma só cor), o pixel de cima (linhas repetidas) e a última posição
+// em que os mesmos dois pixels apareceram. Fica a cópia mais longa, se
+// tiver ao menos webpMinMatch pixels. As distâncias à esquerda e acima
+// usam os códigos curtos 2 e 1 do formato; as demais, distância + 120.
+//
+// Parâmetros:
+//   pixels: pixels ARGB, linha a linha
+//   width: largura da imagem
+//
+// Retorna:
+//   []webpToken: sequência de literais e cópias
+func webpTokens(pixels []uint32, width int) []webpToken {
+	var tokens []webpToken
+	head := make([]int32, 1<<webpHashBits)
+	for i := range head {
+		head[i] = -1
+	}
+	hash := func(i int) int {
+		return int((pixels[i]*0x9e3779b1 ^ pixels[i+1]*0x85ebca6b) >> (32 - webpHashBits))
+	}
+
+	n := len(pixels)
+	for i := 0; i < n; {
+		bestLength, bestDistance := 0, 0
+		candidates := [3]int{1, width, 0}
+		if i+1 < n {
+			if j := head[hash(i)]; j >= 0 {
+				candidates[2] = i - int(j)
+			}
+		}
+		for _, d := range candidates {
+			if d < 1 || d > i || d+webpDistanceBias >= webpMaxDistance {
+				continue
+			}
+			length := 0
+			for length < webpMaxMatch && i+length < n && pixels[i+length] == pixels[i+length-d] {
+				length++
+			}
+			if length > bestLength {
+				bestLength, bestDistance = length, d
+			}
+		}
+
+		step := 1
+		if bestLength >= webpMinMatch {
+			code := bestDistance + webpDistanceBias
+			switch bestDistance {
+			case width:
+				code = 1
+			case 1:
+				code = 2
+			}
+			tokens = append(tokens, webpToken{length: bestLength, distance: code})
+			step = bestLength
+		} else {
+			tokens = append(tokens, webpToken{pixel: pixels[i]})
+		}
+
+		for end := i + step; i < end; i++ {
+			if i+1 < n {
+				head[hash(i)] = int32(i)
+			}
+		}
+	}
+	return tokens
+}
+
+// webpPrefix separa um comprimento ou código de distância (a partir de 1)
+// em código de prefixo e bits extras, como o formato exige.
+//
+// Retorna:
+//   int: código de prefixo
+//   uint: quantidade de bits extras
+//   uint32: valor dos bits extras
+func webpPrefix(value int) (int, uint, uint32) {
+	v := uint32(value - 1)
+	if v < 4 {
+		return int(v), 0, 0
+	}
+	high := uint(31)
+	for v>>high == 0 {
+		high--
+	}
+	second := v >> (high - 1) & 1
+	extraBits := high - 1
+	return int(2*high) + int(second), extraBits, v & (1<<extraBits - 1)
+}
+
+// webpCode é um código de Huffman canônico de um alfabeto.
+type webpCode struct {
+	lengths []uint8  // Comprimento do código de cada símbolo (0 = não usado)
+	codes   []uint32 // Códigos já invertidos (o fluxo de bits começa pelo mais significativo)
+	single  bool     // Só um símbolo usado: gravado com zero bits
+}
+
+// newWebPCode monta o código de Huffman das frequências, com códigos de
+// no máximo limit bits. Se os comprimentos passarem do limite, as
+// frequências são reduzidas à metade e o código é refeito.
+func newWebPCode(freq []int, limit int) webpCode {
+	c := webpCode{lengths: make([]uint8, len(freq)), codes: make([]uint32, len(freq))}
+	type node struct {
+		weight  int
+		symbols []int
+	}
+
+	used := 0
+	for _, f := range freq {
+		if f > 0 {
+			used++
+		}
+	}
+	if used <= 1 {
+		for s, f := range freq {
+			if f > 0 {
+				c.lengths[s] = 1 // Zero bits para o decodificador
+				c.single = true
+			}
+		}
+		return c
+	}
+
+	weights := append([]int(nil), freq...)
+	for {
+		nodes := make([]node, 0, used)
+		for s, f := range weights {
+			if f > 0 {
+				nodes = append(nodes, node{weight: f, symbols: []int{s}})
+			}
+		}
+		for i := range c.lengths {
+			c.lengths[i] = 0
+		}
+		for len(nodes) > 1 {
+			sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].weight < nodes[j].weight })
+			merged := node{
+				weight:  nodes[0].weight + nodes[1].weight,
+				symbols: append(append([]int(nil), nodes[0].symbols...), nodes[1].symbols...),
+			}
+			for _, s := range merged.symbols {
+				c.lengths[s]++
+			}
+			nodes = append([]node{merged}, nodes[2:]...)
+		}
+
+		longest := 0
+		for _, l := range c.lengths {
+			longest = max(longest, int(l))
+		}
+		if longest <= limit {
+			break
+		}
+		for s, f := range weights {
+			if f > 0 {
+				weights[s] = max(1, f/2)
+			}
+		}
+	}
+
+	// Códigos canônicos, como em codeLengthsToCodes do decodificador
+	var count [16]uint32
+	for _, l := range c.lengths {
+		count[l]++
+	}
+	count[0] = 0
+	var next [16]uint32
+	code := uint32(0)
+	for l := 1; l < 16; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+	for s, l := range c.lengths {
+		if l > 0 {
+			c.codes[s] = reverseCode(next[l], uint(l))
+			next[l]++
+		}
+	}
+	return c
+}
+
+// reverseCode inverte os length bits menos significativos de code.
+func reverseCode(code uint32, length uint) uint32 {
+	var out uint32
+	for i := uint(0); i < length; i++ {
+		out = out<<1 | code>>i&1
+	}
+	return out
+}
+
+// write grava um símbolo.
+func (c *webpCode) write(bw *webpBitWriter, symbol int) {
+	if !c.single {
+		bw.writeBits(c.codes[symbol], uint(c.lengths[symbol]))
+	}
+}
+
+// writeHeader grava a descrição do código: um código simples para
+// alfabetos não usados, senão os comprimentos de todos os símbolos,
+// comprimidos com as repetições 16, 17 e 18 do formato e descritos por
+// um segundo código de Huffman.
+func (c *webpCode) writeHeader(bw *webpBitWriter) {
+	used := false
+	for _, l := range c.lengths {
+		used = used || l > 0
+	}
+	if !used {
+		bw.writeBits(1, 1) // Código simples
+		bw.writeBits(0, 1) // Um símbolo
+		bw.writeBits(0, 1) // De 1 bit
+		bw.writeBits(0, 1) // O símbolo 0
+		return
+	}
+
+	// Comprimentos como símbolos de 0 a 18, com bits extras
+	type lengthToken struct {
+		symbol    int
+		extra     uint32
+		extraBits uint
+	}
+	var tokens []lengthToken
+	lengths := c.lengths
+	previous := uint8(8)
+	for i := 0; i < len(lengths); {
+		l := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == l {
+			run++
+		}
+		switch {
+		case l == 0 && run >= 11:
+			run = min(run, 138)
+			tokens = append(tokens, lengthToken{18, uint32(run - 11), 7})
+		case l == 0 && run >= 3:
+			tokens = append(tokens, lengthToken{17, uint32(run - 3), 3})
+		case l == previous && run >= 3:
+			run = min(run, 6)
+			tokens = append(tokens, lengthToken{16, uint32(run - 3), 2})
+		default:
+			run = 1
+			tokens = append(tokens, lengthToken{symbol: int(l)})
+			if l != 0 {
+				previous = l
+			}
+		}
+		i += run
+	}
+
+	freq := make([]int, 19)
+	for _, t := range tokens {
+		freq[t.symbol]++
+	}
+	lengthCode := newWebPCode(freq, 7)
+
+	count := 4
+	for i, s := range webpCodeLengthOrder {
+		if lengthCode.lengths[s] > 0 {
+			count = max(count, i+1)
+		}
+	}
+	bw.writeBits(0, 1) // Código normal
+	bw.writeBits(uint32(count-4), 4)
+	for _, s := range webpCodeLengthOrder[:count] {
+		bw.writeBits(uint32(lengthCode.lengths[s]), 3)
+	}
+	bw.writeBits(0, 1) // Comprimentos de todo o alfabeto
+	for _, t := range tokens {
+		lengthCode.write(bw, t.symbol)
+		bw.writeBits(t.extra, t.extraBits)
+	}
+}
+
+// webpBitWriter acumula bits a partir do menos significativo de cada
+// byte, a ordem do VP8L.
+type webpBitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// writeBits grava os n bits menos significativos de v.
+func (bw *webpBitWriter) writeBits(v uint32, n uint) {
+	bw.acc |= uint64(v&(1<<n-1)) << bw.nbits
+	bw.nbits += n
+	for bw.nbits >= 8 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc >>= 8
+		bw.nbits -= 8
+	}
+}
+
+// flush completa o último byte e retorna os dados gravados.
+func (bw *webpBitWriter) flush() []byte {
+	if bw.nbits > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc, bw.nbits = 0, 0
+	}
+	return bw.buf
+}
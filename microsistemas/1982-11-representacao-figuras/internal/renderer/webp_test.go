@@ -0,0 +1,139 @@
+package renderer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+// webpTestImages retorna imagens com características diferentes: cores
+// lisas com transparência, ruído (todos os símbolos dos alfabetos),
+// gradiente, linhas repetidas e tamanhos mínimos.
+func webpTestImages() map[string]image.Image {
+	flat := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if x > 20 && x < 40 {
+				c = color.NRGBA{R: 30, G: 60, B: 90, A: 128}
+			}
+			flat.SetNRGBA(x, y, c)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	noise := image.NewNRGBA(image.Rect(0, 0, 97, 61))
+	rng.Read(noise.Pix)
+
+	gradient := image.NewNRGBA(image.Rect(0, 0, 300, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 300; x++ {
+			gradient.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(x / 2), B: uint8(y * 10), A: 255})
+		}
+	}
+
+	stripes := image.NewNRGBA(image.Rect(0, 0, 33, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 33; x++ {
+			stripes.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 7), G: uint8(x * 3), B: 9, A: 255})
+		}
+	}
+
+	single := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	single.SetNRGBA(0, 0, color.NRGBA{R: 1, G: 2, B: 3, A: 255})
+
+	column := image.NewNRGBA(image.Rect(0, 0, 1, 50))
+	for y := 0; y < 50; y++ {
+		column.SetNRGBA(0, y, color.NRGBA{R: uint8(y / 10), G: 7, B: 7, A: 255})
+	}
+
+	return map[string]image.Image{
+		"flat": flat, "noise": noise, "gradient": gradient,
+		"stripes": stripes, "single": single, "column": column,
+	}
+}
+
+func TestEncodeWebP_RoundTrip(t *testing.T) {
+	for name, img := range webpTestImages() {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := encodeWebP(&buf, img); err != nil {
+				t.Fatalf("encodeWebP failed: %v", err)
+			}
+			decoded, err := webp.Decode(&buf)
+			if err != nil {
+				t.Fatalf("Encoded WebP does not decode: %v", err)
+			}
+			if decoded.Bounds() != img.Bounds() {
+				t.Fatalf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+			}
+
+			b := img.Bounds()
+			for y := b.Min.Y; y < b.Max.Y; y++ {
+				for x := b.Min.X; x < b.Max.X; x++ {
+					want := color.NRGBAModel.Convert(img.At(x, y))
+					if got := color.NRGBAModel.Convert(decoded.At(x, y)); got != want {
+						t.Fatalf("Pixel (%d, %d): expected %v, got %v", x, y, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWebP_SmallerThanPNG(t *testing.T) {
+	// Figura típica: fundo liso e algumas linhas
+	renderer := New(400, 300)
+	renderer.context.SetRGB(0, 0, 0)
+	for i := 0; i < 10; i++ {
+		renderer.context.DrawLine(20, float64(20+25*i), 380, float64(280-25*i))
+	}
+	renderer.context.Stroke()
+
+	var webpBuf, pngBuf bytes.Buffer
+	if err := encodeWebP(&webpBuf, renderer.image()); err != nil {
+		t.Fatalf("encodeWebP failed: %v", err)
+	}
+	if err := png.Encode(&pngBuf, renderer.image()); err != nil {
+		t.Fatal(err)
+	}
+	if webpBuf.Len() >= pngBuf.Len() {
+		t.Errorf("Expected WebP (%d bytes) smaller than PNG (%d bytes)", webpBuf.Len(), pngBuf.Len())
+	}
+}
+
+func TestEncodeWebP_TooLarge(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, webpMaxSize+1, 1))
+	if err := encodeWebP(&bytes.Buffer{}, img); err == nil {
+		t.Error("Expected error for image wider than the WebP limit")
+	}
+}
+
+func TestWebPPrefix(t *testing.T) {
+	tests := []struct {
+		value     int
+		prefix    int
+		extraBits uint
+		extra     uint32
+	}{
+		{1, 0, 0, 0},
+		{4, 3, 0, 0},
+		{5, 4, 1, 0},
+		{6, 4, 1, 1},
+		{7, 5, 1, 0},
+		{9, 6, 2, 0},
+		{4096, 23, 10, 1023},
+	}
+	for _, tt := range tests {
+		prefix, extraBits, extra := webpPrefix(tt.value)
+		if prefix != tt.prefix || extraBits != tt.extraBits || extra != tt.extra {
+			t.Errorf("webpPrefix(%d) = (%d, %d, %d), expected (%d, %d, %d)",
+				tt.value, prefix, extraBits, extra, tt.prefix, tt.extraBits, tt.extra)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package renderer
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// zBuffer guarda, para cada pixel, a profundidade da superfície mais
+// próxima já desenhada nele.
+//
+// As profundidades são guardadas como uma chave que varia linearmente
+// na tela (ver depthKey), o que permite interpolá-las entre os vértices
+// de cada face sem distorção.
+type zBuffer struct {
+	bounds image.Rectangle // Área da imagem coberta (o viewport atual)
+	depth  []float64       // Chave de profundidade por pixel (+Inf = vazio)
+	slack  []float64       // Folga da superfície em cada pixel (ver depthPlane.tolerance)
+	marks  []int           // Último traço que pintou cada pixel (ver claim)
+	stroke int             // Número do traço atual
+}
+
+// newZBuffer cria um z-buffer vazio para a área informada.
+func newZBuffer(bounds image.Rectangle) *zBuffer {
+	depth := make([]float64, bounds.Dx()*bounds.Dy())
+	for i := range depth {
+		depth[i] = math.Inf(1)
+	}
+	return &zBuffer{bounds: bounds, depth: depth, slack: make([]float64, len(depth)), marks: make([]int, len(depth))}
+}
+
+// nextStroke inicia um novo traço para claim.
+func (z *zBuffer) nextStroke() {
+	z.stroke++
+}
+
+// claim informa se o pixel ainda não foi pintado pelo traço atual e o
+// marca. Um traço semitransparente que passa duas vezes pelo mesmo pixel
+// (nas junções das arestas) não deve escurecê-lo duas vezes.
+func (z *zBuffer) claim(idx int) bool {
+	if z.marks[idx] == z.stroke {
+		return false
+	}
+	z.marks[idx] = z.stroke
+	return true
+}
+
+// index retorna a posição do pixel (x, y) em depth, ou -1 se fora da área.
+func (z *zBuffer) index(x, y int) int {
+	if !(image.Point{X: x, Y: y}).In(z.bounds) {
+		return -1
+	}
+	return (y-z.bounds.Min.Y)*z.bounds.Dx() + (x - z.bounds.Min.X)
+}
+
+// depthKey converte a profundidade de um vértice na chave do z-buffer.
+//
+// Na projeção cônica, a profundidade Pz de um plano não varia
+// linearmente na tela, mas 1/Pz sim (a mesma divisão por Pz das
+// fórmulas do artigo); a chave −1/Pz mantém "menor = mais próximo".
+// Nas projeções paralelas a própria profundidade já é linear.
+func (r *Renderer3D) depthKey(z float64) float64 {
+	if r.camera.IsParallel() {
+		return z
+	}
+	return -1 / z
+}
+
+// depthPlane descreve a chave de profundidade de uma face como função
+// afim das coordenadas de tela: k(x, y) = k0 + dx·(x − x0) + dy·(y − y0).
+type depthPlane struct {
+	x0, y0, k0 float64
+	dx, dy     float64
+}
+
+// at avalia a chave de profundidade no ponto de tela (x, y).
+func (p depthPlane) at(x, y float64) float64 {
+	return p.k0 + p.dx*(x-p.x0) + p.dy*(y-p.y0)
+}
+
+// tolerance é a folga na comparação de profundidade de um traço sobre a
+// superfície: o quanto a chave varia em três quartos de pixel, para que
+// uma aresta sobre a face não se esconda atrás dela pelo arredondamento.
+func (p depthPlane) tolerance() float64 {
+	return 0.75*(math.Abs(p.dx)+math.Abs(p.dy)) + 1e-9
+}
+
+// newLinePlane retorna a chave de profundidade ao longo de um segmento
+// de (x0, y0) a (x1, y1), com as chaves k0 e k1 nas pontas (constante na
+// direção perpendicular).
+func newLinePlane(x0, y0, k0, x1, y1, k1 float64) depthPlane {
+	plane := depthPlane{x0: x0, y0: y0, k0: k0}
+	if length := (x1-x0)*(x1-x0) + (y1-y0)*(y1-y0); length > 0 {
+		plane.dx = (k1 - k0) * (x1 - x0) / length
+		plane.dy = (k1 - k0) * (y1 - y0) / length
+	}
+	return plane
+}
+
+// newDepthPlane ajusta o plano de profundidade de uma face projetada.
+//
+// A normal do polígono (x, y, k) é calculada pelo método de Newell (ver
+// faceNormal); a componente k da normal é proporcional à área projetada.
+//
+// Retorna:
+//   depthPlane: plano ajustado
+//   bool: false se a face está de perfil (área projetada nula)
+func newDepthPlane(xs, ys, ks []float64) (depthPlane, bool) {
+	var nx, ny, nk float64
+	for i := range xs {
+		j := (i + 1) % len(xs)
+		nx += (ys[i] - ys[j]) * (ks[i] + ks[j])
+		ny += (ks[i] - ks[j]) * (xs[i] + xs[j])
+		nk += (xs[i] - xs[j]) * (ys[i] + ys[j])
+	}
+	if math.Abs(nk) < 1e-9 {
+		return depthPlane{}, false
+	}
+	return depthPlane{
+		x0: xs[0], y0: ys[0], k0: ks[0],
+		dx: -nx / nk, dy: -ny / nk,
+	}, true
+}
+
+// rasterizeFaces desenha as faces com um z-buffer.
+//
+// Cada face é preenchida linha a linha (scanline, regra par-ímpar) e um
+// pixel só é pintado se a face estiver mais próxima do que tudo o que já
+// foi desenhado nele. Assim, faces que se interpenetram são resolvidas
+// corretamente, onde o algoritmo do pintor escolheria uma delas inteira.
+//
+// Faces semitransparentes são desenhadas depois das opacas, da mais
+// distante para a mais próxima, misturando-se ao que está atrás delas
+// sem ocupar o z-buffer: o que elas cobrem continua visível através.
+//
+// As linhas da figura são traçadas entre as faces opacas e as
+// semitransparentes, e os contornos por último, todos testando o
+// z-buffer: um trecho só aparece se não houver superfície opaca à sua
+// frente (com uma tolerância de meio pixel na profundidade, para que as
+// arestas das próprias faces visíveis não se escondam atrás delas). As
+// setas das linhas direcionadas aparecem se a ponta estiver visível.
+//
+// Parâmetros:
+//   faces: faces preparadas (ver prepareFaces)
+//   lines: trechos visíveis das linhas da figura (ver figureLine)
+//   cfg: configurações visuais (cor e espessura dos contornos)
+//
+// Retorna:
+//   error: erro se a imagem do renderizador não for RGBA
+func (r *Renderer3D) rasterizeFaces(faces []screenFace, lines []screenLine, cfg RenderConfig) error {
+	img, ok := r.context.Image().(*image.RGBA)
+	if !ok {
+		return fmt.Errorf("imagem do renderizador não é RGBA")
+	}
+
+	// Com superamostragem, rasteriza diretamente na tela interna
+	scale := float64(r.scale)
+	vp := r.viewport
+	bounds := image.Rect(
+		int(math.Round(vp.X*scale)), int(math.Round(vp.Y*scale)),
+		int(math.Round((vp.X+vp.Width)*scale)), int(math.Round((vp.Y+vp.Height)*scale)),
+	).Intersect(img.Bounds())
+	zb := newZBuffer(bounds)
+
+	planes := make([]depthPlane, len(faces))
+	visible := make([]bool, len(faces))
+	xs := make([][]float64, len(faces))
+	ys := make([][]float64, len(faces))
+	for i, face := range faces {
+		xs[i] = make([]float64, len(face.screen))
+		ys[i] = make([]float64, len(face.screen))
+		ks := make([]float64, len(face.screen))
+		for j, p := range face.screen {
+			xs[i][j], ys[i][j], ks[j] = p.X*scale, p.Y*scale, r.depthKey(face.depth[j])
+		}
+		planes[i], visible[i] = newDepthPlane(xs[i], ys[i], ks)
+	}
+
+	// Faces opacas primeiro, ocupando o z-buffer; depois as linhas da
+	// figura e as faces semitransparentes, em ordem de profundidade
+	half := int(math.Max(cfg.LineWidth*scale, 1) / 2)
+	var arrows []screenLine
+	for _, opaque := range []bool{true, false} {
+		if !opaque {
+			arrows = r.rasterizeLines(zb, img, lines, half)
+		}
+		for i, face := range faces {
+			if !visible[i] || (face.fill.A >= 1) != opaque {
+				continue
+			}
+			fill := toRGBA(face.fill)
+			zb.fillPolygon(xs[i], ys[i], planes[i], opaque, func(x, y int) { blendPixel(img, x, y, fill) })
+		}
+	}
+
+	// Contornos testados contra o z-buffer já completo
+	line := toRGBA(cfg.LineColor)
+	for i, face := range faces {
+		if !visible[i] {
+			continue
+		}
+		zb.nextStroke()
+		tolerance := planes[i].tolerance()
+		n := len(face.screen)
+		for j := range face.screen {
+			if face.edges != nil && !face.edges[j] {
+				continue
+			}
+			a, b := face.screen[j], face.screen[(j+1)%n]
+			zb.strokeSegment(a.X*scale, a.Y*scale, b.X*scale, b.Y*scale, planes[i], func(int) float64 { return tolerance }, half,
+				func(x, y int) { blendPixel(img, x, y, line) })
+		}
+	}
+
+	for _, l := range arrows {
+		r.setColor(l.color)
+		r.drawLineArrow(l.a, l.b, cfg)
+	}
+	r.setColor(cfg.LineColor)
+	return nil
+}
+
+// rasterizeLines traça as linhas da figura testando o z-buffer, sem
+// alterá-lo.
+//
+// A profundidade de cada trecho é interpolada entre as pontas, como a
+// das faces; a folga é a do próprio trecho mais a da superfície já
+// desenhada no pixel, para que uma linha sobre uma aresta de face não
+// se esconda atrás da face.
+//
+// Parâmetros:
+//   zb: z-buffer com as faces opacas
+//   img: imagem na escala da tela interna
+//   lines: trechos visíveis das linhas da figura
+//   half: meia espessura do traço em pixels
+//
+// Retorna:
+//   []screenLine: trechos com seta cuja ponta ficou visível
+func (r *Renderer3D) rasterizeLines(zb *zBuffer, img *image.RGBA, lines []screenLine, half int) []screenLine {
+	scale := float64(r.scale)
+	var arrows []screenLine
+	for k, l := range lines {
+		// Um traço por linha: os trechos de uma curva continuam o anterior
+		if k == 0 || l.a != lines[k-1].b {
+			zb.nextStroke()
+		}
+		x0, y0, x1, y1 := l.a.X*scale, l.a.Y*scale, l.b.X*scale, l.b.Y*scale
+		plane := newLinePlane(x0, y0, r.depthKey(l.za), x1, y1, r.depthKey(l.zb))
+		own := plane.tolerance()
+		color := toRGBA(l.color)
+		zb.strokeSegment(x0, y0, x1, y1, plane, func(idx int) float64 { return own + zb.slack[idx] }, half,
+			func(x, y int) { blendPixel(img, x, y, color) })
+
+		if l.arrow {
+			idx := zb.index(int(math.Floor(x1)), int(math.Floor(y1)))
+			if idx < 0 || plane.at(x1, y1) <= zb.depth[idx]+own+zb.slack[idx] {
+				arrows = append(arrows, l)
+			}
+		}
+	}
+	return arrows
+}
+
+// fillPolygon preenche um polígono testando (e opcionalmente atualizando)
+// o z-buffer.
+//
+// Percorre as linhas de pixels que o polígono cobre, calcula onde cada
+// uma cruza as arestas e preenche os trechos entre pares de cruzamentos
+// (amostrando no centro dos pixels).
+//
+// Parâmetros:
+//   xs, ys: vértices do polígono em pixels
+//   plane: plano de profundidade da face
+//   write: se a profundidade da face é gravada (false para faces
+//          semitransparentes, que não escondem o que vem depois)
+//   plot: função que pinta um pixel aprovado no teste de profundidade
+func (z *zBuffer) fillPolygon(xs, ys []float64, plane depthPlane, write bool, plot func(x, y int)) {
+	minY, maxY := ys[0], ys[0]
+	for _, y := range ys {
+		minY = math.Min(minY, y)
+		maxY = math.Max(maxY, y)
+	}
+	startY := int(math.Max(math.Ceil(minY-0.5), float64(z.bounds.Min.Y)))
+	endY := int(math.Min(math.Floor(maxY-0.5), float64(z.bounds.Max.Y-1)))
+
+	n := len(xs)
+	crossings := make([]float64, 0, n)
+	for y := startY; y <= endY; y++ {
+		yc := float64(y) + 0.5
+
+		crossings = crossings[:0]
+		for i := 0; i < n; i++ {
+			j := (i + 1) % n
+			if (ys[i] <= yc) != (ys[j] <= yc) {
+				t := (yc - ys[i]) / (ys[j] - ys[i])
+				crossings = append(crossings, xs[i]+t*(xs[j]-xs[i]))
+			}
+		}
+		sort.Float64s(crossings)
+
+		for k := 0; k+1 < len(crossings); k += 2 {
+			startX := int(math.Max(math.Ceil(crossings[k]-0.5), float64(z.bounds.Min.X)))
+			endX := int(math.Min(math.Ceil(crossings[k+1]-0.5), float64(z.bounds.Max.X)))
+			for x := startX; x < endX; x++ {
+				idx := z.index(x, y)
+				d := plane.at(float64(x)+0.5, yc)
+				if d < z.depth[idx] {
+					if write {
+						z.depth[idx] = d
+						z.slack[idx] = plane.tolerance()
+					}
+					plot(x, y)
+				}
+			}
+		}
+	}
+}
+
+// strokeSegment traça um segmento visível segundo o z-buffer, sem alterá-lo.
+//
+// O segmento é amostrado a cada pixel (DDA); em cada amostra, um
+// quadrado de lado 2·half+1 é pintado nos pixels em que a aresta não
+// está atrás da superfície já desenhada (além da tolerância). Cada pixel
+// é pintado uma única vez por traço (ver claim).
+//
+// Parâmetros:
+//   x0, y0, x1, y1: extremos do segmento em pixels
+//   plane: plano de profundidade da aresta (o da face dona da aresta)
+//   tolerance: folga na comparação de profundidade em cada pixel
+//   half: meia espessura do traço em pixels
+//   plot: função que pinta um pixel visível
+func (z *zBuffer) strokeSegment(x0, y0, x1, y1 float64, plane depthPlane, tolerance func(idx int) float64, half int, plot func(x, y int)) {
+	steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	if steps == 0 {
+		steps = 1
+	}
+
+	for s := 0; s <= steps; s++ {
+		t := float64(s) / float64(steps)
+		x := x0 + t*(x1-x0)
+		y := y0 + t*(y1-y0)
+		d := plane.at(x, y)
+
+		cx, cy := int(math.Floor(x)), int(math.Floor(y))
+		for py := cy - half; py <= cy+half; py++ {
+			for px := cx - half; px <= cx+half; px++ {
+				idx := z.index(px, py)
+				if idx >= 0 && d <= z.depth[idx]+tolerance(idx) && z.claim(idx) {
+					plot(px, py)
+				}
+			}
+		}
+	}
+}
+
+// toRGBA converte uma cor da configuração em cor de 8 bits, com as
+// componentes pré-multiplicadas pela opacidade (como em image.RGBA).
+func toRGBA(c colorRGB) color.RGBA {
+	return color.RGBA{
+		R: uint8(math.Round(c.R * c.A * 255)),
+		G: uint8(math.Round(c.G * c.A * 255)),
+		B: uint8(math.Round(c.B * c.A * 255)),
+		A: uint8(math.Round(c.A * 255)),
+	}
+}
+
+// blendPixel pinta um pixel com a cor c sobre o que já está nele
+// (composição "sobre", com componentes pré-multiplicadas).
+func blendPixel(img *image.RGBA, x, y int, c color.RGBA) {
+	if c.A == 255 {
+		img.SetRGBA(x, y, c)
+		return
+	}
+	dst := img.RGBAAt(x, y)
+	keep := 255 - uint32(c.A)
+	over := func(s, d uint8) uint8 {
+		return s + uint8((uint32(d)*keep+127)/255)
+	}
+	img.SetRGBA(x, y, color.RGBA{
+		R: over(c.R, dst.R),
+		G: over(c.G, dst.G),
+		B: over(c.B, dst.B),
+		A: over(c.A, dst.A),
+	})
+}
@@ -0,0 +1,156 @@
+package renderer
+
+import (
+	"image"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// crossingFigure retorna dois quadrados que se interpenetram: o vermelho
+// se aproxima do observador à esquerda, o azul à direita. As duas faces
+// têm a mesma profundidade média, o que o algoritmo do pintor não resolve.
+func crossingFigure() *types.Figure {
+	return &types.Figure{
+		Pontos: []types.Point3D{
+			{X: -2, Y: 5, Z: -2}, {X: 2, Y: 9, Z: -2}, {X: 2, Y: 9, Z: 2}, {X: -2, Y: 5, Z: 2},
+			{X: -2, Y: 9, Z: -2}, {X: 2, Y: 5, Z: -2}, {X: 2, Y: 5, Z: 2}, {X: -2, Y: 9, Z: 2},
+		},
+		Faces: []types.Face{
+			{Pontos: []int{0, 1, 2, 3}, Cor: "#ff0000"},
+			{Pontos: []int{4, 5, 6, 7}, Cor: "#0000ff"},
+		},
+		Camera: types.DefaultCamera(),
+	}
+}
+
+func zbufferConfig() RenderConfig {
+	cfg := DefaultRenderConfig()
+	cfg.HiddenSurface = HiddenZBuffer
+	return cfg
+}
+
+func TestRasterizeFaces_Interpenetrating(t *testing.T) {
+	renderer := New(200, 150)
+	figure := crossingFigure()
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigureWithConfig(figure, zbufferConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	tests := []struct {
+		name  string
+		point types.Point3D
+		red   bool
+	}{
+		{"left half", types.Point3D{X: -1, Y: 6, Z: 0.5}, true},
+		{"right half", types.Point3D{X: 1, Y: 6, Z: 0.5}, false},
+	}
+	for _, tt := range tests {
+		p := renderer.ProjectToScreen(tt.point)
+		r, _, b, _ := img.At(int(p.X), int(p.Y)).RGBA()
+		red := r > 0xc000 && b < 0x4000
+		blue := b > 0xc000 && r < 0x4000
+		if red != tt.red || blue == tt.red {
+			t.Errorf("%s: expected red=%v, got (%x,%x)", tt.name, tt.red, r, b)
+		}
+	}
+}
+
+func TestRasterizeFaces_MatchesPainter(t *testing.T) {
+	renderer := New(200, 150)
+	figure := facesTestFigure()
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigureWithConfig(figure, zbufferConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	r, g, b, _ := img.At(100, 75).RGBA()
+	if r < 0xc000 || g > 0x4000 || b > 0x4000 {
+		t.Errorf("Expected near red face at center, got (%x,%x,%x)", r, g, b)
+	}
+
+	beside := renderer.ProjectToScreen(types.Point3D{X: 1.9, Y: 8, Z: 0})
+	r, g, b, _ = img.At(int(beside.X), int(beside.Y)).RGBA()
+	if b < 0xc000 || r > 0x4000 {
+		t.Errorf("Expected far blue face beside near face, got (%x,%x,%x)", r, g, b)
+	}
+}
+
+func TestRasterizeFaces_HiddenOutline(t *testing.T) {
+	renderer := New(200, 150)
+	figure := facesTestFigure()
+	// A face distante passa a ser menor e fica inteiramente escondida
+	for i := 4; i < 8; i++ {
+		figure.Pontos[i].X /= 4
+		figure.Pontos[i].Z /= 4
+	}
+	renderer.SetCamera(figure.Camera)
+
+	if err := renderer.RenderFigureWithConfig(figure, zbufferConfig()); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	// A aresta da face distante não atravessa a face próxima
+	img := renderer.GetImage().(image.Image)
+	edge := renderer.ProjectToScreen(types.Point3D{X: 0.5, Y: 8, Z: 0})
+	r, g, b, _ := img.At(int(edge.X), int(edge.Y)).RGBA()
+	if r < 0xc000 || g > 0x4000 || b > 0x4000 {
+		t.Errorf("Expected hidden edge to stay covered by red face, got (%x,%x,%x)", r, g, b)
+	}
+}
+
+func TestRasterizeFaces_Lines(t *testing.T) {
+	// As linhas da figura passam pelo z-buffer (ver checkLinesAndFaces)
+	checkLinesAndFaces(t, HiddenZBuffer)
+}
+
+func TestRasterizeFaces_LineOnFaceEdge(t *testing.T) {
+	// Uma linha sobre a aresta da face próxima não se esconde atrás dela
+	renderer := New(200, 150)
+	figure := facesTestFigure()
+	figure.Linhas = []types.Line{{P1: 0, P2: 1}}
+	renderer.SetCamera(figure.Camera)
+
+	cfg := zbufferConfig()
+	cfg.LineColor = colorRGB{G: 1, A: 1}
+	cfg.LineWidth = 3
+	if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+		t.Fatalf("RenderFigureWithConfig failed: %v", err)
+	}
+
+	img := renderer.GetImage().(image.Image)
+	edge := renderer.ProjectToScreen(types.Point3D{X: 0, Y: 5, Z: -1})
+	r, g, b, _ := img.At(int(edge.X), int(edge.Y)).RGBA()
+	if g < 0xc000 || r > 0x4000 || b > 0x4000 {
+		t.Errorf("Expected the line on the face edge, got (%x,%x,%x)", r, g, b)
+	}
+}
+
+func TestRasterizeFaces_Translucent(t *testing.T) {
+	figure := facesTestFigure()
+	// A face próxima, semitransparente, declarada antes da opaca
+	figure.Faces[0].Cor = "#ff000080"
+
+	for _, hidden := range []HiddenSurface{HiddenPainter, HiddenZBuffer} {
+		renderer := New(200, 150)
+		renderer.SetCamera(figure.Camera)
+
+		cfg := DefaultRenderConfig()
+		cfg.HiddenSurface = hidden
+		if err := renderer.RenderFigureWithConfig(figure, cfg); err != nil {
+			t.Fatalf("RenderFigureWithConfig failed: %v", err)
+		}
+
+		// No centro, o vermelho se mistura ao azul que está atrás
+		img := renderer.GetImage().(image.Image)
+		r, g, b, _ := img.At(100, 75).RGBA()
+		if r < 0x6000 || r > 0xa000 || b < 0x6000 || b > 0xa000 || g > 0x2000 {
+			t.Errorf("%s: expected red blended over blue at center, got (%x,%x,%x)", hidden, r, g, b)
+		}
+	}
+}
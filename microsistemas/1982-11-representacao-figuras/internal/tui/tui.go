@@ -0,0 +1,264 @@
+// Package tui implementa um visualizador de figuras para o terminal,
+// para servidores sem interface gráfica, onde o viewfinder (Fyne) não
+// roda: as arestas são desenhadas com caracteres braille e as setas
+// giram a câmera em volta da figura.
+package tui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+
+	"representacao-figuras/internal/core"
+	"representacao-figuras/internal/renderer"
+	"representacao-figuras/pkg/types"
+)
+
+// Passos da órbita da câmera, em graus.
+const (
+	orbitStep    = 15 // Giro de cada tecla
+	maxElevation = 89 // Elevação máxima, acima ou abaixo da figura
+)
+
+// key é uma tecla reconhecida pelo visualizador.
+type key int
+
+const (
+	keyNone  key = iota
+	keyLeft      // Gira para a esquerda (azimute menor)
+	keyRight     // Gira para a direita (azimute maior)
+	keyUp        // Sobe (elevação maior)
+	keyDown      // Desce (elevação menor)
+	keyReset     // Volta à câmera da figura
+	keyQuit      // Sai do visualizador
+)
+
+// TUI é o visualizador de figuras no terminal.
+type TUI struct {
+	filename string                    // Arquivo YAML da figura
+	prepare  func(*types.Figure) error // Ajustes aplicados à figura carregada
+	figure   *types.Figure             // Figura carregada
+	cfg      renderer.RenderConfig     // Configuração visual da figura
+
+	azimuth, elevation float64 // Órbita atual da câmera, em graus
+	homeAzimuth        float64 // Órbita inicial (ver keyReset)
+	homeElevation      float64
+}
+
+// New cria o visualizador de terminal para um arquivo de figura.
+//
+// Parâmetros:
+//   filename: caminho ou URL do arquivo YAML
+//   prepare: ajustes aplicados à figura carregada (opções de linha de
+//            comando), ou nil
+//
+// Retorna:
+//   *TUI: visualizador pronto para Run
+func New(filename string, prepare func(*types.Figure) error) *TUI {
+	return &TUI{filename: filename, prepare: prepare}
+}
+
+// Run carrega a figura e mostra o visualizador até que o usuário saia
+// (q, Esc ou Ctrl+C).
+//
+// O terminal é controlado pela biblioteca tcell, que cuida do modo
+// bruto, da tela alternativa e das diferenças entre Unix e Windows, e
+// o devolve na saída como estava.
+//
+// Retorna:
+//   error: erro ao carregar a figura ou se a saída não for um terminal
+func (t *TUI) Run() error {
+	if err := t.load(); err != nil {
+		return err
+	}
+
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return fmt.Errorf("a saída padrão não é um terminal: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return fmt.Errorf("erro ao configurar o terminal: %w", err)
+	}
+	return t.run(screen)
+}
+
+// run atende aos eventos de uma tela já iniciada até a saída, e a
+// encerra. A figura é redesenhada a cada tecla e quando a janela muda
+// de tamanho.
+//
+// Os eventos são lidos aqui mesmo, com PollEvent: a leitura do teclado
+// fica com a tcell, que a interrompe em Fini, e nenhuma goroutine
+// continua presa à entrada depois do retorno.
+func (t *TUI) run(screen tcell.Screen) error {
+	defer screen.Fini()
+
+	for {
+		t.draw(screen)
+
+		switch ev := screen.PollEvent().(type) {
+		case nil: // Tela encerrada
+			return nil
+		case *tcell.EventKey:
+			k := keyOf(ev)
+			if k == keyQuit {
+				return nil
+			}
+			t.handle(k)
+		case *tcell.EventResize:
+			screen.Sync()
+		}
+	}
+}
+
+// draw escreve o quadro atual na tela: o desenho em texto normal e a
+// linha de estado em vídeo inverso.
+func (t *TUI) draw(screen tcell.Screen) {
+	screen.Clear()
+	cols, rows := screen.Size()
+	lines := t.frame(cols, rows)
+	for y, line := range lines {
+		style := tcell.StyleDefault
+		if y == len(lines)-1 {
+			style = style.Reverse(true)
+		}
+		x := 0
+		for _, r := range line {
+			screen.SetContent(x, y, r, nil, style)
+			x++
+		}
+	}
+	screen.Show()
+}
+
+// load carrega a figura e parte da órbita equivalente à sua câmera.
+func (t *TUI) load() error {
+	figure, err := core.LoadFigureFromYAML(t.filename)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar arquivo YAML: %w", err)
+	}
+	if t.prepare != nil {
+		if err := t.prepare(figure); err != nil {
+			return err
+		}
+	}
+	cfg, err := renderer.ConfigFromFigure(figure)
+	if err != nil {
+		return fmt.Errorf("erro na configuração de renderização: %w", err)
+	}
+
+	t.figure, t.cfg = figure, cfg
+	t.homeAzimuth, t.homeElevation = orbitOf(renderer.EffectiveCamera(figure.Camera, figure, 4.0/3))
+	t.azimuth, t.elevation = t.homeAzimuth, t.homeElevation
+	return nil
+}
+
+// orbitOf calcula o azimute e a elevação (em graus) da direção do alvo
+// para o observador, invertendo types.Orbit.Position. Sem alvo, o
+// observador olha na direção +Y, como no artigo: azimute e elevação 0.
+func orbitOf(camera types.Camera) (azimuth, elevation float64) {
+	if camera.Target == nil {
+		return 0, 0
+	}
+	dx := camera.Observer.X - camera.Target.X
+	dy := camera.Observer.Y - camera.Target.Y
+	dz := camera.Observer.Z - camera.Target.Z
+	length := math.Sqrt(dx*dx + dy*dy + dz*dz)
+	if length == 0 {
+		return 0, 0
+	}
+	azimuth = math.Mod(math.Atan2(dx, -dy)*180/math.Pi+360, 360)
+	elevation = math.Asin(dz/length) * 180 / math.Pi
+	return math.Round(azimuth), math.Round(elevation)
+}
+
+// handle aplica uma tecla à órbita da câmera.
+func (t *TUI) handle(k key) {
+	switch k {
+	case keyLeft:
+		t.azimuth -= orbitStep
+	case keyRight:
+		t.azimuth += orbitStep
+	case keyUp:
+		t.elevation = math.Min(t.elevation+orbitStep, maxElevation)
+	case keyDown:
+		t.elevation = math.Max(t.elevation-orbitStep, -maxElevation)
+	case keyReset:
+		t.azimuth, t.elevation = t.homeAzimuth, t.homeElevation
+	}
+	t.azimuth = math.Mod(t.azimuth+360, 360)
+}
+
+// camera retorna a câmera da órbita atual: a projeção e o campo de
+// visão são os da figura, com enquadramento automático.
+func (t *TUI) camera() types.Camera {
+	cam := t.figure.Camera
+	cam.View = ""
+	cam.Target = nil
+	cam.Orbit = &types.Orbit{Azimuth: t.azimuth, Elevation: t.elevation, Radius: 1}
+	cam.Auto = true
+	return cam
+}
+
+// frame monta as linhas da tela inteira: o desenho em braille nas
+// primeiras e, na última, a órbita atual e as teclas.
+//
+// Parâmetros:
+//   cols, rows: dimensões do terminal em caracteres
+//
+// Retorna:
+//   []string: uma linha por linha do terminal, a de estado por último
+func (t *TUI) frame(cols, rows int) []string {
+	if rows < 1 {
+		return nil
+	}
+
+	lines := make([]string, rows-1)
+	if cols > 0 && rows > 1 {
+		r := renderer.New(renderer.BrailleSize(cols, rows-1))
+		r.SetCamera(t.camera())
+		drawing, _ := r.RenderBraille(t.figure, t.cfg) // A figura já foi validada em load
+		copy(lines, drawing)
+	}
+
+	status := fmt.Sprintf(" %s  azimute %g  elevação %g   ←→↑↓ girar  r restaurar  q sair",
+		t.figure.Nome, t.azimuth, t.elevation)
+	if runes := []rune(status); len(runes) > cols {
+		status = string(runes[:max(cols, 0)])
+	}
+	return append(lines, status)
+}
+
+// keyOf reconhece uma tecla: as setas, as letras equivalentes dos jogos
+// (wasd) e do vi (hjkl), r, e q, Esc ou Ctrl+C para sair. Outras teclas
+// retornam keyNone.
+func keyOf(ev *tcell.EventKey) key {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		return keyUp
+	case tcell.KeyDown:
+		return keyDown
+	case tcell.KeyLeft:
+		return keyLeft
+	case tcell.KeyRight:
+		return keyRight
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return keyQuit
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'a', 'h':
+			return keyLeft
+		case 'd', 'l':
+			return keyRight
+		case 'w', 'k':
+			return keyUp
+		case 's', 'j':
+			return keyDown
+		case 'r', 'R':
+			return keyReset
+		case 'q', 'Q':
+			return keyQuit
+		}
+	}
+	return keyNone
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"representacao-figuras/internal/renderer"
+	"representacao-figuras/pkg/types"
+)
+
+func TestKeyOf(t *testing.T) {
+	cases := []struct {
+		ev   *tcell.EventKey
+		want key
+	}{
+		{tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), keyUp},
+		{tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), keyDown},
+		{tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone), keyRight},
+		{tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), keyLeft},
+		{tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), keyQuit},
+		{tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl), keyQuit},
+		{tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), keyNone},
+	}
+	for r, want := range map[rune]key{
+		'w': keyUp, 'a': keyLeft, 's': keyDown, 'd': keyRight,
+		'h': keyLeft, 'j': keyDown, 'k': keyUp, 'l': keyRight,
+		'r': keyReset, 'q': keyQuit, 'Q': keyQuit, 'x': keyNone, '1': keyNone,
+	} {
+		cases = append(cases, struct {
+			ev   *tcell.EventKey
+			want key
+		}{tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), want})
+	}
+	for _, c := range cases {
+		if got := keyOf(c.ev); got != c.want {
+			t.Errorf("keyOf(%s): expected %v, got %v", c.ev.Name(), c.want, got)
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	v := &TUI{azimuth: 10, elevation: 80, homeAzimuth: 45, homeElevation: 25}
+
+	v.handle(keyLeft)
+	if v.azimuth != 355 {
+		t.Errorf("Expected azimuth to wrap to 355, got %g", v.azimuth)
+	}
+	v.handle(keyRight)
+	v.handle(keyRight)
+	if v.azimuth != 25 {
+		t.Errorf("Expected azimuth 25, got %g", v.azimuth)
+	}
+
+	v.handle(keyUp)
+	if v.elevation != maxElevation {
+		t.Errorf("Expected elevation clamped to %d, got %g", maxElevation, v.elevation)
+	}
+	v.handle(keyDown)
+	if v.elevation != maxElevation-orbitStep {
+		t.Errorf("Expected elevation %d, got %g", maxElevation-orbitStep, v.elevation)
+	}
+
+	v.handle(keyReset)
+	if v.azimuth != 45 || v.elevation != 25 {
+		t.Errorf("Expected home orbit 45/25, got %g/%g", v.azimuth, v.elevation)
+	}
+}
+
+func TestOrbitOf(t *testing.T) {
+	for _, o := range []types.Orbit{{Azimuth: 45, Elevation: 25, Radius: 10}, {Azimuth: 300, Elevation: -30, Radius: 3}} {
+		target := types.Point3D{X: 1, Y: 2, Z: 3}
+		cam := types.Camera{Observer: o.Position(target), Target: &target}
+		az, el := orbitOf(cam)
+		if az != o.Azimuth || el != o.Elevation {
+			t.Errorf("Expected orbit %g/%g, got %g/%g", o.Azimuth, o.Elevation, az, el)
+		}
+	}
+	if az, el := orbitOf(types.DefaultCamera()); az != 0 || el != 0 {
+		t.Errorf("Expected 0/0 without target, got %g/%g", az, el)
+	}
+}
+
+// squareFigure é um quadrilátero torto, visível de qualquer órbita.
+func squareFigure() *types.Figure {
+	return &types.Figure{
+		Nome: "cubo",
+		Pontos: []types.Point3D{
+			{X: 0, Y: 0, Z: 0}, {X: 1, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: 0, Y: 1, Z: 1},
+		},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 0}},
+		Camera: types.DefaultCamera(),
+	}
+}
+
+func TestFrame(t *testing.T) {
+	v := &TUI{figure: squareFigure(), cfg: renderer.DefaultRenderConfig(), azimuth: 30, elevation: 20}
+
+	frame := v.frame(60, 20)
+	if len(frame) != 20 {
+		t.Fatalf("Expected 20 rows, got %d", len(frame))
+	}
+	drawing := strings.Join(frame[:19], "\n")
+	if !strings.ContainsFunc(drawing, func(r rune) bool { return r > 0x2800 && r <= 0x28ff }) {
+		t.Errorf("Expected braille characters in frame")
+	}
+	if !strings.Contains(frame[19], "cubo  azimute 30  elevação 20") {
+		t.Errorf("Expected status line with orbit, got %q", frame[19])
+	}
+
+	// Terminal estreito: a linha de estado é cortada
+	narrow := v.frame(10, 5)
+	if last := narrow[len(narrow)-1]; strings.Contains(last, "elevação") {
+		t.Errorf("Expected status line truncated to 10 columns, got %q", last)
+	}
+}
+
+func TestRun(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(60, 20)
+	v := &TUI{figure: squareFigure(), cfg: renderer.DefaultRenderConfig(), azimuth: 30, elevation: 20}
+
+	screen.InjectKey(tcell.KeyRight, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'w', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'q', tcell.ModNone)
+
+	done := make(chan error)
+	go func() { done <- v.run(screen) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected run to return after q")
+	}
+
+	if v.azimuth != 45 || v.elevation != 35 {
+		t.Errorf("Expected orbit 45/35 after → and w, got %g/%g", v.azimuth, v.elevation)
+	}
+	// A tela foi encerrada: não há mais eventos a ler
+	if ev := screen.PollEvent(); ev != nil {
+		t.Errorf("Expected screen to be finalized, got event %T", ev)
+	}
+}
@@ -42,6 +42,8 @@ type GUI struct {
 	window       fyne.Window
 	figura       *types.Figure
 	filename     string
+	prepare      func(*types.Figure) error // Ajustes aplicados a cada carregamento
+	cameraFile   string                    // Destino de "Salvar câmera" (vazio = output/<nome>_camera.yaml)
 	renderCfg    renderer.RenderConfig
 	canvasWidth  int
 	canvasHeight int
@@ -57,8 +59,12 @@ type GUI struct {
 	statusLabel *widget.Label
 }
 
-// NewGUI cria uma nova instância do visualizador GUI
-func NewGUI(filename string) *GUI {
+// NewGUI cria uma nova instância do visualizador GUI.
+//
+// A função prepare (opcional) é aplicada à figura a cada carregamento,
+// permitindo que opções de linha de comando sobreponham o YAML também
+// ao recarregar o arquivo.
+func NewGUI(filename string, prepare func(*types.Figure) error) *GUI {
 	myApp := app.New()
 
 	window := myApp.NewWindow("MICRO SISTEMAS - Representação de Figuras 3D")
@@ -74,6 +80,7 @@ func NewGUI(filename string) *GUI {
 		app:          myApp,
 		window:       window,
 		filename:     filename,
+		prepare:      prepare,
 		canvasWidth:  800,
 		canvasHeight: 600,
 		renderCfg:    renderer.DefaultRenderConfig(),
@@ -117,8 +124,9 @@ func (v *GUI) setupUI() {
 	renderBtn := widget.NewButton("🔄 Renderizar", v.renderFigure)
 	reloadBtn := widget.NewButton("📁 Recarregar", v.loadFigure)
 	saveBtn := widget.NewButton("💾 Salvar PNG", v.savePNG)
+	saveCameraBtn := widget.NewButton("📷 Salvar câmera", v.saveCamera)
 
-	buttonBox := container.NewHBox(renderBtn, reloadBtn, saveBtn)
+	buttonBox := container.NewHBox(renderBtn, reloadBtn, saveBtn, saveCameraBtn)
 
 	// Status
 	v.statusLabel = widget.NewLabel("Carregando...")
@@ -154,23 +162,33 @@ func (v *GUI) loadFigure() {
 		return
 	}
 
+	if v.prepare != nil {
+		if err := v.prepare(figura); err != nil {
+			v.statusLabel.SetText(fmt.Sprintf("Erro: %v", err))
+			dialog.ShowError(err, v.window)
+			return
+		}
+	}
+
 	v.figura = figura
 
-	// Configura dimensões do canvas com base na figura
-	v.canvasWidth = 800
-	v.canvasHeight = 600
-	if figura.Render != nil {
-		if figura.Render.CanvasWidth > 0 {
-			v.canvasWidth = figura.Render.CanvasWidth
-		}
-		if figura.Render.CanvasHeight > 0 {
-			v.canvasHeight = figura.Render.CanvasHeight
-		}
+	// Configura dimensões do canvas com base na figura (medidas
+	// inválidas ficam no padrão e são apontadas por ConfigFromFigure)
+	v.canvasWidth, v.canvasHeight, err = renderer.CanvasSize(figura.Render)
+	if err != nil {
+		v.canvasWidth = renderer.DefaultCanvasWidth
+		v.canvasHeight = renderer.DefaultCanvasHeight
 	}
 
 	v.imageCanvas.Image = image.NewRGBA(image.Rect(0, 0, v.canvasWidth, v.canvasHeight))
 	v.imageCanvas.Refresh()
 
+	// Enquadramento automático e órbita são resolvidos uma única vez, para
+	// que os controles mostrem a posição calculada e possam ajustá-la a
+	// partir dali (uma órbita pendente sobreporia o observador editado)
+	aspect := float64(v.canvasWidth) / float64(v.canvasHeight)
+	figura.Camera = renderer.EffectiveCamera(figura.Camera, figura, aspect)
+
 	cfg, err := renderer.ConfigFromFigure(figura)
 	if err != nil {
 		v.statusLabel.SetText(fmt.Sprintf("Configuração inválida: %v", err))
@@ -229,6 +247,7 @@ func (v *GUI) renderFigure() {
 
 	// Cria renderizador
 	r := renderer.New(v.canvasWidth, v.canvasHeight)
+	r.SetSupersampling(v.renderCfg.Antialias)
 	r.SetCamera(v.figura.Camera)
 
 	// Renderiza
@@ -265,10 +284,12 @@ func (v *GUI) savePNG() {
 
 	// Cria novo renderizador para salvar
 	r := renderer.New(v.canvasWidth, v.canvasHeight)
+	r.SetSupersampling(v.renderCfg.Antialias)
+	r.SetBitDepth(v.renderCfg.BitDepth)
 	r.SetCamera(v.figura.Camera)
 	r.RenderFigureWithConfig(v.figura, v.renderCfg)
 
-	err := r.SaveImage(outputFile)
+	err := r.SaveImage(outputFile, renderer.SaveOptions{DPI: v.renderCfg.DPI})
 	if err != nil {
 		dialog.ShowError(err, v.window)
 		return
@@ -277,6 +298,34 @@ func (v *GUI) savePNG() {
 	dialog.ShowInformation("Salvo!", fmt.Sprintf("Imagem salva como %s", outputFile), v.window)
 }
 
+// SetCameraFile define o arquivo gravado pelo botão "Salvar câmera".
+//
+// A extensão escolhe o formato: ".json" grava JSON, as demais YAML.
+func (v *GUI) SetCameraFile(filename string) {
+	v.cameraFile = filename
+}
+
+// saveCamera grava a câmera atual, com os ajustes feitos nos controles,
+// para que o mesmo ponto de vista possa ser usado com --camera-file
+func (v *GUI) saveCamera() {
+	if v.figura == nil {
+		return
+	}
+
+	outputFile := v.cameraFile
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("output/%s_camera.yaml", v.figura.Nome)
+	}
+
+	err := core.SaveCameraFile(outputFile, v.getCameraFromControls())
+	if err != nil {
+		dialog.ShowError(err, v.window)
+		return
+	}
+
+	dialog.ShowInformation("Salvo!", fmt.Sprintf("Câmera salva como %s", outputFile), v.window)
+}
+
 // Run inicia o aplicativo
 func (v *GUI) Run() {
 	v.window.ShowAndRun()
@@ -0,0 +1,31 @@
+10 ! PIRAMIDE (VER PIRAMIDE.YAML), NO ESTILO DO ARTIGO
+20 ! MICRO SISTEMAS, NOVEMBRO DE 1982
+30 OPTION BASE 1
+40 DIM X(5),Y(5),Z(5),U(5),W(5),A(8),B(8)
+50 READ N$,N
+60 FOR I=1 TO N @ READ X(I),Y(I),Z(I) @ NEXT I
+70 READ M
+80 FOR I=1 TO M @ READ A(I),B(I) @ NEXT I
+90 READ V1,V2,V3,R,L1,L2
+100 ! PROJECAO: P'=P-V, X=P'X*R/P'Z, Y=P'Y*R/P'Z
+110 FOR I=1 TO N
+120 U(I)=(X(I)-V1)*R/(Y(I)-V2)
+130 W(I)=(Z(I)-V3)*R/(Y(I)-V2)
+140 NEXT I
+150 GCLEAR @ SCALE -(L1/2),L1/2,-(L2/2),L2/2
+160 FOR I=1 TO M
+170 MOVE U(A(I)),W(A(I)) @ DRAW U(B(I)),W(B(I))
+180 NEXT I
+190 END
+1000 DATA "PIRAMIDE"
+1010 ! PONTOS: N, E X,Y,Z DE CADA UM
+1020 DATA 5
+1030 DATA -1.5,8,-1.5, 1.5,8,-1.5
+1040 DATA 1.5,8,1.5, -1.5,8,1.5
+1050 DATA 0,8,3
+1060 ! LINHAS: M, E OS DOIS PONTOS DE CADA UMA
+1070 DATA 8
+1080 DATA 1,2, 2,3, 3,4, 4,1
+1090 DATA 1,5, 2,5, 3,5, 4,5
+1100 ! CAMERA: V, R, L1 E L2
+1110 DATA 0,0,0, 8, 12.8,9.6
@@ -0,0 +1,171 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Limites do número de amostras de uma curva.
+const (
+	DefaultCurveSamples = 600     // Amostras padrão: curvas lisas mesmo com muitas voltas
+	MinCurveSamples     = 2       // Menor número de amostras (um segmento)
+	MaxCurveSamples     = 100_000 // Maior número de amostras
+)
+
+// CurveSpec descreve uma curva paramétrica: as três coordenadas como
+// expressões em t (ver ParseParametric) e o intervalo de t.
+type CurveSpec struct {
+	Name       string  // Nome da figura (vazio = "curva")
+	X, Y, Z    string  // Expressões das coordenadas
+	TMin, TMax float64 // Intervalo do parâmetro
+	Closed     bool    // O fim emenda no início (o último ponto liga ao primeiro)
+}
+
+// curvePresets são as curvas prontas do comando gen-curve.
+var curvePresets = map[string]CurveSpec{
+	// Figura de Lissajous em três dimensões: frequências 3, 2 e 5
+	"lissajous": {Name: "lissajous", X: "sin(3*t)", Y: "sin(2*t)", Z: "sin(5*t)", TMax: 2 * math.Pi, Closed: true},
+
+	// Hélice de quatro voltas, como uma mola
+	"helice": {Name: "helice", X: "cos(t)", Y: "sin(t)", Z: "t / (4*pi)", TMax: 8 * math.Pi},
+
+	// Nó de trevo: o nó toroidal (2, 3), que dá 2 voltas em torno do
+	// eixo e 3 em torno do tubo
+	"trevo": {Name: "trevo", X: "(2 + cos(3*t)) * cos(2*t)", Y: "(2 + cos(3*t)) * sin(2*t)", Z: "sin(3*t)", TMax: 2 * math.Pi, Closed: true},
+
+	// Nó toroidal (3, 7), com 7 voltas em torno do tubo
+	"no_toroidal": {Name: "no_toroidal", X: "(3 + cos(7*t)) * cos(3*t)", Y: "(3 + cos(7*t)) * sin(3*t)", Z: "sin(7*t)", TMax: 2 * math.Pi, Closed: true},
+}
+
+// curveAliases são os nomes em inglês das curvas prontas.
+var curveAliases = map[string]string{
+	"helix":      "helice",
+	"trefoil":    "trevo",
+	"torus_knot": "no_toroidal",
+}
+
+// CurvePresetNames retorna os nomes das curvas prontas, em ordem
+// alfabética.
+func CurvePresetNames() []string {
+	names := make([]string, 0, len(curvePresets))
+	for name := range curvePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurvePreset retorna uma curva pronta pelo nome ("lissajous", "helice",
+// "trevo" ou "no_toroidal", ou os nomes em inglês "helix", "trefoil" e
+// "torus_knot").
+//
+// Parâmetros:
+//   name: nome da curva
+//
+// Retorna:
+//   CurveSpec: expressões e intervalo da curva
+//   error: erro se o nome for desconhecido
+func CurvePreset(name string) (CurveSpec, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	if alias, ok := curveAliases[key]; ok {
+		key = alias
+	}
+	spec, ok := curvePresets[key]
+	if !ok {
+		return CurveSpec{}, fmt.Errorf("curva desconhecida: %q (use %s)", name, strings.Join(CurvePresetNames(), ", "))
+	}
+	return spec, nil
+}
+
+// Curve gera uma curva paramétrica em arame: amostra as três
+// coordenadas em valores de t igualmente espaçados e liga os pontos
+// consecutivos por linhas retas.
+//
+// Numa curva fechada, o fim do intervalo coincide com o início e não é
+// amostrado; a última linha volta ao primeiro ponto. Onde alguma
+// coordenada não está definida, a amostra fica de fora e a curva se
+// interrompe.
+//
+// Parâmetros:
+//   spec: expressões e intervalo de t
+//   samples: número de pontos da curva
+//
+// Retorna:
+//   *types.Figure: curva em linhas, sem faces
+//   error: erro de sintaxe nas expressões, ou se o intervalo ou o
+//          número de amostras forem inválidos
+func Curve(spec CurveSpec, samples int) (*types.Figure, error) {
+	if !(spec.TMax > spec.TMin) {
+		return nil, fmt.Errorf("intervalo inválido: t de %g a %g (o fim deve ser maior que o início)", spec.TMin, spec.TMax)
+	}
+	minSamples := MinCurveSamples
+	if spec.Closed {
+		minSamples = 3 // Um triângulo, ao menos
+	}
+	if samples < minSamples || samples > MaxCurveSamples {
+		return nil, fmt.Errorf("número de amostras inválido: %d (use de %d a %d)", samples, minSamples, MaxCurveSamples)
+	}
+
+	var coords [3]Parametric
+	for i, src := range []string{spec.X, spec.Y, spec.Z} {
+		f, err := ParseParametric(src)
+		if err != nil {
+			return nil, fmt.Errorf("coordenada %c: %w", 'x'+i, err)
+		}
+		coords[i] = f
+	}
+
+	name := spec.Name
+	if name == "" {
+		name = "curva"
+	}
+	fig := newFigure(name)
+	fig.Render = nil
+
+	// Passo entre amostras: na curva fechada, o fim é o próprio início
+	intervals := samples - 1
+	if spec.Closed {
+		intervals = samples
+	}
+	step := (spec.TMax - spec.TMin) / float64(intervals)
+
+	index := make([]int, samples) // Ponto de cada amostra, ou -1 se indefinida
+	for k := range index {
+		t := spec.TMin + step*float64(k)
+		x, y, z := coords[0](t), coords[1](t), coords[2](t)
+		if !finite(x) || !finite(y) || !finite(z) {
+			index[k] = -1
+			continue
+		}
+		index[k] = len(fig.Pontos)
+		fig.Pontos = append(fig.Pontos, point(x, y, z))
+	}
+
+	for k := 0; k < intervals; k++ {
+		a, b := index[k], index[(k+1)%samples]
+		if a >= 0 && b >= 0 {
+			fig.Linhas = append(fig.Linhas, types.Line{P1: a, P2: b})
+		}
+	}
+	if len(fig.Linhas) == 0 {
+		return nil, fmt.Errorf("a curva não está definida em duas amostras seguidas")
+	}
+	aim(fig)
+	return fig, nil
+}
+
+// finite informa se um valor não é infinito nem NaN.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// aim aponta a câmera para o centro da caixa envolvente da figura, que
+// pode estar longe da origem (gráficos e curvas).
+func aim(fig *types.Figure) {
+	min, max := fig.Bounds()
+	fig.Camera.Target = &types.Point3D{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2, Z: (min.Z + max.Z) / 2}
+}
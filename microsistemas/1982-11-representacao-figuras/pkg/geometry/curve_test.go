@@ -0,0 +1,126 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCurve(t *testing.T) {
+	// Quadrado inscrito no círculo unitário: 4 amostras, curva fechada
+	fig, err := Curve(CurveSpec{X: "cos(t)", Y: "sin(t)", Z: "0", TMax: 2 * math.Pi, Closed: true}, 4)
+	if err != nil {
+		t.Fatalf("Curve failed: %v", err)
+	}
+	if fig.Nome != "curva" {
+		t.Errorf("Expected name curva, got %q", fig.Nome)
+	}
+	if len(fig.Pontos) != 4 || len(fig.Linhas) != 4 {
+		t.Fatalf("Expected 4 points and 4 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	if len(fig.Faces) != 0 || fig.Render != nil {
+		t.Errorf("Expected a wireframe without render options")
+	}
+	if last := fig.Linhas[3]; last.P1 != 3 || last.P2 != 0 {
+		t.Errorf("Expected the last line to close the curve, got %v", last)
+	}
+	if p := fig.Pontos[1]; math.Abs(p.X) > 1e-6 || math.Abs(p.Y-1) > 1e-6 {
+		t.Errorf("Expected the second point at (0, 1), got %+v", p)
+	}
+
+	// A mesma curva aberta amostra o fim do intervalo e não emenda
+	fig, err = Curve(CurveSpec{X: "cos(t)", Y: "sin(t)", Z: "t", TMax: 3}, 4)
+	if err != nil {
+		t.Fatalf("Curve failed: %v", err)
+	}
+	if len(fig.Pontos) != 4 || len(fig.Linhas) != 3 {
+		t.Fatalf("Expected 4 points and 3 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	if z := fig.Pontos[3].Z; z != 3 {
+		t.Errorf("Expected the last point at t=3, got z=%g", z)
+	}
+	if target := fig.Camera.Target; target == nil || target.Z != 1.5 {
+		t.Errorf("Expected the camera aimed at the middle of the curve, got %+v", target)
+	}
+}
+
+func TestCurveUndefined(t *testing.T) {
+	// sqrt(t) não existe para t < 0: a curva começa em t = 0
+	fig, err := Curve(CurveSpec{X: "t", Y: "sqrt(t)", Z: "0", TMin: -2, TMax: 2}, 5)
+	if err != nil {
+		t.Fatalf("Curve failed: %v", err)
+	}
+	if len(fig.Pontos) != 3 || len(fig.Linhas) != 2 {
+		t.Errorf("Expected 3 points and 2 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+
+	// Definida só em amostras alternadas: nenhum trecho
+	if _, err := Curve(CurveSpec{X: "t", Y: "sqrt(-mod(t, 2))", Z: "0", TMax: 3}, 4); err == nil || !strings.Contains(err.Error(), "não está definida") {
+		t.Errorf("Expected undefined curve error, got %v", err)
+	}
+}
+
+func TestCurveInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    CurveSpec
+		samples int
+		message string
+	}{
+		{"reversed t", CurveSpec{X: "t", Y: "t", Z: "t", TMin: 1, TMax: 0}, 10, "intervalo"},
+		{"one sample", CurveSpec{X: "t", Y: "t", Z: "t", TMax: 1}, 1, "amostras"},
+		{"closed segment", CurveSpec{X: "t", Y: "t", Z: "t", TMax: 1, Closed: true}, 2, "amostras"},
+		{"too many samples", CurveSpec{X: "t", Y: "t", Z: "t", TMax: 1}, MaxCurveSamples + 1, "amostras"},
+		{"bad y", CurveSpec{X: "t", Y: "sin(", Z: "t", TMax: 1}, 10, "coordenada y: expressão inválida"},
+		{"surface variable", CurveSpec{X: "t", Y: "t", Z: "x", TMax: 1}, 10, "coordenada z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Curve(tt.spec, tt.samples)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestCurvePresets(t *testing.T) {
+	for _, name := range CurvePresetNames() {
+		t.Run(name, func(t *testing.T) {
+			spec, err := CurvePreset(name)
+			if err != nil {
+				t.Fatalf("CurvePreset failed: %v", err)
+			}
+			fig, err := Curve(spec, DefaultCurveSamples)
+			if err != nil {
+				t.Fatalf("Curve failed: %v", err)
+			}
+			if fig.Nome != name {
+				t.Errorf("Expected name %q, got %q", name, fig.Nome)
+			}
+			lines := DefaultCurveSamples - 1
+			if spec.Closed {
+				lines = DefaultCurveSamples
+			}
+			if len(fig.Pontos) != DefaultCurveSamples || len(fig.Linhas) != lines {
+				t.Errorf("Expected %d points and %d lines, got %d and %d", DefaultCurveSamples, lines, len(fig.Pontos), len(fig.Linhas))
+			}
+		})
+	}
+}
+
+func TestCurvePresetAliases(t *testing.T) {
+	for name, want := range map[string]string{"Helix": "helice", "trefoil": "trevo", "torus-knot": "no_toroidal", "No-Toroidal": "no_toroidal"} {
+		spec, err := CurvePreset(name)
+		if err != nil {
+			t.Fatalf("CurvePreset(%q) failed: %v", name, err)
+		}
+		if spec.Name != want {
+			t.Errorf("CurvePreset(%q): expected %q, got %q", name, want, spec.Name)
+		}
+	}
+	if _, err := CurvePreset("espiral"); err == nil || !strings.Contains(err.Error(), "lissajous") {
+		t.Errorf("Expected unknown curve error listing the presets, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression é uma expressão em x e y já interpretada, pronta para ser
+// avaliada em cada ponto da grade (ver ParseExpression).
+type Expression func(x, y float64) float64
+
+// exprConstants são as constantes aceitas nas expressões.
+var exprConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// exprFunctions são as funções aceitas nas expressões, com o número de
+// argumentos de cada uma.
+var exprFunctions = map[string]struct {
+	args int
+	call func(a []float64) float64
+}{
+	"sin":   {1, func(a []float64) float64 { return math.Sin(a[0]) }},
+	"cos":   {1, func(a []float64) float64 { return math.Cos(a[0]) }},
+	"tan":   {1, func(a []float64) float64 { return math.Tan(a[0]) }},
+	"asin":  {1, func(a []float64) float64 { return math.Asin(a[0]) }},
+	"acos":  {1, func(a []float64) float64 { return math.Acos(a[0]) }},
+	"atan":  {1, func(a []float64) float64 { return math.Atan(a[0]) }},
+	"sinh":  {1, func(a []float64) float64 { return math.Sinh(a[0]) }},
+	"cosh":  {1, func(a []float64) float64 { return math.Cosh(a[0]) }},
+	"tanh":  {1, func(a []float64) float64 { return math.Tanh(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"ln":    {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log10": {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"sign": {1, func(a []float64) float64 {
+		switch {
+		case a[0] > 0:
+			return 1
+		case a[0] < 0:
+			return -1
+		}
+		return 0
+	}},
+	"atan2": {2, func(a []float64) float64 { return math.Atan2(a[0], a[1]) }},
+	"pow":   {2, func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+	"hypot": {2, func(a []float64) float64 { return math.Hypot(a[0], a[1]) }},
+	"mod":   {2, func(a []float64) float64 { return math.Mod(a[0], a[1]) }},
+	"min":   {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max":   {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// ParseExpression interpreta uma expressão matemática em x e y, como
+// "sin(x) * cos(y)" ou "exp(-(x^2 + y^2))".
+//
+// A sintaxe é a das calculadoras e do BASIC: números (com ponto
+// decimal), as variáveis x e y, r (a distância à origem, sqrt(x^2 +
+// y^2)), as constantes pi e e, os operadores + - * / e ^ (ou **, a
+// potência, associativa à direita e mais forte que o sinal: -x^2 é
+// -(x^2)), parênteses e as funções sin, cos, tan, asin, acos, atan,
+// sinh, cosh, tanh, sqrt, abs, exp, log (ou ln), log10, floor, ceil,
+// round, sign, atan2, pow, hypot, mod, min e max. Maiúsculas e
+// minúsculas são equivalentes.
+//
+// Parâmetros:
+//   src: texto da expressão
+//
+// Retorna:
+//   Expression: função que avalia a expressão
+//   error: erro de sintaxe, com a posição (a partir de 1) onde ocorreu
+func ParseExpression(src string) (Expression, error) {
+	return parseExpression(src, surfaceVars, "x, y, r")
+}
+
+// Parametric é uma expressão no parâmetro t já interpretada, uma das
+// coordenadas de uma curva (ver ParseParametric).
+type Parametric func(t float64) float64
+
+// ParseParametric interpreta uma expressão no parâmetro t, como
+// "cos(3*t)", com a mesma sintaxe de ParseExpression.
+//
+// Parâmetros:
+//   src: texto da expressão
+//
+// Retorna:
+//   Parametric: função que avalia a expressão
+//   error: erro de sintaxe, com a posição (a partir de 1) onde ocorreu
+func ParseParametric(src string) (Parametric, error) {
+	expr, err := parseExpression(src, curveVars, "t")
+	if err != nil {
+		return nil, err
+	}
+	return func(t float64) float64 { return expr(t, 0) }, nil
+}
+
+// surfaceVars são as variáveis das superfícies z = f(x, y).
+var surfaceVars = map[string]Expression{
+	"x": func(x, y float64) float64 { return x },
+	"y": func(x, y float64) float64 { return y },
+	"r": func(x, y float64) float64 { return math.Hypot(x, y) },
+}
+
+// curveVars são as variáveis das curvas: o parâmetro t, passado no
+// lugar de x.
+var curveVars = map[string]Expression{
+	"t": func(t, _ float64) float64 { return t },
+}
+
+// parseExpression interpreta uma expressão com as variáveis dadas.
+func parseExpression(src string, vars map[string]Expression, names string) (Expression, error) {
+	p := &exprParser{src: strings.ToLower(src), vars: vars, names: names}
+	p.next()
+	expr, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf("%q inesperado", p.tok)
+	}
+	return expr, nil
+}
+
+// exprParser é um analisador descendente recursivo, um símbolo à
+// frente: sum → product → unary → power → primary.
+type exprParser struct {
+	src   string
+	vars  map[string]Expression // Variáveis aceitas
+	names string                // Nomes das variáveis, para as mensagens
+
+	pos int    // Posição do próximo símbolo
+	tok string // Símbolo atual ("" = fim)
+	at  int    // Posição do símbolo atual, para as mensagens
+}
+
+// next lê o próximo símbolo: um número, um nome ou um operador.
+func (p *exprParser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	p.at = p.pos
+	if p.pos >= len(p.src) {
+		p.tok = ""
+		return
+	}
+
+	start := p.pos
+	c := rune(p.src[p.pos])
+	switch {
+	case unicode.IsDigit(c) || c == '.':
+		for p.pos < len(p.src) && (unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		// Expoente (1e-3), só se seguido de dígitos: "2e" seria 2 e a constante e
+		if p.pos < len(p.src) && p.src[p.pos] == 'e' {
+			end := p.pos + 1
+			if end < len(p.src) && (p.src[end] == '+' || p.src[end] == '-') {
+				end++
+			}
+			if end < len(p.src) && unicode.IsDigit(rune(p.src[end])) {
+				for end < len(p.src) && unicode.IsDigit(rune(p.src[end])) {
+					end++
+				}
+				p.pos = end
+			}
+		}
+	case unicode.IsLetter(c) || c == '_':
+		for p.pos < len(p.src) && (unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '_') {
+			p.pos++
+		}
+	case strings.HasPrefix(p.src[p.pos:], "**"):
+		p.pos += 2
+		p.tok = "^"
+		return
+	default:
+		p.pos++
+	}
+	p.tok = p.src[start:p.pos]
+}
+
+// errorf monta um erro de sintaxe na posição do símbolo atual.
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("expressão inválida na posição %d: %s", p.at+1, fmt.Sprintf(format, args...))
+}
+
+// sum interpreta somas e subtrações.
+func (p *exprParser) sum() (Expression, error) {
+	left, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "+" || p.tok == "-" {
+		op := p.tok
+		p.next()
+		right, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		if op == "+" {
+			left = func(x, y float64) float64 { return a(x, y) + b(x, y) }
+		} else {
+			left = func(x, y float64) float64 { return a(x, y) - b(x, y) }
+		}
+	}
+	return left, nil
+}
+
+// product interpreta multiplicações e divisões.
+func (p *exprParser) product() (Expression, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == "*" || p.tok == "/" {
+		op := p.tok
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		if op == "*" {
+			left = func(x, y float64) float64 { return a(x, y) * b(x, y) }
+		} else {
+			left = func(x, y float64) float64 { return a(x, y) / b(x, y) }
+		}
+	}
+	return left, nil
+}
+
+// unary interpreta o sinal diante de um termo.
+func (p *exprParser) unary() (Expression, error) {
+	switch p.tok {
+	case "-":
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(x, y float64) float64 { return -operand(x, y) }, nil
+	case "+":
+		p.next()
+		return p.unary()
+	}
+	return p.power()
+}
+
+// power interpreta a potência, associativa à direita (2^3^2 = 2^9).
+func (p *exprParser) power() (Expression, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "^" {
+		return base, nil
+	}
+	p.next()
+	exponent, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(x, y float64) float64 { return math.Pow(base(x, y), exponent(x, y)) }, nil
+}
+
+// primary interpreta números, variáveis, constantes, chamadas de função
+// e expressões entre parênteses.
+func (p *exprParser) primary() (Expression, error) {
+	tok := p.tok
+	switch {
+	case tok == "":
+		return nil, p.errorf("expressão incompleta")
+	case tok == "(":
+		p.next()
+		inner, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok != ")" {
+			return nil, p.errorf("falta fechar o parêntese")
+		}
+		p.next()
+		return inner, nil
+	case unicode.IsDigit(rune(tok[0])) || tok[0] == '.':
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, p.errorf("número inválido: %q", tok)
+		}
+		p.next()
+		return func(x, y float64) float64 { return v }, nil
+	}
+
+	if v, ok := p.vars[tok]; ok {
+		p.next()
+		return v, nil
+	}
+	if v, ok := exprConstants[tok]; ok {
+		p.next()
+		return func(x, y float64) float64 { return v }, nil
+	}
+	fn, ok := exprFunctions[tok]
+	if !ok {
+		if unicode.IsLetter(rune(tok[0])) {
+			return nil, p.errorf("nome desconhecido: %q (use %s, pi, e ou uma função como sin)", tok, p.names)
+		}
+		return nil, p.errorf("%q inesperado", tok)
+	}
+
+	p.next()
+	if p.tok != "(" {
+		return nil, p.errorf("falta o parêntese depois de %s", tok)
+	}
+	p.next()
+	var args []Expression
+	for {
+		arg, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.tok != "," {
+			break
+		}
+		p.next()
+	}
+	if p.tok != ")" {
+		return nil, p.errorf("falta fechar o parêntese de %s", tok)
+	}
+	if len(args) != fn.args {
+		return nil, p.errorf("%s recebe %d argumento(s), não %d", tok, fn.args, len(args))
+	}
+	p.next()
+
+	call := fn.call
+	return func(x, y float64) float64 {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			values[i] = arg(x, y)
+		}
+		return call(values)
+	}, nil
+}
@@ -0,0 +1,96 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		src  string
+		x, y float64
+		want float64
+	}{
+		{"1 + 2 * 3", 0, 0, 7},
+		{"(1 + 2) * 3", 0, 0, 9},
+		{"x - y - 1", 5, 2, 2},
+		{"x / y / 2", 8, 2, 2},
+		{"2^3^2", 0, 0, 512},
+		{"2**3", 0, 0, 8},
+		{"-x^2", 3, 0, -9},
+		{"2^-1", 0, 0, 0.5},
+		{"--x", 4, 0, 4},
+		{"1.5e2 + .5", 0, 0, 150.5},
+		{"sin(x) * cos(y)", math.Pi / 2, 0, 1},
+		{"exp(-(x^2 + y^2))", 0, 0, 1},
+		{"r", 3, 4, 5},
+		{"SQRT(X) + Y", 9, 1, 4},
+		{"atan2(y, x)", 1, 1, math.Pi / 4},
+		{"max(x, y) - min(x, y)", 2, 7, 5},
+		{"mod(x, 3)", 7, 0, 1},
+		{"sign(x) * abs(x)", -2, 0, -2},
+		{"pi", 0, 0, math.Pi},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			f, err := ParseExpression(tt.src)
+			if err != nil {
+				t.Fatalf("ParseExpression(%q) failed: %v", tt.src, err)
+			}
+			if got := f(tt.x, tt.y); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("%s at (%g, %g): expected %g, got %g", tt.src, tt.x, tt.y, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		src     string
+		message string
+	}{
+		{"", "incompleta"},
+		{"1 +", "incompleta"},
+		{"sin(x", "posição 6"},
+		{"(x + y", "parêntese"},
+		{"foo(x)", "nome desconhecido"},
+		{"z", "nome desconhecido"},
+		{"sin x", "parêntese depois de sin"},
+		{"atan2(x)", "2 argumento"},
+		{"x y", "\"y\" inesperado"},
+		{"x $ y", "\"$\" inesperado"},
+		{"1..2", "número inválido"},
+		{"2e", "\"e\" inesperado"}, // O número 2 seguido da constante e, sem operador
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := ParseExpression(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestParseParametric(t *testing.T) {
+	f, err := ParseParametric("t^2 + cos(pi * t)")
+	if err != nil {
+		t.Fatalf("ParseParametric failed: %v", err)
+	}
+	if got := f(2); math.Abs(got-5) > 1e-12 {
+		t.Errorf("Expected 5, got %g", got)
+	}
+
+	// As variáveis das superfícies não existem nas curvas
+	for _, src := range []string{"x", "t + y", "r"} {
+		if _, err := ParseParametric(src); err == nil || !strings.Contains(err.Error(), "use t,") {
+			t.Errorf("ParseParametric(%q): expected unknown name error, got %v", src, err)
+		}
+	}
+	if _, err := ParseExpression("t"); err == nil || !strings.Contains(err.Error(), "nome desconhecido") {
+		t.Errorf("Expected t unknown in surfaces, got %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Limites dos L-systems.
+const (
+	MaxLSystemIterations = 16        // Maior número de reescritas
+	MaxLSystemSymbols    = 2_000_000 // Maior cadeia depois das reescritas
+)
+
+// LSystem descreve um sistema de Lindenmayer: a cadeia inicial (axioma)
+// e as regras que reescrevem cada símbolo, aplicadas a todos os símbolos
+// ao mesmo tempo, tantas vezes quantas forem as iterações. A cadeia
+// final comanda uma tartaruga no espaço (ver LSystemFigure).
+type LSystem struct {
+	Name       string          // Nome da figura (vazio = "lsystem")
+	Axiom      string          // Cadeia inicial
+	Rules      map[rune]string // Substituto de cada símbolo (os demais ficam como estão)
+	Angle      float64         // Ângulo dos giros, em graus
+	Iterations int             // Número de reescritas
+	Step       float64         // Comprimento de cada passo (0 = 1)
+}
+
+// lsystemPresets são os L-systems prontos do comando gen-lsystem, quase
+// todos do livro de Prusinkiewicz e Lindenmayer, "The Algorithmic
+// Beauty of Plants" (1990).
+var lsystemPresets = map[string]LSystem{
+	// Floco de neve de Koch: cada lado do triângulo ganha um bico
+	"koch": {Name: "koch", Axiom: "F--F--F", Rules: map[rune]string{'F': "F+F--F+F"}, Angle: 60, Iterations: 4},
+
+	// Curva do dragão, a da dobra repetida de uma tira de papel
+	"dragao": {Name: "dragao", Axiom: "FX", Rules: map[rune]string{'X': "X+YF+", 'Y': "-FX-Y"}, Angle: 90, Iterations: 10},
+
+	// Triângulo de Sierpinski: G também desenha
+	"sierpinski": {Name: "sierpinski", Axiom: "F-G-G", Rules: map[rune]string{'F': "F-G+F+G-F", 'G': "GG"}, Angle: 120, Iterations: 5},
+
+	// Planta no plano, com galhos alternados
+	"planta": {Name: "planta", Axiom: "X", Rules: map[rune]string{'X': "F+[[X]-X]-F[-FX]+X", 'F': "FF"}, Angle: 25, Iterations: 5},
+
+	// Árvore no espaço: cada ramo se abre em três, a 120° um do outro em
+	// volta do galho (5 giros de 24°), e o tronco dobra a cada geração
+	"arvore": {Name: "arvore", Axiom: "A", Rules: map[rune]string{'A': "F[&&A]/////[&&A]/////[&&A]", 'F': "FF"}, Angle: 24, Iterations: 5},
+
+	// Curva de Hilbert no espaço, que passa por todos os nós de um cubo
+	"hilbert": {Name: "hilbert", Axiom: "A", Rules: map[rune]string{
+		'A': "B-F+CFC+F-D&F^D-F+&&CFC+F+B//",
+		'B': "A&F^CFB^F^D^^-F-D^|F^B|FC^F^A//",
+		'C': "|D^|F^B-F+C^F^A&&FA&F^C+F+B^F^D//",
+		'D': "|CFB-F+B|FA&F^A&&FB-F+B|FC//",
+	}, Angle: 90, Iterations: 2},
+}
+
+// lsystemAliases são os nomes em inglês dos L-systems prontos.
+var lsystemAliases = map[string]string{
+	"koch_snowflake": "koch",
+	"dragon":         "dragao",
+	"plant":          "planta",
+	"tree":           "arvore",
+}
+
+// LSystemPresetNames retorna os nomes dos L-systems prontos, em ordem
+// alfabética.
+func LSystemPresetNames() []string {
+	names := make([]string, 0, len(lsystemPresets))
+	for name := range lsystemPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LSystemPreset retorna um L-system pronto pelo nome ("koch", "dragao",
+// "sierpinski", "planta", "arvore" ou "hilbert", ou os nomes em inglês
+// "koch_snowflake", "dragon", "plant" e "tree").
+//
+// Parâmetros:
+//   name: nome do L-system
+//
+// Retorna:
+//   LSystem: axioma, regras, ângulo e iterações (as regras são uma
+//            cópia, que pode ser alterada)
+//   error: erro se o nome for desconhecido
+func LSystemPreset(name string) (LSystem, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	if alias, ok := lsystemAliases[key]; ok {
+		key = alias
+	}
+	sys, ok := lsystemPresets[key]
+	if !ok {
+		return LSystem{}, fmt.Errorf("L-system desconhecido: %q (use %s)", name, strings.Join(LSystemPresetNames(), ", "))
+	}
+	rules := make(map[rune]string, len(sys.Rules))
+	for symbol, body := range sys.Rules {
+		rules[symbol] = body
+	}
+	sys.Rules = rules
+	return sys, nil
+}
+
+// ParseRules converte as regras da opção --rules, pares "símbolo=cadeia"
+// separados por vírgulas, como "F=F+F--F+F" ou "X=F[+X]F[-X]+X,F=FF".
+//
+// Parâmetros:
+//   value: lista de regras
+//
+// Retorna:
+//   map[rune]string: substituto de cada símbolo
+//   error: erro se alguma regra for malformada ou repetir o símbolo
+func ParseRules(value string) (map[rune]string, error) {
+	rules := make(map[rune]string)
+	for _, item := range strings.Split(value, ",") {
+		symbol, body, ok := strings.Cut(strings.TrimSpace(item), "=")
+		symbol, body = strings.TrimSpace(symbol), strings.TrimSpace(body)
+		if !ok || utf8.RuneCountInString(symbol) != 1 {
+			return nil, fmt.Errorf("regra inválida: %q (use símbolo=cadeia, como F=F+F--F+F)", strings.TrimSpace(item))
+		}
+		r, _ := utf8.DecodeRuneInString(symbol)
+		if _, dup := rules[r]; dup {
+			return nil, fmt.Errorf("símbolo %q com mais de uma regra", symbol)
+		}
+		rules[r] = body
+	}
+	return rules, nil
+}
+
+// Expand aplica as regras ao axioma tantas vezes quantas forem as
+// iterações.
+//
+// Retorna:
+//   string: cadeia final
+//   error: erro se o número de iterações for inválido ou a cadeia
+//          passar de MaxLSystemSymbols
+func (s LSystem) Expand() (string, error) {
+	if s.Iterations < 0 || s.Iterations > MaxLSystemIterations {
+		return "", fmt.Errorf("número de iterações inválido: %d (use de 0 a %d)", s.Iterations, MaxLSystemIterations)
+	}
+	current := s.Axiom
+	for i := 0; i < s.Iterations; i++ {
+		var b strings.Builder
+		for _, symbol := range current {
+			if body, ok := s.Rules[symbol]; ok {
+				b.WriteString(body)
+			} else {
+				b.WriteRune(symbol)
+			}
+			if b.Len() > MaxLSystemSymbols {
+				return "", fmt.Errorf("a cadeia passa de %d símbolos na iteração %d; use menos iterações", MaxLSystemSymbols, i+1)
+			}
+		}
+		current = b.String()
+	}
+	return current, nil
+}
+
+// turtle é o estado da tartaruga: a posição e os três eixos que a
+// orientam, a direção em que anda (heading), a sua esquerda (left) e
+// o seu alto (up), com heading × left = up.
+type turtle struct {
+	pos, heading, left, up types.Point3D
+}
+
+// LSystemFigure gera a figura em arame de um L-system: expande a cadeia
+// e a lê como comandos para uma tartaruga, que parte da origem andando
+// para cima (+Z), com a esquerda em -X:
+//
+//   F, G   anda um passo desenhando uma linha
+//   f, g   anda um passo sem desenhar
+//   + -    gira à esquerda ou à direita (em volta do alto)
+//   & ^    inclina para baixo ou para cima (em volta da esquerda)
+//   \ /    rola à esquerda ou à direita (em volta da direção)
+//   |      dá meia-volta
+//   [ ]    guarda e recupera a posição e a orientação (galhos)
+//
+// Os demais símbolos só servem às regras. Sem inclinar nem rolar, o
+// desenho fica no plano XZ, e a câmera o vê de frente; pontos e linhas
+// repetidos são desenhados uma vez só.
+//
+// Parâmetros:
+//   s: L-system a desenhar
+//
+// Retorna:
+//   *types.Figure: desenho em linhas, sem faces
+//   error: erro se as iterações forem inválidas, a cadeia for grande
+//          demais, os colchetes não fecharem ou nada for desenhado
+func LSystemFigure(s LSystem) (*types.Figure, error) {
+	if !(s.Step >= 0) || math.IsInf(s.Step, 0) {
+		return nil, fmt.Errorf("passo inválido: %g (deve ser positivo)", s.Step)
+	}
+	step := s.Step
+	if step == 0 {
+		step = 1
+	}
+	program, err := s.Expand()
+	if err != nil {
+		return nil, err
+	}
+
+	name := s.Name
+	if name == "" {
+		name = "lsystem"
+	}
+	fig := newFigure(name)
+	fig.Render = nil
+
+	// Pontos e linhas já desenhados, para não repetir os galhos que se
+	// sobrepõem
+	index := make(map[types.Point3D]int)
+	drawn := make(map[[2]int]bool)
+	node := func(p types.Point3D) int {
+		p = point(p.X, p.Y, p.Z)
+		if i, ok := index[p]; ok {
+			return i
+		}
+		index[p] = len(fig.Pontos)
+		fig.Pontos = append(fig.Pontos, p)
+		return index[p]
+	}
+
+	angle := s.Angle * math.Pi / 180
+	t := turtle{
+		heading: types.Point3D{Z: 1},
+		left:    types.Point3D{X: -1},
+		up:      types.Point3D{Y: -1},
+	}
+	var stack []turtle
+	for _, symbol := range program {
+		switch symbol {
+		case 'F', 'G', 'f', 'g':
+			from := t.pos
+			t.pos = types.Point3D{X: from.X + t.heading.X*step, Y: from.Y + t.heading.Y*step, Z: from.Z + t.heading.Z*step}
+			if symbol == 'f' || symbol == 'g' {
+				continue
+			}
+			a, b := node(from), node(t.pos)
+			if a == b {
+				continue
+			}
+			if line := [2]int{min(a, b), max(a, b)}; !drawn[line] {
+				drawn[line] = true
+				fig.Linhas = append(fig.Linhas, types.Line{P1: a, P2: b})
+			}
+		case '+':
+			t.heading, t.left = turn(t.heading, t.left, angle)
+		case '-':
+			t.heading, t.left = turn(t.heading, t.left, -angle)
+		case '&':
+			t.heading, t.up = turn(t.heading, t.up, -angle)
+		case '^':
+			t.heading, t.up = turn(t.heading, t.up, angle)
+		case '\\':
+			t.left, t.up = turn(t.left, t.up, angle)
+		case '/':
+			t.left, t.up = turn(t.left, t.up, -angle)
+		case '|':
+			t.heading, t.left = turn(t.heading, t.left, math.Pi)
+		case '[':
+			stack = append(stack, t)
+		case ']':
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("colchete fechado sem ter sido aberto")
+			}
+			t, stack = stack[len(stack)-1], stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%d colchete(s) aberto(s) sem fechar", len(stack))
+	}
+	if len(fig.Linhas) == 0 {
+		return nil, fmt.Errorf("o L-system não desenha nada (use F ou G para andar desenhando)")
+	}
+
+	aim(fig)
+	if min, max := fig.Bounds(); max.Y-min.Y < 1e-9 {
+		fig.Camera.Orbit.Azimuth, fig.Camera.Orbit.Elevation = 0, 0 // Desenho plano: de frente
+	}
+	return fig, nil
+}
+
+// turn gira o par de eixos (a, b) de um ângulo no plano que os contém,
+// levando a em direção a b.
+func turn(a, b types.Point3D, angle float64) (types.Point3D, types.Point3D) {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return types.Point3D{X: a.X*c + b.X*s, Y: a.Y*c + b.Y*s, Z: a.Z*c + b.Z*s},
+		types.Point3D{X: b.X*c - a.X*s, Y: b.Y*c - a.Y*s, Z: b.Z*c - a.Z*s}
+}
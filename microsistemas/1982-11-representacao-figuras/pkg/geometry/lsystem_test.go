@@ -0,0 +1,226 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLSystemExpand(t *testing.T) {
+	// Algas de Lindenmayer: o comprimento segue Fibonacci
+	sys := LSystem{Axiom: "A", Rules: map[rune]string{'A': "AB", 'B': "A"}, Iterations: 5}
+	got, err := sys.Expand()
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if got != "ABAABABAABAAB" {
+		t.Errorf("Expected ABAABABAABAAB, got %q", got)
+	}
+
+	sys.Iterations = 0
+	if got, _ := sys.Expand(); got != "A" {
+		t.Errorf("Expected the axiom with no iterations, got %q", got)
+	}
+}
+
+func TestLSystemExpandLimits(t *testing.T) {
+	sys := LSystem{Axiom: "F", Rules: map[rune]string{'F': "FFFF"}, Iterations: MaxLSystemIterations + 1}
+	if _, err := sys.Expand(); err == nil || !strings.Contains(err.Error(), "iterações") {
+		t.Errorf("Expected iterations error, got %v", err)
+	}
+	sys.Iterations = -1
+	if _, err := sys.Expand(); err == nil || !strings.Contains(err.Error(), "iterações") {
+		t.Errorf("Expected iterations error, got %v", err)
+	}
+	// 4^11 símbolos passam do limite
+	sys.Iterations = 11
+	if _, err := sys.Expand(); err == nil || !strings.Contains(err.Error(), "símbolos") {
+		t.Errorf("Expected length error, got %v", err)
+	}
+}
+
+func TestLSystemFigure(t *testing.T) {
+	// Quadrado: quatro passos com giros de 90°, no plano XZ
+	fig, err := LSystemFigure(LSystem{Axiom: "F+F+F+F", Angle: 90, Step: 2})
+	if err != nil {
+		t.Fatalf("LSystemFigure failed: %v", err)
+	}
+	if fig.Nome != "lsystem" {
+		t.Errorf("Expected name lsystem, got %q", fig.Nome)
+	}
+	if len(fig.Pontos) != 4 || len(fig.Linhas) != 4 {
+		t.Fatalf("Expected 4 points and 4 lines (the path closes), got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	if p := fig.Pontos[1]; p.X != 0 || p.Z != 2 {
+		t.Errorf("Expected the first step up to (0, 0, 2), got %+v", p)
+	}
+	if p := fig.Pontos[2]; p.X != -2 || p.Z != 2 {
+		t.Errorf("Expected + to turn left, to (-2, 0, 2), got %+v", p)
+	}
+	if len(fig.Faces) != 0 || fig.Render != nil {
+		t.Errorf("Expected a wireframe without render options")
+	}
+	if o := fig.Camera.Orbit; o.Azimuth != 0 || o.Elevation != 0 {
+		t.Errorf("Expected a planar drawing seen from the front, got %+v", o)
+	}
+	if target := fig.Camera.Target; target.X != -1 || target.Z != 1 {
+		t.Errorf("Expected the camera aimed at the middle of the drawing, got %+v", target)
+	}
+}
+
+func TestLSystemTurtle(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		x, y, z float64 // Ponto final
+		points  int
+	}{
+		{"move without drawing", "FfF", 0, 0, 3, 4},
+		{"turn right", "F-F", 1, 0, 1, 3},
+		{"pitch down", "F&F", 0, 1, 1, 3},
+		{"pitch up", "F^F", 0, -1, 1, 3},
+		{"roll then turn", "F\\+F", 0, -1, 1, 3},
+		{"turn around", "F+|F", 1, 0, 1, 3},
+		{"branch", "F[+F]F", 0, 0, 2, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fig, err := LSystemFigure(LSystem{Axiom: tt.program, Angle: 90})
+			if err != nil {
+				t.Fatalf("LSystemFigure failed: %v", err)
+			}
+			if len(fig.Pontos) != tt.points {
+				t.Errorf("Expected %d points, got %d", tt.points, len(fig.Pontos))
+			}
+			last := fig.Pontos[fig.Linhas[len(fig.Linhas)-1].P2]
+			if math.Abs(last.X-tt.x) > 1e-9 || math.Abs(last.Y-tt.y) > 1e-9 || math.Abs(last.Z-tt.z) > 1e-9 {
+				t.Errorf("Expected the turtle at (%g, %g, %g), got %+v", tt.x, tt.y, tt.z, last)
+			}
+		})
+	}
+}
+
+func TestLSystemFigureErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sys     LSystem
+		message string
+	}{
+		{"unbalanced close", LSystem{Axiom: "F]F"}, "colchete fechado"},
+		{"unbalanced open", LSystem{Axiom: "[F[F"}, "2 colchete(s)"},
+		{"nothing drawn", LSystem{Axiom: "X", Rules: map[rune]string{'X': "f+X"}, Iterations: 3}, "não desenha"},
+		{"negative step", LSystem{Axiom: "F", Step: -1}, "passo"},
+		{"too many iterations", LSystem{Axiom: "F", Iterations: 100}, "iterações"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LSystemFigure(tt.sys)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestLSystemPresets(t *testing.T) {
+	tests := []struct {
+		name          string
+		points, lines int
+		planar        bool
+	}{
+		{"koch", 768, 768, true},
+		{"dragao", 690, 1024, true},
+		{"sierpinski", 366, 729, true},
+		{"planta", 979, 1000, true},
+		{"arvore", 212, 211, false},
+		{"hilbert", 64, 63, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sys, err := LSystemPreset(tt.name)
+			if err != nil {
+				t.Fatalf("LSystemPreset failed: %v", err)
+			}
+			fig, err := LSystemFigure(sys)
+			if err != nil {
+				t.Fatalf("LSystemFigure failed: %v", err)
+			}
+			if fig.Nome != tt.name {
+				t.Errorf("Expected name %q, got %q", tt.name, fig.Nome)
+			}
+			if len(fig.Pontos) != tt.points || len(fig.Linhas) != tt.lines {
+				t.Errorf("Expected %d points and %d lines, got %d and %d", tt.points, tt.lines, len(fig.Pontos), len(fig.Linhas))
+			}
+			min, max := fig.Bounds()
+			if planar := max.Y-min.Y < 1e-9; planar != tt.planar {
+				t.Errorf("Expected planar=%v, got bounds %+v to %+v", tt.planar, min, max)
+			}
+		})
+	}
+	if got := len(LSystemPresetNames()); got != len(tests) {
+		t.Errorf("Expected %d presets, got %d", len(tests), got)
+	}
+}
+
+func TestLSystemHilbertGrid(t *testing.T) {
+	// A curva de Hilbert passa uma vez por cada nó de uma grade 4×4×4
+	sys, _ := LSystemPreset("hilbert")
+	fig, err := LSystemFigure(sys)
+	if err != nil {
+		t.Fatalf("LSystemFigure failed: %v", err)
+	}
+	min, max := fig.Bounds()
+	if max.X-min.X != 3 || max.Y-min.Y != 3 || max.Z-min.Z != 3 {
+		t.Errorf("Expected a 3×3×3 cube, got %+v to %+v", min, max)
+	}
+	for _, l := range fig.Linhas {
+		a, b := fig.Pontos[l.P1], fig.Pontos[l.P2]
+		if d := math.Abs(a.X-b.X) + math.Abs(a.Y-b.Y) + math.Abs(a.Z-b.Z); d != 1 {
+			t.Errorf("Line %v is not a unit step along an axis: %+v to %+v", l, a, b)
+		}
+	}
+}
+
+func TestLSystemPresetAliases(t *testing.T) {
+	for name, want := range map[string]string{"Dragon": "dragao", "tree": "arvore", "koch-snowflake": "koch", "PLANTA": "planta"} {
+		sys, err := LSystemPreset(name)
+		if err != nil {
+			t.Fatalf("LSystemPreset(%q) failed: %v", name, err)
+		}
+		if sys.Name != want {
+			t.Errorf("LSystemPreset(%q): expected %q, got %q", name, want, sys.Name)
+		}
+	}
+	if _, err := LSystemPreset("samambaia"); err == nil || !strings.Contains(err.Error(), "hilbert") {
+		t.Errorf("Expected unknown L-system error listing the presets, got %v", err)
+	}
+
+	// As regras devolvidas são uma cópia
+	sys, _ := LSystemPreset("koch")
+	sys.Rules['F'] = "F"
+	if again, _ := LSystemPreset("koch"); again.Rules['F'] != "F+F--F+F" {
+		t.Errorf("Expected the preset rules untouched, got %q", again.Rules['F'])
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("X = F[+X]F[-X]+X, F=FF,Y=")
+	if err != nil {
+		t.Fatalf("ParseRules failed: %v", err)
+	}
+	if len(rules) != 3 || rules['X'] != "F[+X]F[-X]+X" || rules['F'] != "FF" || rules['Y'] != "" {
+		t.Errorf("Unexpected rules: %q", rules)
+	}
+
+	for _, value := range []string{"F", "FF=F", "=F", "F=F,,G=G"} {
+		if _, err := ParseRules(value); err == nil || !strings.Contains(err.Error(), "regra inválida") {
+			t.Errorf("ParseRules(%q): expected invalid rule error, got %v", value, err)
+		}
+	}
+	if _, err := ParseRules("F=F,F=FF"); err == nil || !strings.Contains(err.Error(), "mais de uma regra") {
+		t.Errorf("Expected duplicate rule error, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package geometry
+
+import "representacao-figuras/pkg/types"
+
+// MeshStats resume a topologia de uma figura, para diagnosticar malhas
+// importadas de outros programas (ver MeshStatistics).
+type MeshStats struct {
+	Vertices int // Pontos da figura
+	Edges    int // Arestas distintas: linhas e lados das faces
+	Faces    int // Faces da figura
+
+	// Característica de Euler, V - A + F: 2 num sólido fechado sem
+	// furos, como o cubo; 0 num toro
+	Euler int
+
+	// Arestas de uma só face: a borda de uma malha aberta, ou os buracos
+	// de uma que devia ser fechada
+	BoundaryEdges [][2]int
+
+	// Arestas de três ou mais faces, que nenhuma superfície tem: faces
+	// repetidas ou coladas pela aresta, como as páginas de um livro
+	NonManifoldEdges [][2]int
+
+	// Partes desconexas da figura (ver Components)
+	Components int
+
+	// Pontos que nenhuma linha, face ou cota usa (ver IsolatedPoints)
+	IsolatedPoints []int
+}
+
+// Closed informa se as faces formam uma superfície fechada, sem bordas
+// nem arestas de mais de duas faces (uma figura sem faces não é fechada).
+func (s MeshStats) Closed() bool {
+	return s.Faces > 0 && len(s.BoundaryEdges) == 0 && len(s.NonManifoldEdges) == 0
+}
+
+// Manifold informa se cada aresta tem no máximo duas faces, como numa
+// superfície de verdade, aberta ou fechada.
+func (s MeshStats) Manifold() bool {
+	return len(s.NonManifoldEdges) == 0
+}
+
+// MeshStatistics conta os vértices, as arestas e as faces de uma figura,
+// calcula a característica de Euler, aponta as arestas de borda e as
+// que não pertencem a uma superfície (de três ou mais faces), e conta as
+// partes desconexas e os pontos isolados.
+//
+// As arestas são os pares de pontos ligados por uma linha ou por um
+// lado de face, sem sentido: a linha traçada sobre o lado de uma face é
+// a mesma aresta. As linhas e faces com referências a pontos
+// inexistentes são ignoradas, como no desenho, e os lados de uma face
+// que ligam um ponto a ele mesmo não contam.
+//
+// Parâmetros:
+//   fig: figura a examinar (não é alterada)
+//
+// Retorna:
+//   MeshStats: contagens e arestas problemáticas, cada aresta com o
+//              menor índice primeiro, na ordem em que aparecem
+func MeshStatistics(fig *types.Figure) MeshStats {
+	stats := MeshStats{Vertices: len(fig.Pontos), Faces: len(fig.Faces)}
+	var order [][2]int // Arestas na ordem em que aparecem
+	uses := make(map[[2]int]int)
+	add := func(a, b, faces int) {
+		if a == b {
+			return
+		}
+		key := [2]int{min(a, b), max(a, b)}
+		if _, ok := uses[key]; !ok {
+			order = append(order, key)
+		}
+		uses[key] += faces
+	}
+
+	for _, linha := range fig.Linhas {
+		if validPoints(fig, linha.P1, linha.P2) {
+			add(linha.P1, linha.P2, 0)
+		}
+	}
+	for _, face := range fig.Faces {
+		if !validPoints(fig, face.Pontos...) {
+			continue
+		}
+		for i, a := range face.Pontos {
+			add(a, face.Pontos[(i+1)%len(face.Pontos)], 1)
+		}
+	}
+
+	stats.Edges = len(order)
+	stats.Euler = stats.Vertices - stats.Edges + stats.Faces
+	for _, key := range order {
+		switch n := uses[key]; {
+		case n == 1:
+			stats.BoundaryEdges = append(stats.BoundaryEdges, key)
+		case n > 2:
+			stats.NonManifoldEdges = append(stats.NonManifoldEdges, key)
+		}
+	}
+	stats.Components = len(Components(fig))
+	stats.IsolatedPoints = IsolatedPoints(fig)
+	return stats
+}
+
+// Components separa os pontos usados pelas linhas e faces nas partes
+// desconexas da figura: dois pontos estão na mesma parte se um caminho
+// de linhas e faces leva de um ao outro. Uma figura que devia ser uma
+// peça só e tem várias partes costuma ter índices errados no YAML. As
+// linhas e faces com referências a pontos inexistentes são ignoradas, e
+// os pontos isolados (ver IsolatedPoints) não formam partes.
+//
+// Parâmetros:
+//   fig: figura a examinar (não é alterada)
+//
+// Retorna:
+//   [][]int: índices dos pontos de cada parte, em ordem crescente, e as
+//            partes na ordem do seu primeiro ponto
+func Components(fig *types.Figure) [][]int {
+	// Conjuntos disjuntos: parent aponta para um ponto da mesma parte,
+	// até o representante, que aponta para si mesmo
+	parent := make([]int, len(fig.Pontos))
+	used := make([]bool, len(fig.Pontos))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(p int) int {
+		for parent[p] != p {
+			parent[p] = parent[parent[p]] // Encurta o caminho pela metade
+			p = parent[p]
+		}
+		return p
+	}
+	join := func(a, b int) {
+		used[a], used[b] = true, true
+		ra, rb := find(a), find(b)
+		parent[max(ra, rb)] = min(ra, rb) // O representante é o menor ponto
+	}
+
+	for _, linha := range fig.Linhas {
+		if validPoints(fig, linha.P1, linha.P2) {
+			join(linha.P1, linha.P2)
+		}
+	}
+	for _, face := range fig.Faces {
+		if !validPoints(fig, face.Pontos...) {
+			continue
+		}
+		for _, p := range face.Pontos {
+			join(face.Pontos[0], p)
+		}
+	}
+
+	var parts [][]int
+	index := make(map[int]int) // Parte de cada representante
+	for p := range fig.Pontos {
+		if !used[p] {
+			continue
+		}
+		root := find(p)
+		k, ok := index[root]
+		if !ok {
+			k = len(parts)
+			index[root] = k
+			parts = append(parts, nil)
+		}
+		parts[k] = append(parts[k], p)
+	}
+	return parts
+}
+
+// IsolatedPoints lista os pontos que nenhuma linha, face ou cota usa:
+// sobras de uma edição, ou pontos que perderam as linhas por um índice
+// errado. Não aparecem no desenho, a não ser com mostrar_vertices.
+//
+// Parâmetros:
+//   fig: figura a examinar (não é alterada)
+//
+// Retorna:
+//   []int: índices dos pontos isolados, em ordem crescente
+func IsolatedPoints(fig *types.Figure) []int {
+	used := make([]bool, len(fig.Pontos))
+	mark := func(points ...int) {
+		if validPoints(fig, points...) {
+			for _, p := range points {
+				used[p] = true
+			}
+		}
+	}
+	for _, linha := range fig.Linhas {
+		mark(linha.P1, linha.P2)
+	}
+	for _, face := range fig.Faces {
+		mark(face.Pontos...)
+	}
+	for _, medida := range fig.Medidas {
+		for _, name := range []string{medida.De, medida.Ate} {
+			if p, ok := fig.PointIndex(name); ok {
+				mark(p)
+			}
+		}
+	}
+
+	var isolated []int
+	for p, ok := range used {
+		if !ok {
+			isolated = append(isolated, p)
+		}
+	}
+	return isolated
+}
+
+// validPoints informa se todos os índices apontam para pontos da figura;
+// as linhas e faces com uma referência inválida não são desenhadas, e
+// as análises deste arquivo as ignoram.
+func validPoints(fig *types.Figure, points ...int) bool {
+	for _, p := range points {
+		if p < 0 || p >= len(fig.Pontos) {
+			return false
+		}
+	}
+	return true
+}
+
+// FaceArea calcula a área de uma face pela fórmula de Newell, que vale
+// também para polígonos não convexos; uma face com área nula (vértices
+// alinhados ou repetidos) não aparece no desenho.
+//
+// Parâmetros:
+//   points: pontos da figura
+//   face: índices dos vértices da face, todos válidos
+//
+// Retorna:
+//   float64: área da face, na unidade da figura ao quadrado
+func FaceArea(points []types.Point3D, face []int) float64 {
+	_, area := newellNormal(points, face)
+	return area
+}
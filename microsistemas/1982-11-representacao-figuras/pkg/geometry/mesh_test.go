@@ -0,0 +1,115 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestMeshStatistics_Solids(t *testing.T) {
+	box, err := Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	torus, err := Torus(2, 0.5, 12, 8)
+	if err != nil {
+		t.Fatalf("Torus failed: %v", err)
+	}
+
+	tests := []struct {
+		name                   string
+		fig                    *types.Figure
+		vertices, edges, faces int
+		euler                  int
+	}{
+		{"box", box, 8, 12, 6, 2},
+		{"torus", torus, 96, 192, 96, 0},
+		// O arame do cubo tem as mesmas arestas, mas nenhuma face
+		{"wireframe", Wireframe(box), 8, 12, 0, -4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := MeshStatistics(tt.fig)
+			if s.Vertices != tt.vertices || s.Edges != tt.edges || s.Faces != tt.faces || s.Euler != tt.euler {
+				t.Errorf("Expected V=%d E=%d F=%d χ=%d, got %+v", tt.vertices, tt.edges, tt.faces, tt.euler, s)
+			}
+			if len(s.BoundaryEdges) != 0 || !s.Manifold() || s.Closed() != (tt.faces > 0) {
+				t.Errorf("Expected a closed manifold mesh, got %+v", s)
+			}
+		})
+	}
+}
+
+func TestMeshStatistics_Broken(t *testing.T) {
+	box, err := Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	// Sem a tampa: as quatro arestas dela viram borda
+	open := *box
+	open.Faces = box.Faces[1:]
+	s := MeshStatistics(&open)
+	if s.Euler != 1 || len(s.BoundaryEdges) != 4 || s.Closed() || !s.Manifold() {
+		t.Errorf("Expected an open manifold mesh with 4 boundary edges, got %+v", s)
+	}
+	for _, e := range s.BoundaryEdges {
+		if e[0] >= e[1] {
+			t.Errorf("Expected the smaller index first, got %v", e)
+		}
+	}
+
+	// Três triângulos presos pela mesma aresta, uma linha sobre ela, uma
+	// linha solta e referências inválidas, que não contam
+	book := &types.Figure{
+		Pontos: []types.Point3D{{}, {X: 1}, {Y: 1}, {Z: 1}, {Y: -1}, {X: 5}},
+		Linhas: []types.Line{{P1: 1, P2: 0}, {P1: 4, P2: 5}, {P1: 0, P2: 9}},
+		Faces: []types.Face{
+			{Pontos: []int{0, 1, 2}}, {Pontos: []int{1, 0, 3}}, {Pontos: []int{0, 1, 4, 4}},
+		},
+	}
+	s = MeshStatistics(book)
+	if s.Edges != 8 || s.Euler != 1 {
+		t.Errorf("Expected 8 edges and χ=1, got %+v", s)
+	}
+	if want := [][2]int{{0, 1}}; !reflect.DeepEqual(s.NonManifoldEdges, want) || s.Manifold() {
+		t.Errorf("Expected non-manifold edges %v, got %v", want, s.NonManifoldEdges)
+	}
+	if want := [][2]int{{1, 2}, {0, 2}, {0, 3}, {1, 3}, {1, 4}, {0, 4}}; !reflect.DeepEqual(s.BoundaryEdges, want) {
+		t.Errorf("Expected boundary edges %v, got %v", want, s.BoundaryEdges)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	// Um triângulo, uma linha solta ligada de trás para a frente, um ponto
+	// usado só por uma cota e dois pontos que nada usa
+	fig := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 9}, {}, {X: 1}, {Y: 1}, {Z: 1}, {Z: 2, Nome: "M"}, {Z: 3}, {Z: 4, Nome: "N"},
+		},
+		Linhas:  []types.Line{{P1: 6, P2: 4}, {P1: 0, P2: 99}},
+		Faces:   []types.Face{{Pontos: []int{3, 1, 2}}},
+		Medidas: []types.Dimension{{De: "M", Ate: "M"}},
+	}
+
+	want := [][]int{{1, 2, 3}, {4, 6}}
+	if got := Components(fig); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected components %v, got %v", want, got)
+	}
+	if got, want := IsolatedPoints(fig), []int{0, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected isolated points %v, got %v", want, got)
+	}
+
+	s := MeshStatistics(fig)
+	if s.Components != 2 || !reflect.DeepEqual(s.IsolatedPoints, []int{0, 7}) {
+		t.Errorf("Expected 2 components and points 0 and 7 isolated, got %+v", s)
+	}
+
+	box, err := Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	if s := MeshStatistics(box); s.Components != 1 || s.IsolatedPoints != nil {
+		t.Errorf("Expected a single component without isolated points, got %+v", s)
+	}
+}
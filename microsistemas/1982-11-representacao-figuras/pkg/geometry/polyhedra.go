@@ -0,0 +1,335 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// phi é a razão áurea, presente nas coordenadas do dodecaedro, do
+// icosaedro e dos sólidos derivados deles.
+var phi = (1 + math.Sqrt(5)) / 2
+
+// Tetrahedron gera o tetraedro regular: 4 vértices e 4 faces
+// triangulares.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: tetraedro centrado na origem
+//   error: erro se o raio não for positivo
+func Tetrahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("tetraedro", radius, evenSigns(signs(cyclic(1, 1, 1))))
+}
+
+// Hexahedron gera o hexaedro regular (o cubo): 8 vértices e 6 faces
+// quadradas. Ao contrário de Box, é medido pelo raio, como os demais
+// sólidos de Platão.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: cubo centrado na origem
+//   error: erro se o raio não for positivo
+func Hexahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("hexaedro", radius, signs(cyclic(1, 1, 1)))
+}
+
+// Octahedron gera o octaedro regular: 6 vértices, um em cada semieixo,
+// e 8 faces triangulares.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: octaedro centrado na origem
+//   error: erro se o raio não for positivo
+func Octahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("octaedro", radius, signs(cyclic(1, 0, 0)))
+}
+
+// Dodecahedron gera o dodecaedro regular: 20 vértices e 12 faces
+// pentagonais.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: dodecaedro centrado na origem
+//   error: erro se o raio não for positivo
+func Dodecahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("dodecaedro", radius, signs(cyclic(1, 1, 1)), signs(cyclic(0, 1/phi, phi)))
+}
+
+// Icosahedron gera o icosaedro regular: 12 vértices e 20 faces
+// triangulares.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: icosaedro centrado na origem
+//   error: erro se o raio não for positivo
+func Icosahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("icosaedro", radius, signs(cyclic(0, 1, phi)))
+}
+
+// Cuboctahedron gera o cuboctaedro, o sólido de Arquimedes entre o cubo
+// e o octaedro: 12 vértices, 8 triângulos e 6 quadrados.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: cuboctaedro centrado na origem
+//   error: erro se o raio não for positivo
+func Cuboctahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("cuboctaedro", radius, signs(cyclic(1, 1, 0)))
+}
+
+// TruncatedTetrahedron gera o tetraedro truncado, com os cantos do
+// tetraedro cortados: 12 vértices, 4 triângulos e 4 hexágonos.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: tetraedro truncado centrado na origem
+//   error: erro se o raio não for positivo
+func TruncatedTetrahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("tetraedro_truncado", radius, evenSigns(signs(cyclic(3, 1, 1))))
+}
+
+// TruncatedOctahedron gera o octaedro truncado: 24 vértices, 6
+// quadrados e 8 hexágonos; cópias dele preenchem o espaço sem folgas.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: octaedro truncado centrado na origem
+//   error: erro se o raio não for positivo
+func TruncatedOctahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("octaedro_truncado", radius, signs(cyclic(0, 1, 2)), signs(cyclic(0, 2, 1)))
+}
+
+// Icosidodecahedron gera o icosidodecaedro, entre o dodecaedro e o
+// icosaedro: 30 vértices, 20 triângulos e 12 pentágonos.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: icosidodecaedro centrado na origem
+//   error: erro se o raio não for positivo
+func Icosidodecahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("icosidodecaedro", radius, signs(cyclic(0, 0, phi)), signs(cyclic(0.5, phi/2, phi*phi/2)))
+}
+
+// TruncatedIcosahedron gera o icosaedro truncado, a bola de futebol:
+// 60 vértices, 12 pentágonos e 20 hexágonos.
+//
+// Parâmetros:
+//   radius: raio da esfera que passa pelos vértices
+//
+// Retorna:
+//   *types.Figure: icosaedro truncado centrado na origem
+//   error: erro se o raio não for positivo
+func TruncatedIcosahedron(radius float64) (*types.Figure, error) {
+	return polyhedron("icosaedro_truncado", radius,
+		signs(cyclic(0, 1, 3*phi)),
+		signs(cyclic(1, 2+phi, 2*phi)),
+		signs(cyclic(phi, 2, phi*phi*phi)))
+}
+
+// polyhedron monta um poliedro convexo de arestas iguais a partir dos
+// vértices: as arestas ligam os pares de vértices mais próximos, e as
+// faces são encontradas contornando as arestas (ver faceWalk).
+//
+// Parâmetros:
+//   name: nome da figura
+//   radius: raio da esfera que passa pelos vértices
+//   groups: vértices, centrados na origem e à mesma distância dela
+//
+// Retorna:
+//   *types.Figure: poliedro escalado para o raio
+//   error: erro se o raio não for positivo
+func polyhedron(name string, radius float64, groups ...[][3]float64) (*types.Figure, error) {
+	if err := positive("raio", radius); err != nil {
+		return nil, err
+	}
+
+	var vertices [][3]float64
+	for _, group := range groups {
+		vertices = append(vertices, group...)
+	}
+	vertices = unique(vertices)
+
+	// Arestas: os pares à menor distância
+	shortest := math.Inf(1)
+	for i := range vertices {
+		for j := i + 1; j < len(vertices); j++ {
+			shortest = math.Min(shortest, distance(vertices[i], vertices[j]))
+		}
+	}
+	neighbors := make([][]int, len(vertices))
+	for i := range vertices {
+		for j := range vertices {
+			if i != j && distance(vertices[i], vertices[j]) < shortest*(1+1e-9) {
+				neighbors[i] = append(neighbors[i], j)
+			}
+		}
+	}
+
+	f := newFigure(name)
+	scale := radius / math.Sqrt(dot(vertices[0], vertices[0]))
+	for _, v := range vertices {
+		f.Pontos = append(f.Pontos, point(v[0]*scale, v[1]*scale, v[2]*scale))
+	}
+
+	used := make(map[[2]int]bool)
+	for a := range vertices {
+		for _, b := range neighbors[a] {
+			if used[[2]int{a, b}] {
+				continue
+			}
+			face, err := faceWalk(vertices, neighbors, a, b)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for i, p := range face {
+				used[[2]int{p, face[(i+1)%len(face)]}] = true
+			}
+			f.Faces = append(f.Faces, types.Face{Pontos: face})
+		}
+	}
+	return f, nil
+}
+
+// faceWalk contorna a face à esquerda da aresta a→b, vista de fora: a
+// cada vértice, segue pela aresta cujo plano com a anterior deixa todo
+// o sólido atrás de si. O resultado já está em ordem anti-horária.
+func faceWalk(vertices [][3]float64, neighbors [][]int, a, b int) ([]int, error) {
+	face := []int{a}
+	prev, cur := a, b
+	for cur != a {
+		if len(face) > len(vertices) {
+			return nil, fmt.Errorf("face aberta a partir da aresta %d-%d", a, b)
+		}
+		face = append(face, cur)
+		next := -1
+		for _, c := range neighbors[cur] {
+			if c != prev && supporting(vertices, prev, cur, c) {
+				next = c
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("face aberta a partir da aresta %d-%d", a, b)
+		}
+		prev, cur = cur, next
+	}
+	return face, nil
+}
+
+// supporting informa se o plano dos vértices a, b e c, orientado pela
+// regra da mão direita, tem todo o sólido atrás de si: é o plano de uma
+// face, e a ordem a, b, c a percorre no sentido anti-horário visto de
+// fora.
+func supporting(vertices [][3]float64, a, b, c int) bool {
+	pa, pb, pc := vertices[a], vertices[b], vertices[c]
+	normal := cross(sub(pb, pa), sub(pc, pb))
+	if dot(normal, pb) <= 0 {
+		return false
+	}
+	const eps = 1e-9
+	for _, v := range vertices {
+		if dot(normal, sub(v, pb)) > eps {
+			return false
+		}
+	}
+	return true
+}
+
+// cyclic retorna as três permutações cíclicas de (x, y, z): a simetria
+// que os sólidos de Platão e de Arquimedes têm entre os eixos.
+func cyclic(x, y, z float64) [][3]float64 {
+	return [][3]float64{{x, y, z}, {y, z, x}, {z, x, y}}
+}
+
+// signs expande cada ponto com todas as combinações de sinais das
+// coordenadas não nulas.
+func signs(points [][3]float64) [][3]float64 {
+	var out [][3]float64
+	for _, p := range points {
+		for mask := 0; mask < 8; mask++ {
+			q := p
+			skip := false
+			for k := 0; k < 3; k++ {
+				if mask&(1<<k) != 0 {
+					if q[k] == 0 {
+						skip = true // -0 repetiria o ponto
+						break
+					}
+					q[k] = -q[k]
+				}
+			}
+			if !skip {
+				out = append(out, q)
+			}
+		}
+	}
+	return out
+}
+
+// evenSigns mantém só os pontos com um número par de coordenadas
+// negativas: metade dos vértices do cubo forma um tetraedro.
+func evenSigns(points [][3]float64) [][3]float64 {
+	var out [][3]float64
+	for _, p := range points {
+		negative := 0
+		for _, v := range p {
+			if v < 0 {
+				negative++
+			}
+		}
+		if negative%2 == 0 {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// unique remove os pontos repetidos, mantendo a ordem.
+func unique(points [][3]float64) [][3]float64 {
+	seen := make(map[[3]float64]bool)
+	var out [][3]float64
+	for _, p := range points {
+		key := [3]float64{math.Round(p[0] * 1e9), math.Round(p[1] * 1e9), math.Round(p[2] * 1e9)}
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func sub(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func distance(a, b [3]float64) float64 {
+	d := sub(a, b)
+	return math.Sqrt(dot(d, d))
+}
@@ -0,0 +1,112 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+func TestPolyhedra(t *testing.T) {
+	tests := []struct {
+		name   string
+		points int
+		faces  map[int]int // Número de faces por número de lados
+	}{
+		{"tetraedro", 4, map[int]int{3: 4}},
+		{"hexaedro", 8, map[int]int{4: 6}},
+		{"octaedro", 6, map[int]int{3: 8}},
+		{"dodecaedro", 20, map[int]int{5: 12}},
+		{"icosaedro", 12, map[int]int{3: 20}},
+		{"cuboctaedro", 12, map[int]int{3: 8, 4: 6}},
+		{"tetraedro_truncado", 12, map[int]int{3: 4, 6: 4}},
+		{"octaedro_truncado", 24, map[int]int{4: 6, 6: 8}},
+		{"icosidodecaedro", 30, map[int]int{3: 20, 5: 12}},
+		{"icosaedro_truncado", 60, map[int]int{5: 12, 6: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Generate(tt.name, Params{Radius: 2})
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if f.Nome != tt.name {
+				t.Errorf("Expected name %q, got %q", tt.name, f.Nome)
+			}
+			if len(f.Pontos) != tt.points {
+				t.Fatalf("Expected %d points, got %d", tt.points, len(f.Pontos))
+			}
+
+			sides := make(map[int]int)
+			for _, face := range f.Faces {
+				sides[len(face.Pontos)]++
+			}
+			for n, count := range tt.faces {
+				if sides[n] != count {
+					t.Errorf("Expected %d faces with %d sides, got %v", count, n, sides)
+				}
+			}
+			checkMesh(t, f, 2, nil)
+
+			// Vértices na esfera do raio pedido e arestas todas iguais
+			for i, p := range f.Pontos {
+				if r := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z); math.Abs(r-2) > 1e-5 {
+					t.Errorf("Point %d at distance %f, expected 2", i, r)
+				}
+			}
+			edge := -1.0
+			for _, face := range f.Faces {
+				for i, a := range face.Pontos {
+					p, q := f.Pontos[a], f.Pontos[face.Pontos[(i+1)%len(face.Pontos)]]
+					d := math.Sqrt((p.X-q.X)*(p.X-q.X) + (p.Y-q.Y)*(p.Y-q.Y) + (p.Z-q.Z)*(p.Z-q.Z))
+					if edge < 0 {
+						edge = d
+					}
+					if math.Abs(d-edge) > 1e-5 {
+						t.Fatalf("Expected equal edges, got %f and %f", edge, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPolyhedraPlanarFaces(t *testing.T) {
+	f, err := TruncatedIcosahedron(1)
+	if err != nil {
+		t.Fatalf("TruncatedIcosahedron failed: %v", err)
+	}
+	// Todos os vértices de cada face no plano dela
+	for i, face := range f.Faces {
+		normal, c := newell(f, face)
+		length := math.Sqrt(normal.X*normal.X + normal.Y*normal.Y + normal.Z*normal.Z)
+		for _, a := range face.Pontos {
+			p := f.Pontos[a]
+			if d := ((p.X-c.X)*normal.X + (p.Y-c.Y)*normal.Y + (p.Z-c.Z)*normal.Z) / length; math.Abs(d) > 1e-5 {
+				t.Errorf("Face %d: point %d off the plane by %g", i, a, d)
+			}
+		}
+	}
+}
+
+func TestPolyhedraInvalid(t *testing.T) {
+	for _, generate := range []func(float64) (*types.Figure, error){Tetrahedron, Dodecahedron, TruncatedOctahedron} {
+		if _, err := generate(0); err == nil || !strings.Contains(err.Error(), "raio") {
+			t.Errorf("Expected radius error, got %v", err)
+		}
+	}
+}
+
+func TestGeneratePolyhedronAliases(t *testing.T) {
+	for _, name := range []string{"Truncated-Icosahedron", "icosaedro-truncado", "truncated_icosahedron"} {
+		f, err := Generate(name, DefaultParams())
+		if err != nil {
+			t.Fatalf("Generate(%q) failed: %v", name, err)
+		}
+		if f.Nome != "icosaedro_truncado" {
+			t.Errorf("Generate(%q): expected icosaedro_truncado, got %q", name, f.Nome)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+// Package geometry gera figuras prontas para renderizar: sólidos
+// primitivos (caixa, esfera, cilindro, cone e toro) e os poliedros de
+// Platão e de Arquimedes, cujos vértices não se escrevem à mão, e
+// desenhos em arame de gráficos de funções, curvas paramétricas,
+// fractais de L-systems e terrenos, além de figuras aleatórias para
+// testes de desempenho. Simplify reduz as malhas densas demais para um
+// desenho em arame, e MeshStatistics diagnostica a topologia delas.
+//
+// As figuras são centradas na origem, com o eixo Z vertical, faces com
+// os vértices em ordem anti-horária vistos de fora (como exige o
+// descarte de faces traseiras) e uma câmera com enquadramento
+// automático. Gravadas em YAML (ver core.MarshalFigure), podem ser
+// editadas como qualquer outra figura.
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Params são as medidas dos sólidos primitivos; cada sólido usa apenas
+// as que lhe dizem respeito (ver Generate).
+type Params struct {
+	Radius   float64 // Raio da esfera, do cilindro, do cone e do toro (até o centro do tubo)
+	Tube     float64 // Raio do tubo do toro
+	Width    float64 // Largura da caixa (X)
+	Depth    float64 // Profundidade da caixa (Y)
+	Height   float64 // Altura da caixa, do cilindro e do cone (Z)
+	Segments int     // Divisões em volta do eixo vertical
+	Rings    int     // Divisões de polo a polo (esfera) ou em volta do tubo (toro)
+}
+
+// Limites das divisões dos sólidos curvos.
+const (
+	MinSegments = 3   // Menor número de divisões em volta do eixo
+	MinRings    = 2   // Menor número de anéis
+	MaxDivision = 256 // Maior número de divisões ou de anéis
+)
+
+// DefaultParams retorna as medidas padrão: sólidos de cerca de duas
+// unidades, como o cubo dos modelos, com 24 divisões e 12 anéis.
+func DefaultParams() Params {
+	return Params{
+		Radius:   1,
+		Tube:     0.4,
+		Width:    2,
+		Depth:    2,
+		Height:   2,
+		Segments: 24,
+		Rings:    12,
+	}
+}
+
+// generators mapeia os nomes dos sólidos (e os sinônimos em inglês) às
+// funções que os geram.
+var generators = map[string]func(Params) (*types.Figure, error){
+	"caixa":    func(p Params) (*types.Figure, error) { return Box(p.Width, p.Depth, p.Height) },
+	"box":      func(p Params) (*types.Figure, error) { return Box(p.Width, p.Depth, p.Height) },
+	"esfera":   func(p Params) (*types.Figure, error) { return Sphere(p.Radius, p.Segments, p.Rings) },
+	"sphere":   func(p Params) (*types.Figure, error) { return Sphere(p.Radius, p.Segments, p.Rings) },
+	"cilindro": func(p Params) (*types.Figure, error) { return Cylinder(p.Radius, p.Height, p.Segments) },
+	"cylinder": func(p Params) (*types.Figure, error) { return Cylinder(p.Radius, p.Height, p.Segments) },
+	"cone":     func(p Params) (*types.Figure, error) { return Cone(p.Radius, p.Height, p.Segments) },
+	"toro":     func(p Params) (*types.Figure, error) { return Torus(p.Radius, p.Tube, p.Segments, p.Rings) },
+	"torus":    func(p Params) (*types.Figure, error) { return Torus(p.Radius, p.Tube, p.Segments, p.Rings) },
+
+	// Sólidos de Platão e de Arquimedes, medidos pelo raio
+	"tetraedro":             radial(Tetrahedron),
+	"tetrahedron":           radial(Tetrahedron),
+	"hexaedro":              radial(Hexahedron),
+	"cube":                  radial(Hexahedron),
+	"octaedro":              radial(Octahedron),
+	"octahedron":            radial(Octahedron),
+	"dodecaedro":            radial(Dodecahedron),
+	"dodecahedron":          radial(Dodecahedron),
+	"icosaedro":             radial(Icosahedron),
+	"icosahedron":           radial(Icosahedron),
+	"cuboctaedro":           radial(Cuboctahedron),
+	"cuboctahedron":         radial(Cuboctahedron),
+	"tetraedro_truncado":    radial(TruncatedTetrahedron),
+	"truncated_tetrahedron": radial(TruncatedTetrahedron),
+	"octaedro_truncado":     radial(TruncatedOctahedron),
+	"truncated_octahedron":  radial(TruncatedOctahedron),
+	"icosidodecaedro":       radial(Icosidodecahedron),
+	"icosidodecahedron":     radial(Icosidodecahedron),
+	"icosaedro_truncado":    radial(TruncatedIcosahedron),
+	"truncated_icosahedron": radial(TruncatedIcosahedron),
+}
+
+// radial adapta os geradores medidos só pelo raio ao mapa generators.
+func radial(generate func(radius float64) (*types.Figure, error)) func(Params) (*types.Figure, error) {
+	return func(p Params) (*types.Figure, error) { return generate(p.Radius) }
+}
+
+// Names retorna os nomes aceitos por Generate, em ordem alfabética.
+func Names() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generate gera um sólido pelo nome ("caixa", "esfera", "cilindro",
+// "cone", "toro", um dos sólidos de Platão, como "dodecaedro", ou de
+// Arquimedes, como "icosaedro_truncado", ou os nomes em inglês), sem
+// distinção de maiúsculas e com hífen ou sublinhado entre as palavras.
+//
+// Parâmetros:
+//   name: nome do sólido
+//   p: medidas (ver DefaultParams)
+//
+// Retorna:
+//   *types.Figure: figura gerada
+//   error: erro se o nome for desconhecido ou alguma medida for inválida
+func Generate(name string, p Params) (*types.Figure, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	generate, ok := generators[key]
+	if !ok {
+		return nil, fmt.Errorf("sólido desconhecido: %q (use %s)", name, strings.Join(Names(), ", "))
+	}
+	return generate(p)
+}
+
+// Box gera uma caixa (paralelepípedo) com seis faces retangulares. Os
+// vértices têm os nomes do cubo dos modelos: A a D na base, E a H no
+// topo.
+//
+// Parâmetros:
+//   width, depth, height: medidas em X, Y e Z
+//
+// Retorna:
+//   *types.Figure: caixa centrada na origem
+//   error: erro se alguma medida não for positiva
+func Box(width, depth, height float64) (*types.Figure, error) {
+	if err := positive("largura", width, "profundidade", depth, "altura", height); err != nil {
+		return nil, err
+	}
+	x, y, z := width/2, depth/2, height/2
+
+	f := newFigure("caixa")
+	for i, p := range [][3]float64{
+		{-x, -y, -z}, {x, -y, -z}, {x, y, -z}, {-x, y, -z},
+		{-x, -y, z}, {x, -y, z}, {x, y, z}, {-x, y, z},
+	} {
+		f.Pontos = append(f.Pontos, point(p[0], p[1], p[2]))
+		f.Pontos[i].Nome = string(rune('A' + i))
+	}
+	f.Faces = []types.Face{
+		{Pontos: []int{3, 2, 1, 0}}, // Base
+		{Pontos: []int{4, 5, 6, 7}}, // Topo
+		{Pontos: []int{0, 1, 5, 4}}, // Frente (-Y)
+		{Pontos: []int{1, 2, 6, 5}}, // Direita (+X)
+		{Pontos: []int{2, 3, 7, 6}}, // Fundo (+Y)
+		{Pontos: []int{3, 0, 4, 7}}, // Esquerda (-X)
+	}
+	return f, nil
+}
+
+// Sphere gera uma esfera em meridianos e paralelos, como um globo: um
+// vértice em cada polo, rings-1 paralelos de segments vértices,
+// triângulos em volta dos polos e quadriláteros entre os paralelos.
+//
+// Parâmetros:
+//   radius: raio
+//   segments: número de meridianos
+//   rings: número de faixas de polo a polo
+//
+// Retorna:
+//   *types.Figure: esfera centrada na origem
+//   error: erro se o raio ou as divisões forem inválidos
+func Sphere(radius float64, segments, rings int) (*types.Figure, error) {
+	if err := positive("raio", radius); err != nil {
+		return nil, err
+	}
+	if err := divisions(segments, rings, MinRings); err != nil {
+		return nil, err
+	}
+
+	f := newFigure("esfera")
+	f.Pontos = append(f.Pontos, point(0, 0, -radius)) // Polo sul
+	for ring := 1; ring < rings; ring++ {
+		phi := math.Pi * float64(ring) / float64(rings) // Do polo sul ao norte
+		z, r := -radius*math.Cos(phi), radius*math.Sin(phi)
+		f.Pontos = append(f.Pontos, circle(r, z, segments)...)
+	}
+	f.Pontos = append(f.Pontos, point(0, 0, radius)) // Polo norte
+
+	north := len(f.Pontos) - 1
+	at := func(ring, s int) int { return 1 + (ring-1)*segments + s%segments }
+	for s := 0; s < segments; s++ {
+		f.Faces = append(f.Faces, types.Face{Pontos: []int{0, at(1, s+1), at(1, s)}})
+		for ring := 1; ring < rings-1; ring++ {
+			f.Faces = append(f.Faces, types.Face{Pontos: []int{at(ring, s), at(ring, s+1), at(ring+1, s+1), at(ring+1, s)}})
+		}
+		f.Faces = append(f.Faces, types.Face{Pontos: []int{at(rings-1, s), at(rings-1, s+1), north}})
+	}
+	smooth(f)
+	return f, nil
+}
+
+// Cylinder gera um cilindro de eixo vertical: dois círculos de segments
+// vértices, faces laterais retangulares e as duas tampas.
+//
+// Parâmetros:
+//   radius: raio
+//   height: altura
+//   segments: número de divisões do círculo
+//
+// Retorna:
+//   *types.Figure: cilindro centrado na origem
+//   error: erro se alguma medida for inválida
+func Cylinder(radius, height float64, segments int) (*types.Figure, error) {
+	if err := positive("raio", radius, "altura", height); err != nil {
+		return nil, err
+	}
+	if err := divisions(segments, MinRings, MinRings); err != nil {
+		return nil, err
+	}
+
+	f := newFigure("cilindro")
+	f.Pontos = append(circle(radius, -height/2, segments), circle(radius, height/2, segments)...)
+
+	base, top := make([]int, segments), make([]int, segments)
+	for s := 0; s < segments; s++ {
+		next := (s + 1) % segments
+		f.Faces = append(f.Faces, types.Face{Pontos: []int{s, next, segments + next, segments + s}})
+		base[segments-1-s] = s // Tampa de baixo, vista de fora: sentido horário visto de cima
+		top[s] = segments + s
+	}
+	f.Faces = append(f.Faces, types.Face{Pontos: base}, types.Face{Pontos: top})
+	smooth(f)
+	return f, nil
+}
+
+// Cone gera um cone de eixo vertical: a base com segments vértices, o
+// vértice no topo e faces laterais triangulares.
+//
+// Parâmetros:
+//   radius: raio da base
+//   height: altura
+//   segments: número de divisões da base
+//
+// Retorna:
+//   *types.Figure: cone centrado na origem (a meia altura)
+//   error: erro se alguma medida for inválida
+func Cone(radius, height float64, segments int) (*types.Figure, error) {
+	if err := positive("raio", radius, "altura", height); err != nil {
+		return nil, err
+	}
+	if err := divisions(segments, MinRings, MinRings); err != nil {
+		return nil, err
+	}
+
+	f := newFigure("cone")
+	f.Pontos = append(circle(radius, -height/2, segments), point(0, 0, height/2))
+
+	apex := segments
+	base := make([]int, segments)
+	for s := 0; s < segments; s++ {
+		f.Faces = append(f.Faces, types.Face{Pontos: []int{s, (s + 1) % segments, apex}})
+		base[segments-1-s] = s
+	}
+	f.Faces = append(f.Faces, types.Face{Pontos: base})
+	smooth(f)
+	return f, nil
+}
+
+// Torus gera um toro (uma rosca) em volta do eixo vertical: segments
+// círculos do tubo, de rings vértices cada, ligados por quadriláteros.
+//
+// Parâmetros:
+//   radius: distância do eixo ao centro do tubo
+//   tube: raio do tubo (menor que radius)
+//   segments: número de divisões em volta do eixo
+//   rings: número de divisões em volta do tubo
+//
+// Retorna:
+//   *types.Figure: toro centrado na origem
+//   error: erro se alguma medida for inválida
+func Torus(radius, tube float64, segments, rings int) (*types.Figure, error) {
+	if err := positive("raio", radius, "raio do tubo", tube); err != nil {
+		return nil, err
+	}
+	if tube >= radius {
+		return nil, fmt.Errorf("o raio do tubo (%g) deve ser menor que o raio do toro (%g)", tube, radius)
+	}
+	if err := divisions(segments, rings, MinSegments); err != nil { // O tubo é um polígono: ao menos 3 lados
+		return nil, err
+	}
+
+	f := newFigure("toro")
+	for s := 0; s < segments; s++ {
+		theta := 2 * math.Pi * float64(s) / float64(segments)
+		for k := 0; k < rings; k++ {
+			phi := 2 * math.Pi * float64(k) / float64(rings)
+			r := radius + tube*math.Cos(phi)
+			f.Pontos = append(f.Pontos, point(r*math.Cos(theta), r*math.Sin(theta), tube*math.Sin(phi)))
+		}
+	}
+
+	at := func(s, k int) int { return (s%segments)*rings + k%rings }
+	for s := 0; s < segments; s++ {
+		for k := 0; k < rings; k++ {
+			f.Faces = append(f.Faces, types.Face{Pontos: []int{at(s, k), at(s+1, k), at(s+1, k+1), at(s, k+1)}})
+		}
+	}
+	smooth(f)
+	return f, nil
+}
+
+// Wireframe converte as faces de uma figura em linhas, uma por aresta
+// (as compartilhadas por duas faces aparecem uma só vez), para o
+// desenho em arame do artigo, sem preenchimento.
+//
+// Parâmetros:
+//   f: figura com faces (não é alterada)
+//
+// Retorna:
+//   *types.Figure: cópia da figura com as linhas e sem faces
+func Wireframe(f *types.Figure) *types.Figure {
+	wire := *f
+	wire.Linhas = nil
+	if !f.EdgesFromFaces {
+		wire.Linhas = append(wire.Linhas, f.Linhas...)
+	}
+	wire.Linhas = append(wire.Linhas, f.FaceEdges()...)
+	wire.Faces = nil
+	wire.Render = nil
+	wire.EdgesFromFaces = false
+	return &wire
+}
+
+// newFigure cria uma figura vazia com a câmera dos sólidos gerados:
+// olhando para a origem de 30° à direita e 25° acima, com enquadramento
+// automático, e faces claras iluminadas.
+func newFigure(name string) *types.Figure {
+	ambient := 0.5
+	culling := true
+	return &types.Figure{
+		Nome:   name,
+		Linhas: []types.Line{},
+		Camera: types.Camera{
+			Target: &types.Point3D{},
+			Orbit:  &types.Orbit{Azimuth: 30, Elevation: 25, Radius: 10},
+			Auto:   true,
+		},
+		Render: &types.RenderSettings{
+			FaceColor:       "#e8e8e8",
+			BackfaceCulling: &culling,
+			Light:           &types.Light{Ambient: &ambient},
+		},
+	}
+}
+
+// smooth desenha só o contorno das superfícies curvas, como no modelo
+// do cilindro: as arestas entre faces vizinhas quase paralelas somem.
+// O vinco de 40° deixa de fora as do tubo do toro com 12 anéis (30°),
+// mas não as bordas das tampas (90°).
+func smooth(f *types.Figure) {
+	f.Render.Edges = "contorno"
+	f.Render.CreaseAngle = 40
+}
+
+// circle gera segments pontos num círculo horizontal de raio r na
+// altura z, no sentido anti-horário visto de cima a partir de +X.
+func circle(r, z float64, segments int) []types.Point3D {
+	points := make([]types.Point3D, segments)
+	for s := range points {
+		theta := 2 * math.Pi * float64(s) / float64(segments)
+		points[s] = point(r*math.Cos(theta), r*math.Sin(theta), z)
+	}
+	return points
+}
+
+// point cria um ponto com as coordenadas arredondadas a seis casas, para
+// que os arquivos gerados fiquem legíveis (sem -0 nem 1e-17).
+func point(x, y, z float64) types.Point3D {
+	round := func(v float64) float64 { return math.Round(v*1e6)/1e6 + 0 }
+	return types.Point3D{X: round(x), Y: round(y), Z: round(z)}
+}
+
+// positive verifica pares nome/valor de medidas que devem ser positivas.
+func positive(pairs ...any) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if v := pairs[i+1].(float64); !(v > 0) {
+			return fmt.Errorf("%s inválido: %g (deve ser positivo)", pairs[i], v)
+		}
+	}
+	return nil
+}
+
+// divisions verifica o número de divisões em volta do eixo e de anéis
+// (ao menos minRings).
+func divisions(segments, rings, minRings int) error {
+	if segments < MinSegments || segments > MaxDivision {
+		return fmt.Errorf("número de divisões inválido: %d (use de %d a %d)", segments, MinSegments, MaxDivision)
+	}
+	if rings < minRings || rings > MaxDivision {
+		return fmt.Errorf("número de anéis inválido: %d (use de %d a %d)", rings, minRings, MaxDivision)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// newell calcula a normal de uma face pelo método de Newell, como o
+// renderizador, e o centroide dos vértices.
+func newell(f *types.Figure, face types.Face) (normal, centroid types.Point3D) {
+	n := float64(len(face.Pontos))
+	for i, a := range face.Pontos {
+		p, q := f.Pontos[a], f.Pontos[face.Pontos[(i+1)%len(face.Pontos)]]
+		normal.X += (p.Y - q.Y) * (p.Z + q.Z)
+		normal.Y += (p.Z - q.Z) * (p.X + q.X)
+		normal.Z += (p.X - q.X) * (p.Y + q.Y)
+		centroid.X += p.X / n
+		centroid.Y += p.Y / n
+		centroid.Z += p.Z / n
+	}
+	return normal, centroid
+}
+
+func TestPrimitives(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() (*types.Figure, error)
+		points   int
+		faces    int
+		euler    int // V - E + F: 2 nos sólidos fechados, 0 no toro
+		center   func(c types.Point3D) types.Point3D
+	}{
+		{"box", func() (*types.Figure, error) { return Box(2, 3, 4) }, 8, 6, 2, nil},
+		{"sphere", func() (*types.Figure, error) { return Sphere(1, 8, 4) }, 2 + 8*3, 8 * 4, 2, nil},
+		{"sphere two rings", func() (*types.Figure, error) { return Sphere(1, 3, 2) }, 5, 6, 2, nil},
+		{"cylinder", func() (*types.Figure, error) { return Cylinder(1, 2, 12) }, 24, 14, 2, nil},
+		{"cone", func() (*types.Figure, error) { return Cone(1, 2, 10) }, 11, 11, 2, nil},
+		{"torus", func() (*types.Figure, error) { return Torus(2, 0.5, 12, 6) }, 72, 72, 0,
+			// A normal do toro aponta para fora do tubo: do ponto mais
+			// próximo do círculo central
+			func(c types.Point3D) types.Point3D {
+				r := math.Hypot(c.X, c.Y)
+				return types.Point3D{X: 2 * c.X / r, Y: 2 * c.Y / r}
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.generate()
+			if err != nil {
+				t.Fatalf("generate failed: %v", err)
+			}
+			if len(f.Pontos) != tt.points || len(f.Faces) != tt.faces {
+				t.Fatalf("Expected %d points and %d faces, got %d and %d", tt.points, tt.faces, len(f.Pontos), len(f.Faces))
+			}
+			checkMesh(t, f, tt.euler, tt.center)
+		})
+	}
+}
+
+// checkMesh verifica que a malha é fechada, com a característica de
+// Euler esperada, e que as faces apontam para fora (a partir do centro
+// dado para cada centroide de face; nil = a origem).
+func checkMesh(t *testing.T, f *types.Figure, euler int, center func(c types.Point3D) types.Point3D) {
+	t.Helper()
+
+	// Cada aresta orientada aparece uma só vez, e a oposta também:
+	// malha fechada, com todas as faces no mesmo sentido
+	edges := make(map[[2]int]int)
+	for _, face := range f.Faces {
+		for i, a := range face.Pontos {
+			edges[[2]int{a, face.Pontos[(i+1)%len(face.Pontos)]}]++
+		}
+	}
+	for edge, count := range edges {
+		if count != 1 || edges[[2]int{edge[1], edge[0]}] != 1 {
+			t.Fatalf("Edge %v used %d times, reverse %d times", edge, count, edges[[2]int{edge[1], edge[0]}])
+		}
+	}
+	if got := len(f.Pontos) - len(edges)/2 + len(f.Faces); got != euler {
+		t.Errorf("Expected Euler characteristic %d, got %d", euler, got)
+	}
+
+	// Anti-horária vista de fora: a normal aponta para fora
+	for i, face := range f.Faces {
+		normal, c := newell(f, face)
+		origin := types.Point3D{}
+		if center != nil {
+			origin = center(c)
+		}
+		out := (c.X-origin.X)*normal.X + (c.Y-origin.Y)*normal.Y + (c.Z-origin.Z)*normal.Z
+		if out <= 0 {
+			t.Errorf("Face %d %v points inward (normal %+v)", i, face.Pontos, normal)
+		}
+	}
+}
+
+func TestPrimitivesCentered(t *testing.T) {
+	for _, name := range []string{"caixa", "esfera", "cilindro", "cone", "toro"} {
+		f, err := Generate(name, DefaultParams())
+		if err != nil {
+			t.Fatalf("Generate(%q) failed: %v", name, err)
+		}
+		if f.Nome != name {
+			t.Errorf("Expected name %q, got %q", name, f.Nome)
+		}
+		min, max := f.Bounds()
+		for _, v := range []float64{min.X + max.X, min.Y + max.Y, min.Z + max.Z} {
+			if math.Abs(v) > 1e-6 {
+				t.Errorf("%s: expected bounds centered at origin, got %+v %+v", name, min, max)
+				break
+			}
+		}
+		if !f.Camera.Auto || f.Camera.Orbit == nil || f.Camera.Target == nil {
+			t.Errorf("%s: expected an auto-framed orbit camera, got %+v", name, f.Camera)
+		}
+	}
+}
+
+func TestSphereRadius(t *testing.T) {
+	f, err := Sphere(2.5, 16, 8)
+	if err != nil {
+		t.Fatalf("Sphere failed: %v", err)
+	}
+	for i, p := range f.Pontos {
+		if r := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z); math.Abs(r-2.5) > 1e-5 {
+			t.Errorf("Point %d at distance %f, expected 2.5", i, r)
+		}
+		if p.X == 0 && math.Signbit(p.X) {
+			t.Errorf("Point %d has negative zero", i)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	f, err := Generate("Sphere", Params{Radius: 1, Segments: 6, Rings: 3})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if f.Nome != "esfera" || len(f.Pontos) != 2+6*2 {
+		t.Errorf("Expected a 6×3 sphere, got %q with %d points", f.Nome, len(f.Pontos))
+	}
+
+	if _, err := Generate("hipercubo", DefaultParams()); err == nil || !strings.Contains(err.Error(), "esfera") {
+		t.Errorf("Expected unknown solid error listing the names, got %v", err)
+	}
+}
+
+func TestPrimitivesInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() (*types.Figure, error)
+		message  string
+	}{
+		{"box zero width", func() (*types.Figure, error) { return Box(0, 1, 1) }, "largura"},
+		{"sphere negative radius", func() (*types.Figure, error) { return Sphere(-1, 8, 4) }, "raio"},
+		{"sphere few segments", func() (*types.Figure, error) { return Sphere(1, 2, 4) }, "divisões"},
+		{"sphere one ring", func() (*types.Figure, error) { return Sphere(1, 8, 1) }, "anéis"},
+		{"cylinder too many segments", func() (*types.Figure, error) { return Cylinder(1, 1, MaxDivision+1) }, "divisões"},
+		{"cone zero height", func() (*types.Figure, error) { return Cone(1, 0, 8) }, "altura"},
+		{"torus fat tube", func() (*types.Figure, error) { return Torus(1, 1, 8, 8) }, "tubo"},
+		{"torus two rings", func() (*types.Figure, error) { return Torus(2, 1, 8, 2) }, "anéis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.generate()
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestWireframe(t *testing.T) {
+	box, err := Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	wire := Wireframe(box)
+
+	if len(wire.Linhas) != 12 {
+		t.Errorf("Expected 12 edges, got %d", len(wire.Linhas))
+	}
+	if len(wire.Faces) != 0 || wire.Render != nil {
+		t.Errorf("Expected no faces nor render settings, got %d faces and %+v", len(wire.Faces), wire.Render)
+	}
+	// A figura original não muda
+	if len(box.Faces) != 6 || len(box.Linhas) != 0 {
+		t.Errorf("Expected the box untouched, got %d faces and %d lines", len(box.Faces), len(box.Linhas))
+	}
+}
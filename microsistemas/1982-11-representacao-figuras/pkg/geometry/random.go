@@ -0,0 +1,151 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"representacao-figuras/pkg/types"
+)
+
+// Limites das figuras aleatórias, os mesmos da leitura de figuras (ver
+// core.DefaultLoadLimits), para que o arquivo gerado possa ser aberto.
+const (
+	MaxRandomPoints = 1_000_000 // Maior número de pontos
+	MaxRandomLines  = 4_000_000 // Maior número de linhas
+	MaxRandomFaces  = 1_000_000 // Maior número de faces
+)
+
+// RandomParams descreve uma figura aleatória, para medir o desempenho do
+// renderizador e procurar falhas com figuras maiores e mais bagunçadas
+// que as dos modelos.
+type RandomParams struct {
+	Points   int     // Número de pontos
+	Lines    int     // Número de linhas
+	Faces    int     // Número de faces triangulares
+	Clusters int     // Aglomerados de pontos (0 = espalhados por igual)
+	Size     float64 // Aresta do cubo, centrado na origem, em que os pontos caem
+	Seed     int64   // Semente: a mesma semente gera a mesma figura
+}
+
+// DefaultRandomParams retorna a figura aleatória padrão: mil pontos e
+// duas mil linhas num cubo de 10 unidades, com a semente 1.
+func DefaultRandomParams() RandomParams {
+	return RandomParams{Points: 1000, Lines: 2000, Size: 10, Seed: 1}
+}
+
+// Random gera uma figura aleatória: pontos sorteados no cubo ligados
+// por linhas e faces triangulares entre pontos sorteados.
+//
+// Com aglomerados, os pontos se juntam em nuvens em volta de centros
+// sorteados, e cada linha ou face liga pontos da mesma nuvem, como nas
+// malhas de verdade, em que as arestas são curtas; sem eles, as linhas
+// atravessam o cubo de um lado a outro. Nenhuma linha liga um ponto a
+// ele mesmo, mas podem sair linhas repetidas e faces que se cruzam,
+// casos que o renderizador deve aguentar.
+//
+// Parâmetros:
+//   p: quantidades, tamanho e semente
+//
+// Retorna:
+//   *types.Figure: figura aleatória
+//   error: erro se alguma quantidade ou o tamanho forem inválidos
+func Random(p RandomParams) (*types.Figure, error) {
+	switch {
+	case p.Points < 3 || p.Points > MaxRandomPoints:
+		return nil, fmt.Errorf("número de pontos inválido: %d (use de 3 a %d)", p.Points, MaxRandomPoints)
+	case p.Lines < 0 || p.Lines > MaxRandomLines:
+		return nil, fmt.Errorf("número de linhas inválido: %d (use de 0 a %d)", p.Lines, MaxRandomLines)
+	case p.Faces < 0 || p.Faces > MaxRandomFaces:
+		return nil, fmt.Errorf("número de faces inválido: %d (use de 0 a %d)", p.Faces, MaxRandomFaces)
+	case p.Lines == 0 && p.Faces == 0:
+		return nil, fmt.Errorf("a figura precisa de linhas ou faces")
+	case p.Clusters < 0 || p.Clusters > p.Points/3:
+		return nil, fmt.Errorf("número de aglomerados inválido: %d (use de 0 a %d, ao menos 3 pontos em cada)", p.Clusters, p.Points/3)
+	case !(p.Size > 0) || math.IsInf(p.Size, 0):
+		return nil, fmt.Errorf("tamanho inválido: %g (deve ser positivo)", p.Size)
+	}
+	rng := rand.New(rand.NewSource(p.Seed))
+	half := p.Size / 2
+	coord := func() float64 { return (rng.Float64()*2 - 1) * half }
+
+	fig := newFigure("aleatoria")
+	fig.Pontos = make([]types.Point3D, 0, p.Points)
+
+	// groups guarda os pontos de cada aglomerado; sem aglomerados, um
+	// grupo só, com todos
+	var groups [][]int
+	if p.Clusters == 0 {
+		groups = [][]int{make([]int, p.Points)}
+		for i := range groups[0] {
+			groups[0][i] = i
+			fig.Pontos = append(fig.Pontos, point(coord(), coord(), coord()))
+		}
+	} else {
+		// Nuvens cujo raio encolhe com o número delas, para que não se
+		// fundam numa só
+		spread := p.Size / 8 / math.Cbrt(float64(p.Clusters))
+		groups = make([][]int, p.Clusters)
+		for c := range groups {
+			cx, cy, cz := coord(), coord(), coord()
+			// Os pontos são repartidos por igual; os que sobram vão para as primeiras nuvens
+			n := p.Points / p.Clusters
+			if c < p.Points%p.Clusters {
+				n++
+			}
+			for range n {
+				groups[c] = append(groups[c], len(fig.Pontos))
+				fig.Pontos = append(fig.Pontos, point(
+					cx+rng.NormFloat64()*spread,
+					cy+rng.NormFloat64()*spread,
+					cz+rng.NormFloat64()*spread))
+			}
+		}
+	}
+
+	// pick sorteia k pontos distintos de um mesmo grupo, escolhido com
+	// probabilidade proporcional ao seu tamanho
+	pick := func(k int) []int {
+		members := groups[0]
+		if len(groups) > 1 {
+			owner := rng.Intn(p.Points)
+			for _, g := range groups {
+				if owner < len(g) {
+					members = g
+					break
+				}
+				owner -= len(g)
+			}
+		}
+		chosen := make([]int, 0, k)
+		for len(chosen) < k {
+			candidate := members[rng.Intn(len(members))]
+			repeated := false
+			for _, c := range chosen {
+				repeated = repeated || c == candidate
+			}
+			if !repeated {
+				chosen = append(chosen, candidate)
+			}
+		}
+		return chosen
+	}
+
+	fig.Linhas = make([]types.Line, 0, p.Lines)
+	for range p.Lines {
+		ends := pick(2)
+		fig.Linhas = append(fig.Linhas, types.Line{P1: ends[0], P2: ends[1]})
+	}
+	for range p.Faces {
+		fig.Faces = append(fig.Faces, types.Face{Pontos: pick(3)})
+	}
+
+	if p.Faces == 0 {
+		fig.Render = nil
+	} else {
+		// Triângulos sorteados não têm frente nem verso
+		culling := false
+		fig.Render.BackfaceCulling = &culling
+	}
+	return fig, nil
+}
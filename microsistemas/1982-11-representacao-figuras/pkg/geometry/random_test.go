@@ -0,0 +1,120 @@
+package geometry
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	fig, err := Random(RandomParams{Points: 200, Lines: 500, Size: 4, Seed: 3})
+	if err != nil {
+		t.Fatalf("Random failed: %v", err)
+	}
+	if len(fig.Pontos) != 200 || len(fig.Linhas) != 500 || len(fig.Faces) != 0 {
+		t.Fatalf("Expected 200 points, 500 lines and no faces, got %d, %d and %d", len(fig.Pontos), len(fig.Linhas), len(fig.Faces))
+	}
+	if fig.Render != nil {
+		t.Errorf("Expected a wireframe without render options")
+	}
+	for i, p := range fig.Pontos {
+		if math.Abs(p.X) > 2 || math.Abs(p.Y) > 2 || math.Abs(p.Z) > 2 {
+			t.Errorf("Point %d outside the cube: %+v", i, p)
+		}
+	}
+	for i, l := range fig.Linhas {
+		if l.P1 == l.P2 || l.P1 < 0 || l.P2 < 0 || l.P1 >= 200 || l.P2 >= 200 {
+			t.Errorf("Invalid line %d: %v", i, l)
+		}
+	}
+}
+
+func TestRandomSeed(t *testing.T) {
+	p := RandomParams{Points: 50, Lines: 80, Faces: 10, Size: 1, Seed: 42}
+	a, _ := Random(p)
+	b, _ := Random(p)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same figure for the same seed")
+	}
+	p.Seed = 43
+	if c, _ := Random(p); reflect.DeepEqual(a.Pontos, c.Pontos) {
+		t.Errorf("Expected a different figure for another seed")
+	}
+}
+
+func TestRandomFaces(t *testing.T) {
+	fig, err := Random(RandomParams{Points: 30, Faces: 40, Size: 2, Seed: 1})
+	if err != nil {
+		t.Fatalf("Random failed: %v", err)
+	}
+	if len(fig.Faces) != 40 || len(fig.Linhas) != 0 {
+		t.Fatalf("Expected 40 faces and no lines, got %d and %d", len(fig.Faces), len(fig.Linhas))
+	}
+	for i, face := range fig.Faces {
+		a, b, c := face.Pontos[0], face.Pontos[1], face.Pontos[2]
+		if len(face.Pontos) != 3 || a == b || b == c || a == c {
+			t.Errorf("Face %d is not a triangle of distinct points: %v", i, face.Pontos)
+		}
+	}
+	// Faces sorteadas não têm frente: nada de descarte
+	if r := fig.Render; r == nil || r.BackfaceCulling == nil || *r.BackfaceCulling {
+		t.Errorf("Expected backface culling off, got %+v", r)
+	}
+}
+
+func TestRandomClusters(t *testing.T) {
+	fig, err := Random(RandomParams{Points: 301, Lines: 1000, Faces: 100, Clusters: 3, Size: 100, Seed: 5})
+	if err != nil {
+		t.Fatalf("Random failed: %v", err)
+	}
+	if len(fig.Pontos) != 301 {
+		t.Fatalf("Expected 301 points, got %d", len(fig.Pontos))
+	}
+
+	// Os pontos vêm em blocos, um por aglomerado (101, 100, 100); as
+	// linhas e faces não saem do bloco
+	cluster := func(i int) int {
+		if i < 101 {
+			return 0
+		}
+		return 1 + (i-101)/100
+	}
+	for i, l := range fig.Linhas {
+		if cluster(l.P1) != cluster(l.P2) {
+			t.Errorf("Line %d links clusters %d and %d", i, cluster(l.P1), cluster(l.P2))
+		}
+	}
+	for i, face := range fig.Faces {
+		for _, p := range face.Pontos[1:] {
+			if cluster(p) != cluster(face.Pontos[0]) {
+				t.Errorf("Face %d spans clusters: %v", i, face.Pontos)
+			}
+		}
+	}
+}
+
+func TestRandomInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  RandomParams
+		message string
+	}{
+		{"too few points", RandomParams{Points: 2, Lines: 1, Size: 1}, "pontos"},
+		{"too many points", RandomParams{Points: MaxRandomPoints + 1, Lines: 1, Size: 1}, "pontos"},
+		{"negative lines", RandomParams{Points: 10, Lines: -1, Size: 1}, "linhas"},
+		{"negative faces", RandomParams{Points: 10, Lines: 1, Faces: -1, Size: 1}, "faces"},
+		{"nothing to draw", RandomParams{Points: 10, Size: 1}, "linhas ou faces"},
+		{"tiny clusters", RandomParams{Points: 10, Lines: 1, Clusters: 4, Size: 1}, "aglomerados"},
+		{"zero size", RandomParams{Points: 10, Lines: 1}, "tamanho"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Random(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
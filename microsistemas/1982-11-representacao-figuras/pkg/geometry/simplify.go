@@ -0,0 +1,401 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// simplifySearchSteps é o número de tentativas da busca pela tolerância
+// que leva ao número de arestas pedido (ver SimplifyToEdges).
+const simplifySearchSteps = 40
+
+// EdgeCount conta as arestas desenhadas de uma figura: as linhas e os
+// lados das faces, sem contar duas vezes o lado comum a duas faces (nem
+// as linhas derivadas das faces, que são esses mesmos lados).
+func EdgeCount(fig *types.Figure) int {
+	sides := make(map[[2]int]bool)
+	for _, face := range fig.Faces {
+		for i, a := range face.Pontos {
+			b := face.Pontos[(i+1)%len(face.Pontos)]
+			sides[[2]int{min(a, b), max(a, b)}] = true
+		}
+	}
+	if fig.EdgesFromFaces {
+		return len(sides)
+	}
+	return len(fig.Linhas) + len(sides)
+}
+
+// Simplify reduz o número de pontos e de arestas de uma figura densa,
+// como as malhas importadas de outros programas, que têm arestas demais
+// para um desenho em arame legível.
+//
+// O espaço é dividido em cubos de aresta tolerance, e os pontos de cada
+// cubo se fundem num só (agrupamento de vértices). Para que a silhueta e
+// os vincos não se desmanchem, o ponto que fica não é a média do grupo:
+// é o que menos se afasta dos planos das faces e das retas das linhas
+// que passam pelo grupo (a "quádrica" de Garland e Heckbert, como no
+// agrupamento de Lindstrom), de modo que uma quina continua na quina e
+// uma borda reta continua reta. Onde não há como decidir, como no meio
+// de uma face plana, vale a média.
+//
+// Linhas e faces passam a ligar os pontos fundidos; as que encolhem a
+// um ponto somem, e as linhas repetidas ficam uma só. Os pontos com nome
+// nunca se fundem entre si e não saem do lugar, para que as cotas e os
+// nomes continuem valendo: os demais pontos do seu cubo se juntam a
+// eles.
+//
+// Parâmetros:
+//   fig: figura original (não é alterada)
+//   tolerance: aresta dos cubos, na unidade da figura; quanto maior,
+//              mais simples a figura e maior o desvio da original
+//
+// Retorna:
+//   *types.Figure: figura simplificada, com a mesma câmera e o mesmo
+//                  estilo da original
+//   error: erro se a tolerância não for positiva
+func Simplify(fig *types.Figure, tolerance float64) (*types.Figure, error) {
+	if !(tolerance > 0) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("tolerância inválida: %g (deve ser positiva)", tolerance)
+	}
+	origin, _ := fig.Bounds()
+	linhas := fig.Linhas
+	if fig.EdgesFromFaces {
+		linhas = nil // Derivadas das faces de novo no fim
+	}
+
+	// cluster guarda o grupo de cada ponto; os pontos com nome formam
+	// grupos próprios, e os sem nome ficam com o do primeiro ponto com
+	// nome do seu cubo, se houver
+	cluster := make([]int, len(fig.Pontos))
+	var groups []simplifyGroup
+	byCell := make(map[[3]int64]int)
+	cell := func(p types.Point3D) [3]int64 {
+		return [3]int64{
+			int64(math.Floor((p.X - origin.X) / tolerance)),
+			int64(math.Floor((p.Y - origin.Y) / tolerance)),
+			int64(math.Floor((p.Z - origin.Z) / tolerance)),
+		}
+	}
+	for i, p := range fig.Pontos {
+		if p.Nome == "" {
+			continue
+		}
+		cluster[i] = len(groups)
+		groups = append(groups, simplifyGroup{pinned: true, point: p})
+		if _, ok := byCell[cell(p)]; !ok {
+			byCell[cell(p)] = cluster[i]
+		}
+	}
+	for i, p := range fig.Pontos {
+		if p.Nome != "" {
+			continue
+		}
+		key := cell(p)
+		g, ok := byCell[key]
+		if !ok {
+			g = len(groups)
+			byCell[key] = g
+			groups = append(groups, simplifyGroup{})
+		}
+		cluster[i] = g
+		groups[g].add(p)
+	}
+
+	// Quádricas: os planos das faces e as retas das linhas, pesados pela
+	// área e pelo comprimento, somados nos grupos dos seus pontos
+	for _, face := range fig.Faces {
+		normal, area := newellNormal(fig.Pontos, face.Pontos)
+		if area == 0 {
+			continue
+		}
+		q := planeQuadric(normal, fig.Pontos[face.Pontos[0]], area)
+		seen := make(map[int]bool, len(face.Pontos))
+		for _, p := range face.Pontos {
+			if g := cluster[p]; !seen[g] {
+				seen[g] = true
+				groups[g].q.add(q)
+			}
+		}
+	}
+	for _, linha := range linhas {
+		a, b := fig.Pontos[linha.P1], fig.Pontos[linha.P2]
+		if q, ok := lineQuadric(a, b); ok {
+			groups[cluster[linha.P1]].q.add(q)
+			if cluster[linha.P2] != cluster[linha.P1] {
+				groups[cluster[linha.P2]].q.add(q)
+			}
+		}
+	}
+
+	// Pontos da figura nova, na ordem em que os grupos aparecem
+	out := *fig
+	out.Pontos = nil
+	index := make([]int, len(groups))
+	for i := range index {
+		index[i] = -1
+	}
+	for _, g := range cluster {
+		if index[g] >= 0 {
+			continue
+		}
+		index[g] = len(out.Pontos)
+		out.Pontos = append(out.Pontos, groups[g].representative(tolerance))
+	}
+	mapped := func(p int) int { return index[cluster[p]] }
+
+	out.Linhas = nil
+	type lineKey struct {
+		a, b     int
+		directed bool
+	}
+	drawn := make(map[lineKey]bool)
+	for _, linha := range linhas {
+		a, b := mapped(linha.P1), mapped(linha.P2)
+		if len(linha.Controle) == 0 {
+			if a == b {
+				continue
+			}
+			key := lineKey{min(a, b), max(a, b), false}
+			if linha.Direcionada {
+				key = lineKey{a, b, true}
+			}
+			if drawn[key] {
+				continue
+			}
+			drawn[key] = true
+		}
+		linha.P1, linha.P2 = a, b
+		out.Linhas = append(out.Linhas, linha)
+	}
+
+	out.Faces = nil
+	for _, face := range fig.Faces {
+		var points []int
+		for _, p := range face.Pontos {
+			if q := mapped(p); len(points) == 0 || points[len(points)-1] != q {
+				points = append(points, q)
+			}
+		}
+		for len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 3 {
+			continue
+		}
+		face.Pontos = points
+		out.Faces = append(out.Faces, face)
+	}
+	if out.EdgesFromFaces {
+		out.Linhas = out.FaceEdges()
+	}
+	return &out, nil
+}
+
+// SimplifyToEdges simplifica uma figura até que ela tenha no máximo
+// maxEdges arestas (ver EdgeCount), procurando a menor tolerância de
+// Simplify que chega lá.
+//
+// Parâmetros:
+//   fig: figura original (não é alterada)
+//   maxEdges: número máximo de arestas da figura simplificada
+//
+// Retorna:
+//   *types.Figure: figura simplificada (a original, se já tiver poucas
+//                  arestas)
+//   float64: tolerância usada (0 se a figura não precisou mudar)
+//   error: erro se maxEdges não for positivo ou se nem a maior
+//          tolerância chegar lá (por causa dos pontos com nome)
+func SimplifyToEdges(fig *types.Figure, maxEdges int) (*types.Figure, float64, error) {
+	if maxEdges < 1 {
+		return nil, 0, fmt.Errorf("número de arestas inválido: %d (deve ser positivo)", maxEdges)
+	}
+	if EdgeCount(fig) <= maxEdges {
+		return fig, 0, nil
+	}
+
+	// Com cubos maiores que a figura, tudo o que não tem nome vira um
+	// ponto só
+	low, high := fig.Bounds()
+	hi := math.Max(high.X-low.X, math.Max(high.Y-low.Y, high.Z-low.Z)) * 2
+	if hi == 0 {
+		hi = 1
+	}
+	best, err := Simplify(fig, hi)
+	if err != nil {
+		return nil, 0, err
+	}
+	if n := EdgeCount(best); n > maxEdges {
+		return nil, 0, fmt.Errorf("não há como deixar a figura com %d arestas: o mínimo, sem fundir os pontos com nome, é %d", maxEdges, n)
+	}
+
+	lo := 0.0
+	for range simplifySearchSteps {
+		mid := (lo + hi) / 2
+		simple, err := Simplify(fig, mid)
+		if err != nil {
+			return nil, 0, err
+		}
+		if EdgeCount(simple) <= maxEdges {
+			best, hi = simple, mid
+		} else {
+			lo = mid
+		}
+	}
+	return best, hi, nil
+}
+
+// simplifyGroup é um grupo de pontos que Simplify funde num só.
+type simplifyGroup struct {
+	pinned bool          // Ponto com nome, que não sai do lugar
+	point  types.Point3D // O ponto com nome
+	sum    types.Point3D // Soma dos pontos sem nome, para a média
+	count  int           // Número de pontos sem nome
+	q      quadric       // Planos e retas que passam pelo grupo
+}
+
+// add acrescenta um ponto sem nome ao grupo.
+func (g *simplifyGroup) add(p types.Point3D) {
+	g.sum.X += p.X
+	g.sum.Y += p.Y
+	g.sum.Z += p.Z
+	g.count++
+}
+
+// representative escolhe o ponto que substitui o grupo: o ponto com
+// nome, se houver; senão, o de menor erro quádrico, desde que não fuja
+// para longe do grupo; senão, a média.
+func (g *simplifyGroup) representative(tolerance float64) types.Point3D {
+	if g.pinned {
+		return g.point
+	}
+	n := float64(g.count)
+	mean := types.Point3D{X: g.sum.X / n, Y: g.sum.Y / n, Z: g.sum.Z / n}
+	best, ok := g.q.minimize(mean)
+	if !ok || math.Abs(best.X-mean.X) > tolerance || math.Abs(best.Y-mean.Y) > tolerance || math.Abs(best.Z-mean.Z) > tolerance {
+		best = mean
+	}
+	return point(best.X, best.Y, best.Z)
+}
+
+// quadric é a forma quadrática do erro de um ponto x, a soma dos
+// quadrados das distâncias aos planos e às retas acumulados:
+//   erro(x) = xᵀ A x - 2 bᵀ x + c
+// cujo mínimo é a solução de A x = b.
+type quadric struct {
+	a [3][3]float64
+	b [3]float64
+}
+
+// add soma outra quádrica a esta.
+func (q *quadric) add(o quadric) {
+	for i := range 3 {
+		for j := range 3 {
+			q.a[i][j] += o.a[i][j]
+		}
+		q.b[i] += o.b[i]
+	}
+}
+
+// planeQuadric é a quádrica da distância ao plano de normal unitária n
+// que passa por p, com peso w: A = w n nᵀ e b = w (n·p) n.
+func planeQuadric(n, p types.Point3D, w float64) quadric {
+	v := [3]float64{n.X, n.Y, n.Z}
+	d := n.X*p.X + n.Y*p.Y + n.Z*p.Z
+	var q quadric
+	for i := range 3 {
+		for j := range 3 {
+			q.a[i][j] = w * v[i] * v[j]
+		}
+		q.b[i] = w * d * v[i]
+	}
+	return q
+}
+
+// lineQuadric é a quádrica da distância à reta que passa por a e b, com
+// peso igual ao comprimento do segmento: A = w (I - u uᵀ) e b = A a,
+// sendo u a direção da reta.
+func lineQuadric(a, b types.Point3D) (quadric, bool) {
+	d := [3]float64{b.X - a.X, b.Y - a.Y, b.Z - a.Z}
+	w := math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+	if w == 0 {
+		return quadric{}, false
+	}
+	u := [3]float64{d[0] / w, d[1] / w, d[2] / w}
+	p := [3]float64{a.X, a.Y, a.Z}
+	var q quadric
+	for i := range 3 {
+		for j := range 3 {
+			q.a[i][j] = -w * u[i] * u[j]
+		}
+		q.a[i][i] += w
+	}
+	for i := range 3 {
+		for j := range 3 {
+			q.b[i] += q.a[i][j] * p[j]
+		}
+	}
+	return q, true
+}
+
+// minimize encontra o ponto de menor erro. Nas direções em que a
+// quádrica não decide (sobre um plano, ao longo de uma reta), uma
+// pequena atração para o ponto de referência escolhe por ela.
+//
+// Retorna:
+//   types.Point3D: ponto de menor erro
+//   bool: false se a quádrica estiver vazia
+func (q quadric) minimize(ref types.Point3D) (types.Point3D, bool) {
+	trace := q.a[0][0] + q.a[1][1] + q.a[2][2]
+	if !(trace > 0) {
+		return types.Point3D{}, false
+	}
+	lambda := trace * 1e-3
+	m := q.a
+	rhs := q.b
+	r := [3]float64{ref.X, ref.Y, ref.Z}
+	for i := range 3 {
+		m[i][i] += lambda
+		rhs[i] += lambda * r[i]
+	}
+
+	// Regra de Cramer: a matriz é simétrica e, com a atração, positiva
+	det := func(m [3][3]float64) float64 {
+		return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	}
+	d := det(m)
+	if d == 0 || math.IsNaN(d) {
+		return types.Point3D{}, false
+	}
+	var x [3]float64
+	for k := range 3 {
+		mk := m
+		for i := range 3 {
+			mk[i][k] = rhs[i]
+		}
+		x[k] = det(mk) / d
+	}
+	return types.Point3D{X: x[0], Y: x[1], Z: x[2]}, true
+}
+
+// newellNormal calcula a normal unitária e a área de um polígono pelo
+// método de Newell, que vale também para polígonos não convexos e
+// quase planos.
+func newellNormal(points []types.Point3D, face []int) (types.Point3D, float64) {
+	var n types.Point3D
+	for i, a := range face {
+		p, q := points[a], points[face[(i+1)%len(face)]]
+		n.X += (p.Y - q.Y) * (p.Z + q.Z)
+		n.Y += (p.Z - q.Z) * (p.X + q.X)
+		n.Z += (p.X - q.X) * (p.Y + q.Y)
+	}
+	length := math.Sqrt(n.X*n.X + n.Y*n.Y + n.Z*n.Z)
+	if length == 0 {
+		return types.Point3D{}, 0
+	}
+	return types.Point3D{X: n.X / length, Y: n.Y / length, Z: n.Z / length}, length / 2
+}
@@ -0,0 +1,180 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"representacao-figuras/pkg/types"
+)
+
+// denseCube retorna as 12 arestas de um cubo de lado 2, cada uma
+// dividida em 20 trechos, como uma malha importada densa demais.
+func denseCube(t *testing.T) *types.Figure {
+	t.Helper()
+	box, err := Box(2, 2, 2)
+	if err != nil {
+		t.Fatalf("Box failed: %v", err)
+	}
+	fig := Wireframe(box)
+	fig.Subdivide(0.1)
+	return fig
+}
+
+func TestSimplifyKeepsCorners(t *testing.T) {
+	fig := denseCube(t)
+	before := len(fig.Pontos)
+
+	simple, err := Simplify(fig, 0.35)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+	if len(simple.Pontos) >= before/2 || EdgeCount(simple) >= EdgeCount(fig)/2 {
+		t.Errorf("Expected far fewer points and edges, got %d points (from %d) and %d edges (from %d)",
+			len(simple.Pontos), before, EdgeCount(simple), EdgeCount(fig))
+	}
+	// A figura original não muda
+	if len(fig.Pontos) != before {
+		t.Errorf("Expected original figure untouched")
+	}
+
+	// As quinas continuam nas quinas: a caixa envolvente é a mesma, e
+	// cada ponto continua sobre uma aresta do cubo (duas coordenadas em ±1)
+	low, high := simple.Bounds()
+	if low != (types.Point3D{X: -1, Y: -1, Z: -1}) || high != (types.Point3D{X: 1, Y: 1, Z: 1}) {
+		t.Errorf("Expected bounds (-1,-1,-1)-(1,1,1), got %v-%v", low, high)
+	}
+	for i, p := range simple.Pontos {
+		onFace := 0
+		for _, c := range []float64{p.X, p.Y, p.Z} {
+			if math.Abs(math.Abs(c)-1) < 1e-6 {
+				onFace++
+			}
+		}
+		if onFace < 2 {
+			t.Errorf("Point %d left the cube edges: %+v", i, p)
+		}
+	}
+	for i, l := range simple.Linhas {
+		if l.P1 == l.P2 {
+			t.Errorf("Line %d collapsed to a point: %+v", i, l)
+		}
+	}
+}
+
+func TestSimplifyFaces(t *testing.T) {
+	// Grade plana de 10×10 quadrados: continua plana depois de simplificada
+	fig := &types.Figure{}
+	for j := 0; j <= 10; j++ {
+		for i := 0; i <= 10; i++ {
+			fig.Pontos = append(fig.Pontos, types.Point3D{X: float64(i) / 10, Y: float64(j) / 10})
+		}
+	}
+	for j := 0; j < 10; j++ {
+		for i := 0; i < 10; i++ {
+			a := j*11 + i
+			fig.Faces = append(fig.Faces, types.Face{Pontos: []int{a, a + 1, a + 12, a + 11}})
+		}
+	}
+
+	simple, err := Simplify(fig, 0.25)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+	if len(simple.Faces) == 0 || len(simple.Faces) >= len(fig.Faces) {
+		t.Fatalf("Expected fewer faces, got %d", len(simple.Faces))
+	}
+	for i, p := range simple.Pontos {
+		if p.Z != 0 {
+			t.Errorf("Point %d left the plane: %+v", i, p)
+		}
+	}
+	for i, face := range simple.Faces {
+		seen := make(map[int]bool)
+		for _, p := range face.Pontos {
+			seen[p] = true
+		}
+		if len(face.Pontos) < 3 || len(seen) < 3 {
+			t.Errorf("Face %d degenerate: %v", i, face.Pontos)
+		}
+	}
+}
+
+func TestSimplifyNamedPoints(t *testing.T) {
+	fig := &types.Figure{
+		Pontos: []types.Point3D{
+			{X: 0, Nome: "A"}, {X: 0.01}, {X: 0.02, Nome: "B"}, {X: 1}, {X: 1.01},
+		},
+		Linhas: []types.Line{
+			{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 3}, {P1: 3, P2: 4},
+			{P1: 0, P2: 3}, {P1: 1, P2: 4}, // Repetem 0-3 depois da fusão
+			{P1: 1, P2: 3, Direcionada: true},
+		},
+	}
+	simple, err := Simplify(fig, 0.5)
+	if err != nil {
+		t.Fatalf("Simplify failed: %v", err)
+	}
+
+	// A e B não se fundem nem saem do lugar; o ponto sem nome do seu cubo
+	// se junta a A, e os dois da direita viram um só, o terceiro da lista
+	if len(simple.Pontos) != 3 {
+		t.Fatalf("Expected 3 points, got %+v", simple.Pontos)
+	}
+	if simple.Pontos[0] != fig.Pontos[0] || simple.Pontos[1] != fig.Pontos[2] {
+		t.Errorf("Expected named points kept in place, got %+v", simple.Pontos)
+	}
+	if x := simple.Pontos[2].X; x < 1 || x > 1.01 {
+		t.Errorf("Expected merged point between 1 and 1.01, got %v", x)
+	}
+
+	// Sobram A-B, B-direita, A-direita e a linha direcionada
+	if len(simple.Linhas) != 4 {
+		t.Fatalf("Expected 4 lines, got %+v", simple.Linhas)
+	}
+	if l := simple.Linhas[3]; l.P1 != 0 || l.P2 != 2 || !l.Direcionada {
+		t.Errorf("Expected directed line kept, got %+v", l)
+	}
+}
+
+func TestSimplifyToEdges(t *testing.T) {
+	fig := denseCube(t)
+	total := EdgeCount(fig)
+
+	simple, tolerance, err := SimplifyToEdges(fig, 60)
+	if err != nil {
+		t.Fatalf("SimplifyToEdges failed: %v", err)
+	}
+	if n := EdgeCount(simple); n > 60 || n < 12 {
+		t.Errorf("Expected between 12 and 60 edges, got %d", n)
+	}
+	if !(tolerance > 0) {
+		t.Errorf("Expected positive tolerance, got %v", tolerance)
+	}
+
+	// Figura que já cabe no limite volta como está
+	same, tolerance, err := SimplifyToEdges(fig, total)
+	if err != nil || same != fig || tolerance != 0 {
+		t.Errorf("Expected figure unchanged, got tolerance %v (%v)", tolerance, err)
+	}
+
+	if _, _, err := SimplifyToEdges(fig, 0); err == nil {
+		t.Error("Expected error for zero edges, got nil")
+	}
+	// Pontos com nome não se fundem: três deles ligados pedem três arestas
+	named := &types.Figure{
+		Pontos: []types.Point3D{{Nome: "A"}, {X: 1, Nome: "B"}, {Y: 1, Nome: "C"}},
+		Linhas: []types.Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 0}},
+	}
+	if _, _, err := SimplifyToEdges(named, 2); err == nil || !strings.Contains(err.Error(), "mínimo") {
+		t.Errorf("Expected error about the minimum, got %v", err)
+	}
+}
+
+func TestSimplifyInvalid(t *testing.T) {
+	for _, tolerance := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := Simplify(&types.Figure{}, tolerance); err == nil {
+			t.Errorf("Expected error for tolerance %v, got nil", tolerance)
+		}
+	}
+}
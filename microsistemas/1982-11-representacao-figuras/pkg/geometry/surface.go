@@ -0,0 +1,101 @@
+package geometry
+
+import (
+	"fmt"
+
+	"representacao-figuras/pkg/types"
+)
+
+// SurfaceParams é a grade em que a superfície z = f(x, y) é amostrada.
+type SurfaceParams struct {
+	XMin, XMax float64 // Intervalo de x
+	YMin, YMax float64 // Intervalo de y
+	XSteps     int     // Divisões do intervalo de x
+	YSteps     int     // Divisões do intervalo de y
+	ZScale     float64 // Fator aplicado a z (0 = 1), para achatar ou realçar o relevo
+}
+
+// DefaultSurfaceParams retorna a grade padrão: x e y de -3 a 3, em 30
+// divisões cada, o bastante para sin(x)*cos(y) parecer liso.
+func DefaultSurfaceParams() SurfaceParams {
+	return SurfaceParams{
+		XMin: -3, XMax: 3,
+		YMin: -3, YMax: 3,
+		XSteps: 30, YSteps: 30,
+		ZScale: 1,
+	}
+}
+
+// Surface gera o gráfico de uma função z = f(x, y) em arame, como nas
+// revistas da época: a função é amostrada nos nós de uma grade, e as
+// linhas da grade, em x e em y, ligam os pontos vizinhos.
+//
+// Onde a função não está definida (raiz de negativo, divisão por zero),
+// o nó fica de fora, com as linhas que passariam por ele.
+//
+// Parâmetros:
+//   f: função a desenhar (ver ParseExpression)
+//   p: intervalos e divisões da grade
+//
+// Retorna:
+//   *types.Figure: superfície em linhas, sem faces
+//   error: erro se a grade for inválida ou a função não estiver
+//          definida em nenhum nó
+func Surface(f Expression, p SurfaceParams) (*types.Figure, error) {
+	if !(p.XMax > p.XMin) || !(p.YMax > p.YMin) {
+		return nil, fmt.Errorf("intervalo inválido: x de %g a %g, y de %g a %g (o fim deve ser maior que o início)", p.XMin, p.XMax, p.YMin, p.YMax)
+	}
+	for _, steps := range []int{p.XSteps, p.YSteps} {
+		if steps < 1 || steps > MaxDivision {
+			return nil, fmt.Errorf("número de divisões inválido: %d (use de 1 a %d)", steps, MaxDivision)
+		}
+	}
+	scale := p.ZScale
+	if scale == 0 {
+		scale = 1
+	}
+
+	fig := newFigure("superficie")
+	fig.Render = nil
+
+	// Índice do ponto de cada nó (i em x, j em y), ou -1 fora do domínio
+	index := make([][]int, p.XSteps+1)
+	for i := range index {
+		index[i] = make([]int, p.YSteps+1)
+		x := p.XMin + (p.XMax-p.XMin)*float64(i)/float64(p.XSteps)
+		for j := range index[i] {
+			y := p.YMin + (p.YMax-p.YMin)*float64(j)/float64(p.YSteps)
+			z := f(x, y) * scale
+			if !finite(z) {
+				index[i][j] = -1
+				continue
+			}
+			index[i][j] = len(fig.Pontos)
+			fig.Pontos = append(fig.Pontos, point(x, y, z))
+		}
+	}
+	if len(fig.Pontos) == 0 {
+		return nil, fmt.Errorf("a função não está definida em nenhum ponto da grade")
+	}
+
+	link := func(a, b int) {
+		if a >= 0 && b >= 0 {
+			fig.Linhas = append(fig.Linhas, types.Line{P1: a, P2: b})
+		}
+	}
+	for j := 0; j <= p.YSteps; j++ { // Linhas em x, uma para cada y
+		for i := 0; i < p.XSteps; i++ {
+			link(index[i][j], index[i+1][j])
+		}
+	}
+	for i := 0; i <= p.XSteps; i++ { // Linhas em y, uma para cada x
+		for j := 0; j < p.YSteps; j++ {
+			link(index[i][j], index[i][j+1])
+		}
+	}
+	if len(fig.Linhas) == 0 {
+		return nil, fmt.Errorf("a função não está definida em dois pontos vizinhos da grade")
+	}
+	aim(fig)
+	return fig, nil
+}
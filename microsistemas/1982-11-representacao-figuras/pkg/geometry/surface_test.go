@@ -0,0 +1,88 @@
+package geometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSurface(t *testing.T) {
+	f, err := ParseExpression("x * y")
+	if err != nil {
+		t.Fatalf("ParseExpression failed: %v", err)
+	}
+	fig, err := Surface(f, SurfaceParams{XMin: 0, XMax: 4, YMin: -1, YMax: 1, XSteps: 4, YSteps: 2, ZScale: 2})
+	if err != nil {
+		t.Fatalf("Surface failed: %v", err)
+	}
+
+	// 5 × 3 nós; 3 linhas de 4 trechos em x e 5 de 2 trechos em y
+	if len(fig.Pontos) != 15 || len(fig.Linhas) != 3*4+5*2 {
+		t.Fatalf("Expected 15 points and 22 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	if len(fig.Faces) != 0 {
+		t.Errorf("Expected a wireframe, got %d faces", len(fig.Faces))
+	}
+	for _, p := range fig.Pontos {
+		if want := 2 * p.X * p.Y; math.Abs(p.Z-want) > 1e-6 {
+			t.Errorf("Point (%g, %g): expected z=%g, got %g", p.X, p.Y, want, p.Z)
+		}
+	}
+	// Todas as linhas ligam nós vizinhos: um passo em x ou em y
+	for _, l := range fig.Linhas {
+		a, b := fig.Pontos[l.P1], fig.Pontos[l.P2]
+		if dx, dy := math.Abs(a.X-b.X), math.Abs(a.Y-b.Y); !(dx == 1 && dy == 0 || dx == 0 && dy == 1) {
+			t.Errorf("Line %v links non-neighbors %+v and %+v", l, a, b)
+		}
+	}
+	if target := fig.Camera.Target; target == nil || target.X != 2 || target.Y != 0 {
+		t.Errorf("Expected the camera aimed at the middle of the grid, got %+v", target)
+	}
+}
+
+func TestSurfaceUndefined(t *testing.T) {
+	// sqrt(x) não existe para x < 0: os nós da metade esquerda ficam de fora
+	f, err := ParseExpression("sqrt(x)")
+	if err != nil {
+		t.Fatalf("ParseExpression failed: %v", err)
+	}
+	fig, err := Surface(f, SurfaceParams{XMin: -2, XMax: 2, YMin: 0, YMax: 1, XSteps: 4, YSteps: 1})
+	if err != nil {
+		t.Fatalf("Surface failed: %v", err)
+	}
+	if len(fig.Pontos) != 6 || len(fig.Linhas) != 2*2+3 {
+		t.Errorf("Expected 6 points and 7 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	for _, p := range fig.Pontos {
+		if p.X < 0 {
+			t.Errorf("Expected no point with x < 0, got %+v", p)
+		}
+	}
+
+	f, _ = ParseExpression("log(-1 - x^2)")
+	if _, err := Surface(f, DefaultSurfaceParams()); err == nil || !strings.Contains(err.Error(), "não está definida") {
+		t.Errorf("Expected undefined function error, got %v", err)
+	}
+}
+
+func TestSurfaceInvalid(t *testing.T) {
+	f, _ := ParseExpression("x")
+	tests := []struct {
+		name    string
+		params  SurfaceParams
+		message string
+	}{
+		{"reversed x", SurfaceParams{XMin: 1, XMax: -1, YMin: 0, YMax: 1, XSteps: 2, YSteps: 2}, "intervalo"},
+		{"empty y", SurfaceParams{XMin: 0, XMax: 1, YMin: 1, YMax: 1, XSteps: 2, YSteps: 2}, "intervalo"},
+		{"no steps", SurfaceParams{XMin: 0, XMax: 1, YMin: 0, YMax: 1, XSteps: 0, YSteps: 2}, "divisões"},
+		{"too many steps", SurfaceParams{XMin: 0, XMax: 1, YMin: 0, YMax: 1, XSteps: 2, YSteps: MaxDivision + 1}, "divisões"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Surface(f, tt.params); err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package geometry
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"representacao-figuras/pkg/types"
+)
+
+// DefaultTerrainSteps é o número de divisões da grade do terreno no lado
+// mais longo da imagem, quando não é informado.
+const DefaultTerrainSteps = 64
+
+// TerrainParams são as medidas do terreno gerado de um mapa de alturas.
+type TerrainParams struct {
+	Width  float64 // Largura do terreno (X), de uma borda à outra da imagem
+	Depth  float64 // Profundidade (Y) (0 = a da proporção da imagem)
+	Height float64 // Altura dos pontos brancos; os pretos ficam em z = 0
+	XSteps int     // Divisões da grade em X (0 = automático, ver Terrain)
+	YSteps int     // Divisões da grade em Y (0 = automático)
+	Invert bool    // Escuro é alto e claro é baixo, como nos mapas de profundidade
+}
+
+// DefaultTerrainParams retorna as medidas padrão: terreno de 10 unidades
+// de largura, com relevo de até 2 unidades, e a grade automática.
+func DefaultTerrainParams() TerrainParams {
+	return TerrainParams{Width: 10, Height: 2}
+}
+
+// Terrain gera um terreno em arame a partir de um mapa de alturas: uma
+// imagem em tons de cinza em que o branco é o ponto mais alto e o preto
+// o mais baixo. A imagem é amostrada nos nós de uma grade, com
+// interpolação entre os pixels vizinhos, e as linhas da grade ligam os
+// nós vizinhos, como em Surface.
+//
+// O terreno fica centrado na origem, com o topo da imagem ao fundo
+// (+Y). Imagens coloridas são convertidas para tons de cinza. Na grade
+// automática, o lado mais longo da imagem tem DefaultTerrainSteps
+// divisões (ou uma por pixel, em imagens menores), e o outro lado, as
+// que mantêm a proporção.
+//
+// Parâmetros:
+//   img: mapa de alturas
+//   p: medidas do terreno e divisões da grade
+//
+// Retorna:
+//   *types.Figure: terreno em linhas, sem faces
+//   error: erro se a imagem for pequena demais ou as medidas ou as
+//          divisões forem inválidas
+func Terrain(img image.Image, p TerrainParams) (*types.Figure, error) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w < 2 || h < 2 {
+		return nil, fmt.Errorf("imagem pequena demais: %d×%d pixels (mínimo 2×2)", w, h)
+	}
+	depth := p.Depth
+	if depth == 0 {
+		depth = p.Width * float64(h-1) / float64(w-1)
+	}
+	if !(p.Width > 0) || !(depth > 0) {
+		return nil, fmt.Errorf("medidas inválidas: largura %g, profundidade %g (devem ser positivas)", p.Width, depth)
+	}
+	if math.IsNaN(p.Height) || math.IsInf(p.Height, 0) {
+		return nil, fmt.Errorf("altura inválida: %g", p.Height)
+	}
+
+	xSteps, ySteps := p.XSteps, p.YSteps
+	if xSteps == 0 || ySteps == 0 {
+		long := min(DefaultTerrainSteps, max(w, h)-1)
+		auto := func(pixels int) int {
+			return max(1, int(math.Round(float64(long)*float64(pixels-1)/float64(max(w, h)-1))))
+		}
+		if xSteps == 0 {
+			xSteps = auto(w)
+		}
+		if ySteps == 0 {
+			ySteps = auto(h)
+		}
+	}
+	for _, steps := range []int{xSteps, ySteps} {
+		if steps < 1 || steps > MaxDivision {
+			return nil, fmt.Errorf("número de divisões inválido: %d (use de 1 a %d)", steps, MaxDivision)
+		}
+	}
+
+	// Brilho de cada pixel, de 0 (preto) a 1 (branco)
+	gray := make([][]float64, h)
+	for j := range gray {
+		gray[j] = make([]float64, w)
+		for i := range gray[j] {
+			v := float64(color.Gray16Model.Convert(img.At(bounds.Min.X+i, bounds.Min.Y+j)).(color.Gray16).Y) / 0xffff
+			if p.Invert {
+				v = 1 - v
+			}
+			gray[j][i] = v
+		}
+	}
+
+	// sample interpola o brilho na posição (u, v) da imagem, em pixels
+	sample := func(u, v float64) float64 {
+		i, j := min(int(u), w-2), min(int(v), h-2)
+		fu, fv := u-float64(i), v-float64(j)
+		top := gray[j][i]*(1-fu) + gray[j][i+1]*fu
+		bottom := gray[j+1][i]*(1-fu) + gray[j+1][i+1]*fu
+		return top*(1-fv) + bottom*fv
+	}
+
+	fig := newFigure("terreno")
+	fig.Render = nil
+	index := make([][]int, xSteps+1) // Ponto de cada nó (i em x, j em y)
+	for i := range index {
+		index[i] = make([]int, ySteps+1)
+		u := float64(i) / float64(xSteps)
+		for j := range index[i] {
+			v := float64(j) / float64(ySteps)
+			z := sample(u*float64(w-1), v*float64(h-1)) * p.Height
+			index[i][j] = len(fig.Pontos)
+			fig.Pontos = append(fig.Pontos, point(p.Width*(u-0.5), depth*(0.5-v), z))
+		}
+	}
+
+	for j := 0; j <= ySteps; j++ { // Linhas em x, uma para cada y
+		for i := 0; i < xSteps; i++ {
+			fig.Linhas = append(fig.Linhas, types.Line{P1: index[i][j], P2: index[i+1][j]})
+		}
+	}
+	for i := 0; i <= xSteps; i++ { // Linhas em y, uma para cada x
+		for j := 0; j < ySteps; j++ {
+			fig.Linhas = append(fig.Linhas, types.Line{P1: index[i][j], P2: index[i][j+1]})
+		}
+	}
+	aim(fig)
+	return fig, nil
+}
@@ -0,0 +1,126 @@
+package geometry
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+)
+
+// ramp cria um mapa de alturas de w×h pixels com o brilho dado por
+// pixel.
+func ramp(w, h int, pixel func(i, j int) uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			img.SetGray(i, j, color.Gray{Y: pixel(i, j)})
+		}
+	}
+	return img
+}
+
+func TestTerrain(t *testing.T) {
+	// Rampa da esquerda (preto) para a direita (branco), 5×3 pixels
+	img := ramp(5, 3, func(i, j int) uint8 { return uint8(i * 255 / 4) })
+	fig, err := Terrain(img, TerrainParams{Width: 8, Height: 2})
+	if err != nil {
+		t.Fatalf("Terrain failed: %v", err)
+	}
+
+	// Grade automática: um nó por pixel, 4 × 2 divisões
+	if len(fig.Pontos) != 15 || len(fig.Linhas) != 3*4+5*2 {
+		t.Fatalf("Expected 15 points and 22 lines, got %d and %d", len(fig.Pontos), len(fig.Linhas))
+	}
+	if len(fig.Faces) != 0 || fig.Render != nil {
+		t.Errorf("Expected a wireframe without render options")
+	}
+	min, max := fig.Bounds()
+	if min.X != -4 || max.X != 4 || min.Y != -2 || max.Y != 2 {
+		t.Errorf("Expected 8×4 units centered on the origin (image proportion), got %+v to %+v", min, max)
+	}
+	for _, p := range fig.Pontos {
+		if want := (p.X + 4) / 4; math.Abs(p.Z-want) > 0.01 { // 255/4 arredondado
+			t.Errorf("Point (%g, %g): expected z=%g, got %g", p.X, p.Y, want, p.Z)
+		}
+	}
+	if target := fig.Camera.Target; target == nil || target.X != 0 || target.Z != 1 {
+		t.Errorf("Expected the camera aimed at the middle of the terrain, got %+v", target)
+	}
+}
+
+func TestTerrainOrientation(t *testing.T) {
+	// Só a linha de cima da imagem é branca: fica ao fundo (+Y)
+	img := ramp(3, 3, func(i, j int) uint8 {
+		if j == 0 {
+			return 255
+		}
+		return 0
+	})
+	fig, err := Terrain(img, TerrainParams{Width: 2, Height: 1})
+	if err != nil {
+		t.Fatalf("Terrain failed: %v", err)
+	}
+	for _, p := range fig.Pontos {
+		if want := map[bool]float64{true: 1, false: 0}[p.Y == 1]; p.Z != want {
+			t.Errorf("Point (%g, %g): expected z=%g, got %g", p.X, p.Y, want, p.Z)
+		}
+	}
+
+	fig, _ = Terrain(img, TerrainParams{Width: 2, Height: 1, Invert: true})
+	if z := fig.Pontos[0].Z; z != 0 {
+		t.Errorf("Expected the inverted top row low, got z=%g", z)
+	}
+}
+
+func TestTerrainResolution(t *testing.T) {
+	// Interpolação entre pixels: 3 divisões numa imagem de 2 pixels
+	img := ramp(2, 2, func(i, j int) uint8 { return uint8(i * 255) })
+	fig, err := Terrain(img, TerrainParams{Width: 3, Depth: 1, Height: 3, XSteps: 3, YSteps: 1})
+	if err != nil {
+		t.Fatalf("Terrain failed: %v", err)
+	}
+	if len(fig.Pontos) != 8 {
+		t.Fatalf("Expected 8 points, got %d", len(fig.Pontos))
+	}
+	for _, p := range fig.Pontos {
+		if want := p.X + 1.5; math.Abs(p.Z-want) > 1e-6 {
+			t.Errorf("Point (%g, %g): expected z=%g, got %g", p.X, p.Y, want, p.Z)
+		}
+	}
+
+	// Grade automática numa imagem grande: 64 divisões no lado mais longo
+	fig, err = Terrain(image.NewGray(image.Rect(0, 0, 201, 101)), DefaultTerrainParams())
+	if err != nil {
+		t.Fatalf("Terrain failed: %v", err)
+	}
+	if want := 65 * 33; len(fig.Pontos) != want {
+		t.Errorf("Expected a 64×32 grid (%d points), got %d", want, len(fig.Pontos))
+	}
+}
+
+func TestTerrainInvalid(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	tests := []struct {
+		name    string
+		img     image.Image
+		params  TerrainParams
+		message string
+	}{
+		{"one pixel row", image.NewGray(image.Rect(0, 0, 10, 1)), DefaultTerrainParams(), "pequena demais"},
+		{"zero width", img, TerrainParams{Width: 0, Height: 1}, "medidas"},
+		{"negative depth", img, TerrainParams{Width: 1, Depth: -1, Height: 1}, "medidas"},
+		{"infinite height", img, TerrainParams{Width: 1, Height: math.Inf(1)}, "altura"},
+		{"too many steps", img, TerrainParams{Width: 1, Height: 1, XSteps: MaxDivision + 1, YSteps: 2}, "divisões"},
+		{"negative steps", img, TerrainParams{Width: 1, Height: 1, XSteps: 2, YSteps: -1}, "divisões"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Terrain(tt.img, tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package types
+
+// FaceEdges retorna as arestas das faces da figura como linhas, na ordem
+// em que aparecem: o lado comum a duas faces sai uma vez só, seja qual
+// for o sentido em que cada face o percorre, e os lados que ligam um
+// ponto a ele mesmo ficam de fora.
+//
+// Retorna:
+//   []Line: uma linha reta por aresta (nil se a figura não tem faces)
+func (f *Figure) FaceEdges() []Line {
+	var linhas []Line
+	seen := make(map[[2]int]bool)
+	for _, face := range f.Faces {
+		for i, a := range face.Pontos {
+			b := face.Pontos[(i+1)%len(face.Pontos)]
+			key := [2]int{min(a, b), max(a, b)}
+			if a == b || seen[key] {
+				continue
+			}
+			seen[key] = true
+			linhas = append(linhas, Line{P1: a, P2: b})
+		}
+	}
+	return linhas
+}
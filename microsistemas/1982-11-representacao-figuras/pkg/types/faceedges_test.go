@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestFigure_FaceEdges(t *testing.T) {
+	// Dois triângulos com um lado comum, percorrido em sentidos opostos,
+	// e um triângulo que repete um vértice
+	f := Figure{
+		Pontos: []Point3D{{}, {X: 1}, {X: 1, Y: 1}, {Y: 1}},
+		Faces: []Face{
+			{Pontos: []int{0, 1, 2}},
+			{Pontos: []int{0, 2, 3}},
+			{Pontos: []int{3, 3, 0}},
+		},
+	}
+
+	got := f.FaceEdges()
+	want := [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 0}}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d edges, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if l := got[i]; l.P1 != w[0] || l.P2 != w[1] || l.Direcionada || len(l.Controle) > 0 {
+			t.Errorf("Edge %d: expected %v, got %+v", i, w, l)
+		}
+	}
+
+	if edges := (&Figure{Pontos: f.Pontos}).FaceEdges(); edges != nil {
+		t.Errorf("Expected no edges without faces, got %+v", edges)
+	}
+}
+
+func TestFigure_WeldEdgesFromFaces(t *testing.T) {
+	// Quadrado em dois triângulos com os vértices da diagonal repetidos:
+	// as linhas derivadas acompanham as faces depois da fusão
+	f := Figure{
+		Pontos: []Point3D{{}, {X: 1}, {X: 1, Y: 1}, {}, {X: 1, Y: 1}, {Y: 1}},
+		Faces:  []Face{{Pontos: []int{0, 1, 2}}, {Pontos: []int{3, 4, 5}}},
+	}
+	f.Linhas = f.FaceEdges()
+	f.EdgesFromFaces = true
+
+	if _, err := f.Weld(0); err != nil {
+		t.Fatalf("Weld failed: %v", err)
+	}
+	if len(f.Pontos) != 4 || len(f.Linhas) != 5 {
+		t.Errorf("Expected 4 points and 5 edges, got %d and %+v", len(f.Pontos), f.Linhas)
+	}
+}
@@ -0,0 +1,141 @@
+package types
+
+import "math"
+
+// Quaternion representa uma rotação no espaço como quatérnio unitário.
+//
+// Rotações compostas por ângulos de Euler (RotateX, RotateY, RotateZ)
+// sofrem do "gimbal lock": em certas orientações dois eixos se alinham
+// e um grau de liberdade se perde, o que produz saltos ao interpolar
+// entre orientações. O quatérnio q = (cos θ/2, sen θ/2 · eixo) descreve
+// diretamente uma rotação de θ em torno de um eixo arbitrário e pode
+// ser interpolado suavemente (ver Slerp) ou
+// convertido em matriz (ver ToMat4).
+type Quaternion struct {
+	W, X, Y, Z float64 // Parte escalar (W) e vetorial (X, Y, Z)
+}
+
+// IdentityQuaternion retorna o quatérnio da rotação nula.
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// QuaternionFromAxisAngle cria a rotação de um ângulo em torno de um eixo.
+//
+// Parâmetros:
+//   axis: direção do eixo (não precisa ser unitária)
+//   degrees: ângulo em graus, pela regra da mão direita
+//
+// Retorna:
+//   Quaternion: rotação unitária (identidade se o eixo for nulo)
+func QuaternionFromAxisAngle(axis Point3D, degrees float64) Quaternion {
+	length := math.Sqrt(axis.X*axis.X + axis.Y*axis.Y + axis.Z*axis.Z)
+	if length == 0 {
+		return IdentityQuaternion()
+	}
+
+	s, c := math.Sincos(degrees * math.Pi / 360) // sen e cos de θ/2
+	k := s / length
+	return Quaternion{W: c, X: axis.X * k, Y: axis.Y * k, Z: axis.Z * k}
+}
+
+// Mul retorna o produto de Hamilton q · o.
+//
+// Como em Mat4.Mul, a rotação resultante executa primeiro o e depois q.
+func (q Quaternion) Mul(o Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*o.W - q.X*o.X - q.Y*o.Y - q.Z*o.Z,
+		X: q.W*o.X + q.X*o.W + q.Y*o.Z - q.Z*o.Y,
+		Y: q.W*o.Y - q.X*o.Z + q.Y*o.W + q.Z*o.X,
+		Z: q.W*o.Z + q.X*o.Y - q.Y*o.X + q.Z*o.W,
+	}
+}
+
+// Dot retorna o produto escalar entre dois quatérnios.
+func (q Quaternion) Dot(o Quaternion) float64 {
+	return q.W*o.W + q.X*o.X + q.Y*o.Y + q.Z*o.Z
+}
+
+// Normalize retorna o quatérnio com norma 1 (identidade se for nulo).
+//
+// Produtos sucessivos acumulam erros de arredondamento; normalizar de
+// tempos em tempos mantém a rotação livre de deformações.
+func (q Quaternion) Normalize() Quaternion {
+	n := math.Sqrt(q.Dot(q))
+	if n == 0 {
+		return IdentityQuaternion()
+	}
+	return Quaternion{W: q.W / n, X: q.X / n, Y: q.Y / n, Z: q.Z / n}
+}
+
+// Conjugate retorna o conjugado, que num quatérnio unitário é a rotação inversa.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Rotate aplica a rotação a um ponto (em torno da origem), preservando o nome.
+func (q Quaternion) Rotate(p Point3D) Point3D {
+	return q.ToMat4().Transform(p)
+}
+
+// ToMat4 converte a rotação em matriz, para compor com translações e escalas.
+//
+// Usa a forma fechada da matriz de rotação de um quatérnio unitário.
+func (q Quaternion) ToMat4() Mat4 {
+	q = q.Normalize()
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+
+	m := Identity()
+	m[0][0] = 1 - 2*(y*y+z*z)
+	m[0][1] = 2 * (x*y - w*z)
+	m[0][2] = 2 * (x*z + w*y)
+	m[1][0] = 2 * (x*y + w*z)
+	m[1][1] = 1 - 2*(x*x+z*z)
+	m[1][2] = 2 * (y*z - w*x)
+	m[2][0] = 2 * (x*z - w*y)
+	m[2][1] = 2 * (y*z + w*x)
+	m[2][2] = 1 - 2*(x*x+y*y)
+	return m
+}
+
+// Slerp interpola esfericamente entre duas rotações.
+//
+// A rotação intermediária gira com velocidade angular constante pelo
+// menor arco entre a e b:
+//   slerp(a, b, t) = a·sen((1-t)Ω)/sen Ω + b·sen(tΩ)/sen Ω
+// onde cos Ω = a·b. Para rotações quase iguais, usa interpolação linear
+// normalizada, numericamente mais estável.
+//
+// Parâmetros:
+//   a: rotação inicial (t = 0)
+//   b: rotação final (t = 1)
+//   t: fração da interpolação, normalmente entre 0 e 1
+//
+// Retorna:
+//   Quaternion: rotação intermediária, unitária
+func Slerp(a, b Quaternion, t float64) Quaternion {
+	a, b = a.Normalize(), b.Normalize()
+
+	// q e -q representam a mesma rotação: escolhe o sinal que
+	// percorre o menor arco
+	cos := a.Dot(b)
+	if cos < 0 {
+		b = Quaternion{W: -b.W, X: -b.X, Y: -b.Y, Z: -b.Z}
+		cos = -cos
+	}
+
+	wa, wb := 1-t, t
+	if cos < 0.9995 {
+		omega := math.Acos(cos)
+		sin := math.Sin(omega)
+		wa = math.Sin((1-t)*omega) / sin
+		wb = math.Sin(t*omega) / sin
+	}
+
+	return Quaternion{
+		W: wa*a.W + wb*b.W,
+		X: wa*a.X + wb*b.X,
+		Y: wa*a.Y + wb*b.Y,
+		Z: wa*a.Z + wb*b.Z,
+	}.Normalize()
+}
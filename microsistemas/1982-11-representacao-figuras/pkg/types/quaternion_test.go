@@ -0,0 +1,130 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuaternionFromAxisAngle(t *testing.T) {
+	p := Point3D{X: 1, Y: 2, Z: 3}
+
+	// Rotações em torno dos eixos coordenados coincidem com as matrizes
+	tests := []struct {
+		name string
+		axis Point3D
+		m    Mat4
+	}{
+		{"x", Point3D{X: 1}, RotateX(90)},
+		{"y", Point3D{Y: 2}, RotateY(90)}, // Eixo não unitário
+		{"z", Point3D{Z: 1}, RotateZ(90)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := QuaternionFromAxisAngle(tt.axis, 90)
+			if got, want := q.Rotate(p), tt.m.Transform(p); !pointNear(got, want) {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		})
+	}
+
+	// Eixo arbitrário: 120° em torno da diagonal permuta os eixos
+	q := QuaternionFromAxisAngle(Point3D{X: 1, Y: 1, Z: 1}, 120)
+	if got := q.Rotate(Point3D{X: 1}); !pointNear(got, Point3D{Y: 1}) {
+		t.Errorf("Expected X axis to map to Y, got %v", got)
+	}
+
+	if q := QuaternionFromAxisAngle(Point3D{}, 45); q != IdentityQuaternion() {
+		t.Errorf("Expected identity for null axis, got %v", q)
+	}
+}
+
+func TestQuaternionToMat4(t *testing.T) {
+	// A matriz do quatérnio coincide com as rotações em torno dos eixos,
+	// elemento a elemento
+	for _, degrees := range []float64{30, 90, 135, -60} {
+		tests := []struct {
+			name string
+			axis Point3D
+			m    Mat4
+		}{
+			{"x", Point3D{X: 1}, RotateX(degrees)},
+			{"y", Point3D{Y: 1}, RotateY(degrees)},
+			{"z", Point3D{Z: 3}, RotateZ(degrees)}, // Eixo não unitário
+		}
+		for _, tt := range tests {
+			got := QuaternionFromAxisAngle(tt.axis, degrees).ToMat4()
+			for i := 0; i < 4; i++ {
+				for j := 0; j < 4; j++ {
+					if math.Abs(got[i][j]-tt.m[i][j]) > 1e-9 {
+						t.Errorf("%s %g°: expected m[%d][%d] = %g, got %g", tt.name, degrees, i, j, tt.m[i][j], got[i][j])
+					}
+				}
+			}
+		}
+	}
+
+	// Quatérnios não unitários são normalizados antes da conversão
+	q := QuaternionFromAxisAngle(Point3D{X: 1}, 90)
+	scaled := Quaternion{W: 2 * q.W, X: 2 * q.X, Y: 2 * q.Y, Z: 2 * q.Z}
+	if got, want := scaled.ToMat4().Transform(Point3D{Y: 1}), q.Rotate(Point3D{Y: 1}); !pointNear(got, want) {
+		t.Errorf("Expected scaled quaternion to rotate like the unit one, got %v", got)
+	}
+}
+
+func TestQuaternionMul(t *testing.T) {
+	a := QuaternionFromAxisAngle(Point3D{Z: 1}, 90)
+	b := QuaternionFromAxisAngle(Point3D{X: 1}, 90)
+	p := Point3D{X: 1, Y: 2, Z: 3}
+
+	// a·b executa b e depois a, como RotateX(90).Then(RotateZ(90))
+	want := RotateX(90).Then(RotateZ(90)).Transform(p)
+	if got := a.Mul(b).Rotate(p); !pointNear(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// O conjugado desfaz a rotação
+	if got := a.Conjugate().Rotate(a.Rotate(p)); !pointNear(got, p) {
+		t.Errorf("Expected conjugate to undo rotation, got %v", got)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	a := IdentityQuaternion()
+	b := QuaternionFromAxisAngle(Point3D{Z: 1}, 90)
+
+	if got := Slerp(a, b, 0); math.Abs(got.Dot(a)-1) > 1e-9 {
+		t.Errorf("Expected start rotation at t=0, got %v", got)
+	}
+	if got := Slerp(a, b, 1); math.Abs(got.Dot(b)-1) > 1e-9 {
+		t.Errorf("Expected end rotation at t=1, got %v", got)
+	}
+
+	// Norma 1 ao longo de todo o caminho, mesmo com entradas não unitárias
+	scaled := Quaternion{W: 3 * b.W, X: 3 * b.X, Y: 3 * b.Y, Z: 3 * b.Z}
+	for _, tt := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1} {
+		if n := Slerp(a, scaled, tt).Dot(Slerp(a, scaled, tt)); math.Abs(n-1) > 1e-9 {
+			t.Errorf("Expected unit quaternion at t=%g, got norm² %g", tt, n)
+		}
+	}
+	if got := Slerp(a, scaled, 1).Rotate(Point3D{X: 1}); !pointNear(got, Point3D{Y: 1}) {
+		t.Errorf("Expected end rotation with non-unit input, got %v", got)
+	}
+
+	// Meio do caminho: 45° em torno de Z
+	half := Slerp(a, b, 0.5)
+	if got, want := half.Rotate(Point3D{X: 1}), RotateZ(45).Transform(Point3D{X: 1}); !pointNear(got, want) {
+		t.Errorf("Expected 45° rotation at t=0.5, got %v", got)
+	}
+
+	// Pelo menor arco, mesmo com o sinal oposto
+	neg := Quaternion{W: -b.W, X: -b.X, Y: -b.Y, Z: -b.Z}
+	if got := Slerp(a, neg, 0.5).Rotate(Point3D{X: 1}); !pointNear(got, RotateZ(45).Transform(Point3D{X: 1})) {
+		t.Errorf("Expected shortest arc with negated quaternion, got %v", got)
+	}
+
+	// Rotações quase iguais não devem gerar NaN
+	near := Slerp(b, QuaternionFromAxisAngle(Point3D{Z: 1}, 90.001), 0.5)
+	if math.IsNaN(near.W) {
+		t.Error("Expected finite result for nearly equal rotations")
+	}
+}
@@ -0,0 +1,196 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// stylePresets contém os estilos visuais pré-definidos, indexados pelo
+// nome canônico. Cada estilo é um conjunto de configurações de
+// renderização aplicado de uma vez (ver ApplyStyle).
+var stylePresets = map[string]RenderSettings{
+	// Cópia heliográfica (blueprint): linhas brancas sobre o azul das
+	// antigas cópias de engenharia, com grade e carimbo
+	"blueprint": {
+		Background:      "#1d4f91",
+		LineColor:       "white",
+		VertexColor:     "white",
+		FaceColor:       "#2a62a8",
+		HiddenEdgeColor: "#8fb0dc",
+		Grid:            &Grid{Spacing: 25, Color: "rgba(255, 255, 255, 0.15)"},
+		TitleBlock:      &TitleBlock{},
+	},
+
+	// Paleta de Okabe e Ito, distinguível por quem tem daltonismo:
+	// linhas azuis, vértices vermelhão e faces azul-celeste sobre branco
+	"okabe-ito": {
+		Background:      "white",
+		LineColor:       "#0072b2",
+		VertexColor:     "#d55e00",
+		FaceColor:       "#56b4e9",
+		HiddenEdgeColor: "#e69f00",
+		DimensionColor:  "#009e73",
+		LabelHalo:       "contorno",
+	},
+
+	// Alto contraste: linhas amarelas e grossas sobre preto, vértices
+	// brancos e nomes contornados em preto
+	"alto-contraste": {
+		Background:      "black",
+		LineColor:       "#ffff00",
+		VertexColor:     "white",
+		FaceColor:       "#333333",
+		HiddenEdgeColor: "white",
+		DimensionColor:  "white",
+		LineWidth:       2,
+		LabelHalo:       "contorno",
+		LabelHaloColor:  "black",
+	},
+}
+
+// styleAliases mapeia nomes alternativos para os nomes canônicos dos estilos.
+var styleAliases = map[string]string{
+	"heliografica":   "blueprint",
+	"heliográfica":   "blueprint",
+	"planta":         "blueprint",
+	"okabe_ito":      "okabe-ito",
+	"okabeito":       "okabe-ito",
+	"daltonismo":     "okabe-ito",
+	"alto_contraste": "alto-contraste",
+	"contraste":      "alto-contraste",
+	"high-contrast":  "alto-contraste",
+}
+
+// StyleNames retorna os nomes canônicos dos estilos pré-definidos, ordenados.
+func StyleNames() []string {
+	names := make([]string, 0, len(stylePresets))
+	for name := range stylePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StyleSettings retorna as configurações de um estilo pré-definido.
+//
+// Parâmetros:
+//   name: nome do estilo (ex: "blueprint")
+//
+// Retorna:
+//   RenderSettings: configurações do estilo (cópia independente)
+//   error: erro se o nome não corresponde a nenhum estilo conhecido
+func StyleSettings(name string) (RenderSettings, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := styleAliases[key]; ok {
+		key = alias
+	}
+
+	style, ok := stylePresets[key]
+	if !ok {
+		return RenderSettings{}, fmt.Errorf("estilo desconhecido: %q (disponíveis: %s)",
+			name, strings.Join(StyleNames(), ", "))
+	}
+
+	var settings RenderSettings
+	settings.Overlay(style)
+	return settings, nil
+}
+
+// ApplyStyle aplica um estilo pré-definido às configurações de
+// renderização da figura.
+//
+// Os campos definidos pelo estilo substituem os do YAML; os demais
+// (dimensões da imagem, faces, iluminação...) são preservados.
+//
+// Parâmetros:
+//   name: nome do estilo (ex: "blueprint")
+//
+// Retorna:
+//   error: erro se o nome não corresponde a nenhum estilo conhecido
+func (f *Figure) ApplyStyle(name string) error {
+	style, err := StyleSettings(name)
+	if err != nil {
+		return err
+	}
+
+	if f.Render == nil {
+		f.Render = &RenderSettings{}
+	}
+	f.Render.Overlay(style)
+	return nil
+}
+
+// Overlay sobrepõe às configurações os campos definidos em other.
+//
+// Campos com valor zero em other (textos vazios, números nulos,
+// ponteiros nil) são considerados não definidos e mantêm o valor atual.
+// Campos apontados (luz, gradiente...) são copiados, para que alterações
+// posteriores não afetem other.
+//
+// Parâmetros:
+//   other: configurações a sobrepor
+func (s *RenderSettings) Overlay(other RenderSettings) {
+	dst := reflect.ValueOf(s).Elem()
+	src := reflect.ValueOf(other)
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if field.IsZero() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			copied := reflect.New(field.Elem().Type())
+			copied.Elem().Set(field.Elem())
+			field = copied
+		}
+		dst.Field(i).Set(field)
+	}
+}
+
+// Clone retorna uma cópia independente das configurações.
+//
+// Os campos apontados (luz, marca d'água...) e os ponteiros dentro
+// deles (opacidade, luz ambiente...) também são copiados: alterar a
+// cópia, por exemplo com Overlay, não afeta o original.
+//
+// Retorna:
+//   *RenderSettings: cópia (nil se s for nil)
+func (s *RenderSettings) Clone() *RenderSettings {
+	if s == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(s)).Interface().(*RenderSettings)
+}
+
+// deepCopy copia um valor seguindo ponteiros, structs e fatias.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	}
+	return v
+}
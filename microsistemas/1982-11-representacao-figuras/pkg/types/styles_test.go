@@ -0,0 +1,112 @@
+package types
+
+import "testing"
+
+func TestApplyStyle(t *testing.T) {
+	for _, name := range []string{"blueprint", "Blueprint", "heliografica"} {
+		figure := &Figure{Render: &RenderSettings{CanvasWidth: 640, LineColor: "black", FaceColor: "red"}}
+		if err := figure.ApplyStyle(name); err != nil {
+			t.Fatalf("ApplyStyle(%q) failed: %v", name, err)
+		}
+
+		r := figure.Render
+		if r.LineColor != "white" || r.Background == "" || r.Grid == nil || r.TitleBlock == nil {
+			t.Errorf("%q: expected blueprint colors, grid and title block, got %+v", name, r)
+		}
+		if r.CanvasWidth != 640 {
+			t.Errorf("%q: expected canvas width to be preserved, got %d", name, r.CanvasWidth)
+		}
+	}
+
+	// Figura sem seção render
+	figure := &Figure{}
+	if err := figure.ApplyStyle("blueprint"); err != nil || figure.Render == nil {
+		t.Fatalf("Expected render settings to be created, got %+v (%v)", figure.Render, err)
+	}
+
+	// Alterações na figura não afetam o estilo
+	figure.Render.Grid.Spacing = 99
+	other := &Figure{}
+	other.ApplyStyle("blueprint")
+	if other.Render.Grid.Spacing == 99 {
+		t.Error("Expected each figure to get its own copy of the style")
+	}
+}
+
+func TestApplyStyle_Accessible(t *testing.T) {
+	cases := map[string]string{
+		"okabe-ito":      "#0072b2",
+		"daltonismo":     "#0072b2",
+		"alto-contraste": "#ffff00",
+		"high-contrast":  "#ffff00",
+	}
+	for name, line := range cases {
+		figure := &Figure{Render: &RenderSettings{CanvasWidth: 640}}
+		if err := figure.ApplyStyle(name); err != nil {
+			t.Fatalf("ApplyStyle(%q) failed: %v", name, err)
+		}
+		r := figure.Render
+		if r.LineColor != line || r.VertexColor == "" || r.LabelHalo == "" {
+			t.Errorf("%q: expected line color %s, vertex color and label halo, got %+v", name, line, r)
+		}
+		if r.CanvasWidth != 640 {
+			t.Errorf("%q: expected canvas width to be preserved, got %d", name, r.CanvasWidth)
+		}
+	}
+}
+
+func TestApplyStyle_Unknown(t *testing.T) {
+	figure := &Figure{}
+	if err := figure.ApplyStyle("aquarela"); err == nil {
+		t.Error("Expected error for unknown style, got nil")
+	}
+	if figure.Render != nil {
+		t.Errorf("Expected render settings untouched, got %+v", figure.Render)
+	}
+}
+
+func TestRenderSettings_Overlay(t *testing.T) {
+	show := true
+	settings := RenderSettings{Background: "white", LineWidth: 2, ShowVertices: &show}
+	settings.Overlay(RenderSettings{Background: "black", Seed: 7})
+
+	if settings.Background != "black" || settings.Seed != 7 {
+		t.Errorf("Expected defined fields to be overlaid, got %+v", settings)
+	}
+	if settings.LineWidth != 2 || settings.ShowVertices != &show {
+		t.Errorf("Expected zero fields to keep current values, got %+v", settings)
+	}
+}
+
+func TestRenderSettings_Clone(t *testing.T) {
+	show, opacity, ambient := true, 0.3, 0.5
+	original := &RenderSettings{
+		Background: "white",
+		ShowGrid:   &show,
+		Watermark:  &Watermark{Text: "revista", Opacity: &opacity},
+		Light:      &Light{Ambient: &ambient},
+	}
+	clone := original.Clone()
+
+	// Alterações na cópia, inclusive nos ponteiros aninhados, não
+	// chegam ao original
+	clone.Overlay(RenderSettings{Background: "black"})
+	*clone.ShowGrid = false
+	clone.Watermark.Text = "outra"
+	*clone.Watermark.Opacity = 1
+	*clone.Light.Ambient = 0
+
+	if original.Background != "white" || !*original.ShowGrid {
+		t.Errorf("Expected original fields unchanged, got %+v", original)
+	}
+	if original.Watermark.Text != "revista" || *original.Watermark.Opacity != 0.3 {
+		t.Errorf("Expected original watermark unchanged, got %+v", original.Watermark)
+	}
+	if *original.Light.Ambient != 0.5 {
+		t.Errorf("Expected original ambient light 0.5, got %g", *original.Light.Ambient)
+	}
+
+	if (*RenderSettings)(nil).Clone() != nil {
+		t.Error("Expected nil clone of nil settings")
+	}
+}
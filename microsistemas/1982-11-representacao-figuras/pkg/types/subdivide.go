@@ -0,0 +1,103 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MaxSubdivision é o maior número de trechos em que a aresta mais longa
+// pode ser dividida por SubdivideLongest.
+const MaxSubdivision = 1000
+
+// Subdivide divide as arestas retas da figura em trechos de no máximo
+// maxLength, modificando-a no lugar.
+//
+// A projeção leva cada aresta inteira de uma vez: uma cor por aresta na
+// profundidade, a névoa calculada no ponto médio, e projeções que
+// curvam as retas não teriam onde curvá-las. Dividida em trechos mais
+// curtos, a aresta passa a ter vários pontos ao longo dela, e cada
+// trecho é tratado por conta própria, como já acontece com as curvas de
+// Bézier (ver Line.Curve).
+//
+// Cada aresta vira ceil(comprimento / maxLength) trechos iguais, ligados
+// por pontos sem nome acrescentados ao fim da lista, de modo que os
+// índices dos pontos existentes (e as faces e cotas que os usam) não
+// mudam. Numa linha direcionada, só o último trecho leva a seta. As
+// curvas e as arestas das faces ficam como estão.
+//
+// Parâmetros:
+//   maxLength: comprimento máximo de cada trecho (deve ser positivo)
+//
+// Retorna:
+//   int: número de pontos acrescentados
+func (f *Figure) Subdivide(maxLength float64) int {
+	if !(maxLength > 0) {
+		return 0
+	}
+	// Os pontos novos não podem ir parar no vetor compartilhado com uma
+	// cópia rasa da figura
+	f.Pontos = slices.Clip(f.Pontos)
+	added := 0
+	linhas := make([]Line, 0, len(f.Linhas))
+	for _, linha := range f.Linhas {
+		length, ok := f.straightLength(linha)
+		n := int(math.Ceil(length / maxLength))
+		if !ok || n <= 1 {
+			linhas = append(linhas, linha)
+			continue
+		}
+
+		a, b := f.Pontos[linha.P1], f.Pontos[linha.P2]
+		prev := linha.P1
+		for k := 1; k < n; k++ {
+			t := float64(k) / float64(n)
+			f.Pontos = append(f.Pontos, Point3D{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y), Z: a.Z + t*(b.Z-a.Z)})
+			next := len(f.Pontos) - 1
+			linhas = append(linhas, Line{P1: prev, P2: next})
+			prev = next
+		}
+		linhas = append(linhas, Line{P1: prev, P2: linha.P2, Direcionada: linha.Direcionada})
+		added += n - 1
+	}
+	f.Linhas = linhas
+	return added
+}
+
+// SubdivideLongest divide as arestas retas da figura de modo que a mais
+// longa fique com n trechos; as demais são divididas em trechos do
+// mesmo comprimento máximo (ver Subdivide), e as curtas ficam inteiras.
+//
+// Parâmetros:
+//   n: trechos da aresta mais longa (1 = nenhuma divisão)
+//
+// Retorna:
+//   int: número de pontos acrescentados
+//   error: erro se n estiver fora de 1 a MaxSubdivision
+func (f *Figure) SubdivideLongest(n int) (int, error) {
+	if n < 1 || n > MaxSubdivision {
+		return 0, fmt.Errorf("número de trechos inválido: %d (use de 1 a %d)", n, MaxSubdivision)
+	}
+	longest := 0.0
+	for _, linha := range f.Linhas {
+		if length, ok := f.straightLength(linha); ok {
+			longest = math.Max(longest, length)
+		}
+	}
+	if n == 1 || longest == 0 {
+		return 0, nil
+	}
+	// Uma folga, para que a aresta mais longa não ganhe um trecho a mais
+	// por arredondamento
+	return f.Subdivide(longest / float64(n) * (1 + 1e-9)), nil
+}
+
+// straightLength retorna o comprimento de uma aresta reta, ou false se
+// ela for curva ou apontar para pontos inexistentes.
+func (f *Figure) straightLength(linha Line) (float64, bool) {
+	if len(linha.Controle) > 0 || linha.P1 < 0 || linha.P2 < 0 || linha.P1 >= len(f.Pontos) || linha.P2 >= len(f.Pontos) {
+		return 0, false
+	}
+	a, b := f.Pontos[linha.P1], f.Pontos[linha.P2]
+	return math.Sqrt((b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y) + (b.Z-a.Z)*(b.Z-a.Z)), true
+}
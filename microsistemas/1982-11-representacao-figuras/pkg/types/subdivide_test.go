@@ -0,0 +1,85 @@
+package types
+
+import "testing"
+
+func TestFigure_Subdivide(t *testing.T) {
+	f := Figure{
+		Pontos: []Point3D{{Nome: "A"}, {X: 4, Nome: "B"}, {X: 4, Y: 1}, {X: 4, Y: 1, Z: 3}},
+		Linhas: []Line{
+			{P1: 0, P2: 1, Direcionada: true},           // 4 trechos
+			{P1: 1, P2: 2},                              // Curta: fica inteira
+			{P1: 2, P2: 3},                              // 3 trechos
+			{P1: 0, P2: 3, Controle: []Point3D{{Z: 5}}}, // Curva: fica inteira
+		},
+		Faces: []Face{{Pontos: []int{0, 1, 2}}},
+	}
+
+	if added := f.Subdivide(1); added != 5 {
+		t.Errorf("Expected 5 new points, got %d", added)
+	}
+	if len(f.Pontos) != 9 || len(f.Linhas) != 9 {
+		t.Fatalf("Expected 9 points and 9 lines, got %d and %d", len(f.Pontos), len(f.Linhas))
+	}
+
+	// Os pontos originais ficam nos mesmos índices, e os novos não têm nome
+	if f.Pontos[0].Nome != "A" || f.Pontos[1].Nome != "B" || f.Pontos[4].Nome != "" {
+		t.Errorf("Expected original points unchanged, got %+v", f.Pontos)
+	}
+	for i, want := range []Point3D{{X: 1}, {X: 2}, {X: 3}, {X: 4, Y: 1, Z: 1}, {X: 4, Y: 1, Z: 2}} {
+		if !pointNear(f.Pontos[4+i], want) {
+			t.Errorf("Point %d: expected %v, got %v", 4+i, want, f.Pontos[4+i])
+		}
+	}
+
+	// A linha de A a B vira uma cadeia, com a seta só no último trecho
+	chain := [][2]int{{0, 4}, {4, 5}, {5, 6}, {6, 1}}
+	for i, want := range chain {
+		l := f.Linhas[i]
+		if l.P1 != want[0] || l.P2 != want[1] || l.Direcionada != (i == len(chain)-1) {
+			t.Errorf("Line %d: expected %v (arrow %v), got %+v", i, want, i == len(chain)-1, l)
+		}
+	}
+	if l := f.Linhas[4]; l.P1 != 1 || l.P2 != 2 {
+		t.Errorf("Expected short line unchanged, got %+v", l)
+	}
+	if l := f.Linhas[8]; len(l.Controle) != 1 {
+		t.Errorf("Expected curve unchanged, got %+v", l)
+	}
+	if len(f.Faces[0].Pontos) != 3 {
+		t.Errorf("Expected faces unchanged, got %+v", f.Faces)
+	}
+
+	// Comprimento inválido não altera a figura
+	if added := f.Subdivide(0); added != 0 || len(f.Linhas) != 9 {
+		t.Errorf("Expected no change for zero length, got %d new points", added)
+	}
+}
+
+func TestFigure_SubdivideLongest(t *testing.T) {
+	square := func() *Figure {
+		return &Figure{
+			Pontos: []Point3D{{}, {X: 2}, {X: 2, Y: 1}},
+			Linhas: []Line{{P1: 0, P2: 1}, {P1: 1, P2: 2}, {P1: 2, P2: 0}},
+		}
+	}
+
+	// A mais longa (raiz de 5) fica com 4 trechos; a de 2, com 4; a de 1, com 2
+	f := square()
+	added, err := f.SubdivideLongest(4)
+	if err != nil {
+		t.Fatalf("SubdivideLongest failed: %v", err)
+	}
+	if added != 7 || len(f.Linhas) != 10 {
+		t.Errorf("Expected 7 new points and 10 lines, got %d and %d", added, len(f.Linhas))
+	}
+
+	f = square()
+	if added, err := f.SubdivideLongest(1); err != nil || added != 0 || len(f.Linhas) != 3 {
+		t.Errorf("Expected no subdivision for n = 1, got %d (%v)", added, err)
+	}
+	for _, n := range []int{0, -1, MaxSubdivision + 1} {
+		if _, err := square().SubdivideLongest(n); err == nil {
+			t.Errorf("Expected error for %d segments, got nil", n)
+		}
+	}
+}
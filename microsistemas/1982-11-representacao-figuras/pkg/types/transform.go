@@ -0,0 +1,135 @@
+package types
+
+import "math"
+
+// Mat4 é uma matriz 4×4 de transformação em coordenadas homogêneas.
+//
+// O artigo define as figuras diretamente pelas coordenadas dos vértices;
+// para mover, girar ou escalar uma figura era preciso recalcular os
+// pontos à mão no programa BASIC. Com coordenadas homogêneas, qualquer
+// combinação de translações, rotações e escalas vira uma única matriz:
+//   [x' y' z' 1]ᵀ = M · [x y z 1]ᵀ
+//
+// A matriz é armazenada por linhas (m[linha][coluna]) e atua sobre
+// vetores coluna, de modo que a translação ocupa a última coluna.
+type Mat4 [4][4]float64
+
+// Identity retorna a matriz identidade (transformação nula).
+func Identity() Mat4 {
+	return Mat4{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Translate retorna a translação pelo vetor (x, y, z).
+func Translate(x, y, z float64) Mat4 {
+	m := Identity()
+	m[0][3] = x
+	m[1][3] = y
+	m[2][3] = z
+	return m
+}
+
+// Scale retorna a escala pelos fatores (x, y, z) em relação à origem.
+func Scale(x, y, z float64) Mat4 {
+	m := Identity()
+	m[0][0] = x
+	m[1][1] = y
+	m[2][2] = z
+	return m
+}
+
+// RotateX retorna a rotação em torno do eixo X.
+//
+// O ângulo é em graus, positivo no sentido anti-horário para quem olha
+// do lado positivo do eixo para a origem (regra da mão direita).
+func RotateX(degrees float64) Mat4 {
+	s, c := sinCos(degrees)
+	m := Identity()
+	m[1][1], m[1][2] = c, -s
+	m[2][1], m[2][2] = s, c
+	return m
+}
+
+// RotateY retorna a rotação em torno do eixo Y (graus, regra da mão direita).
+func RotateY(degrees float64) Mat4 {
+	s, c := sinCos(degrees)
+	m := Identity()
+	m[0][0], m[0][2] = c, s
+	m[2][0], m[2][2] = -s, c
+	return m
+}
+
+// RotateZ retorna a rotação em torno do eixo Z, o eixo vertical das
+// figuras (graus, regra da mão direita).
+func RotateZ(degrees float64) Mat4 {
+	s, c := sinCos(degrees)
+	m := Identity()
+	m[0][0], m[0][1] = c, -s
+	m[1][0], m[1][1] = s, c
+	return m
+}
+
+// sinCos retorna seno e cosseno de um ângulo em graus.
+func sinCos(degrees float64) (float64, float64) {
+	return math.Sincos(degrees * math.Pi / 180)
+}
+
+// Mul retorna o produto m · o.
+//
+// Aplicado a um ponto, o produto executa primeiro o e depois m. Para
+// encadear transformações na ordem de leitura, use Then.
+func (m Mat4) Mul(o Mat4) Mat4 {
+	var r Mat4
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				r[i][j] += m[i][k] * o[k][j]
+			}
+		}
+	}
+	return r
+}
+
+// Then compõe duas transformações: primeiro m, depois o.
+//
+// Permite escrever a sequência na ordem em que as operações acontecem:
+//   RotateZ(30).Then(Scale(2, 2, 2)).Then(Translate(0, 5, 0))
+func (m Mat4) Then(o Mat4) Mat4 {
+	return o.Mul(m)
+}
+
+// Transform aplica a matriz a um ponto, preservando seu nome.
+//
+// Se a matriz tiver componente projetiva (última linha diferente de
+// 0 0 0 1), o resultado é dividido pela coordenada homogênea w.
+func (m Mat4) Transform(p Point3D) Point3D {
+	x := m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z + m[0][3]
+	y := m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z + m[1][3]
+	z := m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z + m[2][3]
+	w := m[3][0]*p.X + m[3][1]*p.Y + m[3][2]*p.Z + m[3][3]
+
+	if w != 1 && w != 0 {
+		x, y, z = x/w, y/w, z/w
+	}
+	return Point3D{X: x, Y: y, Z: z, Nome: p.Nome}
+}
+
+// Apply transforma todos os pontos da figura, modificando-a no lugar.
+//
+// As linhas referenciam os pontos por índice e não precisam mudar,
+// exceto pelos pontos de controle das curvas, que são coordenadas e
+// acompanham a transformação; a câmera permanece onde está.
+func (f *Figure) Apply(m Mat4) {
+	for i, p := range f.Pontos {
+		f.Pontos[i] = m.Transform(p)
+	}
+	for _, linha := range f.Linhas {
+		for j, c := range linha.Controle {
+			linha.Controle[j] = m.Transform(c)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMat4_Constructors(t *testing.T) {
+	p := Point3D{X: 1, Y: 2, Z: 3, Nome: "P"}
+
+	tests := []struct {
+		name string
+		m    Mat4
+		want Point3D
+	}{
+		{"identity", Identity(), Point3D{X: 1, Y: 2, Z: 3}},
+		{"translate", Translate(1, -2, 0.5), Point3D{X: 2, Y: 0, Z: 3.5}},
+		{"scale", Scale(2, 3, -1), Point3D{X: 2, Y: 6, Z: -3}},
+		{"rotate x", RotateX(90), Point3D{X: 1, Y: -3, Z: 2}},
+		{"rotate y", RotateY(90), Point3D{X: 3, Y: 2, Z: -1}},
+		{"rotate z", RotateZ(90), Point3D{X: -2, Y: 1, Z: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Transform(p)
+			if !pointNear(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if got.Nome != "P" {
+				t.Errorf("Expected point name preserved, got %q", got.Nome)
+			}
+		})
+	}
+}
+
+func TestMat4_Composition(t *testing.T) {
+	p := Point3D{X: 1, Y: 0, Z: 0}
+
+	// Primeiro gira 90° em Z (→ 0,1,0), depois translada
+	m := RotateZ(90).Then(Translate(0, 0, 5))
+	if got := m.Transform(p); !pointNear(got, Point3D{X: 0, Y: 1, Z: 5}) {
+		t.Errorf("Expected rotate then translate (0,1,5), got %v", got)
+	}
+
+	// Mul aplica a ordem inversa: translada primeiro, depois gira
+	m = RotateZ(90).Mul(Translate(1, 0, 0))
+	if got := m.Transform(p); !pointNear(got, Point3D{X: 0, Y: 2, Z: 0}) {
+		t.Errorf("Expected translate then rotate (0,2,0), got %v", got)
+	}
+
+	// Rotações opostas se anulam
+	m = RotateX(37).Then(RotateX(-37))
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if math.Abs(m[i][j]-Identity()[i][j]) > 1e-12 {
+				t.Fatalf("Expected identity, got %v", m)
+			}
+		}
+	}
+}
+
+func TestFigureApply(t *testing.T) {
+	figure := Figure{
+		Pontos: []Point3D{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 1}},
+		Linhas: []Line{{P1: 0, P2: 1}},
+	}
+
+	figure.Apply(Scale(2, 2, 2).Then(Translate(0, 10, 0)))
+
+	if !pointNear(figure.Pontos[0], Point3D{X: 0, Y: 10, Z: 0}) ||
+		!pointNear(figure.Pontos[1], Point3D{X: 2, Y: 12, Z: 2}) {
+		t.Errorf("Unexpected transformed points: %v", figure.Pontos)
+	}
+	if !reflect.DeepEqual(figure.Linhas[0], Line{P1: 0, P2: 1}) {
+		t.Errorf("Expected lines unchanged, got %v", figure.Linhas)
+	}
+}
+
+func TestFigureApply_CurveControlPoints(t *testing.T) {
+	figure := Figure{
+		Pontos: []Point3D{{X: 0, Y: 0, Z: 0}, {X: 2, Y: 0, Z: 0}},
+		Linhas: []Line{{P1: 0, P2: 1, Controle: []Point3D{{X: 1, Y: 0, Z: 1}}}},
+	}
+
+	figure.Apply(Translate(0, 10, 0))
+
+	if got := figure.Linhas[0].Controle[0]; !pointNear(got, Point3D{X: 1, Y: 10, Z: 1}) {
+		t.Errorf("Expected control point to follow the transform, got %v", got)
+	}
+}
+
+// pointNear compara dois pontos com tolerância numérica (ignora o nome)
+func pointNear(a, b Point3D) bool {
+	const eps = 1e-9
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps && math.Abs(a.Z-b.Z) < eps
+}
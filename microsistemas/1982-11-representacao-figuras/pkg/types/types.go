@@ -6,6 +6,13 @@
 // adaptando-os para uma linguagem moderna mantendo fidelidade aos fundamentos.
 package types
 
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
 // Point3D representa um ponto no espaço tridimensional.
 //
 // Sistema de coordenadas conforme o artigo:
@@ -23,18 +30,101 @@ type Point3D struct {
 // Point2D representa um ponto projetado na tela (resultado da projeção 3D→2D).
 //
 // Após aplicar as fórmulas de perspectiva cônica, os pontos 3D são convertidos
-// em coordenadas de tela para renderização. As coordenadas são em pixels.
+// em coordenadas de tela para renderização. Conforme a etapa, as coordenadas
+// são normalizadas (NDC, de -1 a +1 na tela virtual) ou em pixels.
 type Point2D struct {
-	X, Y float64 // Coordenadas na tela em pixels
+	X, Y float64 // Coordenadas na tela (NDC ou pixels)
 }
 
 // Line representa uma linha conectando dois pontos da figura.
 //
 // Conforme descrito no artigo, as figuras são definidas por vértices
 // conectados por segmentos de reta. Esta estrutura armazena os índices
-// dos pontos que devem ser conectados.
+// dos pontos que devem ser conectados. Linhas direcionadas (vetores)
+// terminam numa seta em P2.
+//
+// Com um ou dois pontos de controle, a linha é uma curva de Bézier
+// (quadrática ou cúbica) de P1 a P2, dividida em CurveSegments trechos
+// retos antes da projeção (ver Curve).
 type Line struct {
-	P1, P2 int // Índices dos pontos na lista (base 0)
+	P1, P2      int       // Índices dos pontos na lista (base 0)
+	Direcionada bool      `yaml:"direcionada,omitempty"` // Seta na ponta P2
+	Controle    []Point3D `yaml:"controle,omitempty"`    // Pontos de controle da curva (nenhum = reta)
+}
+
+// CurveSegments é o número de trechos retos em que cada curva é dividida.
+const CurveSegments = 32
+
+// Curve calcula os pontos de uma linha curva, de P1 a P2.
+//
+// A curva de Bézier é avaliada pelo algoritmo de De Casteljau:
+// interpolações lineares sucessivas entre os pontos de controle, que
+// para t de 0 a 1 descrevem a curva. Ela passa por P1 e P2 e, nas
+// pontas, é tangente às retas que levam aos pontos de controle vizinhos.
+//
+// Parâmetros:
+//   points: pontos da figura, referenciados por P1 e P2
+//
+// Retorna:
+//   []Point3D: CurveSegments+1 pontos ao longo da curva (apenas P1 e
+//              P2 para linhas retas)
+func (l Line) Curve(points []Point3D) []Point3D {
+	start, end := points[l.P1], points[l.P2]
+	if len(l.Controle) == 0 {
+		return []Point3D{start, end}
+	}
+
+	control := make([]Point3D, 0, len(l.Controle)+2)
+	control = append(control, start)
+	control = append(control, l.Controle...)
+	control = append(control, end)
+
+	curve := make([]Point3D, CurveSegments+1)
+	work := make([]Point3D, len(control))
+	for i := range curve {
+		t := float64(i) / CurveSegments
+		copy(work, control)
+		for n := len(work) - 1; n > 0; n-- {
+			for k := 0; k < n; k++ {
+				work[k] = Point3D{
+					X: work[k].X + t*(work[k+1].X-work[k].X),
+					Y: work[k].Y + t*(work[k+1].Y-work[k].Y),
+					Z: work[k].Z + t*(work[k+1].Z-work[k].Z),
+				}
+			}
+		}
+		curve[i] = work[0]
+	}
+
+	// As pontas são exatamente os pontos da figura, com os seus nomes
+	curve[0], curve[CurveSegments] = start, end
+	return curve
+}
+
+// Face representa um polígono plano preenchido da figura.
+//
+// O artigo só desenha arestas (aramado); faces permitem representar
+// sólidos, em que as faces mais próximas do observador escondem as
+// mais distantes. Os vértices são índices da lista de pontos, em ordem
+// ao redor do polígono: anti-horária vista de fora do sólido, para que
+// a normal (regra da mão direita) aponte para fora.
+type Face struct {
+	Pontos []int  `yaml:"pontos"`        // Índices dos vértices (base 0), ao menos 3
+	Cor    string `yaml:"cor,omitempty"` // Cor de preenchimento (vazio = cor_faces)
+}
+
+// Dimension descreve uma cota: a distância entre dois pontos nomeados,
+// indicada por uma linha com setas ao lado do segmento que os une, como
+// num desenho técnico.
+//
+// O texto padrão é a distância real no espaço, calculada a partir das
+// coordenadas, e não o comprimento aparente na imagem.
+type Dimension struct {
+	De          string  `yaml:"de"`                    // Nome do ponto inicial
+	Ate         string  `yaml:"ate"`                   // Nome do ponto final
+	Afastamento float64 `yaml:"afastamento,omitempty"` // Distância da linha de cota ao segmento, em pixels (negativa = outro lado)
+	Texto       string  `yaml:"texto,omitempty"`       // Texto no lugar da distância calculada
+	Unidade     string  `yaml:"unidade,omitempty"`     // Unidade escrita após a distância (ex.: cm)
 }
 
 // RenderSettings controla opções visuais de renderização da figura.
@@ -47,17 +137,219 @@ type RenderSettings struct {
 	CanvasWidth  int `yaml:"largura_canvas,omitempty"`  // Largura da imagem
 	CanvasHeight int `yaml:"altura_canvas,omitempty"`   // Altura da imagem
 
+	// Dimensões físicas, para impressão: medidas com unidade ("120mm",
+	// "12cm", "4.7in") convertidas em pixels pela resolução; o dpi
+	// sozinho apenas fica registrado no PNG
+	PrintWidth  string  `yaml:"largura_impressa,omitempty"` // Largura impressa
+	PrintHeight string  `yaml:"altura_impressa,omitempty"`  // Altura impressa
+	DPI         float64 `yaml:"dpi,omitempty"`              // Pontos por polegada (padrão 300)
+
 	// Configurações de cores (nomes ou códigos hex)
 	Background  string `yaml:"fundo,omitempty"`       // Cor de fundo
 	LineColor   string `yaml:"cor_linha,omitempty"`   // Cor das linhas
 	VertexColor string `yaml:"cor_vertices,omitempty"` // Cor dos vértices
+	FaceColor   string `yaml:"cor_faces,omitempty"`    // Cor padrão das faces
+
+	// Fundo em gradiente de duas cores (nil = fundo liso)
+	Gradient *Gradient `yaml:"gradiente,omitempty"`
+
+	// Grade de referência sobre o fundo e carimbo (quadro de título) no
+	// canto inferior direito, como numa prancha de desenho (nil = sem)
+	Grid       *Grid       `yaml:"grade,omitempty"`
+	TitleBlock *TitleBlock `yaml:"carimbo,omitempty"`
+
+	// Título e legenda escritos na imagem, para uso direto em
+	// apresentações (nil = sem)
+	Title   *Annotation `yaml:"titulo,omitempty"`
+	Caption *Annotation `yaml:"legenda,omitempty"`
+
+	// Tabela com os nomes e as coordenadas dos pontos, como as do
+	// artigo (nil = sem)
+	PointTable *PointTable `yaml:"tabela_pontos,omitempty"`
+
+	// Logotipo ou texto semitransparente num canto, para identificar
+	// imagens publicadas em lote (nil = sem)
+	Watermark *Watermark `yaml:"marca_dagua,omitempty"`
+
+	// Eixos X, Y e Z do mundo, projetados pela mesma câmera (nil = sem)
+	Axes *Axes `yaml:"eixos,omitempty"`
+
+	// Cor da caixa envolvente (ver mostrar_caixa)
+	BoundsColor string `yaml:"cor_caixa,omitempty"`
+
+	// Cor das cotas da seção "medidas" (padrão: a cor das linhas)
+	DimensionColor string `yaml:"cor_medidas,omitempty"`
+
+	// Cor das linhas mais distantes do observador: as arestas passam
+	// gradualmente de cor_linha (mais próximas) a esta cor (vazio = desligado)
+	DepthCueColor string `yaml:"cor_distante,omitempty"`
 
 	// Configurações de desenho
 	LineWidth float64 `yaml:"espessura_linha,omitempty"` // Espessura das linhas
+	ArrowSize float64 `yaml:"tamanho_seta,omitempty"`    // Comprimento das setas das linhas direcionadas
+	Antialias string  `yaml:"antialias,omitempty"`       // Superamostragem: 1x, 2x, 3x ou 4x
+	BitDepth  int     `yaml:"bits_por_canal,omitempty"`  // Profundidade do PNG salvo: 8 (padrão) ou 16
+
+	// Fonte dos nomes dos pontos, dos eixos e do carimbo: arquivo
+	// TrueType (relativo ao YAML) e altura em pixels; sem nenhum dos
+	// dois, a fonte de bitmap embutida. Também usada no título e legenda
+	Font     string  `yaml:"fonte,omitempty"`
+	FontSize float64 `yaml:"tamanho_fonte,omitempty"`
+
+	// Estilo das linhas: continuo ou esboco (traço tremido, como a lápis)
+	LineStyle string `yaml:"estilo_linha,omitempty"`
+	Seed      int64  `yaml:"semente,omitempty"` // Semente do tremido do esboço
+
+	// Acabamento das linhas grossas: pontas (redonda, reta ou quadrada)
+	// e junções dos cantos (redonda ou chanfrada)
+	LineCap  string `yaml:"ponta_linha,omitempty"`
+	LineJoin string `yaml:"juncao_linha,omitempty"`
 
 	// Opções de visualização (ponteiros permitem nil = usar padrão)
 	ShowVertices *bool `yaml:"mostrar_vertices,omitempty"` // Mostrar pontos dos vértices
 	ShowLabels   *bool `yaml:"mostrar_nomes,omitempty"`    // Mostrar nomes dos pontos
+	ShowGrid     *bool `yaml:"mostrar_grade,omitempty"`    // Mostrar a grade (padrão: se houver seção grade)
+	ShowAxes     *bool `yaml:"mostrar_eixos,omitempty"`    // Mostrar os eixos (padrão: se houver seção eixos)
+	ShowBounds   *bool `yaml:"mostrar_caixa,omitempty"`    // Mostrar a caixa envolvente da figura
+	ShowTable    *bool `yaml:"mostrar_tabela,omitempty"`   // Mostrar a tabela de pontos (padrão: se houver seção tabela_pontos)
+
+	// Realce atrás dos nomes dos pontos e dos eixos: contorno nas letras
+	// ou fundo (etiqueta), na cor_halo (padrão: a cor de fundo)
+	LabelHalo      string `yaml:"halo_nomes,omitempty"`
+	LabelHaloColor string `yaml:"cor_halo,omitempty"`
+
+	// Faces voltadas para longe do observador são omitidas (sólidos fechados)
+	BackfaceCulling *bool `yaml:"descartar_traseiras,omitempty"`
+
+	// Algoritmo de superfícies ocultas das faces: pintor ou zbuffer
+	HiddenSurface string `yaml:"superficies_ocultas,omitempty"`
+
+	// Arestas das faces: todas ou contorno (só silhueta, bordas e vincos)
+	Edges       string  `yaml:"arestas,omitempty"`
+	CreaseAngle float64 `yaml:"angulo_vinco,omitempty"` // Ângulo mínimo de vinco em graus
+
+	// Arestas escondidas pelas faces: remover ou tracejar (desenho técnico)
+	HiddenEdges     string `yaml:"arestas_ocultas,omitempty"`
+	HiddenEdgeColor string `yaml:"cor_ocultas,omitempty"` // Cor das arestas tracejadas
+
+	// Iluminação das faces por uma luz direcional (nil = sem sombreamento)
+	Light *Light `yaml:"luz,omitempty"`
+
+	// Pontos atrás do observador na projeção cônica
+	NearPlane    float64 `yaml:"plano_proximo,omitempty"` // Profundidade mínima desenhada
+	BehindPolicy string  `yaml:"atras_camera,omitempty"`  // recortar, ajustar ou descartar
+
+	// Proporção quando a tela virtual L1×L2 difere da imagem
+	AspectMode string `yaml:"proporcao,omitempty"`  // esticar, faixas ou ajustar
+	BarColor   string `yaml:"cor_faixas,omitempty"` // Cor das faixas (modo faixas)
+
+	// Renderização estereoscópica (anáglifo e pares estéreo)
+	EyeSeparation float64 `yaml:"separacao_olhos,omitempty"` // Distância entre os olhos
+	Convergence   float64 `yaml:"convergencia,omitempty"`    // Distância ao plano da tela
+}
+
+// Grid descreve a grade de referência desenhada sobre o fundo.
+//
+// Com subdivisões, cada célula é dividida por linhas mais claras (a
+// subgrade), como no papel milimetrado.
+type Grid struct {
+	Spacing      float64 `yaml:"espacamento,omitempty"`  // Distância entre linhas em pixels (padrão: 50)
+	Color        string  `yaml:"cor,omitempty"`          // Cor das linhas (padrão: cinza claro)
+	Subdivisions int     `yaml:"subdivisoes,omitempty"`  // Divisões de cada célula (0 ou 1 = sem subgrade)
+	MinorColor   string  `yaml:"cor_subgrade,omitempty"` // Cor da subgrade (padrão: a da grade, mais transparente)
+}
+
+// Annotation descreve um texto escrito sobre a imagem (título ou legenda).
+//
+// No YAML pode ser apenas o texto ("titulo: Cubo") ou uma seção com o
+// texto e a sua aparência.
+type Annotation struct {
+	Text     string  `yaml:"texto,omitempty"`   // Texto (título vazio = nome da figura)
+	Position string  `yaml:"posicao,omitempty"` // topo, base, topo_esquerda, ... (padrão: título no topo, legenda na base)
+	Size     float64 `yaml:"tamanho,omitempty"` // Altura da fonte em pixels
+	Color    string  `yaml:"cor,omitempty"`     // Cor do texto (padrão: a cor das linhas)
+}
+
+// UnmarshalYAML aceita a anotação como texto simples ou como seção.
+func (a *Annotation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err == nil {
+		*a = Annotation{Text: text}
+		return nil
+	}
+
+	// Tipo auxiliar sem o método, para não chamá-lo recursivamente
+	type section Annotation
+	return unmarshal((*section)(a))
+}
+
+// PointTable descreve a tabela de pontos desenhada sobre a imagem: o
+// nome e as coordenadas X, Y e Z de cada ponto nomeado da figura.
+type PointTable struct {
+	Position string  `yaml:"posicao,omitempty"` // Canto da imagem (padrão: topo_esquerda)
+	Size     float64 `yaml:"tamanho,omitempty"` // Altura da fonte em pixels (padrão: 12)
+}
+
+// Watermark descreve a marca d'água aplicada sobre a imagem: um logotipo
+// PNG ou um texto, semitransparente, num canto.
+//
+// A imagem é relativa ao arquivo YAML; num estilo compartilhado (ver
+// Figure.Style), a mesma marca vale para todas as figuras que o usam.
+type Watermark struct {
+	Image    string   `yaml:"imagem,omitempty"`    // Arquivo da imagem (PNG ou JPEG)
+	Text     string   `yaml:"texto,omitempty"`     // Texto, no lugar da imagem
+	Position string   `yaml:"posicao,omitempty"`   // Canto da imagem (padrão: base_direita)
+	Opacity  *float64 `yaml:"opacidade,omitempty"` // De 0 a 1 (nil = padrão: 0.3)
+	Width    float64  `yaml:"largura,omitempty"`   // Largura da imagem em pixels (0 = original)
+	Size     float64  `yaml:"tamanho,omitempty"`   // Altura da fonte do texto em pixels (padrão: 16)
+	Color    string   `yaml:"cor,omitempty"`       // Cor do texto (padrão: a das linhas)
+}
+
+// Axes descreve os eixos coordenados do mundo desenhados com a figura.
+//
+// Cada eixo vai da origem até o comprimento informado no sentido
+// positivo, terminando numa seta com o seu nome. Mostram as convenções
+// do artigo: X e Y no plano horizontal e Z na vertical.
+type Axes struct {
+	Length float64 `yaml:"comprimento,omitempty"` // Comprimento em unidades da figura (padrão: além da figura)
+	ColorX string  `yaml:"cor_x,omitempty"`       // Cor do eixo X (padrão: vermelho)
+	ColorY string  `yaml:"cor_y,omitempty"`       // Cor do eixo Y (padrão: verde)
+	ColorZ string  `yaml:"cor_z,omitempty"`       // Cor do eixo Z (padrão: azul)
+	Labels *bool   `yaml:"rotulos,omitempty"`     // Nomes X, Y e Z nas pontas (padrão: true)
+}
+
+// TitleBlock descreve o carimbo de uma prancha de desenho técnico: o
+// quadro com título, autor e data no canto inferior direito.
+type TitleBlock struct {
+	Title  string `yaml:"titulo,omitempty"` // Título (padrão: o nome da figura)
+	Author string `yaml:"autor,omitempty"`  // Autor do desenho
+	Date   string `yaml:"data,omitempty"`   // Data, como texto livre
+}
+
+// Gradient descreve um fundo que passa gradualmente de uma cor a outra.
+//
+// Útil para apresentações: a figura se destaca de um fundo em degradê,
+// menos monótono que uma cor lisa.
+type Gradient struct {
+	Start     string `yaml:"inicio,omitempty"`  // Cor inicial (padrão: a cor de fundo)
+	End       string `yaml:"fim"`               // Cor final
+	Direction string `yaml:"direcao,omitempty"` // vertical (padrão), horizontal ou radial
+}
+
+// Light descreve uma luz direcional, como a do sol: os raios chegam
+// paralelos, vindos da mesma direção em toda a cena.
+//
+// Cada face recebe uma única intensidade (sombreamento plano), dada
+// pela lei de Lambert: proporcional ao cosseno do ângulo entre a normal
+// da face e a direção da luz, somada a um termo ambiente que evita que
+// as faces não iluminadas fiquem completamente pretas.
+type Light struct {
+	// Direção de onde vem a luz, em coordenadas do mundo (vetor que
+	// aponta da figura para a fonte); nula = acima, à esquerda e à frente
+	Direction Point3D `yaml:"direcao,omitempty"`
+
+	// Fração da cor recebida mesmo sem luz direta (0 a 1; nil = padrão)
+	Ambient *float64 `yaml:"ambiente,omitempty"`
 }
 
 // Camera representa os parâmetros da câmera virtual conforme o artigo.
@@ -82,6 +374,157 @@ type Camera struct {
 	// Baseadas nas dimensões do HP-85: proporção 4:3
 	Width  float64 `yaml:"largura"` // L1: largura da tela virtual
 	Height float64 `yaml:"altura"`  // L2: altura da tela virtual
+
+	// Campo de visão horizontal em graus (alternativa a L1/L2)
+	// Quando maior que zero, o renderizador deriva L1 e L2 a partir do
+	// ângulo e da proporção da tela, ignorando largura/altura
+	FOV float64 `yaml:"fov,omitempty"`
+
+	// Ponto para onde o observador olha (opcional)
+	// Sem alvo, o observador olha na direção +Y, como no artigo original
+	Target *Point3D `yaml:"alvo,omitempty"`
+
+	// Posição do observador em coordenadas esféricas em torno do alvo
+	// (opcional). Quando presente, substitui o campo observador
+	Orbit *Orbit `yaml:"orbita,omitempty"`
+
+	// Enquadramento automático: posiciona o observador para que a figura
+	// inteira caiba na tela, mantendo a direção de visão
+	Auto bool `yaml:"auto,omitempty"`
+
+	// Tipo de projeção: "conica" (padrão, a do artigo), "ortogonal" ou
+	// "obliqua". Nas projeções paralelas, largura/altura passam a medir
+	// diretamente a região visível em unidades do mundo
+	Projection string `yaml:"projecao,omitempty"`
+
+	// Parâmetros da projeção oblíqua: ângulo (graus) e fator de redução
+	// do eixo de profundidade (1 = cavaleira, 0.5 = gabinete)
+	ObliqueAngle  float64 `yaml:"angulo_obliquo,omitempty"`
+	ObliqueFactor float64 `yaml:"fator_obliquo,omitempty"`
+
+	// Vista pré-definida (ex: "isometrica", "dimetrica", "cavaleira")
+	// que configura projeção, observador e enquadramento automaticamente
+	View string `yaml:"vista,omitempty"`
+}
+
+// Tipos de projeção suportados pelo renderizador.
+const (
+	ProjectionConic        = "conica"    // Perspectiva cônica do artigo
+	ProjectionOrthographic = "ortogonal" // Projeção paralela ortogonal
+	ProjectionOblique      = "obliqua"   // Projeção paralela oblíqua
+)
+
+// IsParallel informa se a câmera usa uma projeção paralela.
+//
+// Em projeções paralelas a distância ao observador não altera o tamanho
+// aparente dos objetos, e L1/L2 medem a região visível do mundo.
+func (c Camera) IsParallel() bool {
+	return c.Projection == ProjectionOrthographic || c.Projection == ProjectionOblique
+}
+
+// Orbit descreve a posição do observador em coordenadas esféricas.
+//
+// É uma forma mais intuitiva de dizer "ver o objeto de 45° acima" do que
+// calcular coordenadas XYZ do observador à mão. Os ângulos seguem o
+// sistema de coordenadas do artigo (Z vertical, Y profundidade):
+// - Azimute 0° coloca o observador à frente do alvo (em -Y), olhando para +Y
+// - Azimute positivo gira o observador no sentido anti-horário visto de cima
+// - Elevação positiva coloca o observador acima do alvo
+type Orbit struct {
+	Azimuth   float64 `yaml:"azimute"` // Ângulo horizontal em graus
+	Elevation float64 `yaml:"elevacao"` // Ângulo vertical em graus
+	Radius    float64 `yaml:"raio"`     // Distância do observador ao alvo
+}
+
+// Position calcula a posição do observador em torno do alvo informado.
+//
+// Conversão de coordenadas esféricas para cartesianas:
+//   x = alvo.x + raio · cos(elevação) · sen(azimute)
+//   y = alvo.y - raio · cos(elevação) · cos(azimute)
+//   z = alvo.z + raio · sen(elevação)
+func (o Orbit) Position(target Point3D) Point3D {
+	az := o.Azimuth * math.Pi / 180
+	el := o.Elevation * math.Pi / 180
+
+	return Point3D{
+		X: target.X + o.Radius*math.Cos(el)*math.Sin(az),
+		Y: target.Y - o.Radius*math.Cos(el)*math.Cos(az),
+		Z: target.Z + o.Radius*math.Sin(el),
+	}
+}
+
+// Bounds calcula a caixa envolvente alinhada aos eixos da figura.
+//
+// Retorna os cantos mínimo e máximo considerando todos os pontos. Para
+// figuras sem pontos, ambos os cantos são a origem.
+func (f *Figure) Bounds() (min, max Point3D) {
+	if len(f.Pontos) == 0 {
+		return Point3D{}, Point3D{}
+	}
+
+	min = Point3D{X: f.Pontos[0].X, Y: f.Pontos[0].Y, Z: f.Pontos[0].Z}
+	max = min
+	for _, p := range f.Pontos[1:] {
+		min.X = math.Min(min.X, p.X)
+		min.Y = math.Min(min.Y, p.Y)
+		min.Z = math.Min(min.Z, p.Z)
+		max.X = math.Max(max.X, p.X)
+		max.Y = math.Max(max.Y, p.Y)
+		max.Z = math.Max(max.Z, p.Z)
+	}
+	return min, max
+}
+
+// ResolveOrbit converte a órbita (se houver) em observador e alvo explícitos.
+//
+// O alvo padrão é a origem. Depois da resolução o observador passa a olhar
+// para o alvo, e o renderizador não precisa conhecer coordenadas esféricas.
+// Chamar o método mais de uma vez produz o mesmo resultado.
+func (c *Camera) ResolveOrbit() {
+	if c.Orbit == nil {
+		return
+	}
+
+	target := Point3D{}
+	if c.Target != nil {
+		target = *c.Target
+	}
+
+	c.Observer = c.Orbit.Position(target)
+	c.Target = &target
+}
+
+// Animation descreve um voo da câmera pela cena (seção "animacao"):
+// quadros-chave com a posição da câmera em instantes definidos, que o
+// renderizador interpola para gerar os quadros intermediários.
+//
+// Projeção, campo de visão e tela virtual vêm da câmera da figura; os
+// quadros-chave definem apenas o observador, a distância R e o alvo.
+type Animation struct {
+	FPS       float64    `yaml:"quadros_por_segundo,omitempty"` // Quadros por segundo (0 = padrão do renderizador)
+	Easing    string     `yaml:"suavizacao,omitempty"`          // Curva do tempo da animação inteira ("" = linear)
+	Keyframes []Keyframe `yaml:"quadros_chave"`                 // Posições da câmera, em ordem de tempo
+}
+
+// Curvas de suavização do tempo da animação, ou de um trecho entre dois
+// quadros-chave: como a câmera acelera e desacelera entre o início e o
+// fim.
+const (
+	EasingLinear = "linear" // Velocidade constante
+	EasingIn     = "in"     // Parte do repouso e acelera
+	EasingOut    = "out"    // Desacelera até parar
+	EasingInOut  = "in-out" // Acelera no início e desacelera no fim
+	EasingCubic  = "cubic"  // Como in-out, com partida e chegada mais lentas
+	EasingHold   = "hold"   // Parada até o fim do trecho, então salta (um corte)
+)
+
+// Keyframe é a posição da câmera num instante da animação.
+type Keyframe struct {
+	Time     float64  `yaml:"tempo"`                // Instante em segundos desde o início
+	Observer Point3D  `yaml:"observador"`           // Posição do observador (ponto V)
+	Distance float64  `yaml:"distancia,omitempty"`  // Distância R do plano projetante (0 = a da câmera)
+	Target   *Point3D `yaml:"alvo,omitempty"`       // Ponto para onde olhar (nil = direção +Y)
+	Easing   string   `yaml:"suavizacao,omitempty"` // Curva do trecho até o próximo quadro-chave ("" = linear)
 }
 
 // Figure representa uma figura tridimensional completa.
@@ -89,15 +532,101 @@ type Camera struct {
 // Esta estrutura encapsula todos os elementos necessários para definir
 // e renderizar uma figura 3D conforme a metodologia do artigo:
 // 1. Pontos no espaço (vértices)
-// 2. Linhas conectando os pontos (arestas)
+// 2. Linhas conectando os pontos (arestas) e, opcionalmente, faces
 // 3. Parâmetros da câmera (observador e projeção)
 // 4. Configurações de renderização (opcionais)
+//
+// Além da câmera principal, a figura pode declarar câmeras nomeadas
+// (ex: "frente", "topo") para alternar entre pontos de vista sem
+// editar o arquivo; UseCamera troca a câmera principal por uma delas.
 type Figure struct {
-	Nome   string          `yaml:"nome"`    // Nome identificador da figura
-	Pontos []Point3D       `yaml:"pontos"`  // Lista de vértices 3D
-	Linhas []Line          `yaml:"linhas"`  // Lista de arestas (segmentos)
-	Camera Camera          `yaml:"camera"`  // Parâmetros de visualização
-	Render *RenderSettings `yaml:"render,omitempty"` // Configurações visuais opcionais
+	Nome    string            `yaml:"nome"`    // Nome identificador da figura
+	Pontos  []Point3D         `yaml:"pontos"`  // Lista de vértices 3D
+	Linhas  []Line            `yaml:"linhas"`  // Lista de arestas (segmentos)
+	Faces   []Face            `yaml:"faces,omitempty"`   // Polígonos preenchidos opcionais
+	Medidas []Dimension       `yaml:"medidas,omitempty"` // Cotas entre pontos nomeados
+	Camera  Camera            `yaml:"camera"`  // Parâmetros de visualização
+	Cameras map[string]Camera `yaml:"cameras,omitempty"` // Câmeras nomeadas opcionais
+	Render  *RenderSettings   `yaml:"render,omitempty"`  // Configurações visuais opcionais
+
+	// Arquivo de estilo compartilhado (ou nome de preset) com
+	// configurações visuais que a seção "render" pode sobrepor
+	Style string `yaml:"estilo,omitempty"`
+
+	// Script Starlark que acrescenta pontos, linhas e faces à figura
+	// quando ela é carregada, para as figuras que pedem laços e contas
+	Script string `yaml:"script,omitempty"`
+
+	// Linhas derivadas das faces no carregamento, por a figura não ter
+	// linhas próprias (ver FaceEdges): servem aos desenhos só de linhas,
+	// mas o desenho com faces não as traça, pois as faces já desenham
+	// o seu contorno, e a figura gravada não as repete
+	EdgesFromFaces bool `yaml:"-"`
+
+	// Voo da câmera pela cena (comando animate)
+	Animacao *Animation `yaml:"animacao,omitempty"`
+}
+
+// PointIndex procura um ponto da figura pelo nome.
+//
+// Parâmetros:
+//   name: nome do ponto, como declarado em "nome" no YAML
+//
+// Retorna:
+//   int: índice do primeiro ponto com esse nome
+//   bool: false se nenhum ponto tem esse nome
+func (f *Figure) PointIndex(name string) (int, bool) {
+	if name == "" {
+		return 0, false
+	}
+	for i, p := range f.Pontos {
+		if p.Nome == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// CameraNames retorna os nomes das câmeras nomeadas da figura, ordenados.
+func (f *Figure) CameraNames() []string {
+	names := make([]string, 0, len(f.Cameras))
+	for name := range f.Cameras {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseCamera substitui a câmera principal por uma das câmeras nomeadas.
+//
+// Parâmetros:
+//   name: nome da câmera, como declarado em "cameras" no YAML
+//
+// Retorna:
+//   error: erro se a figura não declara uma câmera com esse nome
+func (f *Figure) UseCamera(name string) error {
+	camera, ok := f.Cameras[name]
+	if !ok {
+		if len(f.Cameras) == 0 {
+			return fmt.Errorf("câmera desconhecida: %q (a figura não declara câmeras nomeadas)", name)
+		}
+		return fmt.Errorf("câmera desconhecida: %q (disponíveis: %s)",
+			name, strings.Join(f.CameraNames(), ", "))
+	}
+
+	// O alvo é um ponteiro: copia para que alterações na câmera
+	// principal não afetem a câmera nomeada
+	if camera.Target != nil {
+		target := *camera.Target
+		camera.Target = &target
+	}
+	if camera.Orbit != nil {
+		orbit := *camera.Orbit
+		camera.Orbit = &orbit
+	}
+
+	f.Camera = camera
+	return nil
 }
 
 // DefaultCamera retorna uma câmera com configuração padrão baseada no artigo.
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -43,6 +45,42 @@ func TestLine(t *testing.T) {
 	}
 }
 
+func TestLineCurve(t *testing.T) {
+	points := []Point3D{{X: 0, Y: 0, Z: 0, Nome: "A"}, {X: 4, Y: 0, Z: 0, Nome: "B"}}
+
+	// Reta: apenas as pontas
+	straight := Line{P1: 0, P2: 1}.Curve(points)
+	if len(straight) != 2 || straight[0] != points[0] || straight[1] != points[1] {
+		t.Errorf("Expected the two end points, got %v", straight)
+	}
+
+	tests := []struct {
+		name    string
+		control []Point3D
+		middle  Point3D
+	}{
+		// Quadrática: B(½) = ¼·P1 + ½·C + ¼·P2
+		{"quadratic", []Point3D{{X: 2, Z: 4}}, Point3D{X: 2, Z: 2}},
+		// Cúbica: B(½) = ⅛·P1 + ⅜·C1 + ⅜·C2 + ⅛·P2
+		{"cubic", []Point3D{{X: 0, Z: 4}, {X: 4, Z: 4}}, Point3D{X: 2, Z: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curve := Line{P1: 0, P2: 1, Controle: tt.control}.Curve(points)
+			if len(curve) != CurveSegments+1 {
+				t.Fatalf("Expected %d points, got %d", CurveSegments+1, len(curve))
+			}
+			if curve[0] != points[0] || curve[CurveSegments] != points[1] {
+				t.Errorf("Expected the curve to start and end at the figure's points, got %v and %v",
+					curve[0], curve[CurveSegments])
+			}
+			if got := curve[CurveSegments/2]; !pointNear(got, tt.middle) {
+				t.Errorf("Expected middle point %v, got %v", tt.middle, got)
+			}
+		})
+	}
+}
+
 func TestDefaultCamera(t *testing.T) {
 	camera := DefaultCamera()
 
@@ -130,4 +168,138 @@ func TestRenderSettings(t *testing.T) {
 	if settings.ShowLabels == nil || *settings.ShowLabels {
 		t.Error("Expected ShowLabels=false")
 	}
-}
\ No newline at end of file
+}
+
+func TestOrbitPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		orbit    Orbit
+		expected Point3D
+	}{
+		{
+			name:     "front of target",
+			orbit:    Orbit{Azimuth: 0, Elevation: 0, Radius: 10},
+			expected: Point3D{X: 0, Y: -10, Z: 0},
+		},
+		{
+			name:     "right side",
+			orbit:    Orbit{Azimuth: 90, Elevation: 0, Radius: 10},
+			expected: Point3D{X: 10, Y: 0, Z: 0},
+		},
+		{
+			name:     "directly above",
+			orbit:    Orbit{Azimuth: 0, Elevation: 90, Radius: 10},
+			expected: Point3D{X: 0, Y: 0, Z: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.orbit.Position(Point3D{})
+			if math.Abs(p.X-tt.expected.X) > 1e-9 || math.Abs(p.Y-tt.expected.Y) > 1e-9 ||
+				math.Abs(p.Z-tt.expected.Z) > 1e-9 {
+				t.Errorf("Expected (%f,%f,%f), got (%f,%f,%f)",
+					tt.expected.X, tt.expected.Y, tt.expected.Z, p.X, p.Y, p.Z)
+			}
+		})
+	}
+}
+
+func TestCameraResolveOrbit(t *testing.T) {
+	camera := DefaultCamera()
+	camera.Target = &Point3D{X: 0, Y: 5, Z: 0}
+	camera.Orbit = &Orbit{Azimuth: 0, Elevation: 0, Radius: 5}
+
+	camera.ResolveOrbit()
+
+	if camera.Observer.X != 0 || math.Abs(camera.Observer.Y) > 1e-9 || camera.Observer.Z != 0 {
+		t.Errorf("Expected observer at origin, got (%f,%f,%f)",
+			camera.Observer.X, camera.Observer.Y, camera.Observer.Z)
+	}
+
+	// Sem alvo explícito, a órbita é em torno da origem
+	camera = DefaultCamera()
+	camera.Orbit = &Orbit{Azimuth: 0, Elevation: 0, Radius: 3}
+	camera.ResolveOrbit()
+
+	if camera.Target == nil || *camera.Target != (Point3D{}) {
+		t.Errorf("Expected target at origin, got %+v", camera.Target)
+	}
+}
+
+func TestFigureBounds(t *testing.T) {
+	figure := Figure{
+		Pontos: []Point3D{
+			{X: 1, Y: -2, Z: 3},
+			{X: -4, Y: 5, Z: 0},
+			{X: 2, Y: 0, Z: -6},
+		},
+	}
+
+	min, max := figure.Bounds()
+	if min.X != -4 || min.Y != -2 || min.Z != -6 {
+		t.Errorf("Expected min (-4,-2,-6), got (%f,%f,%f)", min.X, min.Y, min.Z)
+	}
+	if max.X != 2 || max.Y != 5 || max.Z != 3 {
+		t.Errorf("Expected max (2,5,3), got (%f,%f,%f)", max.X, max.Y, max.Z)
+	}
+
+	empty := Figure{}
+	min, max = empty.Bounds()
+	if min != (Point3D{}) || max != (Point3D{}) {
+		t.Error("Expected origin bounds for empty figure")
+	}
+}
+
+func TestFigurePointIndex(t *testing.T) {
+	figure := Figure{
+		Pontos: []Point3D{{Nome: "A"}, {}, {Nome: "B"}, {Nome: "B"}},
+	}
+
+	if i, ok := figure.PointIndex("B"); !ok || i != 2 {
+		t.Errorf("Expected first point named B at 2, got %d (%v)", i, ok)
+	}
+	if _, ok := figure.PointIndex("C"); ok {
+		t.Error("Expected unknown name not to be found")
+	}
+	if _, ok := figure.PointIndex(""); ok {
+		t.Error("Expected empty name not to match unnamed points")
+	}
+}
+
+func TestFigureUseCamera(t *testing.T) {
+	target := Point3D{X: 1, Y: 2, Z: 3}
+	figure := Figure{
+		Camera: DefaultCamera(),
+		Cameras: map[string]Camera{
+			"topo":   {Observer: Point3D{Z: 10}, Distance: 5, Target: &target},
+			"frente": {Observer: Point3D{Y: -10}, Distance: 7},
+		},
+	}
+
+	names := figure.CameraNames()
+	if len(names) != 2 || names[0] != "frente" || names[1] != "topo" {
+		t.Errorf("Expected sorted names [frente topo], got %v", names)
+	}
+
+	if err := figure.UseCamera("topo"); err != nil {
+		t.Fatalf("UseCamera failed: %v", err)
+	}
+	if figure.Camera.Distance != 5 || figure.Camera.Observer.Z != 10 {
+		t.Errorf("Expected camera topo to be selected, got %+v", figure.Camera)
+	}
+
+	// Alterar a câmera principal não deve afetar a câmera nomeada
+	figure.Camera.Target.X = 99
+	if figure.Cameras["topo"].Target.X != 1 {
+		t.Error("Expected named camera target to be copied")
+	}
+
+	err := figure.UseCamera("lado")
+	if err == nil {
+		t.Fatal("Expected error for unknown camera, got nil")
+	}
+	if !strings.Contains(err.Error(), "frente, topo") {
+		t.Errorf("Expected error to list available cameras, got %q", err)
+	}
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// viewPreset descreve uma vista axonométrica ou oblíqua clássica.
+//
+// As vistas complementam a perspectiva cônica do artigo com as projeções
+// paralelas usadas em desenho técnico. Todas ligam o enquadramento
+// automático, já que a escala de uma projeção paralela depende do
+// tamanho da figura.
+type viewPreset struct {
+	projection    string  // Tipo de projeção
+	azimuth       float64 // Azimute do observador (graus)
+	elevation     float64 // Elevação do observador (graus)
+	obliqueAngle  float64 // Ângulo do eixo de profundidade (oblíquas)
+	obliqueFactor float64 // Redução do eixo de profundidade (oblíquas)
+}
+
+// viewPresets contém as vistas pré-definidas, indexadas pelo nome canônico.
+var viewPresets = map[string]viewPreset{
+	// Isométrica: os três eixos igualmente reduzidos (elevação de
+	// atan(1/√2) ≈ 35,264° com azimute de 45°)
+	"isometrica": {
+		projection: ProjectionOrthographic,
+		azimuth:    45,
+		elevation:  math.Atan(1/math.Sqrt2) * 180 / math.Pi,
+	},
+
+	// Dimétrica 2:1: arestas horizontais com inclinação de 1:2 na tela,
+	// a convenção popularizada pelos jogos de computador dos anos 80
+	"dimetrica": {
+		projection: ProjectionOrthographic,
+		azimuth:    45,
+		elevation:  30,
+	},
+
+	// Cavaleira: face frontal em verdadeira grandeza e profundidade
+	// desenhada a 45° sem redução
+	"cavaleira": {
+		projection:    ProjectionOblique,
+		obliqueAngle:  45,
+		obliqueFactor: 1,
+	},
+
+	// Gabinete: como a cavaleira, mas com profundidade reduzida à metade,
+	// o que parece mais natural ao olho
+	"gabinete": {
+		projection:    ProjectionOblique,
+		obliqueAngle:  45,
+		obliqueFactor: 0.5,
+	},
+
+	// Perspectiva: a projeção cônica do artigo com enquadramento automático
+	"perspectiva": {
+		projection: ProjectionConic,
+		azimuth:    30,
+		elevation:  20,
+	},
+}
+
+// viewAliases mapeia nomes alternativos (abreviados ou em inglês) para
+// os nomes canônicos das vistas.
+var viewAliases = map[string]string{
+	"iso":         "isometrica",
+	"isometric":   "isometrica",
+	"isométrica":  "isometrica",
+	"dimetric":    "dimetrica",
+	"dimétrica":   "dimetrica",
+	"cavalier":    "cavaleira",
+	"cabinet":     "gabinete",
+	"perspective": "perspectiva",
+	"conica":      "perspectiva",
+	"cônica":      "perspectiva",
+}
+
+// ViewNames retorna os nomes canônicos das vistas pré-definidas, ordenados.
+func ViewNames() []string {
+	names := make([]string, 0, len(viewPresets))
+	for name := range viewPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyView configura a câmera com uma vista pré-definida.
+//
+// Define o tipo de projeção e a direção de visão (via órbita em torno do
+// alvo, que é a origem se não houver alvo) e liga o enquadramento
+// automático. Distância R e dimensões L1/L2 servem apenas de ponto de
+// partida, já que o enquadramento recalcula a escala.
+//
+// Parâmetros:
+//   name: nome da vista (ex: "iso", "isometrica", "dimetrica", "cavaleira")
+//
+// Retorna:
+//   error: erro se o nome não corresponde a nenhuma vista conhecida
+func (c *Camera) ApplyView(name string) error {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := viewAliases[key]; ok {
+		key = alias
+	}
+
+	preset, ok := viewPresets[key]
+	if !ok {
+		return fmt.Errorf("vista desconhecida: %q (disponíveis: %s)",
+			name, strings.Join(ViewNames(), ", "))
+	}
+
+	c.View = key
+	c.Projection = preset.projection
+	c.ObliqueAngle = preset.obliqueAngle
+	c.ObliqueFactor = preset.obliqueFactor
+	c.Orbit = &Orbit{
+		Azimuth:   preset.azimuth,
+		Elevation: preset.elevation,
+		Radius:    1, // Recalculado pelo enquadramento automático
+	}
+	c.Auto = true
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApplyView(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		canonical  string
+		projection string
+		elevation  float64
+	}{
+		{"isometric short", "iso", "isometrica", ProjectionOrthographic, 35.264},
+		{"isometric english", "Isometric", "isometrica", ProjectionOrthographic, 35.264},
+		{"dimetric", "dimetrica", "dimetrica", ProjectionOrthographic, 30},
+		{"cavalier", "cavalier", "cavaleira", ProjectionOblique, 0},
+		{"cabinet", "gabinete", "gabinete", ProjectionOblique, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			camera := DefaultCamera()
+			if err := camera.ApplyView(tt.input); err != nil {
+				t.Fatalf("ApplyView(%q) failed: %v", tt.input, err)
+			}
+
+			if camera.View != tt.canonical {
+				t.Errorf("Expected view=%q, got %q", tt.canonical, camera.View)
+			}
+			if camera.Projection != tt.projection {
+				t.Errorf("Expected projection=%q, got %q", tt.projection, camera.Projection)
+			}
+			if camera.Orbit == nil || math.Abs(camera.Orbit.Elevation-tt.elevation) > 0.001 {
+				t.Errorf("Expected elevation=%f, got %+v", tt.elevation, camera.Orbit)
+			}
+			if !camera.Auto {
+				t.Error("Expected view presets to enable auto-fit")
+			}
+		})
+	}
+}
+
+func TestApplyView_Unknown(t *testing.T) {
+	camera := DefaultCamera()
+	if err := camera.ApplyView("trimetrica-inexistente"); err == nil {
+		t.Error("Expected error for unknown view, got nil")
+	}
+}
+
+func TestViewNames(t *testing.T) {
+	names := ViewNames()
+	if len(names) != len(viewPresets) {
+		t.Errorf("Expected %d view names, got %d", len(viewPresets), len(names))
+	}
+
+	// Todos os aliases devem apontar para vistas existentes
+	for alias, name := range viewAliases {
+		if _, ok := viewPresets[name]; !ok {
+			t.Errorf("Alias %q points to unknown view %q", alias, name)
+		}
+	}
+}
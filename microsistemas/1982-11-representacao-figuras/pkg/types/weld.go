@@ -0,0 +1,151 @@
+package types
+
+import (
+	"fmt"
+	"math"
+)
+
+// Weld funde os pontos que estão a menos de epsilon um do outro,
+// modificando a figura no lugar.
+//
+// Modelos convertidos de outros formatos costumam repetir cada vértice
+// uma vez para cada face que o usa: o arquivo incha, as arestas comuns
+// são desenhadas duas vezes e a renderização fica mais lenta. Os
+// pontos são percorridos em ordem, e cada um se junta a um ponto já
+// mantido que esteja a até epsilon dele (com epsilon zero, só aos
+// idênticos); as linhas e faces passam a apontar para o que ficou.
+// Depois da fusão, as linhas que viram um ponto somem, as linhas retas
+// repetidas ficam uma só, os vértices repetidos em seguida numa face
+// ficam um só, e as faces com menos de três vértices somem. As linhas
+// derivadas das faces (ver EdgesFromFaces) são derivadas de novo das
+// faces que ficaram.
+//
+// Dois pontos com nomes diferentes nunca se fundem, para que as cotas e
+// os nomes continuem valendo; um ponto sem nome que se funde a um com
+// nome passa a ser ele, e o ponto que fica herda o nome do que se junta
+// a ele, se não tiver um.
+//
+// Parâmetros:
+//   epsilon: distância máxima entre pontos fundidos (zero ou positiva)
+//
+// Retorna:
+//   int: número de pontos removidos
+//   error: erro se epsilon for negativo ou inválido
+func (f *Figure) Weld(epsilon float64) (int, error) {
+	if !(epsilon >= 0) || math.IsInf(epsilon, 0) {
+		return 0, fmt.Errorf("distância inválida: %g (deve ser zero ou positiva)", epsilon)
+	}
+
+	// Os pontos já mantidos ficam num mapa de células de lado epsilon;
+	// os vizinhos de um ponto só podem estar na sua célula ou nas 26 em
+	// volta dela. Com epsilon zero, a célula é o próprio ponto
+	search := 1.0
+	cell := func(p Point3D) [3]float64 {
+		return [3]float64{math.Floor(p.X / epsilon), math.Floor(p.Y / epsilon), math.Floor(p.Z / epsilon)}
+	}
+	if epsilon == 0 {
+		search = 0
+		cell = func(p Point3D) [3]float64 { return [3]float64{p.X, p.Y, p.Z} }
+	}
+	cells := make(map[[3]float64][]int)
+	kept := make([]Point3D, 0, len(f.Pontos))
+	index := make([]int, len(f.Pontos)) // Ponto mantido de cada ponto original
+
+	for i, p := range f.Pontos {
+		c := cell(p)
+		target := -1
+	neighbours:
+		for dx := -search; dx <= search; dx++ {
+			for dy := -search; dy <= search; dy++ {
+				for dz := -search; dz <= search; dz++ {
+					for _, k := range cells[[3]float64{c[0] + dx, c[1] + dy, c[2] + dz}] {
+						q := kept[k]
+						if p.Nome != "" && q.Nome != "" && p.Nome != q.Nome {
+							continue
+						}
+						if (p.X-q.X)*(p.X-q.X)+(p.Y-q.Y)*(p.Y-q.Y)+(p.Z-q.Z)*(p.Z-q.Z) <= epsilon*epsilon {
+							target = k
+							break neighbours
+						}
+					}
+				}
+			}
+		}
+		if target < 0 {
+			target = len(kept)
+			kept = append(kept, p)
+			cells[c] = append(cells[c], target)
+		} else if kept[target].Nome == "" {
+			kept[target].Nome = p.Nome
+		}
+		index[i] = target
+	}
+	removed := len(f.Pontos) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	f.Pontos = kept
+
+	// Linhas: os índices novos, sem as que viraram um ponto e sem as
+	// retas repetidas (as direcionadas só repetem no mesmo sentido)
+	type lineKey struct {
+		a, b     int
+		directed bool
+	}
+	drawn := make(map[lineKey]bool)
+	linhas := make([]Line, 0, len(f.Linhas))
+	for _, linha := range f.Linhas {
+		if linha.P1 < 0 || linha.P2 < 0 || linha.P1 >= len(index) || linha.P2 >= len(index) {
+			linhas = append(linhas, linha) // A validação aponta o erro
+			continue
+		}
+		linha.P1, linha.P2 = index[linha.P1], index[linha.P2]
+		if len(linha.Controle) == 0 {
+			if linha.P1 == linha.P2 {
+				continue
+			}
+			key := lineKey{min(linha.P1, linha.P2), max(linha.P1, linha.P2), false}
+			if linha.Direcionada {
+				key = lineKey{linha.P1, linha.P2, true}
+			}
+			if drawn[key] {
+				continue
+			}
+			drawn[key] = true
+		}
+		linhas = append(linhas, linha)
+	}
+	f.Linhas = linhas
+
+	faces := make([]Face, 0, len(f.Faces))
+	for _, face := range f.Faces {
+		points := make([]int, 0, len(face.Pontos))
+		valid := true
+		for _, p := range face.Pontos {
+			if p < 0 || p >= len(index) {
+				valid = false
+				break
+			}
+			if q := index[p]; len(points) == 0 || points[len(points)-1] != q {
+				points = append(points, q)
+			}
+		}
+		if !valid {
+			faces = append(faces, face) // A validação aponta o erro
+			continue
+		}
+		for len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+		if len(points) < 3 {
+			continue
+		}
+		face.Pontos = points
+		faces = append(faces, face)
+	}
+	f.Faces = faces
+	if f.EdgesFromFaces {
+		f.Linhas = f.FaceEdges()
+	}
+	return removed, nil
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFigure_Weld(t *testing.T) {
+	// Dois triângulos que repetem os vértices da aresta comum, como nos
+	// modelos convertidos, mais uma linha por cima dessa aresta
+	f := Figure{
+		Pontos: []Point3D{
+			{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1, Nome: "C"},
+			{X: 1, Y: 0}, {X: 1e-7, Y: 1}, {X: 1, Y: 1},
+		},
+		Linhas: []Line{
+			{P1: 1, P2: 2}, {P1: 3, P2: 4}, // A mesma aresta depois da fusão
+			{P1: 1, P2: 3}, // Vira um ponto
+			{P1: 4, P2: 1, Direcionada: true},
+		},
+		Faces: []Face{
+			{Pontos: []int{0, 1, 2}},
+			{Pontos: []int{3, 5, 4}},
+			{Pontos: []int{1, 3, 4}}, // Degenerada depois da fusão
+		},
+		Medidas: []Dimension{{De: "C", Ate: "C"}},
+	}
+
+	removed, err := f.Weld(1e-6)
+	if err != nil {
+		t.Fatalf("Weld failed: %v", err)
+	}
+	if removed != 2 || len(f.Pontos) != 4 {
+		t.Fatalf("Expected 2 points removed (4 left), got %d (%+v)", removed, f.Pontos)
+	}
+	if f.Pontos[2].Nome != "C" || f.Pontos[3] != (Point3D{X: 1, Y: 1}) {
+		t.Errorf("Expected points kept in order, got %+v", f.Pontos)
+	}
+
+	want := []Line{{P1: 1, P2: 2}, {P1: 2, P2: 1, Direcionada: true}}
+	if len(f.Linhas) != len(want) {
+		t.Fatalf("Expected %d lines, got %+v", len(want), f.Linhas)
+	}
+	for i := range want {
+		if got := f.Linhas[i]; got.P1 != want[i].P1 || got.P2 != want[i].P2 || got.Direcionada != want[i].Direcionada {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+	if len(f.Faces) != 2 || f.Faces[1].Pontos[0] != 1 || f.Faces[1].Pontos[1] != 3 || f.Faces[1].Pontos[2] != 2 {
+		t.Errorf("Expected the two triangles sharing points 1 and 2, got %+v", f.Faces)
+	}
+}
+
+func TestFigure_WeldNames(t *testing.T) {
+	f := Figure{
+		Pontos: []Point3D{{Nome: "A"}, {Nome: "B"}, {}, {X: 5}, {X: 5, Nome: "D"}},
+		Linhas: []Line{{P1: 0, P2: 1}, {P1: 2, P2: 3}, {P1: 1, P2: 4}},
+	}
+	removed, err := f.Weld(0)
+	if err != nil {
+		t.Fatalf("Weld failed: %v", err)
+	}
+
+	// A e B não se fundem; o ponto sem nome se junta a A, e o ponto que
+	// ficou em (5, 0, 0) herda o nome D
+	if removed != 2 || len(f.Pontos) != 3 {
+		t.Fatalf("Expected 2 points removed, got %d (%+v)", removed, f.Pontos)
+	}
+	if f.Pontos[0].Nome != "A" || f.Pontos[1].Nome != "B" || f.Pontos[2].Nome != "D" {
+		t.Errorf("Expected names A, B and D, got %+v", f.Pontos)
+	}
+	if i, ok := f.PointIndex("D"); !ok || i != 2 {
+		t.Errorf("Expected D at index 2, got %d (%v)", i, ok)
+	}
+
+	// Com epsilon zero, só se fundem os pontos idênticos (e 0 com -0)
+	g := Figure{Pontos: []Point3D{{X: 1}, {X: 1 + 1e-12}, {X: math.Copysign(0, -1)}, {}}}
+	if removed, _ := g.Weld(0); removed != 1 {
+		t.Errorf("Expected only the signed zero merged, got %d removed", removed)
+	}
+
+	for _, epsilon := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := f.Weld(epsilon); err == nil {
+			t.Errorf("Expected error for epsilon %v, got nil", epsilon)
+		}
+	}
+}